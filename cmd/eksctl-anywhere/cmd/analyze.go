@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var analyzeCmd = &cobra.Command{
+	Use:   "analyze",
+	Short: "Analyze resources",
+	Long:  "Use eksctl anywhere analyze to run the EKS-A analyzers against collected data",
+}
+
+func init() {
+	rootCmd.AddCommand(analyzeCmd)
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+)
+
+var analyzeSupportBundleCmd = &cobra.Command{
+	Use:   "support-bundle <archive>",
+	Short: "Analyze a support bundle archive",
+	Long: "Run the EKS-A analyzers against an existing support bundle archive without accessing the cluster. " +
+		"The analyzers are rebuilt from the cluster config stored in the archive by generate support-bundle, " +
+		"or default to the ones used without a cluster config when the archive doesn't contain it",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	Args:         cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := analyzeSupportBundle(cmd.Context(), args[0]); err != nil {
+			return fmt.Errorf("failed to analyze support bundle: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	analyzeCmd.AddCommand(analyzeSupportBundleCmd)
+}
+
+func analyzeSupportBundle(ctx context.Context, archive string) error {
+	archivePath, err := filepath.Abs(archive)
+	if err != nil {
+		return err
+	}
+
+	deps, err := dependencies.NewFactory().
+		WithExecutableMountDirs(filepath.Dir(archivePath)).
+		WithWriterFolder(strings.TrimSuffix(filepath.Base(archivePath), ".tar.gz") + "-analysis").
+		WithDiagnosticBundleFactory().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	supportBundle, err := deps.DignosticCollectorFactory.DiagnosticBundleFromArchive(archivePath)
+	if err != nil {
+		return err
+	}
+
+	if err = supportBundle.Analyze(ctx, archivePath); err != nil {
+		return err
+	}
+
+	return supportBundle.PrintAnalysis()
+}
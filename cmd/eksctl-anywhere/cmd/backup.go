@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const defaultSSHKeyFileName = "eks-a-id_rsa"
+
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Backup resources",
+	Long:  "Use eksctl anywhere backup to save the state of a cluster",
+}
+
+func init() {
+	rootCmd.AddCommand(backupCmd)
+}
+
+// etcdBackupOptions are the flags shared by the etcd backup and restore commands.
+type etcdBackupOptions struct {
+	clusterName string
+	namespace   string
+	// kubeConfig is an optional kubeconfig file of the management cluster.
+	kubeConfig  string
+	sshKey      string
+	sshUsername string
+	// sshKnownHosts is the known_hosts file used to verify the etcd machines host keys.
+	sshKnownHosts           string
+	sshInsecureSkipHostKeys bool
+	snapshot                string
+	dir                     string
+	s3                      etcdbackup.S3Config
+}
+
+func applyEtcdBackupFlags(cmd *cobra.Command, opts *etcdBackupOptions) {
+	flagSet := cmd.Flags()
+	flagSet.StringVar(&opts.clusterName, "cluster-name", "", "Name of the cluster")
+	flagSet.StringVarP(&opts.namespace, "namespace", "n", "default", "Namespace of the EKS-A cluster")
+	flagSet.StringVar(&opts.kubeConfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	flagSet.StringVar(&opts.sshKey, "ssh-key", "", fmt.Sprintf("Private key to ssh into the etcd machines (default \"<cluster-name>/%s\")", defaultSSHKeyFileName))
+	flagSet.StringVar(&opts.sshUsername, "ssh-username", "", "User to ssh into the etcd machines (default: first user in the machine config)")
+	flagSet.StringVar(&opts.sshKnownHosts, "ssh-known-hosts", "", "Known hosts file to verify the host keys of the etcd machines (default \"~/.ssh/known_hosts\")")
+	flagSet.BoolVar(&opts.sshInsecureSkipHostKeys, "ssh-insecure-skip-host-key-verification", false, "Don't verify the host keys of the etcd machines. Only use it in networks where the machine addresses can't be spoofed")
+	applyEtcdStorageFlags(flagSet, opts)
+
+	if err := cmd.MarkFlagRequired("cluster-name"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func applyEtcdStorageFlags(flagSet *pflag.FlagSet, opts *etcdBackupOptions) {
+	flagSet.StringVar(&opts.dir, "dir", "etcd-backups", "Local directory for the snapshots, ignored if --s3-bucket is set")
+	flagSet.StringVar(&opts.s3.Bucket, "s3-bucket", "", "S3 bucket for the snapshots")
+	flagSet.StringVar(&opts.s3.Prefix, "s3-prefix", "", "Prefix for the snapshot keys in the S3 bucket")
+	flagSet.StringVar(&opts.s3.Region, "s3-region", "", "Region of the S3 bucket")
+	flagSet.StringVar(&opts.s3.Endpoint, "s3-endpoint", "", "Endpoint of an S3-compatible object storage, like MinIO")
+}
+
+func (o *etcdBackupOptions) store() (etcdbackup.Store, error) {
+	if o.s3.Bucket != "" {
+		return etcdbackup.NewS3Store(o.s3)
+	}
+	return etcdbackup.NewLocalStore(o.dir), nil
+}
+
+func (o *etcdBackupOptions) backupOptions(kubeConfig string) etcdbackup.Options {
+	return etcdbackup.Options{
+		ManagementCluster: &types.Cluster{KubeconfigFile: kubeConfig},
+		ClusterName:       o.clusterName,
+		Namespace:         o.namespace,
+		SSHUsername:       o.sshUsername,
+	}
+}
+
+func (o *etcdBackupOptions) sshClient(sshKey string) (*etcdbackup.SSHClient, error) {
+	if o.sshInsecureSkipHostKeys {
+		logger.MarkWarning("Skipping host key verification for the etcd machines")
+		return etcdbackup.NewInsecureSSHClient(sshKey)
+	}
+
+	knownHosts := o.sshKnownHosts
+	if knownHosts == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("getting home directory for the default known hosts file: %v", err)
+		}
+		knownHosts = filepath.Join(home, ".ssh", "known_hosts")
+	}
+
+	client, err := etcdbackup.NewSSHClient(sshKey, knownHosts)
+	if err != nil {
+		return nil, fmt.Errorf("%v, add the etcd machines host keys to the known hosts file or set --ssh-insecure-skip-host-key-verification", err)
+	}
+
+	return client, nil
+}
+
+// newEtcdBackupClient builds an etcdbackup.Client from the command flags. The returned
+// dependencies need to be closed by the caller.
+func newEtcdBackupClient(cmd *cobra.Command, opts *etcdBackupOptions) (*etcdbackup.Client, string, *dependencies.Dependencies, error) {
+	kubeConfig, err := kubeconfig.ResolveAndValidateFilename(opts.kubeConfig, "")
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	sshKey := opts.sshKey
+	if sshKey == "" {
+		sshKey = filepath.Join(opts.clusterName, defaultSSHKeyFileName)
+	}
+	remote, err := opts.sshClient(sshKey)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	store, err := opts.store()
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	deps, err := dependencies.NewFactory().
+		WithExecutableMountDirs(kubeConfig).
+		WithKubectl().
+		Build(cmd.Context())
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("unable to initialize executables: %v", err)
+	}
+
+	return etcdbackup.NewClient(deps.Kubectl, remote, store), kubeConfig, deps, nil
+}
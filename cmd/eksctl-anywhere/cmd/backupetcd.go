@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+var beo = &etcdBackupOptions{}
+
+var backupEtcdCmd = &cobra.Command{
+	Use:          "etcd",
+	Short:        "Take a snapshot of the etcd database of a cluster",
+	Long:         "Take a snapshot of the etcd database from one of the control plane or external etcd machines of a cluster and save it to a local directory or an S3 bucket",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, kubeConfig, deps, err := newEtcdBackupClient(cmd, beo)
+		if err != nil {
+			return err
+		}
+		defer close(cmd.Context(), deps)
+
+		name := beo.snapshot
+		if name == "" {
+			name = client.SnapshotName(beo.clusterName)
+		}
+
+		metadata, err := client.Backup(cmd.Context(), beo.backupOptions(kubeConfig), name)
+		if err != nil {
+			return err
+		}
+
+		logger.MarkSuccess("Etcd snapshot saved", "name", metadata.Name, "kubernetesVersion", metadata.KubernetesVersion, "bundles", metadata.BundlesName)
+		return nil
+	},
+}
+
+func init() {
+	backupCmd.AddCommand(backupEtcdCmd)
+	applyEtcdBackupFlags(backupEtcdCmd, beo)
+	backupEtcdCmd.Flags().StringVar(&beo.snapshot, "name", "", "Name of the snapshot (default \"<cluster-name>-etcd-<timestamp>\")")
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/managementbackup"
+	"github.com/aws/eks-anywhere/pkg/tar"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type managementBackupOptions struct {
+	clusterName string
+	namespace   string
+	// kubeConfig is an optional kubeconfig file of the management cluster.
+	kubeConfig string
+	archive    string
+}
+
+var bmo = &managementBackupOptions{}
+
+var backupManagementClusterCmd = &cobra.Command{
+	Use:   "management-cluster",
+	Short: "Backup the state of a management cluster",
+	Long: "Save the EKS-A objects of a management cluster and its workload clusters, together with their bundles, " +
+		"EKS-D releases and CAPI objects, to an archive that can be restored into a new management cluster",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, mgmt, deps, err := newManagementBackupClient(cmd, bmo)
+		if err != nil {
+			return err
+		}
+		defer close(cmd.Context(), deps)
+
+		archive := bmo.archive
+		if archive == "" {
+			archive = fmt.Sprintf("%s-management-backup.tar.gz", bmo.clusterName)
+		}
+
+		metadata, err := client.Backup(cmd.Context(), mgmt, bmo.clusterName, bmo.namespace, archive)
+		if err != nil {
+			return err
+		}
+
+		logger.MarkSuccess("Management cluster backup saved", "archive", archive, "workloadClusters", metadata.WorkloadClusters)
+		return nil
+	},
+}
+
+func init() {
+	backupCmd.AddCommand(backupManagementClusterCmd)
+	flagSet := backupManagementClusterCmd.Flags()
+	flagSet.StringVar(&bmo.clusterName, "cluster-name", "", "Name of the management cluster")
+	flagSet.StringVarP(&bmo.namespace, "namespace", "n", "default", "Namespace of the EKS-A clusters")
+	flagSet.StringVar(&bmo.kubeConfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	flagSet.StringVar(&bmo.archive, "archive", "", "File to save the backup to (default \"<cluster-name>-management-backup.tar.gz\")")
+
+	if err := backupManagementClusterCmd.MarkFlagRequired("cluster-name"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+// newManagementBackupClient builds a managementbackup.Client from the command flags. The returned
+// dependencies need to be closed by the caller.
+func newManagementBackupClient(cmd *cobra.Command, opts *managementBackupOptions) (*managementbackup.Client, *types.Cluster, *dependencies.Dependencies, error) {
+	kubeConfig, err := kubeconfig.ResolveAndValidateFilename(opts.kubeConfig, "")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	deps, err := dependencies.NewFactory().
+		WithExecutableMountDirs(kubeConfig).
+		WithKubectl().
+		WithClusterctl().
+		Build(cmd.Context())
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("unable to initialize executables: %v", err)
+	}
+
+	mgmt := &types.Cluster{Name: opts.clusterName, KubeconfigFile: kubeConfig}
+	return managementbackup.NewClient(deps.Kubectl, deps.Clusterctl, tar.NewGzipPackager()), mgmt, deps, nil
+}
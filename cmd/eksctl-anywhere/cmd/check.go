@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/certificates"
+	"github.com/aws/eks-anywhere/pkg/crypto"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check resources",
+	Long:  "Use eksctl anywhere check to inspect the health of a cluster",
+}
+
+func init() {
+	rootCmd.AddCommand(checkCmd)
+}
+
+// certificatesOptions are the flags shared by the certificates check and rotate commands.
+type certificatesOptions struct {
+	clusterName string
+	namespace   string
+	// kubeConfig is an optional kubeconfig file of the management cluster.
+	kubeConfig string
+}
+
+func applyCertificatesFlags(cmd *cobra.Command, opts *certificatesOptions) {
+	flagSet := cmd.Flags()
+	flagSet.StringVar(&opts.clusterName, "cluster-name", "", "Name of the cluster")
+	flagSet.StringVarP(&opts.namespace, "namespace", "n", "default", "Namespace of the EKS-A cluster")
+	flagSet.StringVar(&opts.kubeConfig, "kubeconfig", "", "Management cluster kubeconfig file")
+
+	if err := cmd.MarkFlagRequired("cluster-name"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+// newCertificatesClient builds a certificates.Client from the command flags. The returned
+// dependencies need to be closed by the caller.
+func newCertificatesClient(cmd *cobra.Command, opts *certificatesOptions) (*certificates.Client, *types.Cluster, *dependencies.Dependencies, error) {
+	kubeConfig, err := kubeconfig.ResolveAndValidateFilename(opts.kubeConfig, "")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	deps, err := dependencies.NewFactory().
+		WithExecutableMountDirs(kubeConfig).
+		WithKubectl().
+		Build(cmd.Context())
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("unable to initialize executables: %v", err)
+	}
+
+	return certificates.NewClient(deps.Kubectl, certificates.NewTLSProber(), crypto.NewCertificateGenerator()), &types.Cluster{KubeconfigFile: kubeConfig}, deps, nil
+}
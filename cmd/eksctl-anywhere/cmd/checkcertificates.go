@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/certificates"
+)
+
+type checkCertificatesOptions struct {
+	certificatesOptions
+	output       string
+	expiryWindow time.Duration
+}
+
+var cco = &checkCertificatesOptions{}
+
+var checkCertificatesCmd = &cobra.Command{
+	Use:   "certificates",
+	Short: "Check the expiry of the cluster certificates",
+	Long: "Report the expiry date of the apiserver, etcd and kubelet certificates served by every machine of a cluster " +
+		"and of its AWS IAM Authenticator CA. It fails if any certificate expires within the expiry window",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := certificates.ValidateOutput(cco.output); err != nil {
+			return err
+		}
+
+		client, mgmt, deps, err := newCertificatesClient(cmd, &cco.certificatesOptions)
+		if err != nil {
+			return err
+		}
+		defer close(cmd.Context(), deps)
+
+		certs, err := client.Check(cmd.Context(), mgmt, cco.clusterName, cco.namespace)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if err = certificates.PrintCertificates(os.Stdout, cco.output, certs, now, cco.expiryWindow); err != nil {
+			return err
+		}
+
+		if expiring := certificates.Expiring(certs, now, cco.expiryWindow); len(expiring) > 0 {
+			return fmt.Errorf("%d certificates expire within %s, renew them with rotate certificates", len(expiring), cco.expiryWindow)
+		}
+		return nil
+	},
+}
+
+func init() {
+	checkCmd.AddCommand(checkCertificatesCmd)
+	applyCertificatesFlags(checkCertificatesCmd, &cco.certificatesOptions)
+	flagSet := checkCertificatesCmd.Flags()
+	flagSet.StringVarP(&cco.output, "output", "o", certificates.OutputTable, "Specifies the output format (valid option: table, json, yaml)")
+	flagSet.DurationVar(&cco.expiryWindow, "expiry-window", certificates.DefaultExpiryWindow, "Report certificates expiring within this time")
+}
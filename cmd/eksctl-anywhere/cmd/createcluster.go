@@ -6,25 +6,32 @@ import (
 	"runtime"
 
 	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/awsiamauth"
+	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clustermanager"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/features"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/validations/createvalidations"
+	"github.com/aws/eks-anywhere/pkg/workflow"
 	"github.com/aws/eks-anywhere/pkg/workflow/management"
+	"github.com/aws/eks-anywhere/pkg/workflow/statestore"
 	"github.com/aws/eks-anywhere/pkg/workflows"
 )
 
 type createClusterOptions struct {
 	clusterOptions
 	timeoutOptions
+	validationRunOptions
 	forceClean            bool
 	skipIpCheck           bool
 	hardwareCSVPath       string
@@ -47,6 +54,7 @@ func init() {
 	createCmd.AddCommand(createClusterCmd)
 	applyClusterOptionFlags(createClusterCmd.Flags(), &cc.clusterOptions)
 	applyTimeoutFlags(createClusterCmd.Flags(), &cc.timeoutOptions)
+	applyValidationRunFlags(createClusterCmd.Flags(), &cc.validationRunOptions)
 	applyTinkerbellHardwareFlag(createClusterCmd.Flags(), &cc.hardwareCSVPath)
 	createClusterCmd.Flags().StringVar(&cc.tinkerbellBootstrapIP, "tinkerbell-bootstrap-ip", "", "Override the local tinkerbell IP in the bootstrap cluster")
 	createClusterCmd.Flags().BoolVar(&cc.forceClean, "force-cleanup", false, "Force deletion of previously created bootstrap cluster")
@@ -61,6 +69,10 @@ func init() {
 func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) error {
 	ctx := cmd.Context()
 
+	if err := cc.validationRunOptions.validate(); err != nil {
+		return err
+	}
+
 	clusterConfigFileExist := validations.FileExists(cc.fileName)
 	if !clusterConfigFileExist {
 		return fmt.Errorf("the cluster config file %s does not exist", cc.fileName)
@@ -159,6 +171,8 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 		ManagementCluster: getManagementCluster(clusterSpec),
 		Provider:          deps.Provider,
 		CliConfig:         cliConfig,
+		Concurrency:       cc.concurrency,
+		ValidationTimeout: cc.timeout,
 	}
 	createValidations := createvalidations.New(validationOpts)
 
@@ -178,6 +192,12 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 			Cluster:                       clustermanager.NewCreateClusterShim(clusterSpec, deps.ClusterManager, deps.Provider),
 			FS:                            deps.Writer,
 		}
+		if features.IsActive(features.CheckpointEnabled()) {
+			if wflw.StateStore, err = workflowStateStore(clusterSpec, deps.Writer); err != nil {
+				return err
+			}
+		}
+
 		wflw.WithHookRegistrar(awsiamauth.NewHookRegistrar(deps.AwsIamAuth, clusterSpec))
 
 		// Not all provider implementations want to bind hooks so we explicitly check if they
@@ -194,3 +214,26 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 	cleanup(deps, &err)
 	return err
 }
+
+// workflowStateStore returns the store for the progress of the create cluster workflow. The state of
+// clusters managed by an existing management cluster is kept in a Secret of the management cluster,
+// so the creation can be resumed from any machine with access to it. Otherwise there is no cluster to
+// keep it in until the workflow creates one, so it's kept in the cluster directory.
+func workflowStateStore(clusterSpec *cluster.Spec, writer filewriter.FileWriter) (workflow.StateStore, error) {
+	name := fmt.Sprintf("%s-workflow-state", clusterSpec.Cluster.Name)
+	if clusterSpec.Cluster.IsSelfManaged() || clusterSpec.ManagementCluster == nil {
+		return statestore.NewFile(writer, name+".yaml"), nil
+	}
+
+	config, err := clientcmd.BuildConfigFromFlags("", clusterSpec.ManagementCluster.KubeconfigFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading management cluster kubeconfig for the workflow state: %v", err)
+	}
+
+	client, err := crclient.New(config, crclient.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("creating management cluster client for the workflow state: %v", err)
+	}
+
+	return statestore.NewSecret(client, constants.EksaSystemNamespace, name), nil
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/clusterinfo"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+)
+
+type describeClusterOptions struct {
+	output string
+	// kubeConfig is an optional kubeconfig file of the management cluster
+	// to query.
+	kubeConfig string
+	namespace  string
+}
+
+var dco = &describeClusterOptions{}
+
+func init() {
+	describeCmd.AddCommand(describeClusterCommand)
+
+	describeClusterCommand.Flags().StringVarP(&dco.output, "output", "o", clusterinfo.OutputTable,
+		"Specifies the output format (valid option: table, json, yaml)")
+	describeClusterCommand.Flags().StringVar(&dco.kubeConfig, "kubeconfig", "",
+		"Path to an optional kubeconfig file of the management cluster.")
+	describeClusterCommand.Flags().StringVarP(&dco.namespace, "namespace", "n", "default",
+		"Namespace of the EKS-A cluster.")
+}
+
+var describeClusterCommand = &cobra.Command{
+	Use:          "cluster <cluster-name> [flags]",
+	Short:        "Describe an EKS-A cluster",
+	Long:         "This command aggregates the EKS-A and Cluster API objects of a cluster, their status and their recent failures",
+	PreRunE:      preRunDescribeCluster,
+	SilenceUsage: true,
+	Args:         cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return describeCluster(cmd.Context(), args[0], dco)
+	},
+}
+
+func preRunDescribeCluster(cmd *cobra.Command, args []string) error {
+	if err := bindFlagsToViper(cmd, args); err != nil {
+		return err
+	}
+
+	return clusterinfo.ValidateOutput(dco.output)
+}
+
+func describeCluster(ctx context.Context, clusterName string, opts *describeClusterOptions) error {
+	kubeConfig, err := kubeconfig.ResolveAndValidateFilename(opts.kubeConfig, "")
+	if err != nil {
+		return err
+	}
+
+	client, closer, err := newClusterInfoClient(ctx, kubeConfig)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, closer)
+
+	description, err := client.DescribeCluster(ctx, kubeConfig, clusterName, opts.namespace)
+	if err != nil {
+		return err
+	}
+
+	return clusterinfo.PrintDescription(os.Stdout, opts.output, description)
+}
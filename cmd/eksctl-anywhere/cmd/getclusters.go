@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/clusterinfo"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+)
+
+type getClustersOptions struct {
+	output string
+	// kubeConfig is an optional kubeconfig file of the management cluster
+	// to query.
+	kubeConfig    string
+	namespace     string
+	allNamespaces bool
+}
+
+var gco = &getClustersOptions{}
+
+func init() {
+	getCmd.AddCommand(getClustersCommand)
+
+	getClustersCommand.Flags().StringVarP(&gco.output, "output", "o", clusterinfo.OutputTable,
+		"Specifies the output format (valid option: table, json, yaml)")
+	getClustersCommand.Flags().StringVar(&gco.kubeConfig, "kubeconfig", "",
+		"Path to an optional kubeconfig file of the management cluster.")
+	getClustersCommand.Flags().StringVarP(&gco.namespace, "namespace", "n", "default",
+		"Namespace of the EKS-A clusters.")
+	getClustersCommand.Flags().BoolVarP(&gco.allNamespaces, "all-namespaces", "A", false,
+		"List the EKS-A clusters in all namespaces.")
+}
+
+var getClustersCommand = &cobra.Command{
+	Use:          "cluster(s) [flags]",
+	Aliases:      []string{"cluster", "clusters"},
+	Short:        "Get cluster(s)",
+	Long:         "This command is used to display the EKS-A clusters managed by a management cluster",
+	PreRunE:      preRunGetClusters,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return getClusters(cmd.Context(), gco)
+	},
+}
+
+func preRunGetClusters(cmd *cobra.Command, args []string) error {
+	if err := bindFlagsToViper(cmd, args); err != nil {
+		return err
+	}
+
+	if gco.allNamespaces && cmd.Flags().Changed("namespace") {
+		return errors.New("--namespace and --all-namespaces can't be used together")
+	}
+
+	return clusterinfo.ValidateOutput(gco.output)
+}
+
+func getClusters(ctx context.Context, opts *getClustersOptions) error {
+	kubeConfig, err := kubeconfig.ResolveAndValidateFilename(opts.kubeConfig, "")
+	if err != nil {
+		return err
+	}
+
+	client, closer, err := newClusterInfoClient(ctx, kubeConfig)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, closer)
+
+	namespace := opts.namespace
+	if opts.allNamespaces {
+		namespace = ""
+	}
+
+	summaries, err := client.ListClusters(ctx, kubeConfig, namespace)
+	if err != nil {
+		return err
+	}
+
+	return clusterinfo.PrintSummaries(os.Stdout, opts.output, summaries)
+}
+
+func newClusterInfoClient(ctx context.Context, kubeConfig string) (*clusterinfo.Client, *dependencies.Dependencies, error) {
+	deps, err := dependencies.NewFactory().
+		WithExecutableMountDirs(kubeConfig).
+		WithKubectl().
+		Build(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to initialize executables: %v", err)
+	}
+
+	return clusterinfo.NewClient(deps.Kubectl), deps, nil
+}
@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var gitopsCmd = &cobra.Command{
+	Use:   "gitops",
+	Short: "Inspect GitOps managed clusters",
+	Long:  "Use eksctl anywhere gitops to inspect clusters managed with Flux",
+}
+
+func init() {
+	rootCmd.AddCommand(gitopsCmd)
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	gitfactory "github.com/aws/eks-anywhere/pkg/git/factory"
+	"github.com/aws/eks-anywhere/pkg/gitops/drift"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type gitopsStatusOptions struct {
+	clusterName string
+	// kubeConfig is an optional kubeconfig file of the management cluster.
+	kubeConfig string
+	output     string
+}
+
+var gso = &gitopsStatusOptions{}
+
+var gitopsStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Report drift between the cluster and its GitOps repository",
+	Long: "Compare the EKS-A cluster config committed to the Flux repository with the objects in the cluster, " +
+		"reporting the fields that differ and the last revision reconciled by Flux. It fails if any drift is found",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := drift.ValidateOutput(gso.output); err != nil {
+			return err
+		}
+
+		status, err := gso.status(cmd)
+		if err != nil {
+			return err
+		}
+
+		if err = drift.PrintStatus(os.Stdout, gso.output, status); err != nil {
+			return err
+		}
+
+		if !status.InSync() {
+			return fmt.Errorf("cluster %s has %d fields drifted from the GitOps repository", gso.clusterName, len(status.Differences))
+		}
+		return nil
+	},
+}
+
+func init() {
+	gitopsCmd.AddCommand(gitopsStatusCmd)
+	flagSet := gitopsStatusCmd.Flags()
+	flagSet.StringVar(&gso.clusterName, "cluster-name", "", "Name of the cluster")
+	flagSet.StringVar(&gso.kubeConfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	flagSet.StringVarP(&gso.output, "output", "o", drift.OutputTable, "Specifies the output format (valid option: table, json, yaml)")
+
+	if err := gitopsStatusCmd.MarkFlagRequired("cluster-name"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (o *gitopsStatusOptions) status(cmd *cobra.Command) (*drift.Status, error) {
+	ctx := cmd.Context()
+	kubeConfig, err := kubeconfig.ResolveAndValidateFilename(o.kubeConfig, "")
+	if err != nil {
+		return nil, err
+	}
+
+	deps, err := dependencies.NewFactory().
+		WithExecutableMountDirs(kubeConfig).
+		WithWriterFolder(o.clusterName).
+		WithWriter().
+		WithKubectl().
+		WithUnAuthKubeClient().
+		Build(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialize executables: %v", err)
+	}
+	defer close(ctx, deps)
+
+	managementCluster := &types.Cluster{KubeconfigFile: kubeConfig}
+	eksaCluster, err := deps.Kubectl.GetEksaCluster(ctx, managementCluster, o.clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	live, err := cluster.NewDefaultConfigClientBuilder().Build(ctx, deps.UnAuthKubeClient.KubeconfigClient(kubeConfig), eksaCluster)
+	if err != nil {
+		return nil, fmt.Errorf("reading cluster config from the cluster: %v", err)
+	}
+
+	if live.FluxConfig == nil {
+		return nil, fmt.Errorf("cluster %s is not managed with Flux", o.clusterName)
+	}
+
+	repoDir, err := os.MkdirTemp("", "eksa-gitops-status-")
+	if err != nil {
+		return nil, fmt.Errorf("creating directory for the gitops repository: %v", err)
+	}
+	defer os.RemoveAll(repoDir)
+
+	tools, err := gitfactory.Build(ctx, eksaCluster, live.FluxConfig, deps.Writer, gitfactory.WithRepositoryDirectory(repoDir))
+	if err != nil {
+		return nil, fmt.Errorf("creating Git provider: %v", err)
+	}
+
+	committed, err := drift.ReadCommittedConfig(ctx, tools.Client, repoDir, live.FluxConfig, o.clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	differences, err := drift.Detect(committed, live)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := drift.GetRevisions(ctx, deps.Kubectl, kubeConfig, live.FluxConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &drift.Status{
+		Cluster:     o.clusterName,
+		ConfigFile:  flux.ClusterConfigFilePath(live.FluxConfig, o.clusterName),
+		Revisions:   revisions,
+		Differences: differences,
+	}, nil
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
 	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/version"
 )
 
@@ -72,6 +73,78 @@ func buildClusterManagerOpts(t timeoutOptions) ([]clustermanager.ClusterManagerO
 	}, nil
 }
 
+type validationRunOptions struct {
+	concurrency int
+	timeout     time.Duration
+}
+
+func applyValidationRunFlags(flagSet *pflag.FlagSet, o *validationRunOptions) {
+	flagSet.IntVar(&o.concurrency, "validation-concurrency", validations.DefaultConcurrency, "Maximum number of independent preflight validations to run in parallel, they run sequentially by default")
+	flagSet.DurationVar(&o.timeout, "validation-timeout", 0, "Maximum duration of each preflight validation, no limit if 0")
+}
+
+func (o validationRunOptions) validate() error {
+	if o.concurrency < 1 {
+		return fmt.Errorf("invalid validation concurrency %d, it must be at least 1", o.concurrency)
+	}
+	if o.timeout < 0 {
+		return fmt.Errorf("invalid validation timeout %s, it can't be negative", o.timeout)
+	}
+	return nil
+}
+
+type validationOptions struct {
+	validationRunOptions
+	skipValidations []string
+	reportFile      string
+	reportFormat    string
+}
+
+func applyValidationFlags(flagSet *pflag.FlagSet, o *validationOptions) {
+	flagSet.StringSliceVar(&o.skipValidations, "skip-validations", nil,
+		fmt.Sprintf("Non-critical validations to skip (valid options: %s)", strings.Join(validations.SkippableValidations(), ", ")))
+	flagSet.StringVar(&o.reportFile, "validation-report", "", "File to write the results of the validations to, for machine consumption")
+	flagSet.StringVar(&o.reportFormat, "validation-report-format", validations.ReportFormatJSON, "Format of the validation report (valid options: json, junit)")
+	applyValidationRunFlags(flagSet, &o.validationRunOptions)
+}
+
+// skippedValidations validates the validation flags and returns the set of validations to skip.
+func (o validationOptions) skippedValidations() (map[string]bool, error) {
+	if err := validations.ValidateReportFormat(o.reportFormat); err != nil {
+		return nil, err
+	}
+	if err := o.validationRunOptions.validate(); err != nil {
+		return nil, err
+	}
+	return validations.ValidateSkippedValidations(o.skipValidations)
+}
+
+// newReport returns the report to record the validation results in, or nil if no report was requested.
+func (o validationOptions) newReport(provider string) *validations.Report {
+	if o.reportFile == "" {
+		return nil
+	}
+	return validations.NewReport(provider)
+}
+
+// writeReport writes report if one was requested and returns err, the error of the command
+// that ran the validations, or the error writing the report if the command succeeded.
+func (o validationOptions) writeReport(report *validations.Report, err error) error {
+	if report == nil {
+		return err
+	}
+
+	if reportErr := report.WriteFile(o.reportFile, o.reportFormat); reportErr != nil {
+		if err != nil {
+			logger.Error(reportErr, "Failed writing validation report", "file", o.reportFile)
+			return err
+		}
+		return reportErr
+	}
+	logger.V(3).Info("Validation report written", "file", o.reportFile)
+	return err
+}
+
 type clusterOptions struct {
 	fileName             string
 	bundlesOverride      string
@@ -140,6 +213,8 @@ func buildCliConfig(clusterSpec *cluster.Spec) *config.CliConfig {
 		cliConfig.GitSshKeyPassphrase = os.Getenv(config.EksaGitPassphraseTokenEnv)
 		cliConfig.GitPrivateKeyFile = os.Getenv(config.EksaGitPrivateKeyTokenEnv)
 		cliConfig.GitKnownHostsFile = os.Getenv(config.EksaGitKnownHostsFileEnv)
+		cliConfig.GitUsername = os.Getenv(config.EksaGitUsernameEnv)
+		cliConfig.GitPassword = os.Getenv(config.EksaGitPasswordEnv)
 	}
 
 	return cliConfig
@@ -162,7 +237,7 @@ func getManagementCluster(clusterSpec *cluster.Spec) *types.Cluster {
 func (c *clusterOptions) directoriesToMount(clusterSpec *cluster.Spec, cliConfig *config.CliConfig, addDirs ...string) ([]string, error) {
 	dirs := c.mountDirs()
 	fluxConfig := clusterSpec.FluxConfig
-	if fluxConfig != nil && fluxConfig.Spec.Git != nil {
+	if fluxConfig != nil && fluxConfig.Spec.Git != nil && !fluxConfig.Spec.Git.IsHTTPS() {
 		dirs = append(dirs, filepath.Dir(cliConfig.GitPrivateKeyFile))
 		dirs = append(dirs, filepath.Dir(cliConfig.GitKnownHostsFile))
 	}
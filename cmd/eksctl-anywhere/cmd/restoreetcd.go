@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+var reo = &etcdBackupOptions{}
+
+var restoreEtcdCmd = &cobra.Command{
+	Use:   "etcd",
+	Short: "Restore an etcd snapshot into a cluster",
+	Long: "Restore an etcd snapshot into all the etcd members of a cluster. The cluster should be a freshly created " +
+		"control plane running the same Kubernetes version as the cluster the snapshot was taken from",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, kubeConfig, deps, err := newEtcdBackupClient(cmd, reo)
+		if err != nil {
+			return err
+		}
+		defer close(cmd.Context(), deps)
+
+		if err = client.Restore(cmd.Context(), reo.backupOptions(kubeConfig), reo.snapshot); err != nil {
+			return err
+		}
+
+		logger.MarkSuccess("Etcd snapshot restored", "name", reo.snapshot)
+		return nil
+	},
+}
+
+func init() {
+	restoreCmd.AddCommand(restoreEtcdCmd)
+	applyEtcdBackupFlags(restoreEtcdCmd, reo)
+	restoreEtcdCmd.Flags().StringVar(&reo.snapshot, "snapshot", "", "Name of the snapshot to restore")
+	if err := restoreEtcdCmd.MarkFlagRequired("snapshot"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
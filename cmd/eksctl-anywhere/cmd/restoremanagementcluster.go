@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/managementbackup"
+	"github.com/aws/eks-anywhere/pkg/tar"
+)
+
+type restoreManagementClusterOptions struct {
+	managementBackupOptions
+	// create holds the create cluster options used when the new management cluster is created by the restore.
+	create createClusterOptions
+}
+
+var rmo = &restoreManagementClusterOptions{}
+
+var restoreManagementClusterCmd = &cobra.Command{
+	Use:   "management-cluster",
+	Short: "Restore a management cluster backup into a new management cluster",
+	Long: "Restore the workload clusters saved in a management cluster backup into a new management cluster, " +
+		"which takes over their management. Unless --kubeconfig points to an existing management cluster, " +
+		"the new management cluster is created first from the management-cluster.yaml file in the backup archive",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if rmo.kubeConfig == "" {
+			if err := rmo.createManagementCluster(cmd); err != nil {
+				return err
+			}
+		}
+
+		client, mgmt, deps, err := newManagementBackupClient(cmd, &rmo.managementBackupOptions)
+		if err != nil {
+			return err
+		}
+		defer close(cmd.Context(), deps)
+
+		metadata, err := client.Restore(cmd.Context(), mgmt, rmo.archive)
+		if err != nil {
+			return err
+		}
+
+		logger.MarkSuccess("Management cluster backup restored", "managementCluster", metadata.ManagementCluster, "workloadClusters", metadata.WorkloadClusters)
+		return nil
+	},
+}
+
+func init() {
+	restoreCmd.AddCommand(restoreManagementClusterCmd)
+	flagSet := restoreManagementClusterCmd.Flags()
+	flagSet.StringVar(&rmo.kubeConfig, "kubeconfig", "", "Existing management cluster kubeconfig file, if not set a new management cluster is created from the backup")
+	flagSet.StringVar(&rmo.archive, "archive", "", "Backup archive to restore")
+	applyTimeoutFlags(flagSet, &rmo.create.timeoutOptions)
+	applyValidationRunFlags(flagSet, &rmo.create.validationRunOptions)
+	applyTinkerbellHardwareFlag(flagSet, &rmo.create.hardwareCSVPath)
+	flagSet.StringVar(&rmo.create.tinkerbellBootstrapIP, "tinkerbell-bootstrap-ip", "", "Override the local tinkerbell IP in the bootstrap cluster")
+	flagSet.BoolVar(&rmo.create.forceClean, "force-cleanup", false, "Force deletion of previously created bootstrap cluster")
+	flagSet.BoolVar(&rmo.create.skipIpCheck, "skip-ip-check", false, "Skip check for whether cluster control plane ip is in use")
+
+	if err := restoreManagementClusterCmd.MarkFlagRequired("archive"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+// createManagementCluster creates the new management cluster with the cluster config saved in the backup,
+// through a bootstrap cluster like create cluster does, and points the restore to its kubeconfig.
+func (o *restoreManagementClusterOptions) createManagementCluster(cmd *cobra.Command) error {
+	metadata, config, err := managementbackup.NewClient(nil, nil, tar.NewGzipPackager()).ReadManagementClusterConfig(o.archive)
+	if err != nil {
+		return err
+	}
+
+	o.create.fileName = fmt.Sprintf("%s-%s", metadata.ManagementCluster, managementbackup.ManagementClusterConfigFile)
+	if err = os.WriteFile(o.create.fileName, config, 0o600); err != nil {
+		return fmt.Errorf("writing management cluster config: %v", err)
+	}
+
+	logger.Info("Creating new management cluster from backup", "cluster", metadata.ManagementCluster, "config", o.create.fileName)
+	if err = o.create.createCluster(cmd, nil); err != nil {
+		return fmt.Errorf("creating management cluster %s: %v", metadata.ManagementCluster, err)
+	}
+
+	o.kubeConfig = kubeconfig.FromClusterName(metadata.ManagementCluster)
+	return nil
+}
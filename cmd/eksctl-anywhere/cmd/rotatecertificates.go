@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+var rco = &certificatesOptions{}
+
+var rotateCertificatesCmd = &cobra.Command{
+	Use:   "certificates",
+	Short: "Renew the cluster certificates",
+	Long: "Renew the apiserver, etcd and kubelet certificates of a cluster by rolling out all its machines, " +
+		"external etcd first, then the control plane and finally the worker nodes. " +
+		"The command returns once the rollouts are started, follow them with get clusters",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, mgmt, deps, err := newCertificatesClient(cmd, rco)
+		if err != nil {
+			return err
+		}
+		defer close(cmd.Context(), deps)
+
+		rollouts, err := client.Rotate(cmd.Context(), mgmt, rco.clusterName, rco.namespace)
+		if err != nil {
+			return err
+		}
+
+		for _, r := range rollouts {
+			logger.Info("Rollout started", "kind", r.Kind, "name", r.Name)
+		}
+		logger.MarkSuccess("Certificates rotation started", "cluster", rco.clusterName)
+		return nil
+	},
+}
+
+func init() {
+	rotateCmd.AddCommand(rotateCertificatesCmd)
+	applyCertificatesFlags(rotateCertificatesCmd, rco)
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/encryption"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type encryptionKeysOptions struct {
+	clusterName string
+	namespace   string
+	// kubeConfig is an optional kubeconfig file of the management cluster.
+	kubeConfig string
+}
+
+var reko = &encryptionKeysOptions{}
+
+var rotateEncryptionKeysCmd = &cobra.Command{
+	Use:   "encryption-keys",
+	Short: "Rotate the cluster encryption keys",
+	Long: "Replace the aescbc and secretbox keys of a cluster with new keys and rewrite all its encrypted resources with them. " +
+		"The control plane is rolled out three times: to add the new keys, to encrypt with them and to remove the previous keys " +
+		"once the resources are rewritten",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return reko.rotate(cmd)
+	},
+}
+
+func init() {
+	rotateCmd.AddCommand(rotateEncryptionKeysCmd)
+
+	flagSet := rotateEncryptionKeysCmd.Flags()
+	flagSet.StringVar(&reko.clusterName, "cluster-name", "", "Name of the cluster")
+	flagSet.StringVarP(&reko.namespace, "namespace", "n", "default", "Namespace of the EKS-A cluster")
+	flagSet.StringVar(&reko.kubeConfig, "kubeconfig", "", "Management cluster kubeconfig file")
+
+	if err := rotateEncryptionKeysCmd.MarkFlagRequired("cluster-name"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (o *encryptionKeysOptions) rotate(cmd *cobra.Command) error {
+	ctx := cmd.Context()
+	kubeConfig, err := kubeconfig.ResolveAndValidateFilename(o.kubeConfig, "")
+	if err != nil {
+		return err
+	}
+
+	deps, err := dependencies.NewFactory().
+		WithExecutableMountDirs(kubeConfig).
+		WithWriterFolder(o.clusterName).
+		WithWriter().
+		WithKubectl().
+		Build(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to initialize executables: %v", err)
+	}
+	defer close(ctx, deps)
+
+	client := encryption.NewClient(deps.Kubectl, deps.Writer)
+	if err = client.RotateKeys(ctx, &types.Cluster{KubeconfigFile: kubeConfig}, o.clusterName, o.namespace); err != nil {
+		return err
+	}
+
+	logger.MarkSuccess("Encryption keys rotated", "cluster", o.clusterName)
+	return nil
+}
@@ -2,12 +2,15 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
 	"github.com/aws/eks-anywhere/pkg/types"
@@ -19,10 +22,16 @@ import (
 type upgradeClusterOptions struct {
 	clusterOptions
 	timeoutOptions
+	validationOptions
 	wConfig               string
 	forceClean            bool
+	rollbackOnFailure     bool
 	hardwareCSVPath       string
 	tinkerbellBootstrapIP string
+	// gitopsPullRequest makes the upgrade open a pull request with the cluster config changes
+	// and wait for it to be merged, instead of pushing them to the flux config branch.
+	gitopsPullRequest        bool
+	gitopsPullRequestTimeout time.Duration
 }
 
 var uc = &upgradeClusterOptions{}
@@ -45,9 +54,13 @@ func init() {
 	upgradeCmd.AddCommand(upgradeClusterCmd)
 	applyClusterOptionFlags(upgradeClusterCmd.Flags(), &uc.clusterOptions)
 	applyTimeoutFlags(upgradeClusterCmd.Flags(), &uc.timeoutOptions)
+	applyValidationFlags(upgradeClusterCmd.Flags(), &uc.validationOptions)
 	applyTinkerbellHardwareFlag(upgradeClusterCmd.Flags(), &uc.hardwareCSVPath)
 	upgradeClusterCmd.Flags().StringVarP(&uc.wConfig, "w-config", "w", "", "Kubeconfig file to use when upgrading a workload cluster")
 	upgradeClusterCmd.Flags().BoolVar(&uc.forceClean, "force-cleanup", false, "Force deletion of previously created bootstrap cluster")
+	upgradeClusterCmd.Flags().BoolVar(&uc.rollbackOnFailure, "rollback-on-failure", false, "Snapshot the cluster before upgrading and revert to the previous spec if the upgrade fails")
+	upgradeClusterCmd.Flags().BoolVar(&uc.gitopsPullRequest, "gitops-pull-request", false, "Open a pull request with the cluster config changes in the GitOps repository and wait for it to be merged instead of pushing to the Flux branch")
+	upgradeClusterCmd.Flags().DurationVar(&uc.gitopsPullRequestTimeout, "gitops-pull-request-timeout", time.Hour, "Maximum time to wait for the GitOps pull request to be merged")
 
 	if err := upgradeClusterCmd.MarkFlagRequired("filename"); err != nil {
 		log.Fatalf("Error marking flag as required: %v", err)
@@ -57,6 +70,11 @@ func init() {
 func (uc *upgradeClusterOptions) upgradeCluster(cmd *cobra.Command) error {
 	ctx := cmd.Context()
 
+	skippedValidations, err := uc.skippedValidations()
+	if err != nil {
+		return err
+	}
+
 	clusterConfigFileExist := validations.FileExists(uc.fileName)
 	if !clusterConfigFileExist {
 		return fmt.Errorf("the cluster config file %s does not exist", uc.fileName)
@@ -85,7 +103,15 @@ func (uc *upgradeClusterOptions) upgradeCluster(cmd *cobra.Command) error {
 		return err
 	}
 
+	if uc.gitopsPullRequest {
+		if err := validateGitOpsPullRequest(clusterSpec); err != nil {
+			return err
+		}
+	}
+
 	cliConfig := buildCliConfig(clusterSpec)
+	cliConfig.GitOpsPullRequest = uc.gitopsPullRequest
+	cliConfig.GitOpsPullRequestTimeout = uc.gitopsPullRequestTimeout
 	dirs, err := uc.directoriesToMount(clusterSpec, cliConfig)
 	if err != nil {
 		return err
@@ -123,6 +149,11 @@ func (uc *upgradeClusterOptions) upgradeCluster(cmd *cobra.Command) error {
 		deps.EksdUpgrader,
 		deps.EksdInstaller,
 	)
+	if uc.rollbackOnFailure {
+		upgradeCluster.WithRollback()
+	}
+	report := uc.newReport(deps.Provider.Name())
+	upgradeCluster.WithValidationReport(report)
 
 	workloadCluster := &types.Cluster{
 		Name:           clusterSpec.Cluster.Name,
@@ -137,18 +168,22 @@ func (uc *upgradeClusterOptions) upgradeCluster(cmd *cobra.Command) error {
 	}
 
 	validationOpts := &validations.Opts{
-		Kubectl:           deps.Kubectl,
-		Spec:              clusterSpec,
-		WorkloadCluster:   workloadCluster,
-		ManagementCluster: managementCluster,
-		Provider:          deps.Provider,
-		CliConfig:         cliConfig,
+		Kubectl:            deps.Kubectl,
+		Spec:               clusterSpec,
+		WorkloadCluster:    workloadCluster,
+		ManagementCluster:  managementCluster,
+		Provider:           deps.Provider,
+		CliConfig:          cliConfig,
+		SkippedValidations: skippedValidations,
+		Concurrency:        uc.concurrency,
+		ValidationTimeout:  uc.timeout,
+		Report:             report,
 	}
 	upgradeValidations := upgradevalidations.New(validationOpts)
 
 	err = upgradeCluster.Run(ctx, clusterSpec, managementCluster, workloadCluster, upgradeValidations, uc.forceClean)
 	cleanup(deps, &err)
-	return err
+	return uc.writeReport(report, err)
 }
 
 func (uc *upgradeClusterOptions) commonValidations(ctx context.Context) (cluster *v1alpha1.Cluster, err error) {
@@ -164,3 +199,15 @@ func (uc *upgradeClusterOptions) commonValidations(ctx context.Context) (cluster
 
 	return clusterConfig, nil
 }
+
+// validateGitOpsPullRequest checks pull requests can be opened in the gitops repository of the cluster,
+// which requires a Flux config with a git provider that has an API: GitHub, GitLab or Bitbucket Server.
+func validateGitOpsPullRequest(clusterSpec *cluster.Spec) error {
+	if clusterSpec.FluxConfig == nil {
+		return errors.New("--gitops-pull-request requires a cluster managed with Flux")
+	}
+	if clusterSpec.FluxConfig.Spec.Git != nil {
+		return errors.New("--gitops-pull-request is not supported for the generic git provider, use github, gitlab or bitbucketServer")
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/validations/configlint"
+)
+
+type validateConfigOptions struct {
+	fileName string
+	output   string
+}
+
+var vco = &validateConfigOptions{}
+
+var validateConfigCmd = &cobra.Command{
+	Use:   "config -f <cluster-config-file> [flags]",
+	Short: "Validate a cluster config file offline",
+	Long: "Run the validations of a cluster config file that don't need access to any infrastructure, " +
+		"reporting each issue found with its YAML document index, line and JSON path. It fails if any error is found",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return vco.validateConfig()
+	},
+}
+
+func init() {
+	validateCmd.AddCommand(validateConfigCmd)
+	validateConfigCmd.Flags().StringVarP(&vco.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	validateConfigCmd.Flags().StringVarP(&vco.output, "output", "o", configlint.OutputText, "Specifies the output format (valid option: text, json)")
+
+	if err := validateConfigCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (o *validateConfigOptions) validateConfig() error {
+	if err := configlint.ValidateOutput(o.output); err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(o.fileName)
+	if err != nil {
+		return fmt.Errorf("reading cluster config file: %v", err)
+	}
+
+	issues := configlint.Lint(content)
+	if err = configlint.PrintIssues(os.Stdout, o.output, o.fileName, issues); err != nil {
+		return err
+	}
+
+	if configlint.HasErrors(issues) {
+		return fmt.Errorf("cluster config file %s is invalid", o.fileName)
+	}
+	return nil
+}
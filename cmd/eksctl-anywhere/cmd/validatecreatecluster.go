@@ -19,6 +19,7 @@ import (
 
 type validateOptions struct {
 	clusterOptions
+	validationOptions
 	hardwareCSVPath       string
 	tinkerbellBootstrapIP string
 }
@@ -39,6 +40,7 @@ func init() {
 	applyTinkerbellHardwareFlag(validateCreateClusterCmd.Flags(), &valOpt.hardwareCSVPath)
 	validateCreateClusterCmd.Flags().StringVarP(&valOpt.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
 	validateCreateClusterCmd.Flags().StringVar(&valOpt.tinkerbellBootstrapIP, "tinkerbell-bootstrap-ip", "", "Override the local tinkerbell IP in the bootstrap cluster")
+	applyValidationFlags(validateCreateClusterCmd.Flags(), &valOpt.validationOptions)
 
 	if err := validateCreateClusterCmd.MarkFlagRequired("filename"); err != nil {
 		log.Fatalf("Error marking flag as required: %v", err)
@@ -48,6 +50,11 @@ func init() {
 func (valOpt *validateOptions) validateCreateCluster(cmd *cobra.Command, _ []string) error {
 	ctx := cmd.Context()
 
+	skippedValidations, err := valOpt.skippedValidations()
+	if err != nil {
+		return err
+	}
+
 	clusterSpec, err := cluster.NewSpecFromClusterConfig(valOpt.fileName, version.Get())
 	if err != nil {
 		return err
@@ -90,16 +97,21 @@ func (valOpt *validateOptions) validateCreateCluster(cmd *cobra.Command, _ []str
 			Name:           clusterSpec.Cluster.Name,
 			KubeconfigFile: kubeconfig.FromClusterName(clusterSpec.Cluster.Name),
 		},
-		ManagementCluster: getManagementCluster(clusterSpec),
-		Provider:          deps.Provider,
-		CliConfig:         cliConfig,
+		ManagementCluster:  getManagementCluster(clusterSpec),
+		Provider:           deps.Provider,
+		CliConfig:          cliConfig,
+		SkippedValidations: skippedValidations,
+		Concurrency:        valOpt.concurrency,
+		ValidationTimeout:  valOpt.timeout,
+		Report:             valOpt.newReport(deps.Provider.Name()),
 	}
 
 	createValidations := createvalidations.New(validationOpts)
 
-	commandVal := createcluster.NewValidations(clusterSpec, deps.Provider, deps.GitOpsFlux, createValidations, deps.DockerClient)
+	commandVal := createcluster.NewValidations(clusterSpec, deps.Provider, deps.GitOpsFlux, createValidations, deps.DockerClient).
+		WithReport(validationOpts.Report)
 	err = commandVal.Validate(ctx)
 
 	cleanupDirectory(tmpPath)
-	return err
+	return valOpt.writeReport(validationOpts.Report, err)
 }
@@ -0,0 +1,133 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/certificates"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// certificatesCheckInterval is the minimum time between two certificate checks for the same cluster.
+const certificatesCheckInterval = time.Hour
+
+// CertificateChecker reads the certificates served by a list of targets.
+type CertificateChecker interface {
+	Check(ctx context.Context, targets []certificates.Target) []certificates.Certificate
+}
+
+// ClusterReconcilerOption configures optional behavior of a ClusterReconciler.
+type ClusterReconcilerOption func(*ClusterReconciler)
+
+// WithCertificateExpiryCheck makes the ClusterReconciler read the certificates served by the cluster
+// machines and set the CertificatesValid condition to false when any of them expires within window.
+func WithCertificateExpiryCheck(checker CertificateChecker, window time.Duration) ClusterReconcilerOption {
+	return func(r *ClusterReconciler) {
+		r.certificates = &certificateExpiryCheck{
+			client:      r.client,
+			checker:     checker,
+			window:      window,
+			now:         time.Now,
+			lastChecked: map[client.ObjectKey]time.Time{},
+		}
+	}
+}
+
+type certificateExpiryCheck struct {
+	client  client.Client
+	checker CertificateChecker
+	window  time.Duration
+	now     func() time.Time
+
+	mu          sync.Mutex
+	lastChecked map[client.ObjectKey]time.Time
+}
+
+// reconcile sets the CertificatesValid condition of a cluster, checking its certificates at most
+// once every certificatesCheckInterval. It returns when the next check is due.
+func (c *certificateExpiryCheck) reconcile(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (time.Duration, error) {
+	now := c.now()
+	key := client.ObjectKeyFromObject(cluster)
+
+	c.mu.Lock()
+	last, ok := c.lastChecked[key]
+	c.mu.Unlock()
+	if ok && now.Sub(last) < certificatesCheckInterval {
+		return certificatesCheckInterval - now.Sub(last), nil
+	}
+
+	machines := &clusterv1.MachineList{}
+	if err := c.client.List(ctx, machines,
+		client.InNamespace(constants.EksaSystemNamespace),
+		client.MatchingLabels{clusterv1.ClusterLabelName: cluster.Name},
+	); err != nil {
+		return 0, fmt.Errorf("listing machines for certificate check: %v", err)
+	}
+
+	log.Info("Checking certificates expiry")
+	targets := certificates.TargetsForMachines(machines.Items, cluster.Spec.ExternalEtcdConfiguration == nil)
+	certs := c.checker.Check(ctx, targets)
+	if certificates.UsesAWSIamAuth(cluster) {
+		certs = append(certs, c.awsIamAuthCA(ctx, cluster))
+	}
+	for _, cert := range certs {
+		if cert.Error != "" {
+			log.Info("Unable to read certificate", "component", cert.Component, "machine", cert.Machine, "error", cert.Error)
+		}
+	}
+
+	if expiring := certificates.Expiring(certs, now, c.window); len(expiring) > 0 {
+		conditions.MarkFalse(cluster, anywherev1.CertificatesValidCondition, anywherev1.CertificatesExpiringReason,
+			clusterv1.ConditionSeverityWarning, "%s", expiringMessage(expiring))
+	} else if len(certs) > 0 {
+		conditions.MarkTrue(cluster, anywherev1.CertificatesValidCondition)
+	}
+
+	c.mu.Lock()
+	c.lastChecked[key] = now
+	c.mu.Unlock()
+
+	return certificatesCheckInterval, nil
+}
+
+// awsIamAuthCA reads the AWS IAM Authenticator CA of a cluster from its secret in the management cluster.
+func (c *certificateExpiryCheck) awsIamAuthCA(ctx context.Context, cluster *anywherev1.Cluster) certificates.Certificate {
+	name := certificates.AWSIamAuthCASecretName(cluster.Name)
+	secret := &corev1.Secret{}
+	err := c.client.Get(ctx, client.ObjectKey{Namespace: constants.EksaSystemNamespace, Name: name}, secret)
+	if err != nil {
+		err = fmt.Errorf("getting secret %s: %v", name, err)
+	}
+
+	return certificates.AWSIamAuthCACertificate(cluster.Name, secret, err)
+}
+
+func expiringMessage(expiring []certificates.Certificate) string {
+	m := make([]string, 0, len(expiring))
+	for _, c := range expiring {
+		m = append(m, fmt.Sprintf("%s certificate in machine %s expires on %s", c.Component, c.Machine, c.NotAfter.UTC().Format(time.RFC3339)))
+	}
+	return strings.Join(m, ", ")
+}
+
+// withRequeueAfter returns a result that requeues at the latest after d.
+func withRequeueAfter(result ctrl.Result, d time.Duration) ctrl.Result {
+	if result.Requeue && result.RequeueAfter == 0 {
+		return result
+	}
+	if result.RequeueAfter == 0 || d < result.RequeueAfter {
+		result.RequeueAfter = d
+	}
+	return result
+}
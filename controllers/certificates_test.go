@@ -0,0 +1,165 @@
+package controllers_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/controllers"
+	"github.com/aws/eks-anywhere/controllers/mocks"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/certificates"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+type fakeCertificateChecker struct {
+	notAfter time.Time
+	targets  []certificates.Target
+}
+
+func (f *fakeCertificateChecker) Check(_ context.Context, targets []certificates.Target) []certificates.Certificate {
+	f.targets = append(f.targets, targets...)
+	certs := make([]certificates.Certificate, 0, len(targets))
+	for _, t := range targets {
+		notAfter := metav1.NewTime(f.notAfter)
+		certs = append(certs, certificates.Certificate{Component: t.Component, Machine: t.Machine, Source: t.Address, NotAfter: &notAfter})
+	}
+	return certs
+}
+
+func TestClusterReconcilerReconcileCertificatesExpiring(t *testing.T) {
+	tests := []struct {
+		name       string
+		notAfter   time.Time
+		wantStatus string
+	}{
+		{
+			name:       "expiring",
+			notAfter:   time.Now().Add(24 * time.Hour),
+			wantStatus: "False",
+		},
+		{
+			name:       "valid",
+			notAfter:   time.Now().Add(365 * 24 * time.Hour),
+			wantStatus: "True",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			cluster := &anywherev1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-management-cluster", Namespace: "default"},
+				Spec: anywherev1.ClusterSpec{
+					BundlesRef: &anywherev1.BundlesRef{Name: "my-bundles-ref"},
+				},
+			}
+			machine := &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-management-cluster-md-1",
+					Namespace: constants.EksaSystemNamespace,
+					Labels:    map[string]string{clusterv1.ClusterLabelName: cluster.Name},
+				},
+				Status: clusterv1.MachineStatus{
+					Addresses: clusterv1.MachineAddresses{{Type: clusterv1.MachineExternalIP, Address: "10.0.0.2"}},
+				},
+			}
+
+			providerReconciler := mocks.NewMockProviderClusterReconciler(gomock.NewController(t))
+			providerReconciler.EXPECT().ReconcileWorkerNodes(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(cluster))
+			c := fake.NewClientBuilder().WithRuntimeObjects(cluster, machine).Build()
+			checker := &fakeCertificateChecker{notAfter: tt.notAfter}
+
+			r := controllers.NewClusterReconciler(c, newRegistryMock(providerReconciler),
+				controllers.WithCertificateExpiryCheck(checker, certificates.DefaultExpiryWindow),
+			)
+			result, err := r.Reconcile(ctx, clusterRequest(cluster))
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(result.RequeueAfter).To(Equal(time.Hour))
+			g.Expect(checker.targets).To(Equal([]certificates.Target{
+				{Component: certificates.Kubelet, Machine: machine.Name, Address: "10.0.0.2:10250"},
+			}))
+
+			api := &anywherev1.Cluster{}
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(cluster), api)).To(Succeed())
+			condition := conditions.Get(api, anywherev1.CertificatesValidCondition)
+			g.Expect(condition).NotTo(BeNil())
+			g.Expect(string(condition.Status)).To(Equal(tt.wantStatus))
+		})
+	}
+}
+
+func TestClusterReconcilerReconcileCertificatesAWSIamAuthCAExpiring(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-management-cluster", Namespace: "default"},
+		Spec: anywherev1.ClusterSpec{
+			BundlesRef:           &anywherev1.BundlesRef{Name: "my-bundles-ref"},
+			IdentityProviderRefs: []anywherev1.Ref{{Kind: anywherev1.AWSIamConfigKind, Name: "aws-iam"}},
+		},
+	}
+	caNotAfter := time.Now().Add(24 * time.Hour)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-management-cluster-aws-iam-authenticator-ca",
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Data: map[string][]byte{"cert.pem": pemCertificate(t, caNotAfter)},
+	}
+
+	providerReconciler := mocks.NewMockProviderClusterReconciler(gomock.NewController(t))
+	providerReconciler.EXPECT().ReconcileWorkerNodes(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(cluster))
+	awsIamConfig := &anywherev1.AWSIamConfig{ObjectMeta: metav1.ObjectMeta{Name: "aws-iam", Namespace: "default"}}
+	c := fake.NewClientBuilder().WithRuntimeObjects(cluster, awsIamConfig, secret).Build()
+	checker := &fakeCertificateChecker{notAfter: time.Now().Add(365 * 24 * time.Hour)}
+
+	r := controllers.NewClusterReconciler(c, newRegistryMock(providerReconciler),
+		controllers.WithCertificateExpiryCheck(checker, certificates.DefaultExpiryWindow),
+	)
+	_, err := r.Reconcile(ctx, clusterRequest(cluster))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	api := &anywherev1.Cluster{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(cluster), api)).To(Succeed())
+	condition := conditions.Get(api, anywherev1.CertificatesValidCondition)
+	g.Expect(condition).NotTo(BeNil())
+	g.Expect(string(condition.Status)).To(Equal("False"))
+	g.Expect(condition.Message).To(ContainSubstring("aws-iam-authenticator-ca certificate"))
+}
+
+func pemCertificate(t *testing.T, notAfter time.Time) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "aws-iam-authenticator"},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
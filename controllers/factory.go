@@ -13,9 +13,12 @@ import (
 	"github.com/aws/eks-anywhere/pkg/dependencies"
 	ciliumreconciler "github.com/aws/eks-anywhere/pkg/networking/cilium/reconciler"
 	cnireconciler "github.com/aws/eks-anywhere/pkg/networking/reconciler"
+	cloudstackreconciler "github.com/aws/eks-anywhere/pkg/providers/cloudstack/reconciler"
 	dockerreconciler "github.com/aws/eks-anywhere/pkg/providers/docker/reconciler"
+	nutanixreconciler "github.com/aws/eks-anywhere/pkg/providers/nutanix/reconciler"
 	"github.com/aws/eks-anywhere/pkg/providers/snow"
 	snowreconciler "github.com/aws/eks-anywhere/pkg/providers/snow/reconciler"
+	tinkerbellreconciler "github.com/aws/eks-anywhere/pkg/providers/tinkerbell/reconciler"
 	vspherereconciler "github.com/aws/eks-anywhere/pkg/providers/vsphere/reconciler"
 )
 
@@ -28,15 +31,18 @@ type Factory struct {
 	registryBuilder   *clusters.ProviderClusterReconcilerRegistryBuilder
 	reconcilers       Reconcilers
 
-	tracker                  *remote.ClusterCacheTracker
-	registry                 *clusters.ProviderClusterReconcilerRegistry
-	dockerClusterReconciler  *dockerreconciler.Reconciler
-	vsphereClusterReconciler *vspherereconciler.Reconciler
-	snowClusterReconciler    *snowreconciler.Reconciler
-	cniReconciler            *cnireconciler.Reconciler
-	ipValidator              *clusters.IPValidator
-	logger                   logr.Logger
-	deps                     *dependencies.Dependencies
+	tracker                     *remote.ClusterCacheTracker
+	registry                    *clusters.ProviderClusterReconcilerRegistry
+	dockerClusterReconciler     *dockerreconciler.Reconciler
+	vsphereClusterReconciler    *vspherereconciler.Reconciler
+	snowClusterReconciler       *snowreconciler.Reconciler
+	tinkerbellClusterReconciler *tinkerbellreconciler.Reconciler
+	cloudstackClusterReconciler *cloudstackreconciler.Reconciler
+	nutanixClusterReconciler    *nutanixreconciler.Reconciler
+	cniReconciler               *cnireconciler.Reconciler
+	ipValidator                 *clusters.IPValidator
+	logger                      logr.Logger
+	deps                        *dependencies.Dependencies
 }
 
 type Reconcilers struct {
@@ -76,7 +82,7 @@ func (f *Factory) Build(ctx context.Context) (*Reconcilers, error) {
 	return &f.reconcilers, nil
 }
 
-func (f *Factory) WithClusterReconciler(capiProviders []clusterctlv1.Provider) *Factory {
+func (f *Factory) WithClusterReconciler(capiProviders []clusterctlv1.Provider, opts ...ClusterReconcilerOption) *Factory {
 	f.dependencyFactory.WithGovc()
 	f.withTracker().WithProviderClusterReconcilerRegistry(capiProviders)
 
@@ -88,6 +94,7 @@ func (f *Factory) WithClusterReconciler(capiProviders []clusterctlv1.Provider) *
 		f.reconcilers.ClusterReconciler = NewClusterReconciler(
 			f.manager.GetClient(),
 			f.registry,
+			opts...,
 		)
 
 		return nil
@@ -172,9 +179,12 @@ func (f *Factory) withTracker() *Factory {
 }
 
 const (
-	dockerProviderName  = "docker"
-	snowProviderName    = "snow"
-	vSphereProviderName = "vsphere"
+	dockerProviderName     = "docker"
+	snowProviderName       = "snow"
+	vSphereProviderName    = "vsphere"
+	tinkerbellProviderName = "tinkerbell"
+	cloudstackProviderName = "cloudstack"
+	nutanixProviderName    = "nutanix"
 )
 
 func (f *Factory) WithProviderClusterReconcilerRegistry(capiProviders []clusterctlv1.Provider) *Factory {
@@ -192,6 +202,12 @@ func (f *Factory) WithProviderClusterReconcilerRegistry(capiProviders []clusterc
 			f.withSnowClusterReconciler()
 		case vSphereProviderName:
 			f.withVSphereClusterReconciler()
+		case tinkerbellProviderName:
+			f.withTinkerbellClusterReconciler()
+		case cloudstackProviderName:
+			f.withCloudStackClusterReconciler()
+		case nutanixProviderName:
+			f.withNutanixClusterReconciler()
 		default:
 			f.logger.Info("Found unknown CAPI provider, ignoring", "providerName", p.ProviderName)
 		}
@@ -276,6 +292,76 @@ func (f *Factory) withSnowClusterReconciler() *Factory {
 	return f
 }
 
+func (f *Factory) withTinkerbellClusterReconciler() *Factory {
+	f.withCNIReconciler().withTracker().withIPValidator()
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.tinkerbellClusterReconciler != nil {
+			return nil
+		}
+
+		f.tinkerbellClusterReconciler = tinkerbellreconciler.New(
+			f.manager.GetClient(),
+			f.cniReconciler,
+			f.tracker,
+			f.ipValidator,
+		)
+		f.registryBuilder.Add(anywherev1.TinkerbellDatacenterKind, f.tinkerbellClusterReconciler)
+
+		return nil
+	})
+
+	return f
+}
+
+func (f *Factory) withCloudStackClusterReconciler() *Factory {
+	f.dependencyFactory.WithCloudStackValidatorRegistry(false)
+	f.withTracker().withCNIReconciler().withIPValidator()
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.cloudstackClusterReconciler != nil {
+			return nil
+		}
+
+		f.cloudstackClusterReconciler = cloudstackreconciler.New(
+			f.manager.GetClient(),
+			f.deps.CloudStackValidatorRegistry,
+			f.cniReconciler,
+			f.tracker,
+			f.ipValidator,
+		)
+		f.registryBuilder.Add(anywherev1.CloudStackDatacenterKind, f.cloudstackClusterReconciler)
+
+		return nil
+	})
+
+	return f
+}
+
+func (f *Factory) withNutanixClusterReconciler() *Factory {
+	f.dependencyFactory.WithNutanixValidatorRegistry()
+	f.withTracker().withCNIReconciler().withIPValidator()
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.nutanixClusterReconciler != nil {
+			return nil
+		}
+
+		f.nutanixClusterReconciler = nutanixreconciler.New(
+			f.manager.GetClient(),
+			f.deps.NutanixValidatorRegistry,
+			f.cniReconciler,
+			f.tracker,
+			f.ipValidator,
+		)
+		f.registryBuilder.Add(anywherev1.NutanixDatacenterKind, f.nutanixClusterReconciler)
+
+		return nil
+	})
+
+	return f
+}
+
 func (f *Factory) withCNIReconciler() *Factory {
 	f.dependencyFactory.WithCiliumTemplater()
 
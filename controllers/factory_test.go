@@ -78,6 +78,18 @@ func TestFactoryBuildClusterReconciler(t *testing.T) {
 			Type:         string(clusterctlv1.InfrastructureProviderType),
 			ProviderName: "snow",
 		},
+		{
+			Type:         string(clusterctlv1.InfrastructureProviderType),
+			ProviderName: "tinkerbell",
+		},
+		{
+			Type:         string(clusterctlv1.InfrastructureProviderType),
+			ProviderName: "cloudstack",
+		},
+		{
+			Type:         string(clusterctlv1.InfrastructureProviderType),
+			ProviderName: "nutanix",
+		},
 		{
 			Type:         string(clusterctlv1.InfrastructureProviderType),
 			ProviderName: "unknown-provider",
@@ -0,0 +1,225 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	neturl "net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	gogitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+	"github.com/aws/eks-anywhere/pkg/gitops/drift"
+)
+
+const (
+	// gitopsDriftCheckInterval is the minimum time between two gitops drift checks for the same cluster.
+	gitopsDriftCheckInterval = 5 * time.Minute
+	// maxDriftMessageDifferences is the maximum number of differences listed in the GitOpsSynced condition.
+	maxDriftMessageDifferences = 5
+)
+
+// Keys of the secret created by flux bootstrap with the credentials of the gitops repository.
+const (
+	fluxSecretIdentityKey   = "identity"
+	fluxSecretKnownHostsKey = "known_hosts"
+	fluxSecretUsernameKey   = "username"
+	fluxSecretPasswordKey   = "password"
+	fluxSecretCAKey         = "caFile"
+)
+
+var gitRepositoryGVK = schema.GroupVersionKind{Group: "source.toolkit.fluxcd.io", Version: "v1beta2", Kind: "GitRepository"}
+
+// GitClientBuilder builds a client that clones the git repository at repoURL in repoDir, authenticating
+// with auth and trusting caBundle, when set, in addition to the system certificates.
+type GitClientBuilder func(repoURL, repoDir string, auth transport.AuthMethod, caBundle []byte) drift.GitClient
+
+// WithGitOpsDriftCheck makes the ClusterReconciler compare the objects of clusters managed with Flux
+// with the cluster config committed to the gitops repository and set the GitOpsSynced condition.
+// The repository is cloned with a client built by newGitClient, using the credentials of the Flux
+// GitRepository.
+func WithGitOpsDriftCheck(newGitClient GitClientBuilder) ClusterReconcilerOption {
+	return func(r *ClusterReconciler) {
+		r.gitops = &gitopsDriftCheck{
+			client:       r.client,
+			newGitClient: newGitClient,
+			now:          time.Now,
+			lastChecked:  map[client.ObjectKey]time.Time{},
+		}
+	}
+}
+
+type gitopsDriftCheck struct {
+	client       client.Client
+	newGitClient GitClientBuilder
+	now          func() time.Time
+
+	mu          sync.Mutex
+	lastChecked map[client.ObjectKey]time.Time
+}
+
+// forget drops the last check time of a cluster that is deleted or no longer managed with Flux.
+func (c *gitopsDriftCheck) forget(key client.ObjectKey) {
+	c.mu.Lock()
+	delete(c.lastChecked, key)
+	c.mu.Unlock()
+}
+
+// reconcile sets the GitOpsSynced condition of a cluster managed with Flux, checking for drift at
+// most once every gitopsDriftCheckInterval. It returns when the next check is due, or 0 if the cluster
+// is not managed with Flux.
+func (c *gitopsDriftCheck) reconcile(ctx context.Context, log logr.Logger, clus *anywherev1.Cluster) (time.Duration, error) {
+	key := client.ObjectKeyFromObject(clus)
+	if clus.Spec.GitOpsRef == nil || clus.Spec.GitOpsRef.Kind != anywherev1.FluxConfigKind {
+		c.forget(key)
+		return 0, nil
+	}
+
+	now := c.now()
+
+	c.mu.Lock()
+	last, ok := c.lastChecked[key]
+	c.mu.Unlock()
+	if ok && now.Sub(last) < gitopsDriftCheckInterval {
+		return gitopsDriftCheckInterval - now.Sub(last), nil
+	}
+
+	fluxConfig := &anywherev1.FluxConfig{}
+	if err := c.client.Get(ctx, client.ObjectKey{Namespace: clus.Namespace, Name: clus.Spec.GitOpsRef.Name}, fluxConfig); err != nil {
+		return 0, fmt.Errorf("getting flux config for gitops drift check: %v", err)
+	}
+
+	log.Info("Checking gitops drift")
+	revision, diffs, err := c.detect(ctx, clus, fluxConfig)
+	switch {
+	case err != nil:
+		log.Info("Unable to check gitops drift", "error", err)
+		conditions.MarkUnknown(clus, anywherev1.GitOpsSyncedCondition, anywherev1.GitOpsDriftCheckFailedReason, "%s", err)
+	case len(diffs) > 0:
+		conditions.MarkFalse(clus, anywherev1.GitOpsSyncedCondition, anywherev1.GitOpsDriftDetectedReason,
+			clusterv1.ConditionSeverityWarning, "%s", driftMessage(revision, diffs))
+	default:
+		conditions.MarkTrue(clus, anywherev1.GitOpsSyncedCondition)
+	}
+
+	c.mu.Lock()
+	c.lastChecked[key] = now
+	c.mu.Unlock()
+
+	return gitopsDriftCheckInterval, nil
+}
+
+// detect clones the gitops repository synced by Flux and compares the cluster config committed to it
+// with the cluster objects. It returns the last revision fetched by Flux and the differences.
+func (c *gitopsDriftCheck) detect(ctx context.Context, clus *anywherev1.Cluster, fluxConfig *anywherev1.FluxConfig) (string, []drift.Difference, error) {
+	namespace := fluxConfig.Spec.SystemNamespace
+	repo := &unstructured.Unstructured{}
+	repo.SetGroupVersionKind(gitRepositoryGVK)
+	if err := c.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: namespace}, repo); err != nil {
+		return "", nil, fmt.Errorf("getting flux git repository: %v", err)
+	}
+
+	url, _, _ := unstructured.NestedString(repo.Object, "spec", "url")
+	secretName, _, _ := unstructured.NestedString(repo.Object, "spec", "secretRef", "name")
+	revision, _, _ := unstructured.NestedString(repo.Object, "status", "artifact", "revision")
+	if url == "" {
+		return "", nil, fmt.Errorf("flux git repository %s doesn't have a url", namespace)
+	}
+
+	workDir, err := os.MkdirTemp("", "eksa-gitops-drift-")
+	if err != nil {
+		return "", nil, fmt.Errorf("creating directory for the gitops repository: %v", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	auth, caBundle, err := c.gitAuth(ctx, namespace, secretName, url, workDir)
+	if err != nil {
+		return "", nil, err
+	}
+
+	repoDir := filepath.Join(workDir, "repository")
+	committed, err := drift.ReadCommittedConfig(ctx, c.newGitClient(url, repoDir, auth, caBundle), repoDir, fluxConfig, clus.Name)
+	if err != nil {
+		return "", nil, err
+	}
+
+	live, err := cluster.NewDefaultConfigClientBuilder().Build(ctx, clientutil.NewKubeClient(c.client), clus)
+	if err != nil {
+		return "", nil, fmt.Errorf("reading cluster config: %v", err)
+	}
+
+	diffs, err := drift.Detect(committed, live)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return revision, diffs, nil
+}
+
+// gitAuth reads the credentials and CA bundle used by Flux to clone the repository at repoURL from its
+// secret, either an SSH key or a username and password. The SSH known hosts are written to workDir.
+func (c *gitopsDriftCheck) gitAuth(ctx context.Context, namespace, secretName, repoURL, workDir string) (transport.AuthMethod, []byte, error) {
+	if secretName == "" {
+		return nil, nil, nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := c.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: secretName}, secret); err != nil {
+		return nil, nil, fmt.Errorf("getting flux git repository secret: %v", err)
+	}
+
+	caBundle := secret.Data[fluxSecretCAKey]
+	if identity, ok := secret.Data[fluxSecretIdentityKey]; ok {
+		user := "git"
+		if u, err := neturl.Parse(repoURL); err == nil && u.User != nil && u.User.Username() != "" {
+			user = u.User.Username()
+		}
+
+		auth, err := gogitssh.NewPublicKeys(user, identity, string(secret.Data[fluxSecretPasswordKey]))
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading flux git repository ssh key: %v", err)
+		}
+
+		knownHosts := filepath.Join(workDir, "known_hosts")
+		if err = os.WriteFile(knownHosts, secret.Data[fluxSecretKnownHostsKey], 0o600); err != nil {
+			return nil, nil, fmt.Errorf("writing flux git repository known hosts: %v", err)
+		}
+		if auth.HostKeyCallback, err = gogitssh.NewKnownHostsCallback(knownHosts); err != nil {
+			return nil, nil, fmt.Errorf("reading flux git repository known hosts: %v", err)
+		}
+		return auth, caBundle, nil
+	}
+
+	if username, ok := secret.Data[fluxSecretUsernameKey]; ok {
+		return &githttp.BasicAuth{Username: string(username), Password: string(secret.Data[fluxSecretPasswordKey])}, caBundle, nil
+	}
+
+	return nil, caBundle, nil
+}
+
+func driftMessage(revision string, diffs []drift.Difference) string {
+	m := make([]string, 0, maxDriftMessageDifferences)
+	for i, d := range diffs {
+		if i == maxDriftMessageDifferences {
+			m = append(m, fmt.Sprintf("and %d more", len(diffs)-i))
+			break
+		}
+		m = append(m, d.String())
+	}
+	return fmt.Sprintf("cluster differs from the gitops repository, last revision fetched by Flux %s: %s", revision, strings.Join(m, ", "))
+}
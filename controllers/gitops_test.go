@@ -0,0 +1,308 @@
+package controllers_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	gogitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/controllers"
+	"github.com/aws/eks-anywhere/controllers/mocks"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/gitops/drift"
+)
+
+const committedConfigFile = "clusters/my-cluster/my-cluster/eksa-system/eksa-cluster.yaml"
+
+// fakeGitRepository records the clients built by the drift check and writes its files in the
+// repository directory when they clone it.
+type fakeGitRepository struct {
+	files    map[string]string
+	clones   int
+	url      string
+	auth     transport.AuthMethod
+	caBundle []byte
+}
+
+func (f *fakeGitRepository) newClient(repoURL, repoDir string, auth transport.AuthMethod, caBundle []byte) drift.GitClient {
+	f.url, f.auth, f.caBundle = repoURL, auth, caBundle
+	return &fakeGitClient{repo: f, dir: repoDir}
+}
+
+type fakeGitClient struct {
+	repo *fakeGitRepository
+	dir  string
+}
+
+func (c *fakeGitClient) Clone(_ context.Context) error {
+	c.repo.clones++
+	for name, content := range c.repo.files {
+		p := filepath.Join(c.dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *fakeGitClient) Branch(_ string) error {
+	return nil
+}
+
+func TestClusterReconcilerReconcileGitOpsDrift(t *testing.T) {
+	tests := []struct {
+		name              string
+		committedVersion  anywherev1.KubernetesVersion
+		configFile        string
+		wantStatus        string
+		wantReason        string
+		wantMessageSubstr string
+	}{
+		{
+			name:             "synced",
+			committedVersion: "1.23",
+			configFile:       committedConfigFile,
+			wantStatus:       "True",
+		},
+		{
+			name:              "drifted",
+			committedVersion:  "1.22",
+			configFile:        committedConfigFile,
+			wantStatus:        "False",
+			wantReason:        anywherev1.GitOpsDriftDetectedReason,
+			wantMessageSubstr: "cluster differs from the gitops repository, last revision fetched by Flux main/abc: Cluster my-cluster spec.kubernetesVersion: committed 1.22, live 1.23",
+		},
+		{
+			name:              "config not committed",
+			committedVersion:  "1.23",
+			configFile:        "clusters/other/eksa-cluster.yaml",
+			wantStatus:        "Unknown",
+			wantReason:        anywherev1.GitOpsDriftCheckFailedReason,
+			wantMessageSubstr: "reading committed cluster config",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			cluster, datacenter, fluxConfig := gitopsClusterObjects()
+
+			committed := cluster.DeepCopy()
+			committed.Spec.KubernetesVersion = tt.committedVersion
+			committed.Spec.BundlesRef = nil
+			repo := &fakeGitRepository{files: map[string]string{tt.configFile: manifest(t, committed, datacenter, fluxConfig)}}
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "flux-system", Namespace: "flux-system"},
+				Data: map[string][]byte{
+					"username": []byte("jane"),
+					"password": []byte("token"),
+					"caFile":   []byte("ca"),
+				},
+			}
+
+			providerReconciler := mocks.NewMockProviderClusterReconciler(gomock.NewController(t))
+			providerReconciler.EXPECT().ReconcileWorkerNodes(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(cluster))
+			c := fake.NewClientBuilder().WithRuntimeObjects(cluster, datacenter, fluxConfig, secret, gitRepository("https://git.internal/fleet.git")).Build()
+
+			r := controllers.NewClusterReconciler(c, newRegistryMock(providerReconciler),
+				controllers.WithGitOpsDriftCheck(repo.newClient),
+			)
+			result, err := r.Reconcile(ctx, clusterRequest(cluster))
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(result.RequeueAfter).To(Equal(5 * time.Minute))
+			g.Expect(repo.url).To(Equal("https://git.internal/fleet.git"))
+			g.Expect(repo.auth).To(Equal(&githttp.BasicAuth{Username: "jane", Password: "token"}))
+			g.Expect(repo.caBundle).To(Equal([]byte("ca")))
+
+			api := &anywherev1.Cluster{}
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(cluster), api)).To(Succeed())
+			condition := conditions.Get(api, anywherev1.GitOpsSyncedCondition)
+			g.Expect(condition).NotTo(BeNil())
+			g.Expect(string(condition.Status)).To(Equal(tt.wantStatus))
+			g.Expect(condition.Reason).To(Equal(tt.wantReason))
+			g.Expect(condition.Message).To(ContainSubstring(tt.wantMessageSubstr))
+		})
+	}
+}
+
+func TestClusterReconcilerReconcileGitOpsDriftSSHAuth(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster, datacenter, fluxConfig := gitopsClusterObjects()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	g.Expect(err).NotTo(HaveOccurred())
+	publicKey, err := ssh.NewPublicKey(&key.PublicKey)
+	g.Expect(err).NotTo(HaveOccurred())
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "flux-system", Namespace: "flux-system"},
+		Data: map[string][]byte{
+			"identity":    pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+			"known_hosts": append([]byte("github.com "), ssh.MarshalAuthorizedKey(publicKey)...),
+		},
+	}
+
+	committed := cluster.DeepCopy()
+	committed.Spec.BundlesRef = nil
+	repo := &fakeGitRepository{files: map[string]string{committedConfigFile: manifest(t, committed, datacenter, fluxConfig)}}
+
+	providerReconciler := mocks.NewMockProviderClusterReconciler(gomock.NewController(t))
+	providerReconciler.EXPECT().ReconcileWorkerNodes(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(cluster))
+	c := fake.NewClientBuilder().WithRuntimeObjects(cluster, datacenter, fluxConfig, secret, gitRepository("ssh://git@github.com/janedoe/fleet")).Build()
+
+	r := controllers.NewClusterReconciler(c, newRegistryMock(providerReconciler),
+		controllers.WithGitOpsDriftCheck(repo.newClient),
+	)
+	_, err = r.Reconcile(ctx, clusterRequest(cluster))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo.auth).To(BeAssignableToTypeOf(&gogitssh.PublicKeys{}))
+	g.Expect(repo.auth.(*gogitssh.PublicKeys).User).To(Equal("git"))
+
+	api := &anywherev1.Cluster{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(cluster), api)).To(Succeed())
+	g.Expect(conditions.IsTrue(api, anywherev1.GitOpsSyncedCondition)).To(BeTrue())
+}
+
+func TestClusterReconcilerReconcileGitOpsDriftForgetsDeletedCluster(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster, datacenter, fluxConfig := gitopsClusterObjects()
+
+	committed := cluster.DeepCopy()
+	committed.Spec.BundlesRef = nil
+	repo := &fakeGitRepository{files: map[string]string{committedConfigFile: manifest(t, committed, datacenter, fluxConfig)}}
+
+	providerReconciler := mocks.NewMockProviderClusterReconciler(gomock.NewController(t))
+	providerReconciler.EXPECT().ReconcileWorkerNodes(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(cluster)).Times(2)
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "flux-system", Namespace: "flux-system"}}
+	c := fake.NewClientBuilder().WithRuntimeObjects(cluster.DeepCopy(), datacenter, fluxConfig, secret, gitRepository("https://git.internal/fleet.git")).Build()
+
+	r := controllers.NewClusterReconciler(c, newRegistryMock(providerReconciler),
+		controllers.WithGitOpsDriftCheck(repo.newClient),
+	)
+	_, err := r.Reconcile(ctx, clusterRequest(cluster))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo.clones).To(Equal(1))
+
+	api := &anywherev1.Cluster{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(cluster), api)).To(Succeed())
+	api.Finalizers = nil
+	g.Expect(c.Update(ctx, api)).To(Succeed())
+	g.Expect(c.Delete(ctx, api)).To(Succeed())
+	_, err = r.Reconcile(ctx, clusterRequest(cluster))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(c.Create(ctx, cluster.DeepCopy())).To(Succeed())
+	_, err = r.Reconcile(ctx, clusterRequest(cluster))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo.clones).To(Equal(2), "a cluster created again with the same name should be checked right away")
+}
+
+func TestClusterReconcilerReconcileGitOpsDriftNoFlux(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"},
+		Spec: anywherev1.ClusterSpec{
+			BundlesRef: &anywherev1.BundlesRef{Name: "my-bundles-ref"},
+		},
+	}
+	repo := &fakeGitRepository{}
+
+	providerReconciler := mocks.NewMockProviderClusterReconciler(gomock.NewController(t))
+	providerReconciler.EXPECT().ReconcileWorkerNodes(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(cluster))
+	c := fake.NewClientBuilder().WithRuntimeObjects(cluster).Build()
+
+	r := controllers.NewClusterReconciler(c, newRegistryMock(providerReconciler),
+		controllers.WithGitOpsDriftCheck(repo.newClient),
+	)
+	result, err := r.Reconcile(ctx, clusterRequest(cluster))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(BeZero())
+	g.Expect(repo.clones).To(BeZero())
+
+	api := &anywherev1.Cluster{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(cluster), api)).To(Succeed())
+	g.Expect(conditions.Get(api, anywherev1.GitOpsSyncedCondition)).To(BeNil())
+}
+
+// gitopsClusterObjects returns a self-managed docker cluster managed with Flux, its datacenter and flux config.
+func gitopsClusterObjects() (*anywherev1.Cluster, *anywherev1.DockerDatacenterConfig, *anywherev1.FluxConfig) {
+	cluster := &anywherev1.Cluster{
+		TypeMeta:   metav1.TypeMeta{Kind: anywherev1.ClusterKind, APIVersion: anywherev1.GroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"},
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion: "1.23",
+			BundlesRef:        &anywherev1.BundlesRef{Name: "my-bundles-ref"},
+			DatacenterRef:     anywherev1.Ref{Kind: anywherev1.DockerDatacenterKind, Name: "my-cluster"},
+			GitOpsRef:         &anywherev1.Ref{Kind: anywherev1.FluxConfigKind, Name: "my-flux"},
+		},
+	}
+	datacenter := &anywherev1.DockerDatacenterConfig{
+		TypeMeta:   metav1.TypeMeta{Kind: anywherev1.DockerDatacenterKind, APIVersion: anywherev1.GroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"},
+	}
+	fluxConfig := &anywherev1.FluxConfig{
+		TypeMeta:   metav1.TypeMeta{Kind: anywherev1.FluxConfigKind, APIVersion: anywherev1.GroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{Name: "my-flux", Namespace: "default"},
+		Spec: anywherev1.FluxConfigSpec{
+			Branch:            "main",
+			ClusterConfigPath: "clusters/my-cluster",
+			SystemNamespace:   "flux-system",
+		},
+	}
+	return cluster, datacenter, fluxConfig
+}
+
+// gitRepository returns the Flux GitRepository created by flux bootstrap for the repository at url.
+func gitRepository(url string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "source.toolkit.fluxcd.io/v1beta2",
+		"kind":       "GitRepository",
+		"metadata":   map[string]interface{}{"name": "flux-system", "namespace": "flux-system"},
+		"spec": map[string]interface{}{
+			"url":       url,
+			"secretRef": map[string]interface{}{"name": "flux-system"},
+		},
+		"status": map[string]interface{}{
+			"artifact": map[string]interface{}{"revision": "main/abc"},
+		},
+	}}
+}
+
+// manifest marshals the objects in a single multi-document yaml.
+func manifest(t *testing.T, objs ...interface{}) string {
+	t.Helper()
+	content := ""
+	for _, o := range objs {
+		b, err := yaml.Marshal(o)
+		if err != nil {
+			t.Fatal(err)
+		}
+		content += string(b) + "---\n"
+	}
+	return content
+}
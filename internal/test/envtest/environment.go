@@ -11,6 +11,7 @@ import (
 
 	eksdv1alpha1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
 	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
+	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -40,7 +41,9 @@ const (
 	capiPackage         = "sigs.k8s.io/cluster-api"
 	capdPackage         = "sigs.k8s.io/cluster-api/test"
 	capvPackage         = "sigs.k8s.io/cluster-api-provider-vsphere"
+	capcPackage         = "sigs.k8s.io/cluster-api-provider-cloudstack"
 	etcdProviderPackage = "github.com/aws/etcdadm-controller"
+	tinkPackage         = "github.com/tinkerbell/tink"
 )
 
 func init() {
@@ -60,6 +63,7 @@ func init() {
 	utilruntime.Must(eksdv1alpha1.AddToScheme(scheme.Scheme))
 	utilruntime.Must(snowv1.AddToScheme(scheme.Scheme))
 	utilruntime.Must(addonsv1.AddToScheme(scheme.Scheme))
+	utilruntime.Must(tinkv1alpha1.AddToScheme(scheme.Scheme))
 }
 
 var packages = []moduleWithCRD{
@@ -68,10 +72,12 @@ var packages = []moduleWithCRD{
 		withAdditionalCustomCRDPath("controlplane/kubeadm/config/crd/bases"),
 	),
 	mustBuildModuleWithCRDs(capvPackage),
+	mustBuildModuleWithCRDs(capcPackage),
 	mustBuildModuleWithCRDs(capdPackage,
 		withMainCustomCRDPath("infrastructure/docker/config/crd/bases"),
 	),
 	mustBuildModuleWithCRDs(etcdProviderPackage),
+	mustBuildModuleWithCRDs(tinkPackage),
 }
 
 type Environment struct {
@@ -125,6 +131,8 @@ func newEnvironment(ctx context.Context) (*Environment, error) {
 		filepath.Join(root, "config", "crd", "bases"),
 		filepath.Join(currentDir, "config", "eks-d-crds.yaml"),
 		filepath.Join(currentDir, "config", "snow-crds.yaml"),
+		filepath.Join(currentDir, "config", "tinkerbell-crds.yaml"),
+		filepath.Join(currentDir, "config", "nutanix-crds.yaml"),
 	)
 	extraCRDPaths, err := getPathsToPackagesCRDs(root, packages...)
 	if err != nil {
@@ -4,11 +4,13 @@ import (
 	"context"
 	"flag"
 	"os"
+	"time"
 
 	eksdv1alpha1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
 	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
 	"github.com/go-logr/logr"
 	"github.com/spf13/pflag"
+	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -29,8 +31,11 @@ import (
 
 	"github.com/aws/eks-anywhere/controllers"
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/certificates"
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/features"
+	"github.com/aws/eks-anywhere/pkg/gitops/drift"
+	"github.com/aws/eks-anywhere/pkg/policy"
 	snowv1 "github.com/aws/eks-anywhere/pkg/providers/snow/api/v1beta1"
 	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
@@ -54,6 +59,7 @@ func init() {
 	utilruntime.Must(eksdv1alpha1.AddToScheme(scheme))
 	utilruntime.Must(snowv1.AddToScheme(scheme))
 	utilruntime.Must(addonsv1.AddToScheme(scheme))
+	utilruntime.Must(tinkv1alpha1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -63,6 +69,10 @@ type config struct {
 	probeAddr            string
 	gates                []string
 	logging              *logsv1.LoggingConfiguration
+	// certificateExpiryWindow is how long before expiring certificates are reported in the cluster status.
+	certificateExpiryWindow time.Duration
+	// gitopsDriftCheck enables the comparison of Flux managed clusters with their gitops repository.
+	gitopsDriftCheck bool
 }
 
 func newConfig() *config {
@@ -84,6 +94,10 @@ func initFlags(fs *pflag.FlagSet, config *config) {
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
 	fs.StringSliceVar(&config.gates, "feature-gates", []string{}, "A set of key=value pairs that describe feature gates for alpha/experimental features. ")
+	fs.DurationVar(&config.certificateExpiryWindow, "certificate-expiry-window", certificates.DefaultExpiryWindow,
+		"Time before expiring that cluster certificates are reported in the CertificatesValid condition. Set to 0 to disable the check.")
+	fs.BoolVar(&config.gitopsDriftCheck, "gitops-drift-check", false,
+		"Clone the gitops repository of clusters managed with Flux, compare it with the cluster config and report drift in the GitOpsSynced condition.")
 }
 
 func main() {
@@ -123,7 +137,7 @@ func main() {
 	// Setup the context that's going to be used in controllers and for the manager.
 	ctx := ctrl.SetupSignalHandler()
 
-	setupReconcilers(ctx, setupLog, mgr)
+	setupReconcilers(ctx, setupLog, mgr, config)
 	setupWebhooks(setupLog, mgr)
 	setupChecks(setupLog, mgr)
 	//+kubebuilder:scaffold:builder
@@ -135,16 +149,16 @@ func main() {
 	}
 }
 
-func setupReconcilers(ctx context.Context, setupLog logr.Logger, mgr ctrl.Manager) {
+func setupReconcilers(ctx context.Context, setupLog logr.Logger, mgr ctrl.Manager, config *config) {
 	if features.IsActive(features.FullLifecycleAPI()) {
-		setupFullLifecycleReconcilers(ctx, setupLog, mgr)
+		setupFullLifecycleReconcilers(ctx, setupLog, mgr, config)
 	} else {
 		setupLog.Info("Setting up legacy cluster controller")
 		setupLegacyClusterReconciler(setupLog, mgr)
 	}
 }
 
-func setupFullLifecycleReconcilers(ctx context.Context, setupLog logr.Logger, mgr ctrl.Manager) {
+func setupFullLifecycleReconcilers(ctx context.Context, setupLog logr.Logger, mgr ctrl.Manager, config *config) {
 	setupLog.Info("Reading CAPI providers")
 	providers, err := clusterapi.GetProviders(ctx, mgr.GetAPIReader())
 	if err != nil {
@@ -152,8 +166,17 @@ func setupFullLifecycleReconcilers(ctx context.Context, setupLog logr.Logger, mg
 		os.Exit(1)
 	}
 
+	var clusterReconcilerOpts []controllers.ClusterReconcilerOption
+	if config.certificateExpiryWindow > 0 {
+		checker := certificates.NewChecker(certificates.NewTLSProber())
+		clusterReconcilerOpts = append(clusterReconcilerOpts, controllers.WithCertificateExpiryCheck(checker, config.certificateExpiryWindow))
+	}
+	if config.gitopsDriftCheck {
+		clusterReconcilerOpts = append(clusterReconcilerOpts, controllers.WithGitOpsDriftCheck(drift.NewGitClient))
+	}
+
 	factory := controllers.NewFactory(ctrl.Log, mgr).
-		WithClusterReconciler(providers).
+		WithClusterReconciler(providers, clusterReconcilerOpts...).
 		WithVSphereDatacenterReconciler().
 		WithSnowMachineConfigReconciler()
 
@@ -194,14 +217,19 @@ func setupLegacyClusterReconciler(setupLog logr.Logger, mgr ctrl.Manager) {
 }
 
 func setupWebhooks(setupLog logr.Logger, mgr ctrl.Manager) {
-	setupCoreWebhooks(setupLog, mgr)
-	setupVSphereWebhooks(setupLog, mgr)
-	setupCloudstackWebhooks(setupLog, mgr)
-	setupSnowWebhooks(setupLog, mgr)
+	// The API reader avoids caching every ConfigMap in the cluster just to read the policies.
+	policies := policy.NewClusterValidator(mgr.GetAPIReader())
+	setupCoreWebhooks(setupLog, mgr, policies)
+	setupVSphereWebhooks(setupLog, mgr, policies)
+	setupCloudstackWebhooks(setupLog, mgr, policies)
+	setupSnowWebhooks(setupLog, mgr, policies)
+	setupNutanixWebhooks(setupLog, mgr, policies)
+	setupTinkerbellWebhooks(setupLog, mgr, policies)
+	setupDockerWebhooks(setupLog, mgr, policies)
 }
 
-func setupCoreWebhooks(setupLog logr.Logger, mgr ctrl.Manager) {
-	if err := (&anywherev1.Cluster{}).SetupWebhookWithManager(mgr); err != nil {
+func setupCoreWebhooks(setupLog logr.Logger, mgr ctrl.Manager, policies anywherev1.PolicyValidator) {
+	if err := (&anywherev1.Cluster{}).SetupWebhookWithManager(mgr, policies); err != nil {
 		setupLog.Error(err, "unable to create webhook", WEBHOOK, anywherev1.ClusterKind)
 		os.Exit(1)
 	}
@@ -223,39 +251,68 @@ func setupCoreWebhooks(setupLog logr.Logger, mgr ctrl.Manager) {
 	}
 }
 
-func setupVSphereWebhooks(setupLog logr.Logger, mgr ctrl.Manager) {
-	if err := (&anywherev1.VSphereDatacenterConfig{}).SetupWebhookWithManager(mgr); err != nil {
+func setupVSphereWebhooks(setupLog logr.Logger, mgr ctrl.Manager, policies anywherev1.PolicyValidator) {
+	if err := (&anywherev1.VSphereDatacenterConfig{}).SetupWebhookWithManager(mgr, policies); err != nil {
 		setupLog.Error(err, "unable to create webhook", WEBHOOK, anywherev1.VSphereDatacenterKind)
 		os.Exit(1)
 	}
-	if err := (&anywherev1.VSphereMachineConfig{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&anywherev1.VSphereMachineConfig{}).SetupWebhookWithManager(mgr, policies); err != nil {
 		setupLog.Error(err, "unable to create webhook", WEBHOOK, anywherev1.VSphereMachineConfigKind)
 		os.Exit(1)
 	}
 }
 
-func setupCloudstackWebhooks(setupLog logr.Logger, mgr ctrl.Manager) {
-	if err := (&anywherev1.CloudStackDatacenterConfig{}).SetupWebhookWithManager(mgr); err != nil {
+func setupCloudstackWebhooks(setupLog logr.Logger, mgr ctrl.Manager, policies anywherev1.PolicyValidator) {
+	if err := (&anywherev1.CloudStackDatacenterConfig{}).SetupWebhookWithManager(mgr, policies); err != nil {
 		setupLog.Error(err, "unable to create webhook", WEBHOOK, anywherev1.CloudStackDatacenterKind)
 		os.Exit(1)
 	}
-	if err := (&anywherev1.CloudStackMachineConfig{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&anywherev1.CloudStackMachineConfig{}).SetupWebhookWithManager(mgr, policies); err != nil {
 		setupLog.Error(err, "unable to create webhook", WEBHOOK, anywherev1.CloudStackMachineConfigKind)
 		os.Exit(1)
 	}
 }
 
-func setupSnowWebhooks(setupLog logr.Logger, mgr ctrl.Manager) {
-	if err := (&anywherev1.SnowMachineConfig{}).SetupWebhookWithManager(mgr); err != nil {
+func setupSnowWebhooks(setupLog logr.Logger, mgr ctrl.Manager, policies anywherev1.PolicyValidator) {
+	if err := (&anywherev1.SnowMachineConfig{}).SetupWebhookWithManager(mgr, policies); err != nil {
 		setupLog.Error(err, "unable to create webhook", WEBHOOK, anywherev1.SnowMachineConfigKind)
 		os.Exit(1)
 	}
-	if err := (&anywherev1.SnowDatacenterConfig{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&anywherev1.SnowDatacenterConfig{}).SetupWebhookWithManager(mgr, policies); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "SnowDatacenterConfig")
 		os.Exit(1)
 	}
 }
 
+func setupNutanixWebhooks(setupLog logr.Logger, mgr ctrl.Manager, policies anywherev1.PolicyValidator) {
+	if err := (&anywherev1.NutanixDatacenterConfig{}).SetupWebhookWithManager(mgr, policies); err != nil {
+		setupLog.Error(err, "unable to create webhook", WEBHOOK, anywherev1.NutanixDatacenterKind)
+		os.Exit(1)
+	}
+	if err := (&anywherev1.NutanixMachineConfig{}).SetupWebhookWithManager(mgr, policies); err != nil {
+		setupLog.Error(err, "unable to create webhook", WEBHOOK, anywherev1.NutanixMachineConfigKind)
+		os.Exit(1)
+	}
+}
+
+func setupTinkerbellWebhooks(setupLog logr.Logger, mgr ctrl.Manager, policies anywherev1.PolicyValidator) {
+	if err := (&anywherev1.TinkerbellDatacenterConfig{}).SetupWebhookWithManager(mgr, policies); err != nil {
+		setupLog.Error(err, "unable to create webhook", WEBHOOK, anywherev1.TinkerbellDatacenterKind)
+		os.Exit(1)
+	}
+	if err := (&anywherev1.TinkerbellMachineConfig{}).SetupWebhookWithManager(mgr, policies); err != nil {
+		setupLog.Error(err, "unable to create webhook", WEBHOOK, anywherev1.TinkerbellMachineConfigKind)
+		os.Exit(1)
+	}
+}
+
+func setupDockerWebhooks(setupLog logr.Logger, mgr ctrl.Manager, policies anywherev1.PolicyValidator) {
+	if err := (&anywherev1.DockerDatacenterConfig{}).SetupWebhookWithManager(mgr, policies); err != nil {
+		setupLog.Error(err, "unable to create webhook", WEBHOOK, anywherev1.DockerDatacenterKind)
+		os.Exit(1)
+	}
+}
+
 func setupChecks(setupLog logr.Logger, mgr ctrl.Manager) {
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
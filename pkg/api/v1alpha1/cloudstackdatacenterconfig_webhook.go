@@ -32,10 +32,9 @@ import (
 // log is for logging in this package.
 var cloudstackdatacenterconfiglog = logf.Log.WithName("cloudstackdatacenterconfig-resource")
 
-func (r *CloudStackDatacenterConfig) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewWebhookManagedBy(mgr).
-		For(r).
-		Complete()
+// SetupWebhookWithManager registers the CloudStackDatacenterConfig webhooks, checking the CloudStackDatacenterConfig objects against policies.
+func (r *CloudStackDatacenterConfig) SetupWebhookWithManager(mgr ctrl.Manager, policies PolicyValidator) error {
+	return setupWebhookWithPolicies(mgr, r, CloudStackDatacenterKind, policies)
 }
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
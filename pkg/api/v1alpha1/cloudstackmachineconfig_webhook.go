@@ -14,10 +14,9 @@ import (
 // log is for logging in this package.
 var cloudstackmachineconfiglog = logf.Log.WithName("cloudstackmachineconfig-resource")
 
-func (r *CloudStackMachineConfig) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewWebhookManagedBy(mgr).
-		For(r).
-		Complete()
+// SetupWebhookWithManager registers the CloudStackMachineConfig webhooks, checking the CloudStackMachineConfig objects against policies.
+func (r *CloudStackMachineConfig) SetupWebhookWithManager(mgr ctrl.Manager, policies PolicyValidator) error {
+	return setupWebhookWithPolicies(mgr, r, CloudStackMachineConfigKind, policies)
 }
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -8,13 +8,18 @@ import (
 	"net"
 	"net/url"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
 	"sigs.k8s.io/yaml"
 
 	"github.com/aws/eks-anywhere/pkg/constants"
@@ -199,6 +204,11 @@ var clusterConfigValidations = []func(*Cluster) error{
 	validatePodIAMConfig,
 	validateCPUpgradeRolloutStrategy,
 	validateControlPlaneLabels,
+	validateKubeletConfigurations,
+	validateControlPlaneComponentExtraArgs,
+	validateAuditPolicy,
+	validateEncryptionConfiguration,
+	validatePodSecurity,
 }
 
 // GetClusterConfig parses a Cluster object from a multiobject yaml file in disk
@@ -256,6 +266,18 @@ func ValidateClusterConfigContent(clusterConfig *Cluster) error {
 	return nil
 }
 
+// ClusterConfigContentErrors runs the same validations as ValidateClusterConfigContent
+// but returns all the errors found instead of stopping at the first one.
+func ClusterConfigContentErrors(clusterConfig *Cluster) []error {
+	var errs []error
+	for _, v := range clusterConfigValidations {
+		if err := v(clusterConfig); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
 // ParseClusterConfig unmarshalls an API object implementing the KindAccessor interface
 // from a multiobject yaml file in disk. It doesn't set defaults nor validates the object.
 func ParseClusterConfig(fileName string, clusterConfig KindAccessor) error {
@@ -514,6 +536,377 @@ func validateNodeLabels(labels map[string]string, fldPath *field.Path) error {
 	return nil
 }
 
+var (
+	kubeletReservedResources = map[string]struct{}{"cpu": {}, "memory": {}, "ephemeral-storage": {}, "pid": {}}
+	kubeletEvictionSignals   = map[string]struct{}{
+		"memory.available":   {},
+		"nodefs.available":   {},
+		"nodefs.inodesFree":  {},
+		"imagefs.available":  {},
+		"imagefs.inodesFree": {},
+		"pid.available":      {},
+	}
+	kubeletTopologyManagerPolicies = map[string]struct{}{"none": {}, "best-effort": {}, "restricted": {}, "single-numa-node": {}}
+	kubeletCPUManagerPolicies      = map[string]struct{}{"none": {}, "static": {}}
+)
+
+func validateKubeletConfigurations(clusterConfig *Cluster) error {
+	if err := validateKubeletConfiguration(clusterConfig.Spec.ControlPlaneConfiguration.KubeletConfiguration); err != nil {
+		return fmt.Errorf("kubeletConfiguration for control plane not valid: %v", err)
+	}
+	for _, workerNodeGroupConfig := range clusterConfig.Spec.WorkerNodeGroupConfigurations {
+		if err := validateKubeletConfiguration(workerNodeGroupConfig.KubeletConfiguration); err != nil {
+			return fmt.Errorf("kubeletConfiguration for worker node group %v not valid: %v", workerNodeGroupConfig.Name, err)
+		}
+	}
+	return nil
+}
+
+func validateKubeletConfiguration(c *KubeletConfiguration) error {
+	if c == nil {
+		return nil
+	}
+	if c.MaxPods != nil && *c.MaxPods <= 0 {
+		return errors.New("maxPods must be greater than 0")
+	}
+	if err := validateKubeletReservedResources("systemReserved", c.SystemReserved); err != nil {
+		return err
+	}
+	if err := validateKubeletReservedResources("kubeReserved", c.KubeReserved); err != nil {
+		return err
+	}
+	if err := validateKubeletEvictionThresholds("evictionHard", c.EvictionHard); err != nil {
+		return err
+	}
+	if err := validateKubeletEvictionThresholds("evictionSoft", c.EvictionSoft); err != nil {
+		return err
+	}
+	for signal, gracePeriod := range c.EvictionSoftGracePeriod {
+		if _, ok := c.EvictionSoft[signal]; !ok {
+			return fmt.Errorf("evictionSoftGracePeriod %s has no matching evictionSoft threshold", signal)
+		}
+		if d, err := time.ParseDuration(gracePeriod); err != nil || d < 0 {
+			return fmt.Errorf("evictionSoftGracePeriod %s must be a positive duration, got %s", signal, gracePeriod)
+		}
+	}
+	for signal := range c.EvictionSoft {
+		if _, ok := c.EvictionSoftGracePeriod[signal]; !ok {
+			return fmt.Errorf("evictionSoft %s requires a grace period in evictionSoftGracePeriod", signal)
+		}
+	}
+	if err := validateKubeletPercent("imageGCHighThresholdPercent", c.ImageGCHighThresholdPercent); err != nil {
+		return err
+	}
+	if err := validateKubeletPercent("imageGCLowThresholdPercent", c.ImageGCLowThresholdPercent); err != nil {
+		return err
+	}
+	if c.ImageGCHighThresholdPercent != nil && c.ImageGCLowThresholdPercent != nil && *c.ImageGCLowThresholdPercent >= *c.ImageGCHighThresholdPercent {
+		return errors.New("imageGCLowThresholdPercent must be lower than imageGCHighThresholdPercent")
+	}
+	if _, ok := kubeletTopologyManagerPolicies[c.TopologyManagerPolicy]; c.TopologyManagerPolicy != "" && !ok {
+		return fmt.Errorf("topologyManagerPolicy %s not supported, must be one of none, best-effort, restricted, single-numa-node", c.TopologyManagerPolicy)
+	}
+	if _, ok := kubeletCPUManagerPolicies[c.CPUManagerPolicy]; c.CPUManagerPolicy != "" && !ok {
+		return fmt.Errorf("cpuManagerPolicy %s not supported, must be one of none, static", c.CPUManagerPolicy)
+	}
+	if c.CPUManagerPolicy == "static" && c.SystemReserved["cpu"] == "" && c.KubeReserved["cpu"] == "" {
+		return errors.New("cpuManagerPolicy static requires cpu to be reserved in systemReserved or kubeReserved")
+	}
+	return nil
+}
+
+func validateKubeletReservedResources(name string, reserved map[string]string) error {
+	for r, q := range reserved {
+		if _, ok := kubeletReservedResources[r]; !ok {
+			return fmt.Errorf("%s resource %s not supported, must be one of cpu, memory, ephemeral-storage, pid", name, r)
+		}
+		if _, err := resource.ParseQuantity(q); err != nil {
+			return fmt.Errorf("%s %s quantity %s not valid: %v", name, r, q, err)
+		}
+	}
+	return nil
+}
+
+func validateKubeletEvictionThresholds(name string, thresholds map[string]string) error {
+	for signal, threshold := range thresholds {
+		if _, ok := kubeletEvictionSignals[signal]; !ok {
+			return fmt.Errorf("%s signal %s not supported", name, signal)
+		}
+		if strings.HasSuffix(threshold, "%") {
+			p, err := strconv.ParseFloat(strings.TrimSuffix(threshold, "%"), 64)
+			if err != nil || p < 0 || p > 100 {
+				return fmt.Errorf("%s %s percentage %s not valid", name, signal, threshold)
+			}
+			continue
+		}
+		if _, err := resource.ParseQuantity(threshold); err != nil {
+			return fmt.Errorf("%s %s quantity %s not valid: %v", name, signal, threshold, err)
+		}
+	}
+	return nil
+}
+
+func validateKubeletPercent(name string, percent *int) error {
+	if percent != nil && (*percent < 0 || *percent > 100) {
+		return fmt.Errorf("%s must be between 0 and 100", name)
+	}
+	return nil
+}
+
+var (
+	// apiServerManagedFlags are the kube-apiserver flags set by EKS Anywhere, which can't be overridden in apiServerExtraArgs.
+	apiServerManagedFlags = map[string]struct{}{
+		"audit-log-maxage":                         {},
+		"audit-log-maxbackup":                      {},
+		"audit-log-maxsize":                        {},
+		"audit-log-path":                           {},
+		"audit-policy-file":                        {},
+		"authentication-token-webhook-config-file": {},
+		"cloud-provider":                           {},
+		"oidc-client-id":                           {},
+		"oidc-groups-claim":                        {},
+		"oidc-groups-prefix":                       {},
+		"oidc-issuer-url":                          {},
+		"oidc-required-claim":                      {},
+		"oidc-username-claim":                      {},
+		"oidc-username-prefix":                     {},
+		"profiling":                                {},
+		"service-account-issuer":                   {},
+		"tls-cipher-suites":                        {},
+	}
+	// apiServerAllowedFlags are the kube-apiserver flags supported in apiServerExtraArgs.
+	apiServerAllowedFlags = map[string]struct{}{
+		"default-not-ready-toleration-seconds":   {},
+		"default-unreachable-toleration-seconds": {},
+		"disable-admission-plugins":              {},
+		"enable-admission-plugins":               {},
+		"event-ttl":                              {},
+		"feature-gates":                          {},
+		"goaway-chance":                          {},
+		"max-mutating-requests-inflight":         {},
+		"max-requests-inflight":                  {},
+		"min-request-timeout":                    {},
+		"request-timeout":                        {},
+		"service-node-port-range":                {},
+		"watch-cache-sizes":                      {},
+	}
+	// controllerManagerManagedFlags are the kube-controller-manager flags set by EKS Anywhere,
+	// which can't be overridden in controllerManagerExtraArgs.
+	controllerManagerManagedFlags = map[string]struct{}{
+		"cloud-provider":              {},
+		"enable-hostpath-provisioner": {},
+		"node-cidr-mask-size":         {},
+		"profiling":                   {},
+		"tls-cipher-suites":           {},
+	}
+	// controllerManagerAllowedFlags are the kube-controller-manager flags supported in controllerManagerExtraArgs.
+	controllerManagerAllowedFlags = map[string]struct{}{
+		"concurrent-deployment-syncs":           {},
+		"concurrent-namespace-syncs":            {},
+		"concurrent-replicaset-syncs":           {},
+		"concurrent-service-syncs":              {},
+		"feature-gates":                         {},
+		"horizontal-pod-autoscaler-sync-period": {},
+		"kube-api-burst":                        {},
+		"kube-api-qps":                          {},
+		"node-monitor-grace-period":             {},
+		"node-monitor-period":                   {},
+		"node-startup-grace-period":             {},
+		"terminated-pod-gc-threshold":           {},
+	}
+	// schedulerManagedFlags are the kube-scheduler flags set by EKS Anywhere, which can't be overridden in schedulerExtraArgs.
+	schedulerManagedFlags = map[string]struct{}{
+		"profiling":         {},
+		"tls-cipher-suites": {},
+	}
+	// schedulerAllowedFlags are the kube-scheduler flags supported in schedulerExtraArgs.
+	schedulerAllowedFlags = map[string]struct{}{
+		"feature-gates":               {},
+		"leader-elect-lease-duration": {},
+		"leader-elect-renew-deadline": {},
+		"leader-elect-retry-period":   {},
+	}
+)
+
+func validateControlPlaneComponentExtraArgs(clusterConfig *Cluster) error {
+	cp := clusterConfig.Spec.ControlPlaneConfiguration
+	if err := validateComponentExtraArgs("apiServerExtraArgs", cp.APIServerExtraArgs, apiServerManagedFlags, apiServerAllowedFlags); err != nil {
+		return err
+	}
+	if err := validateComponentExtraArgs("controllerManagerExtraArgs", cp.ControllerManagerExtraArgs, controllerManagerManagedFlags, controllerManagerAllowedFlags); err != nil {
+		return err
+	}
+	return validateComponentExtraArgs("schedulerExtraArgs", cp.SchedulerExtraArgs, schedulerManagedFlags, schedulerAllowedFlags)
+}
+
+func validateComponentExtraArgs(name string, args map[string]string, managed, allowed map[string]struct{}) error {
+	for _, flag := range sortedKeys(args) {
+		if _, ok := managed[flag]; ok {
+			return fmt.Errorf("controlPlaneConfiguration.%s flag %s is managed by EKS Anywhere and can't be overridden", name, flag)
+		}
+		if _, ok := allowed[flag]; !ok {
+			return fmt.Errorf("controlPlaneConfiguration.%s flag %s is not supported, supported flags are %s", name, flag, strings.Join(sortedKeys(allowed), ", "))
+		}
+		if args[flag] == "" {
+			return fmt.Errorf("controlPlaneConfiguration.%s flag %s can't have an empty value", name, flag)
+		}
+	}
+	return nil
+}
+
+func validateAuditPolicy(clusterConfig *Cluster) error {
+	auditPolicy := clusterConfig.Spec.AuditPolicy
+	if auditPolicy == nil {
+		return nil
+	}
+	if auditPolicy.ConfigMapRef != nil && auditPolicy.Inline != "" {
+		return errors.New("auditPolicy.configMapRef and auditPolicy.inline are mutually exclusive")
+	}
+	if auditPolicy.ConfigMapRef != nil && auditPolicy.ConfigMapRef.Name == "" {
+		return errors.New("auditPolicy.configMapRef.name is required")
+	}
+	if auditPolicy.Inline != "" {
+		if err := ValidateAuditPolicy(auditPolicy.Inline); err != nil {
+			return fmt.Errorf("auditPolicy.inline: %v", err)
+		}
+	}
+	if auditPolicy.LogMaxAge != nil && *auditPolicy.LogMaxAge < 0 {
+		return errors.New("auditPolicy.logMaxAge cannot be a negative number")
+	}
+	if auditPolicy.LogMaxBackup != nil && *auditPolicy.LogMaxBackup < 0 {
+		return errors.New("auditPolicy.logMaxBackup cannot be a negative number")
+	}
+	if auditPolicy.LogMaxSize != nil && *auditPolicy.LogMaxSize < 0 {
+		return errors.New("auditPolicy.logMaxSize cannot be a negative number")
+	}
+	return nil
+}
+
+// ValidateAuditPolicy validates that policy is an audit.k8s.io/v1 Policy yaml the kube-apiserver can load.
+func ValidateAuditPolicy(policy string) error {
+	p := &auditv1.Policy{}
+	if err := yaml.UnmarshalStrict([]byte(policy), p); err != nil {
+		return fmt.Errorf("invalid audit policy: %v", err)
+	}
+	if gvk := p.GroupVersionKind(); gvk != auditv1.SchemeGroupVersion.WithKind("Policy") {
+		return fmt.Errorf("invalid audit policy: apiVersion and kind must be %s and Policy", auditv1.SchemeGroupVersion)
+	}
+	return nil
+}
+
+func validateEncryptionConfiguration(clusterConfig *Cluster) error {
+	config := clusterConfig.Spec.EncryptionConfiguration
+	if config == nil {
+		return nil
+	}
+	for _, r := range config.Resources {
+		if r == "" {
+			return errors.New("encryptionConfiguration.resources cannot contain an empty resource")
+		}
+	}
+	if len(config.Providers) == 0 {
+		return errors.New("encryptionConfiguration.providers must contain at least one provider")
+	}
+
+	types := map[EncryptionProviderType]bool{}
+	kmsNames := map[string]bool{}
+	for i, p := range config.Providers {
+		switch p.Type {
+		case AESCBCEncryptionProvider, SecretboxEncryptionProvider:
+			if types[p.Type] {
+				return fmt.Errorf("encryptionConfiguration.providers can only contain one %s provider", p.Type)
+			}
+			if p.KMS != nil {
+				return fmt.Errorf("encryptionConfiguration.providers[%d].kms can only be set for kms providers", i)
+			}
+		case KMSEncryptionProvider:
+			if err := validateKMSConfiguration(p.KMS); err != nil {
+				return fmt.Errorf("encryptionConfiguration.providers[%d].kms: %v", i, err)
+			}
+			if kmsNames[p.KMS.Name] {
+				return fmt.Errorf("encryptionConfiguration.providers[%d].kms.name %s is duplicated", i, p.KMS.Name)
+			}
+			kmsNames[p.KMS.Name] = true
+		default:
+			return fmt.Errorf("encryptionConfiguration.providers[%d].type %s is not supported, use aescbc, secretbox or kms", i, p.Type)
+		}
+		types[p.Type] = true
+	}
+
+	return nil
+}
+
+func validateKMSConfiguration(kms *KMSConfiguration) error {
+	if kms == nil {
+		return errors.New("is required for kms providers")
+	}
+	if kms.APIVersion != "" && kms.APIVersion != "v1" && kms.APIVersion != "v2" {
+		return fmt.Errorf("apiVersion %s is not supported, use v1 or v2", kms.APIVersion)
+	}
+	if kms.Name == "" {
+		return errors.New("name is required")
+	}
+	if !strings.HasPrefix(kms.Endpoint, "unix:///") {
+		return errors.New("endpoint must be a unix socket path with the unix:/// prefix")
+	}
+	if kms.CacheSize != nil && kms.APIVersion == "v2" {
+		return errors.New("cacheSize is only supported by v1 plugins")
+	}
+	if kms.Timeout != nil && kms.Timeout.Duration <= 0 {
+		return errors.New("timeout must be a positive duration")
+	}
+	return nil
+}
+
+var podSecurityVersionRegex = regexp.MustCompile(`^v1\.[0-9]+$`)
+
+func validatePodSecurity(clusterConfig *Cluster) error {
+	podSecurity := clusterConfig.Spec.PodSecurity
+	if podSecurity == nil {
+		return nil
+	}
+	if clusterConfig.Spec.KubernetesVersion < Kube123 {
+		return fmt.Errorf("podSecurity is only supported for kubernetes versions %s and above", Kube123)
+	}
+
+	modes := []struct {
+		name    string
+		level   PodSecurityLevel
+		version string
+	}{
+		{name: "enforce", level: podSecurity.Enforce, version: podSecurity.EnforceVersion},
+		{name: "audit", level: podSecurity.Audit, version: podSecurity.AuditVersion},
+		{name: "warn", level: podSecurity.Warn, version: podSecurity.WarnVersion},
+	}
+	for _, m := range modes {
+		switch m.level {
+		case "", PrivilegedPodSecurityLevel, BaselinePodSecurityLevel, RestrictedPodSecurityLevel:
+		default:
+			return fmt.Errorf("podSecurity.%s %s is not supported, use privileged, baseline or restricted", m.name, m.level)
+		}
+		if m.version != "" && m.version != DefaultPodSecurityVersion && !podSecurityVersionRegex.MatchString(m.version) {
+			return fmt.Errorf("podSecurity.%sVersion %s is invalid, use latest or a kubernetes minor version like v1.24", m.name, m.version)
+		}
+	}
+
+	for _, ns := range podSecurity.ExemptNamespaces {
+		if errs := utilvalidation.IsDNS1123Label(ns); len(errs) > 0 {
+			return fmt.Errorf("podSecurity.exemptNamespaces %s is not a valid namespace: %s", ns, strings.Join(errs, ", "))
+		}
+	}
+
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func validateEtcdReplicas(clusterConfig *Cluster) error {
 	if clusterConfig.Spec.ExternalEtcdConfiguration == nil {
 		return nil
@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/api/core/v1"
@@ -2825,6 +2826,504 @@ func TestValidateMDUpgradeRolloutStrategy(t *testing.T) {
 	}
 }
 
+func TestValidateKubeletConfigurations(t *testing.T) {
+	tests := []struct {
+		name                 string
+		wantErr              string
+		kubeletConfiguration *KubeletConfiguration
+	}{
+		{
+			name:                 "no kubelet configuration",
+			kubeletConfiguration: nil,
+		},
+		{
+			name: "valid",
+			kubeletConfiguration: &KubeletConfiguration{
+				MaxPods:                     ptr.Int(50),
+				SystemReserved:              map[string]string{"cpu": "500m", "memory": "1Gi"},
+				KubeReserved:                map[string]string{"ephemeral-storage": "1Gi", "pid": "1000"},
+				EvictionHard:                map[string]string{"memory.available": "100Mi", "nodefs.available": "10%"},
+				EvictionSoft:                map[string]string{"memory.available": "300Mi"},
+				EvictionSoftGracePeriod:     map[string]string{"memory.available": "1m30s"},
+				ImageGCHighThresholdPercent: ptr.Int(85),
+				ImageGCLowThresholdPercent:  ptr.Int(80),
+				TopologyManagerPolicy:       "single-numa-node",
+				CPUManagerPolicy:            "static",
+			},
+		},
+		{
+			name:                 "max pods not positive",
+			wantErr:              "maxPods must be greater than 0",
+			kubeletConfiguration: &KubeletConfiguration{MaxPods: ptr.Int(0)},
+		},
+		{
+			name:                 "reserved resource not supported",
+			wantErr:              "systemReserved resource gpu not supported",
+			kubeletConfiguration: &KubeletConfiguration{SystemReserved: map[string]string{"gpu": "1"}},
+		},
+		{
+			name:                 "reserved quantity not valid",
+			wantErr:              "kubeReserved memory quantity lots not valid",
+			kubeletConfiguration: &KubeletConfiguration{KubeReserved: map[string]string{"memory": "lots"}},
+		},
+		{
+			name:                 "eviction signal not supported",
+			wantErr:              "evictionHard signal memory.free not supported",
+			kubeletConfiguration: &KubeletConfiguration{EvictionHard: map[string]string{"memory.free": "100Mi"}},
+		},
+		{
+			name:                 "eviction percentage not valid",
+			wantErr:              "evictionHard nodefs.available percentage 110% not valid",
+			kubeletConfiguration: &KubeletConfiguration{EvictionHard: map[string]string{"nodefs.available": "110%"}},
+		},
+		{
+			name:    "eviction soft without grace period",
+			wantErr: "evictionSoft memory.available requires a grace period in evictionSoftGracePeriod",
+			kubeletConfiguration: &KubeletConfiguration{
+				EvictionSoft: map[string]string{"memory.available": "300Mi"},
+			},
+		},
+		{
+			name:    "grace period without eviction soft",
+			wantErr: "evictionSoftGracePeriod memory.available has no matching evictionSoft threshold",
+			kubeletConfiguration: &KubeletConfiguration{
+				EvictionSoftGracePeriod: map[string]string{"memory.available": "1m"},
+			},
+		},
+		{
+			name:    "grace period not valid",
+			wantErr: "evictionSoftGracePeriod memory.available must be a positive duration, got soon",
+			kubeletConfiguration: &KubeletConfiguration{
+				EvictionSoft:            map[string]string{"memory.available": "300Mi"},
+				EvictionSoftGracePeriod: map[string]string{"memory.available": "soon"},
+			},
+		},
+		{
+			name:                 "image gc threshold out of range",
+			wantErr:              "imageGCHighThresholdPercent must be between 0 and 100",
+			kubeletConfiguration: &KubeletConfiguration{ImageGCHighThresholdPercent: ptr.Int(101)},
+		},
+		{
+			name:    "image gc low threshold not lower than high",
+			wantErr: "imageGCLowThresholdPercent must be lower than imageGCHighThresholdPercent",
+			kubeletConfiguration: &KubeletConfiguration{
+				ImageGCHighThresholdPercent: ptr.Int(80),
+				ImageGCLowThresholdPercent:  ptr.Int(80),
+			},
+		},
+		{
+			name:                 "topology manager policy not supported",
+			wantErr:              "topologyManagerPolicy strict not supported",
+			kubeletConfiguration: &KubeletConfiguration{TopologyManagerPolicy: "strict"},
+		},
+		{
+			name:                 "cpu manager policy not supported",
+			wantErr:              "cpuManagerPolicy dynamic not supported",
+			kubeletConfiguration: &KubeletConfiguration{CPUManagerPolicy: "dynamic"},
+		},
+		{
+			name:                 "static cpu manager policy without reserved cpu",
+			wantErr:              "cpuManagerPolicy static requires cpu to be reserved in systemReserved or kubeReserved",
+			kubeletConfiguration: &KubeletConfiguration{CPUManagerPolicy: "static"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{
+				Spec: ClusterSpec{
+					ControlPlaneConfiguration: ControlPlaneConfiguration{
+						KubeletConfiguration: tt.kubeletConfiguration,
+					},
+				},
+			}
+			err := validateKubeletConfigurations(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring("kubeletConfiguration for control plane not valid: " + tt.wantErr)))
+			}
+
+			cluster = &Cluster{
+				Spec: ClusterSpec{
+					WorkerNodeGroupConfigurations: []WorkerNodeGroupConfiguration{
+						{
+							Name:                 "md-0",
+							KubeletConfiguration: tt.kubeletConfiguration,
+						},
+					},
+				},
+			}
+			err = validateKubeletConfigurations(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring("kubeletConfiguration for worker node group md-0 not valid: " + tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidateControlPlaneComponentExtraArgs(t *testing.T) {
+	tests := []struct {
+		name                      string
+		wantErr                   string
+		controlPlaneConfiguration ControlPlaneConfiguration
+	}{
+		{
+			name: "no extra args",
+		},
+		{
+			name: "valid",
+			controlPlaneConfiguration: ControlPlaneConfiguration{
+				APIServerExtraArgs:         map[string]string{"event-ttl": "2h", "enable-admission-plugins": "AlwaysPullImages"},
+				ControllerManagerExtraArgs: map[string]string{"node-monitor-grace-period": "20s"},
+				SchedulerExtraArgs:         map[string]string{"feature-gates": "MinDomainsInPodTopologySpread=true"},
+			},
+		},
+		{
+			name:    "apiServerExtraArgs managed flag",
+			wantErr: "controlPlaneConfiguration.apiServerExtraArgs flag oidc-issuer-url is managed by EKS Anywhere and can't be overridden",
+			controlPlaneConfiguration: ControlPlaneConfiguration{
+				APIServerExtraArgs: map[string]string{"oidc-issuer-url": "https://issuer"},
+			},
+		},
+		{
+			name:    "apiServerExtraArgs flag not supported",
+			wantErr: "controlPlaneConfiguration.apiServerExtraArgs flag etcd-servers is not supported",
+			controlPlaneConfiguration: ControlPlaneConfiguration{
+				APIServerExtraArgs: map[string]string{"etcd-servers": "https://127.0.0.1:2379"},
+			},
+		},
+		{
+			name:    "controllerManagerExtraArgs managed flag",
+			wantErr: "controlPlaneConfiguration.controllerManagerExtraArgs flag node-cidr-mask-size is managed by EKS Anywhere and can't be overridden",
+			controlPlaneConfiguration: ControlPlaneConfiguration{
+				ControllerManagerExtraArgs: map[string]string{"node-cidr-mask-size": "28"},
+			},
+		},
+		{
+			name:    "controllerManagerExtraArgs empty value",
+			wantErr: "controlPlaneConfiguration.controllerManagerExtraArgs flag terminated-pod-gc-threshold can't have an empty value",
+			controlPlaneConfiguration: ControlPlaneConfiguration{
+				ControllerManagerExtraArgs: map[string]string{"terminated-pod-gc-threshold": ""},
+			},
+		},
+		{
+			name:    "schedulerExtraArgs flag not supported",
+			wantErr: "controlPlaneConfiguration.schedulerExtraArgs flag config is not supported, supported flags are feature-gates, leader-elect-lease-duration, leader-elect-renew-deadline, leader-elect-retry-period",
+			controlPlaneConfiguration: ControlPlaneConfiguration{
+				SchedulerExtraArgs: map[string]string{"config": "/etc/kubernetes/scheduler.yaml"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{
+				Spec: ClusterSpec{
+					ControlPlaneConfiguration: tt.controlPlaneConfiguration,
+				},
+			}
+			err := validateControlPlaneComponentExtraArgs(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidateAuditPolicy(t *testing.T) {
+	validPolicy := "apiVersion: audit.k8s.io/v1\nkind: Policy\nrules:\n- level: Metadata\n"
+	tests := []struct {
+		name        string
+		wantErr     string
+		auditPolicy *AuditPolicy
+	}{
+		{
+			name: "no audit policy",
+		},
+		{
+			name:        "valid inline",
+			auditPolicy: &AuditPolicy{Inline: validPolicy, LogMaxAge: ptr.Int(7)},
+		},
+		{
+			name:        "valid configmap",
+			auditPolicy: &AuditPolicy{ConfigMapRef: &AuditPolicyConfigMapRef{Name: "audit-policy"}},
+		},
+		{
+			name:        "inline and configmap",
+			wantErr:     "auditPolicy.configMapRef and auditPolicy.inline are mutually exclusive",
+			auditPolicy: &AuditPolicy{Inline: validPolicy, ConfigMapRef: &AuditPolicyConfigMapRef{Name: "audit-policy"}},
+		},
+		{
+			name:        "configmap without name",
+			wantErr:     "auditPolicy.configMapRef.name is required",
+			auditPolicy: &AuditPolicy{ConfigMapRef: &AuditPolicyConfigMapRef{}},
+		},
+		{
+			name:        "inline wrong kind",
+			wantErr:     "auditPolicy.inline: invalid audit policy: apiVersion and kind must be audit.k8s.io/v1 and Policy",
+			auditPolicy: &AuditPolicy{Inline: "apiVersion: audit.k8s.io/v1beta1\nkind: Policy\n"},
+		},
+		{
+			name:        "inline unknown field",
+			wantErr:     "auditPolicy.inline: invalid audit policy",
+			auditPolicy: &AuditPolicy{Inline: validPolicy + "levels: []\n"},
+		},
+		{
+			name:        "negative log max size",
+			wantErr:     "auditPolicy.logMaxSize cannot be a negative number",
+			auditPolicy: &AuditPolicy{LogMaxSize: ptr.Int(-1)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{
+				Spec: ClusterSpec{
+					AuditPolicy: tt.auditPolicy,
+				},
+			}
+			err := validateAuditPolicy(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestAuditPolicyLogSettingsDefaults(t *testing.T) {
+	g := NewWithT(t)
+	var auditPolicy *AuditPolicy
+	g.Expect(auditPolicy.LogMaxAgeOrDefault()).To(Equal(30))
+	g.Expect(auditPolicy.LogMaxBackupOrDefault()).To(Equal(10))
+	g.Expect(auditPolicy.LogMaxSizeOrDefault()).To(Equal(512))
+
+	auditPolicy = &AuditPolicy{LogMaxAge: ptr.Int(7), LogMaxBackup: ptr.Int(3), LogMaxSize: ptr.Int(100)}
+	g.Expect(auditPolicy.LogMaxAgeOrDefault()).To(Equal(7))
+	g.Expect(auditPolicy.LogMaxBackupOrDefault()).To(Equal(3))
+	g.Expect(auditPolicy.LogMaxSizeOrDefault()).To(Equal(100))
+}
+
+func TestValidateEncryptionConfiguration(t *testing.T) {
+	validKMS := &KMSConfiguration{Name: "aws-kms", Endpoint: "unix:///var/run/kms/socket.sock"}
+	tests := []struct {
+		name    string
+		wantErr string
+		config  *EncryptionConfiguration
+	}{
+		{
+			name: "no encryption configuration",
+		},
+		{
+			name: "valid",
+			config: &EncryptionConfiguration{
+				Resources: []string{"secrets", "configmaps"},
+				Providers: []EncryptionProvider{
+					{Type: KMSEncryptionProvider, KMS: validKMS},
+					{Type: AESCBCEncryptionProvider},
+					{Type: SecretboxEncryptionProvider},
+				},
+			},
+		},
+		{
+			name:    "empty resource",
+			wantErr: "encryptionConfiguration.resources cannot contain an empty resource",
+			config: &EncryptionConfiguration{
+				Resources: []string{""},
+				Providers: []EncryptionProvider{{Type: AESCBCEncryptionProvider}},
+			},
+		},
+		{
+			name:    "no providers",
+			wantErr: "encryptionConfiguration.providers must contain at least one provider",
+			config:  &EncryptionConfiguration{},
+		},
+		{
+			name:    "duplicated aescbc",
+			wantErr: "encryptionConfiguration.providers can only contain one aescbc provider",
+			config: &EncryptionConfiguration{
+				Providers: []EncryptionProvider{{Type: AESCBCEncryptionProvider}, {Type: AESCBCEncryptionProvider}},
+			},
+		},
+		{
+			name:    "kms in secretbox provider",
+			wantErr: "encryptionConfiguration.providers[0].kms can only be set for kms providers",
+			config: &EncryptionConfiguration{
+				Providers: []EncryptionProvider{{Type: SecretboxEncryptionProvider, KMS: validKMS}},
+			},
+		},
+		{
+			name:    "unsupported type",
+			wantErr: "encryptionConfiguration.providers[0].type aesgcm is not supported",
+			config: &EncryptionConfiguration{
+				Providers: []EncryptionProvider{{Type: "aesgcm"}},
+			},
+		},
+		{
+			name:    "kms without configuration",
+			wantErr: "encryptionConfiguration.providers[0].kms: is required for kms providers",
+			config: &EncryptionConfiguration{
+				Providers: []EncryptionProvider{{Type: KMSEncryptionProvider}},
+			},
+		},
+		{
+			name:    "duplicated kms name",
+			wantErr: "encryptionConfiguration.providers[1].kms.name aws-kms is duplicated",
+			config: &EncryptionConfiguration{
+				Providers: []EncryptionProvider{{Type: KMSEncryptionProvider, KMS: validKMS}, {Type: KMSEncryptionProvider, KMS: validKMS}},
+			},
+		},
+		{
+			name:    "kms unsupported api version",
+			wantErr: "encryptionConfiguration.providers[0].kms: apiVersion v3 is not supported",
+			config: &EncryptionConfiguration{
+				Providers: []EncryptionProvider{{Type: KMSEncryptionProvider, KMS: &KMSConfiguration{APIVersion: "v3", Name: "kms", Endpoint: "unix:///kms.sock"}}},
+			},
+		},
+		{
+			name:    "kms without name",
+			wantErr: "encryptionConfiguration.providers[0].kms: name is required",
+			config: &EncryptionConfiguration{
+				Providers: []EncryptionProvider{{Type: KMSEncryptionProvider, KMS: &KMSConfiguration{Endpoint: "unix:///kms.sock"}}},
+			},
+		},
+		{
+			name:    "kms tcp endpoint",
+			wantErr: "encryptionConfiguration.providers[0].kms: endpoint must be a unix socket path with the unix:/// prefix",
+			config: &EncryptionConfiguration{
+				Providers: []EncryptionProvider{{Type: KMSEncryptionProvider, KMS: &KMSConfiguration{Name: "kms", Endpoint: "tcp://10.0.0.1:8080"}}},
+			},
+		},
+		{
+			name:    "kms v2 cache size",
+			wantErr: "encryptionConfiguration.providers[0].kms: cacheSize is only supported by v1 plugins",
+			config: &EncryptionConfiguration{
+				Providers: []EncryptionProvider{{Type: KMSEncryptionProvider, KMS: &KMSConfiguration{APIVersion: "v2", Name: "kms", Endpoint: "unix:///kms.sock", CacheSize: ptr.Int32(100)}}},
+			},
+		},
+		{
+			name:    "kms negative timeout",
+			wantErr: "encryptionConfiguration.providers[0].kms: timeout must be a positive duration",
+			config: &EncryptionConfiguration{
+				Providers: []EncryptionProvider{{Type: KMSEncryptionProvider, KMS: &KMSConfiguration{Name: "kms", Endpoint: "unix:///kms.sock", Timeout: &metav1.Duration{Duration: -time.Second}}}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{
+				Spec: ClusterSpec{
+					EncryptionConfiguration: tt.config,
+				},
+			}
+			err := validateEncryptionConfiguration(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestEncryptionConfigurationResourcesOrDefault(t *testing.T) {
+	g := NewWithT(t)
+	config := &EncryptionConfiguration{}
+	g.Expect(config.ResourcesOrDefault()).To(Equal([]string{"secrets"}))
+
+	config.Resources = []string{"secrets", "configmaps"}
+	g.Expect(config.ResourcesOrDefault()).To(Equal([]string{"secrets", "configmaps"}))
+}
+
+func TestValidatePodSecurity(t *testing.T) {
+	tests := []struct {
+		name              string
+		wantErr           string
+		kubernetesVersion KubernetesVersion
+		podSecurity       *PodSecurity
+	}{
+		{
+			name:              "no pod security",
+			kubernetesVersion: Kube122,
+		},
+		{
+			name:              "valid",
+			kubernetesVersion: Kube123,
+			podSecurity: &PodSecurity{
+				Enforce:          BaselinePodSecurityLevel,
+				EnforceVersion:   "v1.23",
+				Audit:            RestrictedPodSecurityLevel,
+				AuditVersion:     "latest",
+				Warn:             RestrictedPodSecurityLevel,
+				ExemptNamespaces: []string{"monitoring"},
+			},
+		},
+		{
+			name:              "unsupported kubernetes version",
+			wantErr:           "podSecurity is only supported for kubernetes versions 1.23 and above",
+			kubernetesVersion: Kube122,
+			podSecurity:       &PodSecurity{},
+		},
+		{
+			name:              "unsupported level",
+			wantErr:           "podSecurity.warn strict is not supported",
+			kubernetesVersion: Kube124,
+			podSecurity:       &PodSecurity{Warn: "strict"},
+		},
+		{
+			name:              "invalid version",
+			wantErr:           "podSecurity.auditVersion 1.24 is invalid",
+			kubernetesVersion: Kube124,
+			podSecurity:       &PodSecurity{AuditVersion: "1.24"},
+		},
+		{
+			name:              "invalid exempt namespace",
+			wantErr:           "podSecurity.exemptNamespaces My_Namespace is not a valid namespace",
+			kubernetesVersion: Kube124,
+			podSecurity:       &PodSecurity{ExemptNamespaces: []string{"My_Namespace"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{
+				Spec: ClusterSpec{
+					KubernetesVersion: tt.kubernetesVersion,
+					PodSecurity:       tt.podSecurity,
+				},
+			}
+			err := validatePodSecurity(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestPodSecurityDefaults(t *testing.T) {
+	g := NewWithT(t)
+	podSecurity := &PodSecurity{}
+	g.Expect(podSecurity.EnforceOrDefault()).To(Equal(PrivilegedPodSecurityLevel))
+	g.Expect(podSecurity.AuditOrDefault()).To(Equal(PrivilegedPodSecurityLevel))
+	g.Expect(podSecurity.WarnOrDefault()).To(Equal(PrivilegedPodSecurityLevel))
+	g.Expect(podSecurity.EnforceVersionOrDefault()).To(Equal("latest"))
+	g.Expect(podSecurity.AuditVersionOrDefault()).To(Equal("latest"))
+	g.Expect(podSecurity.WarnVersionOrDefault()).To(Equal("latest"))
+
+	podSecurity = &PodSecurity{Enforce: RestrictedPodSecurityLevel, EnforceVersion: "v1.24"}
+	g.Expect(podSecurity.EnforceOrDefault()).To(Equal(RestrictedPodSecurityLevel))
+	g.Expect(podSecurity.EnforceVersionOrDefault()).To(Equal("v1.24"))
+}
+
 func TestGetClusterDefaultKubernetesVersion(t *testing.T) {
 	g := NewWithT(t)
 	g.Expect(GetClusterDefaultKubernetesVersion()).To(Equal(Kube124))
@@ -2982,3 +3481,23 @@ func TestClusterMDUpgradeRolloutStrategyNotNil(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterConfigContentErrors(t *testing.T) {
+	g := NewWithT(t)
+	c := &Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		Spec: ClusterSpec{
+			ControlPlaneConfiguration: ControlPlaneConfiguration{Count: 2},
+			WorkerNodeGroupConfigurations: []WorkerNodeGroupConfiguration{
+				{Name: "md-0", Count: ptr.Int(-1)},
+			},
+		},
+	}
+
+	errs := ClusterConfigContentErrors(c)
+	g.Expect(errs).To(ContainElements(
+		MatchError("control plane node count cannot be an even number"),
+		MatchError("validating autoscaling configuration: worker node count must be zero or greater if autoscaling is not enabled"),
+	))
+	g.Expect(ValidateClusterConfigContent(c)).To(Equal(errs[0]))
+}
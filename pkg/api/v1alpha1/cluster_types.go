@@ -56,6 +56,13 @@ type ClusterSpec struct {
 	PodIAMConfig                *PodIAMConfig                `json:"podIamConfig,omitempty"`
 	// BundlesRef contains a reference to the Bundles containing the desired dependencies for the cluster
 	BundlesRef *BundlesRef `json:"bundlesRef,omitempty"`
+	// AuditPolicy defines the kube-apiserver audit policy and audit log settings.
+	// Defaults to the EKS Anywhere audit policy.
+	AuditPolicy *AuditPolicy `json:"auditPolicy,omitempty"`
+	// EncryptionConfiguration defines the providers the kube-apiserver uses to encrypt resources at rest in etcd.
+	EncryptionConfiguration *EncryptionConfiguration `json:"encryptionConfiguration,omitempty"`
+	// PodSecurity defines the cluster wide Pod Security Admission defaults.
+	PodSecurity *PodSecurity `json:"podSecurity,omitempty"`
 }
 
 func (n *Cluster) Equal(o *Cluster) bool {
@@ -101,6 +108,15 @@ func (n *Cluster) Equal(o *Cluster) bool {
 	if !n.Spec.BundlesRef.Equal(o.Spec.BundlesRef) {
 		return false
 	}
+	if !n.Spec.AuditPolicy.Equal(o.Spec.AuditPolicy) {
+		return false
+	}
+	if !n.Spec.EncryptionConfiguration.Equal(o.Spec.EncryptionConfiguration) {
+		return false
+	}
+	if !n.Spec.PodSecurity.Equal(o.Spec.PodSecurity) {
+		return false
+	}
 
 	return true
 }
@@ -217,6 +233,17 @@ type ControlPlaneConfiguration struct {
 	// UpgradeRolloutStrategy determines the rollout strategy to use for rolling upgrades
 	// and related parameters/knobs
 	UpgradeRolloutStrategy *ControlPlaneUpgradeRolloutStrategy `json:"upgradeRolloutStrategy,omitempty"`
+	// KubeletConfiguration defines the kubelet settings for the control plane nodes
+	KubeletConfiguration *KubeletConfiguration `json:"kubeletConfiguration,omitempty"`
+	// APIServerExtraArgs defines additional flags for the kube-apiserver. Only an allow-listed set of flags is supported
+	// and the flags cannot be changed after the cluster is created.
+	APIServerExtraArgs map[string]string `json:"apiServerExtraArgs,omitempty"`
+	// ControllerManagerExtraArgs defines additional flags for the kube-controller-manager. Only an allow-listed set of flags
+	// is supported and the flags cannot be changed after the cluster is created.
+	ControllerManagerExtraArgs map[string]string `json:"controllerManagerExtraArgs,omitempty"`
+	// SchedulerExtraArgs defines additional flags for the kube-scheduler. Only an allow-listed set of flags is supported
+	// and the flags cannot be changed after the cluster is created.
+	SchedulerExtraArgs map[string]string `json:"schedulerExtraArgs,omitempty"`
 }
 
 func TaintsSliceEqual(s1, s2 []corev1.Taint) bool {
@@ -261,7 +288,21 @@ func (n *ControlPlaneConfiguration) Equal(o *ControlPlaneConfiguration) bool {
 		return false
 	}
 	return n.Count == o.Count && n.Endpoint.Equal(o.Endpoint) && n.MachineGroupRef.Equal(o.MachineGroupRef) &&
-		TaintsSliceEqual(n.Taints, o.Taints) && MapEqual(n.Labels, o.Labels)
+		TaintsSliceEqual(n.Taints, o.Taints) && MapEqual(n.Labels, o.Labels) &&
+		n.KubeletConfiguration.Equal(o.KubeletConfiguration) && n.ComponentExtraArgsEqual(o)
+}
+
+// ComponentExtraArgsEqual returns true if the extra args of the control plane components are the same.
+func (n *ControlPlaneConfiguration) ComponentExtraArgsEqual(o *ControlPlaneConfiguration) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return MapEqual(n.APIServerExtraArgs, o.APIServerExtraArgs) &&
+		MapEqual(n.ControllerManagerExtraArgs, o.ControllerManagerExtraArgs) &&
+		MapEqual(n.SchedulerExtraArgs, o.SchedulerExtraArgs)
 }
 
 type Endpoint struct {
@@ -295,6 +336,8 @@ type WorkerNodeGroupConfiguration struct {
 	// UpgradeRolloutStrategy determines the rollout strategy to use for rolling upgrades
 	// and related parameters/knobs
 	UpgradeRolloutStrategy *WorkerNodesUpgradeRolloutStrategy `json:"upgradeRolloutStrategy,omitempty"`
+	// KubeletConfiguration defines the kubelet settings for the worker nodes
+	KubeletConfiguration *KubeletConfiguration `json:"kubeletConfiguration,omitempty"`
 }
 
 func generateWorkerNodeGroupKey(c WorkerNodeGroupConfiguration) (key string) {
@@ -333,7 +376,8 @@ func WorkerNodeGroupConfigurationsSliceEqual(a, b []WorkerNodeGroupConfiguration
 		return false
 	}
 
-	return WorkerNodeGroupConfigurationSliceTaintsEqual(a, b) && WorkerNodeGroupConfigurationsLabelsMapEqual(a, b)
+	return WorkerNodeGroupConfigurationSliceTaintsEqual(a, b) && WorkerNodeGroupConfigurationsLabelsMapEqual(a, b) &&
+		WorkerNodeGroupConfigurationsKubeletConfigurationEqual(a, b)
 }
 
 func WorkerNodeGroupConfigurationSliceTaintsEqual(a, b []WorkerNodeGroupConfiguration) bool {
@@ -378,6 +422,26 @@ func WorkerNodeGroupConfigurationsLabelsMapEqual(a, b []WorkerNodeGroupConfigura
 	return true
 }
 
+// WorkerNodeGroupConfigurationsKubeletConfigurationEqual compares the kubelet configuration of the node groups present in both a and b.
+func WorkerNodeGroupConfigurationsKubeletConfigurationEqual(a, b []WorkerNodeGroupConfiguration) bool {
+	m := make(map[string]*KubeletConfiguration, len(a))
+	for _, nodeGroup := range a {
+		m[nodeGroup.Name] = nodeGroup.KubeletConfiguration
+	}
+
+	for _, nodeGroup := range b {
+		kubeletConfiguration, ok := m[nodeGroup.Name]
+		if !ok {
+			// added/removed node groups are immaterial, like in the taints and labels comparisons
+			continue
+		}
+		if !kubeletConfiguration.Equal(nodeGroup.KubeletConfiguration) {
+			return false
+		}
+	}
+	return true
+}
+
 type ClusterNetwork struct {
 	// Comma-separated list of CIDR blocks to use for pod and service subnets.
 	// Defaults to 192.168.0.0/16 for pod subnet.
@@ -800,6 +864,347 @@ type AutoScalingConfiguration struct {
 	MaxCount int `json:"maxCount,omitempty"`
 }
 
+// KubeletConfiguration defines the kubelet settings of a group of nodes.
+// They are passed to the kubelet as flags, so their format is the same as the kubelet flags.
+type KubeletConfiguration struct {
+	// MaxPods is the maximum number of pods that can run on a node.
+	MaxPods *int `json:"maxPods,omitempty"`
+	// SystemReserved is the set of resources reserved for the OS system daemons,
+	// with cpu, memory, ephemeral-storage and pid as keys. For example, cpu set to 500m.
+	SystemReserved map[string]string `json:"systemReserved,omitempty"`
+	// KubeReserved is the set of resources reserved for the Kubernetes system daemons,
+	// with cpu, memory, ephemeral-storage and pid as keys.
+	KubeReserved map[string]string `json:"kubeReserved,omitempty"`
+	// EvictionHard is the set of eviction thresholds that trigger a pod eviction,
+	// with the eviction signal as key and a quantity or percentage as value. For example, memory.available set to 100Mi.
+	EvictionHard map[string]string `json:"evictionHard,omitempty"`
+	// EvictionSoft is the set of eviction thresholds that trigger a pod eviction after their grace period.
+	EvictionSoft map[string]string `json:"evictionSoft,omitempty"`
+	// EvictionSoftGracePeriod is the grace period of each EvictionSoft signal. For example, memory.available set to 1m30s.
+	EvictionSoftGracePeriod map[string]string `json:"evictionSoftGracePeriod,omitempty"`
+	// ImageGCHighThresholdPercent is the disk usage percent after which image garbage collection always runs.
+	ImageGCHighThresholdPercent *int `json:"imageGCHighThresholdPercent,omitempty"`
+	// ImageGCLowThresholdPercent is the disk usage percent before which image garbage collection never runs.
+	ImageGCLowThresholdPercent *int `json:"imageGCLowThresholdPercent,omitempty"`
+	// TopologyManagerPolicy is the topology manager policy, one of none, best-effort, restricted or single-numa-node.
+	TopologyManagerPolicy string `json:"topologyManagerPolicy,omitempty"`
+	// CPUManagerPolicy is the cpu manager policy, one of none or static.
+	CPUManagerPolicy string `json:"cpuManagerPolicy,omitempty"`
+}
+
+func (n *KubeletConfiguration) Equal(o *KubeletConfiguration) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return intPtrEqual(n.MaxPods, o.MaxPods) &&
+		MapEqual(n.SystemReserved, o.SystemReserved) &&
+		MapEqual(n.KubeReserved, o.KubeReserved) &&
+		MapEqual(n.EvictionHard, o.EvictionHard) &&
+		MapEqual(n.EvictionSoft, o.EvictionSoft) &&
+		MapEqual(n.EvictionSoftGracePeriod, o.EvictionSoftGracePeriod) &&
+		intPtrEqual(n.ImageGCHighThresholdPercent, o.ImageGCHighThresholdPercent) &&
+		intPtrEqual(n.ImageGCLowThresholdPercent, o.ImageGCLowThresholdPercent) &&
+		n.TopologyManagerPolicy == o.TopologyManagerPolicy &&
+		n.CPUManagerPolicy == o.CPUManagerPolicy
+}
+
+func intPtrEqual(a, b *int) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return *a == *b
+}
+
+const (
+	// DefaultAuditPolicyConfigMapKey is the ConfigMap key holding the audit policy when the reference doesn't set one.
+	DefaultAuditPolicyConfigMapKey = "policy.yaml"
+	// DefaultAuditLogMaxAge is the default number of days the kube-apiserver keeps the rotated audit log files.
+	DefaultAuditLogMaxAge = 30
+	// DefaultAuditLogMaxBackup is the default number of rotated audit log files the kube-apiserver keeps.
+	DefaultAuditLogMaxBackup = 10
+	// DefaultAuditLogMaxSize is the default size in megabytes of the audit log file before it gets rotated.
+	DefaultAuditLogMaxSize = 512
+)
+
+// AuditPolicy defines the kube-apiserver audit policy, either inline or in a ConfigMap, and the audit log settings.
+type AuditPolicy struct {
+	// ConfigMapRef references a ConfigMap in the cluster namespace holding the audit policy.
+	ConfigMapRef *AuditPolicyConfigMapRef `json:"configMapRef,omitempty"`
+	// Inline defines the audit policy yaml.
+	Inline string `json:"inline,omitempty"`
+	// LogMaxAge is the number of days the rotated audit log files are kept. Defaults to 30.
+	LogMaxAge *int `json:"logMaxAge,omitempty"`
+	// LogMaxBackup is the number of rotated audit log files kept. Defaults to 10.
+	LogMaxBackup *int `json:"logMaxBackup,omitempty"`
+	// LogMaxSize is the size in megabytes of the audit log file before it gets rotated. Defaults to 512.
+	LogMaxSize *int `json:"logMaxSize,omitempty"`
+}
+
+// AuditPolicyConfigMapRef references the ConfigMap key holding an audit policy.
+type AuditPolicyConfigMapRef struct {
+	// Name is the name of the ConfigMap.
+	Name string `json:"name"`
+	// Key is the ConfigMap key holding the audit policy. Defaults to policy.yaml.
+	Key string `json:"key,omitempty"`
+}
+
+// KeyOrDefault returns the ConfigMap key holding the audit policy.
+func (r *AuditPolicyConfigMapRef) KeyOrDefault() string {
+	if r.Key == "" {
+		return DefaultAuditPolicyConfigMapKey
+	}
+	return r.Key
+}
+
+// Equal returns true if both audit policies are the same.
+func (a *AuditPolicy) Equal(o *AuditPolicy) bool {
+	if a == o {
+		return true
+	}
+	if a == nil || o == nil {
+		return false
+	}
+	if (a.ConfigMapRef == nil) != (o.ConfigMapRef == nil) {
+		return false
+	}
+	if a.ConfigMapRef != nil && (a.ConfigMapRef.Name != o.ConfigMapRef.Name || a.ConfigMapRef.KeyOrDefault() != o.ConfigMapRef.KeyOrDefault()) {
+		return false
+	}
+	return a.Inline == o.Inline && intPtrEqual(a.LogMaxAge, o.LogMaxAge) &&
+		intPtrEqual(a.LogMaxBackup, o.LogMaxBackup) && intPtrEqual(a.LogMaxSize, o.LogMaxSize)
+}
+
+// LogMaxAgeOrDefault returns the number of days the rotated audit log files are kept.
+func (a *AuditPolicy) LogMaxAgeOrDefault() int {
+	if a == nil || a.LogMaxAge == nil {
+		return DefaultAuditLogMaxAge
+	}
+	return *a.LogMaxAge
+}
+
+// LogMaxBackupOrDefault returns the number of rotated audit log files kept.
+func (a *AuditPolicy) LogMaxBackupOrDefault() int {
+	if a == nil || a.LogMaxBackup == nil {
+		return DefaultAuditLogMaxBackup
+	}
+	return *a.LogMaxBackup
+}
+
+// LogMaxSizeOrDefault returns the size in megabytes of the audit log file before it gets rotated.
+func (a *AuditPolicy) LogMaxSizeOrDefault() int {
+	if a == nil || a.LogMaxSize == nil {
+		return DefaultAuditLogMaxSize
+	}
+	return *a.LogMaxSize
+}
+
+// EncryptionProviderType is the type of a kube-apiserver encryption provider.
+type EncryptionProviderType string
+
+const (
+	// AESCBCEncryptionProvider encrypts resources with AES-CBC keys generated by the CLI.
+	AESCBCEncryptionProvider EncryptionProviderType = "aescbc"
+	// SecretboxEncryptionProvider encrypts resources with XSalsa20 and Poly1305 keys generated by the CLI.
+	SecretboxEncryptionProvider EncryptionProviderType = "secretbox"
+	// KMSEncryptionProvider encrypts resources with a KMS plugin running on the control plane nodes.
+	KMSEncryptionProvider EncryptionProviderType = "kms"
+)
+
+// DefaultEncryptedResources are the resources encrypted when the encryption configuration doesn't set them.
+var DefaultEncryptedResources = []string{"secrets"}
+
+// EncryptionConfiguration defines the providers the kube-apiserver uses to encrypt resources at rest in etcd.
+type EncryptionConfiguration struct {
+	// Resources encrypted by the providers. Defaults to secrets.
+	Resources []string `json:"resources,omitempty"`
+	// Providers used to encrypt and decrypt the resources. The first provider encrypts new writes.
+	Providers []EncryptionProvider `json:"providers"`
+}
+
+// EncryptionProvider is a kube-apiserver encryption provider.
+type EncryptionProvider struct {
+	// Type of the provider, aescbc, secretbox or kms. The keys of aescbc and secretbox
+	// providers are generated by the CLI.
+	Type EncryptionProviderType `json:"type"`
+	// KMS defines the KMS plugin of a kms provider.
+	KMS *KMSConfiguration `json:"kms,omitempty"`
+}
+
+// KMSConfiguration defines the KMS plugin of a kms encryption provider.
+type KMSConfiguration struct {
+	// APIVersion of the KMS plugin, v1 or v2. Defaults to v1.
+	APIVersion string `json:"apiVersion,omitempty"`
+	// Name of the KMS plugin.
+	Name string `json:"name"`
+	// Endpoint is the unix socket the KMS plugin listens on, for example unix:///var/run/kmsplugin/socket.sock.
+	Endpoint string `json:"endpoint"`
+	// CacheSize is the number of data encryption keys cached in memory. Only used by v1 plugins.
+	CacheSize *int32 `json:"cacheSize,omitempty"`
+	// Timeout for the calls to the KMS plugin. Defaults to 3s.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// ResourcesOrDefault returns the resources encrypted by the providers.
+func (e *EncryptionConfiguration) ResourcesOrDefault() []string {
+	if len(e.Resources) == 0 {
+		return DefaultEncryptedResources
+	}
+	return e.Resources
+}
+
+// Equal returns true if both encryption configurations are the same.
+func (e *EncryptionConfiguration) Equal(o *EncryptionConfiguration) bool {
+	if e == o {
+		return true
+	}
+	if e == nil || o == nil {
+		return false
+	}
+	if !SliceEqual(e.ResourcesOrDefault(), o.ResourcesOrDefault()) || len(e.Providers) != len(o.Providers) {
+		return false
+	}
+	for i := range e.Providers {
+		if !e.Providers[i].Equal(&o.Providers[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal returns true if both encryption providers are the same.
+func (p *EncryptionProvider) Equal(o *EncryptionProvider) bool {
+	if p.Type != o.Type {
+		return false
+	}
+	if p.KMS == o.KMS {
+		return true
+	}
+	if p.KMS == nil || o.KMS == nil {
+		return false
+	}
+	return p.KMS.APIVersion == o.KMS.APIVersion && p.KMS.Name == o.KMS.Name && p.KMS.Endpoint == o.KMS.Endpoint &&
+		int32PtrEqual(p.KMS.CacheSize, o.KMS.CacheSize) && durationPtrEqual(p.KMS.Timeout, o.KMS.Timeout)
+}
+
+func int32PtrEqual(a, b *int32) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return *a == *b
+}
+
+func durationPtrEqual(a, b *metav1.Duration) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return a.Duration == b.Duration
+}
+
+// PodSecurityLevel is a Pod Security Standards level.
+type PodSecurityLevel string
+
+const (
+	// PrivilegedPodSecurityLevel allows all pods.
+	PrivilegedPodSecurityLevel PodSecurityLevel = "privileged"
+	// BaselinePodSecurityLevel prevents known privilege escalations.
+	BaselinePodSecurityLevel PodSecurityLevel = "baseline"
+	// RestrictedPodSecurityLevel enforces the current pod hardening best practices.
+	RestrictedPodSecurityLevel PodSecurityLevel = "restricted"
+)
+
+// DefaultPodSecurityVersion is the version of the Pod Security Standards used when the pod security doesn't set one.
+const DefaultPodSecurityVersion = "latest"
+
+// PodSecurity defines the cluster wide defaults of the Pod Security Admission controller,
+// used for the namespaces without pod security labels.
+type PodSecurity struct {
+	// Enforce is the level of the policy whose violations reject the pods. Defaults to privileged.
+	Enforce PodSecurityLevel `json:"enforce,omitempty"`
+	// EnforceVersion is the Kubernetes minor version of the enforced policy, for example v1.24. Defaults to latest.
+	EnforceVersion string `json:"enforceVersion,omitempty"`
+	// Audit is the level of the policy whose violations are recorded in the audit log. Defaults to privileged.
+	Audit PodSecurityLevel `json:"audit,omitempty"`
+	// AuditVersion is the Kubernetes minor version of the audited policy, for example v1.24. Defaults to latest.
+	AuditVersion string `json:"auditVersion,omitempty"`
+	// Warn is the level of the policy whose violations return a warning to the user. Defaults to privileged.
+	Warn PodSecurityLevel `json:"warn,omitempty"`
+	// WarnVersion is the Kubernetes minor version of the warned policy, for example v1.24. Defaults to latest.
+	WarnVersion string `json:"warnVersion,omitempty"`
+	// ExemptNamespaces are namespaces where the pod security is not checked, on top of the
+	// EKS Anywhere system namespaces which are always exempted.
+	ExemptNamespaces []string `json:"exemptNamespaces,omitempty"`
+}
+
+// EnforceOrDefault returns the level of the enforced policy.
+func (p *PodSecurity) EnforceOrDefault() PodSecurityLevel {
+	return podSecurityLevelOrDefault(p.Enforce)
+}
+
+// AuditOrDefault returns the level of the audited policy.
+func (p *PodSecurity) AuditOrDefault() PodSecurityLevel {
+	return podSecurityLevelOrDefault(p.Audit)
+}
+
+// WarnOrDefault returns the level of the warned policy.
+func (p *PodSecurity) WarnOrDefault() PodSecurityLevel {
+	return podSecurityLevelOrDefault(p.Warn)
+}
+
+// EnforceVersionOrDefault returns the version of the enforced policy.
+func (p *PodSecurity) EnforceVersionOrDefault() string {
+	return podSecurityVersionOrDefault(p.EnforceVersion)
+}
+
+// AuditVersionOrDefault returns the version of the audited policy.
+func (p *PodSecurity) AuditVersionOrDefault() string {
+	return podSecurityVersionOrDefault(p.AuditVersion)
+}
+
+// WarnVersionOrDefault returns the version of the warned policy.
+func (p *PodSecurity) WarnVersionOrDefault() string {
+	return podSecurityVersionOrDefault(p.WarnVersion)
+}
+
+func podSecurityLevelOrDefault(l PodSecurityLevel) PodSecurityLevel {
+	if l == "" {
+		return PrivilegedPodSecurityLevel
+	}
+	return l
+}
+
+func podSecurityVersionOrDefault(v string) string {
+	if v == "" {
+		return DefaultPodSecurityVersion
+	}
+	return v
+}
+
+// Equal returns true if both pod securities are the same.
+func (p *PodSecurity) Equal(o *PodSecurity) bool {
+	if p == o {
+		return true
+	}
+	if p == nil || o == nil {
+		return false
+	}
+	return p.EnforceOrDefault() == o.EnforceOrDefault() && p.EnforceVersionOrDefault() == o.EnforceVersionOrDefault() &&
+		p.AuditOrDefault() == o.AuditOrDefault() && p.AuditVersionOrDefault() == o.AuditVersionOrDefault() &&
+		p.WarnOrDefault() == o.WarnOrDefault() && p.WarnVersionOrDefault() == o.WarnVersionOrDefault() &&
+		SliceEqual(p.ExemptNamespaces, o.ExemptNamespaces)
+}
+
 // ControlPlaneUpgradeRolloutStrategy indicates rollout strategy for cluster.
 type ControlPlaneUpgradeRolloutStrategy struct {
 	Type          string                          `json:"type,omitempty"`
@@ -2,6 +2,7 @@ package v1alpha1_test
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
@@ -1080,6 +1081,137 @@ func TestClusterEqualProxyConfiguration(t *testing.T) {
 	}
 }
 
+func TestClusterEqualEncryptionConfiguration(t *testing.T) {
+	kms := func(timeout time.Duration) v1alpha1.EncryptionProvider {
+		return v1alpha1.EncryptionProvider{
+			Type: v1alpha1.KMSEncryptionProvider,
+			KMS: &v1alpha1.KMSConfiguration{
+				Name:     "aws-kms",
+				Endpoint: "unix:///var/run/kms/socket.sock",
+				Timeout:  &metav1.Duration{Duration: timeout},
+			},
+		}
+	}
+	testCases := []struct {
+		testName                       string
+		cluster1Config, cluster2Config *v1alpha1.EncryptionConfiguration
+		want                           bool
+	}{
+		{
+			testName: "both nil",
+			want:     true,
+		},
+		{
+			testName: "one nil, one exists",
+			cluster1Config: &v1alpha1.EncryptionConfiguration{
+				Providers: []v1alpha1.EncryptionProvider{{Type: v1alpha1.AESCBCEncryptionProvider}},
+			},
+			want: false,
+		},
+		{
+			testName: "both exist, same with default resources",
+			cluster1Config: &v1alpha1.EncryptionConfiguration{
+				Providers: []v1alpha1.EncryptionProvider{kms(time.Second), {Type: v1alpha1.AESCBCEncryptionProvider}},
+			},
+			cluster2Config: &v1alpha1.EncryptionConfiguration{
+				Resources: []string{"secrets"},
+				Providers: []v1alpha1.EncryptionProvider{kms(time.Second), {Type: v1alpha1.AESCBCEncryptionProvider}},
+			},
+			want: true,
+		},
+		{
+			testName: "both exist, diff providers order",
+			cluster1Config: &v1alpha1.EncryptionConfiguration{
+				Providers: []v1alpha1.EncryptionProvider{{Type: v1alpha1.SecretboxEncryptionProvider}, {Type: v1alpha1.AESCBCEncryptionProvider}},
+			},
+			cluster2Config: &v1alpha1.EncryptionConfiguration{
+				Providers: []v1alpha1.EncryptionProvider{{Type: v1alpha1.AESCBCEncryptionProvider}, {Type: v1alpha1.SecretboxEncryptionProvider}},
+			},
+			want: false,
+		},
+		{
+			testName: "both exist, diff kms",
+			cluster1Config: &v1alpha1.EncryptionConfiguration{
+				Providers: []v1alpha1.EncryptionProvider{kms(time.Second)},
+			},
+			cluster2Config: &v1alpha1.EncryptionConfiguration{
+				Providers: []v1alpha1.EncryptionProvider{kms(time.Minute)},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.testName, func(t *testing.T) {
+			cluster1 := &v1alpha1.Cluster{
+				Spec: v1alpha1.ClusterSpec{
+					EncryptionConfiguration: tt.cluster1Config,
+				},
+			}
+			cluster2 := &v1alpha1.Cluster{
+				Spec: v1alpha1.ClusterSpec{
+					EncryptionConfiguration: tt.cluster2Config,
+				},
+			}
+
+			g := NewWithT(t)
+			g.Expect(cluster1.Equal(cluster2)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestClusterEqualPodSecurity(t *testing.T) {
+	testCases := []struct {
+		testName                 string
+		cluster1PSA, cluster2PSA *v1alpha1.PodSecurity
+		want                     bool
+	}{
+		{
+			testName: "both nil",
+			want:     true,
+		},
+		{
+			testName:    "one nil, one exists",
+			cluster1PSA: &v1alpha1.PodSecurity{},
+			want:        false,
+		},
+		{
+			testName:    "both exist, same with defaults",
+			cluster1PSA: &v1alpha1.PodSecurity{Enforce: v1alpha1.PrivilegedPodSecurityLevel, EnforceVersion: "latest"},
+			cluster2PSA: &v1alpha1.PodSecurity{},
+			want:        true,
+		},
+		{
+			testName:    "both exist, diff level",
+			cluster1PSA: &v1alpha1.PodSecurity{Warn: v1alpha1.BaselinePodSecurityLevel},
+			cluster2PSA: &v1alpha1.PodSecurity{Warn: v1alpha1.RestrictedPodSecurityLevel},
+			want:        false,
+		},
+		{
+			testName:    "both exist, diff exempt namespaces",
+			cluster1PSA: &v1alpha1.PodSecurity{ExemptNamespaces: []string{"monitoring"}},
+			cluster2PSA: &v1alpha1.PodSecurity{ExemptNamespaces: []string{"logging"}},
+			want:        false,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.testName, func(t *testing.T) {
+			cluster1 := &v1alpha1.Cluster{
+				Spec: v1alpha1.ClusterSpec{
+					PodSecurity: tt.cluster1PSA,
+				},
+			}
+			cluster2 := &v1alpha1.Cluster{
+				Spec: v1alpha1.ClusterSpec{
+					PodSecurity: tt.cluster2PSA,
+				},
+			}
+
+			g := NewWithT(t)
+			g.Expect(cluster1.Equal(cluster2)).To(Equal(tt.want))
+		})
+	}
+}
+
 func TestClusterEqualRegistryMirrorConfiguration(t *testing.T) {
 	testCases := []struct {
 		testName                   string
@@ -1852,3 +1984,108 @@ func TestNodes_Equal(t *testing.T) {
 		})
 	}
 }
+
+func TestKubeletConfigurationEqual(t *testing.T) {
+	tests := []struct {
+		name   string
+		k1, k2 *v1alpha1.KubeletConfiguration
+		want   bool
+	}{
+		{
+			name: "both nil",
+			k1:   nil,
+			k2:   nil,
+			want: true,
+		},
+		{
+			name: "one nil",
+			k1:   nil,
+			k2:   &v1alpha1.KubeletConfiguration{},
+			want: false,
+		},
+		{
+			name: "same",
+			k1: &v1alpha1.KubeletConfiguration{
+				MaxPods:                     ptr.Int(50),
+				SystemReserved:              map[string]string{"cpu": "500m"},
+				EvictionHard:                map[string]string{"memory.available": "100Mi"},
+				ImageGCHighThresholdPercent: ptr.Int(85),
+				CPUManagerPolicy:            "static",
+			},
+			k2: &v1alpha1.KubeletConfiguration{
+				MaxPods:                     ptr.Int(50),
+				SystemReserved:              map[string]string{"cpu": "500m"},
+				EvictionHard:                map[string]string{"memory.available": "100Mi"},
+				ImageGCHighThresholdPercent: ptr.Int(85),
+				CPUManagerPolicy:            "static",
+			},
+			want: true,
+		},
+		{
+			name: "different max pods",
+			k1:   &v1alpha1.KubeletConfiguration{MaxPods: ptr.Int(50)},
+			k2:   &v1alpha1.KubeletConfiguration{MaxPods: ptr.Int(110)},
+			want: false,
+		},
+		{
+			name: "max pods removed",
+			k1:   &v1alpha1.KubeletConfiguration{MaxPods: ptr.Int(50)},
+			k2:   &v1alpha1.KubeletConfiguration{},
+			want: false,
+		},
+		{
+			name: "different eviction thresholds",
+			k1:   &v1alpha1.KubeletConfiguration{EvictionHard: map[string]string{"memory.available": "100Mi"}},
+			k2:   &v1alpha1.KubeletConfiguration{EvictionHard: map[string]string{"memory.available": "200Mi"}},
+			want: false,
+		},
+		{
+			name: "different topology manager policy",
+			k1:   &v1alpha1.KubeletConfiguration{TopologyManagerPolicy: "none"},
+			k2:   &v1alpha1.KubeletConfiguration{TopologyManagerPolicy: "best-effort"},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(tt.k1.Equal(tt.k2)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestWorkerNodeGroupConfigurationsKubeletConfigurationEqual(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []v1alpha1.WorkerNodeGroupConfiguration
+		want bool
+	}{
+		{
+			name: "same",
+			a:    []v1alpha1.WorkerNodeGroupConfiguration{{Name: "md-0", KubeletConfiguration: &v1alpha1.KubeletConfiguration{MaxPods: ptr.Int(50)}}},
+			b:    []v1alpha1.WorkerNodeGroupConfiguration{{Name: "md-0", KubeletConfiguration: &v1alpha1.KubeletConfiguration{MaxPods: ptr.Int(50)}}},
+			want: true,
+		},
+		{
+			name: "different",
+			a:    []v1alpha1.WorkerNodeGroupConfiguration{{Name: "md-0", KubeletConfiguration: &v1alpha1.KubeletConfiguration{MaxPods: ptr.Int(50)}}},
+			b:    []v1alpha1.WorkerNodeGroupConfiguration{{Name: "md-0"}},
+			want: false,
+		},
+		{
+			name: "node group added",
+			a:    []v1alpha1.WorkerNodeGroupConfiguration{{Name: "md-0"}},
+			b: []v1alpha1.WorkerNodeGroupConfiguration{
+				{Name: "md-0"},
+				{Name: "md-1", KubeletConfiguration: &v1alpha1.KubeletConfiguration{MaxPods: ptr.Int(50)}},
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(v1alpha1.WorkerNodeGroupConfigurationsKubeletConfigurationEqual(tt.a, tt.b)).To(Equal(tt.want))
+		})
+	}
+}
@@ -30,10 +30,9 @@ import (
 // log is for logging in this package.
 var clusterlog = logf.Log.WithName("cluster-resource")
 
-func (r *Cluster) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewWebhookManagedBy(mgr).
-		For(r).
-		Complete()
+// SetupWebhookWithManager registers the Cluster webhooks, checking the Cluster objects against policies.
+func (r *Cluster) SetupWebhookWithManager(mgr ctrl.Manager, policies PolicyValidator) error {
+	return setupWebhookWithPolicies(mgr, r, ClusterKind, policies)
 }
 
 //+kubebuilder:webhook:path=/mutate-anywhere-eks-amazonaws-com-v1alpha1-cluster,mutating=true,failurePolicy=fail,sideEffects=None,groups=anywhere.eks.amazonaws.com,resources=clusters,verbs=create;update,versions=v1alpha1,name=mutation.cluster.anywhere.amazonaws.com,admissionReviewVersions={v1,v1beta1}
@@ -138,6 +137,12 @@ func validateImmutableFieldsCluster(new, old *Cluster) field.ErrorList {
 			field.Forbidden(specPath.Child("ControlPlaneConfiguration.endpoint"), fmt.Sprintf("field is immutable %v", new.Spec.ControlPlaneConfiguration.Endpoint)))
 	}
 
+	if !new.Spec.ControlPlaneConfiguration.ComponentExtraArgsEqual(&old.Spec.ControlPlaneConfiguration) {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(specPath.Child("controlPlaneConfiguration"), "apiServerExtraArgs, controllerManagerExtraArgs and schedulerExtraArgs are immutable"))
+	}
+
 	if !new.Spec.DatacenterRef.Equal(&old.Spec.DatacenterRef) {
 		allErrs = append(
 			allErrs,
@@ -195,6 +200,12 @@ func validateImmutableFieldsCluster(new, old *Cluster) field.ErrorList {
 			field.Forbidden(specPath.Child("GitOpsRef"), fmt.Sprintf("field is immutable %v", new.Spec.GitOpsRef)))
 	}
 
+	if !new.Spec.EncryptionConfiguration.Equal(old.Spec.EncryptionConfiguration) {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(specPath.Child("encryptionConfiguration"), "field is immutable"))
+	}
+
 	if !old.IsSelfManaged() {
 		clusterlog.Info("Cluster config is associated with workload cluster", "name", old.Name)
 
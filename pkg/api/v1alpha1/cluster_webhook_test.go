@@ -273,6 +273,53 @@ func TestManagementClusterValidateUpdateControlPlaneConfigurationLabelsImmutable
 	g.Expect(c.ValidateUpdate(cOld)).NotTo(Succeed())
 }
 
+func TestClusterValidateUpdateControlPlaneComponentExtraArgsImmutable(t *testing.T) {
+	tests := []struct {
+		name   string
+		update func(*v1alpha1.ControlPlaneConfiguration)
+	}{
+		{
+			name: "apiServerExtraArgs changed",
+			update: func(c *v1alpha1.ControlPlaneConfiguration) {
+				c.APIServerExtraArgs["event-ttl"] = "2h"
+			},
+		},
+		{
+			name: "controllerManagerExtraArgs added",
+			update: func(c *v1alpha1.ControlPlaneConfiguration) {
+				c.ControllerManagerExtraArgs = map[string]string{"node-monitor-grace-period": "20s"}
+			},
+		},
+		{
+			name: "schedulerExtraArgs removed",
+			update: func(c *v1alpha1.ControlPlaneConfiguration) {
+				c.SchedulerExtraArgs = nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cOld := createCluster()
+			cOld.Spec.ControlPlaneConfiguration.APIServerExtraArgs = map[string]string{"event-ttl": "1h"}
+			cOld.Spec.ControlPlaneConfiguration.SchedulerExtraArgs = map[string]string{"leader-elect-lease-duration": "30s"}
+			c := cOld.DeepCopy()
+			tt.update(&c.Spec.ControlPlaneConfiguration)
+
+			g := NewWithT(t)
+			g.Expect(c.ValidateUpdate(cOld)).To(MatchError(ContainSubstring("apiServerExtraArgs, controllerManagerExtraArgs and schedulerExtraArgs are immutable")))
+		})
+	}
+}
+
+func TestClusterValidateUpdateControlPlaneComponentExtraArgsUnchanged(t *testing.T) {
+	cOld := createCluster()
+	cOld.Spec.ControlPlaneConfiguration.APIServerExtraArgs = map[string]string{"event-ttl": "1h"}
+	c := cOld.DeepCopy()
+
+	g := NewWithT(t)
+	g.Expect(c.ValidateUpdate(cOld)).To(Succeed())
+}
+
 func TestManagementClusterValidateUpdateControlPlaneConfigurationOldMachineGroupRefImmutable(t *testing.T) {
 	cOld := &v1alpha1.Cluster{
 		Spec: v1alpha1.ClusterSpec{
@@ -635,6 +682,18 @@ func TestClusterValidateUpdateProxyConfigurationImmutable(t *testing.T) {
 	g.Expect(c.ValidateUpdate(cOld)).NotTo(Succeed())
 }
 
+func TestClusterValidateUpdateEncryptionConfigurationImmutable(t *testing.T) {
+	cOld := createCluster()
+	cOld.Spec.EncryptionConfiguration = &v1alpha1.EncryptionConfiguration{
+		Providers: []v1alpha1.EncryptionProvider{{Type: v1alpha1.AESCBCEncryptionProvider}},
+	}
+	c := cOld.DeepCopy()
+	c.Spec.EncryptionConfiguration.Providers = append(c.Spec.EncryptionConfiguration.Providers, v1alpha1.EncryptionProvider{Type: v1alpha1.SecretboxEncryptionProvider})
+
+	g := NewWithT(t)
+	g.Expect(c.ValidateUpdate(cOld)).To(MatchError(ContainSubstring("spec.encryptionConfiguration: Forbidden: field is immutable")))
+}
+
 func TestClusterValidateUpdateProxyConfigurationNoProxyImmutable(t *testing.T) {
 	cOld := createCluster()
 	cOld.Spec.ProxyConfiguration = &v1alpha1.ProxyConfiguration{
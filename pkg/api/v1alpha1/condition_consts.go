@@ -0,0 +1,24 @@
+package v1alpha1
+
+import clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+const (
+	// CertificatesValidCondition reports whether the apiserver, etcd and kubelet certificates served by the
+	// cluster machines are far enough from their expiry date.
+	CertificatesValidCondition clusterv1.ConditionType = "CertificatesValid"
+
+	// CertificatesExpiringReason (Severity=Warning) documents a cluster with certificates expiring soon.
+	CertificatesExpiringReason = "CertificatesExpiring"
+
+	// GitOpsSyncedCondition reports whether the EKS-A objects of a cluster managed with Flux match the
+	// cluster config committed to the gitops repository.
+	GitOpsSyncedCondition clusterv1.ConditionType = "GitOpsSynced"
+
+	// GitOpsDriftDetectedReason (Severity=Warning) documents a cluster whose objects differ from the
+	// committed config.
+	GitOpsDriftDetectedReason = "GitOpsDriftDetected"
+
+	// GitOpsDriftCheckFailedReason documents a cluster whose committed config couldn't be compared
+	// with its objects.
+	GitOpsDriftCheckFailedReason = "GitOpsDriftCheckFailed"
+)
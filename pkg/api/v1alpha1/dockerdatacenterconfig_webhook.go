@@ -0,0 +1,12 @@
+package v1alpha1
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// SetupWebhookWithManager registers the DockerDatacenterConfig webhook, which checks the DockerDatacenterConfig objects against policies.
+func (r *DockerDatacenterConfig) SetupWebhookWithManager(mgr ctrl.Manager, policies PolicyValidator) error {
+	return setupWebhookWithPolicies(mgr, r, DockerDatacenterKind, policies)
+}
+
+//+kubebuilder:webhook:path=/validate-anywhere-eks-amazonaws-com-v1alpha1-dockerdatacenterconfig,mutating=false,failurePolicy=fail,sideEffects=None,groups=anywhere.eks.amazonaws.com,resources=dockerdatacenterconfigs,verbs=create;update,versions=v1alpha1,name=validation.dockerdatacenterconfig.anywhere.amazonaws.com,admissionReviewVersions={v1,v1beta1}
@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"net/url"
@@ -13,14 +14,17 @@ const (
 	RsaAlgorithm     = "rsa"
 	EcdsaAlgorithm   = "ecdsa"
 	Ed25519Algorithm = "ed25519"
+
+	// GitlabDefaultHostname is the GitLab instance used when gitlab.hostname is not set.
+	GitlabDefaultHostname = "gitlab.com"
 )
 
 func validateFluxConfig(config *FluxConfig) error {
-	if config.Spec.Git != nil && config.Spec.Github != nil {
+	switch providers := config.Spec.providersCount(); {
+	case providers > 1:
 		return errors.New("must specify only one provider")
-	}
-	if config.Spec.Git == nil && config.Spec.Github == nil {
-		return errors.New("must specify a provider. Valid options are git and github")
+	case providers == 0:
+		return errors.New("must specify a provider. Valid options are git, github, gitlab and bitbucketServer")
 	}
 	if config.Spec.Github != nil {
 		err := validateGithubProviderConfig(*config.Spec.Github)
@@ -34,6 +38,18 @@ func validateFluxConfig(config *FluxConfig) error {
 			return err
 		}
 	}
+	if config.Spec.Gitlab != nil {
+		err := validateGitlabProviderConfig(*config.Spec.Gitlab)
+		if err != nil {
+			return err
+		}
+	}
+	if config.Spec.BitbucketServer != nil {
+		err := validateBitbucketServerProviderConfig(*config.Spec.BitbucketServer)
+		if err != nil {
+			return err
+		}
+	}
 
 	if len(config.Spec.Branch) > 0 {
 		err := validateGitBranchName(config.Spec.Branch)
@@ -49,6 +65,20 @@ func validateGitProviderConfig(gitProviderConfig GitProviderConfig) error {
 	if len(gitProviderConfig.RepositoryUrl) <= 0 {
 		return errors.New("'repositoryUrl' is not set or empty in gitProviderConfig; repositoryUrl is a required field")
 	}
+	if err := validateRepositoryUrl(gitProviderConfig.RepositoryUrl); err != nil {
+		return err
+	}
+
+	if gitProviderConfig.IsHTTPS() {
+		if len(gitProviderConfig.SshKeyAlgorithm) > 0 {
+			return errors.New("'sshKeyAlgorithm' is only valid with an ssh repositoryUrl in gitProviderConfig")
+		}
+		return validateCaBundle(gitProviderConfig.CaBundle, "gitProviderConfig")
+	}
+
+	if len(gitProviderConfig.CaBundle) > 0 {
+		return errors.New("'caBundle' is only valid with an https repositoryUrl in gitProviderConfig")
+	}
 	if len(gitProviderConfig.SshKeyAlgorithm) > 0 {
 		if err := validateSshKeyAlgorithm(gitProviderConfig.SshKeyAlgorithm); err != nil {
 			return err
@@ -57,7 +87,14 @@ func validateGitProviderConfig(gitProviderConfig GitProviderConfig) error {
 		logger.Info("Warning: 'sshKeyAlgorithm' is not set, defaulting to 'ecdsa'")
 	}
 
-	return validateRepositoryUrl(gitProviderConfig.RepositoryUrl)
+	return nil
+}
+
+// IsHTTPS returns true if the repository is accessed over HTTPS, with basic or token
+// authentication, instead of SSH.
+func (c *GitProviderConfig) IsHTTPS() bool {
+	u, err := url.Parse(c.RepositoryUrl)
+	return err == nil && u.Scheme == "https"
 }
 
 func validateGithubProviderConfig(config GithubProviderConfig) error {
@@ -74,12 +111,67 @@ func validateGithubProviderConfig(config GithubProviderConfig) error {
 	return nil
 }
 
+func validateGitlabProviderConfig(config GitlabProviderConfig) error {
+	if len(config.Owner) <= 0 {
+		return errors.New("'owner' is not set or empty in gitlabProviderConfig; owner is a required field")
+	}
+	if len(config.Repository) <= 0 {
+		return errors.New("'repository' is not set or empty in gitlabProviderConfig; repository is a required field")
+	}
+	if err := validateGitRepoName(config.Repository); err != nil {
+		return err
+	}
+	if err := validateCaBundle(config.CaBundle, "gitlabProviderConfig"); err != nil {
+		return err
+	}
+	if len(config.Hostname) > 0 {
+		return validateGitHostname(config.Hostname)
+	}
+	return nil
+}
+
+func validateBitbucketServerProviderConfig(config BitbucketServerProviderConfig) error {
+	if len(config.Owner) <= 0 {
+		return errors.New("'owner' is not set or empty in bitbucketServerProviderConfig; owner is a required field")
+	}
+	if len(config.Repository) <= 0 {
+		return errors.New("'repository' is not set or empty in bitbucketServerProviderConfig; repository is a required field")
+	}
+	if err := validateGitRepoName(config.Repository); err != nil {
+		return err
+	}
+	if len(config.Hostname) <= 0 {
+		return errors.New("'hostname' is not set or empty in bitbucketServerProviderConfig; hostname is a required field")
+	}
+	if err := validateCaBundle(config.CaBundle, "bitbucketServerProviderConfig"); err != nil {
+		return err
+	}
+	return validateGitHostname(config.Hostname)
+}
+
+// validateCaBundle checks a git provider CA bundle, when set, contains at least one PEM encoded certificate.
+func validateCaBundle(caBundle, providerConfig string) error {
+	if len(caBundle) > 0 && !x509.NewCertPool().AppendCertsFromPEM([]byte(caBundle)) {
+		return fmt.Errorf("'caBundle' in %s doesn't contain any valid PEM encoded certificate", providerConfig)
+	}
+	return nil
+}
+
+// validateGitHostname checks the hostname of a git provider is a host, optionally with a port, and not a URL.
+func validateGitHostname(hostname string) error {
+	u, err := url.Parse("https://" + hostname)
+	if err != nil || u.Host != hostname {
+		return fmt.Errorf("invalid git provider hostname %s, it must be a host name without scheme or path", hostname)
+	}
+	return nil
+}
+
 func validateRepositoryUrl(repositoryUrl string) error {
 	url, err := url.Parse(repositoryUrl)
 	if err != nil {
 		return fmt.Errorf("unable to parse repository url: %v", err)
 	}
-	if url.Scheme != "ssh" {
+	if url.Scheme != "ssh" && url.Scheme != "https" {
 		return fmt.Errorf("invalid repository url scheme: %v", url.Scheme)
 	}
 	return nil
@@ -92,6 +184,23 @@ func validateSshKeyAlgorithm(sshKeyAlgorithm string) error {
 	return nil
 }
 
+func (c *FluxConfigSpec) providersCount() int {
+	count := 0
+	if c.Git != nil {
+		count++
+	}
+	if c.Github != nil {
+		count++
+	}
+	if c.Gitlab != nil {
+		count++
+	}
+	if c.BitbucketServer != nil {
+		count++
+	}
+	return count
+}
+
 func setFluxConfigDefaults(flux *FluxConfig) {
 	if flux == nil {
 		return
@@ -105,4 +214,8 @@ func setFluxConfigDefaults(flux *FluxConfig) {
 	if len(c.Branch) == 0 {
 		c.Branch = FluxDefaultBranch
 	}
+
+	if c.Gitlab != nil && len(c.Gitlab.Hostname) == 0 {
+		c.Gitlab.Hostname = GitlabDefaultHostname
+	}
 }
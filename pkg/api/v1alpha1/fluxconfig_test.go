@@ -14,6 +14,18 @@ const (
 	EksaGitKnownHostsFileEnv  = "EKSA_GIT_KNOWN_HOSTS"
 )
 
+const testGitCaBundle = `-----BEGIN CERTIFICATE-----
+MIIBijCCATGgAwIBAgIUVEsVTeAfEEN5043esr/dRwSxZ+YwCgYIKoZIzj0EAwIw
+GjEYMBYGA1UEAwwPZ2l0LmV4YW1wbGUuY29tMCAXDTI2MTAxNzAyMzQ0NloYDzIx
+MjYwOTIzMDIzNDQ2WjAaMRgwFgYDVQQDDA9naXQuZXhhbXBsZS5jb20wWTATBgcq
+hkjOPQIBBggqhkjOPQMBBwNCAAQwAPVvgbFSQNeReVF44EZaZqz8Yx/V6X/B+ujI
+ZdP5Hw4eKWOExai/TgagGdkxl2x1Lnx2DdhkCe6XWxYqNbZ3o1MwUTAdBgNVHQ4E
+FgQUM749Sbo82GsrBTN+vEZb+v0eLXMwHwYDVR0jBBgwFoAUM749Sbo82GsrBTN+
+vEZb+v0eLXMwDwYDVR0TAQH/BAUwAwEB/zAKBggqhkjOPQQDAgNHADBEAiAMfJvX
+vHcNU9voRG5h+mFk/Z8SpnaDU8sPlxQhAjHOlAIgT5K0XteDp6IcxqevEzHwC+se
+zvTIwcB78DQzNrHXW/w=
+-----END CERTIFICATE-----`
+
 func TestValidateFluxConfig(t *testing.T) {
 	tests := []struct {
 		testName    string
@@ -188,6 +200,226 @@ func TestValidateFluxConfig(t *testing.T) {
 			gitProvider: true,
 			error:       nil,
 		},
+		{
+			testName: "valid https repo url with ca bundle",
+			fluxConfig: &FluxConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       FluxConfigKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-flux-git",
+					Namespace: "default",
+				},
+				Spec: FluxConfigSpec{
+					Git: &GitProviderConfig{
+						RepositoryUrl: "https://git.example.com/username/repo.git",
+						CaBundle:      testGitCaBundle,
+					},
+				},
+			},
+			wantErr: false,
+			error:   nil,
+		},
+		{
+			testName: "https repo url with ssh key algo",
+			fluxConfig: &FluxConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       FluxConfigKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-flux-git",
+					Namespace: "default",
+				},
+				Spec: FluxConfigSpec{
+					Git: &GitProviderConfig{
+						RepositoryUrl:   "https://git.example.com/username/repo.git",
+						SshKeyAlgorithm: RsaAlgorithm,
+					},
+				},
+			},
+			wantErr: true,
+			error:   errors.New("'sshKeyAlgorithm' is only valid with an ssh repositoryUrl in gitProviderConfig"),
+		},
+		{
+			testName: "invalid ca bundle",
+			fluxConfig: &FluxConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       FluxConfigKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-flux-git",
+					Namespace: "default",
+				},
+				Spec: FluxConfigSpec{
+					Git: &GitProviderConfig{
+						RepositoryUrl: "https://git.example.com/username/repo.git",
+						CaBundle:      "not a certificate",
+					},
+				},
+			},
+			wantErr: true,
+			error:   errors.New("'caBundle' in gitProviderConfig doesn't contain any valid PEM encoded certificate"),
+		},
+		{
+			testName: "ssh repo url with ca bundle",
+			fluxConfig: &FluxConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       FluxConfigKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-flux-git",
+					Namespace: "default",
+				},
+				Spec: FluxConfigSpec{
+					Git: &GitProviderConfig{
+						RepositoryUrl: "ssh://git@github.com/username/repo.git",
+						CaBundle:      testGitCaBundle,
+					},
+				},
+			},
+			wantErr: true,
+			error:   errors.New("'caBundle' is only valid with an https repositoryUrl in gitProviderConfig"),
+		},
+		{
+			testName: "valid fluxconfig gitlab",
+			fluxConfig: &FluxConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       FluxConfigKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-flux",
+					Namespace: "default",
+				},
+				Spec: FluxConfigSpec{
+					Gitlab: &GitlabProviderConfig{
+						Owner:      "janedoe/subgroup",
+						Repository: "flux-fleet",
+						Hostname:   "gitlab.internal:8443",
+					},
+				},
+			},
+			wantErr: false,
+			error:   nil,
+		},
+		{
+			testName: "invalid gitlab hostname",
+			fluxConfig: &FluxConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       FluxConfigKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-flux",
+					Namespace: "default",
+				},
+				Spec: FluxConfigSpec{
+					Gitlab: &GitlabProviderConfig{
+						Owner:      "janedoe",
+						Repository: "flux-fleet",
+						Hostname:   "https://gitlab.internal",
+					},
+				},
+			},
+			wantErr: true,
+			error:   errors.New("invalid git provider hostname https://gitlab.internal, it must be a host name without scheme or path"),
+		},
+		{
+			testName: "invalid gitlab ca bundle",
+			fluxConfig: &FluxConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       FluxConfigKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-flux",
+					Namespace: "default",
+				},
+				Spec: FluxConfigSpec{
+					Gitlab: &GitlabProviderConfig{
+						Owner:      "janedoe",
+						Repository: "flux-fleet",
+						Hostname:   "gitlab.internal",
+						CaBundle:   "not a certificate",
+					},
+				},
+			},
+			wantErr: true,
+			error:   errors.New("'caBundle' in gitlabProviderConfig doesn't contain any valid PEM encoded certificate"),
+		},
+		{
+			testName: "valid fluxconfig bitbucket server",
+			fluxConfig: &FluxConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       FluxConfigKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-flux",
+					Namespace: "default",
+				},
+				Spec: FluxConfigSpec{
+					BitbucketServer: &BitbucketServerProviderConfig{
+						Owner:      "PROJ",
+						Repository: "flux-fleet",
+						Hostname:   "bitbucket.internal",
+						CaBundle:   testGitCaBundle,
+					},
+				},
+			},
+			wantErr: false,
+			error:   nil,
+		},
+		{
+			testName: "empty bitbucket server hostname",
+			fluxConfig: &FluxConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       FluxConfigKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-flux",
+					Namespace: "default",
+				},
+				Spec: FluxConfigSpec{
+					BitbucketServer: &BitbucketServerProviderConfig{
+						Owner:      "PROJ",
+						Repository: "flux-fleet",
+					},
+				},
+			},
+			wantErr: true,
+			error:   errors.New("'hostname' is not set or empty in bitbucketServerProviderConfig; hostname is a required field"),
+		},
+		{
+			testName: "multiple providers",
+			fluxConfig: &FluxConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       FluxConfigKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-flux",
+					Namespace: "default",
+				},
+				Spec: FluxConfigSpec{
+					Github: &GithubProviderConfig{
+						Owner:      "janedoe",
+						Repository: "flux-fleet",
+					},
+					Gitlab: &GitlabProviderConfig{
+						Owner:      "janedoe",
+						Repository: "flux-fleet",
+					},
+				},
+			},
+			wantErr: true,
+			error:   errors.New("must specify only one provider"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -208,3 +440,16 @@ func TestValidateFluxConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestSetFluxConfigDefaultsGitlabHostname(t *testing.T) {
+	fluxConfig := &FluxConfig{
+		Spec: FluxConfigSpec{
+			Gitlab: &GitlabProviderConfig{Owner: "janedoe", Repository: "flux-fleet"},
+		},
+	}
+
+	fluxConfig.SetDefaults()
+	if fluxConfig.Spec.Gitlab.Hostname != GitlabDefaultHostname {
+		t.Fatalf("FluxConfig.SetDefaults() gitlab hostname = %s, want %s", fluxConfig.Spec.Gitlab.Hostname, GitlabDefaultHostname)
+	}
+}
@@ -35,6 +35,12 @@ type FluxConfigSpec struct {
 
 	// Used to specify Git provider that will be used to host the git files
 	Git *GitProviderConfig `json:"git,omitempty"`
+
+	// Used to specify GitLab provider to host the Git repo and host the git files
+	Gitlab *GitlabProviderConfig `json:"gitlab,omitempty"`
+
+	// Used to specify Bitbucket Server provider to host the Git repo and host the git files
+	BitbucketServer *BitbucketServerProviderConfig `json:"bitbucketServer,omitempty"`
 }
 
 type GithubProviderConfig struct {
@@ -48,12 +54,49 @@ type GithubProviderConfig struct {
 	Personal bool `json:"personal,omitempty"`
 }
 
+type GitlabProviderConfig struct {
+	// Owner is the user or group name of the Git provider. Subgroups are separated by '/'.
+	Owner string `json:"owner"`
+
+	// Repository name.
+	Repository string `json:"repository"`
+
+	// Hostname of a self-managed GitLab instance. Defaults to gitlab.com
+	Hostname string `json:"hostname,omitempty"`
+
+	// if true, the owner is assumed to be a Git user; otherwise a group.
+	Personal bool `json:"personal,omitempty"`
+
+	// PEM encoded CA bundle used to verify the TLS certificate of a self-managed GitLab instance.
+	CaBundle string `json:"caBundle,omitempty"`
+}
+
+type BitbucketServerProviderConfig struct {
+	// Owner is the project key or, for personal repositories, the user name.
+	Owner string `json:"owner"`
+
+	// Repository name.
+	Repository string `json:"repository"`
+
+	// Hostname of the Bitbucket Server instance.
+	Hostname string `json:"hostname"`
+
+	// if true, the owner is assumed to be a Bitbucket user; otherwise a project.
+	Personal bool `json:"personal,omitempty"`
+
+	// PEM encoded CA bundle used to verify the TLS certificate of the Bitbucket Server instance.
+	CaBundle string `json:"caBundle,omitempty"`
+}
+
 type GitProviderConfig struct {
 	// Repository URL for the repository to be used with flux. Can be either an SSH or HTTPS url.
 	RepositoryUrl string `json:"repositoryUrl"`
 
 	// SSH public key algorithm for the private key specified (rsa, ecdsa, ed25519) (default ecdsa)
 	SshKeyAlgorithm string `json:"sshKeyAlgorithm,omitempty"`
+
+	// PEM encoded CA bundle used to verify the TLS certificate of the git server. Only valid with an HTTPS repository url.
+	CaBundle string `json:"caBundle,omitempty"`
 }
 
 // FluxConfigStatus defines the observed state of FluxConfig.
@@ -97,7 +140,7 @@ func (e *FluxConfigSpec) Equal(n *FluxConfigSpec) bool {
 	if e.ClusterConfigPath != n.ClusterConfigPath {
 		return false
 	}
-	return e.Git.Equal(n.Git) && e.Github.Equal(n.Github)
+	return e.Git.Equal(n.Git) && e.Github.Equal(n.Github) && e.Gitlab.Equal(n.Gitlab) && e.BitbucketServer.Equal(n.BitbucketServer)
 }
 
 func (e *GithubProviderConfig) Equal(n *GithubProviderConfig) bool {
@@ -110,6 +153,26 @@ func (e *GithubProviderConfig) Equal(n *GithubProviderConfig) bool {
 	return *e == *n
 }
 
+func (e *GitlabProviderConfig) Equal(n *GitlabProviderConfig) bool {
+	if e == n {
+		return true
+	}
+	if e == nil || n == nil {
+		return false
+	}
+	return *e == *n
+}
+
+func (e *BitbucketServerProviderConfig) Equal(n *BitbucketServerProviderConfig) bool {
+	if e == n {
+		return true
+	}
+	if e == nil || n == nil {
+		return false
+	}
+	return *e == *n
+}
+
 func (e *GitProviderConfig) Equal(n *GitProviderConfig) bool {
 	if e == n {
 		return true
@@ -0,0 +1,12 @@
+package v1alpha1
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// SetupWebhookWithManager registers the NutanixDatacenterConfig webhook, which checks the NutanixDatacenterConfig objects against policies.
+func (r *NutanixDatacenterConfig) SetupWebhookWithManager(mgr ctrl.Manager, policies PolicyValidator) error {
+	return setupWebhookWithPolicies(mgr, r, NutanixDatacenterKind, policies)
+}
+
+//+kubebuilder:webhook:path=/validate-anywhere-eks-amazonaws-com-v1alpha1-nutanixdatacenterconfig,mutating=false,failurePolicy=fail,sideEffects=None,groups=anywhere.eks.amazonaws.com,resources=nutanixdatacenterconfigs,verbs=create;update,versions=v1alpha1,name=validation.nutanixdatacenterconfig.anywhere.amazonaws.com,admissionReviewVersions={v1,v1beta1}
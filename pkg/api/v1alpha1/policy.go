@@ -0,0 +1,122 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// policyValidationTimeout bounds the time a webhook spends checking an object against the policies,
+// which includes reading them from the API server.
+const policyValidationTimeout = 10 * time.Second
+
+// PolicyValidator validates objects against the policies configured by the management cluster admins.
+type PolicyValidator interface {
+	ValidatePolicies(ctx context.Context, obj runtime.Object) error
+}
+
+// setupWebhookWithPolicies registers a validating webhook for obj that runs its own webhook.Validator
+// validations, if it implements it, and then checks it against the policies in policies.
+// A nil policies only runs the object validations.
+func setupWebhookWithPolicies(mgr ctrl.Manager, obj client.Object, kind string, policies PolicyValidator) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(obj).
+		WithValidator(&policyWebhook{kind: kind, policies: policies}).
+		Complete()
+}
+
+// policyWebhook is an admission.CustomValidator adding the policy validation to the validations of an object.
+type policyWebhook struct {
+	kind     string
+	policies PolicyValidator
+}
+
+var _ admission.CustomValidator = &policyWebhook{}
+
+// ValidateCreate implements admission.CustomValidator.
+func (w *policyWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	if v, ok := obj.(webhook.Validator); ok {
+		if err := v.ValidateCreate(); err != nil {
+			return err
+		}
+	}
+
+	return w.validatePolicies(ctx, obj)
+}
+
+// ValidateUpdate implements admission.CustomValidator.
+func (w *policyWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	if v, ok := newObj.(webhook.Validator); ok {
+		if err := v.ValidateUpdate(oldObj); err != nil {
+			return err
+		}
+	}
+
+	// Only spec changes are checked against the policies, so objects created before a policy
+	// was added can still be updated by the controllers, for example to add finalizers.
+	sameSpec, err := sameSpec(oldObj, newObj)
+	if err != nil {
+		return apierrors.NewBadRequest(err.Error())
+	}
+	if sameSpec {
+		return nil
+	}
+
+	return w.validatePolicies(ctx, newObj)
+}
+
+// ValidateDelete implements admission.CustomValidator.
+func (w *policyWebhook) ValidateDelete(_ context.Context, obj runtime.Object) error {
+	if v, ok := obj.(webhook.Validator); ok {
+		return v.ValidateDelete()
+	}
+
+	return nil
+}
+
+// validatePolicies returns an invalid error for obj if it doesn't follow the policies.
+func (w *policyWebhook) validatePolicies(ctx context.Context, obj runtime.Object) error {
+	if w.policies == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, policyValidationTimeout)
+	defer cancel()
+
+	if err := w.policies.ValidatePolicies(ctx, obj); err != nil {
+		name := ""
+		if accessor, aErr := meta.Accessor(obj); aErr == nil {
+			name = accessor.GetName()
+		}
+		return apierrors.NewInvalid(
+			GroupVersion.WithKind(w.kind).GroupKind(),
+			name,
+			field.ErrorList{field.Forbidden(field.NewPath("spec"), err.Error())},
+		)
+	}
+
+	return nil
+}
+
+func sameSpec(oldObj, newObj runtime.Object) (bool, error) {
+	oldContent, err := runtime.DefaultUnstructuredConverter.ToUnstructured(oldObj)
+	if err != nil {
+		return false, fmt.Errorf("converting old object: %v", err)
+	}
+	newContent, err := runtime.DefaultUnstructuredConverter.ToUnstructured(newObj)
+	if err != nil {
+		return false, fmt.Errorf("converting new object: %v", err)
+	}
+
+	return equality.Semantic.DeepEqual(oldContent["spec"], newContent["spec"]), nil
+}
@@ -0,0 +1,80 @@
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type fakePolicyValidator struct {
+	calls int
+	err   error
+}
+
+func (f *fakePolicyValidator) ValidatePolicies(ctx context.Context, _ runtime.Object) error {
+	f.calls++
+	if _, ok := ctx.Deadline(); !ok {
+		return errors.New("policies validated without a deadline")
+	}
+	return f.err
+}
+
+func TestPolicyWebhookValidateCreatePolicyViolation(t *testing.T) {
+	g := NewWithT(t)
+	policies := &fakePolicyValidator{err: errors.New("insecure datacenters are not allowed")}
+	w := &policyWebhook{kind: NutanixDatacenterKind, policies: policies}
+	dc := &NutanixDatacenterConfig{ObjectMeta: metav1.ObjectMeta{Name: "nutanix"}}
+
+	err := w.ValidateCreate(context.Background(), dc)
+	g.Expect(apierrors.IsInvalid(err)).To(BeTrue())
+	g.Expect(err).To(MatchError(ContainSubstring("insecure datacenters are not allowed")))
+	g.Expect(policies.calls).To(Equal(1))
+}
+
+func TestPolicyWebhookValidateCreateObjectValidationFails(t *testing.T) {
+	g := NewWithT(t)
+	policies := &fakePolicyValidator{}
+	w := &policyWebhook{kind: ClusterKind, policies: policies}
+	c := &Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}}
+	c.Spec.ManagementCluster.Name = "mgmt"
+	c.Spec.KubernetesVersion = "1.1"
+
+	g.Expect(w.ValidateCreate(context.Background(), c)).NotTo(Succeed())
+	g.Expect(policies.calls).To(BeZero())
+}
+
+func TestPolicyWebhookValidateCreateNoPolicies(t *testing.T) {
+	g := NewWithT(t)
+	w := &policyWebhook{kind: TinkerbellDatacenterKind}
+
+	g.Expect(w.ValidateCreate(context.Background(), &TinkerbellDatacenterConfig{})).To(Succeed())
+}
+
+func TestPolicyWebhookValidateUpdateSameSpec(t *testing.T) {
+	g := NewWithT(t)
+	policies := &fakePolicyValidator{err: errors.New("violation")}
+	w := &policyWebhook{kind: DockerDatacenterKind, policies: policies}
+	oldDC := &DockerDatacenterConfig{ObjectMeta: metav1.ObjectMeta{Name: "docker"}}
+	newDC := oldDC.DeepCopy()
+	newDC.Finalizers = []string{"finalizer"}
+
+	g.Expect(w.ValidateUpdate(context.Background(), oldDC, newDC)).To(Succeed())
+	g.Expect(policies.calls).To(BeZero())
+}
+
+func TestPolicyWebhookValidateUpdateSpecChanged(t *testing.T) {
+	g := NewWithT(t)
+	policies := &fakePolicyValidator{err: errors.New("violation")}
+	w := &policyWebhook{kind: TinkerbellDatacenterKind, policies: policies}
+	oldDC := &TinkerbellDatacenterConfig{ObjectMeta: metav1.ObjectMeta{Name: "tinkerbell"}}
+	newDC := oldDC.DeepCopy()
+	newDC.Spec.TinkerbellIP = "1.2.3.4"
+
+	g.Expect(apierrors.IsInvalid(w.ValidateUpdate(context.Background(), oldDC, newDC))).To(BeTrue())
+	g.Expect(policies.calls).To(Equal(1))
+}
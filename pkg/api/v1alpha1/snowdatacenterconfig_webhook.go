@@ -24,10 +24,9 @@ import (
 // log is for logging in this package.
 var snowdatacenterconfiglog = logf.Log.WithName("snowdatacenterconfig-resource")
 
-func (r *SnowDatacenterConfig) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewWebhookManagedBy(mgr).
-		For(r).
-		Complete()
+// SetupWebhookWithManager registers the SnowDatacenterConfig webhooks, checking the SnowDatacenterConfig objects against policies.
+func (r *SnowDatacenterConfig) SetupWebhookWithManager(mgr ctrl.Manager, policies PolicyValidator) error {
+	return setupWebhookWithPolicies(mgr, r, SnowDatacenterKind, policies)
 }
 
 // TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
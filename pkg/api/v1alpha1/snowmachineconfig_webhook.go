@@ -24,10 +24,9 @@ import (
 // log is for logging in this package.
 var snowmachineconfiglog = logf.Log.WithName("snowmachineconfig-resource")
 
-func (r *SnowMachineConfig) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewWebhookManagedBy(mgr).
-		For(r).
-		Complete()
+// SetupWebhookWithManager registers the SnowMachineConfig webhooks, checking the SnowMachineConfig objects against policies.
+func (r *SnowMachineConfig) SetupWebhookWithManager(mgr ctrl.Manager, policies PolicyValidator) error {
+	return setupWebhookWithPolicies(mgr, r, SnowMachineConfigKind, policies)
 }
 
 //+kubebuilder:webhook:path=/mutate-anywhere-eks-amazonaws-com-v1alpha1-snowmachineconfig,mutating=true,failurePolicy=fail,sideEffects=None,groups=anywhere.eks.amazonaws.com,resources=snowmachineconfigs,verbs=create;update,versions=v1alpha1,name=mutation.snowmachineconfig.anywhere.amazonaws.com,admissionReviewVersions={v1,v1beta1}
@@ -30,10 +30,9 @@ import (
 // log is for logging in this package.
 var vspheredatacenterconfiglog = logf.Log.WithName("vspheredatacenterconfig-resource")
 
-func (r *VSphereDatacenterConfig) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewWebhookManagedBy(mgr).
-		For(r).
-		Complete()
+// SetupWebhookWithManager registers the VSphereDatacenterConfig webhooks, checking the VSphereDatacenterConfig objects against policies.
+func (r *VSphereDatacenterConfig) SetupWebhookWithManager(mgr ctrl.Manager, policies PolicyValidator) error {
+	return setupWebhookWithPolicies(mgr, r, VSphereDatacenterKind, policies)
 }
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -15,10 +15,9 @@ import (
 // log is for logging in this package.
 var vspheremachineconfiglog = logf.Log.WithName("vspheremachineconfig-resource")
 
-func (r *VSphereMachineConfig) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewWebhookManagedBy(mgr).
-		For(r).
-		Complete()
+// SetupWebhookWithManager registers the VSphereMachineConfig webhooks, checking the VSphereMachineConfig objects against policies.
+func (r *VSphereMachineConfig) SetupWebhookWithManager(mgr ctrl.Manager, policies PolicyValidator) error {
+	return setupWebhookWithPolicies(mgr, r, VSphereMachineConfigKind, policies)
 }
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
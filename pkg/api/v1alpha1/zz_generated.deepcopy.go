@@ -22,6 +22,7 @@ package v1alpha1
 import (
 	apiv1beta1 "github.com/aws/eks-anywhere/pkg/providers/snow/api/v1beta1"
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/cluster-api/api/v1beta1"
 )
@@ -223,6 +224,56 @@ func (in *AWSIamConfigStatus) DeepCopy() *AWSIamConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditPolicy) DeepCopyInto(out *AuditPolicy) {
+	*out = *in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(AuditPolicyConfigMapRef)
+		**out = **in
+	}
+	if in.LogMaxAge != nil {
+		in, out := &in.LogMaxAge, &out.LogMaxAge
+		*out = new(int)
+		**out = **in
+	}
+	if in.LogMaxBackup != nil {
+		in, out := &in.LogMaxBackup, &out.LogMaxBackup
+		*out = new(int)
+		**out = **in
+	}
+	if in.LogMaxSize != nil {
+		in, out := &in.LogMaxSize, &out.LogMaxSize
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditPolicy.
+func (in *AuditPolicy) DeepCopy() *AuditPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditPolicyConfigMapRef) DeepCopyInto(out *AuditPolicyConfigMapRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditPolicyConfigMapRef.
+func (in *AuditPolicyConfigMapRef) DeepCopy() *AuditPolicyConfigMapRef {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditPolicyConfigMapRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutoScalingConfiguration) DeepCopyInto(out *AutoScalingConfiguration) {
 	*out = *in
@@ -238,6 +289,21 @@ func (in *AutoScalingConfiguration) DeepCopy() *AutoScalingConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BitbucketServerProviderConfig) DeepCopyInto(out *BitbucketServerProviderConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BitbucketServerProviderConfig.
+func (in *BitbucketServerProviderConfig) DeepCopy() *BitbucketServerProviderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BitbucketServerProviderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BundlesRef) DeepCopyInto(out *BundlesRef) {
 	*out = *in
@@ -723,6 +789,21 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(BundlesRef)
 		**out = **in
 	}
+	if in.AuditPolicy != nil {
+		in, out := &in.AuditPolicy, &out.AuditPolicy
+		*out = new(AuditPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EncryptionConfiguration != nil {
+		in, out := &in.EncryptionConfiguration, &out.EncryptionConfiguration
+		*out = new(EncryptionConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSecurity != nil {
+		in, out := &in.PodSecurity, &out.PodSecurity
+		*out = new(PodSecurity)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpec.
@@ -799,6 +880,32 @@ func (in *ControlPlaneConfiguration) DeepCopyInto(out *ControlPlaneConfiguration
 		*out = new(ControlPlaneUpgradeRolloutStrategy)
 		**out = **in
 	}
+	if in.KubeletConfiguration != nil {
+		in, out := &in.KubeletConfiguration, &out.KubeletConfiguration
+		*out = new(KubeletConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.APIServerExtraArgs != nil {
+		in, out := &in.APIServerExtraArgs, &out.APIServerExtraArgs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ControllerManagerExtraArgs != nil {
+		in, out := &in.ControllerManagerExtraArgs, &out.ControllerManagerExtraArgs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SchedulerExtraArgs != nil {
+		in, out := &in.SchedulerExtraArgs, &out.SchedulerExtraArgs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneConfiguration.
@@ -966,6 +1073,53 @@ func (in *EksdReleaseRef) DeepCopy() *EksdReleaseRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EncryptionConfiguration) DeepCopyInto(out *EncryptionConfiguration) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]EncryptionProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionConfiguration.
+func (in *EncryptionConfiguration) DeepCopy() *EncryptionConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(EncryptionConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EncryptionProvider) DeepCopyInto(out *EncryptionProvider) {
+	*out = *in
+	if in.KMS != nil {
+		in, out := &in.KMS, &out.KMS
+		*out = new(KMSConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionProvider.
+func (in *EncryptionProvider) DeepCopy() *EncryptionProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(EncryptionProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Endpoint) DeepCopyInto(out *Endpoint) {
 	*out = *in
@@ -1089,6 +1243,16 @@ func (in *FluxConfigSpec) DeepCopyInto(out *FluxConfigSpec) {
 		*out = new(GitProviderConfig)
 		**out = **in
 	}
+	if in.Gitlab != nil {
+		in, out := &in.Gitlab, &out.Gitlab
+		*out = new(GitlabProviderConfig)
+		**out = **in
+	}
+	if in.BitbucketServer != nil {
+		in, out := &in.BitbucketServer, &out.BitbucketServer
+		*out = new(BitbucketServerProviderConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluxConfigSpec.
@@ -1251,6 +1415,21 @@ func (in *GithubProviderConfig) DeepCopy() *GithubProviderConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitlabProviderConfig) DeepCopyInto(out *GitlabProviderConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitlabProviderConfig.
+func (in *GitlabProviderConfig) DeepCopy() *GitlabProviderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GitlabProviderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in HardwareSelector) DeepCopyInto(out *HardwareSelector) {
 	{
@@ -1272,6 +1451,31 @@ func (in HardwareSelector) DeepCopy() HardwareSelector {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KMSConfiguration) DeepCopyInto(out *KMSConfiguration) {
+	*out = *in
+	if in.CacheSize != nil {
+		in, out := &in.CacheSize, &out.CacheSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KMSConfiguration.
+func (in *KMSConfiguration) DeepCopy() *KMSConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(KMSConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KindnetdConfig) DeepCopyInto(out *KindnetdConfig) {
 	*out = *in
@@ -1287,6 +1491,71 @@ func (in *KindnetdConfig) DeepCopy() *KindnetdConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeletConfiguration) DeepCopyInto(out *KubeletConfiguration) {
+	*out = *in
+	if in.MaxPods != nil {
+		in, out := &in.MaxPods, &out.MaxPods
+		*out = new(int)
+		**out = **in
+	}
+	if in.SystemReserved != nil {
+		in, out := &in.SystemReserved, &out.SystemReserved
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.KubeReserved != nil {
+		in, out := &in.KubeReserved, &out.KubeReserved
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EvictionHard != nil {
+		in, out := &in.EvictionHard, &out.EvictionHard
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EvictionSoft != nil {
+		in, out := &in.EvictionSoft, &out.EvictionSoft
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EvictionSoftGracePeriod != nil {
+		in, out := &in.EvictionSoftGracePeriod, &out.EvictionSoftGracePeriod
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ImageGCHighThresholdPercent != nil {
+		in, out := &in.ImageGCHighThresholdPercent, &out.ImageGCHighThresholdPercent
+		*out = new(int)
+		**out = **in
+	}
+	if in.ImageGCLowThresholdPercent != nil {
+		in, out := &in.ImageGCLowThresholdPercent, &out.ImageGCLowThresholdPercent
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletConfiguration.
+func (in *KubeletConfiguration) DeepCopy() *KubeletConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeletConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagementCluster) DeepCopyInto(out *ManagementCluster) {
 	*out = *in
@@ -1745,6 +2014,26 @@ func (in *PodIAMConfig) DeepCopy() *PodIAMConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSecurity) DeepCopyInto(out *PodSecurity) {
+	*out = *in
+	if in.ExemptNamespaces != nil {
+		in, out := &in.ExemptNamespaces, &out.ExemptNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurity.
+func (in *PodSecurity) DeepCopy() *PodSecurity {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSecurity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Pods) DeepCopyInto(out *Pods) {
 	*out = *in
@@ -2600,6 +2889,11 @@ func (in *WorkerNodeGroupConfiguration) DeepCopyInto(out *WorkerNodeGroupConfigu
 		*out = new(WorkerNodesUpgradeRolloutStrategy)
 		**out = **in
 	}
+	if in.KubeletConfiguration != nil {
+		in, out := &in.KubeletConfiguration, &out.KubeletConfiguration
+		*out = new(KubeletConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerNodeGroupConfiguration.
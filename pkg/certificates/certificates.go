@@ -0,0 +1,146 @@
+package certificates
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// Component identifies what a certificate is used for.
+type Component string
+
+// Components whose certificates are checked.
+const (
+	APIServer    Component = "apiserver"
+	Etcd         Component = "etcd"
+	Kubelet      Component = "kubelet"
+	AWSIamAuthCA Component = "aws-iam-authenticator-ca"
+)
+
+const (
+	apiServerPort = 6443
+	etcdPort      = 2379
+	kubeletPort   = 10250
+)
+
+// DefaultExpiryWindow is how long before expiring a certificate is reported as expiring soon by default.
+const DefaultExpiryWindow = 30 * 24 * time.Hour
+
+// Target is a TLS endpoint in a machine that serves a certificate.
+type Target struct {
+	Component Component
+	Machine   string
+	// Address is the host:port of the endpoint.
+	Address string
+}
+
+// Certificate is the expiry information of a certificate.
+type Certificate struct {
+	Component Component `json:"component"`
+	Machine   string    `json:"machine,omitempty"`
+	// Source is where the certificate was read from, a host:port address or a secret.
+	Source   string       `json:"source"`
+	Subject  string       `json:"subject,omitempty"`
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+	// Error is set when the certificate could not be read.
+	Error string `json:"error,omitempty"`
+}
+
+// ExpiresWithin returns true if the certificate has already expired or it expires before now + window.
+// Certificates that could not be read are never reported as expiring.
+func (c Certificate) ExpiresWithin(now time.Time, window time.Duration) bool {
+	return c.NotAfter != nil && c.NotAfter.Time.Before(now.Add(window))
+}
+
+// Expiring returns the certificates that expire before now + window.
+func Expiring(certs []Certificate, now time.Time, window time.Duration) []Certificate {
+	var expiring []Certificate
+	for _, c := range certs {
+		if c.ExpiresWithin(now, window) {
+			expiring = append(expiring, c)
+		}
+	}
+	return expiring
+}
+
+// TargetsForMachines returns the TLS endpoints serving the apiserver, etcd and kubelet certificates
+// in the machines of a cluster. Stacked etcd is served from the control plane machines, otherwise
+// from the external etcd machines. Machines without an address are skipped.
+func TargetsForMachines(machines []clusterv1.Machine, stackedEtcd bool) []Target {
+	var targets []Target
+	for i := range machines {
+		m := &machines[i]
+		address := machineAddress(m)
+		if address == "" {
+			continue
+		}
+
+		target := func(c Component, port int) Target {
+			return Target{Component: c, Machine: m.Name, Address: net.JoinHostPort(address, strconv.Itoa(port))}
+		}
+
+		if _, ok := m.Labels[clusterv1.MachineEtcdClusterLabelName]; ok {
+			targets = append(targets, target(Etcd, etcdPort))
+			continue
+		}
+
+		if _, ok := m.Labels[clusterv1.MachineControlPlaneLabelName]; ok {
+			targets = append(targets, target(APIServer, apiServerPort))
+			if stackedEtcd {
+				targets = append(targets, target(Etcd, etcdPort))
+			}
+		}
+		targets = append(targets, target(Kubelet, kubeletPort))
+	}
+
+	return targets
+}
+
+// ParsePEM reads the first certificate in PEM encoded data.
+func ParsePEM(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing certificate: %v", err)
+	}
+
+	return cert, nil
+}
+
+func newCertificate(t Target, cert *x509.Certificate, err error) Certificate {
+	c := Certificate{
+		Component: t.Component,
+		Machine:   t.Machine,
+		Source:    t.Address,
+	}
+	if err != nil {
+		c.Error = err.Error()
+		return c
+	}
+
+	notAfter := metav1.NewTime(cert.NotAfter)
+	c.Subject = cert.Subject.CommonName
+	c.NotAfter = &notAfter
+	return c
+}
+
+func machineAddress(m *clusterv1.Machine) string {
+	for _, t := range []clusterv1.MachineAddressType{clusterv1.MachineExternalIP, clusterv1.MachineInternalIP} {
+		for _, a := range m.Status.Addresses {
+			if a.Type == t && a.Address != "" {
+				return a.Address
+			}
+		}
+	}
+	return ""
+}
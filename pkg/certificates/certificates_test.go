@@ -0,0 +1,139 @@
+package certificates_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/certificates"
+)
+
+var now = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestTargetsForMachinesStackedEtcd(t *testing.T) {
+	g := NewWithT(t)
+	machines := []clusterv1.Machine{
+		machine("cp-1", map[string]string{clusterv1.MachineControlPlaneLabelName: ""}, "10.0.0.1"),
+		machine("md-1", nil, "10.0.0.2"),
+		machine("md-2", nil, ""),
+	}
+
+	g.Expect(certificates.TargetsForMachines(machines, true)).To(Equal([]certificates.Target{
+		{Component: certificates.APIServer, Machine: "cp-1", Address: "10.0.0.1:6443"},
+		{Component: certificates.Etcd, Machine: "cp-1", Address: "10.0.0.1:2379"},
+		{Component: certificates.Kubelet, Machine: "cp-1", Address: "10.0.0.1:10250"},
+		{Component: certificates.Kubelet, Machine: "md-1", Address: "10.0.0.2:10250"},
+	}))
+}
+
+func TestTargetsForMachinesExternalEtcd(t *testing.T) {
+	g := NewWithT(t)
+	machines := []clusterv1.Machine{
+		machine("etcd-1", map[string]string{clusterv1.MachineEtcdClusterLabelName: "cluster-etcd"}, "10.0.0.3"),
+		machine("cp-1", map[string]string{clusterv1.MachineControlPlaneLabelName: ""}, "10.0.0.1"),
+	}
+
+	g.Expect(certificates.TargetsForMachines(machines, false)).To(Equal([]certificates.Target{
+		{Component: certificates.Etcd, Machine: "etcd-1", Address: "10.0.0.3:2379"},
+		{Component: certificates.APIServer, Machine: "cp-1", Address: "10.0.0.1:6443"},
+		{Component: certificates.Kubelet, Machine: "cp-1", Address: "10.0.0.1:10250"},
+	}))
+}
+
+func TestExpiring(t *testing.T) {
+	g := NewWithT(t)
+	expired := certificate("expired", now.Add(-time.Hour))
+	expiring := certificate("expiring", now.Add(24*time.Hour))
+	valid := certificate("valid", now.Add(365*24*time.Hour))
+	failed := certificates.Certificate{Component: certificates.Kubelet, Machine: "failed", Error: "connection refused"}
+
+	g.Expect(certificates.Expiring([]certificates.Certificate{expired, expiring, valid, failed}, now, certificates.DefaultExpiryWindow)).To(
+		Equal([]certificates.Certificate{expired, expiring}),
+	)
+}
+
+func TestCertificateStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		cert certificates.Certificate
+		want string
+	}{
+		{name: "ok", cert: certificate("m", now.Add(365*24*time.Hour)), want: certificates.StatusOK},
+		{name: "expiring", cert: certificate("m", now.Add(24*time.Hour)), want: certificates.StatusExpiring},
+		{name: "expired", cert: certificate("m", now), want: certificates.StatusExpired},
+		{name: "error", cert: certificates.Certificate{Error: "timeout"}, want: certificates.StatusError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			NewWithT(t).Expect(tt.cert.Status(now, certificates.DefaultExpiryWindow)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestParsePEM(t *testing.T) {
+	g := NewWithT(t)
+	notAfter := now.Add(time.Hour)
+
+	cert, err := certificates.ParsePEM(pemCertificate(t, "ca", notAfter))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cert.Subject.CommonName).To(Equal("ca"))
+	g.Expect(cert.NotAfter).To(Equal(notAfter))
+}
+
+func TestParsePEMError(t *testing.T) {
+	g := NewWithT(t)
+	_, err := certificates.ParsePEM([]byte("not a certificate"))
+	g.Expect(err).To(MatchError(ContainSubstring("no PEM data found")))
+}
+
+func machine(name string, labels map[string]string, address string) clusterv1.Machine {
+	m := clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	if address != "" {
+		m.Status.Addresses = clusterv1.MachineAddresses{{Type: clusterv1.MachineExternalIP, Address: address}}
+	}
+	return m
+}
+
+func certificate(machine string, notAfter time.Time) certificates.Certificate {
+	t := metav1.NewTime(notAfter)
+	return certificates.Certificate{Component: certificates.Kubelet, Machine: machine, NotAfter: &t}
+}
+
+func x509Certificate(t *testing.T, commonName string, notAfter time.Time) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+func pemCertificate(t *testing.T, commonName string, notAfter time.Time) []byte {
+	cert, _ := x509Certificate(t, commonName, notAfter)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+}
@@ -0,0 +1,91 @@
+package certificates
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+const defaultDialTimeout = 10 * time.Second
+
+// Prober reads the certificate served by a TLS endpoint.
+type Prober interface {
+	PeerCertificate(ctx context.Context, address string) (*x509.Certificate, error)
+}
+
+// TLSProber reads certificates with a TLS handshake. It doesn't verify the certificate chain
+// nor present a client certificate, so it can read certificates from endpoints that require client auth.
+type TLSProber struct {
+	timeout time.Duration
+}
+
+// NewTLSProber returns a new TLSProber.
+func NewTLSProber() *TLSProber {
+	return &TLSProber{timeout: defaultDialTimeout}
+}
+
+// PeerCertificate returns the leaf certificate served by address.
+func (p *TLSProber) PeerCertificate(ctx context.Context, address string) (*x509.Certificate, error) {
+	var leaf *x509.Certificate
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: p.timeout},
+		Config: &tls.Config{
+			// #nosec G402 The certificate is only read to check its expiry date, nothing is sent over the connection.
+			InsecureSkipVerify: true,
+			VerifyConnection: func(s tls.ConnectionState) error {
+				if len(s.PeerCertificates) > 0 {
+					leaf = s.PeerCertificates[0]
+				}
+				return nil
+			},
+		},
+	}
+
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	// Servers requiring a client certificate fail the handshake after sending their own.
+	if leaf != nil {
+		if conn != nil {
+			conn.Close()
+		}
+		return leaf, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("connecting to %s: %v", address, err)
+	}
+	conn.Close()
+
+	return nil, fmt.Errorf("no certificate served by %s", address)
+}
+
+// Checker reads the certificates of a list of targets.
+type Checker struct {
+	prober Prober
+}
+
+// NewChecker returns a new Checker.
+func NewChecker(prober Prober) *Checker {
+	return &Checker{prober: prober}
+}
+
+// Check reads the certificates served by all targets concurrently. Targets that can't be reached
+// are returned with an Error instead of failing the whole check. Certificates are returned in the
+// same order as targets.
+func (c *Checker) Check(ctx context.Context, targets []Target) []Certificate {
+	certs := make([]Certificate, len(targets))
+	var wg sync.WaitGroup
+	for i, t := range targets {
+		wg.Add(1)
+		go func(i int, t Target) {
+			defer wg.Done()
+			cert, err := c.prober.PeerCertificate(ctx, t.Address)
+			certs[i] = newCertificate(t, cert, err)
+		}(i, t)
+	}
+	wg.Wait()
+
+	return certs
+}
@@ -0,0 +1,77 @@
+package certificates_test
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/certificates"
+	"github.com/aws/eks-anywhere/pkg/certificates/mocks"
+)
+
+func TestTLSProberPeerCertificate(t *testing.T) {
+	g := NewWithT(t)
+	notAfter := time.Now().Add(time.Hour).Truncate(time.Second).UTC()
+	cert, key := x509Certificate(t, "kube-apiserver", notAfter)
+
+	server := httptest.NewUnstartedServer(http.NotFoundHandler())
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{cert.Raw}, PrivateKey: key}},
+		ClientAuth:   tls.RequireAnyClientCert,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	got, err := certificates.NewTLSProber().PeerCertificate(context.Background(), server.Listener.Addr().String())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got.Subject.CommonName).To(Equal("kube-apiserver"))
+	g.Expect(got.NotAfter).To(Equal(notAfter))
+}
+
+func TestTLSProberPeerCertificateError(t *testing.T) {
+	g := NewWithT(t)
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	g.Expect(err).NotTo(HaveOccurred())
+	address := l.Addr().String()
+	l.Close()
+
+	_, err = certificates.NewTLSProber().PeerCertificate(context.Background(), address)
+	g.Expect(err).To(MatchError(ContainSubstring("connecting to " + address)))
+}
+
+func TestCheckerCheck(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	prober := mocks.NewMockProber(gomock.NewController(t))
+	notAfter := now.Add(time.Hour)
+	cert, _ := x509Certificate(t, "etcd", notAfter)
+	targets := []certificates.Target{
+		{Component: certificates.Etcd, Machine: "cp-1", Address: "10.0.0.1:2379"},
+		{Component: certificates.Kubelet, Machine: "cp-1", Address: "10.0.0.1:10250"},
+	}
+
+	prober.EXPECT().PeerCertificate(ctx, "10.0.0.1:2379").Return(cert, nil)
+	prober.EXPECT().PeerCertificate(ctx, "10.0.0.1:10250").Return(nil, errors.New("connection refused"))
+
+	certs := certificates.NewChecker(prober).Check(ctx, targets)
+	g.Expect(certs).To(HaveLen(2))
+	g.Expect(certs[0].Component).To(Equal(certificates.Etcd))
+	g.Expect(certs[0].Source).To(Equal("10.0.0.1:2379"))
+	g.Expect(certs[0].Subject).To(Equal("etcd"))
+	g.Expect(certs[0].NotAfter.Time).To(Equal(notAfter))
+	g.Expect(certs[0].Error).To(BeEmpty())
+	g.Expect(certs[1]).To(Equal(certificates.Certificate{
+		Component: certificates.Kubelet,
+		Machine:   "cp-1",
+		Source:    "10.0.0.1:10250",
+		Error:     "connection refused",
+	}))
+}
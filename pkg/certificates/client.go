@@ -0,0 +1,136 @@
+package certificates
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/crypto"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+var (
+	eksaClusterResourceType = fmt.Sprintf("clusters.%s", v1alpha1.GroupVersion.Group)
+	capiMachineResourceType = fmt.Sprintf("machines.%s", clusterv1.GroupVersion.Group)
+)
+
+// KubectlClient reads and updates the cluster objects in the management cluster.
+type KubectlClient interface {
+	GetObject(ctx context.Context, resourceType, name, namespace, kubeconfig string, obj runtime.Object) error
+	ListObjects(ctx context.Context, resourceType, namespace, kubeconfig string, list kubernetes.ObjectList) error
+	ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
+	MergePatchResource(ctx context.Context, resourceType, name, patch, kubeconfig, namespace string) error
+}
+
+// Keys of the AWS IAM Authenticator CA secret.
+const (
+	awsIamAuthCACertKey = "cert.pem"
+	awsIamAuthCAKeyKey  = "key.pem"
+)
+
+// Client checks and rotates the certificates of EKS-A clusters.
+type Client struct {
+	kubectl KubectlClient
+	checker *Checker
+	certgen crypto.CertificateGenerator
+	now     func() time.Time
+}
+
+// NewClient returns a new Client that reads certificates with prober and generates
+// new AWS IAM Authenticator CAs with certgen.
+func NewClient(kubectl KubectlClient, prober Prober, certgen crypto.CertificateGenerator) *Client {
+	return &Client{
+		kubectl: kubectl,
+		checker: NewChecker(prober),
+		certgen: certgen,
+		now:     time.Now,
+	}
+}
+
+// Check reads the apiserver, etcd and kubelet certificates from all the machines of a cluster and,
+// if the cluster uses AWS IAM Authenticator, its CA certificate.
+func (c *Client) Check(ctx context.Context, managementCluster *types.Cluster, clusterName, namespace string) ([]Certificate, error) {
+	kubeconfig := managementCluster.KubeconfigFile
+	cluster, err := c.cluster(ctx, kubeconfig, clusterName, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	machines := &clusterv1.MachineList{}
+	if err = c.kubectl.ListObjects(ctx, capiMachineResourceType, constants.EksaSystemNamespace, kubeconfig, machines); err != nil {
+		return nil, fmt.Errorf("listing machines for cluster %s: %v", clusterName, err)
+	}
+
+	certs := c.checker.Check(ctx, TargetsForMachines(MachinesForCluster(machines.Items, clusterName), cluster.Spec.ExternalEtcdConfiguration == nil))
+
+	if UsesAWSIamAuth(cluster) {
+		certs = append(certs, c.awsIamAuthCA(ctx, kubeconfig, clusterName))
+	}
+
+	return certs, nil
+}
+
+func (c *Client) cluster(ctx context.Context, kubeconfig, clusterName, namespace string) (*v1alpha1.Cluster, error) {
+	cluster := &v1alpha1.Cluster{}
+	if err := c.kubectl.GetObject(ctx, eksaClusterResourceType, clusterName, namespace, kubeconfig, cluster); err != nil {
+		return nil, fmt.Errorf("getting EKS-A cluster %s: %v", clusterName, err)
+	}
+	return cluster, nil
+}
+
+func (c *Client) awsIamAuthCA(ctx context.Context, kubeconfig, clusterName string) Certificate {
+	name := AWSIamAuthCASecretName(clusterName)
+	secret := &corev1.Secret{}
+	err := c.kubectl.GetObject(ctx, "secret", name, constants.EksaSystemNamespace, kubeconfig, secret)
+	if err != nil {
+		err = fmt.Errorf("getting secret %s: %v", name, err)
+	}
+
+	return AWSIamAuthCACertificate(clusterName, secret, err)
+}
+
+// AWSIamAuthCACertificate returns the AWS IAM Authenticator CA certificate of a cluster stored in secret.
+// readErr is the error reading the secret, if any, and it's reported as the Certificate error.
+func AWSIamAuthCACertificate(clusterName string, secret *corev1.Secret, readErr error) Certificate {
+	t := Target{Component: AWSIamAuthCA, Address: fmt.Sprintf("secret/%s", AWSIamAuthCASecretName(clusterName))}
+	if readErr != nil {
+		return newCertificate(t, nil, readErr)
+	}
+
+	cert, err := ParsePEM(secret.Data[awsIamAuthCACertKey])
+	return newCertificate(t, cert, err)
+}
+
+// AWSIamAuthCASecretName returns the name of the secret in the management cluster
+// holding the AWS IAM Authenticator CA of a cluster.
+func AWSIamAuthCASecretName(clusterName string) string {
+	return fmt.Sprintf("%s-aws-iam-authenticator-ca", clusterName)
+}
+
+// MachinesForCluster returns the machines labeled as part of a cluster.
+func MachinesForCluster(machines []clusterv1.Machine, clusterName string) []clusterv1.Machine {
+	clusterMachines := make([]clusterv1.Machine, 0, len(machines))
+	for _, m := range machines {
+		if m.Labels[clusterv1.ClusterLabelName] == clusterName {
+			clusterMachines = append(clusterMachines, m)
+		}
+	}
+	return clusterMachines
+}
+
+// UsesAWSIamAuth returns true if the cluster authenticates users with AWS IAM Authenticator.
+func UsesAWSIamAuth(cluster *v1alpha1.Cluster) bool {
+	for _, r := range cluster.Spec.IdentityProviderRefs {
+		if r.Kind == v1alpha1.AWSIamConfigKind {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,250 @@
+package certificates_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/certificates"
+	"github.com/aws/eks-anywhere/pkg/certificates/mocks"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	cryptomocks "github.com/aws/eks-anywhere/pkg/crypto/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const kubeconfig = "mgmt.kubeconfig"
+
+type clientTest struct {
+	*WithT
+	ctx     context.Context
+	kubectl *mocks.MockKubectlClient
+	prober  *mocks.MockProber
+	certgen *cryptomocks.MockCertificateGenerator
+	client  *certificates.Client
+	mgmt    *types.Cluster
+	cluster *v1alpha1.Cluster
+}
+
+func newClientTest(t *testing.T) *clientTest {
+	ctrl := gomock.NewController(t)
+	kubectl := mocks.NewMockKubectlClient(ctrl)
+	prober := mocks.NewMockProber(ctrl)
+	certgen := cryptomocks.NewMockCertificateGenerator(ctrl)
+
+	return &clientTest{
+		WithT:   NewWithT(t),
+		ctx:     context.Background(),
+		kubectl: kubectl,
+		prober:  prober,
+		certgen: certgen,
+		client:  certificates.NewClient(kubectl, prober, certgen),
+		mgmt:    &types.Cluster{Name: "mgmt", KubeconfigFile: kubeconfig},
+		cluster: &v1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "w01", Namespace: "default"},
+		},
+	}
+}
+
+func (tt *clientTest) expectGetCluster() {
+	tt.kubectl.EXPECT().GetObject(tt.ctx, "clusters.anywhere.eks.amazonaws.com", "w01", "default", kubeconfig, &v1alpha1.Cluster{}).
+		DoAndReturn(func(_ context.Context, _, _, _, _ string, obj runtime.Object) error {
+			tt.cluster.DeepCopyInto(obj.(*v1alpha1.Cluster))
+			return nil
+		})
+}
+
+func TestClientCheck(t *testing.T) {
+	tt := newClientTest(t)
+	tt.cluster.Spec.IdentityProviderRefs = []v1alpha1.Ref{{Kind: v1alpha1.AWSIamConfigKind, Name: "aws-iam"}}
+	notAfter := now.Add(time.Hour)
+	cert, _ := x509Certificate(t, "kubelet", notAfter)
+	caNotAfter := now.Add(100 * 365 * 24 * time.Hour)
+
+	tt.expectGetCluster()
+	tt.kubectl.EXPECT().ListObjects(tt.ctx, "machines.cluster.x-k8s.io", constants.EksaSystemNamespace, kubeconfig, &clusterv1.MachineList{}).
+		DoAndReturn(func(_ context.Context, _, _, _ string, list kubernetes.ObjectList) error {
+			list.(*clusterv1.MachineList).Items = []clusterv1.Machine{
+				machine("w01-md-1", map[string]string{clusterv1.ClusterLabelName: "w01"}, "10.0.0.2"),
+				machine("w02-md-1", map[string]string{clusterv1.ClusterLabelName: "w02"}, "10.0.0.3"),
+			}
+			return nil
+		})
+	tt.prober.EXPECT().PeerCertificate(tt.ctx, "10.0.0.2:10250").Return(cert, nil)
+	tt.kubectl.EXPECT().GetObject(tt.ctx, "secret", "w01-aws-iam-authenticator-ca", constants.EksaSystemNamespace, kubeconfig, &corev1.Secret{}).
+		DoAndReturn(func(_ context.Context, _, _, _, _ string, obj runtime.Object) error {
+			obj.(*corev1.Secret).Data = map[string][]byte{"cert.pem": pemCertificate(t, "aws-iam-authenticator", caNotAfter)}
+			return nil
+		})
+
+	certs, err := tt.client.Check(tt.ctx, tt.mgmt, "w01", "default")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(certs).To(HaveLen(2))
+	tt.Expect(certs[0].Machine).To(Equal("w01-md-1"))
+	tt.Expect(certs[0].NotAfter.Time).To(Equal(notAfter))
+	tt.Expect(certs[1].Component).To(Equal(certificates.AWSIamAuthCA))
+	tt.Expect(certs[1].Source).To(Equal("secret/w01-aws-iam-authenticator-ca"))
+	tt.Expect(certs[1].NotAfter.Time).To(Equal(caNotAfter))
+}
+
+func TestClientCheckGetClusterError(t *testing.T) {
+	tt := newClientTest(t)
+	tt.kubectl.EXPECT().GetObject(tt.ctx, "clusters.anywhere.eks.amazonaws.com", "w01", "default", kubeconfig, &v1alpha1.Cluster{}).
+		Return(errors.New("not found"))
+
+	_, err := tt.client.Check(tt.ctx, tt.mgmt, "w01", "default")
+	tt.Expect(err).To(MatchError(ContainSubstring("getting EKS-A cluster w01: not found")))
+}
+
+func TestClientRotateStackedEtcd(t *testing.T) {
+	tt := newClientTest(t)
+	tt.expectGetCluster()
+	tt.kubectl.EXPECT().MergePatchResource(tt.ctx, "kubeadmcontrolplanes.controlplane.cluster.x-k8s.io", "w01",
+		gomock.Any(), kubeconfig, constants.EksaSystemNamespace).
+		DoAndReturn(func(_ context.Context, _, _, patch, _, _ string) error {
+			tt.Expect(patch).To(HavePrefix(`{"spec":{"rolloutAfter":"`))
+			return nil
+		})
+	tt.kubectl.EXPECT().ListObjects(tt.ctx, "machinedeployments.cluster.x-k8s.io", constants.EksaSystemNamespace, kubeconfig, &clusterv1.MachineDeploymentList{}).
+		DoAndReturn(func(_ context.Context, _, _, _ string, list kubernetes.ObjectList) error {
+			list.(*clusterv1.MachineDeploymentList).Items = []clusterv1.MachineDeployment{
+				machineDeployment("w01-md-0", "w01"),
+				machineDeployment("w02-md-0", "w02"),
+			}
+			return nil
+		})
+	tt.kubectl.EXPECT().MergePatchResource(tt.ctx, "machinedeployments.cluster.x-k8s.io", "w01-md-0",
+		gomock.Any(), kubeconfig, constants.EksaSystemNamespace).
+		DoAndReturn(func(_ context.Context, _, _, patch, _, _ string) error {
+			tt.Expect(patch).To(ContainSubstring(`"cluster.x-k8s.io/restartedAt"`))
+			return nil
+		})
+
+	rollouts, err := tt.client.Rotate(tt.ctx, tt.mgmt, "w01", "default")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(rollouts).To(Equal([]certificates.Rollout{
+		{Kind: "KubeadmControlPlane", Name: "w01"},
+		{Kind: "MachineDeployment", Name: "w01-md-0"},
+	}))
+}
+
+func TestClientRotateExternalEtcd(t *testing.T) {
+	tt := newClientTest(t)
+	tt.cluster.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{Count: 3}
+	tt.expectGetCluster()
+	tt.kubectl.EXPECT().GetObject(tt.ctx, "etcdadmclusters.etcdcluster.cluster.x-k8s.io", "w01-etcd", constants.EksaSystemNamespace, kubeconfig, &etcdv1.EtcdadmCluster{}).
+		DoAndReturn(func(_ context.Context, _, _, _, _ string, obj runtime.Object) error {
+			obj.(*etcdv1.EtcdadmCluster).Spec.InfrastructureTemplate = corev1.ObjectReference{
+				APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+				Kind:       "VSphereMachineTemplate",
+				Name:       "w01-etcd-template-1",
+			}
+			return nil
+		})
+	tt.kubectl.EXPECT().GetObject(tt.ctx, "vspheremachinetemplates.infrastructure.cluster.x-k8s.io", "w01-etcd-template-1",
+		constants.EksaSystemNamespace, kubeconfig, &unstructured.Unstructured{}).
+		DoAndReturn(func(_ context.Context, _, _, _, _ string, obj runtime.Object) error {
+			u := obj.(*unstructured.Unstructured)
+			u.SetAPIVersion("infrastructure.cluster.x-k8s.io/v1beta1")
+			u.SetKind("VSphereMachineTemplate")
+			u.SetName("w01-etcd-template-1")
+			u.SetNamespace(constants.EksaSystemNamespace)
+			u.SetResourceVersion("1234")
+			return nil
+		})
+	tt.kubectl.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.mgmt, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *types.Cluster, data []byte) error {
+			tt.Expect(string(data)).To(ContainSubstring("name: w01-etcd-template-2"))
+			tt.Expect(string(data)).NotTo(ContainSubstring("resourceVersion"))
+			return nil
+		})
+	tt.kubectl.EXPECT().MergePatchResource(tt.ctx, "etcdadmclusters.etcdcluster.cluster.x-k8s.io", "w01-etcd",
+		`{"spec":{"infrastructureTemplate":{"name":"w01-etcd-template-2"}}}`, kubeconfig, constants.EksaSystemNamespace)
+	tt.kubectl.EXPECT().MergePatchResource(tt.ctx, "kubeadmcontrolplanes.controlplane.cluster.x-k8s.io", "w01",
+		gomock.Any(), kubeconfig, constants.EksaSystemNamespace)
+	tt.kubectl.EXPECT().ListObjects(tt.ctx, "machinedeployments.cluster.x-k8s.io", constants.EksaSystemNamespace, kubeconfig, &clusterv1.MachineDeploymentList{})
+
+	rollouts, err := tt.client.Rotate(tt.ctx, tt.mgmt, "w01", "default")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(rollouts).To(Equal([]certificates.Rollout{
+		{Kind: "EtcdadmCluster", Name: "w01-etcd"},
+		{Kind: "KubeadmControlPlane", Name: "w01"},
+	}))
+}
+
+func TestClientRotateAWSIamAuthCA(t *testing.T) {
+	tt := newClientTest(t)
+	tt.cluster.Spec.IdentityProviderRefs = []v1alpha1.Ref{{Kind: v1alpha1.AWSIamConfigKind, Name: "aws-iam"}}
+	tt.expectGetCluster()
+	tt.kubectl.EXPECT().GetObject(tt.ctx, "secret", "w01-aws-iam-authenticator-ca", constants.EksaSystemNamespace, kubeconfig, &corev1.Secret{}).
+		DoAndReturn(func(_ context.Context, _, _, _, _ string, obj runtime.Object) error {
+			secret := obj.(*corev1.Secret)
+			secret.Name = "w01-aws-iam-authenticator-ca"
+			secret.Namespace = constants.EksaSystemNamespace
+			secret.Labels = map[string]string{"clusterctl.cluster.x-k8s.io/move": "true"}
+			secret.ResourceVersion = "1234"
+			secret.Data = map[string][]byte{"cert.pem": []byte("old-cert"), "key.pem": []byte("old-key")}
+			return nil
+		})
+	tt.certgen.EXPECT().GenerateIamAuthSelfSignCertKeyPair().Return([]byte("new-cert"), []byte("new-key"), nil)
+	tt.kubectl.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.mgmt, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *types.Cluster, data []byte) error {
+			secret := &corev1.Secret{}
+			tt.Expect(yaml.UnmarshalStrict(data, secret)).To(Succeed())
+			tt.Expect(secret.Kind).To(Equal("Secret"))
+			tt.Expect(secret.Labels).To(HaveKeyWithValue("clusterctl.cluster.x-k8s.io/move", "true"))
+			tt.Expect(secret.ResourceVersion).To(BeEmpty())
+			tt.Expect(secret.Data).To(Equal(map[string][]byte{"cert.pem": []byte("new-cert"), "key.pem": []byte("new-key")}))
+			return nil
+		})
+	tt.kubectl.EXPECT().MergePatchResource(tt.ctx, "kubeadmcontrolplanes.controlplane.cluster.x-k8s.io", "w01",
+		gomock.Any(), kubeconfig, constants.EksaSystemNamespace)
+	tt.kubectl.EXPECT().ListObjects(tt.ctx, "machinedeployments.cluster.x-k8s.io", constants.EksaSystemNamespace, kubeconfig, &clusterv1.MachineDeploymentList{})
+
+	rollouts, err := tt.client.Rotate(tt.ctx, tt.mgmt, "w01", "default")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(rollouts).To(Equal([]certificates.Rollout{
+		{Kind: "Secret", Name: "w01-aws-iam-authenticator-ca"},
+		{Kind: "KubeadmControlPlane", Name: "w01"},
+	}))
+}
+
+func TestClientRotateAWSIamAuthCAGenerateError(t *testing.T) {
+	tt := newClientTest(t)
+	tt.cluster.Spec.IdentityProviderRefs = []v1alpha1.Ref{{Kind: v1alpha1.AWSIamConfigKind, Name: "aws-iam"}}
+	tt.expectGetCluster()
+	tt.kubectl.EXPECT().GetObject(tt.ctx, "secret", "w01-aws-iam-authenticator-ca", constants.EksaSystemNamespace, kubeconfig, &corev1.Secret{})
+	tt.certgen.EXPECT().GenerateIamAuthSelfSignCertKeyPair().Return(nil, nil, errors.New("no entropy"))
+
+	_, err := tt.client.Rotate(tt.ctx, tt.mgmt, "w01", "default")
+	tt.Expect(err).To(MatchError(ContainSubstring("generating aws-iam-authenticator ca for cluster w01: no entropy")))
+}
+
+func TestClientRotateControlPlaneError(t *testing.T) {
+	tt := newClientTest(t)
+	tt.expectGetCluster()
+	tt.kubectl.EXPECT().MergePatchResource(tt.ctx, "kubeadmcontrolplanes.controlplane.cluster.x-k8s.io", "w01",
+		gomock.Any(), kubeconfig, constants.EksaSystemNamespace).Return(errors.New("forbidden"))
+
+	_, err := tt.client.Rotate(tt.ctx, tt.mgmt, "w01", "default")
+	tt.Expect(err).To(MatchError(ContainSubstring("rolling out control plane for cluster w01: forbidden")))
+}
+
+func machineDeployment(name, clusterName string) clusterv1.MachineDeployment {
+	return clusterv1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       clusterv1.MachineDeploymentSpec{ClusterName: clusterName},
+	}
+}
@@ -0,0 +1,101 @@
+package certificates
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Output formats supported by PrintCertificates.
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputYAML  = "yaml"
+)
+
+// Certificate statuses, relative to an expiry window.
+const (
+	StatusOK       = "OK"
+	StatusExpiring = "EXPIRING"
+	StatusExpired  = "EXPIRED"
+	StatusError    = "ERROR"
+)
+
+// ValidateOutput returns an error if format is not a supported output format.
+func ValidateOutput(format string) error {
+	switch format {
+	case OutputTable, OutputJSON, OutputYAML:
+		return nil
+	default:
+		return fmt.Errorf("invalid output format [%s], valid options are: %s, %s, %s", format, OutputTable, OutputJSON, OutputYAML)
+	}
+}
+
+// Status returns the status of the certificate at now for the given expiry window.
+func (c Certificate) Status(now time.Time, window time.Duration) string {
+	switch {
+	case c.NotAfter == nil:
+		return StatusError
+	case !c.NotAfter.Time.After(now):
+		return StatusExpired
+	case c.ExpiresWithin(now, window):
+		return StatusExpiring
+	default:
+		return StatusOK
+	}
+}
+
+type certificateStatus struct {
+	Certificate `json:",inline"`
+	Status      string `json:"status"`
+}
+
+// PrintCertificates writes the certificates with their status to w in the given format.
+func PrintCertificates(w io.Writer, format string, certs []Certificate, now time.Time, window time.Duration) error {
+	switch format {
+	case OutputJSON, OutputYAML:
+		statuses := make([]certificateStatus, 0, len(certs))
+		for _, c := range certs {
+			statuses = append(statuses, certificateStatus{Certificate: c, Status: c.Status(now, window)})
+		}
+		return printSerialized(w, format, statuses)
+	case OutputTable:
+	default:
+		return ValidateOutput(format)
+	}
+
+	t := tabwriter.NewWriter(w, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(t, "COMPONENT\tMACHINE\tSOURCE\tEXPIRES\tSTATUS")
+	for _, c := range certs {
+		expires := c.Error
+		if c.NotAfter != nil {
+			expires = c.NotAfter.UTC().Format(time.RFC3339)
+		}
+		fmt.Fprintf(t, "%s\t%s\t%s\t%s\t%s\n", c.Component, c.Machine, c.Source, expires, c.Status(now, window))
+	}
+	if err := t.Flush(); err != nil {
+		return fmt.Errorf("failed flushing table writer: %v", err)
+	}
+	return nil
+}
+
+func printSerialized(w io.Writer, format string, obj interface{}) error {
+	var content []byte
+	var err error
+	if format == OutputJSON {
+		content, err = json.MarshalIndent(obj, "", "  ")
+		content = append(content, '\n')
+	} else {
+		content, err = yaml.Marshal(obj)
+	}
+	if err != nil {
+		return fmt.Errorf("failed serializing output to %s: %v", format, err)
+	}
+
+	_, err = w.Write(content)
+	return err
+}
@@ -0,0 +1,39 @@
+package certificates_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/certificates"
+)
+
+func TestPrintCertificatesTable(t *testing.T) {
+	g := NewWithT(t)
+	certs := []certificates.Certificate{
+		certificate("w01-md-1", now.Add(24*time.Hour)),
+		{Component: certificates.Etcd, Machine: "w01-cp-1", Source: "10.0.0.1:2379", Error: "timeout"},
+	}
+	out := &strings.Builder{}
+
+	g.Expect(certificates.PrintCertificates(out, certificates.OutputTable, certs, now, certificates.DefaultExpiryWindow)).To(Succeed())
+	g.Expect(out.String()).To(ContainSubstring("COMPONENT"))
+	g.Expect(out.String()).To(MatchRegexp(`kubelet\s+w01-md-1\s+2023-01-02T00:00:00Z\s+EXPIRING`))
+	g.Expect(out.String()).To(MatchRegexp(`etcd\s+w01-cp-1\s+10.0.0.1:2379\s+timeout\s+ERROR`))
+}
+
+func TestPrintCertificatesJSON(t *testing.T) {
+	g := NewWithT(t)
+	out := &strings.Builder{}
+
+	g.Expect(certificates.PrintCertificates(out, certificates.OutputJSON, []certificates.Certificate{certificate("w01-md-1", now)}, now, time.Hour)).To(Succeed())
+	g.Expect(out.String()).To(ContainSubstring(`"machine": "w01-md-1"`))
+	g.Expect(out.String()).To(ContainSubstring(`"status": "EXPIRED"`))
+}
+
+func TestPrintCertificatesInvalidOutput(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(certificates.PrintCertificates(&strings.Builder{}, "xml", nil, now, time.Hour)).To(MatchError(ContainSubstring("invalid output format [xml]")))
+}
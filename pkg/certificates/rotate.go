@@ -0,0 +1,183 @@
+package certificates
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// restartedAtAnnotation triggers a MachineDeployment rollout when set in its machine template,
+// the same way clusterctl alpha rollout restart does.
+const restartedAtAnnotation = "cluster.x-k8s.io/restartedAt"
+
+var (
+	kubeadmControlPlaneResourceType = fmt.Sprintf("kubeadmcontrolplanes.%s", controlplanev1.GroupVersion.Group)
+	machineDeploymentResourceType   = fmt.Sprintf("machinedeployments.%s", clusterv1.GroupVersion.Group)
+	etcdadmClusterResourceType      = fmt.Sprintf("etcdadmclusters.%s", etcdv1.GroupVersion.Group)
+)
+
+// Rollout is a CAPI object that was updated to replace its machines.
+type Rollout struct {
+	Kind string
+	Name string
+}
+
+// Rotate renews the apiserver, etcd and kubelet certificates of a cluster by rolling out all its machines,
+// which get new certificates when they join the cluster. External etcd machines are rolled out first by
+// pointing the EtcdadmCluster to a copy of its machine template, then the control plane with the KubeadmControlPlane
+// rolloutAfter and finally the worker nodes by annotating the MachineDeployments machine templates.
+// If the cluster uses AWS IAM Authenticator its CA is replaced before rolling out the control plane,
+// whose machines read it from the CA secret when they join the cluster.
+func (c *Client) Rotate(ctx context.Context, managementCluster *types.Cluster, clusterName, namespace string) ([]Rollout, error) {
+	kubeconfig := managementCluster.KubeconfigFile
+	cluster, err := c.cluster(ctx, kubeconfig, clusterName, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	now := c.now().UTC().Format(time.RFC3339)
+	var rollouts []Rollout
+
+	if cluster.Spec.ExternalEtcdConfiguration != nil {
+		rollout, err := c.rolloutEtcd(ctx, managementCluster, cluster)
+		if err != nil {
+			return nil, err
+		}
+		rollouts = append(rollouts, *rollout)
+	}
+
+	if UsesAWSIamAuth(cluster) {
+		rollout, err := c.renewAWSIamAuthCA(ctx, managementCluster, clusterName)
+		if err != nil {
+			return nil, err
+		}
+		rollouts = append(rollouts, *rollout)
+	}
+
+	logger.V(3).Info("Rolling out control plane", "cluster", clusterName)
+	patch := fmt.Sprintf(`{"spec":{"rolloutAfter":%q}}`, now)
+	if err = c.kubectl.MergePatchResource(ctx, kubeadmControlPlaneResourceType, clusterName, patch, kubeconfig, constants.EksaSystemNamespace); err != nil {
+		return nil, fmt.Errorf("rolling out control plane for cluster %s: %v", clusterName, err)
+	}
+	rollouts = append(rollouts, Rollout{Kind: "KubeadmControlPlane", Name: clusterName})
+
+	mds := &clusterv1.MachineDeploymentList{}
+	if err = c.kubectl.ListObjects(ctx, machineDeploymentResourceType, constants.EksaSystemNamespace, kubeconfig, mds); err != nil {
+		return nil, fmt.Errorf("listing machine deployments for cluster %s: %v", clusterName, err)
+	}
+
+	patch = fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`, restartedAtAnnotation, now)
+	for _, md := range mds.Items {
+		if md.Spec.ClusterName != clusterName {
+			continue
+		}
+		logger.V(3).Info("Rolling out worker nodes", "machineDeployment", md.Name)
+		if err = c.kubectl.MergePatchResource(ctx, machineDeploymentResourceType, md.Name, patch, kubeconfig, constants.EksaSystemNamespace); err != nil {
+			return nil, fmt.Errorf("rolling out machine deployment %s: %v", md.Name, err)
+		}
+		rollouts = append(rollouts, Rollout{Kind: "MachineDeployment", Name: md.Name})
+	}
+
+	return rollouts, nil
+}
+
+// renewAWSIamAuthCA replaces the certificate and key in the AWS IAM Authenticator CA secret of a cluster
+// with a new self-signed pair. Machines already running keep the previous CA until they are rolled out.
+func (c *Client) renewAWSIamAuthCA(ctx context.Context, managementCluster *types.Cluster, clusterName string) (*Rollout, error) {
+	name := AWSIamAuthCASecretName(clusterName)
+	secret := &corev1.Secret{}
+	if err := c.kubectl.GetObject(ctx, "secret", name, constants.EksaSystemNamespace, managementCluster.KubeconfigFile, secret); err != nil {
+		return nil, fmt.Errorf("getting secret %s: %v", name, err)
+	}
+
+	cert, key, err := c.certgen.GenerateIamAuthSelfSignCertKeyPair()
+	if err != nil {
+		return nil, fmt.Errorf("generating aws-iam-authenticator ca for cluster %s: %v", clusterName, err)
+	}
+
+	secret.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"}
+	secret.ResourceVersion = ""
+	secret.UID = ""
+	secret.ManagedFields = nil
+	secret.Data = map[string][]byte{
+		awsIamAuthCACertKey: cert,
+		awsIamAuthCAKeyKey:  key,
+	}
+
+	content, err := yaml.Marshal(secret)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling secret %s: %v", name, err)
+	}
+
+	logger.V(3).Info("Renewing aws-iam-authenticator ca", "cluster", clusterName)
+	if err = c.kubectl.ApplyKubeSpecFromBytes(ctx, managementCluster, content); err != nil {
+		return nil, fmt.Errorf("renewing aws-iam-authenticator ca for cluster %s: %v", clusterName, err)
+	}
+
+	return &Rollout{Kind: "Secret", Name: name}, nil
+}
+
+// rolloutEtcd replaces the external etcd machines of a cluster. EtcdadmCluster doesn't support rolloutAfter,
+// so the machines are replaced by moving it to a copy of its current machine template.
+func (c *Client) rolloutEtcd(ctx context.Context, managementCluster *types.Cluster, cluster *v1alpha1.Cluster) (*Rollout, error) {
+	kubeconfig := managementCluster.KubeconfigFile
+	etcdName := fmt.Sprintf("%s-etcd", cluster.Name)
+	etcd := &etcdv1.EtcdadmCluster{}
+	if err := c.kubectl.GetObject(ctx, etcdadmClusterResourceType, etcdName, constants.EksaSystemNamespace, kubeconfig, etcd); err != nil {
+		return nil, fmt.Errorf("getting etcdadm cluster %s: %v", etcdName, err)
+	}
+
+	ref := etcd.Spec.InfrastructureTemplate
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return nil, fmt.Errorf("parsing etcd machine template api version: %v", err)
+	}
+
+	template := &unstructured.Unstructured{}
+	templateResourceType := fmt.Sprintf("%ss.%s", strings.ToLower(ref.Kind), gv.Group)
+	if err = c.kubectl.GetObject(ctx, templateResourceType, ref.Name, constants.EksaSystemNamespace, kubeconfig, template); err != nil {
+		return nil, fmt.Errorf("getting etcd machine template %s: %v", ref.Name, err)
+	}
+
+	newName := clusterapi.IncrementNameWithFallbackDefault(ref.Name, clusterapi.DefaultObjectName(ref.Name))
+	template.SetName(newName)
+	template.SetResourceVersion("")
+	template.SetUID("")
+	template.SetManagedFields(nil)
+	template.SetGeneration(0)
+	unstructured.RemoveNestedField(template.Object, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(template.Object, "status")
+
+	content, err := yaml.Marshal(template.Object)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling etcd machine template %s: %v", newName, err)
+	}
+
+	logger.V(3).Info("Rolling out etcd machines", "cluster", cluster.Name, "machineTemplate", newName)
+	if err = c.kubectl.ApplyKubeSpecFromBytes(ctx, managementCluster, content); err != nil {
+		return nil, fmt.Errorf("creating etcd machine template %s: %v", newName, err)
+	}
+
+	patch := fmt.Sprintf(`{"spec":{"infrastructureTemplate":{"name":%q}}}`, newName)
+	if err = c.kubectl.MergePatchResource(ctx, etcdadmClusterResourceType, etcdName, patch, kubeconfig, constants.EksaSystemNamespace); err != nil {
+		return nil, fmt.Errorf("rolling out etcd machines for cluster %s: %v", cluster.Name, err)
+	}
+
+	return &Rollout{Kind: "EtcdadmCluster", Name: etcdName}, nil
+}
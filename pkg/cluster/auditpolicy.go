@@ -0,0 +1,100 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+const configMapKind = "ConfigMap"
+
+func auditPolicyEntry() *ConfigManagerEntry {
+	return &ConfigManagerEntry{
+		APIObjectMapping: map[string]APIObjectGenerator{
+			configMapKind: func() APIObject {
+				return &corev1.ConfigMap{}
+			},
+		},
+		Processors: []ParsedProcessor{processAuditPolicyConfigMap},
+		Validations: []Validation{
+			validateAuditPolicyConfigMap,
+		},
+	}
+}
+
+func processAuditPolicyConfigMap(c *Config, objects ObjectLookup) {
+	ref := auditPolicyConfigMapRef(c.Cluster)
+	if ref == nil {
+		return
+	}
+
+	if cm := objects.GetFromRef(corev1.SchemeGroupVersion.String(), anywherev1.Ref{Kind: configMapKind, Name: ref.Name}); cm != nil {
+		c.AuditPolicyConfigMap = cm.(*corev1.ConfigMap)
+	}
+}
+
+func validateAuditPolicyConfigMap(c *Config) error {
+	ref := auditPolicyConfigMapRef(c.Cluster)
+	if ref == nil {
+		return nil
+	}
+
+	if c.AuditPolicyConfigMap == nil {
+		return fmt.Errorf("ConfigMap %s referenced in auditPolicy.configMapRef not found", ref.Name)
+	}
+	if err := validateSameNamespace(c, c.AuditPolicyConfigMap); err != nil {
+		return err
+	}
+
+	policy, ok := c.AuditPolicyConfigMap.Data[ref.KeyOrDefault()]
+	if !ok {
+		return fmt.Errorf("ConfigMap %s referenced in auditPolicy.configMapRef doesn't contain key %s", ref.Name, ref.KeyOrDefault())
+	}
+	if err := anywherev1.ValidateAuditPolicy(policy); err != nil {
+		return fmt.Errorf("ConfigMap %s referenced in auditPolicy.configMapRef: %v", ref.Name, err)
+	}
+
+	return nil
+}
+
+func getAuditPolicyConfigMap(ctx context.Context, client Client, c *Config) error {
+	ref := auditPolicyConfigMapRef(c.Cluster)
+	if ref == nil {
+		return nil
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := client.Get(ctx, ref.Name, c.Cluster.Namespace, cm); err != nil {
+		return err
+	}
+
+	c.AuditPolicyConfigMap = cm
+
+	return nil
+}
+
+func auditPolicyConfigMapRef(cluster *anywherev1.Cluster) *anywherev1.AuditPolicyConfigMapRef {
+	if cluster.Spec.AuditPolicy == nil {
+		return nil
+	}
+	return cluster.Spec.AuditPolicy.ConfigMapRef
+}
+
+// AuditPolicy returns the audit policy set by the user in the cluster spec, either inline or in the referenced ConfigMap.
+// It returns an empty string when the cluster uses the default EKS Anywhere audit policy.
+func (c *Config) AuditPolicy() string {
+	auditPolicy := c.Cluster.Spec.AuditPolicy
+	if auditPolicy == nil {
+		return ""
+	}
+	if auditPolicy.Inline != "" {
+		return auditPolicy.Inline
+	}
+	if auditPolicy.ConfigMapRef != nil && c.AuditPolicyConfigMap != nil {
+		return c.AuditPolicyConfigMap.Data[auditPolicy.ConfigMapRef.KeyOrDefault()]
+	}
+	return ""
+}
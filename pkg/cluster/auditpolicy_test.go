@@ -0,0 +1,160 @@
+package cluster_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/cluster/mocks"
+)
+
+const auditPolicyConfig = `apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  name: my-cluster
+  namespace: default
+spec:
+  auditPolicy:
+    configMapRef:
+      name: my-audit-policy
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: my-audit-policy
+  namespace: default
+data:
+  policy.yaml: |
+    apiVersion: audit.k8s.io/v1
+    kind: Policy
+    rules:
+    - level: Metadata
+`
+
+func TestParseConfigAuditPolicyConfigMap(t *testing.T) {
+	g := NewWithT(t)
+	config, err := cluster.ParseConfig([]byte(auditPolicyConfig))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.AuditPolicyConfigMap).NotTo(BeNil())
+	g.Expect(config.AuditPolicyConfigMap.Name).To(Equal("my-audit-policy"))
+	g.Expect(config.AuditPolicy()).To(Equal("apiVersion: audit.k8s.io/v1\nkind: Policy\nrules:\n- level: Metadata"))
+	g.Expect(config.ChildObjects()).To(ContainElement(config.AuditPolicyConfigMap))
+}
+
+func TestConfigAuditPolicyInline(t *testing.T) {
+	g := NewWithT(t)
+	config := &cluster.Config{
+		Cluster: &anywherev1.Cluster{
+			Spec: anywherev1.ClusterSpec{
+				AuditPolicy: &anywherev1.AuditPolicy{Inline: "policy"},
+			},
+		},
+	}
+	g.Expect(config.AuditPolicy()).To(Equal("policy"))
+
+	config.Cluster.Spec.AuditPolicy = nil
+	g.Expect(config.AuditPolicy()).To(BeEmpty())
+}
+
+func TestConfigManagerValidateAuditPolicyConfigMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*cluster.Config)
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			mutate: func(*cluster.Config) {},
+		},
+		{
+			name: "missing",
+			mutate: func(c *cluster.Config) {
+				c.AuditPolicyConfigMap = nil
+			},
+			wantErr: "ConfigMap my-audit-policy referenced in auditPolicy.configMapRef not found",
+		},
+		{
+			name: "different namespace",
+			mutate: func(c *cluster.Config) {
+				c.AuditPolicyConfigMap.Namespace = "other"
+			},
+			wantErr: "ConfigMap and Cluster objects must have the same namespace specified",
+		},
+		{
+			name: "missing key",
+			mutate: func(c *cluster.Config) {
+				c.Cluster.Spec.AuditPolicy.ConfigMapRef.Key = "audit.yaml"
+			},
+			wantErr: "ConfigMap my-audit-policy referenced in auditPolicy.configMapRef doesn't contain key audit.yaml",
+		},
+		{
+			name: "invalid policy",
+			mutate: func(c *cluster.Config) {
+				c.AuditPolicyConfigMap.Data["policy.yaml"] = "kind: Pod"
+			},
+			wantErr: "ConfigMap my-audit-policy referenced in auditPolicy.configMapRef: invalid audit policy",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			config, err := cluster.ParseConfig([]byte(auditPolicyConfig))
+			g.Expect(err).NotTo(HaveOccurred())
+			tt.mutate(config)
+
+			err = cluster.ValidateConfig(config)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(MatchError(ContainSubstring("auditPolicy")))
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestDefaultConfigClientBuilderAuditPolicyConfigMap(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	b := cluster.NewDefaultConfigClientBuilder()
+	ctrl := gomock.NewController(t)
+	client := mocks.NewMockClient(ctrl)
+	cluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "default",
+		},
+		Spec: anywherev1.ClusterSpec{
+			AuditPolicy: &anywherev1.AuditPolicy{
+				ConfigMapRef: &anywherev1.AuditPolicyConfigMapRef{Name: "my-audit-policy"},
+			},
+		},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-audit-policy",
+			Namespace: "default",
+		},
+		Data: map[string]string{"policy.yaml": "policy"},
+	}
+
+	client.EXPECT().Get(ctx, "my-audit-policy", "default", &corev1.ConfigMap{}).DoAndReturn(
+		func(ctx context.Context, name, namespace string, obj runtime.Object) error {
+			cm := obj.(*corev1.ConfigMap)
+			cm.ObjectMeta = configMap.ObjectMeta
+			cm.Data = configMap.Data
+			return nil
+		},
+	)
+
+	config, err := b.Build(ctx, client, cluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.AuditPolicyConfigMap).To(Equal(configMap))
+	g.Expect(config.AuditPolicy()).To(Equal("policy"))
+}
@@ -10,9 +10,16 @@ func NewDefaultConfigClientBuilder() *ConfigClientBuilder {
 		getSnowDatacenter,
 		getSnowMachineConfigs,
 		getSnowIdentitySecret,
+		getTinkerbellDatacenter,
+		getTinkerbellMachineConfigs,
+		getCloudStackDatacenter,
+		getCloudStackMachineConfigs,
+		getNutanixDatacenter,
+		getNutanixMachineConfigs,
 		getOIDC,
 		getAWSIam,
 		getGitOps,
 		getFluxConfig,
+		getAuditPolicyConfigMap,
 	)
 }
@@ -7,6 +7,8 @@ import (
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
@@ -65,3 +67,99 @@ func TestConfigClientBuilderBuildError(t *testing.T) {
 	g.Expect(err).To(MatchError(ContainSubstring("processor error")))
 	g.Expect(timesCalled).To(Equal(1), "processor should be called 1 times")
 }
+
+func TestDefaultConfigClientBuilderNutanixCluster(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	b := cluster.NewDefaultConfigClientBuilder()
+	ctrl := gomock.NewController(t)
+	client := mocks.NewMockClient(ctrl)
+	cluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "default",
+		},
+		Spec: anywherev1.ClusterSpec{
+			DatacenterRef: anywherev1.Ref{
+				Kind: anywherev1.NutanixDatacenterKind,
+				Name: "datacenter",
+			},
+			ControlPlaneConfiguration: anywherev1.ControlPlaneConfiguration{
+				MachineGroupRef: &anywherev1.Ref{
+					Kind: anywherev1.NutanixMachineConfigKind,
+					Name: "machine-1",
+				},
+			},
+			WorkerNodeGroupConfigurations: []anywherev1.WorkerNodeGroupConfiguration{
+				{
+					MachineGroupRef: &anywherev1.Ref{
+						Kind: anywherev1.NutanixMachineConfigKind,
+						Name: "machine-2",
+					},
+				},
+				{
+					MachineGroupRef: &anywherev1.Ref{
+						Kind: anywherev1.VSphereMachineConfigKind, // Should not process this one
+						Name: "machine-3",
+					},
+				},
+			},
+		},
+	}
+	datacenter := &anywherev1.NutanixDatacenterConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "datacenter",
+			Namespace: "default",
+		},
+		Spec: anywherev1.NutanixDatacenterConfigSpec{
+			Endpoint: "prism.nutanix.com",
+			Port:     9440,
+		},
+	}
+	machineControlPlane := &anywherev1.NutanixMachineConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machine-1",
+			Namespace: "default",
+		},
+	}
+	machineWorker := &anywherev1.NutanixMachineConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machine-2",
+			Namespace: "default",
+		},
+	}
+
+	client.EXPECT().Get(ctx, "datacenter", "default", &anywherev1.NutanixDatacenterConfig{}).Return(nil).DoAndReturn(
+		func(ctx context.Context, name, namespace string, obj runtime.Object) error {
+			d := obj.(*anywherev1.NutanixDatacenterConfig)
+			d.ObjectMeta = datacenter.ObjectMeta
+			d.Spec = datacenter.Spec
+			return nil
+		},
+	)
+
+	client.EXPECT().Get(ctx, "machine-1", "default", &anywherev1.NutanixMachineConfig{}).Return(nil).DoAndReturn(
+		func(ctx context.Context, name, namespace string, obj runtime.Object) error {
+			m := obj.(*anywherev1.NutanixMachineConfig)
+			m.ObjectMeta = machineControlPlane.ObjectMeta
+			return nil
+		},
+	)
+
+	client.EXPECT().Get(ctx, "machine-2", "default", &anywherev1.NutanixMachineConfig{}).Return(nil).DoAndReturn(
+		func(ctx context.Context, name, namespace string, obj runtime.Object) error {
+			m := obj.(*anywherev1.NutanixMachineConfig)
+			m.ObjectMeta = machineWorker.ObjectMeta
+			return nil
+		},
+	)
+
+	config, err := b.Build(ctx, client, cluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config).NotTo(BeNil())
+	g.Expect(config.Cluster).To(Equal(cluster))
+	g.Expect(config.NutanixDatacenter).To(Equal(datacenter))
+	g.Expect(len(config.NutanixMachineConfigs)).To(Equal(2))
+	g.Expect(config.NutanixMachineConfigs["machine-1"]).To(Equal(machineControlPlane))
+	g.Expect(config.NutanixMachineConfigs["machine-2"]).To(Equal(machineWorker))
+}
@@ -1,6 +1,10 @@
 package cluster
 
-import anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+import (
+	"context"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
 
 func cloudstackEntry() *ConfigManagerEntry {
 	return &ConfigManagerEntry{
@@ -88,3 +92,42 @@ func processCloudStackMachineConfig(c *Config, objects ObjectLookup, machineRef
 
 	c.CloudStackMachineConfigs[m.GetName()] = m.(*anywherev1.CloudStackMachineConfig)
 }
+
+func getCloudStackDatacenter(ctx context.Context, client Client, c *Config) error {
+	if c.Cluster.Spec.DatacenterRef.Kind != anywherev1.CloudStackDatacenterKind {
+		return nil
+	}
+
+	datacenter := &anywherev1.CloudStackDatacenterConfig{}
+	if err := client.Get(ctx, c.Cluster.Spec.DatacenterRef.Name, c.Cluster.Namespace, datacenter); err != nil {
+		return err
+	}
+
+	c.CloudStackDatacenter = datacenter
+	return nil
+}
+
+func getCloudStackMachineConfigs(ctx context.Context, client Client, c *Config) error {
+	if c.Cluster.Spec.DatacenterRef.Kind != anywherev1.CloudStackDatacenterKind {
+		return nil
+	}
+
+	if c.CloudStackMachineConfigs == nil {
+		c.CloudStackMachineConfigs = map[string]*anywherev1.CloudStackMachineConfig{}
+	}
+
+	for _, machineRef := range c.Cluster.MachineConfigRefs() {
+		if machineRef.Kind != anywherev1.CloudStackMachineConfigKind {
+			continue
+		}
+
+		machine := &anywherev1.CloudStackMachineConfig{}
+		if err := client.Get(ctx, machineRef.Name, c.Cluster.Namespace, machine); err != nil {
+			return err
+		}
+
+		c.CloudStackMachineConfigs[machine.Name] = machine
+	}
+
+	return nil
+}
@@ -1,5 +1,12 @@
 package cluster
 
+import (
+	"fmt"
+	"strings"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
 func clusterEntry() *ConfigManagerEntry {
 	return &ConfigManagerEntry{
 		Defaulters: []Defaulter{
@@ -12,6 +19,86 @@ func clusterEntry() *ConfigManagerEntry {
 			func(c *Config) error {
 				return c.Cluster.Validate()
 			},
+			validateKubeletConfigurationOSFamily,
 		},
 	}
 }
+
+// validateKubeletConfigurationOSFamily checks the node groups with a kubeletConfiguration don't run Bottlerocket.
+// The Bottlerocket bootstrap of the Cluster API version in use only writes the node labels, taints and provider id
+// to the Bottlerocket kubernetes settings, so none of the kubeletConfiguration fields can be set on those nodes.
+func validateKubeletConfigurationOSFamily(c *Config) error {
+	cp := c.Cluster.Spec.ControlPlaneConfiguration
+	if fields := kubeletConfigurationFields(cp.KubeletConfiguration); len(fields) > 0 && machineConfigOSFamily(c, cp.MachineGroupRef) == anywherev1.Bottlerocket {
+		return fmt.Errorf("kubeletConfiguration for control plane is not supported for %s, its bootstrap can't set %s",
+			anywherev1.Bottlerocket, strings.Join(fields, ", "))
+	}
+	for _, w := range c.Cluster.Spec.WorkerNodeGroupConfigurations {
+		if fields := kubeletConfigurationFields(w.KubeletConfiguration); len(fields) > 0 && machineConfigOSFamily(c, w.MachineGroupRef) == anywherev1.Bottlerocket {
+			return fmt.Errorf("kubeletConfiguration for worker node group %s is not supported for %s, its bootstrap can't set %s",
+				w.Name, anywherev1.Bottlerocket, strings.Join(fields, ", "))
+		}
+	}
+	return nil
+}
+
+// kubeletConfigurationFields returns the names of the fields set in a kubeletConfiguration, none if it's nil.
+func kubeletConfigurationFields(k *anywherev1.KubeletConfiguration) []string {
+	if k == nil {
+		return nil
+	}
+	fields := []struct {
+		name string
+		set  bool
+	}{
+		{name: "maxPods", set: k.MaxPods != nil},
+		{name: "systemReserved", set: len(k.SystemReserved) > 0},
+		{name: "kubeReserved", set: len(k.KubeReserved) > 0},
+		{name: "evictionHard", set: len(k.EvictionHard) > 0},
+		{name: "evictionSoft", set: len(k.EvictionSoft) > 0},
+		{name: "evictionSoftGracePeriod", set: len(k.EvictionSoftGracePeriod) > 0},
+		{name: "imageGCHighThresholdPercent", set: k.ImageGCHighThresholdPercent != nil},
+		{name: "imageGCLowThresholdPercent", set: k.ImageGCLowThresholdPercent != nil},
+		{name: "topologyManagerPolicy", set: k.TopologyManagerPolicy != ""},
+		{name: "cpuManagerPolicy", set: k.CPUManagerPolicy != ""},
+	}
+
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if f.set {
+			names = append(names, f.name)
+		}
+	}
+	return names
+}
+
+// machineConfigOSFamily returns the OS family of the machine config referenced by a node group,
+// or an empty OS family if the config doesn't contain it.
+func machineConfigOSFamily(c *Config, ref *anywherev1.Ref) anywherev1.OSFamily {
+	if ref == nil {
+		return ""
+	}
+	switch ref.Kind {
+	case anywherev1.VSphereMachineConfigKind:
+		if m := c.VsphereMachineConfig(ref.Name); m != nil {
+			return m.OSFamily()
+		}
+	case anywherev1.CloudStackMachineConfigKind:
+		if m := c.CloudStackMachineConfig(ref.Name); m != nil {
+			return m.OSFamily()
+		}
+	case anywherev1.SnowMachineConfigKind:
+		if m := c.SnowMachineConfig(ref.Name); m != nil {
+			return m.OSFamily()
+		}
+	case anywherev1.NutanixMachineConfigKind:
+		if m := c.NutanixMachineConfig(ref.Name); m != nil {
+			return m.OSFamily()
+		}
+	case anywherev1.TinkerbellMachineConfigKind:
+		if m := c.TinkerbellMachineConfig(ref.Name); m != nil {
+			return m.OSFamily()
+		}
+	}
+	return ""
+}
@@ -16,15 +16,19 @@ type Config struct {
 	DockerDatacenter         *anywherev1.DockerDatacenterConfig
 	SnowDatacenter           *anywherev1.SnowDatacenterConfig
 	NutanixDatacenter        *anywherev1.NutanixDatacenterConfig
+	TinkerbellDatacenter     *anywherev1.TinkerbellDatacenterConfig
 	VSphereMachineConfigs    map[string]*anywherev1.VSphereMachineConfig
 	CloudStackMachineConfigs map[string]*anywherev1.CloudStackMachineConfig
 	SnowMachineConfigs       map[string]*anywherev1.SnowMachineConfig
 	NutanixMachineConfigs    map[string]*anywherev1.NutanixMachineConfig
+	TinkerbellMachineConfigs map[string]*anywherev1.TinkerbellMachineConfig
 	OIDCConfigs              map[string]*anywherev1.OIDCConfig
 	AWSIAMConfigs            map[string]*anywherev1.AWSIamConfig
 	GitOpsConfig             *anywherev1.GitOpsConfig
 	FluxConfig               *anywherev1.FluxConfig
 	SnowCredentialsSecret    *v1.Secret
+	// AuditPolicyConfigMap is the ConfigMap holding the audit policy referenced in the Cluster auditPolicy.
+	AuditPolicyConfigMap *v1.ConfigMap
 }
 
 func (c *Config) VsphereMachineConfig(name string) *anywherev1.VSphereMachineConfig {
@@ -51,6 +55,11 @@ func (c *Config) NutanixMachineConfig(name string) *anywherev1.NutanixMachineCon
 	return c.NutanixMachineConfigs[name]
 }
 
+// TinkerbellMachineConfig returns the TinkerbellMachineConfig with the given name.
+func (c *Config) TinkerbellMachineConfig(name string) *anywherev1.TinkerbellMachineConfig {
+	return c.TinkerbellMachineConfigs[name]
+}
+
 func (c *Config) DeepCopy() *Config {
 	c2 := &Config{
 		Cluster:              c.Cluster.DeepCopy(),
@@ -59,8 +68,10 @@ func (c *Config) DeepCopy() *Config {
 		NutanixDatacenter:    c.NutanixDatacenter.DeepCopy(),
 		DockerDatacenter:     c.DockerDatacenter.DeepCopy(),
 		SnowDatacenter:       c.SnowDatacenter.DeepCopy(),
+		TinkerbellDatacenter: c.TinkerbellDatacenter.DeepCopy(),
 		GitOpsConfig:         c.GitOpsConfig.DeepCopy(),
 		FluxConfig:           c.FluxConfig.DeepCopy(),
+		AuditPolicyConfigMap: c.AuditPolicyConfigMap.DeepCopy(),
 	}
 
 	if c.VSphereMachineConfigs != nil {
@@ -106,6 +117,13 @@ func (c *Config) DeepCopy() *Config {
 		c2.SnowMachineConfigs[k] = v.DeepCopy()
 	}
 
+	if c.TinkerbellMachineConfigs != nil {
+		c2.TinkerbellMachineConfigs = make(map[string]*anywherev1.TinkerbellMachineConfig, len(c.TinkerbellMachineConfigs))
+	}
+	for k, v := range c.TinkerbellMachineConfigs {
+		c2.TinkerbellMachineConfigs[k] = v.DeepCopy()
+	}
+
 	return c2
 }
 
@@ -114,8 +132,8 @@ func (c *Config) ChildObjects() []kubernetes.Object {
 	objs := make(
 		[]kubernetes.Object,
 		0,
-		len(c.VSphereMachineConfigs)+len(c.SnowMachineConfigs)+len(c.CloudStackMachineConfigs)+4,
-		// machine configs length + datacenter + OIDC + IAM + gitops
+		len(c.VSphereMachineConfigs)+len(c.SnowMachineConfigs)+len(c.CloudStackMachineConfigs)+len(c.TinkerbellMachineConfigs)+5,
+		// machine configs length + datacenter + OIDC + IAM + gitops + audit policy
 	)
 
 	objs = appendIfNotNil(objs,
@@ -124,8 +142,10 @@ func (c *Config) ChildObjects() []kubernetes.Object {
 		c.NutanixDatacenter,
 		c.DockerDatacenter,
 		c.SnowDatacenter,
+		c.TinkerbellDatacenter,
 		c.GitOpsConfig,
 		c.FluxConfig,
+		c.AuditPolicyConfigMap,
 	)
 
 	for _, e := range c.VSphereMachineConfigs {
@@ -144,6 +164,10 @@ func (c *Config) ChildObjects() []kubernetes.Object {
 		objs = appendIfNotNil(objs, e)
 	}
 
+	for _, e := range c.TinkerbellMachineConfigs {
+		objs = appendIfNotNil(objs, e)
+	}
+
 	for _, e := range c.OIDCConfigs {
 		objs = appendIfNotNil(objs, e)
 	}
@@ -1,6 +1,8 @@
 package cluster
 
 import (
+	"context"
+
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 )
 
@@ -66,3 +68,42 @@ func processNutanixMachineConfig(c *Config, objects ObjectLookup, machineRef *an
 
 	c.NutanixMachineConfigs[m.GetName()] = m.(*anywherev1.NutanixMachineConfig)
 }
+
+func getNutanixDatacenter(ctx context.Context, client Client, c *Config) error {
+	if c.Cluster.Spec.DatacenterRef.Kind != anywherev1.NutanixDatacenterKind {
+		return nil
+	}
+
+	datacenter := &anywherev1.NutanixDatacenterConfig{}
+	if err := client.Get(ctx, c.Cluster.Spec.DatacenterRef.Name, c.Cluster.Namespace, datacenter); err != nil {
+		return err
+	}
+
+	c.NutanixDatacenter = datacenter
+	return nil
+}
+
+func getNutanixMachineConfigs(ctx context.Context, client Client, c *Config) error {
+	if c.Cluster.Spec.DatacenterRef.Kind != anywherev1.NutanixDatacenterKind {
+		return nil
+	}
+
+	if c.NutanixMachineConfigs == nil {
+		c.NutanixMachineConfigs = map[string]*anywherev1.NutanixMachineConfig{}
+	}
+
+	for _, machineRef := range c.Cluster.MachineConfigRefs() {
+		if machineRef.Kind != anywherev1.NutanixMachineConfigKind {
+			continue
+		}
+
+		machine := &anywherev1.NutanixMachineConfig{}
+		if err := client.Get(ctx, machineRef.Name, c.Cluster.Namespace, machine); err != nil {
+			return err
+		}
+
+		c.NutanixMachineConfigs[machine.Name] = machine
+	}
+
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
 )
 
 func TestValidateConfig(t *testing.T) {
@@ -75,3 +76,49 @@ func TestValidateConfigDifferentNamespace(t *testing.T) {
 		MatchError(ContainSubstring("VSphereDatacenterConfig and Cluster objects must have the same namespace specified")),
 	)
 }
+
+func TestValidateConfigKubeletConfiguration(t *testing.T) {
+	kubeletConfiguration := &anywherev1.KubeletConfiguration{
+		MaxPods:          ptr.Int(50),
+		CPUManagerPolicy: "none",
+	}
+	tests := []struct {
+		name                 string
+		osFamily             anywherev1.OSFamily
+		kubeletConfiguration *anywherev1.KubeletConfiguration
+		wantErr              string
+	}{
+		{
+			name:                 "ubuntu",
+			osFamily:             anywherev1.Ubuntu,
+			kubeletConfiguration: kubeletConfiguration,
+		},
+		{
+			name:                 "bottlerocket",
+			osFamily:             anywherev1.Bottlerocket,
+			kubeletConfiguration: kubeletConfiguration,
+			wantErr:              "kubeletConfiguration for worker node group workers-1 is not supported for bottlerocket, its bootstrap can't set maxPods, cpuManagerPolicy",
+		},
+		{
+			name:                 "bottlerocket empty",
+			osFamily:             anywherev1.Bottlerocket,
+			kubeletConfiguration: &anywherev1.KubeletConfiguration{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			c := clusterConfigFromFile(t, "testdata/cluster_1_19.yaml")
+			c.Cluster.Spec.WorkerNodeGroupConfigurations[0].KubeletConfiguration = tt.kubeletConfiguration
+			c.VSphereMachineConfigs["eksa-unit-test"].Spec.OSFamily = tt.osFamily
+			c.VSphereMachineConfigs["eksa-unit-test"].Spec.Users[0].Name = "ec2-user"
+
+			err := cluster.ValidateConfig(c)
+			if tt.wantErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
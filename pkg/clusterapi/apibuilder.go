@@ -172,25 +172,30 @@ func KubeadmControlPlane(clusterSpec *cluster.Spec, infrastructureObject APIObje
 					Etcd: etcd,
 					APIServer: bootstrapv1.APIServer{
 						ControlPlaneComponent: bootstrapv1.ControlPlaneComponent{
-							ExtraArgs:    map[string]string{},
+							ExtraArgs:    ExtraArgs{}.Append(APIServerExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration)),
 							ExtraVolumes: []bootstrapv1.HostPathMount{},
 						},
 					},
 					ControllerManager: bootstrapv1.ControlPlaneComponent{
 						ExtraArgs: ControllerManagerArgs(clusterSpec),
 					},
+					Scheduler: bootstrapv1.ControlPlaneComponent{
+						ExtraArgs: SchedulerExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration),
+					},
 				},
 				InitConfiguration: &bootstrapv1.InitConfiguration{
 					NodeRegistration: bootstrapv1.NodeRegistrationOptions{
 						KubeletExtraArgs: SecureTlsCipherSuitesExtraArgs().
-							Append(ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration)),
+							Append(ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration)).
+							Append(KubeletConfigurationExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.KubeletConfiguration)),
 						Taints: clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Taints,
 					},
 				},
 				JoinConfiguration: &bootstrapv1.JoinConfiguration{
 					NodeRegistration: bootstrapv1.NodeRegistrationOptions{
 						KubeletExtraArgs: SecureTlsCipherSuitesExtraArgs().
-							Append(ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration)),
+							Append(ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration)).
+							Append(KubeletConfigurationExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.KubeletConfiguration)),
 						Taints: clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Taints,
 					},
 				},
@@ -233,8 +238,9 @@ func KubeadmConfigTemplate(clusterSpec *cluster.Spec, workerNodeGroupConfig anyw
 					},
 					JoinConfiguration: &bootstrapv1.JoinConfiguration{
 						NodeRegistration: bootstrapv1.NodeRegistrationOptions{
-							KubeletExtraArgs: WorkerNodeLabelsExtraArgs(workerNodeGroupConfig),
-							Taints:           workerNodeGroupConfig.Taints,
+							KubeletExtraArgs: WorkerNodeLabelsExtraArgs(workerNodeGroupConfig).
+								Append(KubeletConfigurationExtraArgs(workerNodeGroupConfig.KubeletConfiguration)),
+							Taints: workerNodeGroupConfig.Taints,
 						},
 					},
 					PreKubeadmCommands:  []string{},
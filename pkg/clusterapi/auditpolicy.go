@@ -0,0 +1,72 @@
+package clusterapi
+
+import (
+	"strconv"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+const (
+	auditPolicyFile             = "/etc/kubernetes/audit-policy.yaml"
+	bottlerocketAuditPolicyFile = "/var/lib/kubeadm/audit-policy.yaml"
+	auditLogDir                 = "/var/log/kubernetes"
+	auditLogPath                = "/var/log/kubernetes/api-audit.log"
+)
+
+// AuditLogExtraArgs returns the kube-apiserver flags to write the audit log with the audit policy file.
+func AuditLogExtraArgs(auditPolicy *v1alpha1.AuditPolicy) ExtraArgs {
+	return ExtraArgs{
+		"audit-policy-file":   auditPolicyFile,
+		"audit-log-path":      auditLogPath,
+		"audit-log-maxage":    strconv.Itoa(auditPolicy.LogMaxAgeOrDefault()),
+		"audit-log-maxbackup": strconv.Itoa(auditPolicy.LogMaxBackupOrDefault()),
+		"audit-log-maxsize":   strconv.Itoa(auditPolicy.LogMaxSizeOrDefault()),
+	}
+}
+
+func auditMounts(osFamily v1alpha1.OSFamily) []bootstrapv1.HostPathMount {
+	policyHostPath := auditPolicyFile
+	if osFamily == v1alpha1.Bottlerocket {
+		policyHostPath = bottlerocketAuditPolicyFile
+	}
+
+	return []bootstrapv1.HostPathMount{
+		{
+			Name:      "audit-policy",
+			HostPath:  policyHostPath,
+			MountPath: auditPolicyFile,
+			ReadOnly:  true,
+			PathType:  "File",
+		},
+		{
+			Name:      "audit-log-dir",
+			HostPath:  auditLogDir,
+			MountPath: auditLogDir,
+			ReadOnly:  false,
+			PathType:  "DirectoryOrCreate",
+		},
+	}
+}
+
+// SetAuditPolicyInKubeadmControlPlane enables the kube-apiserver audit log with the given audit policy
+// and the audit log settings of the cluster.
+func SetAuditPolicyInKubeadmControlPlane(kcp *controlplanev1.KubeadmControlPlane, policy string, auditPolicy *v1alpha1.AuditPolicy, osFamily v1alpha1.OSFamily) {
+	apiServer := &kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer
+	if apiServer.ExtraArgs == nil {
+		apiServer.ExtraArgs = map[string]string{}
+	}
+	for k, v := range AuditLogExtraArgs(auditPolicy) {
+		apiServer.ExtraArgs[k] = v
+	}
+
+	apiServer.ExtraVolumes = append(apiServer.ExtraVolumes, auditMounts(osFamily)...)
+
+	kcp.Spec.KubeadmConfigSpec.Files = append(kcp.Spec.KubeadmConfigSpec.Files, bootstrapv1.File{
+		Path:    auditPolicyFile,
+		Owner:   "root:root",
+		Content: policy,
+	})
+}
@@ -0,0 +1,102 @@
+package clusterapi_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+func TestAuditLogExtraArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		auditPolicy *v1alpha1.AuditPolicy
+		want        clusterapi.ExtraArgs
+	}{
+		{
+			name: "defaults",
+			want: clusterapi.ExtraArgs{
+				"audit-policy-file":   "/etc/kubernetes/audit-policy.yaml",
+				"audit-log-path":      "/var/log/kubernetes/api-audit.log",
+				"audit-log-maxage":    "30",
+				"audit-log-maxbackup": "10",
+				"audit-log-maxsize":   "512",
+			},
+		},
+		{
+			name:        "log settings",
+			auditPolicy: &v1alpha1.AuditPolicy{LogMaxAge: ptr.Int(7), LogMaxBackup: ptr.Int(2), LogMaxSize: ptr.Int(100)},
+			want: clusterapi.ExtraArgs{
+				"audit-policy-file":   "/etc/kubernetes/audit-policy.yaml",
+				"audit-log-path":      "/var/log/kubernetes/api-audit.log",
+				"audit-log-maxage":    "7",
+				"audit-log-maxbackup": "2",
+				"audit-log-maxsize":   "100",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(clusterapi.AuditLogExtraArgs(tt.auditPolicy)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestSetAuditPolicyInKubeadmControlPlane(t *testing.T) {
+	tests := []struct {
+		name           string
+		osFamily       v1alpha1.OSFamily
+		policyHostPath string
+	}{
+		{
+			name:           "ubuntu",
+			osFamily:       v1alpha1.Ubuntu,
+			policyHostPath: "/etc/kubernetes/audit-policy.yaml",
+		},
+		{
+			name:           "bottlerocket",
+			osFamily:       v1alpha1.Bottlerocket,
+			policyHostPath: "/var/lib/kubeadm/audit-policy.yaml",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			auditPolicy := &v1alpha1.AuditPolicy{LogMaxAge: ptr.Int(7)}
+			got := wantKubeadmControlPlane()
+			clusterapi.SetAuditPolicyInKubeadmControlPlane(got, "policy", auditPolicy, tt.osFamily)
+
+			want := wantKubeadmControlPlane()
+			want.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer.ExtraArgs = clusterapi.AuditLogExtraArgs(auditPolicy)
+			want.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer.ExtraVolumes = []bootstrapv1.HostPathMount{
+				{
+					Name:      "audit-policy",
+					HostPath:  tt.policyHostPath,
+					MountPath: "/etc/kubernetes/audit-policy.yaml",
+					ReadOnly:  true,
+					PathType:  "File",
+				},
+				{
+					Name:      "audit-log-dir",
+					HostPath:  "/var/log/kubernetes",
+					MountPath: "/var/log/kubernetes",
+					ReadOnly:  false,
+					PathType:  "DirectoryOrCreate",
+				},
+			}
+			want.Spec.KubeadmConfigSpec.Files = []bootstrapv1.File{
+				{
+					Path:    "/etc/kubernetes/audit-policy.yaml",
+					Owner:   "root:root",
+					Content: "policy",
+				},
+			}
+			g.Expect(got).To(Equal(want))
+		})
+	}
+}
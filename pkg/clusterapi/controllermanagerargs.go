@@ -6,5 +6,6 @@ import (
 
 func ControllerManagerArgs(clusterSpec *cluster.Spec) ExtraArgs {
 	return SecureTlsCipherSuitesExtraArgs().
-		Append(NodeCIDRMaskExtraArgs(&clusterSpec.Cluster.Spec.ClusterNetwork))
+		Append(NodeCIDRMaskExtraArgs(&clusterSpec.Cluster.Spec.ClusterNetwork)).
+		Append(ControllerManagerExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration))
 }
@@ -0,0 +1,108 @@
+package clusterapi
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/encryption"
+)
+
+const (
+	encryptionConfigFile             = "/etc/kubernetes/encryption-config.yaml"
+	bottlerocketEncryptionConfigFile = "/var/lib/kubeadm/encryption-config.yaml"
+)
+
+// EncryptionConfigurationExtraArgs returns the kube-apiserver flags to encrypt resources with the encryption configuration file.
+func EncryptionConfigurationExtraArgs(config *v1alpha1.EncryptionConfiguration) ExtraArgs {
+	args := ExtraArgs{}
+	if config == nil {
+		return args
+	}
+	args.AddIfNotEmpty("encryption-provider-config", encryptionConfigFile)
+
+	return args
+}
+
+// KMSPluginSocketDirs returns the directories of the unix sockets of the KMS plugins in the encryption configuration,
+// which need to be mounted in the kube-apiserver.
+func KMSPluginSocketDirs(config *v1alpha1.EncryptionConfiguration) []string {
+	if config == nil {
+		return nil
+	}
+
+	var dirs []string
+	seen := map[string]bool{}
+	for _, p := range config.Providers {
+		if p.KMS == nil {
+			continue
+		}
+		dir := path.Dir(strings.TrimPrefix(p.KMS.Endpoint, "unix://"))
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+func encryptionConfigurationMounts(config *v1alpha1.EncryptionConfiguration, osFamily v1alpha1.OSFamily) []bootstrapv1.HostPathMount {
+	configHostPath := encryptionConfigFile
+	if osFamily == v1alpha1.Bottlerocket {
+		configHostPath = bottlerocketEncryptionConfigFile
+	}
+
+	mounts := []bootstrapv1.HostPathMount{
+		{
+			Name:      "encryption-config",
+			HostPath:  configHostPath,
+			MountPath: encryptionConfigFile,
+			ReadOnly:  true,
+			PathType:  "File",
+		},
+	}
+	for i, dir := range KMSPluginSocketDirs(config) {
+		mounts = append(mounts, bootstrapv1.HostPathMount{
+			Name:      fmt.Sprintf("kms-plugin-%d", i),
+			HostPath:  dir,
+			MountPath: dir,
+			ReadOnly:  false,
+			PathType:  "DirectoryOrCreate",
+		})
+	}
+	return mounts
+}
+
+// SetEncryptionConfigurationInKubeadmControlPlane configures the kube-apiserver to encrypt resources with the encryption
+// configuration the CLI stores in a secret of the management cluster, written to the control plane nodes when they join the cluster.
+func SetEncryptionConfigurationInKubeadmControlPlane(kcp *controlplanev1.KubeadmControlPlane, clusterName string, config *v1alpha1.EncryptionConfiguration, osFamily v1alpha1.OSFamily) {
+	if config == nil {
+		return
+	}
+
+	apiServer := &kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer
+	if apiServer.ExtraArgs == nil {
+		apiServer.ExtraArgs = map[string]string{}
+	}
+	for k, v := range EncryptionConfigurationExtraArgs(config) {
+		apiServer.ExtraArgs[k] = v
+	}
+
+	apiServer.ExtraVolumes = append(apiServer.ExtraVolumes, encryptionConfigurationMounts(config, osFamily)...)
+
+	kcp.Spec.KubeadmConfigSpec.Files = append(kcp.Spec.KubeadmConfigSpec.Files, bootstrapv1.File{
+		Path:        encryptionConfigFile,
+		Owner:       "root:root",
+		Permissions: "0600",
+		ContentFrom: &bootstrapv1.FileSource{
+			Secret: bootstrapv1.SecretFileSource{
+				Name: encryption.ConfigSecretName(clusterName),
+				Key:  encryption.ConfigSecretKey,
+			},
+		},
+	})
+}
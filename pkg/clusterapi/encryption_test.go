@@ -0,0 +1,108 @@
+package clusterapi_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+)
+
+func kmsEncryptionConfiguration() *v1alpha1.EncryptionConfiguration {
+	return &v1alpha1.EncryptionConfiguration{
+		Providers: []v1alpha1.EncryptionProvider{
+			{Type: v1alpha1.KMSEncryptionProvider, KMS: &v1alpha1.KMSConfiguration{Name: "kms-1", Endpoint: "unix:///var/run/kms/kms-1.sock"}},
+			{Type: v1alpha1.KMSEncryptionProvider, KMS: &v1alpha1.KMSConfiguration{Name: "kms-2", Endpoint: "unix:///var/run/kms/kms-2.sock"}},
+			{Type: v1alpha1.KMSEncryptionProvider, KMS: &v1alpha1.KMSConfiguration{Name: "kms-3", Endpoint: "unix:///run/plugin/kms.sock"}},
+			{Type: v1alpha1.AESCBCEncryptionProvider},
+		},
+	}
+}
+
+func TestEncryptionConfigurationExtraArgs(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(clusterapi.EncryptionConfigurationExtraArgs(nil)).To(Equal(clusterapi.ExtraArgs{}))
+	g.Expect(clusterapi.EncryptionConfigurationExtraArgs(kmsEncryptionConfiguration())).To(Equal(clusterapi.ExtraArgs{
+		"encryption-provider-config": "/etc/kubernetes/encryption-config.yaml",
+	}))
+}
+
+func TestKMSPluginSocketDirs(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(clusterapi.KMSPluginSocketDirs(nil)).To(BeEmpty())
+	g.Expect(clusterapi.KMSPluginSocketDirs(kmsEncryptionConfiguration())).To(Equal([]string{"/var/run/kms", "/run/plugin"}))
+}
+
+func TestSetEncryptionConfigurationInKubeadmControlPlaneNil(t *testing.T) {
+	g := NewWithT(t)
+	got := wantKubeadmControlPlane()
+	clusterapi.SetEncryptionConfigurationInKubeadmControlPlane(got, "w01", nil, v1alpha1.Ubuntu)
+	g.Expect(got).To(Equal(wantKubeadmControlPlane()))
+}
+
+func TestSetEncryptionConfigurationInKubeadmControlPlane(t *testing.T) {
+	tests := []struct {
+		name           string
+		osFamily       v1alpha1.OSFamily
+		configHostPath string
+	}{
+		{
+			name:           "ubuntu",
+			osFamily:       v1alpha1.Ubuntu,
+			configHostPath: "/etc/kubernetes/encryption-config.yaml",
+		},
+		{
+			name:           "bottlerocket",
+			osFamily:       v1alpha1.Bottlerocket,
+			configHostPath: "/var/lib/kubeadm/encryption-config.yaml",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			config := kmsEncryptionConfiguration()
+			got := wantKubeadmControlPlane()
+			clusterapi.SetEncryptionConfigurationInKubeadmControlPlane(got, "w01", config, tt.osFamily)
+
+			want := wantKubeadmControlPlane()
+			want.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer.ExtraArgs = clusterapi.EncryptionConfigurationExtraArgs(config)
+			want.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer.ExtraVolumes = []bootstrapv1.HostPathMount{
+				{
+					Name:      "encryption-config",
+					HostPath:  tt.configHostPath,
+					MountPath: "/etc/kubernetes/encryption-config.yaml",
+					ReadOnly:  true,
+					PathType:  "File",
+				},
+				{
+					Name:      "kms-plugin-0",
+					HostPath:  "/var/run/kms",
+					MountPath: "/var/run/kms",
+					PathType:  "DirectoryOrCreate",
+				},
+				{
+					Name:      "kms-plugin-1",
+					HostPath:  "/run/plugin",
+					MountPath: "/run/plugin",
+					PathType:  "DirectoryOrCreate",
+				},
+			}
+			want.Spec.KubeadmConfigSpec.Files = []bootstrapv1.File{
+				{
+					Path:        "/etc/kubernetes/encryption-config.yaml",
+					Owner:       "root:root",
+					Permissions: "0600",
+					ContentFrom: &bootstrapv1.FileSource{
+						Secret: bootstrapv1.SecretFileSource{
+							Name: "w01-encryption-config",
+							Key:  "encryption-config.yaml",
+						},
+					},
+				},
+			}
+			g.Expect(got).To(Equal(want))
+		})
+	}
+}
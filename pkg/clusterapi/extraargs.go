@@ -81,6 +81,54 @@ func ResolvConfExtraArgs(resolvConf *v1alpha1.ResolvConf) ExtraArgs {
 	return args
 }
 
+// KubeletConfigurationExtraArgs returns the kubelet flags for the kubelet settings of a node group.
+func KubeletConfigurationExtraArgs(kubeletConfiguration *v1alpha1.KubeletConfiguration) ExtraArgs {
+	if kubeletConfiguration == nil {
+		return nil
+	}
+	args := ExtraArgs{}
+	if kubeletConfiguration.MaxPods != nil {
+		args.AddIfNotEmpty("max-pods", strconv.Itoa(*kubeletConfiguration.MaxPods))
+	}
+	args.AddIfNotEmpty("system-reserved", mapToArg(kubeletConfiguration.SystemReserved, "="))
+	args.AddIfNotEmpty("kube-reserved", mapToArg(kubeletConfiguration.KubeReserved, "="))
+	args.AddIfNotEmpty("eviction-hard", mapToArg(kubeletConfiguration.EvictionHard, "<"))
+	args.AddIfNotEmpty("eviction-soft", mapToArg(kubeletConfiguration.EvictionSoft, "<"))
+	args.AddIfNotEmpty("eviction-soft-grace-period", mapToArg(kubeletConfiguration.EvictionSoftGracePeriod, "="))
+	if kubeletConfiguration.ImageGCHighThresholdPercent != nil {
+		args.AddIfNotEmpty("image-gc-high-threshold", strconv.Itoa(*kubeletConfiguration.ImageGCHighThresholdPercent))
+	}
+	if kubeletConfiguration.ImageGCLowThresholdPercent != nil {
+		args.AddIfNotEmpty("image-gc-low-threshold", strconv.Itoa(*kubeletConfiguration.ImageGCLowThresholdPercent))
+	}
+	args.AddIfNotEmpty("topology-manager-policy", kubeletConfiguration.TopologyManagerPolicy)
+	args.AddIfNotEmpty("cpu-manager-policy", kubeletConfiguration.CPUManagerPolicy)
+	return args
+}
+
+// APIServerExtraArgs returns the kube-apiserver flags defined in the control plane configuration.
+func APIServerExtraArgs(cpc v1alpha1.ControlPlaneConfiguration) ExtraArgs {
+	return controlPlaneComponentExtraArgs(cpc.APIServerExtraArgs)
+}
+
+// ControllerManagerExtraArgs returns the kube-controller-manager flags defined in the control plane configuration.
+func ControllerManagerExtraArgs(cpc v1alpha1.ControlPlaneConfiguration) ExtraArgs {
+	return controlPlaneComponentExtraArgs(cpc.ControllerManagerExtraArgs)
+}
+
+// SchedulerExtraArgs returns the kube-scheduler flags defined in the control plane configuration.
+func SchedulerExtraArgs(cpc v1alpha1.ControlPlaneConfiguration) ExtraArgs {
+	return controlPlaneComponentExtraArgs(cpc.SchedulerExtraArgs)
+}
+
+// controlPlaneComponentExtraArgs copies the flags so appending to the result doesn't modify the cluster spec.
+func controlPlaneComponentExtraArgs(flags map[string]string) ExtraArgs {
+	if len(flags) == 0 {
+		return nil
+	}
+	return ExtraArgs{}.Append(flags)
+}
+
 // We don't need to add these once the Kubernetes components default to using the secure cipher suites.
 func SecureTlsCipherSuitesExtraArgs() ExtraArgs {
 	args := ExtraArgs{}
@@ -154,12 +202,15 @@ func requiredClaimToArg(r *v1alpha1.OIDCConfigRequiredClaim) string {
 }
 
 func labelsMapToArg(m map[string]string) string {
-	labels := make([]string, 0, len(m))
+	return mapToArg(m, "=")
+}
+
+func mapToArg(m map[string]string, separator string) string {
+	pairs := make([]string, 0, len(m))
 	for k, v := range m {
-		labels = append(labels, fmt.Sprintf("%s=%s", k, v))
+		pairs = append(pairs, k+separator+v)
 	}
 
-	sort.Strings(labels)
-	labelStr := strings.Join(labels, ",")
-	return labelStr
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
 }
@@ -554,3 +554,98 @@ func TestFeatureGatesExtraArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestKubeletConfigurationExtraArgs(t *testing.T) {
+	tests := []struct {
+		testName             string
+		kubeletConfiguration *v1alpha1.KubeletConfiguration
+		want                 clusterapi.ExtraArgs
+	}{
+		{
+			testName:             "no kubelet configuration",
+			kubeletConfiguration: nil,
+			want:                 nil,
+		},
+		{
+			testName:             "empty kubelet configuration",
+			kubeletConfiguration: &v1alpha1.KubeletConfiguration{},
+			want:                 clusterapi.ExtraArgs{},
+		},
+		{
+			testName: "full kubelet configuration",
+			kubeletConfiguration: &v1alpha1.KubeletConfiguration{
+				MaxPods:                     ptr.Int(50),
+				SystemReserved:              map[string]string{"memory": "1Gi", "cpu": "500m"},
+				KubeReserved:                map[string]string{"cpu": "250m"},
+				EvictionHard:                map[string]string{"nodefs.available": "10%", "memory.available": "100Mi"},
+				EvictionSoft:                map[string]string{"memory.available": "300Mi"},
+				EvictionSoftGracePeriod:     map[string]string{"memory.available": "1m30s"},
+				ImageGCHighThresholdPercent: ptr.Int(85),
+				ImageGCLowThresholdPercent:  ptr.Int(80),
+				TopologyManagerPolicy:       "single-numa-node",
+				CPUManagerPolicy:            "static",
+			},
+			want: clusterapi.ExtraArgs{
+				"max-pods":                   "50",
+				"system-reserved":            "cpu=500m,memory=1Gi",
+				"kube-reserved":              "cpu=250m",
+				"eviction-hard":              "memory.available<100Mi,nodefs.available<10%",
+				"eviction-soft":              "memory.available<300Mi",
+				"eviction-soft-grace-period": "memory.available=1m30s",
+				"image-gc-high-threshold":    "85",
+				"image-gc-low-threshold":     "80",
+				"topology-manager-policy":    "single-numa-node",
+				"cpu-manager-policy":         "static",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			if got := clusterapi.KubeletConfigurationExtraArgs(tt.kubeletConfiguration); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("KubeletConfigurationExtraArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestControlPlaneComponentExtraArgs(t *testing.T) {
+	cpc := v1alpha1.ControlPlaneConfiguration{
+		APIServerExtraArgs:         map[string]string{"event-ttl": "2h"},
+		ControllerManagerExtraArgs: map[string]string{"node-monitor-grace-period": "20s"},
+	}
+	tests := []struct {
+		testName string
+		got      clusterapi.ExtraArgs
+		want     clusterapi.ExtraArgs
+	}{
+		{
+			testName: "api server extra args",
+			got:      clusterapi.APIServerExtraArgs(cpc),
+			want:     clusterapi.ExtraArgs{"event-ttl": "2h"},
+		},
+		{
+			testName: "controller manager extra args",
+			got:      clusterapi.ControllerManagerExtraArgs(cpc),
+			want:     clusterapi.ExtraArgs{"node-monitor-grace-period": "20s"},
+		},
+		{
+			testName: "no scheduler extra args",
+			got:      clusterapi.SchedulerExtraArgs(cpc),
+			want:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("extra args = %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+
+	clusterapi.APIServerExtraArgs(cpc).Append(clusterapi.ExtraArgs{"event-ttl": "1h"})
+	if cpc.APIServerExtraArgs["event-ttl"] != "2h" {
+		t.Errorf("APIServerExtraArgs() result shares the cluster spec map")
+	}
+}
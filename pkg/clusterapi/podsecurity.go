@@ -0,0 +1,182 @@
+package clusterapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+const (
+	admissionConfigFile             = "/etc/kubernetes/admission-control-config.yaml"
+	bottlerocketAdmissionConfigFile = "/var/lib/kubeadm/admission-control-config.yaml"
+)
+
+// podSecuritySystemNamespaces are the namespaces of the EKS Anywhere components, always exempted from
+// the pod security defaults so a restrictive policy doesn't prevent the cluster from being managed.
+var podSecuritySystemNamespaces = []string{
+	constants.KubeSystemNamespace,
+	constants.EksaSystemNamespace,
+	constants.EksaPackagesName,
+	constants.CertManagerNamespace,
+	constants.CapiSystemNamespace,
+	constants.CapiWebhookSystemNamespace,
+	constants.CapiKubeadmBootstrapSystemNamespace,
+	constants.CapiKubeadmControlPlaneSystemNamespace,
+	constants.EtcdAdmBootstrapProviderSystemNamespace,
+	constants.EtcdAdmControllerSystemNamespace,
+	constants.CapdSystemNamespace,
+	constants.CapcSystemNamespace,
+	constants.CapvSystemNamespace,
+	constants.CaptSystemNamespace,
+	constants.CapasSystemNamespace,
+	constants.CapxSystemNamespace,
+	v1alpha1.FluxDefaultNamespace,
+}
+
+// admissionConfiguration is the kube-apiserver apiserver.config.k8s.io/v1 AdmissionConfiguration,
+// with the configuration of the PodSecurity admission plugin.
+type admissionConfiguration struct {
+	metav1.TypeMeta `json:",inline"`
+	Plugins         []admissionPluginConfiguration `json:"plugins"`
+}
+
+type admissionPluginConfiguration struct {
+	Name          string                   `json:"name"`
+	Configuration podSecurityConfiguration `json:"configuration"`
+}
+
+// podSecurityConfiguration is the pod-security.admission.config.k8s.io/v1beta1 PodSecurityConfiguration,
+// supported from kubernetes 1.23.
+type podSecurityConfiguration struct {
+	metav1.TypeMeta `json:",inline"`
+	Defaults        podSecurityDefaults   `json:"defaults"`
+	Exemptions      podSecurityExemptions `json:"exemptions"`
+}
+
+type podSecurityDefaults struct {
+	Enforce        v1alpha1.PodSecurityLevel `json:"enforce"`
+	EnforceVersion string                    `json:"enforce-version"`
+	Audit          v1alpha1.PodSecurityLevel `json:"audit"`
+	AuditVersion   string                    `json:"audit-version"`
+	Warn           v1alpha1.PodSecurityLevel `json:"warn"`
+	WarnVersion    string                    `json:"warn-version"`
+}
+
+type podSecurityExemptions struct {
+	Namespaces []string `json:"namespaces"`
+}
+
+// PodSecurityExtraArgs returns the kube-apiserver flags to configure the admission plugins with the admission configuration file.
+func PodSecurityExtraArgs(podSecurity *v1alpha1.PodSecurity) ExtraArgs {
+	args := ExtraArgs{}
+	if podSecurity == nil {
+		return args
+	}
+	args.AddIfNotEmpty("admission-control-config-file", admissionConfigFile)
+
+	return args
+}
+
+// PodSecurityExemptNamespaces returns the sorted namespaces exempted from the pod security defaults,
+// the EKS Anywhere system namespaces and the ones set in the pod security.
+func PodSecurityExemptNamespaces(podSecurity *v1alpha1.PodSecurity) []string {
+	seen := map[string]bool{}
+	namespaces := make([]string, 0, len(podSecuritySystemNamespaces)+len(podSecurity.ExemptNamespaces))
+	for _, ns := range append(append([]string{}, podSecuritySystemNamespaces...), podSecurity.ExemptNamespaces...) {
+		if !seen[ns] {
+			seen[ns] = true
+			namespaces = append(namespaces, ns)
+		}
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+// PodSecurityAdmissionConfiguration returns the kube-apiserver admission configuration yaml setting the
+// cluster wide defaults of the PodSecurity admission plugin.
+func PodSecurityAdmissionConfiguration(podSecurity *v1alpha1.PodSecurity) (string, error) {
+	config := admissionConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apiserver.config.k8s.io/v1",
+			Kind:       "AdmissionConfiguration",
+		},
+		Plugins: []admissionPluginConfiguration{
+			{
+				Name: "PodSecurity",
+				Configuration: podSecurityConfiguration{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: "pod-security.admission.config.k8s.io/v1beta1",
+						Kind:       "PodSecurityConfiguration",
+					},
+					Defaults: podSecurityDefaults{
+						Enforce:        podSecurity.EnforceOrDefault(),
+						EnforceVersion: podSecurity.EnforceVersionOrDefault(),
+						Audit:          podSecurity.AuditOrDefault(),
+						AuditVersion:   podSecurity.AuditVersionOrDefault(),
+						Warn:           podSecurity.WarnOrDefault(),
+						WarnVersion:    podSecurity.WarnVersionOrDefault(),
+					},
+					Exemptions: podSecurityExemptions{
+						Namespaces: PodSecurityExemptNamespaces(podSecurity),
+					},
+				},
+			},
+		},
+	}
+
+	content, err := yaml.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("marshalling pod security admission configuration: %v", err)
+	}
+
+	return strings.TrimSpace(string(content)), nil
+}
+
+// SetPodSecurityInKubeadmControlPlane configures the kube-apiserver PodSecurity admission plugin with the cluster wide
+// defaults of the pod security, written to the control plane nodes in the admission configuration file.
+func SetPodSecurityInKubeadmControlPlane(kcp *controlplanev1.KubeadmControlPlane, podSecurity *v1alpha1.PodSecurity, osFamily v1alpha1.OSFamily) error {
+	if podSecurity == nil {
+		return nil
+	}
+
+	config, err := PodSecurityAdmissionConfiguration(podSecurity)
+	if err != nil {
+		return err
+	}
+
+	apiServer := &kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer
+	if apiServer.ExtraArgs == nil {
+		apiServer.ExtraArgs = map[string]string{}
+	}
+	for k, v := range PodSecurityExtraArgs(podSecurity) {
+		apiServer.ExtraArgs[k] = v
+	}
+
+	configHostPath := admissionConfigFile
+	if osFamily == v1alpha1.Bottlerocket {
+		configHostPath = bottlerocketAdmissionConfigFile
+	}
+	apiServer.ExtraVolumes = append(apiServer.ExtraVolumes, bootstrapv1.HostPathMount{
+		Name:      "admission-control-config",
+		HostPath:  configHostPath,
+		MountPath: admissionConfigFile,
+		ReadOnly:  true,
+		PathType:  "File",
+	})
+
+	kcp.Spec.KubeadmConfigSpec.Files = append(kcp.Spec.KubeadmConfigSpec.Files, bootstrapv1.File{
+		Path:    admissionConfigFile,
+		Owner:   "root:root",
+		Content: config,
+	})
+
+	return nil
+}
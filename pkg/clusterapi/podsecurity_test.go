@@ -0,0 +1,123 @@
+package clusterapi_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+)
+
+const wantAdmissionConfiguration = `apiVersion: apiserver.config.k8s.io/v1
+kind: AdmissionConfiguration
+plugins:
+- configuration:
+    apiVersion: pod-security.admission.config.k8s.io/v1beta1
+    defaults:
+      audit: restricted
+      audit-version: latest
+      enforce: baseline
+      enforce-version: v1.23
+      warn: privileged
+      warn-version: latest
+    exemptions:
+      namespaces:
+      - capas-system
+      - capc-system
+      - capd-system
+      - capi-kubeadm-bootstrap-system
+      - capi-kubeadm-control-plane-system
+      - capi-system
+      - capi-webhook-system
+      - capt-system
+      - capv-system
+      - capx-system
+      - cert-manager
+      - eksa-packages
+      - eksa-system
+      - etcdadm-bootstrap-provider-system
+      - etcdadm-controller-system
+      - flux-system
+      - kube-system
+      - monitoring
+    kind: PodSecurityConfiguration
+  name: PodSecurity`
+
+func podSecurity() *v1alpha1.PodSecurity {
+	return &v1alpha1.PodSecurity{
+		Enforce:          v1alpha1.BaselinePodSecurityLevel,
+		EnforceVersion:   "v1.23",
+		Audit:            v1alpha1.RestrictedPodSecurityLevel,
+		ExemptNamespaces: []string{"monitoring", "eksa-system"},
+	}
+}
+
+func TestPodSecurityExtraArgs(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(clusterapi.PodSecurityExtraArgs(nil)).To(Equal(clusterapi.ExtraArgs{}))
+	g.Expect(clusterapi.PodSecurityExtraArgs(podSecurity())).To(Equal(clusterapi.ExtraArgs{
+		"admission-control-config-file": "/etc/kubernetes/admission-control-config.yaml",
+	}))
+}
+
+func TestPodSecurityAdmissionConfiguration(t *testing.T) {
+	g := NewWithT(t)
+	got, err := clusterapi.PodSecurityAdmissionConfiguration(podSecurity())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got).To(Equal(wantAdmissionConfiguration))
+}
+
+func TestSetPodSecurityInKubeadmControlPlaneNil(t *testing.T) {
+	g := NewWithT(t)
+	got := wantKubeadmControlPlane()
+	g.Expect(clusterapi.SetPodSecurityInKubeadmControlPlane(got, nil, v1alpha1.Ubuntu)).To(Succeed())
+	g.Expect(got).To(Equal(wantKubeadmControlPlane()))
+}
+
+func TestSetPodSecurityInKubeadmControlPlane(t *testing.T) {
+	tests := []struct {
+		name           string
+		osFamily       v1alpha1.OSFamily
+		configHostPath string
+	}{
+		{
+			name:           "ubuntu",
+			osFamily:       v1alpha1.Ubuntu,
+			configHostPath: "/etc/kubernetes/admission-control-config.yaml",
+		},
+		{
+			name:           "bottlerocket",
+			osFamily:       v1alpha1.Bottlerocket,
+			configHostPath: "/var/lib/kubeadm/admission-control-config.yaml",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got := wantKubeadmControlPlane()
+			g.Expect(clusterapi.SetPodSecurityInKubeadmControlPlane(got, podSecurity(), tt.osFamily)).To(Succeed())
+
+			want := wantKubeadmControlPlane()
+			want.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer.ExtraArgs = clusterapi.PodSecurityExtraArgs(podSecurity())
+			want.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer.ExtraVolumes = []bootstrapv1.HostPathMount{
+				{
+					Name:      "admission-control-config",
+					HostPath:  tt.configHostPath,
+					MountPath: "/etc/kubernetes/admission-control-config.yaml",
+					ReadOnly:  true,
+					PathType:  "File",
+				},
+			}
+			want.Spec.KubeadmConfigSpec.Files = []bootstrapv1.File{
+				{
+					Path:    "/etc/kubernetes/admission-control-config.yaml",
+					Owner:   "root:root",
+					Content: wantAdmissionConfiguration,
+				},
+			}
+			g.Expect(got).To(Equal(want))
+		})
+	}
+}
@@ -2,6 +2,8 @@ package clusterapi
 
 import (
 	"context"
+	"sort"
+	"strings"
 
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -123,9 +125,11 @@ func GetKubeadmConfigTemplate(ctx context.Context, client kubernetes.Client, nam
 // Implements ObjectComparator.
 func KubeadmConfigTemplateEqual(new, old *kubeadmv1.KubeadmConfigTemplate) bool {
 	// DeepDerivative treats empty map (length == 0) as unset field. We need to manually compare certain fields
-	// such as taints, so that setting it to empty will trigger machine recreate
+	// such as taints, so that setting it to empty will trigger machine recreate.
+	// The kubelet extra args are also compared on their own, since the order of the pairs in the map valued
+	// flags, such as eviction-hard, doesn't change the kubelet configuration.
 	return kubeadmConfigTemplateTaintsEqual(new, old) && kubeadmConfigTemplateExtraArgsEqual(new, old) &&
-		equality.Semantic.DeepDerivative(new.Spec, old.Spec)
+		equality.Semantic.DeepDerivative(withoutKubeletExtraArgs(new).Spec, withoutKubeletExtraArgs(old).Spec)
 }
 
 func kubeadmConfigTemplateTaintsEqual(new, old *kubeadmv1.KubeadmConfigTemplate) bool {
@@ -140,8 +144,63 @@ func kubeadmConfigTemplateTaintsEqual(new, old *kubeadmv1.KubeadmConfigTemplate)
 func kubeadmConfigTemplateExtraArgsEqual(new, old *kubeadmv1.KubeadmConfigTemplate) bool {
 	return new.Spec.Template.Spec.JoinConfiguration == nil ||
 		old.Spec.Template.Spec.JoinConfiguration == nil ||
-		anywherev1.MapEqual(
+		kubeletExtraArgsEqual(
 			new.Spec.Template.Spec.JoinConfiguration.NodeRegistration.KubeletExtraArgs,
 			old.Spec.Template.Spec.JoinConfiguration.NodeRegistration.KubeletExtraArgs,
 		)
 }
+
+// kubeletMapArgs are the kubelet flags whose value is a comma separated list of pairs.
+var kubeletMapArgs = map[string]struct{}{
+	"node-labels":                {},
+	"system-reserved":            {},
+	"kube-reserved":              {},
+	"eviction-hard":              {},
+	"eviction-soft":              {},
+	"eviction-soft-grace-period": {},
+}
+
+func kubeletExtraArgsEqual(new, old map[string]string) bool {
+	if len(new) != len(old) {
+		return false
+	}
+	for k, v := range new {
+		o, ok := old[k]
+		if !ok {
+			return false
+		}
+		if _, ok := kubeletMapArgs[k]; ok {
+			if !listArgEqual(v, o) {
+				return false
+			}
+		} else if v != o {
+			return false
+		}
+	}
+	return true
+}
+
+func listArgEqual(a, b string) bool {
+	aItems := strings.Split(a, ",")
+	bItems := strings.Split(b, ",")
+	if len(aItems) != len(bItems) {
+		return false
+	}
+	sort.Strings(aItems)
+	sort.Strings(bItems)
+	for i := range aItems {
+		if aItems[i] != bItems[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func withoutKubeletExtraArgs(k *kubeadmv1.KubeadmConfigTemplate) *kubeadmv1.KubeadmConfigTemplate {
+	if k.Spec.Template.Spec.JoinConfiguration == nil {
+		return k
+	}
+	k = k.DeepCopy()
+	k.Spec.Template.Spec.JoinConfiguration.NodeRegistration.KubeletExtraArgs = nil
+	return k
+}
@@ -0,0 +1,407 @@
+package clusterinfo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+// maxFailures is the maximum number of failures reported when describing a cluster.
+const maxFailures = 10
+
+var (
+	eksaClusterResourceType         = fmt.Sprintf("clusters.%s", v1alpha1.GroupVersion.Group)
+	capiClusterResourceType         = fmt.Sprintf("clusters.%s", clusterv1.GroupVersion.Group)
+	capiMachineResourceType         = fmt.Sprintf("machines.%s", clusterv1.GroupVersion.Group)
+	kubeadmControlPlaneResourceType = fmt.Sprintf("kubeadmcontrolplanes.controlplane.%s", clusterv1.GroupVersion.Group)
+)
+
+// KubectlClient reads cluster objects from a Kubernetes API server.
+type KubectlClient interface {
+	ListObjects(ctx context.Context, resourceType, namespace, kubeconfig string, list kubernetes.ObjectList) error
+	GetObject(ctx context.Context, resourceType, name, namespace, kubeconfig string, obj runtime.Object) error
+	GetBundles(ctx context.Context, kubeconfigFile, name, namespace string) (*releasev1alpha1.Bundles, error)
+	GetMachineDeploymentsForCluster(ctx context.Context, clusterName string, opts ...executables.KubectlOpt) ([]clusterv1.MachineDeployment, error)
+	GetEksaClusters(ctx context.Context, opts ...executables.KubectlOpt) ([]v1alpha1.Cluster, error)
+}
+
+// Client collects the state of EKS-A clusters from a management cluster.
+type Client struct {
+	kubectl KubectlClient
+}
+
+// NewClient returns a new Client.
+func NewClient(kubectl KubectlClient) *Client {
+	return &Client{kubectl: kubectl}
+}
+
+// ReplicaCount is the number of ready and desired replicas of a group of machines.
+type ReplicaCount struct {
+	Ready   int32 `json:"ready"`
+	Desired int32 `json:"desired"`
+}
+
+func (r ReplicaCount) String() string {
+	return fmt.Sprintf("%d/%d", r.Ready, r.Desired)
+}
+
+// Summary is a short overview of the state of an EKS-A cluster.
+type Summary struct {
+	Name              string                `json:"name"`
+	Namespace         string                `json:"namespace"`
+	Provider          string                `json:"provider"`
+	KubernetesVersion string                `json:"kubernetesVersion"`
+	BundlesName       string                `json:"bundlesName,omitempty"`
+	BundlesVersion    int                   `json:"bundlesVersion,omitempty"`
+	ControlPlane      ReplicaCount          `json:"controlPlane"`
+	Workers           ReplicaCount          `json:"workers"`
+	Conditions        []clusterv1.Condition `json:"conditions,omitempty"`
+	FailureMessage    string                `json:"failureMessage,omitempty"`
+}
+
+// Failure is a problem reported by one of the objects that make up a cluster.
+type Failure struct {
+	Object  string       `json:"object"`
+	Reason  string       `json:"reason,omitempty"`
+	Message string       `json:"message"`
+	Time    *metav1.Time `json:"time,omitempty"`
+}
+
+// Description aggregates the EKS-A and CAPI objects of a cluster.
+type Description struct {
+	Summary            Summary                             `json:"summary"`
+	Cluster            *v1alpha1.Cluster                   `json:"cluster"`
+	DatacenterConfig   *unstructured.Unstructured          `json:"datacenterConfig,omitempty"`
+	MachineConfigs     []*unstructured.Unstructured        `json:"machineConfigs,omitempty"`
+	CAPICluster        *clusterv1.Cluster                  `json:"capiCluster,omitempty"`
+	ControlPlane       *controlplanev1.KubeadmControlPlane `json:"kubeadmControlPlane,omitempty"`
+	MachineDeployments []clusterv1.MachineDeployment       `json:"machineDeployments,omitempty"`
+	Failures           []Failure                           `json:"failures,omitempty"`
+}
+
+// ListClusters returns a Summary for every EKS-A cluster in a namespace of the cluster pointed by kubeconfig.
+// An empty namespace lists the clusters in all namespaces.
+func (c *Client) ListClusters(ctx context.Context, kubeconfig, namespace string) ([]Summary, error) {
+	opts := []executables.KubectlOpt{executables.WithKubeconfig(kubeconfig)}
+	if namespace == "" {
+		opts = append(opts, executables.WithAllNamespaces())
+	} else {
+		opts = append(opts, executables.WithNamespace(namespace))
+	}
+
+	clusters, err := c.kubectl.GetEksaClusters(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("listing EKS-A clusters: %v", err)
+	}
+
+	summaries := make([]Summary, 0, len(clusters))
+	for i := range clusters {
+		cluster := &clusters[i]
+		kcp, err := c.getControlPlane(ctx, kubeconfig, cluster.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		mds, err := c.getMachineDeployments(ctx, kubeconfig, cluster.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		s, err := c.summarize(ctx, kubeconfig, cluster, kcp, mds)
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, *s)
+	}
+
+	return summaries, nil
+}
+
+// DescribeCluster returns a Description of an EKS-A cluster.
+func (c *Client) DescribeCluster(ctx context.Context, kubeconfig, name, namespace string) (*Description, error) {
+	cluster := &v1alpha1.Cluster{}
+	if err := c.kubectl.GetObject(ctx, eksaClusterResourceType, name, namespace, kubeconfig, cluster); err != nil {
+		return nil, fmt.Errorf("getting EKS-A cluster %s: %v", name, err)
+	}
+
+	d := &Description{
+		Cluster: cluster,
+	}
+
+	var err error
+	d.DatacenterConfig, err = c.getEKSAObject(ctx, kubeconfig, cluster.Spec.DatacenterRef.Kind, cluster.Spec.DatacenterRef.Name, cluster.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ref := range machineGroupRefs(cluster) {
+		m, err := c.getEKSAObject(ctx, kubeconfig, ref.Kind, ref.Name, cluster.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		if m != nil {
+			d.MachineConfigs = append(d.MachineConfigs, m)
+		}
+	}
+
+	capiCluster := &clusterv1.Cluster{}
+	if err = c.getOptionalObject(ctx, kubeconfig, capiClusterResourceType, cluster.Name, capiCluster); err != nil {
+		return nil, err
+	}
+	if capiCluster.Name != "" {
+		d.CAPICluster = capiCluster
+	}
+
+	d.ControlPlane, err = c.getControlPlane(ctx, kubeconfig, cluster.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	d.MachineDeployments, err = c.getMachineDeployments(ctx, kubeconfig, cluster.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := c.summarize(ctx, kubeconfig, cluster, d.ControlPlane, d.MachineDeployments)
+	if err != nil {
+		return nil, err
+	}
+	d.Summary = *summary
+
+	machines, err := c.getMachines(ctx, kubeconfig, cluster.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Failures = failures(d, machines)
+
+	return d, nil
+}
+
+// summarize builds the Summary of a cluster. Replica counts are read from the KubeadmControlPlane and
+// MachineDeployments when they exist, falling back to the counts in the cluster spec.
+func (c *Client) summarize(ctx context.Context, kubeconfig string, cluster *v1alpha1.Cluster, kcp *controlplanev1.KubeadmControlPlane, mds []clusterv1.MachineDeployment) (*Summary, error) {
+	s := &Summary{
+		Name:              cluster.Name,
+		Namespace:         cluster.Namespace,
+		Provider:          providerName(cluster.Spec.DatacenterRef.Kind),
+		KubernetesVersion: string(cluster.Spec.KubernetesVersion),
+		Conditions:        cluster.Status.Conditions,
+		ControlPlane:      ReplicaCount{Desired: int32(cluster.Spec.ControlPlaneConfiguration.Count)},
+	}
+
+	if cluster.Status.FailureMessage != nil {
+		s.FailureMessage = *cluster.Status.FailureMessage
+	}
+
+	if cluster.Spec.BundlesRef != nil {
+		s.BundlesName = cluster.Spec.BundlesRef.Name
+		bundles, err := c.kubectl.GetBundles(ctx, kubeconfig, cluster.Spec.BundlesRef.Name, cluster.Spec.BundlesRef.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("getting bundles for cluster %s: %v", cluster.Name, err)
+		}
+		s.BundlesVersion = bundles.Spec.Number
+	}
+
+	for _, w := range cluster.Spec.WorkerNodeGroupConfigurations {
+		if w.Count != nil {
+			s.Workers.Desired += int32(*w.Count)
+		}
+	}
+
+	if kcp != nil {
+		s.ControlPlane = ReplicaCount{Ready: kcp.Status.ReadyReplicas, Desired: kcp.Status.Replicas}
+	}
+
+	if len(mds) > 0 {
+		s.Workers = ReplicaCount{}
+		for _, md := range mds {
+			s.Workers.Ready += md.Status.ReadyReplicas
+			s.Workers.Desired += md.Status.Replicas
+		}
+	}
+
+	return s, nil
+}
+
+func (c *Client) getControlPlane(ctx context.Context, kubeconfig, clusterName string) (*controlplanev1.KubeadmControlPlane, error) {
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	if err := c.getOptionalObject(ctx, kubeconfig, kubeadmControlPlaneResourceType, clusterName, kcp); err != nil {
+		return nil, err
+	}
+	if kcp.Name == "" {
+		return nil, nil
+	}
+	return kcp, nil
+}
+
+func (c *Client) getMachineDeployments(ctx context.Context, kubeconfig, clusterName string) ([]clusterv1.MachineDeployment, error) {
+	mds, err := c.kubectl.GetMachineDeploymentsForCluster(ctx, clusterName,
+		executables.WithKubeconfig(kubeconfig),
+		executables.WithNamespace(constants.EksaSystemNamespace),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("getting machine deployments for cluster %s: %v", clusterName, err)
+	}
+	return mds, nil
+}
+
+func (c *Client) getMachines(ctx context.Context, kubeconfig, clusterName string) ([]clusterv1.Machine, error) {
+	machines := &clusterv1.MachineList{}
+	if err := c.kubectl.ListObjects(ctx, capiMachineResourceType, constants.EksaSystemNamespace, kubeconfig, machines); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("listing machines for cluster %s: %v", clusterName, err)
+	}
+
+	clusterMachines := make([]clusterv1.Machine, 0, len(machines.Items))
+	for _, m := range machines.Items {
+		if m.Labels[clusterv1.ClusterLabelName] == clusterName {
+			clusterMachines = append(clusterMachines, m)
+		}
+	}
+	return clusterMachines, nil
+}
+
+// getOptionalObject gets a CAPI object, leaving obj untouched if it doesn't exist.
+func (c *Client) getOptionalObject(ctx context.Context, kubeconfig, resourceType, name string, obj runtime.Object) error {
+	err := c.kubectl.GetObject(ctx, resourceType, name, constants.EksaSystemNamespace, kubeconfig, obj)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("getting %s %s: %v", resourceType, name, err)
+	}
+	return nil
+}
+
+// getEKSAObject gets an EKS-A object by kind, returning nil if it doesn't exist.
+func (c *Client) getEKSAObject(ctx context.Context, kubeconfig, kind, name, namespace string) (*unstructured.Unstructured, error) {
+	if kind == "" || name == "" {
+		return nil, nil
+	}
+
+	resourceType := fmt.Sprintf("%ss.%s", strings.ToLower(kind), v1alpha1.GroupVersion.Group)
+	obj := &unstructured.Unstructured{}
+	if err := c.kubectl.GetObject(ctx, resourceType, name, namespace, kubeconfig, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting %s %s: %v", kind, name, err)
+	}
+	return obj, nil
+}
+
+func machineGroupRefs(cluster *v1alpha1.Cluster) []v1alpha1.Ref {
+	var refs []v1alpha1.Ref
+	seen := map[v1alpha1.Ref]struct{}{}
+	add := func(ref *v1alpha1.Ref) {
+		if ref == nil {
+			return
+		}
+		if _, ok := seen[*ref]; ok {
+			return
+		}
+		seen[*ref] = struct{}{}
+		refs = append(refs, *ref)
+	}
+
+	add(cluster.Spec.ControlPlaneConfiguration.MachineGroupRef)
+	for _, w := range cluster.Spec.WorkerNodeGroupConfigurations {
+		add(w.MachineGroupRef)
+	}
+	if cluster.Spec.ExternalEtcdConfiguration != nil {
+		add(cluster.Spec.ExternalEtcdConfiguration.MachineGroupRef)
+	}
+
+	return refs
+}
+
+// providerName converts a datacenter kind, like VSphereDatacenterConfig, to a provider name, like vsphere.
+func providerName(datacenterKind string) string {
+	return strings.ToLower(strings.TrimSuffix(datacenterKind, "DatacenterConfig"))
+}
+
+// failures collects the failure messages and false conditions reported by the objects in a
+// Description and their machines, most recent first.
+func failures(d *Description, machines []clusterv1.Machine) []Failure {
+	var f []Failure
+
+	if d.Summary.FailureMessage != "" {
+		f = append(f, Failure{Object: objectName(v1alpha1.ClusterKind, d.Cluster.Name), Message: d.Summary.FailureMessage})
+	}
+
+	if d.CAPICluster != nil {
+		name := objectName("CAPICluster", d.CAPICluster.Name)
+		if d.CAPICluster.Status.FailureMessage != nil {
+			f = append(f, Failure{Object: name, Message: *d.CAPICluster.Status.FailureMessage})
+		}
+		f = append(f, conditionFailures(name, d.CAPICluster.Status.Conditions)...)
+	}
+
+	if d.ControlPlane != nil {
+		name := objectName("KubeadmControlPlane", d.ControlPlane.Name)
+		if d.ControlPlane.Status.FailureMessage != nil {
+			f = append(f, Failure{Object: name, Message: *d.ControlPlane.Status.FailureMessage})
+		}
+		f = append(f, conditionFailures(name, d.ControlPlane.Status.Conditions)...)
+	}
+
+	for _, md := range d.MachineDeployments {
+		f = append(f, conditionFailures(objectName("MachineDeployment", md.Name), md.Status.Conditions)...)
+	}
+
+	for _, m := range machines {
+		name := objectName("Machine", m.Name)
+		if m.Status.FailureMessage != nil {
+			f = append(f, Failure{Object: name, Message: *m.Status.FailureMessage})
+		}
+		f = append(f, conditionFailures(name, m.Status.Conditions)...)
+	}
+
+	sort.SliceStable(f, func(i, j int) bool {
+		if f[i].Time == nil || f[j].Time == nil {
+			return f[j].Time == nil && f[i].Time != nil
+		}
+		return f[j].Time.Before(f[i].Time)
+	})
+
+	if len(f) > maxFailures {
+		f = f[:maxFailures]
+	}
+
+	return f
+}
+
+func conditionFailures(object string, conditions clusterv1.Conditions) []Failure {
+	var f []Failure
+	for i := range conditions {
+		c := conditions[i]
+		if c.Status != "False" || c.Severity == clusterv1.ConditionSeverityInfo || c.Message == "" {
+			continue
+		}
+		f = append(f, Failure{
+			Object:  object,
+			Reason:  fmt.Sprintf("%s: %s", c.Type, c.Reason),
+			Message: c.Message,
+			Time:    &c.LastTransitionTime,
+		})
+	}
+	return f
+}
+
+func objectName(kind, name string) string {
+	return fmt.Sprintf("%s/%s", kind, name)
+}
@@ -0,0 +1,309 @@
+package clusterinfo_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/clusterinfo"
+	"github.com/aws/eks-anywhere/pkg/clusterinfo/mocks"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const (
+	kubeconfig = "mgmt.kubeconfig"
+	namespace  = "default"
+)
+
+type clusterInfoTest struct {
+	*WithT
+	ctx     context.Context
+	kubectl *mocks.MockKubectlClient
+	client  *clusterinfo.Client
+	cluster *v1alpha1.Cluster
+}
+
+func newClusterInfoTest(t *testing.T) *clusterInfoTest {
+	ctrl := gomock.NewController(t)
+	kubectl := mocks.NewMockKubectlClient(ctrl)
+	return &clusterInfoTest{
+		WithT:   NewWithT(t),
+		ctx:     context.Background(),
+		kubectl: kubectl,
+		client:  clusterinfo.NewClient(kubectl),
+		cluster: eksaCluster("workload"),
+	}
+}
+
+func eksaCluster(name string) *v1alpha1.Cluster {
+	return &v1alpha1.Cluster{
+		TypeMeta: metav1.TypeMeta{Kind: v1alpha1.ClusterKind},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: v1alpha1.ClusterSpec{
+			KubernetesVersion: v1alpha1.Kube124,
+			DatacenterRef: v1alpha1.Ref{
+				Kind: v1alpha1.VSphereDatacenterKind,
+				Name: name,
+			},
+			ControlPlaneConfiguration: v1alpha1.ControlPlaneConfiguration{
+				Count: 3,
+				MachineGroupRef: &v1alpha1.Ref{
+					Kind: v1alpha1.VSphereMachineConfigKind,
+					Name: name + "-cp",
+				},
+			},
+			WorkerNodeGroupConfigurations: []v1alpha1.WorkerNodeGroupConfiguration{
+				{
+					Name:  "md-0",
+					Count: intPtr(2),
+					MachineGroupRef: &v1alpha1.Ref{
+						Kind: v1alpha1.VSphereMachineConfigKind,
+						Name: name + "-cp",
+					},
+				},
+			},
+			BundlesRef: &v1alpha1.BundlesRef{
+				Name:      "bundles-10",
+				Namespace: constants.EksaSystemNamespace,
+			},
+		},
+		Status: v1alpha1.ClusterStatus{
+			Conditions: clusterv1.Conditions{
+				{Type: clusterv1.ReadyCondition, Status: "True"},
+			},
+		},
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func notFound() error {
+	return apierrors.NewNotFound(schema.GroupResource{}, "")
+}
+
+func (tt *clusterInfoTest) expectGetEksaClusters(namespace string, clusters []v1alpha1.Cluster, err error) {
+	namespaceOpt := executables.WithAllNamespaces()
+	if namespace != "" {
+		namespaceOpt = executables.WithNamespace(namespace)
+	}
+	tt.kubectl.EXPECT().GetEksaClusters(tt.ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, opts ...executables.KubectlOpt) ([]v1alpha1.Cluster, error) {
+			tt.Expect(kubectlArgs(opts...)).To(Equal(kubectlArgs(executables.WithKubeconfig(kubeconfig), namespaceOpt)))
+			return clusters, err
+		})
+}
+
+func (tt *clusterInfoTest) expectSummary(cluster *v1alpha1.Cluster) {
+	tt.kubectl.EXPECT().GetBundles(tt.ctx, kubeconfig, "bundles-10", constants.EksaSystemNamespace).Return(
+		&releasev1alpha1.Bundles{Spec: releasev1alpha1.BundlesSpec{Number: 10}}, nil,
+	)
+	tt.kubectl.EXPECT().GetObject(
+		tt.ctx, "kubeadmcontrolplanes.controlplane.cluster.x-k8s.io", cluster.Name, constants.EksaSystemNamespace, kubeconfig, gomock.Any(),
+	).DoAndReturn(func(_ context.Context, _, _, _, _ string, obj runtime.Object) error {
+		kcp := obj.(*controlplanev1.KubeadmControlPlane)
+		kcp.Name = cluster.Name
+		kcp.Status.Replicas = 3
+		kcp.Status.ReadyReplicas = 2
+		return nil
+	})
+	tt.kubectl.EXPECT().GetMachineDeploymentsForCluster(tt.ctx, cluster.Name, gomock.Any(), gomock.Any()).Return(
+		[]clusterv1.MachineDeployment{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: cluster.Name + "-md-0"},
+				Status:     clusterv1.MachineDeploymentStatus{Replicas: 2, ReadyReplicas: 2},
+			},
+		}, nil,
+	)
+}
+
+func TestClientListClusters(t *testing.T) {
+	tt := newClusterInfoTest(t)
+	tt.expectGetEksaClusters(namespace, []v1alpha1.Cluster{*tt.cluster}, nil)
+	tt.expectSummary(tt.cluster)
+
+	summaries, err := tt.client.ListClusters(tt.ctx, kubeconfig, namespace)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(summaries).To(ConsistOf(clusterinfo.Summary{
+		Name:              "workload",
+		Namespace:         namespace,
+		Provider:          "vsphere",
+		KubernetesVersion: "1.24",
+		BundlesName:       "bundles-10",
+		BundlesVersion:    10,
+		ControlPlane:      clusterinfo.ReplicaCount{Ready: 2, Desired: 3},
+		Workers:           clusterinfo.ReplicaCount{Ready: 2, Desired: 2},
+		Conditions:        tt.cluster.Status.Conditions,
+	}))
+}
+
+func TestClientListClustersAllNamespaces(t *testing.T) {
+	tt := newClusterInfoTest(t)
+	tt.expectGetEksaClusters("", nil, nil)
+
+	summaries, err := tt.client.ListClusters(tt.ctx, kubeconfig, "")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(summaries).To(BeEmpty())
+}
+
+func TestClientListClustersNoCAPIObjects(t *testing.T) {
+	tt := newClusterInfoTest(t)
+	tt.cluster.Spec.BundlesRef = nil
+	tt.expectGetEksaClusters(namespace, []v1alpha1.Cluster{*tt.cluster}, nil)
+	tt.kubectl.EXPECT().GetObject(tt.ctx, "kubeadmcontrolplanes.controlplane.cluster.x-k8s.io", "workload", constants.EksaSystemNamespace, kubeconfig, gomock.Any()).
+		Return(notFound())
+	tt.kubectl.EXPECT().GetMachineDeploymentsForCluster(tt.ctx, "workload", gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	summaries, err := tt.client.ListClusters(tt.ctx, kubeconfig, namespace)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(summaries).To(HaveLen(1))
+	tt.Expect(summaries[0].ControlPlane).To(Equal(clusterinfo.ReplicaCount{Ready: 0, Desired: 3}))
+	tt.Expect(summaries[0].Workers).To(Equal(clusterinfo.ReplicaCount{Ready: 0, Desired: 2}))
+}
+
+func TestClientListClustersErrorListing(t *testing.T) {
+	tt := newClusterInfoTest(t)
+	tt.expectGetEksaClusters(namespace, nil, errors.New("connection refused"))
+
+	_, err := tt.client.ListClusters(tt.ctx, kubeconfig, namespace)
+	tt.Expect(err).To(MatchError(ContainSubstring("listing EKS-A clusters: connection refused")))
+}
+
+func TestClientListClustersErrorGettingBundles(t *testing.T) {
+	tt := newClusterInfoTest(t)
+	tt.expectGetEksaClusters(namespace, []v1alpha1.Cluster{*tt.cluster}, nil)
+	tt.kubectl.EXPECT().GetObject(tt.ctx, "kubeadmcontrolplanes.controlplane.cluster.x-k8s.io", "workload", constants.EksaSystemNamespace, kubeconfig, gomock.Any()).
+		Return(notFound())
+	tt.kubectl.EXPECT().GetMachineDeploymentsForCluster(tt.ctx, "workload", gomock.Any(), gomock.Any()).Return(nil, nil)
+	tt.kubectl.EXPECT().GetBundles(tt.ctx, kubeconfig, "bundles-10", constants.EksaSystemNamespace).Return(nil, errors.New("bundles not found"))
+
+	_, err := tt.client.ListClusters(tt.ctx, kubeconfig, namespace)
+	tt.Expect(err).To(MatchError(ContainSubstring("getting bundles for cluster workload: bundles not found")))
+}
+
+func TestClientDescribeCluster(t *testing.T) {
+	tt := newClusterInfoTest(t)
+	failedAt := metav1.NewTime(time.Date(2023, 1, 10, 0, 0, 0, 0, time.UTC))
+	olderFailure := metav1.NewTime(time.Date(2023, 1, 9, 0, 0, 0, 0, time.UTC))
+
+	tt.kubectl.EXPECT().GetObject(tt.ctx, "clusters.anywhere.eks.amazonaws.com", "workload", namespace, kubeconfig, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _, _, _ string, obj runtime.Object) error {
+			tt.cluster.DeepCopyInto(obj.(*v1alpha1.Cluster))
+			return nil
+		})
+	tt.expectSummary(tt.cluster)
+	tt.kubectl.EXPECT().GetObject(tt.ctx, "vspheredatacenterconfigs.anywhere.eks.amazonaws.com", "workload", namespace, kubeconfig, gomock.Any()).
+		Return(nil)
+	tt.kubectl.EXPECT().GetObject(tt.ctx, "vspheremachineconfigs.anywhere.eks.amazonaws.com", "workload-cp", namespace, kubeconfig, gomock.Any()).
+		Return(nil)
+	tt.kubectl.EXPECT().GetObject(tt.ctx, "clusters.cluster.x-k8s.io", "workload", constants.EksaSystemNamespace, kubeconfig, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _, _, _ string, obj runtime.Object) error {
+			c := obj.(*clusterv1.Cluster)
+			c.Name = "workload"
+			c.Status.Conditions = clusterv1.Conditions{
+				{
+					Type:               clusterv1.ReadyCondition,
+					Status:             "False",
+					Severity:           clusterv1.ConditionSeverityWarning,
+					Reason:             "ScalingUp",
+					Message:            "Scaling up control plane",
+					LastTransitionTime: olderFailure,
+				},
+			}
+			return nil
+		})
+	tt.kubectl.EXPECT().ListObjects(tt.ctx, "machines.cluster.x-k8s.io", constants.EksaSystemNamespace, kubeconfig, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _, _ string, list kubernetes.ObjectList) error {
+			list.(*clusterv1.MachineList).Items = []clusterv1.Machine{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "workload-cp-1",
+						Labels: map[string]string{clusterv1.ClusterLabelName: "workload"},
+					},
+					Status: clusterv1.MachineStatus{
+						Conditions: clusterv1.Conditions{
+							{
+								Type:               clusterv1.InfrastructureReadyCondition,
+								Status:             "False",
+								Severity:           clusterv1.ConditionSeverityError,
+								Reason:             "CloningFailed",
+								Message:            "template not found",
+								LastTransitionTime: failedAt,
+							},
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "other-cp-1",
+						Labels: map[string]string{clusterv1.ClusterLabelName: "other"},
+					},
+					Status: clusterv1.MachineStatus{FailureMessage: stringPtr("other cluster failure")},
+				},
+			}
+			return nil
+		})
+
+	d, err := tt.client.DescribeCluster(tt.ctx, kubeconfig, "workload", namespace)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(d.Cluster.Name).To(Equal("workload"))
+	tt.Expect(d.Summary.BundlesVersion).To(Equal(10))
+	tt.Expect(d.DatacenterConfig).NotTo(BeNil())
+	tt.Expect(d.MachineConfigs).To(HaveLen(1))
+	tt.Expect(d.CAPICluster).NotTo(BeNil())
+	tt.Expect(d.ControlPlane).NotTo(BeNil())
+	tt.Expect(d.MachineDeployments).To(HaveLen(1))
+	tt.Expect(d.Failures).To(Equal([]clusterinfo.Failure{
+		{
+			Object:  "Machine/workload-cp-1",
+			Reason:  "InfrastructureReady: CloningFailed",
+			Message: "template not found",
+			Time:    &failedAt,
+		},
+		{
+			Object:  "CAPICluster/workload",
+			Reason:  "Ready: ScalingUp",
+			Message: "Scaling up control plane",
+			Time:    &olderFailure,
+		},
+	}))
+}
+
+func TestClientDescribeClusterNotFound(t *testing.T) {
+	tt := newClusterInfoTest(t)
+	tt.kubectl.EXPECT().GetObject(tt.ctx, "clusters.anywhere.eks.amazonaws.com", "workload", namespace, kubeconfig, gomock.Any()).
+		Return(notFound())
+
+	_, err := tt.client.DescribeCluster(tt.ctx, kubeconfig, "workload", namespace)
+	tt.Expect(err).To(MatchError(ContainSubstring("getting EKS-A cluster workload")))
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func kubectlArgs(opts ...executables.KubectlOpt) []string {
+	var args []string
+	for _, opt := range opts {
+		opt(&args)
+	}
+	return args
+}
@@ -0,0 +1,171 @@
+package clusterinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/yaml"
+)
+
+// Output formats supported by the printers.
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputYAML  = "yaml"
+)
+
+// ValidateOutput returns an error if format is not a supported output format.
+func ValidateOutput(format string) error {
+	switch format {
+	case OutputTable, OutputJSON, OutputYAML:
+		return nil
+	default:
+		return fmt.Errorf("invalid output format [%s], valid options are: %s, %s, %s", format, OutputTable, OutputJSON, OutputYAML)
+	}
+}
+
+// PrintSummaries writes a list of cluster summaries to w in the given format.
+func PrintSummaries(w io.Writer, format string, summaries []Summary) error {
+	if format != OutputTable {
+		return printSerialized(w, format, summaries)
+	}
+
+	t := tabwriter.NewWriter(w, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(t, "NAME\tNAMESPACE\tPROVIDER\tKUBERNETES VERSION\tBUNDLES VERSION\tCONTROL PLANE\tWORKERS\tCONDITIONS")
+	for _, s := range summaries {
+		fmt.Fprintf(t, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			s.Name, s.Namespace, s.Provider, s.KubernetesVersion, bundlesVersion(s), s.ControlPlane, s.Workers, conditionsSummary(s.Conditions),
+		)
+	}
+	if err := t.Flush(); err != nil {
+		return fmt.Errorf("failed flushing table writer: %v", err)
+	}
+	return nil
+}
+
+// PrintDescription writes a cluster description to w in the given format.
+func PrintDescription(w io.Writer, format string, d *Description) error {
+	if format != OutputTable {
+		return printSerialized(w, format, d)
+	}
+
+	t := tabwriter.NewWriter(w, 10, 4, 3, ' ', 0)
+	s := d.Summary
+	fmt.Fprintf(t, "Name:\t%s\n", s.Name)
+	fmt.Fprintf(t, "Namespace:\t%s\n", s.Namespace)
+	fmt.Fprintf(t, "Provider:\t%s\n", s.Provider)
+	fmt.Fprintf(t, "Kubernetes Version:\t%s\n", s.KubernetesVersion)
+	fmt.Fprintf(t, "Bundles:\t%s\n", bundlesVersion(s))
+	if d.DatacenterConfig != nil {
+		fmt.Fprintf(t, "Datacenter Config:\t%s/%s\n", d.DatacenterConfig.GetKind(), d.DatacenterConfig.GetName())
+	}
+	for _, m := range d.MachineConfigs {
+		fmt.Fprintf(t, "Machine Config:\t%s/%s\n", m.GetKind(), m.GetName())
+	}
+	if s.FailureMessage != "" {
+		fmt.Fprintf(t, "Failure Message:\t%s\n", s.FailureMessage)
+	}
+
+	fmt.Fprintln(t, "\nConditions:")
+	printConditions(t, s.Conditions)
+
+	if d.CAPICluster != nil {
+		fmt.Fprintln(t, "\nCAPI Cluster:")
+		fmt.Fprintf(t, "  Phase:\t%s\n", d.CAPICluster.Status.Phase)
+		fmt.Fprintf(t, "  Control Plane Ready:\t%t\n", d.CAPICluster.Status.ControlPlaneReady)
+		fmt.Fprintf(t, "  Infrastructure Ready:\t%t\n", d.CAPICluster.Status.InfrastructureReady)
+	}
+
+	if d.ControlPlane != nil {
+		fmt.Fprintln(t, "\nControl Plane:")
+		fmt.Fprintf(t, "  Name:\t%s\n", d.ControlPlane.Name)
+		fmt.Fprintf(t, "  Version:\t%s\n", d.ControlPlane.Spec.Version)
+		fmt.Fprintf(t, "  Ready:\t%s\n", s.ControlPlane)
+		fmt.Fprintf(t, "  Updated:\t%d\n", d.ControlPlane.Status.UpdatedReplicas)
+	}
+
+	if len(d.MachineDeployments) > 0 {
+		fmt.Fprintln(t, "\nMachine Deployments:")
+		fmt.Fprintln(t, "  NAME\tPHASE\tVERSION\tREADY\tUPDATED")
+		for _, md := range d.MachineDeployments {
+			version := ""
+			if md.Spec.Template.Spec.Version != nil {
+				version = *md.Spec.Template.Spec.Version
+			}
+			fmt.Fprintf(t, "  %s\t%s\t%s\t%d/%d\t%d\n",
+				md.Name, md.Status.Phase, version, md.Status.ReadyReplicas, md.Status.Replicas, md.Status.UpdatedReplicas,
+			)
+		}
+	}
+
+	if len(d.Failures) > 0 {
+		fmt.Fprintln(t, "\nRecent Failures:")
+		fmt.Fprintln(t, "  OBJECT\tREASON\tMESSAGE\tTIME")
+		for _, f := range d.Failures {
+			time := ""
+			if f.Time != nil {
+				time = f.Time.UTC().Format("2006-01-02T15:04:05Z")
+			}
+			fmt.Fprintf(t, "  %s\t%s\t%s\t%s\n", f.Object, f.Reason, f.Message, time)
+		}
+	}
+
+	if err := t.Flush(); err != nil {
+		return fmt.Errorf("failed flushing table writer: %v", err)
+	}
+	return nil
+}
+
+func printSerialized(w io.Writer, format string, obj interface{}) error {
+	var content []byte
+	var err error
+	switch format {
+	case OutputJSON:
+		content, err = json.MarshalIndent(obj, "", "  ")
+		content = append(content, '\n')
+	case OutputYAML:
+		content, err = yaml.Marshal(obj)
+	default:
+		return ValidateOutput(format)
+	}
+	if err != nil {
+		return fmt.Errorf("failed serializing output to %s: %v", format, err)
+	}
+
+	_, err = w.Write(content)
+	return err
+}
+
+func printConditions(w io.Writer, conditions clusterv1.Conditions) {
+	if len(conditions) == 0 {
+		fmt.Fprintln(w, "  <none>")
+		return
+	}
+	fmt.Fprintln(w, "  TYPE\tSTATUS\tREASON\tMESSAGE")
+	for _, c := range conditions {
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", c.Type, c.Status, c.Reason, c.Message)
+	}
+}
+
+func bundlesVersion(s Summary) string {
+	if s.BundlesName == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s (%d)", s.BundlesName, s.BundlesVersion)
+}
+
+// conditionsSummary lists the condition types with their status, like Ready=True,ControlPlaneReady=False.
+func conditionsSummary(conditions clusterv1.Conditions) string {
+	if len(conditions) == 0 {
+		return "<none>"
+	}
+	c := make([]string, 0, len(conditions))
+	for _, condition := range conditions {
+		c = append(c, fmt.Sprintf("%s=%s", condition.Type, condition.Status))
+	}
+	return strings.Join(c, ",")
+}
@@ -0,0 +1,77 @@
+package clusterinfo_test
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/clusterinfo"
+)
+
+func summaries() []clusterinfo.Summary {
+	return []clusterinfo.Summary{
+		{
+			Name:              "mgmt",
+			Namespace:         "default",
+			Provider:          "vsphere",
+			KubernetesVersion: "1.24",
+			BundlesName:       "bundles-10",
+			BundlesVersion:    10,
+			ControlPlane:      clusterinfo.ReplicaCount{Ready: 3, Desired: 3},
+			Workers:           clusterinfo.ReplicaCount{Ready: 1, Desired: 2},
+			Conditions: clusterv1.Conditions{
+				{Type: clusterv1.ReadyCondition, Status: "False"},
+			},
+		},
+	}
+}
+
+func TestPrintSummariesTable(t *testing.T) {
+	g := NewWithT(t)
+	b := &bytes.Buffer{}
+	g.Expect(clusterinfo.PrintSummaries(b, clusterinfo.OutputTable, summaries())).To(Succeed())
+	g.Expect(b.String()).To(Equal(
+		"NAME      NAMESPACE   PROVIDER   KUBERNETES VERSION   BUNDLES VERSION   CONTROL PLANE   WORKERS   CONDITIONS\n" +
+			"mgmt      default     vsphere    1.24                 bundles-10 (10)   3/3             1/2       Ready=False\n",
+	))
+}
+
+func TestPrintSummariesJSON(t *testing.T) {
+	g := NewWithT(t)
+	b := &bytes.Buffer{}
+	g.Expect(clusterinfo.PrintSummaries(b, clusterinfo.OutputJSON, summaries())).To(Succeed())
+	g.Expect(b.String()).To(ContainSubstring(`"controlPlane": {
+      "ready": 3,
+      "desired": 3
+    }`))
+}
+
+func TestPrintSummariesYAML(t *testing.T) {
+	g := NewWithT(t)
+	b := &bytes.Buffer{}
+	g.Expect(clusterinfo.PrintSummaries(b, clusterinfo.OutputYAML, summaries())).To(Succeed())
+	g.Expect(b.String()).To(ContainSubstring("- bundlesName: bundles-10\n  bundlesVersion: 10\n"))
+}
+
+func TestPrintDescriptionTable(t *testing.T) {
+	g := NewWithT(t)
+	b := &bytes.Buffer{}
+	d := &clusterinfo.Description{
+		Summary: summaries()[0],
+		Failures: []clusterinfo.Failure{
+			{Object: "Machine/mgmt-md-0-1", Message: "VM could not be cloned"},
+		},
+	}
+	g.Expect(clusterinfo.PrintDescription(b, clusterinfo.OutputTable, d)).To(Succeed())
+	g.Expect(b.String()).To(ContainSubstring("Provider:"))
+	g.Expect(b.String()).To(ContainSubstring("Recent Failures:"))
+	g.Expect(b.String()).To(ContainSubstring("VM could not be cloned"))
+}
+
+func TestValidateOutput(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(clusterinfo.ValidateOutput(clusterinfo.OutputYAML)).To(Succeed())
+	g.Expect(clusterinfo.ValidateOutput("xml")).To(MatchError(ContainSubstring("invalid output format [xml]")))
+}
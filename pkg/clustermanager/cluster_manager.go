@@ -27,6 +27,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/clustermarshaller"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/diagnostics"
+	"github.com/aws/eks-anywhere/pkg/encryption"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
@@ -79,6 +80,8 @@ type ClusterManager struct {
 type ClusterClient interface {
 	KubernetesClient
 	MoveManagement(ctx context.Context, org, target *types.Cluster) error
+	BackupManagement(ctx context.Context, cluster *types.Cluster, managementStatePath string) error
+	RestoreManagement(ctx context.Context, cluster *types.Cluster, managementStatePath string) error
 	WaitForClusterReady(ctx context.Context, cluster *types.Cluster, timeout string, clusterName string) error
 	WaitForControlPlaneAvailable(ctx context.Context, cluster *types.Cluster, timeout string, newClusterName string) error
 	WaitForControlPlaneReady(ctx context.Context, cluster *types.Cluster, timeout string, newClusterName string) error
@@ -260,6 +263,22 @@ func (c *ClusterManager) MoveCAPI(ctx context.Context, from, to *types.Cluster,
 	return nil
 }
 
+// BackupCAPI saves the CAPI objects of a management cluster to managementStatePath.
+func (c *ClusterManager) BackupCAPI(ctx context.Context, cluster *types.Cluster, managementStatePath string) error {
+	if err := c.clusterClient.BackupManagement(ctx, cluster, managementStatePath); err != nil {
+		return fmt.Errorf("backing up CAPI resources of management cluster before moving to bootstrap cluster: %v", err)
+	}
+	return nil
+}
+
+// RestoreCAPI creates in cluster the CAPI objects saved to managementStatePath by BackupCAPI.
+func (c *ClusterManager) RestoreCAPI(ctx context.Context, cluster *types.Cluster, managementStatePath string) error {
+	if err := c.clusterClient.RestoreManagement(ctx, cluster, managementStatePath); err != nil {
+		return fmt.Errorf("restoring CAPI resources of management cluster from backup: %v", err)
+	}
+	return nil
+}
+
 func (c *ClusterManager) writeCAPISpecFile(clusterName string, content []byte) error {
 	fileName := fmt.Sprintf("%s-eks-a-cluster.yaml", clusterName)
 	if _, err := c.writer.Write(fileName, content); err != nil {
@@ -722,6 +741,22 @@ func (c *ClusterManager) CreateAwsIamAuthCaSecret(ctx context.Context, managemen
 	return c.awsIamAuth.CreateAndInstallAWSIAMAuthCASecret(ctx, managementCluster, workloadClusterName)
 }
 
+// CreateEncryptionConfigSecret creates the secret holding the kube-apiserver encryption configuration of a cluster,
+// with new keys for its aescbc and secretbox providers, in the management cluster. The control plane machines read
+// it from the secret when they join the cluster.
+func (c *ClusterManager) CreateEncryptionConfigSecret(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	secret, err := encryption.NewConfigSecret(clusterSpec.Cluster, encryption.GenerateKey)
+	if err != nil {
+		return fmt.Errorf("generating encryption configuration secret: %v", err)
+	}
+
+	if err = c.clusterClient.ApplyKubeSpecFromBytes(ctx, managementCluster, secret); err != nil {
+		return fmt.Errorf("applying encryption configuration secret: %v", err)
+	}
+
+	return nil
+}
+
 func (c *ClusterManager) SaveLogsManagementCluster(ctx context.Context, spec *cluster.Spec, cluster *types.Cluster) error {
 	if cluster == nil {
 		return nil
@@ -1387,6 +1387,62 @@ func TestClusterManagerMoveCAPIErrorMove(t *testing.T) {
 	}
 }
 
+func TestClusterManagerBackupCAPISuccess(t *testing.T) {
+	from := &types.Cluster{
+		Name: "from-cluster",
+	}
+	ctx := context.Background()
+
+	c, m := newClusterManager(t)
+	m.client.EXPECT().BackupManagement(ctx, from, "backup-dir")
+
+	if err := c.BackupCAPI(ctx, from, "backup-dir"); err != nil {
+		t.Errorf("ClusterManager.BackupCAPI() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerBackupCAPIError(t *testing.T) {
+	from := &types.Cluster{
+		Name: "from-cluster",
+	}
+	ctx := context.Background()
+
+	c, m := newClusterManager(t)
+	m.client.EXPECT().BackupManagement(ctx, from, "backup-dir").Return(errors.New("backing up CAPI resources"))
+
+	if err := c.BackupCAPI(ctx, from, "backup-dir"); err == nil {
+		t.Error("ClusterManager.BackupCAPI() error = nil, wantErr not nil")
+	}
+}
+
+func TestClusterManagerRestoreCAPISuccess(t *testing.T) {
+	to := &types.Cluster{
+		Name: "to-cluster",
+	}
+	ctx := context.Background()
+
+	c, m := newClusterManager(t)
+	m.client.EXPECT().RestoreManagement(ctx, to, "backup-dir")
+
+	if err := c.RestoreCAPI(ctx, to, "backup-dir"); err != nil {
+		t.Errorf("ClusterManager.RestoreCAPI() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerRestoreCAPIError(t *testing.T) {
+	to := &types.Cluster{
+		Name: "to-cluster",
+	}
+	ctx := context.Background()
+
+	c, m := newClusterManager(t)
+	m.client.EXPECT().RestoreManagement(ctx, to, "backup-dir").Return(errors.New("restoring CAPI resources"))
+
+	if err := c.RestoreCAPI(ctx, to, "backup-dir"); err == nil {
+		t.Error("ClusterManager.RestoreCAPI() error = nil, wantErr not nil")
+	}
+}
+
 func TestClusterManagerMoveCAPIErrorGetClustersBeforeMove(t *testing.T) {
 	from := &types.Cluster{
 		Name: "from-cluster",
@@ -2484,6 +2540,30 @@ func TestCreateAwsIamAuthCaSecretSuccess(t *testing.T) {
 	tt.Expect(err).To(BeNil())
 }
 
+func TestCreateEncryptionConfigSecretSuccess(t *testing.T) {
+	tt := newTest(t)
+	tt.clusterSpec.Cluster.Spec.EncryptionConfiguration = &v1alpha1.EncryptionConfiguration{
+		Providers: []v1alpha1.EncryptionProvider{{Type: v1alpha1.AESCBCEncryptionProvider}},
+	}
+
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, data []byte) error {
+			tt.Expect(string(data)).To(ContainSubstring("encryption-config.yaml"))
+			return nil
+		},
+	)
+
+	tt.Expect(tt.clusterManager.CreateEncryptionConfigSecret(tt.ctx, tt.cluster, tt.clusterSpec)).To(Succeed())
+}
+
+func TestCreateEncryptionConfigSecretNoEncryptionConfiguration(t *testing.T) {
+	tt := newTest(t)
+
+	tt.Expect(tt.clusterManager.CreateEncryptionConfigSecret(tt.ctx, tt.cluster, tt.clusterSpec)).To(
+		MatchError(ContainSubstring("generating encryption configuration secret")),
+	)
+}
+
 func TestClusterManagerDeleteClusterSelfManagedCluster(t *testing.T) {
 	tt := newTest(t)
 	managementCluster := &types.Cluster{
@@ -3,6 +3,8 @@ package clustermarshaller
 import (
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
 
 	"github.com/aws/eks-anywhere/internal/pkg/api"
@@ -40,6 +42,9 @@ func MarshalClusterSpec(clusterSpec *cluster.Spec, datacenterConfig providers.Da
 	if clusterSpec.AWSIamConfig != nil {
 		marshallables = append(marshallables, clusterSpec.AWSIamConfig.ConvertConfigToConfigGenerateStruct())
 	}
+	if clusterSpec.AuditPolicyConfigMap != nil {
+		marshallables = append(marshallables, auditPolicyConfigMap(clusterSpec.AuditPolicyConfigMap))
+	}
 	if clusterSpec.TinkerbellTemplateConfigs != nil {
 		for _, t := range clusterSpec.TinkerbellTemplateConfigs {
 			marshallables = append(marshallables, t.ConvertConfigToConfigGenerateStruct())
@@ -64,6 +69,22 @@ func MarshalClusterSpec(clusterSpec *cluster.Spec, datacenterConfig providers.Da
 	return templater.AppendYamlResources(resources...), nil
 }
 
+// auditPolicyConfigMap returns the audit policy ConfigMap with only the fields set by the user,
+// so it can be applied to a different cluster.
+func auditPolicyConfigMap(cm *corev1.ConfigMap) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cm.Name,
+			Namespace: cm.Namespace,
+		},
+		Data: cm.Data,
+	}
+}
+
 func WriteClusterConfig(clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig, writer filewriter.FileWriter) error {
 	resourcesSpec, err := MarshalClusterSpec(clusterSpec, datacenterConfig, machineConfigs)
 	if err != nil {
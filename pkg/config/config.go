@@ -1,9 +1,13 @@
 package config
 
+import "time"
+
 const (
 	EksaGitPassphraseTokenEnv = "EKSA_GIT_SSH_KEY_PASSPHRASE"
 	EksaGitPrivateKeyTokenEnv = "EKSA_GIT_PRIVATE_KEY"
 	EksaGitKnownHostsFileEnv  = "EKSA_GIT_KNOWN_HOSTS"
+	EksaGitUsernameEnv        = "EKSA_GIT_USERNAME"
+	EksaGitPasswordEnv        = "EKSA_GIT_PASSWORD"
 	SshKnownHostsEnv          = "SSH_KNOWN_HOSTS"
 	EksaAccessKeyIdEnv        = "EKSA_AWS_ACCESS_KEY_ID"
 	EksaSecretAccessKeyEnv    = "EKSA_AWS_SECRET_ACCESS_KEY"
@@ -16,4 +20,12 @@ type CliConfig struct {
 	GitSshKeyPassphrase string
 	GitPrivateKeyFile   string
 	GitKnownHostsFile   string
+	GitUsername         string
+	GitPassword         string
+	GitCaFile           string
+	// GitOpsPullRequest makes cluster upgrades open a pull request with the cluster config changes
+	// instead of pushing them to the gitops branch.
+	GitOpsPullRequest bool
+	// GitOpsPullRequestTimeout is how long to wait for the pull request to be merged.
+	GitOpsPullRequestTimeout time.Duration
 }
@@ -3,15 +3,12 @@ package dependencies
 import (
 	"context"
 	"crypto/tls"
-	"crypto/x509"
-	"encoding/pem"
 	"fmt"
 	"net/http"
 	"os"
 	"time"
 
 	"github.com/google/uuid"
-	prismgoclient "github.com/nutanix-cloud-native/prism-go-client"
 	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
 	"golang.org/x/exp/maps"
 
@@ -97,6 +94,7 @@ type Dependencies struct {
 	VSphereValidator            *vsphere.Validator
 	VSphereDefaulter            *vsphere.Defaulter
 	NutanixPrismClient          *v3.Client
+	NutanixValidatorRegistry    nutanix.ValidatorRegistry
 	SnowValidator               *snow.AwsClientValidator
 	IPValidator                 *validator.IPValidator
 }
@@ -448,9 +446,6 @@ func (f *Factory) WithProvider(clusterConfigFile string, clusterConfig *v1alpha1
 				return fmt.Errorf("unable to get machine config from file %s: %v", clusterConfigFile, err)
 			}
 
-			skipVerifyTransport := http.DefaultTransport.(*http.Transport).Clone()
-			skipVerifyTransport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
-			httpClient := &http.Client{Transport: skipVerifyTransport}
 			provider := nutanix.NewProvider(
 				datacenterConfig,
 				machineConfigs,
@@ -458,7 +453,7 @@ func (f *Factory) WithProvider(clusterConfigFile string, clusterConfig *v1alpha1
 				f.dependencies.Kubectl,
 				f.dependencies.NutanixPrismClient.V3,
 				crypto.NewTlsValidator(),
-				httpClient,
+				newNutanixHTTPClient(),
 				time.Now,
 			)
 			f.dependencies.Provider = provider
@@ -1207,43 +1202,40 @@ func (f *Factory) WithPrismClient(clusterConfigFile string) *Factory {
 			return fmt.Errorf("unable to get datacenter config from file %s: %v", clusterConfigFile, err)
 		}
 
-		clientOpts := make([]v3.ClientOption, 0)
-		if datacenterConfig.Spec.AdditionalTrustBundle != "" {
-			block, _ := pem.Decode([]byte(datacenterConfig.Spec.AdditionalTrustBundle))
-			certs, err := x509.ParseCertificates(block.Bytes)
-			if err != nil {
-				return fmt.Errorf("unable to parse additional trust bundle %s: %v", datacenterConfig.Spec.AdditionalTrustBundle, err)
-			}
-			if len(certs) == 0 {
-				return fmt.Errorf("unable to extract certs from the addtional trust bundle %s", datacenterConfig.Spec.AdditionalTrustBundle)
-			}
-			clientOpts = append(clientOpts, v3.WithCertificate(certs[0]))
+		client, err := nutanix.NewPrismClient(datacenterConfig, nutanix.GetCredsFromEnv())
+		if err != nil {
+			return err
 		}
+		f.dependencies.NutanixPrismClient = client
+		return nil
+	})
 
-		endpoint := datacenterConfig.Spec.Endpoint
-		port := datacenterConfig.Spec.Port
-		url := fmt.Sprintf("%s:%d", endpoint, port)
-		creds := nutanix.GetCredsFromEnv()
-		nutanixCreds := prismgoclient.Credentials{
-			URL:      url,
-			Username: creds.PrismCentral.Username,
-			Password: creds.PrismCentral.Password,
-			Endpoint: endpoint,
-			Port:     fmt.Sprintf("%d", port),
-			Insecure: datacenterConfig.Spec.Insecure,
-		}
+	return f
+}
 
-		client, err := v3.NewV3Client(nutanixCreds, clientOpts...)
-		if err != nil {
-			return fmt.Errorf("error creating nutanix client: %v", err)
+// WithNutanixValidatorRegistry initializes the Nutanix validator registry for the object being constructed to make it available in the constructor.
+func (f *Factory) WithNutanixValidatorRegistry() *Factory {
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.dependencies.NutanixValidatorRegistry != nil {
+			return nil
 		}
-		f.dependencies.NutanixPrismClient = client
+
+		f.dependencies.NutanixValidatorRegistry = nutanix.NewValidatorFactory(crypto.NewTlsValidator(), newNutanixHTTPClient())
+
 		return nil
 	})
 
 	return f
 }
 
+// newNutanixHTTPClient returns the http client used to check Prism Central is reachable.
+// TLS is validated separately against the datacenter trust bundle, so it's skipped here.
+func newNutanixHTTPClient() *http.Client {
+	skipVerifyTransport := http.DefaultTransport.(*http.Transport).Clone()
+	skipVerifyTransport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	return &http.Client{Transport: skipVerifyTransport}
+}
+
 func getProxyConfiguration(clusterSpec *cluster.Spec) (httpProxy, httpsProxy string, noProxy []string) {
 	proxyConfiguration := clusterSpec.Cluster.Spec.ProxyConfiguration
 	if proxyConfiguration != nil {
@@ -209,6 +209,7 @@ func TestFactoryBuildWithMultipleDependencies(t *testing.T) {
 		WithManifestReader().
 		WithUnAuthKubeClient().
 		WithCloudStackValidatorRegistry(false).
+		WithNutanixValidatorRegistry().
 		WithVSphereDefaulter().
 		WithVSphereValidator().
 		WithCiliumTemplater().
@@ -233,6 +234,7 @@ func TestFactoryBuildWithMultipleDependencies(t *testing.T) {
 	tt.Expect(deps.VSphereValidator).NotTo(BeNil())
 	tt.Expect(deps.CiliumTemplater).NotTo(BeNil())
 	tt.Expect(deps.IPValidator).NotTo(BeNil())
+	tt.Expect(deps.NutanixValidatorRegistry).NotTo(BeNil())
 }
 
 func TestFactoryBuildWithProxyConfiguration(t *testing.T) {
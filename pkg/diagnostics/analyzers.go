@@ -215,9 +215,12 @@ func (a *analyzerFactory) eksaDockerAnalyzers() []*Analyze {
 
 // EksaLogTextAnalyzers given a slice of Collectors will check which namespaced log collectors are present
 // and return the log analyzers associated with the namespace in the namespaceLogTextAnalyzersMap.
+// It does the same for the host logs copied from the nodes, using the collector name as key in the hostLogTextAnalyzersMap.
 func (a *analyzerFactory) EksaLogTextAnalyzers(collectors []*Collect) []*Analyze {
 	var analyzers []*Analyze
 	analyzersMap := a.namespaceLogTextAnalyzersMap()
+	hostAnalyzersMap := a.hostLogTextAnalyzersMap()
+	hostLogsSeen := map[string]bool{}
 	for _, collector := range collectors {
 		if collector.Logs != nil {
 			analyzer, ok := analyzersMap[collector.Logs.Namespace]
@@ -225,6 +228,13 @@ func (a *analyzerFactory) EksaLogTextAnalyzers(collectors []*Collect) []*Analyze
 				analyzers = append(analyzers, analyzer...)
 			}
 		}
+		if collector.CopyFromHost != nil && !hostLogsSeen[collector.CopyFromHost.Name] {
+			analyzer, ok := hostAnalyzersMap[collector.CopyFromHost.Name]
+			if ok {
+				analyzers = append(analyzers, analyzer...)
+			}
+			hostLogsSeen[collector.CopyFromHost.Name] = true
+		}
 	}
 	return analyzers
 }
@@ -237,6 +247,77 @@ func (a *analyzerFactory) namespaceLogTextAnalyzersMap() map[string][]*Analyze {
 	}
 }
 
+// hostLogTextAnalyzersMap is used to associate log text analyzers with the logs copied from the nodes.
+// the key of the analyzers map is the copyFromHost collector name, and the value are the associated log text analyzers.
+// copyFromHost stores the files under <collector name>/<node name>/, so the file names are globs matching every node.
+func (a *analyzerFactory) hostLogTextAnalyzersMap() map[string][]*Analyze {
+	return map[string][]*Analyze{
+		hostlogPath("syslog"):            a.nodeServicesLogAnalyzers(path.Join(hostlogPath("syslog"), "*", "syslog")),
+		hostlogPath("messages"):          a.nodeServicesLogAnalyzers(path.Join(hostlogPath("messages"), "*", "messages")),
+		hostlogPath("journal"):           a.nodeServicesLogAnalyzers(path.Join(hostlogPath("journal"), "*", "*", "*.journal")),
+		hostlogPath("cloud-init-output"): a.cloudInitLogAnalyzers(path.Join(hostlogPath("cloud-init-output"), "*", "cloud-init-output.log")),
+	}
+}
+
+// nodeServicesLogAnalyzers looks for kubelet and containerd failures in the node system logs.
+// Journal files are binary, but journald stores short messages uncompressed so the same patterns still match them.
+func (a *analyzerFactory) nodeServicesLogAnalyzers(fileName string) []*Analyze {
+	return []*Analyze{
+		a.hostLogTextAnalyzer(
+			fmt.Sprintf("%s: Kubelet failed to start. Log: %s", logAnalysisAnalyzerPrefix, fileName),
+			fileName,
+			`kubelet.*(failed to run Kubelet|Failed to start ContainerManager)`,
+			fmt.Sprintf("Kubelet failed to start on a node. See %s", fileName),
+			"Kubelet started correctly",
+		),
+		a.hostLogTextAnalyzer(
+			fmt.Sprintf("%s: Container image pull failed. Log: %s", logAnalysisAnalyzerPrefix, fileName),
+			fileName,
+			`failed to pull and unpack image`,
+			fmt.Sprintf("Containerd failed to pull an image on a node, check the registry mirror and proxy configuration. See %s", fileName),
+			"Container images pulled correctly",
+		),
+	}
+}
+
+func (a *analyzerFactory) cloudInitLogAnalyzers(fileName string) []*Analyze {
+	return []*Analyze{
+		a.hostLogTextAnalyzer(
+			fmt.Sprintf("%s: Cloud-init failed. Log: %s", logAnalysisAnalyzerPrefix, fileName),
+			fileName,
+			`(Failed to run module|Traceback \(most recent call last\))`,
+			fmt.Sprintf("Cloud-init failed to bootstrap a node. See %s", fileName),
+			"Cloud-init ran correctly",
+		),
+	}
+}
+
+func (a *analyzerFactory) hostLogTextAnalyzer(checkName, fileName, regex, failMessage, passMessage string) *Analyze {
+	return &Analyze{
+		TextAnalyze: &textAnalyze{
+			analyzeMeta: analyzeMeta{
+				CheckName: checkName,
+			},
+			FileName:     fileName,
+			RegexPattern: regex,
+			Outcomes: []*outcome{
+				{
+					Fail: &singleOutcome{
+						When:    "true",
+						Message: failMessage,
+					},
+				},
+				{
+					Pass: &singleOutcome{
+						When:    "false",
+						Message: passMessage,
+					},
+				},
+			},
+		},
+	}
+}
+
 func (a *analyzerFactory) capiKubeadmControlPlaneSystemLogAnalyzers() []*Analyze {
 	capiCpManagerPod := "capi-kubeadm-control-plane-controller-manager-*"
 	capiCpManagerContainerLogFile := capiCpManagerPod + ".log"
@@ -7,6 +7,7 @@ import (
 
 	eksav1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/diagnostics"
+	"github.com/aws/eks-anywhere/pkg/providers"
 )
 
 func TestManagementClusterAnalyzers(t *testing.T) {
@@ -44,6 +45,28 @@ func TestEksaLogTextAnalyzers(t *testing.T) {
 	}
 }
 
+func TestEksaLogTextAnalyzersHostLogs(t *testing.T) {
+	g := NewGomegaWithT(t)
+	collectorFactory := diagnostics.NewDefaultCollectorFactory()
+	collectors := collectorFactory.EksaHostCollectors([]providers.MachineConfig{
+		&eksav1alpha1.VSphereMachineConfig{Spec: eksav1alpha1.VSphereMachineConfigSpec{OSFamily: eksav1alpha1.RedHat}},
+	})
+	collectors = append(collectors, collectors...)
+	analyzerFactory := diagnostics.NewAnalyzerFactory()
+	analyzers := analyzerFactory.EksaLogTextAnalyzers(collectors)
+	var files []string
+	for _, analyzer := range analyzers {
+		files = append(files, analyzer.TextAnalyze.FileName)
+	}
+	g.Expect(files).To(Equal([]string{
+		"hostLogs/cloud-init-output/*/cloud-init-output.log",
+		"hostLogs/messages/*/messages",
+		"hostLogs/messages/*/messages",
+		"hostLogs/journal/*/*/*.journal",
+		"hostLogs/journal/*/*/*.journal",
+	}))
+}
+
 func TestVsphereDataCenterConfigAnalyzers(t *testing.T) {
 	g := NewGomegaWithT(t)
 	datacenter := eksav1alpha1.Ref{Kind: eksav1alpha1.VSphereDatacenterKind}
@@ -0,0 +1,116 @@
+package diagnostics
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/templater"
+)
+
+const (
+	clusterConfigPath     = "eksa"
+	clusterConfigFileName = "cluster-config.yaml"
+)
+
+// marshalClusterConfig returns the multi-document yaml of the cluster config objects,
+// in the same format as the cluster config file.
+func marshalClusterConfig(config *cluster.Config) ([]byte, error) {
+	objs := append([]kubernetes.Object{config.Cluster}, config.ChildObjects()...)
+	resources := make([][]byte, 0, len(objs))
+	for _, obj := range objs {
+		resource, err := yaml.Marshal(obj)
+		if err != nil {
+			return nil, fmt.Errorf("marshalling cluster config object: %v", err)
+		}
+		resources = append(resources, resource)
+	}
+	return templater.AppendYamlResources(resources...), nil
+}
+
+// readArchiveClusterConfig reads the cluster config stored by the ClusterConfigCollectors in a support bundle archive.
+// It returns a nil config when the archive doesn't contain one, for example when it was generated from a custom bundle config.
+func readArchiveClusterConfig(archivePath string) (*cluster.Config, error) {
+	content, err := readArchiveFile(archivePath, path.Join(clusterConfigPath, clusterConfigFileName))
+	if err != nil {
+		return nil, err
+	}
+	if content == nil {
+		return nil, nil
+	}
+
+	config, err := cluster.ParseConfig(content)
+	if err != nil {
+		return nil, fmt.Errorf("parsing cluster config from support bundle archive %s: %v", archivePath, err)
+	}
+	return config, nil
+}
+
+// readArchiveFile returns the content of the file in the gzipped tar archive whose path ends in filePath.
+// Troubleshoot nests the collected files under a folder named after the bundle, so only the suffix is matched.
+func readArchiveFile(archivePath, filePath string) ([]byte, error) {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("opening support bundle archive: %v", err)
+	}
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading support bundle archive %s: %v", archivePath, err)
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading support bundle archive %s: %v", archivePath, err)
+		}
+
+		name := path.Clean(header.Name)
+		if header.Typeflag != tar.TypeReg || (name != filePath && !strings.HasSuffix(name, "/"+filePath)) {
+			continue
+		}
+
+		content, err := io.ReadAll(tarReader)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s from support bundle archive %s: %v", filePath, archivePath, err)
+		}
+		return content, nil
+	}
+}
+
+// machineConfigs returns the machine configs of every provider in the cluster config.
+func machineConfigs(config *cluster.Config) []providers.MachineConfig {
+	var configs []providers.MachineConfig
+	for _, m := range config.VSphereMachineConfigs {
+		configs = append(configs, m)
+	}
+	for _, m := range config.CloudStackMachineConfigs {
+		configs = append(configs, m)
+	}
+	for _, m := range config.SnowMachineConfigs {
+		configs = append(configs, m)
+	}
+	for _, m := range config.NutanixMachineConfigs {
+		configs = append(configs, m)
+	}
+	for _, m := range config.TinkerbellMachineConfigs {
+		configs = append(configs, m)
+	}
+	return configs
+}
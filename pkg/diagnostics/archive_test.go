@@ -0,0 +1,135 @@
+package diagnostics_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/diagnostics"
+	"github.com/aws/eks-anywhere/pkg/executables"
+)
+
+func TestDiagnosticBundleFromArchive(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.Background()
+	config, err := cluster.ParseConfigFromFile("testdata/cluster-config.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+	clusterConfig, err := diagnostics.MarshalClusterConfig(config)
+	g.Expect(err).NotTo(HaveOccurred())
+	archivePath := writeArchive(t, map[string]string{
+		"support-bundle-2022-10-17T10_00_00/eksa/cluster-config.yaml": string(clusterConfig),
+		"support-bundle-2022-10-17T10_00_00/version.yaml":             "version: v1",
+	})
+
+	a := givenMockAnalyzerFactory(t)
+	a.EXPECT().EksaGitopsAnalyzers().Return(nil)
+	a.EXPECT().DataCenterConfigAnalyzers(config.Cluster.Spec.DatacenterRef).Return(nil)
+	a.EXPECT().DefaultAnalyzers().Return(nil)
+	a.EXPECT().ManagementClusterAnalyzers().Return(nil)
+	a.EXPECT().PackageAnalyzers().Return(nil)
+	a.EXPECT().EksaLogTextAnalyzers(gomock.Any()).Return(nil)
+
+	c := givenMockCollectorsFactory(t)
+	c.EXPECT().DataCenterConfigCollectors(config.Cluster.Spec.DatacenterRef, gomock.Any()).Return(nil)
+	c.EXPECT().EksaHostCollectors(gomock.Len(2)).Return(nil)
+	c.EXPECT().DefaultCollectors().Return(nil)
+	c.EXPECT().ManagementClusterCollectors().Return(nil)
+	c.EXPECT().PackagesCollectors().Return(nil)
+
+	w := givenWriter(t)
+	w.EXPECT().Write(gomock.Any(), gomock.Any()).Return("eksa-unit-test-bundle.yaml", nil)
+	w.EXPECT().Write(gomock.Any(), gomock.Any()).Return("eksa-unit-test-analysis.yaml", nil)
+
+	tc := givenTroubleshootClient(t)
+	tc.EXPECT().Analyze(ctx, "eksa-unit-test-bundle.yaml", archivePath).Return([]*executables.SupportBundleAnalysis{{Title: "test", IsPass: true}}, nil)
+
+	f := diagnostics.NewFactory(diagnostics.EksaDiagnosticBundleFactoryOpts{
+		AnalyzerFactory:  a,
+		CollectorFactory: c,
+		Client:           tc,
+		Writer:           w,
+	})
+	b, err := f.DiagnosticBundleFromArchive(archivePath)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(b.Analyze(ctx, archivePath)).To(Succeed())
+}
+
+func TestDiagnosticBundleFromArchiveWithoutClusterConfig(t *testing.T) {
+	g := NewGomegaWithT(t)
+	archivePath := writeArchive(t, map[string]string{
+		"support-bundle-2022-10-17T10_00_00/version.yaml": "version: v1",
+	})
+
+	a := givenMockAnalyzerFactory(t)
+	a.EXPECT().DefaultAnalyzers().Return(nil)
+	a.EXPECT().ManagementClusterAnalyzers().Return(nil)
+	a.EXPECT().EksaLogTextAnalyzers(gomock.Any()).Return(nil)
+
+	c := givenMockCollectorsFactory(t)
+	c.EXPECT().DefaultCollectors().Return(nil)
+	c.EXPECT().ManagementClusterCollectors().Return(nil)
+
+	w := givenWriter(t)
+	w.EXPECT().Write(gomock.Any(), gomock.Any())
+
+	f := diagnostics.NewFactory(diagnostics.EksaDiagnosticBundleFactoryOpts{
+		AnalyzerFactory:  a,
+		CollectorFactory: c,
+		Writer:           w,
+	})
+	_, err := f.DiagnosticBundleFromArchive(archivePath)
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestDiagnosticBundleFromArchiveInvalidClusterConfig(t *testing.T) {
+	g := NewGomegaWithT(t)
+	archivePath := writeArchive(t, map[string]string{
+		"support-bundle-2022-10-17T10_00_00/eksa/cluster-config.yaml": "kind: VSphereDatacenterConfig",
+	})
+
+	f := diagnostics.NewFactory(getOpts(t))
+	_, err := f.DiagnosticBundleFromArchive(archivePath)
+	g.Expect(err).To(MatchError(ContainSubstring("parsing cluster config from support bundle archive")))
+}
+
+func TestDiagnosticBundleFromArchiveNotFound(t *testing.T) {
+	g := NewGomegaWithT(t)
+	f := diagnostics.NewFactory(getOpts(t))
+	_, err := f.DiagnosticBundleFromArchive(filepath.Join(t.TempDir(), "missing.tar.gz"))
+	g.Expect(err).To(MatchError(ContainSubstring("opening support bundle archive")))
+}
+
+func writeArchive(t *testing.T, files map[string]string) string {
+	archivePath := filepath.Join(t.TempDir(), "support-bundle-2022-10-17T10_00_00.tar.gz")
+	file, err := os.Create(archivePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for name, content := range files {
+		header := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}
+		if err = tarWriter.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = tarWriter.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = tarWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err = gzipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return archivePath
+}
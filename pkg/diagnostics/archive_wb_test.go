@@ -0,0 +1,3 @@
+package diagnostics
+
+var MarshalClusterConfig = marshalClusterConfig
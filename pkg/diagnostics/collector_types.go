@@ -13,6 +13,7 @@ type Collect struct {
 	CopyFromHost     *copyFromHost     `json:"copyFromHost,omitempty"`
 	Exec             *exec             `json:"exec,omitempty"`
 	RunPod           *runPod           `json:"runPod,omitempty"`
+	Data             *data             `json:"data,omitempty"`
 }
 
 type clusterResources struct {
@@ -69,6 +70,13 @@ type exec struct {
 	Timeout       string   `json:"timeout,omitempty"`
 }
 
+// data stores static content in the bundle archive, under <name>/<collectorName>.
+type data struct {
+	collectorMeta `json:",inline"`
+	Name          string `json:"name,omitempty"`
+	Data          string `json:"data"`
+}
+
 type imagePullSecrets struct {
 	Name       string            `json:"name,omitempty"`
 	Data       map[string]string `json:"data,omitempty"`
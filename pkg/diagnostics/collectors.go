@@ -51,18 +51,46 @@ func (c *collectorFactory) EksaHostCollectors(machineConfigs []providers.Machine
 	var collectors []*Collect
 	collectorsMap := c.getCollectorsMap()
 
-	// we don't want to duplicate the collectors if multiple machine configs have the same OS family
+	// we don't want to duplicate the collectors if multiple machine configs have the same OS family,
+	// or if different OS families collect the same host log
 	osFamiliesSeen := map[v1alpha1.OSFamily]bool{}
+	hostLogsSeen := map[string]bool{}
 	for _, config := range machineConfigs {
-		if _, seen := osFamiliesSeen[config.OSFamily()]; !seen {
-			collectors = append(collectors, collectorsMap[config.OSFamily()]...)
-			osFamiliesSeen[config.OSFamily()] = true
+		if _, seen := osFamiliesSeen[config.OSFamily()]; seen {
+			continue
 		}
+		for _, collector := range collectorsMap[config.OSFamily()] {
+			if !hostLogsSeen[collector.CopyFromHost.Name] {
+				collectors = append(collectors, collector)
+				hostLogsSeen[collector.CopyFromHost.Name] = true
+			}
+		}
+		osFamiliesSeen[config.OSFamily()] = true
 	}
 	return collectors
 }
 
+// ClusterConfigCollectors stores the EKS-A cluster config in the bundle archive,
+// so the analyzers can be rebuilt from the archive alone.
+func (c *collectorFactory) ClusterConfigCollectors(clusterConfig []byte) []*Collect {
+	return []*Collect{
+		{
+			Data: &data{
+				collectorMeta: collectorMeta{
+					CollectorName: clusterConfigFileName,
+				},
+				Name: clusterConfigPath,
+				Data: string(clusterConfig),
+			},
+		},
+	}
+}
+
 func (c *collectorFactory) DataCenterConfigCollectors(datacenter v1alpha1.Ref, spec *cluster.Spec) []*Collect {
+	return append(c.providerCollectors(datacenter, spec), c.auditLogCollectors(datacenter, spec)...)
+}
+
+func (c *collectorFactory) providerCollectors(datacenter v1alpha1.Ref, spec *cluster.Spec) []*Collect {
 	switch datacenter.Kind {
 	case v1alpha1.VSphereDatacenterKind:
 		return c.eksaVsphereCollectors(spec)
@@ -81,6 +109,21 @@ func (c *collectorFactory) DataCenterConfigCollectors(datacenter v1alpha1.Ref, s
 	}
 }
 
+// auditLogCollectors collects the kube-apiserver audit log from the control plane nodes.
+// Providers without a default audit policy only write the audit log when the cluster spec sets one.
+func (c *collectorFactory) auditLogCollectors(datacenter v1alpha1.Ref, spec *cluster.Spec) []*Collect {
+	switch datacenter.Kind {
+	case v1alpha1.VSphereDatacenterKind, v1alpha1.DockerDatacenterKind, v1alpha1.CloudStackDatacenterKind:
+	default:
+		if spec == nil || spec.Cluster.Spec.AuditPolicy == nil {
+			return nil
+		}
+	}
+	return []*Collect{
+		c.hostLogCollector("kube-apiserver-audit", "/var/log/kubernetes/api-audit.log", ""),
+	}
+}
+
 func (c *collectorFactory) eksaNutanixCollectors() []*Collect {
 	nutanixLogs := []*Collect{
 		{
@@ -175,39 +218,47 @@ func (c *collectorFactory) getCollectorsMap() map[v1alpha1.OSFamily][]*Collect {
 	return map[v1alpha1.OSFamily][]*Collect{
 		v1alpha1.Ubuntu:       c.ubuntuHostCollectors(),
 		v1alpha1.Bottlerocket: c.bottleRocketHostCollectors(),
+		v1alpha1.RedHat:       c.redHatHostCollectors(),
 	}
 }
 
+// bottleRocketHostCollectors collects the journal, which holds the admin container, containerd and kubelet logs,
+// along with the settings persisted by the Bottlerocket API (what apiclient returns) and the kubelet and
+// containerd configs rendered from them.
 func (c *collectorFactory) bottleRocketHostCollectors() []*Collect {
-	return []*Collect{}
+	return []*Collect{
+		c.hostLogCollector("journal", "/var/log/journal", time.Minute.String()),
+		c.hostLogCollector("bottlerocket-settings", "/var/lib/bottlerocket/datastore/current/live", ""),
+		c.hostLogCollector("kubelet-config", "/etc/kubernetes/kubelet/config", ""),
+		c.hostLogCollector("containerd-config", "/etc/containerd/config.toml", ""),
+	}
 }
 
 func (c *collectorFactory) ubuntuHostCollectors() []*Collect {
 	return []*Collect{
-		{
-			CopyFromHost: &copyFromHost{
-				Name:      hostlogPath("cloud-init"),
-				Namespace: constants.EksaDiagnosticsNamespace,
-				Image:     c.DiagnosticCollectorImage,
-				HostPath:  "/var/log/cloud-init.log",
-			},
-		},
-		{
-			CopyFromHost: &copyFromHost{
-				Name:      hostlogPath("cloud-init-output"),
-				Namespace: constants.EksaDiagnosticsNamespace,
-				Image:     c.DiagnosticCollectorImage,
-				HostPath:  "/var/log/cloud-init-output.log",
-			},
-		},
-		{
-			CopyFromHost: &copyFromHost{
-				Name:      hostlogPath("syslog"),
-				Namespace: constants.EksaDiagnosticsNamespace,
-				Image:     c.DiagnosticCollectorImage,
-				HostPath:  "/var/log/syslog",
-				Timeout:   time.Minute.String(),
-			},
+		c.hostLogCollector("cloud-init", "/var/log/cloud-init.log", ""),
+		c.hostLogCollector("cloud-init-output", "/var/log/cloud-init-output.log", ""),
+		c.hostLogCollector("syslog", "/var/log/syslog", time.Minute.String()),
+	}
+}
+
+func (c *collectorFactory) redHatHostCollectors() []*Collect {
+	return []*Collect{
+		c.hostLogCollector("cloud-init", "/var/log/cloud-init.log", ""),
+		c.hostLogCollector("cloud-init-output", "/var/log/cloud-init-output.log", ""),
+		c.hostLogCollector("messages", "/var/log/messages", time.Minute.String()),
+		c.hostLogCollector("journal", "/var/log/journal", time.Minute.String()),
+	}
+}
+
+func (c *collectorFactory) hostLogCollector(logType, hostPath, timeout string) *Collect {
+	return &Collect{
+		CopyFromHost: &copyFromHost{
+			Name:      hostlogPath(logType),
+			Namespace: constants.EksaDiagnosticsNamespace,
+			Image:     c.DiagnosticCollectorImage,
+			HostPath:  hostPath,
+			Timeout:   timeout,
 		},
 	}
 }
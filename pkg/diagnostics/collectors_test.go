@@ -13,6 +13,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/diagnostics"
+	"github.com/aws/eks-anywhere/pkg/providers"
 )
 
 func TestVsphereDataCenterConfigCollectors(t *testing.T) {
@@ -52,7 +53,7 @@ func TestVsphereDataCenterConfigCollectors(t *testing.T) {
 	datacenter := eksav1alpha1.Ref{Kind: eksav1alpha1.VSphereDatacenterKind}
 	factory := diagnostics.NewDefaultCollectorFactory()
 	collectors := factory.DataCenterConfigCollectors(datacenter, spec)
-	g.Expect(collectors).To(HaveLen(11), "DataCenterConfigCollectors() mismatch between number of desired collectors and actual")
+	g.Expect(collectors).To(HaveLen(12), "DataCenterConfigCollectors() mismatch between number of desired collectors and actual")
 	g.Expect(collectors[0].Logs.Namespace).To(Equal(constants.CapvSystemNamespace))
 	g.Expect(collectors[0].Logs.Name).To(Equal(fmt.Sprintf("logs/%s", constants.CapvSystemNamespace)))
 	for _, collector := range collectors[1:7] {
@@ -62,6 +63,7 @@ func TestVsphereDataCenterConfigCollectors(t *testing.T) {
 	g.Expect(collectors[8].RunPod.PodSpec.Containers[0].Name).To(Equal("check-host-port"))
 	g.Expect(collectors[9].RunPod.PodSpec.Containers[0].Name).To(Equal("ping-host-ip"))
 	g.Expect(collectors[10].RunPod.PodSpec.Containers[0].Name).To(Equal("check-cloud-controller"))
+	g.Expect(collectors[11].CopyFromHost.HostPath).To(Equal("/var/log/kubernetes/api-audit.log"))
 }
 
 func TestCloudStackDataCenterConfigCollectors(t *testing.T) {
@@ -70,13 +72,14 @@ func TestCloudStackDataCenterConfigCollectors(t *testing.T) {
 	datacenter := eksav1alpha1.Ref{Kind: eksav1alpha1.CloudStackDatacenterKind}
 	factory := diagnostics.NewDefaultCollectorFactory()
 	collectors := factory.DataCenterConfigCollectors(datacenter, spec)
-	g.Expect(collectors).To(HaveLen(10), "DataCenterConfigCollectors() mismatch between number of desired collectors and actual")
+	g.Expect(collectors).To(HaveLen(11), "DataCenterConfigCollectors() mismatch between number of desired collectors and actual")
 	g.Expect(collectors[0].Logs.Namespace).To(Equal(constants.CapcSystemNamespace))
 	g.Expect(collectors[0].Logs.Name).To(Equal(fmt.Sprintf("logs/%s", constants.CapcSystemNamespace)))
-	for _, collector := range collectors[1:] {
+	for _, collector := range collectors[1:10] {
 		g.Expect([]string{"kubectl"}).To(Equal(collector.RunPod.PodSpec.Containers[0].Command))
 		g.Expect("eksa-diagnostics").To(Equal(collector.RunPod.Namespace))
 	}
+	g.Expect(collectors[10].CopyFromHost.HostPath).To(Equal("/var/log/kubernetes/api-audit.log"))
 }
 
 func TestTinkerbellDataCenterConfigCollectors(t *testing.T) {
@@ -94,6 +97,19 @@ func TestTinkerbellDataCenterConfigCollectors(t *testing.T) {
 	}
 }
 
+func TestTinkerbellDataCenterConfigCollectorsWithAuditPolicy(t *testing.T) {
+	g := NewGomegaWithT(t)
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.AuditPolicy = &eksav1alpha1.AuditPolicy{}
+	})
+	datacenter := eksav1alpha1.Ref{Kind: eksav1alpha1.TinkerbellDatacenterKind}
+	factory := diagnostics.NewDefaultCollectorFactory()
+	collectors := factory.DataCenterConfigCollectors(datacenter, spec)
+	g.Expect(collectors).To(HaveLen(14), "DataCenterConfigCollectors() mismatch between number of desired collectors and actual")
+	g.Expect(collectors[13].CopyFromHost.Name).To(Equal("hostLogs/kube-apiserver-audit"))
+	g.Expect(collectors[13].CopyFromHost.HostPath).To(Equal("/var/log/kubernetes/api-audit.log"))
+}
+
 func TestSnowCollectors(t *testing.T) {
 	g := NewGomegaWithT(t)
 	spec := test.NewClusterSpec(func(s *cluster.Spec) {})
@@ -123,3 +139,65 @@ func TestNutanixCollectors(t *testing.T) {
 		g.Expect("eksa-diagnostics").To(Equal(collector.RunPod.Namespace))
 	}
 }
+
+func TestBottlerocketHostCollectors(t *testing.T) {
+	g := NewGomegaWithT(t)
+	factory := diagnostics.NewCollectorFactory("image")
+	collectors := factory.EksaHostCollectors([]providers.MachineConfig{vsphereMachineConfig(eksav1alpha1.Bottlerocket)})
+	g.Expect(collectors).To(HaveLen(4), "EksaHostCollectors() mismatch between number of desired collectors and actual")
+	g.Expect(collectors[0].CopyFromHost.Name).To(Equal("hostLogs/journal"))
+	g.Expect(collectors[0].CopyFromHost.HostPath).To(Equal("/var/log/journal"))
+	g.Expect(collectors[1].CopyFromHost.HostPath).To(Equal("/var/lib/bottlerocket/datastore/current/live"))
+	g.Expect(collectors[2].CopyFromHost.HostPath).To(Equal("/etc/kubernetes/kubelet/config"))
+	g.Expect(collectors[3].CopyFromHost.HostPath).To(Equal("/etc/containerd/config.toml"))
+	for _, collector := range collectors {
+		g.Expect(collector.CopyFromHost.Namespace).To(Equal(constants.EksaDiagnosticsNamespace))
+		g.Expect(collector.CopyFromHost.Image).To(Equal("image"))
+	}
+}
+
+func TestRedHatHostCollectors(t *testing.T) {
+	g := NewGomegaWithT(t)
+	factory := diagnostics.NewDefaultCollectorFactory()
+	collectors := factory.EksaHostCollectors([]providers.MachineConfig{vsphereMachineConfig(eksav1alpha1.RedHat)})
+	g.Expect(collectors).To(HaveLen(4), "EksaHostCollectors() mismatch between number of desired collectors and actual")
+	g.Expect(collectors[0].CopyFromHost.HostPath).To(Equal("/var/log/cloud-init.log"))
+	g.Expect(collectors[1].CopyFromHost.HostPath).To(Equal("/var/log/cloud-init-output.log"))
+	g.Expect(collectors[2].CopyFromHost.HostPath).To(Equal("/var/log/messages"))
+	g.Expect(collectors[3].CopyFromHost.HostPath).To(Equal("/var/log/journal"))
+}
+
+func TestHostCollectorsNoDuplicates(t *testing.T) {
+	g := NewGomegaWithT(t)
+	factory := diagnostics.NewDefaultCollectorFactory()
+	collectors := factory.EksaHostCollectors([]providers.MachineConfig{
+		vsphereMachineConfig(eksav1alpha1.Ubuntu),
+		vsphereMachineConfig(eksav1alpha1.RedHat),
+		vsphereMachineConfig(eksav1alpha1.Ubuntu),
+	})
+	var names []string
+	for _, collector := range collectors {
+		names = append(names, collector.CopyFromHost.Name)
+	}
+	g.Expect(names).To(Equal([]string{
+		"hostLogs/cloud-init", "hostLogs/cloud-init-output", "hostLogs/syslog", "hostLogs/messages", "hostLogs/journal",
+	}))
+}
+
+func vsphereMachineConfig(osFamily eksav1alpha1.OSFamily) *eksav1alpha1.VSphereMachineConfig {
+	return &eksav1alpha1.VSphereMachineConfig{
+		Spec: eksav1alpha1.VSphereMachineConfigSpec{
+			OSFamily: osFamily,
+		},
+	}
+}
+
+func TestClusterConfigCollectors(t *testing.T) {
+	g := NewGomegaWithT(t)
+	factory := diagnostics.NewDefaultCollectorFactory()
+	collectors := factory.ClusterConfigCollectors([]byte("kind: Cluster"))
+	g.Expect(collectors).To(HaveLen(1))
+	g.Expect(collectors[0].Data.Name).To(Equal("eksa"))
+	g.Expect(collectors[0].Data.CollectorName).To(Equal("cluster-config.yaml"))
+	g.Expect(collectors[0].Data.Data).To(Equal("kind: Cluster"))
+}
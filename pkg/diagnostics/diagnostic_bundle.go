@@ -107,7 +107,13 @@ func newDiagnosticBundleFromSpec(af AnalyzerFactory, cf CollectorFactory, spec *
 		writer:           writer,
 	}
 
+	clusterConfig, err := marshalClusterConfig(spec.Config)
+	if err != nil {
+		return nil, err
+	}
+
 	b = b.
+		WithClusterConfig(clusterConfig).
 		WithGitOpsConfig(spec.GitOpsConfig).
 		WithOidcConfig(spec.OIDCConfig).
 		WithExternalEtcd(spec.Cluster.Spec.ExternalEtcdConfiguration).
@@ -119,7 +125,7 @@ func newDiagnosticBundleFromSpec(af AnalyzerFactory, cf CollectorFactory, spec *
 		WithPackagesCollectors().
 		WithLogTextAnalyzers()
 
-	err := b.WriteBundleConfig()
+	err = b.WriteBundleConfig()
 	if err != nil {
 		return nil, fmt.Errorf("writing bundle config: %v", err)
 	}
@@ -127,6 +133,63 @@ func newDiagnosticBundleFromSpec(af AnalyzerFactory, cf CollectorFactory, spec *
 	return b, nil
 }
 
+// newDiagnosticBundleFromArchive rebuilds the analyzers of a support bundle from the cluster config stored in its archive,
+// the same way newDiagnosticBundleFromSpec builds them, so an existing archive can be analyzed without access to the cluster.
+// If the archive doesn't contain a cluster config, only the default analyzers are used.
+func newDiagnosticBundleFromArchive(af AnalyzerFactory, cf CollectorFactory, client BundleClient, archivePath string, writer filewriter.FileWriter) (*EksaDiagnosticBundle, error) {
+	config, err := readArchiveClusterConfig(archivePath)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &EksaDiagnosticBundle{
+		bundle: &supportBundle{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "SupportBundle",
+				APIVersion: troubleshootApiVersion,
+			},
+			Spec: supportBundleSpec{},
+		},
+		analyzerFactory:  af,
+		collectorFactory: cf,
+		client:           client,
+		writer:           writer,
+	}
+
+	if config == nil {
+		logger.Info("Support bundle archive doesn't contain an EKS-A cluster config, using the default analyzers", "archive", archivePath)
+		b.bundle.Name = "default"
+		b = b.WithDefaultAnalyzers().WithDefaultCollectors().WithManagementCluster(true)
+	} else {
+		spec := &cluster.Spec{Config: config}
+		// Config supports multiple oidc configs but only one can be referenced by the cluster
+		for _, oc := range config.OIDCConfigs {
+			spec.OIDCConfig = oc
+			break
+		}
+
+		b.bundle.Name = config.Cluster.Name
+		b.clusterSpec = spec
+		b = b.
+			WithGitOpsConfig(spec.GitOpsConfig).
+			WithOidcConfig(spec.OIDCConfig).
+			WithExternalEtcd(spec.Cluster.Spec.ExternalEtcdConfiguration).
+			WithDatacenterConfig(spec.Cluster.Spec.DatacenterRef, spec).
+			WithMachineConfigs(machineConfigs(config)).
+			WithManagementCluster(spec.Cluster.IsSelfManaged()).
+			WithDefaultAnalyzers().
+			WithDefaultCollectors().
+			WithPackagesCollectors()
+	}
+	b = b.WithLogTextAnalyzers()
+
+	if err = b.WriteBundleConfig(); err != nil {
+		return nil, fmt.Errorf("writing bundle config: %v", err)
+	}
+
+	return b, nil
+}
+
 func newDiagnosticBundleDefault(af AnalyzerFactory, cf CollectorFactory) *EksaDiagnosticBundle {
 	b := &EksaDiagnosticBundle{
 		bundle: &supportBundle{
@@ -169,6 +232,17 @@ func (e *EksaDiagnosticBundle) CollectAndAnalyze(ctx context.Context, sinceTimeV
 
 	logger.Info("Support bundle archive created", "path", archivePath)
 
+	if err = e.Analyze(ctx, archivePath); err != nil {
+		return err
+	}
+
+	e.deleteDiagnosticNamespaceAndRoles(ctx)
+	return nil
+}
+
+// Analyze runs the bundle analyzers against an existing support bundle archive and writes the analysis to a file.
+// It doesn't need access to the cluster.
+func (e *EksaDiagnosticBundle) Analyze(ctx context.Context, archivePath string) error {
 	logger.Info("Analyzing support bundle", "bundle", e.bundlePath, "archive", archivePath)
 	analysis, err := e.client.Analyze(ctx, e.bundlePath, archivePath)
 	if err != nil {
@@ -181,8 +255,6 @@ func (e *EksaDiagnosticBundle) CollectAndAnalyze(ctx context.Context, sinceTimeV
 		return err
 	}
 	logger.Info("Analysis output generated", "path", analysisPath)
-
-	e.deleteDiagnosticNamespaceAndRoles(ctx)
 	return nil
 }
 
@@ -298,6 +370,12 @@ func (e *EksaDiagnosticBundle) WithMachineConfigs(configs []providers.MachineCon
 	return e
 }
 
+// WithClusterConfig stores the cluster config in the bundle archive, which allows to analyze it later with the same analyzers.
+func (e *EksaDiagnosticBundle) WithClusterConfig(clusterConfig []byte) *EksaDiagnosticBundle {
+	e.bundle.Spec.Collectors = append(e.bundle.Spec.Collectors, e.collectorFactory.ClusterConfigCollectors(clusterConfig)...)
+	return e
+}
+
 func (e *EksaDiagnosticBundle) WithLogTextAnalyzers() *EksaDiagnosticBundle {
 	e.bundle.Spec.Analyzers = append(e.bundle.Spec.Analyzers, e.analyzerFactory.EksaLogTextAnalyzers(e.bundle.Spec.Collectors)...)
 	return e
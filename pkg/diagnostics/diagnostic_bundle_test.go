@@ -124,6 +124,7 @@ func TestGenerateBundleConfigWithExternalEtcd(t *testing.T) {
 		a.EXPECT().PackageAnalyzers().Return(nil)
 
 		c := givenMockCollectorsFactory(t)
+		c.EXPECT().ClusterConfigCollectors(gomock.Any()).Return(nil)
 		c.EXPECT().DefaultCollectors().Return(nil)
 		c.EXPECT().EksaHostCollectors(gomock.Any()).Return(nil)
 		c.EXPECT().ManagementClusterCollectors().Return(nil)
@@ -186,6 +187,7 @@ func TestGenerateBundleConfigWithOidc(t *testing.T) {
 		w.EXPECT().Write(gomock.Any(), gomock.Any())
 
 		c := givenMockCollectorsFactory(t)
+		c.EXPECT().ClusterConfigCollectors(gomock.Any()).Return(nil)
 		c.EXPECT().DefaultCollectors().Return(nil)
 		c.EXPECT().EksaHostCollectors(gomock.Any()).Return(nil)
 		c.EXPECT().ManagementClusterCollectors().Return(nil)
@@ -245,6 +247,7 @@ func TestGenerateBundleConfigWithGitOps(t *testing.T) {
 		w.EXPECT().Write(gomock.Any(), gomock.Any())
 
 		c := givenMockCollectorsFactory(t)
+		c.EXPECT().ClusterConfigCollectors(gomock.Any()).Return(nil)
 		c.EXPECT().DefaultCollectors().Return(nil)
 		c.EXPECT().EksaHostCollectors(gomock.Any()).Return(nil)
 		c.EXPECT().ManagementClusterCollectors().Return(nil)
@@ -328,6 +331,7 @@ func TestBundleFromSpecComplete(t *testing.T) {
 		a.EXPECT().PackageAnalyzers().Return(nil)
 
 		c := givenMockCollectorsFactory(t)
+		c.EXPECT().ClusterConfigCollectors(gomock.Any()).Return(nil)
 		c.EXPECT().DefaultCollectors().Return(nil)
 		c.EXPECT().EksaHostCollectors(gomock.Any()).Return(nil)
 		c.EXPECT().ManagementClusterCollectors().Return(nil)
@@ -58,3 +58,8 @@ func (f *eksaDiagnosticBundleFactory) DiagnosticBundleDefault() DiagnosticBundle
 func (f *eksaDiagnosticBundleFactory) DiagnosticBundleCustom(kubeconfig string, bundlePath string) DiagnosticBundle {
 	return newDiagnosticBundleCustom(f.analyzerFactory, f.collectorFactory, f.client, f.kubectl, bundlePath, kubeconfig, f.writer)
 }
+
+// DiagnosticBundleFromArchive returns a bundle with the analyzers rebuilt from the cluster config stored in the archive.
+func (f *eksaDiagnosticBundleFactory) DiagnosticBundleFromArchive(archivePath string) (DiagnosticBundle, error) {
+	return newDiagnosticBundleFromArchive(f.analyzerFactory, f.collectorFactory, f.client, archivePath, f.writer)
+}
@@ -21,6 +21,7 @@ type DiagnosticBundleFactory interface {
 	DiagnosticBundleManagementCluster(spec *cluster.Spec, kubeconfig string) (DiagnosticBundle, error)
 	DiagnosticBundleDefault() DiagnosticBundle
 	DiagnosticBundleCustom(kubeconfig string, bundlePath string) DiagnosticBundle
+	DiagnosticBundleFromArchive(archivePath string) (DiagnosticBundle, error)
 }
 
 type DiagnosticBundle interface {
@@ -29,6 +30,7 @@ type DiagnosticBundle interface {
 	PrintAnalysis() error
 	WriteAnalysisToFile() (path string, err error)
 	CollectAndAnalyze(ctx context.Context, sinceTimeValue *time.Time) error
+	Analyze(ctx context.Context, archivePath string) error
 	WithDefaultAnalyzers() *EksaDiagnosticBundle
 	WithDefaultCollectors() *EksaDiagnosticBundle
 	WithDatacenterConfig(config v1alpha1.Ref, spec *cluster.Spec) *EksaDiagnosticBundle
@@ -36,6 +38,7 @@ type DiagnosticBundle interface {
 	WithExternalEtcd(config *v1alpha1.ExternalEtcdConfiguration) *EksaDiagnosticBundle
 	WithGitOpsConfig(config *v1alpha1.GitOpsConfig) *EksaDiagnosticBundle
 	WithMachineConfigs(configs []providers.MachineConfig) *EksaDiagnosticBundle
+	WithClusterConfig(clusterConfig []byte) *EksaDiagnosticBundle
 	WithLogTextAnalyzers() *EksaDiagnosticBundle
 }
 
@@ -56,4 +59,5 @@ type CollectorFactory interface {
 	ManagementClusterCollectors() []*Collect
 	EksaHostCollectors(configs []providers.MachineConfig) []*Collect
 	DataCenterConfigCollectors(datacenter v1alpha1.Ref, spec *cluster.Spec) []*Collect
+	ClusterConfigCollectors(clusterConfig []byte) []*Collect
 }
@@ -136,6 +136,21 @@ func (mr *MockDiagnosticBundleFactoryMockRecorder) DiagnosticBundleDefault() *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiagnosticBundleDefault", reflect.TypeOf((*MockDiagnosticBundleFactory)(nil).DiagnosticBundleDefault))
 }
 
+// DiagnosticBundleFromArchive mocks base method.
+func (m *MockDiagnosticBundleFactory) DiagnosticBundleFromArchive(archivePath string) (diagnostics.DiagnosticBundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiagnosticBundleFromArchive", archivePath)
+	ret0, _ := ret[0].(diagnostics.DiagnosticBundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiagnosticBundleFromArchive indicates an expected call of DiagnosticBundleFromArchive.
+func (mr *MockDiagnosticBundleFactoryMockRecorder) DiagnosticBundleFromArchive(archivePath interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiagnosticBundleFromArchive", reflect.TypeOf((*MockDiagnosticBundleFactory)(nil).DiagnosticBundleFromArchive), archivePath)
+}
+
 // DiagnosticBundleManagementCluster mocks base method.
 func (m *MockDiagnosticBundleFactory) DiagnosticBundleManagementCluster(spec *cluster.Spec, kubeconfig string) (diagnostics.DiagnosticBundle, error) {
 	m.ctrl.T.Helper()
@@ -189,6 +204,20 @@ func (m *MockDiagnosticBundle) EXPECT() *MockDiagnosticBundleMockRecorder {
 	return m.recorder
 }
 
+// Analyze mocks base method.
+func (m *MockDiagnosticBundle) Analyze(ctx context.Context, archivePath string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Analyze", ctx, archivePath)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Analyze indicates an expected call of Analyze.
+func (mr *MockDiagnosticBundleMockRecorder) Analyze(ctx, archivePath interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Analyze", reflect.TypeOf((*MockDiagnosticBundle)(nil).Analyze), ctx, archivePath)
+}
+
 // CollectAndAnalyze mocks base method.
 func (m *MockDiagnosticBundle) CollectAndAnalyze(ctx context.Context, sinceTimeValue *time.Time) error {
 	m.ctrl.T.Helper()
@@ -231,6 +260,20 @@ func (mr *MockDiagnosticBundleMockRecorder) PrintBundleConfig() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrintBundleConfig", reflect.TypeOf((*MockDiagnosticBundle)(nil).PrintBundleConfig))
 }
 
+// WithClusterConfig mocks base method.
+func (m *MockDiagnosticBundle) WithClusterConfig(clusterConfig []byte) *diagnostics.EksaDiagnosticBundle {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithClusterConfig", clusterConfig)
+	ret0, _ := ret[0].(*diagnostics.EksaDiagnosticBundle)
+	return ret0
+}
+
+// WithClusterConfig indicates an expected call of WithClusterConfig.
+func (mr *MockDiagnosticBundleMockRecorder) WithClusterConfig(clusterConfig interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithClusterConfig", reflect.TypeOf((*MockDiagnosticBundle)(nil).WithClusterConfig), clusterConfig)
+}
+
 // WithDatacenterConfig mocks base method.
 func (m *MockDiagnosticBundle) WithDatacenterConfig(config v1alpha1.Ref, spec *cluster.Spec) *diagnostics.EksaDiagnosticBundle {
 	m.ctrl.T.Helper()
@@ -530,6 +573,20 @@ func (m *MockCollectorFactory) EXPECT() *MockCollectorFactoryMockRecorder {
 	return m.recorder
 }
 
+// ClusterConfigCollectors mocks base method.
+func (m *MockCollectorFactory) ClusterConfigCollectors(clusterConfig []byte) []*diagnostics.Collect {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClusterConfigCollectors", clusterConfig)
+	ret0, _ := ret[0].([]*diagnostics.Collect)
+	return ret0
+}
+
+// ClusterConfigCollectors indicates an expected call of ClusterConfigCollectors.
+func (mr *MockCollectorFactoryMockRecorder) ClusterConfigCollectors(clusterConfig interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClusterConfigCollectors", reflect.TypeOf((*MockCollectorFactory)(nil).ClusterConfigCollectors), clusterConfig)
+}
+
 // DataCenterConfigCollectors mocks base method.
 func (m *MockCollectorFactory) DataCenterConfigCollectors(datacenter v1alpha1.Ref, spec *cluster.Spec) []*diagnostics.Collect {
 	m.ctrl.T.Helper()
@@ -0,0 +1,165 @@
+package encryption
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiserverconfigv1 "k8s.io/apiserver/pkg/apis/config/v1"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// ConfigSecretKey is the key of the kube-apiserver encryption configuration in the config secret.
+const ConfigSecretKey = "encryption-config.yaml"
+
+// keySize is the size in bytes of the keys of both the aescbc and secretbox providers.
+const keySize = 32
+
+// KeyGenerator generates the keys of the aescbc and secretbox providers.
+type KeyGenerator func() (apiserverconfigv1.Key, error)
+
+// GenerateKey returns a new random key named after the time it was generated.
+func GenerateKey() (apiserverconfigv1.Key, error) {
+	secret := make([]byte, keySize)
+	if _, err := rand.Read(secret); err != nil {
+		return apiserverconfigv1.Key{}, fmt.Errorf("generating encryption key: %v", err)
+	}
+
+	return apiserverconfigv1.Key{
+		Name:   "key-" + time.Now().UTC().Format("20060102150405"),
+		Secret: base64.StdEncoding.EncodeToString(secret),
+	}, nil
+}
+
+// ConfigSecretName returns the name of the secret in the management cluster holding the
+// kube-apiserver encryption configuration of a cluster.
+func ConfigSecretName(clusterName string) string {
+	return fmt.Sprintf("%s-encryption-config", clusterName)
+}
+
+// NewConfig returns the kube-apiserver encryption configuration for the providers of a cluster, with a new key
+// for its aescbc and secretbox providers. The identity provider is always added last so the resources written
+// before the encryption was enabled can still be read.
+func NewConfig(config *v1alpha1.EncryptionConfiguration, generateKey KeyGenerator) (*apiserverconfigv1.EncryptionConfiguration, error) {
+	providers := make([]apiserverconfigv1.ProviderConfiguration, 0, len(config.Providers)+1)
+	for _, p := range config.Providers {
+		switch p.Type {
+		case v1alpha1.AESCBCEncryptionProvider:
+			key, err := generateKey()
+			if err != nil {
+				return nil, err
+			}
+			providers = append(providers, apiserverconfigv1.ProviderConfiguration{
+				AESCBC: &apiserverconfigv1.AESConfiguration{Keys: []apiserverconfigv1.Key{key}},
+			})
+		case v1alpha1.SecretboxEncryptionProvider:
+			key, err := generateKey()
+			if err != nil {
+				return nil, err
+			}
+			providers = append(providers, apiserverconfigv1.ProviderConfiguration{
+				Secretbox: &apiserverconfigv1.SecretboxConfiguration{Keys: []apiserverconfigv1.Key{key}},
+			})
+		case v1alpha1.KMSEncryptionProvider:
+			providers = append(providers, apiserverconfigv1.ProviderConfiguration{KMS: kmsConfiguration(p.KMS)})
+		default:
+			return nil, fmt.Errorf("encryption provider %s is not supported", p.Type)
+		}
+	}
+	providers = append(providers, apiserverconfigv1.ProviderConfiguration{Identity: &apiserverconfigv1.IdentityConfiguration{}})
+
+	return &apiserverconfigv1.EncryptionConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: apiserverconfigv1.SchemeGroupVersion.String(),
+			Kind:       "EncryptionConfiguration",
+		},
+		Resources: []apiserverconfigv1.ResourceConfiguration{
+			{
+				Resources: config.ResourcesOrDefault(),
+				Providers: providers,
+			},
+		},
+	}, nil
+}
+
+func kmsConfiguration(kms *v1alpha1.KMSConfiguration) *apiserverconfigv1.KMSConfiguration {
+	apiVersion := kms.APIVersion
+	if apiVersion == "" {
+		apiVersion = "v1"
+	}
+
+	return &apiserverconfigv1.KMSConfiguration{
+		APIVersion: apiVersion,
+		Name:       kms.Name,
+		Endpoint:   kms.Endpoint,
+		CacheSize:  kms.CacheSize,
+		Timeout:    kms.Timeout,
+	}
+}
+
+// NewConfigSecret returns the yaml of the secret holding the kube-apiserver encryption configuration
+// of a new cluster, with new keys for its aescbc and secretbox providers.
+func NewConfigSecret(cluster *v1alpha1.Cluster, generateKey KeyGenerator) ([]byte, error) {
+	if cluster.Spec.EncryptionConfiguration == nil {
+		return nil, errors.New("cluster doesn't have an encryption configuration")
+	}
+
+	config, err := NewConfig(cluster.Spec.EncryptionConfiguration, generateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return ConfigSecret(cluster.Name, config)
+}
+
+// ConfigSecret returns the yaml of the secret holding the kube-apiserver encryption configuration of a cluster.
+// The secret is moved with the cluster CAPI objects.
+func ConfigSecret(clusterName string, config *apiserverconfigv1.EncryptionConfiguration) ([]byte, error) {
+	content, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling encryption configuration: %v", err)
+	}
+
+	secret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ConfigSecretName(clusterName),
+			Namespace: constants.EksaSystemNamespace,
+			Labels: map[string]string{
+				clusterctlv1.ClusterctlMoveLabelName: "true",
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			ConfigSecretKey: content,
+		},
+	}
+
+	return yaml.Marshal(secret)
+}
+
+// ConfigFromSecret reads the kube-apiserver encryption configuration from its secret.
+func ConfigFromSecret(secret *corev1.Secret) (*apiserverconfigv1.EncryptionConfiguration, error) {
+	content, ok := secret.Data[ConfigSecretKey]
+	if !ok {
+		return nil, fmt.Errorf("secret %s doesn't contain key %s", secret.Name, ConfigSecretKey)
+	}
+
+	config := &apiserverconfigv1.EncryptionConfiguration{}
+	if err := yaml.UnmarshalStrict(content, config); err != nil {
+		return nil, fmt.Errorf("reading encryption configuration from secret %s: %v", secret.Name, err)
+	}
+
+	return config, nil
+}
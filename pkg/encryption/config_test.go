@@ -0,0 +1,124 @@
+package encryption_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiserverconfigv1 "k8s.io/apiserver/pkg/apis/config/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/encryption"
+)
+
+func keyGenerator(names ...string) encryption.KeyGenerator {
+	i := 0
+	return func() (apiserverconfigv1.Key, error) {
+		name := names[i]
+		i++
+		return apiserverconfigv1.Key{Name: name, Secret: name + "-secret"}, nil
+	}
+}
+
+func TestGenerateKey(t *testing.T) {
+	g := NewWithT(t)
+	key, err := encryption.GenerateKey()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(key.Name).To(HavePrefix("key-"))
+
+	secret, err := base64.StdEncoding.DecodeString(key.Secret)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(secret).To(HaveLen(32))
+}
+
+func TestNewConfig(t *testing.T) {
+	g := NewWithT(t)
+	config := &v1alpha1.EncryptionConfiguration{
+		Providers: []v1alpha1.EncryptionProvider{
+			{
+				Type: v1alpha1.KMSEncryptionProvider,
+				KMS:  &v1alpha1.KMSConfiguration{Name: "aws-kms", Endpoint: "unix:///var/run/kms/socket.sock"},
+			},
+			{Type: v1alpha1.AESCBCEncryptionProvider},
+			{Type: v1alpha1.SecretboxEncryptionProvider},
+		},
+	}
+
+	got, err := encryption.NewConfig(config, keyGenerator("aescbc", "secretbox"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got).To(Equal(&apiserverconfigv1.EncryptionConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apiserver.config.k8s.io/v1",
+			Kind:       "EncryptionConfiguration",
+		},
+		Resources: []apiserverconfigv1.ResourceConfiguration{
+			{
+				Resources: []string{"secrets"},
+				Providers: []apiserverconfigv1.ProviderConfiguration{
+					{KMS: &apiserverconfigv1.KMSConfiguration{APIVersion: "v1", Name: "aws-kms", Endpoint: "unix:///var/run/kms/socket.sock"}},
+					{AESCBC: &apiserverconfigv1.AESConfiguration{Keys: []apiserverconfigv1.Key{{Name: "aescbc", Secret: "aescbc-secret"}}}},
+					{Secretbox: &apiserverconfigv1.SecretboxConfiguration{Keys: []apiserverconfigv1.Key{{Name: "secretbox", Secret: "secretbox-secret"}}}},
+					{Identity: &apiserverconfigv1.IdentityConfiguration{}},
+				},
+			},
+		},
+	}))
+}
+
+func TestNewConfigKeyError(t *testing.T) {
+	g := NewWithT(t)
+	config := &v1alpha1.EncryptionConfiguration{
+		Providers: []v1alpha1.EncryptionProvider{{Type: v1alpha1.AESCBCEncryptionProvider}},
+	}
+
+	_, err := encryption.NewConfig(config, func() (apiserverconfigv1.Key, error) {
+		return apiserverconfigv1.Key{}, errors.New("no entropy")
+	})
+	g.Expect(err).To(MatchError("no entropy"))
+}
+
+func TestNewConfigSecret(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "w01"},
+		Spec: v1alpha1.ClusterSpec{
+			EncryptionConfiguration: &v1alpha1.EncryptionConfiguration{
+				Resources: []string{"secrets", "configmaps"},
+				Providers: []v1alpha1.EncryptionProvider{{Type: v1alpha1.AESCBCEncryptionProvider}},
+			},
+		},
+	}
+
+	content, err := encryption.NewConfigSecret(cluster, keyGenerator("key-1"))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	secret := &corev1.Secret{}
+	g.Expect(yaml.Unmarshal(content, secret)).To(Succeed())
+	g.Expect(secret.Name).To(Equal("w01-encryption-config"))
+	g.Expect(secret.Namespace).To(Equal("eksa-system"))
+	g.Expect(secret.Labels).To(HaveKeyWithValue("clusterctl.cluster.x-k8s.io/move", "true"))
+
+	config, err := encryption.ConfigFromSecret(secret)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.Resources).To(HaveLen(1))
+	g.Expect(config.Resources[0].Resources).To(Equal([]string{"secrets", "configmaps"}))
+	g.Expect(config.Resources[0].Providers[0].AESCBC.Keys).To(Equal([]apiserverconfigv1.Key{{Name: "key-1", Secret: "key-1-secret"}}))
+}
+
+func TestNewConfigSecretNoEncryptionConfiguration(t *testing.T) {
+	g := NewWithT(t)
+	_, err := encryption.NewConfigSecret(&v1alpha1.Cluster{}, keyGenerator())
+	g.Expect(err).To(MatchError("cluster doesn't have an encryption configuration"))
+}
+
+func TestConfigFromSecretMissingKey(t *testing.T) {
+	g := NewWithT(t)
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "w01-encryption-config"}}
+
+	_, err := encryption.ConfigFromSecret(secret)
+	g.Expect(err).To(MatchError("secret w01-encryption-config doesn't contain key encryption-config.yaml"))
+}
@@ -0,0 +1,249 @@
+package encryption
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	apiserverconfigv1 "k8s.io/apiserver/pkg/apis/config/v1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/secret"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	controlPlaneRolloutTimeout = 60 * time.Minute
+	controlPlaneRolloutBackoff = 10 * time.Second
+)
+
+var (
+	eksaClusterResourceType         = fmt.Sprintf("clusters.%s", v1alpha1.GroupVersion.Group)
+	kubeadmControlPlaneResourceType = fmt.Sprintf("kubeadmcontrolplanes.%s", controlplanev1.GroupVersion.Group)
+)
+
+// KubectlClient reads and updates the cluster objects in the management cluster and
+// rewrites the encrypted resources of a cluster.
+type KubectlClient interface {
+	GetObject(ctx context.Context, resourceType, name, namespace, kubeconfig string, obj runtime.Object) error
+	ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
+	MergePatchResource(ctx context.Context, resourceType, name, patch, kubeconfig, namespace string) error
+	ReplaceResources(ctx context.Context, resourceTypes []string, kubeconfig string) error
+}
+
+// Client rotates the encryption keys of EKS-A clusters.
+type Client struct {
+	kubectl     KubectlClient
+	writer      filewriter.FileWriter
+	generateKey KeyGenerator
+	retrier     *retrier.Retrier
+	now         func() time.Time
+}
+
+// ClientOpt configures a Client.
+type ClientOpt func(*Client)
+
+// WithKeyGenerator sets the generator of the new keys.
+func WithKeyGenerator(generateKey KeyGenerator) ClientOpt {
+	return func(c *Client) {
+		c.generateKey = generateKey
+	}
+}
+
+// WithRetrier sets the retrier used to wait for the control plane rollouts.
+func WithRetrier(r *retrier.Retrier) ClientOpt {
+	return func(c *Client) {
+		c.retrier = r
+	}
+}
+
+// NewClient returns a new Client. writer is used to write the kubeconfig of the clusters
+// whose encrypted resources are rewritten.
+func NewClient(kubectl KubectlClient, writer filewriter.FileWriter, opts ...ClientOpt) *Client {
+	c := &Client{
+		kubectl:     kubectl,
+		writer:      writer,
+		generateKey: GenerateKey,
+		retrier: retrier.New(controlPlaneRolloutTimeout, retrier.WithRetryPolicy(func(_ int, _ error) (bool, time.Duration) {
+			return true, controlPlaneRolloutBackoff
+		})),
+		now: time.Now,
+	}
+
+	for _, o := range opts {
+		o(c)
+	}
+
+	return c
+}
+
+// RotateKeys replaces the keys of the aescbc and secretbox providers of a cluster and rewrites its encrypted
+// resources with the new keys. Each step rolls out the control plane and waits for all its machines to be
+// replaced, so every kube-apiserver reads the same encryption configuration before the next step:
+//  1. The new keys are added after the current ones, so all the kube-apiservers can decrypt with them.
+//  2. The new keys are moved first, so the kube-apiservers encrypt with them.
+//  3. The encrypted resources are rewritten with the new keys and the previous keys are removed.
+func (c *Client) RotateKeys(ctx context.Context, managementCluster *types.Cluster, clusterName, namespace string) error {
+	kubeconfig := managementCluster.KubeconfigFile
+	cluster := &v1alpha1.Cluster{}
+	if err := c.kubectl.GetObject(ctx, eksaClusterResourceType, clusterName, namespace, kubeconfig, cluster); err != nil {
+		return fmt.Errorf("getting EKS-A cluster %s: %v", clusterName, err)
+	}
+	if cluster.Spec.EncryptionConfiguration == nil {
+		return fmt.Errorf("cluster %s doesn't have an encryption configuration", clusterName)
+	}
+
+	name := ConfigSecretName(clusterName)
+	configSecret := &corev1.Secret{}
+	if err := c.kubectl.GetObject(ctx, "secret", name, constants.EksaSystemNamespace, kubeconfig, configSecret); err != nil {
+		return fmt.Errorf("getting secret %s: %v", name, err)
+	}
+
+	config, err := ConfigFromSecret(configSecret)
+	if err != nil {
+		return err
+	}
+
+	providers := keyProviders(config)
+	if len(providers) == 0 {
+		return fmt.Errorf("cluster %s doesn't have aescbc or secretbox encryption providers", clusterName)
+	}
+
+	previousKeys := make([][]apiserverconfigv1.Key, 0, len(providers))
+	newKeys := make([]apiserverconfigv1.Key, 0, len(providers))
+	for _, keys := range providers {
+		if len(*keys) == 0 {
+			return fmt.Errorf("secret %s has an encryption provider without keys", name)
+		}
+		key, err := c.generateKey()
+		if err != nil {
+			return err
+		}
+		previousKeys = append(previousKeys, *keys)
+		newKeys = append(newKeys, key)
+	}
+
+	logger.Info("Adding new encryption keys", "cluster", clusterName)
+	for i, keys := range providers {
+		*keys = append([]apiserverconfigv1.Key{previousKeys[i][0], newKeys[i]}, previousKeys[i][1:]...)
+	}
+	if err = c.updateConfig(ctx, managementCluster, clusterName, config); err != nil {
+		return err
+	}
+
+	logger.Info("Encrypting with the new encryption keys", "cluster", clusterName)
+	for i, keys := range providers {
+		*keys = append([]apiserverconfigv1.Key{newKeys[i]}, previousKeys[i]...)
+	}
+	if err = c.updateConfig(ctx, managementCluster, clusterName, config); err != nil {
+		return err
+	}
+
+	logger.Info("Rewriting encrypted resources", "cluster", clusterName)
+	if err = c.rewriteResources(ctx, managementCluster, clusterName, cluster.Spec.EncryptionConfiguration.ResourcesOrDefault()); err != nil {
+		return err
+	}
+
+	logger.Info("Removing previous encryption keys", "cluster", clusterName)
+	for i, keys := range providers {
+		*keys = []apiserverconfigv1.Key{newKeys[i]}
+	}
+	return c.updateConfig(ctx, managementCluster, clusterName, config)
+}
+
+// keyProviders returns the keys of the aescbc and secretbox providers of an encryption configuration.
+func keyProviders(config *apiserverconfigv1.EncryptionConfiguration) []*[]apiserverconfigv1.Key {
+	var keys []*[]apiserverconfigv1.Key
+	for i := range config.Resources {
+		for j := range config.Resources[i].Providers {
+			p := &config.Resources[i].Providers[j]
+			if p.AESCBC != nil {
+				keys = append(keys, &p.AESCBC.Keys)
+			}
+			if p.Secretbox != nil {
+				keys = append(keys, &p.Secretbox.Keys)
+			}
+		}
+	}
+	return keys
+}
+
+// updateConfig replaces the encryption configuration in the config secret and rolls out the control plane,
+// whose machines read it from the secret when they join the cluster.
+func (c *Client) updateConfig(ctx context.Context, managementCluster *types.Cluster, clusterName string, config *apiserverconfigv1.EncryptionConfiguration) error {
+	content, err := ConfigSecret(clusterName, config)
+	if err != nil {
+		return err
+	}
+	if err = c.kubectl.ApplyKubeSpecFromBytes(ctx, managementCluster, content); err != nil {
+		return fmt.Errorf("updating encryption configuration for cluster %s: %v", clusterName, err)
+	}
+
+	kubeconfig := managementCluster.KubeconfigFile
+	logger.V(3).Info("Rolling out control plane", "cluster", clusterName)
+	patch := fmt.Sprintf(`{"spec":{"rolloutAfter":%q}}`, c.now().UTC().Format(time.RFC3339))
+	if err = c.kubectl.MergePatchResource(ctx, kubeadmControlPlaneResourceType, clusterName, patch, kubeconfig, constants.EksaSystemNamespace); err != nil {
+		return fmt.Errorf("rolling out control plane for cluster %s: %v", clusterName, err)
+	}
+
+	logger.V(3).Info("Waiting for control plane rollout", "cluster", clusterName)
+	return c.retrier.Retry(func() error {
+		kcp := &controlplanev1.KubeadmControlPlane{}
+		if err := c.kubectl.GetObject(ctx, kubeadmControlPlaneResourceType, clusterName, constants.EksaSystemNamespace, kubeconfig, kcp); err != nil {
+			return fmt.Errorf("getting kubeadm control plane %s: %v", clusterName, err)
+		}
+		return controlPlaneRolledOut(kcp)
+	})
+}
+
+// controlPlaneRolledOut returns an error until all the machines of the control plane are updated and ready.
+func controlPlaneRolledOut(kcp *controlplanev1.KubeadmControlPlane) error {
+	if kcp.Status.ObservedGeneration < kcp.Generation {
+		return fmt.Errorf("kubeadm control plane %s status is outdated", kcp.Name)
+	}
+
+	replicas := int32(1)
+	if kcp.Spec.Replicas != nil {
+		replicas = *kcp.Spec.Replicas
+	}
+	if kcp.Status.Replicas != replicas || kcp.Status.UpdatedReplicas != replicas || kcp.Status.ReadyReplicas != replicas {
+		return fmt.Errorf("kubeadm control plane %s has %d/%d machines updated and %d ready",
+			kcp.Name, kcp.Status.UpdatedReplicas, replicas, kcp.Status.ReadyReplicas)
+	}
+
+	return nil
+}
+
+// rewriteResources replaces all the encrypted resources of a cluster with their current content,
+// so the kube-apiserver writes them encrypted with the new keys.
+func (c *Client) rewriteResources(ctx context.Context, managementCluster *types.Cluster, clusterName string, resources []string) error {
+	name := secret.Name(clusterName, secret.Kubeconfig)
+	kubeconfigSecret := &corev1.Secret{}
+	if err := c.kubectl.GetObject(ctx, "secret", name, constants.EksaSystemNamespace, managementCluster.KubeconfigFile, kubeconfigSecret); err != nil {
+		return fmt.Errorf("getting kubeconfig for cluster %s: %v", clusterName, err)
+	}
+
+	content, ok := kubeconfigSecret.Data[secret.KubeconfigDataName]
+	if !ok {
+		return errors.New("kubeconfig secret doesn't contain a kubeconfig")
+	}
+
+	kubeconfig, err := c.writer.Write(fmt.Sprintf("%s-encryption.kubeconfig", clusterName), content, filewriter.Permission0600)
+	if err != nil {
+		return fmt.Errorf("writing kubeconfig for cluster %s: %v", clusterName, err)
+	}
+
+	if err = c.kubectl.ReplaceResources(ctx, resources, kubeconfig); err != nil {
+		return fmt.Errorf("rewriting encrypted resources for cluster %s: %v", clusterName, err)
+	}
+
+	return nil
+}
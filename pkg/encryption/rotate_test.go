@@ -0,0 +1,209 @@
+package encryption_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	apiserverconfigv1 "k8s.io/apiserver/pkg/apis/config/v1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/encryption"
+	"github.com/aws/eks-anywhere/pkg/encryption/mocks"
+	writermocks "github.com/aws/eks-anywhere/pkg/filewriter/mocks"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	kubeconfig           = "mgmt.kubeconfig"
+	clusterResourceType  = "clusters.anywhere.eks.amazonaws.com"
+	kcpResourceType      = "kubeadmcontrolplanes.controlplane.cluster.x-k8s.io"
+	workloadKubeconfig   = "w01/w01-encryption.kubeconfig"
+	configSecretName     = "w01-encryption-config"
+	kubeconfigSecretName = "w01-kubeconfig"
+)
+
+type rotateTest struct {
+	*WithT
+	ctx     context.Context
+	kubectl *mocks.MockKubectlClient
+	writer  *writermocks.MockFileWriter
+	client  *encryption.Client
+	mgmt    *types.Cluster
+	cluster *v1alpha1.Cluster
+	config  *apiserverconfigv1.EncryptionConfiguration
+	applied [][]apiserverconfigv1.Key
+}
+
+func newRotateTest(t *testing.T) *rotateTest {
+	ctrl := gomock.NewController(t)
+	kubectl := mocks.NewMockKubectlClient(ctrl)
+	writer := writermocks.NewMockFileWriter(ctrl)
+	g := NewWithT(t)
+
+	cluster := &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "w01", Namespace: "default"},
+		Spec: v1alpha1.ClusterSpec{
+			EncryptionConfiguration: &v1alpha1.EncryptionConfiguration{
+				Providers: []v1alpha1.EncryptionProvider{{Type: v1alpha1.AESCBCEncryptionProvider}},
+			},
+		},
+	}
+	config, err := encryption.NewConfig(cluster.Spec.EncryptionConfiguration, keyGenerator("key-old"))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	return &rotateTest{
+		WithT:   g,
+		ctx:     context.Background(),
+		kubectl: kubectl,
+		writer:  writer,
+		client: encryption.NewClient(kubectl, writer,
+			encryption.WithKeyGenerator(keyGenerator("key-new")),
+			encryption.WithRetrier(retrier.NewWithMaxRetries(1, 0)),
+		),
+		mgmt:    &types.Cluster{Name: "mgmt", KubeconfigFile: kubeconfig},
+		cluster: cluster,
+		config:  config,
+	}
+}
+
+func (tt *rotateTest) configSecret() *corev1.Secret {
+	content, err := encryption.ConfigSecret(tt.cluster.Name, tt.config)
+	tt.Expect(err).NotTo(HaveOccurred())
+	secret := &corev1.Secret{}
+	tt.Expect(yaml.Unmarshal(content, secret)).To(Succeed())
+	return secret
+}
+
+func (tt *rotateTest) expectGetObject(resourceType, name, namespace string, want runtime.Object) *gomock.Call {
+	return tt.kubectl.EXPECT().GetObject(tt.ctx, resourceType, name, namespace, kubeconfig, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _, _, _, _ string, obj runtime.Object) error {
+			content, err := yaml.Marshal(want)
+			tt.Expect(err).NotTo(HaveOccurred())
+			return yaml.Unmarshal(content, obj)
+		},
+	)
+}
+
+func (tt *rotateTest) expectGetCluster() {
+	tt.expectGetObject(clusterResourceType, "w01", "default", tt.cluster)
+	tt.expectGetObject("secret", configSecretName, "eksa-system", tt.configSecret())
+}
+
+func (tt *rotateTest) expectUpdateConfig() *gomock.Call {
+	tt.kubectl.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.mgmt, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, content []byte) error {
+			secret := &corev1.Secret{}
+			tt.Expect(yaml.Unmarshal(content, secret)).To(Succeed())
+			tt.Expect(secret.Name).To(Equal(configSecretName))
+			config, err := encryption.ConfigFromSecret(secret)
+			tt.Expect(err).NotTo(HaveOccurred())
+			tt.applied = append(tt.applied, config.Resources[0].Providers[0].AESCBC.Keys)
+			return nil
+		},
+	)
+	tt.kubectl.EXPECT().MergePatchResource(tt.ctx, kcpResourceType, "w01", gomock.Any(), kubeconfig, "eksa-system")
+	return tt.expectGetObject(kcpResourceType, "w01", "eksa-system", rolledOutKCP())
+}
+
+func (tt *rotateTest) expectRewriteResources() {
+	tt.expectGetObject("secret", kubeconfigSecretName, "eksa-system", &corev1.Secret{
+		Data: map[string][]byte{"value": []byte("kubeconfig")},
+	})
+	tt.writer.EXPECT().Write("w01-encryption.kubeconfig", []byte("kubeconfig"), gomock.Any()).Return(workloadKubeconfig, nil)
+	tt.kubectl.EXPECT().ReplaceResources(tt.ctx, []string{"secrets"}, workloadKubeconfig)
+}
+
+func rolledOutKCP() *controlplanev1.KubeadmControlPlane {
+	replicas := int32(3)
+	return &controlplanev1.KubeadmControlPlane{
+		ObjectMeta: metav1.ObjectMeta{Name: "w01", Generation: 2},
+		Spec:       controlplanev1.KubeadmControlPlaneSpec{Replicas: &replicas},
+		Status: controlplanev1.KubeadmControlPlaneStatus{
+			ObservedGeneration: 2,
+			Replicas:           3,
+			UpdatedReplicas:    3,
+			ReadyReplicas:      3,
+		},
+	}
+}
+
+func TestClientRotateKeys(t *testing.T) {
+	tt := newRotateTest(t)
+	tt.expectGetCluster()
+	tt.expectUpdateConfig()
+	tt.expectUpdateConfig()
+	tt.expectRewriteResources()
+	tt.expectUpdateConfig()
+
+	tt.Expect(tt.client.RotateKeys(tt.ctx, tt.mgmt, "w01", "default")).To(Succeed())
+
+	oldKey := apiserverconfigv1.Key{Name: "key-old", Secret: "key-old-secret"}
+	newKey := apiserverconfigv1.Key{Name: "key-new", Secret: "key-new-secret"}
+	tt.Expect(tt.applied).To(Equal([][]apiserverconfigv1.Key{
+		{oldKey, newKey},
+		{newKey, oldKey},
+		{newKey},
+	}))
+}
+
+func TestClientRotateKeysNoEncryptionConfiguration(t *testing.T) {
+	tt := newRotateTest(t)
+	tt.cluster.Spec.EncryptionConfiguration = nil
+	tt.expectGetObject(clusterResourceType, "w01", "default", tt.cluster)
+
+	tt.Expect(tt.client.RotateKeys(tt.ctx, tt.mgmt, "w01", "default")).To(
+		MatchError("cluster w01 doesn't have an encryption configuration"),
+	)
+}
+
+func TestClientRotateKeysOnlyKMS(t *testing.T) {
+	tt := newRotateTest(t)
+	tt.config.Resources[0].Providers = []apiserverconfigv1.ProviderConfiguration{
+		{KMS: &apiserverconfigv1.KMSConfiguration{APIVersion: "v2", Name: "aws-kms", Endpoint: "unix:///var/run/kms/socket.sock"}},
+		{Identity: &apiserverconfigv1.IdentityConfiguration{}},
+	}
+	tt.expectGetCluster()
+
+	tt.Expect(tt.client.RotateKeys(tt.ctx, tt.mgmt, "w01", "default")).To(
+		MatchError("cluster w01 doesn't have aescbc or secretbox encryption providers"),
+	)
+}
+
+func TestClientRotateKeysControlPlaneNotRolledOut(t *testing.T) {
+	tt := newRotateTest(t)
+	tt.expectGetCluster()
+	tt.kubectl.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.mgmt, gomock.Any())
+	tt.kubectl.EXPECT().MergePatchResource(tt.ctx, kcpResourceType, "w01", gomock.Any(), kubeconfig, "eksa-system")
+	kcp := rolledOutKCP()
+	kcp.Status.UpdatedReplicas = 1
+	tt.expectGetObject(kcpResourceType, "w01", "eksa-system", kcp)
+
+	tt.Expect(tt.client.RotateKeys(tt.ctx, tt.mgmt, "w01", "default")).To(
+		MatchError(ContainSubstring("kubeadm control plane w01 has 1/3 machines updated and 3 ready")),
+	)
+}
+
+func TestClientRotateKeysRewriteError(t *testing.T) {
+	tt := newRotateTest(t)
+	tt.expectGetCluster()
+	tt.expectUpdateConfig()
+	tt.expectUpdateConfig()
+	tt.expectGetObject("secret", kubeconfigSecretName, "eksa-system", &corev1.Secret{
+		Data: map[string][]byte{"value": []byte("kubeconfig")},
+	})
+	tt.writer.EXPECT().Write("w01-encryption.kubeconfig", []byte("kubeconfig"), gomock.Any()).Return(workloadKubeconfig, nil)
+	tt.kubectl.EXPECT().ReplaceResources(tt.ctx, []string{"secrets"}, workloadKubeconfig).Return(errors.New("conflict"))
+
+	tt.Expect(tt.client.RotateKeys(tt.ctx, tt.mgmt, "w01", "default")).To(
+		MatchError("rewriting encrypted resources for cluster w01: conflict"),
+	)
+}
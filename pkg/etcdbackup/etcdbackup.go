@@ -0,0 +1,435 @@
+package etcdbackup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/version"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+var (
+	eksaClusterResourceType = fmt.Sprintf("clusters.%s", v1alpha1.GroupVersion.Group)
+	capiClusterResourceType = fmt.Sprintf("clusters.%s", clusterv1.GroupVersion.Group)
+	capiMachineResourceType = fmt.Sprintf("machines.%s", clusterv1.GroupVersion.Group)
+)
+
+// KubectlClient reads and updates the cluster objects in the management cluster.
+type KubectlClient interface {
+	GetObject(ctx context.Context, resourceType, name, namespace, kubeconfig string, obj runtime.Object) error
+	ListObjects(ctx context.Context, resourceType, namespace, kubeconfig string, list kubernetes.ObjectList) error
+	GetBundles(ctx context.Context, kubeconfigFile, name, namespace string) (*releasev1alpha1.Bundles, error)
+	UpdateAnnotationInNamespace(ctx context.Context, resourceType, objectName string, annotations map[string]string, cluster *types.Cluster, namespace string) error
+	RemoveAnnotationInNamespace(ctx context.Context, resourceType, objectName, key string, cluster *types.Cluster, namespace string) error
+}
+
+// Options identifies the cluster to back up or restore and how to reach its machines.
+type Options struct {
+	// ManagementCluster is the cluster where the EKS-A and CAPI objects of the cluster live.
+	ManagementCluster *types.Cluster
+	ClusterName       string
+	Namespace         string
+	// SSHUsername is the user to log in the etcd machines. If empty, the first user
+	// in the machine config of the etcd machines is used.
+	SSHUsername string
+}
+
+// Client takes etcd snapshots of EKS-A clusters and restores them.
+type Client struct {
+	kubectl KubectlClient
+	remote  RemoteClient
+	store   Store
+	now     func() time.Time
+}
+
+// NewClient returns a new Client that saves and reads snapshots from store.
+func NewClient(kubectl KubectlClient, remote RemoteClient, store Store) *Client {
+	return &Client{
+		kubectl: kubectl,
+		remote:  remote,
+		store:   store,
+		now:     time.Now,
+	}
+}
+
+// clusterEtcd holds the information needed to reach the etcd members of a cluster.
+type clusterEtcd struct {
+	cluster  *v1alpha1.Cluster
+	topology Topology
+	osFamily v1alpha1.OSFamily
+	commands snapshotCommands
+	username string
+	machines []clusterv1.Machine
+}
+
+// SnapshotName returns the default name for a new snapshot of a cluster.
+func (c *Client) SnapshotName(clusterName string) string {
+	return fmt.Sprintf("%s-etcd-%s", clusterName, c.now().UTC().Format("20060102150405"))
+}
+
+// Backup takes a snapshot of the etcd database of a cluster from one of its healthy etcd machines and
+// saves it to the store with the given name, together with its Metadata.
+func (c *Client) Backup(ctx context.Context, opts Options, name string) (*Metadata, error) {
+	etcd, err := c.clusterEtcd(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &Metadata{
+		Name:              name,
+		ClusterName:       etcd.cluster.Name,
+		KubernetesVersion: etcd.cluster.Spec.KubernetesVersion,
+		EksaVersion:       version.Get().GitVersion,
+		Topology:          etcd.topology,
+		CreatedAt:         metav1.NewTime(c.now()),
+	}
+
+	if ref := etcd.cluster.Spec.BundlesRef; ref != nil {
+		bundles, err := c.kubectl.GetBundles(ctx, opts.ManagementCluster.KubeconfigFile, ref.Name, ref.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("getting bundles for cluster %s: %v", etcd.cluster.Name, err)
+		}
+		metadata.BundlesName = bundles.Name
+		metadata.BundlesVersion = bundles.Spec.Number
+	}
+
+	machine, err := c.healthyMachine(ctx, etcd)
+	if err != nil {
+		return nil, err
+	}
+	address := machineAddress(machine)
+	metadata.Machine = machine.Name
+
+	logger.Info("Taking etcd snapshot", "machine", machine.Name)
+	if err = c.remote.Run(ctx, etcd.username, address, etcd.commands.snapshot(), nil, nil); err != nil {
+		return nil, fmt.Errorf("taking etcd snapshot in machine %s: %v", machine.Name, err)
+	}
+	defer c.removeRemoteSnapshot(ctx, etcd, address)
+
+	snapshot, err := os.CreateTemp("", "eksa-etcd-snapshot")
+	if err != nil {
+		return nil, fmt.Errorf("creating temporary snapshot file: %v", err)
+	}
+	defer os.Remove(snapshot.Name())
+	defer snapshot.Close()
+
+	if err = c.remote.Run(ctx, etcd.username, address, fmt.Sprintf("sudo cat %s", etcd.commands.snapshotFile()), nil, snapshot); err != nil {
+		return nil, fmt.Errorf("downloading etcd snapshot from machine %s: %v", machine.Name, err)
+	}
+
+	if _, err = snapshot.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("reading temporary snapshot file: %v", err)
+	}
+
+	logger.V(3).Info("Saving etcd snapshot", "name", name)
+	if err = c.store.Put(ctx, snapshotKey(name), snapshot); err != nil {
+		return nil, fmt.Errorf("saving etcd snapshot: %v", err)
+	}
+
+	if err = putMetadata(ctx, c.store, metadata); err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+// Restore replaces the etcd data of a cluster with the snapshot saved in the store with the given name.
+// The target cluster is meant to be a freshly created control plane running the same Kubernetes
+// version the snapshot was taken from. EKS-A and CAPI reconciliation for the cluster are paused during
+// the restore and resumed once all the etcd members are back up. If the restore fails before any etcd
+// member is stopped, reconciliation is resumed. Otherwise it's left paused, since the controllers would
+// replace the machines with the stopped members, and the returned error explains how to recover.
+func (c *Client) Restore(ctx context.Context, opts Options, name string) error {
+	metadata, err := getMetadata(ctx, c.store, name)
+	if err != nil {
+		return err
+	}
+
+	etcd, err := c.clusterEtcd(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	commands, ok := etcd.commands.(etcdCommands)
+	if !ok {
+		return fmt.Errorf("etcd restore is not supported for %s machines", etcd.osFamily)
+	}
+
+	if err = validateRestore(metadata, etcd); err != nil {
+		return err
+	}
+
+	members, err := c.members(ctx, etcd)
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := os.CreateTemp("", "eksa-etcd-snapshot")
+	if err != nil {
+		return fmt.Errorf("creating temporary snapshot file: %v", err)
+	}
+	defer os.Remove(snapshot.Name())
+	defer snapshot.Close()
+
+	if err = c.store.Get(ctx, snapshotKey(name), snapshot); err != nil {
+		return fmt.Errorf("reading etcd snapshot %s: %v", name, err)
+	}
+
+	if err = c.pauseReconcile(ctx, opts, etcd.cluster); err != nil {
+		return err
+	}
+
+	if err = c.uploadSnapshot(ctx, etcd, members, snapshot); err != nil {
+		c.resumeReconcileAfterError(ctx, opts, etcd.cluster)
+		return err
+	}
+
+	if err = c.restoreMembers(ctx, etcd, commands, members, name); err != nil {
+		return fmt.Errorf("%v\n%s", err, recoveryMessage(opts, etcd.cluster))
+	}
+
+	return c.resumeReconcile(ctx, opts, etcd.cluster)
+}
+
+func (c *Client) uploadSnapshot(ctx context.Context, etcd *clusterEtcd, members []member, snapshot *os.File) error {
+	for _, m := range members {
+		if _, err := snapshot.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("reading temporary snapshot file: %v", err)
+		}
+		logger.V(3).Info("Uploading etcd snapshot", "machine", m.machine)
+		if err := c.remote.Run(ctx, etcd.username, m.address, fmt.Sprintf("sudo tee %s > /dev/null", remoteSnapshotPath), snapshot, nil); err != nil {
+			return fmt.Errorf("uploading etcd snapshot to machine %s: %v", m.machine, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) restoreMembers(ctx context.Context, etcd *clusterEtcd, commands etcdCommands, members []member, name string) error {
+	logger.Info("Stopping etcd members")
+	for _, m := range members {
+		if err := c.remote.Run(ctx, etcd.username, m.address, commands.stop(), nil, nil); err != nil {
+			return fmt.Errorf("stopping etcd in machine %s: %v", m.machine, err)
+		}
+	}
+
+	logger.Info("Restoring etcd snapshot", "snapshot", name)
+	cluster := initialCluster(members)
+	for _, m := range members {
+		if err := c.remote.Run(ctx, etcd.username, m.address, commands.restore(m, cluster), nil, nil); err != nil {
+			return fmt.Errorf("restoring etcd snapshot in machine %s: %v", m.machine, err)
+		}
+	}
+
+	logger.Info("Starting etcd members")
+	for _, m := range members {
+		if err := c.remote.Run(ctx, etcd.username, m.address, commands.start(), nil, nil); err != nil {
+			return fmt.Errorf("starting etcd in machine %s: %v", m.machine, err)
+		}
+		c.removeRemoteSnapshot(ctx, etcd, m.address)
+	}
+
+	return nil
+}
+
+// recoveryMessage explains how to bring a cluster back after a restore failed with etcd members stopped.
+func recoveryMessage(opts Options, cluster *v1alpha1.Cluster) string {
+	return fmt.Sprintf("Reconciliation for cluster %[1]s was left paused. The data of each etcd member before the restore "+
+		"was moved to %[2]s in its machine if the restore reached it. Once etcd is running in all the etcd machines, "+
+		"resume reconciliation with:\n"+
+		"  kubectl --kubeconfig %[3]s annotate clusters.cluster.x-k8s.io %[1]s -n %[4]s %[5]s-\n"+
+		"  kubectl --kubeconfig %[3]s annotate clusters.anywhere.eks.amazonaws.com %[1]s -n %[6]s %[7]s-",
+		cluster.Name, previousDataDir, opts.ManagementCluster.KubeconfigFile, constants.EksaSystemNamespace,
+		clusterv1.PausedAnnotation, cluster.Namespace, cluster.PausedAnnotation())
+}
+
+func (c *Client) clusterEtcd(ctx context.Context, opts Options) (*clusterEtcd, error) {
+	kubeconfig := opts.ManagementCluster.KubeconfigFile
+	cluster := &v1alpha1.Cluster{}
+	if err := c.kubectl.GetObject(ctx, eksaClusterResourceType, opts.ClusterName, opts.Namespace, kubeconfig, cluster); err != nil {
+		return nil, fmt.Errorf("getting EKS-A cluster %s: %v", opts.ClusterName, err)
+	}
+
+	topology := topologyFor(cluster)
+	ref := topology.machineGroupRef(cluster)
+	if ref == nil {
+		return nil, fmt.Errorf("etcd backup is not supported for provider %s: etcd machines are not reachable through ssh", cluster.Spec.DatacenterRef.Kind)
+	}
+
+	machineConfig := &unstructured.Unstructured{}
+	resourceType := fmt.Sprintf("%ss.%s", strings.ToLower(ref.Kind), v1alpha1.GroupVersion.Group)
+	if err := c.kubectl.GetObject(ctx, resourceType, ref.Name, cluster.Namespace, kubeconfig, machineConfig); err != nil {
+		return nil, fmt.Errorf("getting etcd machine config %s: %v", ref.Name, err)
+	}
+
+	osFamily, _, _ := unstructured.NestedString(machineConfig.Object, "spec", "osFamily")
+
+	username := opts.SSHUsername
+	if username == "" {
+		users, _, _ := unstructured.NestedSlice(machineConfig.Object, "spec", "users")
+		if len(users) > 0 {
+			if u, ok := users[0].(map[string]interface{}); ok {
+				username, _ = u["name"].(string)
+			}
+		}
+	}
+	if username == "" {
+		return nil, fmt.Errorf("no ssh user found in machine config %s, an ssh username is required", ref.Name)
+	}
+
+	machines, err := c.etcdMachines(ctx, kubeconfig, cluster.Name, topology)
+	if err != nil {
+		return nil, err
+	}
+
+	return &clusterEtcd{
+		cluster:  cluster,
+		topology: topology,
+		osFamily: v1alpha1.OSFamily(osFamily),
+		commands: commandsFor(topology, v1alpha1.OSFamily(osFamily)),
+		username: username,
+		machines: machines,
+	}, nil
+}
+
+// etcdMachines returns the running machines of a cluster that have an etcd member.
+func (c *Client) etcdMachines(ctx context.Context, kubeconfig, clusterName string, topology Topology) ([]clusterv1.Machine, error) {
+	machines := &clusterv1.MachineList{}
+	if err := c.kubectl.ListObjects(ctx, capiMachineResourceType, constants.EksaSystemNamespace, kubeconfig, machines); err != nil {
+		return nil, fmt.Errorf("listing machines for cluster %s: %v", clusterName, err)
+	}
+
+	etcdMachines := make([]clusterv1.Machine, 0, len(machines.Items))
+	for _, m := range machines.Items {
+		if m.Labels[clusterv1.ClusterLabelName] != clusterName {
+			continue
+		}
+		if _, ok := m.Labels[topology.machineLabel()]; !ok {
+			continue
+		}
+		if m.Status.Phase != string(clusterv1.MachinePhaseRunning) || machineAddress(&m) == "" {
+			continue
+		}
+		etcdMachines = append(etcdMachines, m)
+	}
+
+	if len(etcdMachines) == 0 {
+		return nil, fmt.Errorf("no running etcd machines found for cluster %s", clusterName)
+	}
+
+	return etcdMachines, nil
+}
+
+// healthyMachine returns the first etcd machine of a cluster whose etcd member is healthy.
+func (c *Client) healthyMachine(ctx context.Context, etcd *clusterEtcd) (*clusterv1.Machine, error) {
+	var err error
+	for i := range etcd.machines {
+		m := &etcd.machines[i]
+		if err = c.remote.Run(ctx, etcd.username, machineAddress(m), etcd.commands.health(), nil, nil); err == nil {
+			return m, nil
+		}
+		logger.V(3).Info("Etcd member is not healthy", "machine", m.Name, "error", err)
+	}
+
+	return nil, fmt.Errorf("no healthy etcd members found for cluster %s: %v", etcd.cluster.Name, err)
+}
+
+// members returns the etcd members running in the machines of a cluster, reading their names from their hostnames.
+func (c *Client) members(ctx context.Context, etcd *clusterEtcd) ([]member, error) {
+	members := make([]member, 0, len(etcd.machines))
+	for i := range etcd.machines {
+		m := member{machine: etcd.machines[i].Name, address: machineAddress(&etcd.machines[i])}
+		hostname := &bytes.Buffer{}
+		if err := c.remote.Run(ctx, etcd.username, m.address, "hostname", nil, hostname); err != nil {
+			return nil, fmt.Errorf("getting hostname of machine %s: %v", m.machine, err)
+		}
+		m.name = strings.TrimSpace(hostname.String())
+		members = append(members, m)
+	}
+
+	return members, nil
+}
+
+func validateRestore(metadata *Metadata, etcd *clusterEtcd) error {
+	if metadata.KubernetesVersion != etcd.cluster.Spec.KubernetesVersion {
+		return fmt.Errorf("snapshot %s was taken from a cluster running kubernetes %s, cluster %s is running kubernetes %s",
+			metadata.Name, metadata.KubernetesVersion, etcd.cluster.Name, etcd.cluster.Spec.KubernetesVersion)
+	}
+
+	if metadata.Topology != etcd.topology {
+		return fmt.Errorf("snapshot %s was taken from %s etcd, cluster %s uses %s etcd",
+			metadata.Name, metadata.Topology, etcd.cluster.Name, etcd.topology)
+	}
+
+	if ref := etcd.cluster.Spec.BundlesRef; ref != nil && metadata.BundlesName != "" && ref.Name != metadata.BundlesName {
+		logger.MarkWarning("Snapshot was taken with different bundles", "snapshotBundles", metadata.BundlesName, "clusterBundles", ref.Name)
+	}
+
+	return nil
+}
+
+func (c *Client) pauseReconcile(ctx context.Context, opts Options, cluster *v1alpha1.Cluster) error {
+	logger.V(3).Info("Pausing EKS-A and CAPI cluster reconciliation")
+	err := c.kubectl.UpdateAnnotationInNamespace(ctx, eksaClusterResourceType, cluster.Name,
+		map[string]string{cluster.PausedAnnotation(): "true"}, opts.ManagementCluster, cluster.Namespace)
+	if err != nil {
+		return fmt.Errorf("pausing EKS-A cluster reconciliation: %v", err)
+	}
+
+	err = c.kubectl.UpdateAnnotationInNamespace(ctx, capiClusterResourceType, cluster.Name,
+		map[string]string{clusterv1.PausedAnnotation: "true"}, opts.ManagementCluster, constants.EksaSystemNamespace)
+	if err != nil {
+		c.resumeReconcileAfterError(ctx, opts, cluster)
+		return fmt.Errorf("pausing CAPI cluster reconciliation: %v", err)
+	}
+
+	return nil
+}
+
+func (c *Client) resumeReconcile(ctx context.Context, opts Options, cluster *v1alpha1.Cluster) error {
+	logger.V(3).Info("Resuming EKS-A and CAPI cluster reconciliation")
+	err := c.kubectl.RemoveAnnotationInNamespace(ctx, capiClusterResourceType, cluster.Name,
+		clusterv1.PausedAnnotation, opts.ManagementCluster, constants.EksaSystemNamespace)
+	if err != nil {
+		return fmt.Errorf("resuming CAPI cluster reconciliation: %v", err)
+	}
+
+	err = c.kubectl.RemoveAnnotationInNamespace(ctx, eksaClusterResourceType, cluster.Name,
+		cluster.PausedAnnotation(), opts.ManagementCluster, cluster.Namespace)
+	if err != nil {
+		return fmt.Errorf("resuming EKS-A cluster reconciliation: %v", err)
+	}
+
+	return nil
+}
+
+// resumeReconcileAfterError resumes reconciliation when a restore fails before changing etcd.
+// Failures are only logged so they don't hide the original error.
+func (c *Client) resumeReconcileAfterError(ctx context.Context, opts Options, cluster *v1alpha1.Cluster) {
+	if err := c.resumeReconcile(ctx, opts, cluster); err != nil {
+		logger.Error(err, "Failed resuming reconciliation, resume it by removing the paused annotations from the EKS-A and CAPI clusters", "cluster", cluster.Name)
+	}
+}
+
+// removeRemoteSnapshot deletes the snapshot copy from a machine. Failures are only logged
+// since the snapshot is overwritten by the next backup or restore.
+func (c *Client) removeRemoteSnapshot(ctx context.Context, etcd *clusterEtcd, address string) {
+	if err := c.remote.Run(ctx, etcd.username, address, fmt.Sprintf("sudo rm -f %s", etcd.commands.snapshotFile()), nil, nil); err != nil {
+		logger.V(3).Info("Failed removing etcd snapshot from machine", "address", address, "error", err)
+	}
+}
@@ -0,0 +1,419 @@
+package etcdbackup_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const (
+	kubeconfig = "mgmt.kubeconfig"
+	snapshot   = "w01-etcd-20230110000000"
+)
+
+type etcdBackupTest struct {
+	*WithT
+	ctx      context.Context
+	kubectl  *mocks.MockKubectlClient
+	remote   *mocks.MockRemoteClient
+	store    *etcdbackup.LocalStore
+	client   *etcdbackup.Client
+	cluster  *v1alpha1.Cluster
+	mgmt     *types.Cluster
+	opts     etcdbackup.Options
+	osFamily string
+}
+
+func newEtcdBackupTest(t *testing.T) *etcdBackupTest {
+	ctrl := gomock.NewController(t)
+	kubectl := mocks.NewMockKubectlClient(ctrl)
+	remote := mocks.NewMockRemoteClient(ctrl)
+	store := etcdbackup.NewLocalStore(t.TempDir())
+	mgmt := &types.Cluster{Name: "mgmt", KubeconfigFile: kubeconfig}
+
+	return &etcdBackupTest{
+		WithT:   NewWithT(t),
+		ctx:     context.Background(),
+		kubectl: kubectl,
+		remote:  remote,
+		store:   store,
+		client:  etcdbackup.NewClient(kubectl, remote, store),
+		mgmt:    mgmt,
+		cluster: &v1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "w01", Namespace: "default"},
+			Spec: v1alpha1.ClusterSpec{
+				KubernetesVersion: v1alpha1.Kube124,
+				ControlPlaneConfiguration: v1alpha1.ControlPlaneConfiguration{
+					MachineGroupRef: &v1alpha1.Ref{Kind: v1alpha1.VSphereMachineConfigKind, Name: "w01-cp"},
+				},
+				BundlesRef: &v1alpha1.BundlesRef{Name: "bundles-10", Namespace: constants.EksaSystemNamespace},
+			},
+		},
+		opts: etcdbackup.Options{
+			ManagementCluster: mgmt,
+			ClusterName:       "w01",
+			Namespace:         "default",
+		},
+		osFamily: string(v1alpha1.Ubuntu),
+	}
+}
+
+func (tt *etcdBackupTest) withExternalEtcd() {
+	tt.cluster.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{
+		Count:           2,
+		MachineGroupRef: &v1alpha1.Ref{Kind: v1alpha1.VSphereMachineConfigKind, Name: "w01-etcd"},
+	}
+}
+
+func machine(name, label, address string) clusterv1.Machine {
+	return clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				clusterv1.ClusterLabelName: "w01",
+				label:                      "",
+			},
+		},
+		Status: clusterv1.MachineStatus{
+			Phase:     string(clusterv1.MachinePhaseRunning),
+			Addresses: clusterv1.MachineAddresses{{Type: clusterv1.MachineExternalIP, Address: address}},
+		},
+	}
+}
+
+func (tt *etcdBackupTest) expectClusterEtcd(machineConfig string, machines ...clusterv1.Machine) {
+	tt.kubectl.EXPECT().GetObject(tt.ctx, "clusters.anywhere.eks.amazonaws.com", "w01", "default", kubeconfig, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _, _, _ string, obj runtime.Object) error {
+			tt.cluster.DeepCopyInto(obj.(*v1alpha1.Cluster))
+			return nil
+		})
+	tt.kubectl.EXPECT().GetObject(tt.ctx, "vspheremachineconfigs.anywhere.eks.amazonaws.com", machineConfig, "default", kubeconfig, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _, _, _ string, obj runtime.Object) error {
+			obj.(*unstructured.Unstructured).Object = map[string]interface{}{
+				"spec": map[string]interface{}{
+					"osFamily": tt.osFamily,
+					"users":    []interface{}{map[string]interface{}{"name": "capv"}},
+				},
+			}
+			return nil
+		})
+	tt.kubectl.EXPECT().ListObjects(tt.ctx, "machines.cluster.x-k8s.io", constants.EksaSystemNamespace, kubeconfig, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _, _ string, list kubernetes.ObjectList) error {
+			list.(*clusterv1.MachineList).Items = machines
+			return nil
+		})
+}
+
+func (tt *etcdBackupTest) saveSnapshot(metadata etcdbackup.Metadata) {
+	content, err := yaml.Marshal(metadata)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(tt.store.Put(tt.ctx, snapshot+".yaml", bytes.NewReader(content))).To(Succeed())
+	tt.Expect(tt.store.Put(tt.ctx, snapshot+".db", strings.NewReader("snapshot data"))).To(Succeed())
+}
+
+func TestClientBackupStackedEtcd(t *testing.T) {
+	tt := newEtcdBackupTest(t)
+	tt.expectClusterEtcd("w01-cp",
+		machine("w01-md-0-1", clusterv1.MachineDeploymentLabelName, "10.0.0.3"),
+		machine("w01-cp-1", clusterv1.MachineControlPlaneLabelName, "10.0.0.1"),
+	)
+	tt.kubectl.EXPECT().GetBundles(tt.ctx, kubeconfig, "bundles-10", constants.EksaSystemNamespace).Return(
+		&releasev1alpha1.Bundles{
+			ObjectMeta: metav1.ObjectMeta{Name: "bundles-10"},
+			Spec:       releasev1alpha1.BundlesSpec{Number: 10},
+		}, nil,
+	)
+	gomock.InOrder(
+		tt.remote.EXPECT().Run(tt.ctx, "capv", "10.0.0.1", gomock.Any(), nil, nil).
+			DoAndReturn(func(_ context.Context, _, _, command string, _ io.Reader, _ io.Writer) error {
+				tt.Expect(command).To(HaveSuffix("endpoint health"))
+				return nil
+			}),
+		tt.remote.EXPECT().Run(tt.ctx, "capv", "10.0.0.1", gomock.Any(), nil, nil).
+			DoAndReturn(func(_ context.Context, _, _, command string, _ io.Reader, _ io.Writer) error {
+				tt.Expect(command).To(HavePrefix("sudo crictl exec"))
+				tt.Expect(command).To(ContainSubstring("snapshot save /var/lib/etcd/eksa-etcd-snapshot.db"))
+				return nil
+			}),
+		tt.remote.EXPECT().Run(tt.ctx, "capv", "10.0.0.1", "sudo cat /var/lib/eksa-etcd-snapshot.db", nil, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _, _ string, _ io.Reader, stdout io.Writer) error {
+				_, err := stdout.Write([]byte("snapshot data"))
+				return err
+			}),
+		tt.remote.EXPECT().Run(tt.ctx, "capv", "10.0.0.1", "sudo rm -f /var/lib/eksa-etcd-snapshot.db", nil, nil),
+	)
+
+	metadata, err := tt.client.Backup(tt.ctx, tt.opts, snapshot)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(metadata.ClusterName).To(Equal("w01"))
+	tt.Expect(metadata.KubernetesVersion).To(Equal(v1alpha1.Kube124))
+	tt.Expect(metadata.BundlesName).To(Equal("bundles-10"))
+	tt.Expect(metadata.BundlesVersion).To(Equal(10))
+	tt.Expect(metadata.Topology).To(Equal(etcdbackup.StackedTopology))
+	tt.Expect(metadata.Machine).To(Equal("w01-cp-1"))
+
+	data := &bytes.Buffer{}
+	tt.Expect(tt.store.Get(tt.ctx, snapshot+".db", data)).To(Succeed())
+	tt.Expect(data.String()).To(Equal("snapshot data"))
+
+	savedMetadata := &bytes.Buffer{}
+	tt.Expect(tt.store.Get(tt.ctx, snapshot+".yaml", savedMetadata)).To(Succeed())
+	tt.Expect(savedMetadata.String()).To(ContainSubstring("kubernetesVersion: \"1.24\""))
+}
+
+func TestClientBackupSnapshotError(t *testing.T) {
+	tt := newEtcdBackupTest(t)
+	tt.expectClusterEtcd("w01-cp", machine("w01-cp-1", clusterv1.MachineControlPlaneLabelName, "10.0.0.1"))
+	tt.kubectl.EXPECT().GetBundles(tt.ctx, kubeconfig, "bundles-10", constants.EksaSystemNamespace).Return(&releasev1alpha1.Bundles{}, nil)
+	gomock.InOrder(
+		tt.remote.EXPECT().Run(tt.ctx, "capv", "10.0.0.1", gomock.Any(), nil, nil),
+		tt.remote.EXPECT().Run(tt.ctx, "capv", "10.0.0.1", gomock.Any(), nil, nil).Return(errors.New("etcd not running")),
+	)
+
+	_, err := tt.client.Backup(tt.ctx, tt.opts, snapshot)
+	tt.Expect(err).To(MatchError("taking etcd snapshot in machine w01-cp-1: etcd not running"))
+}
+
+func TestClientBackupSkipsUnhealthyMember(t *testing.T) {
+	tt := newEtcdBackupTest(t)
+	tt.expectClusterEtcd("w01-cp",
+		machine("w01-cp-1", clusterv1.MachineControlPlaneLabelName, "10.0.0.1"),
+		machine("w01-cp-2", clusterv1.MachineControlPlaneLabelName, "10.0.0.2"),
+	)
+	tt.kubectl.EXPECT().GetBundles(tt.ctx, kubeconfig, "bundles-10", constants.EksaSystemNamespace).Return(&releasev1alpha1.Bundles{}, nil)
+	gomock.InOrder(
+		tt.remote.EXPECT().Run(tt.ctx, "capv", "10.0.0.1", gomock.Any(), nil, nil).Return(errors.New("unhealthy")),
+		tt.remote.EXPECT().Run(tt.ctx, "capv", "10.0.0.2", gomock.Any(), nil, nil),
+		tt.remote.EXPECT().Run(tt.ctx, "capv", "10.0.0.2", gomock.Any(), nil, nil),
+		tt.remote.EXPECT().Run(tt.ctx, "capv", "10.0.0.2", "sudo cat /var/lib/eksa-etcd-snapshot.db", nil, gomock.Any()),
+		tt.remote.EXPECT().Run(tt.ctx, "capv", "10.0.0.2", "sudo rm -f /var/lib/eksa-etcd-snapshot.db", nil, nil),
+	)
+
+	metadata, err := tt.client.Backup(tt.ctx, tt.opts, snapshot)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(metadata.Machine).To(Equal("w01-cp-2"))
+}
+
+func TestClientBackupNoHealthyMembers(t *testing.T) {
+	tt := newEtcdBackupTest(t)
+	tt.expectClusterEtcd("w01-cp", machine("w01-cp-1", clusterv1.MachineControlPlaneLabelName, "10.0.0.1"))
+	tt.kubectl.EXPECT().GetBundles(tt.ctx, kubeconfig, "bundles-10", constants.EksaSystemNamespace).Return(&releasev1alpha1.Bundles{}, nil)
+	tt.remote.EXPECT().Run(tt.ctx, "capv", "10.0.0.1", gomock.Any(), nil, nil).Return(errors.New("unhealthy"))
+
+	_, err := tt.client.Backup(tt.ctx, tt.opts, snapshot)
+	tt.Expect(err).To(MatchError("no healthy etcd members found for cluster w01: unhealthy"))
+}
+
+func TestClientBackupNoRunningMachines(t *testing.T) {
+	tt := newEtcdBackupTest(t)
+	m := machine("w01-cp-1", clusterv1.MachineControlPlaneLabelName, "10.0.0.1")
+	m.Status.Phase = string(clusterv1.MachinePhaseProvisioning)
+	tt.expectClusterEtcd("w01-cp", m)
+
+	_, err := tt.client.Backup(tt.ctx, tt.opts, snapshot)
+	tt.Expect(err).To(MatchError("no running etcd machines found for cluster w01"))
+}
+
+func TestClientBackupBottlerocket(t *testing.T) {
+	tt := newEtcdBackupTest(t)
+	tt.osFamily = string(v1alpha1.Bottlerocket)
+	tt.expectClusterEtcd("w01-cp", machine("w01-cp-1", clusterv1.MachineControlPlaneLabelName, "10.0.0.1"))
+	tt.kubectl.EXPECT().GetBundles(tt.ctx, kubeconfig, "bundles-10", constants.EksaSystemNamespace).Return(&releasev1alpha1.Bundles{}, nil)
+	inHost := func(want string) func(context.Context, string, string, string, io.Reader, io.Writer) error {
+		return func(_ context.Context, _, _, command string, _ io.Reader, _ io.Writer) error {
+			tt.Expect(command).To(HaveSuffix(" | base64 -d | sudo sheltie"))
+			encoded := strings.TrimSuffix(strings.TrimPrefix(command, "echo "), " | base64 -d | sudo sheltie")
+			decoded, err := base64.StdEncoding.DecodeString(encoded)
+			tt.Expect(err).NotTo(HaveOccurred())
+			tt.Expect(string(decoded)).To(ContainSubstring("--cacert /var/lib/kubeadm/pki/etcd/ca.crt"))
+			tt.Expect(string(decoded)).To(ContainSubstring(want))
+			return nil
+		}
+	}
+	gomock.InOrder(
+		tt.remote.EXPECT().Run(tt.ctx, "capv", "10.0.0.1", gomock.Any(), nil, nil).DoAndReturn(inHost("endpoint health")),
+		tt.remote.EXPECT().Run(tt.ctx, "capv", "10.0.0.1", gomock.Any(), nil, nil).
+			DoAndReturn(inHost("snapshot save /var/lib/etcd/eksa-etcd-snapshot.db && mv /var/lib/etcd/eksa-etcd-snapshot.db /var/lib/eksa-etcd-snapshot.db")),
+		tt.remote.EXPECT().Run(tt.ctx, "capv", "10.0.0.1", "sudo cat /.bottlerocket/rootfs/var/lib/eksa-etcd-snapshot.db", nil, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _, _ string, _ io.Reader, stdout io.Writer) error {
+				_, err := stdout.Write([]byte("snapshot data"))
+				return err
+			}),
+		tt.remote.EXPECT().Run(tt.ctx, "capv", "10.0.0.1", "sudo rm -f /.bottlerocket/rootfs/var/lib/eksa-etcd-snapshot.db", nil, nil),
+	)
+
+	metadata, err := tt.client.Backup(tt.ctx, tt.opts, snapshot)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(metadata.Machine).To(Equal("w01-cp-1"))
+
+	data := &bytes.Buffer{}
+	tt.Expect(tt.store.Get(tt.ctx, snapshot+".db", data)).To(Succeed())
+	tt.Expect(data.String()).To(Equal("snapshot data"))
+}
+
+func TestClientRestoreBottlerocket(t *testing.T) {
+	tt := newEtcdBackupTest(t)
+	tt.osFamily = string(v1alpha1.Bottlerocket)
+	tt.saveSnapshot(etcdbackup.Metadata{
+		Name:              snapshot,
+		KubernetesVersion: v1alpha1.Kube124,
+		Topology:          etcdbackup.StackedTopology,
+	})
+	tt.expectClusterEtcd("w01-cp", machine("w01-cp-1", clusterv1.MachineControlPlaneLabelName, "10.0.0.1"))
+
+	err := tt.client.Restore(tt.ctx, tt.opts, snapshot)
+	tt.Expect(err).To(MatchError("etcd restore is not supported for bottlerocket machines"))
+}
+
+func TestClientRestoreExternalEtcd(t *testing.T) {
+	tt := newEtcdBackupTest(t)
+	tt.withExternalEtcd()
+	tt.opts.SSHUsername = "ec2-user"
+	tt.saveSnapshot(etcdbackup.Metadata{
+		Name:              snapshot,
+		ClusterName:       "w01",
+		KubernetesVersion: v1alpha1.Kube124,
+		BundlesName:       "bundles-10",
+		Topology:          etcdbackup.ExternalTopology,
+		CreatedAt:         metav1.NewTime(time.Date(2023, 1, 10, 0, 0, 0, 0, time.UTC)),
+	})
+	tt.expectClusterEtcd("w01-etcd",
+		machine("w01-etcd-1", clusterv1.MachineEtcdClusterLabelName, "10.0.0.1"),
+		machine("w01-etcd-2", clusterv1.MachineEtcdClusterLabelName, "10.0.0.2"),
+	)
+
+	hostname := func(name string) func(context.Context, string, string, string, io.Reader, io.Writer) error {
+		return func(_ context.Context, _, _, _ string, _ io.Reader, stdout io.Writer) error {
+			_, err := stdout.Write([]byte(name + "\n"))
+			return err
+		}
+	}
+	upload := func(_ context.Context, _, _, _ string, stdin io.Reader, _ io.Writer) error {
+		content, err := io.ReadAll(stdin)
+		tt.Expect(string(content)).To(Equal("snapshot data"))
+		return err
+	}
+	initialCluster := "w01-etcd-1=https://10.0.0.1:2380,w01-etcd-2=https://10.0.0.2:2380"
+	restore := func(name, address string) string {
+		return "sudo rm -rf /var/lib/etcd.pre-restore && sudo mv /var/lib/etcd /var/lib/etcd.pre-restore && " +
+			"sudo ETCDCTL_API=3 /opt/bin/etcdctl snapshot restore /var/lib/eksa-etcd-snapshot.db --name " + name +
+			" --initial-cluster " + initialCluster + " --initial-cluster-token eksa-etcd-restore" +
+			" --initial-advertise-peer-urls https://" + address + ":2380 --data-dir /var/lib/etcd"
+	}
+
+	gomock.InOrder(
+		tt.remote.EXPECT().Run(tt.ctx, "ec2-user", "10.0.0.1", "hostname", nil, gomock.Any()).DoAndReturn(hostname("w01-etcd-1")),
+		tt.remote.EXPECT().Run(tt.ctx, "ec2-user", "10.0.0.2", "hostname", nil, gomock.Any()).DoAndReturn(hostname("w01-etcd-2")),
+		tt.kubectl.EXPECT().UpdateAnnotationInNamespace(tt.ctx, "clusters.anywhere.eks.amazonaws.com", "w01",
+			map[string]string{"anywhere.eks.amazonaws.com/paused": "true"}, tt.mgmt, "default"),
+		tt.kubectl.EXPECT().UpdateAnnotationInNamespace(tt.ctx, "clusters.cluster.x-k8s.io", "w01",
+			map[string]string{"cluster.x-k8s.io/paused": "true"}, tt.mgmt, constants.EksaSystemNamespace),
+		tt.remote.EXPECT().Run(tt.ctx, "ec2-user", "10.0.0.1", "sudo tee /var/lib/eksa-etcd-snapshot.db > /dev/null", gomock.Any(), nil).DoAndReturn(upload),
+		tt.remote.EXPECT().Run(tt.ctx, "ec2-user", "10.0.0.2", "sudo tee /var/lib/eksa-etcd-snapshot.db > /dev/null", gomock.Any(), nil).DoAndReturn(upload),
+		tt.remote.EXPECT().Run(tt.ctx, "ec2-user", "10.0.0.1", "sudo systemctl stop etcd", nil, nil),
+		tt.remote.EXPECT().Run(tt.ctx, "ec2-user", "10.0.0.2", "sudo systemctl stop etcd", nil, nil),
+		tt.remote.EXPECT().Run(tt.ctx, "ec2-user", "10.0.0.1", restore("w01-etcd-1", "10.0.0.1"), nil, nil),
+		tt.remote.EXPECT().Run(tt.ctx, "ec2-user", "10.0.0.2", restore("w01-etcd-2", "10.0.0.2"), nil, nil),
+		tt.remote.EXPECT().Run(tt.ctx, "ec2-user", "10.0.0.1", "sudo systemctl start etcd", nil, nil),
+		tt.remote.EXPECT().Run(tt.ctx, "ec2-user", "10.0.0.1", "sudo rm -f /var/lib/eksa-etcd-snapshot.db", nil, nil),
+		tt.remote.EXPECT().Run(tt.ctx, "ec2-user", "10.0.0.2", "sudo systemctl start etcd", nil, nil),
+		tt.remote.EXPECT().Run(tt.ctx, "ec2-user", "10.0.0.2", "sudo rm -f /var/lib/eksa-etcd-snapshot.db", nil, nil),
+		tt.kubectl.EXPECT().RemoveAnnotationInNamespace(tt.ctx, "clusters.cluster.x-k8s.io", "w01",
+			"cluster.x-k8s.io/paused", tt.mgmt, constants.EksaSystemNamespace),
+		tt.kubectl.EXPECT().RemoveAnnotationInNamespace(tt.ctx, "clusters.anywhere.eks.amazonaws.com", "w01",
+			"anywhere.eks.amazonaws.com/paused", tt.mgmt, "default"),
+	)
+
+	tt.Expect(tt.client.Restore(tt.ctx, tt.opts, snapshot)).To(Succeed())
+}
+
+func (tt *etcdBackupTest) expectRestoreUntilPaused() {
+	tt.saveSnapshot(etcdbackup.Metadata{
+		Name:              snapshot,
+		KubernetesVersion: v1alpha1.Kube124,
+		Topology:          etcdbackup.StackedTopology,
+	})
+	tt.expectClusterEtcd("w01-cp", machine("w01-cp-1", clusterv1.MachineControlPlaneLabelName, "10.0.0.1"))
+	gomock.InOrder(
+		tt.remote.EXPECT().Run(tt.ctx, "capv", "10.0.0.1", "hostname", nil, gomock.Any()),
+		tt.kubectl.EXPECT().UpdateAnnotationInNamespace(tt.ctx, "clusters.anywhere.eks.amazonaws.com", "w01",
+			map[string]string{"anywhere.eks.amazonaws.com/paused": "true"}, tt.mgmt, "default"),
+		tt.kubectl.EXPECT().UpdateAnnotationInNamespace(tt.ctx, "clusters.cluster.x-k8s.io", "w01",
+			map[string]string{"cluster.x-k8s.io/paused": "true"}, tt.mgmt, constants.EksaSystemNamespace),
+	)
+}
+
+func TestClientRestoreUploadErrorResumesReconcile(t *testing.T) {
+	tt := newEtcdBackupTest(t)
+	tt.expectRestoreUntilPaused()
+	gomock.InOrder(
+		tt.remote.EXPECT().Run(tt.ctx, "capv", "10.0.0.1", "sudo tee /var/lib/eksa-etcd-snapshot.db > /dev/null", gomock.Any(), nil).
+			Return(errors.New("disk full")),
+		tt.kubectl.EXPECT().RemoveAnnotationInNamespace(tt.ctx, "clusters.cluster.x-k8s.io", "w01",
+			"cluster.x-k8s.io/paused", tt.mgmt, constants.EksaSystemNamespace),
+		tt.kubectl.EXPECT().RemoveAnnotationInNamespace(tt.ctx, "clusters.anywhere.eks.amazonaws.com", "w01",
+			"anywhere.eks.amazonaws.com/paused", tt.mgmt, "default"),
+	)
+
+	err := tt.client.Restore(tt.ctx, tt.opts, snapshot)
+	tt.Expect(err).To(MatchError("uploading etcd snapshot to machine w01-cp-1: disk full"))
+}
+
+func TestClientRestoreStopErrorLeavesReconcilePaused(t *testing.T) {
+	tt := newEtcdBackupTest(t)
+	tt.expectRestoreUntilPaused()
+	gomock.InOrder(
+		tt.remote.EXPECT().Run(tt.ctx, "capv", "10.0.0.1", "sudo tee /var/lib/eksa-etcd-snapshot.db > /dev/null", gomock.Any(), nil),
+		tt.remote.EXPECT().Run(tt.ctx, "capv", "10.0.0.1", gomock.Any(), nil, nil).Return(errors.New("timed out")),
+	)
+
+	err := tt.client.Restore(tt.ctx, tt.opts, snapshot)
+	tt.Expect(err).To(MatchError(HavePrefix("stopping etcd in machine w01-cp-1: timed out\nReconciliation for cluster w01 was left paused.")))
+	tt.Expect(err).To(MatchError(ContainSubstring(
+		"kubectl --kubeconfig mgmt.kubeconfig annotate clusters.cluster.x-k8s.io w01 -n eksa-system cluster.x-k8s.io/paused-",
+	)))
+	tt.Expect(err).To(MatchError(ContainSubstring(
+		"kubectl --kubeconfig mgmt.kubeconfig annotate clusters.anywhere.eks.amazonaws.com w01 -n default anywhere.eks.amazonaws.com/paused-",
+	)))
+}
+
+func TestClientRestoreKubernetesVersionMismatch(t *testing.T) {
+	tt := newEtcdBackupTest(t)
+	tt.saveSnapshot(etcdbackup.Metadata{
+		Name:              snapshot,
+		KubernetesVersion: v1alpha1.Kube123,
+		Topology:          etcdbackup.StackedTopology,
+	})
+	tt.expectClusterEtcd("w01-cp", machine("w01-cp-1", clusterv1.MachineControlPlaneLabelName, "10.0.0.1"))
+
+	err := tt.client.Restore(tt.ctx, tt.opts, snapshot)
+	tt.Expect(err).To(MatchError(ContainSubstring("taken from a cluster running kubernetes 1.23, cluster w01 is running kubernetes 1.24")))
+}
+
+func TestClientRestoreSnapshotNotFound(t *testing.T) {
+	tt := newEtcdBackupTest(t)
+
+	err := tt.client.Restore(tt.ctx, tt.opts, snapshot)
+	tt.Expect(err).To(MatchError(ContainSubstring("reading metadata for snapshot " + snapshot)))
+}
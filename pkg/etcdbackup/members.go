@@ -0,0 +1,245 @@
+package etcdbackup
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// Topology is the way etcd is deployed in a cluster.
+type Topology string
+
+const (
+	// StackedTopology is etcd running as a static pod in the control plane machines, managed by kubeadm.
+	StackedTopology Topology = "stacked"
+	// ExternalTopology is etcd running in dedicated machines, managed by etcdadm.
+	ExternalTopology Topology = "external"
+)
+
+const (
+	// remoteSnapshotPath is where snapshots are written to and read from in the etcd machines.
+	remoteSnapshotPath  = "/var/lib/eksa-etcd-snapshot.db"
+	dataDir             = "/var/lib/etcd"
+	previousDataDir     = "/var/lib/etcd.pre-restore"
+	restoreClusterToken = "eksa-etcd-restore"
+	peerPort            = "2380"
+
+	stackedManifest        = "/etc/kubernetes/manifests/etcd.yaml"
+	stackedStoppedManifest = "/etc/kubernetes/eksa-etcd.yaml"
+
+	// bottlerocketRootFS is where the host filesystem is mounted in the Bottlerocket admin container.
+	bottlerocketRootFS = "/.bottlerocket/rootfs"
+)
+
+// member is an etcd member running in one of the cluster machines.
+type member struct {
+	machine string
+	address string
+	// name is the etcd member name, which is the machine hostname.
+	name string
+}
+
+func (m member) peerURL() string {
+	return fmt.Sprintf("https://%s:%s", m.address, peerPort)
+}
+
+// snapshotCommands builds the shell commands that take an etcd snapshot in the machines of a Topology.
+type snapshotCommands interface {
+	// health succeeds if the etcd member in the machine is healthy.
+	health() string
+	// snapshot saves a snapshot of the etcd member in the machine to remoteSnapshotPath.
+	snapshot() string
+	// snapshotFile is the path of remoteSnapshotPath for the ssh user.
+	snapshotFile() string
+}
+
+// etcdCommands builds the shell commands that manage etcd in the machines of a Topology.
+type etcdCommands interface {
+	snapshotCommands
+	stop() string
+	restore(m member, initialCluster string) string
+	start() string
+}
+
+func commandsFor(t Topology, osFamily v1alpha1.OSFamily) snapshotCommands {
+	if osFamily == v1alpha1.Bottlerocket {
+		return bottlerocketEtcdCommands{topology: t}
+	}
+	if t == ExternalTopology {
+		return externalEtcdCommands{}
+	}
+	return stackedEtcdCommands{}
+}
+
+func topologyFor(cluster *v1alpha1.Cluster) Topology {
+	if cluster.Spec.ExternalEtcdConfiguration != nil {
+		return ExternalTopology
+	}
+	return StackedTopology
+}
+
+// machineLabel is the label that identifies the machines running etcd for a Topology.
+func (t Topology) machineLabel() string {
+	if t == ExternalTopology {
+		return clusterv1.MachineEtcdClusterLabelName
+	}
+	return clusterv1.MachineControlPlaneLabelName
+}
+
+// machineGroupRef returns the reference to the machine config of the machines running etcd.
+func (t Topology) machineGroupRef(cluster *v1alpha1.Cluster) *v1alpha1.Ref {
+	if t == ExternalTopology {
+		return cluster.Spec.ExternalEtcdConfiguration.MachineGroupRef
+	}
+	return cluster.Spec.ControlPlaneConfiguration.MachineGroupRef
+}
+
+func initialCluster(members []member) string {
+	c := make([]string, 0, len(members))
+	for _, m := range members {
+		c = append(c, fmt.Sprintf("%s=%s", m.name, m.peerURL()))
+	}
+	return strings.Join(c, ",")
+}
+
+func restoreFlags(m member, initialCluster string) string {
+	return fmt.Sprintf("snapshot restore %s --name %s --initial-cluster %s --initial-cluster-token %s --initial-advertise-peer-urls %s --data-dir %s",
+		remoteSnapshotPath, m.name, initialCluster, restoreClusterToken, m.peerURL(), dataDir,
+	)
+}
+
+// moveDataDir keeps a copy of the current etcd data, replacing the one from a previous restore, if any.
+func moveDataDir() string {
+	return fmt.Sprintf("sudo rm -rf %[2]s && sudo mv %[1]s %[2]s", dataDir, previousDataDir)
+}
+
+// containerSnapshotPath is where the snapshot is saved when etcdctl runs in the etcd container. It's in the
+// etcd data dir, the only host path mounted in the container, and it's then moved out so it's not restored with the data.
+var containerSnapshotPath = fmt.Sprintf("%s/eksa-etcd-snapshot.db", dataDir)
+
+// stackedEtcdCommands runs etcdctl inside the etcd container started by kubelet from the kubeadm static pod manifest.
+type stackedEtcdCommands struct{}
+
+func (stackedEtcdCommands) etcdctl(args string) string {
+	return "sudo crictl exec $(sudo crictl ps --name etcd -q | head -n 1) etcdctl " +
+		"--endpoints https://127.0.0.1:2379 --cacert /etc/kubernetes/pki/etcd/ca.crt " +
+		"--cert /etc/kubernetes/pki/etcd/server.crt --key /etc/kubernetes/pki/etcd/server.key " + args
+}
+
+func (c stackedEtcdCommands) health() string {
+	return c.etcdctl("endpoint health")
+}
+
+func (c stackedEtcdCommands) snapshot() string {
+	return fmt.Sprintf("%s && sudo mv %s %s", c.etcdctl("snapshot save "+containerSnapshotPath), containerSnapshotPath, remoteSnapshotPath)
+}
+
+func (stackedEtcdCommands) snapshotFile() string {
+	return remoteSnapshotPath
+}
+
+func (stackedEtcdCommands) stop() string {
+	return fmt.Sprintf("sudo mv %s %s && timeout 300 sh -c 'while sudo crictl ps --name etcd -q | grep -q .; do sleep 5; done'",
+		stackedManifest, stackedStoppedManifest)
+}
+
+func (stackedEtcdCommands) restore(m member, initialCluster string) string {
+	// etcdctl is only available in the etcd image, so the restore runs in a one-off container.
+	return fmt.Sprintf("%s && sudo ctr -n k8s.io run --rm --net-host "+
+		"--mount type=bind,src=/var/lib,dst=/var/lib,options=rbind:rw "+
+		"$(sudo awk '$1 == \"image:\" {print $2; exit}' %s) eksa-etcd-restore etcdctl %s",
+		moveDataDir(), stackedStoppedManifest, restoreFlags(m, initialCluster))
+}
+
+func (stackedEtcdCommands) start() string {
+	return fmt.Sprintf("sudo mv %s %s", stackedStoppedManifest, stackedManifest)
+}
+
+// externalEtcdCommands uses the etcdctl binary and systemd unit installed by etcdadm.
+type externalEtcdCommands struct{}
+
+func (externalEtcdCommands) etcdctl(args string) string {
+	return "sudo ETCDCTL_API=3 /opt/bin/etcdctl " +
+		"--endpoints https://127.0.0.1:2379 --cacert /etc/etcd/pki/ca.crt " +
+		"--cert /etc/etcd/pki/etcdctl-etcd-client.crt --key /etc/etcd/pki/etcdctl-etcd-client.key " + args
+}
+
+func (c externalEtcdCommands) health() string {
+	return c.etcdctl("endpoint health")
+}
+
+func (c externalEtcdCommands) snapshot() string {
+	return c.etcdctl("snapshot save " + remoteSnapshotPath)
+}
+
+func (externalEtcdCommands) snapshotFile() string {
+	return remoteSnapshotPath
+}
+
+func (externalEtcdCommands) stop() string {
+	return "sudo systemctl stop etcd"
+}
+
+func (externalEtcdCommands) restore(m member, initialCluster string) string {
+	return fmt.Sprintf("%s && sudo ETCDCTL_API=3 /opt/bin/etcdctl %s", moveDataDir(), restoreFlags(m, initialCluster))
+}
+
+func (externalEtcdCommands) start() string {
+	return "sudo systemctl start etcd"
+}
+
+// bottlerocketEtcdCommands runs etcdctl inside the etcd container of a Bottlerocket machine. The ssh sessions land
+// in the admin container, so the commands are run in the host namespaces through sheltie. Only snapshots are
+// supported, since the etcd static pods are managed by the bootstrap host containers.
+type bottlerocketEtcdCommands struct {
+	topology Topology
+}
+
+// bottlerocketEtcdContainer finds the running etcd container in the kubernetes containerd namespace.
+const bottlerocketEtcdContainer = `$(ctr -n k8s.io c ls -q 'labels."io.kubernetes.container.name"==etcd' | ` +
+	`while read c; do ctr -n k8s.io t ls | awk -v c="$c" '$1 == c && $3 == "RUNNING" {print c}'; done | head -n 1)`
+
+func (c bottlerocketEtcdCommands) etcdctl(args string) string {
+	// etcdadm and kubeadm mount the etcd certificates in different paths.
+	certsDir := "/var/lib/kubeadm/pki/etcd"
+	if c.topology == ExternalTopology {
+		certsDir = "/var/lib/etcd/pki"
+	}
+	return fmt.Sprintf("ctr -n k8s.io t exec --exec-id eksa-etcdctl %s etcdctl "+
+		"--endpoints https://127.0.0.1:2379 --cacert %[2]s/ca.crt --cert %[2]s/server.crt --key %[2]s/server.key %[3]s",
+		bottlerocketEtcdContainer, certsDir, args)
+}
+
+func (c bottlerocketEtcdCommands) health() string {
+	return inBottlerocketHost(c.etcdctl("endpoint health"))
+}
+
+func (c bottlerocketEtcdCommands) snapshot() string {
+	return inBottlerocketHost(fmt.Sprintf("%s && mv %s %s", c.etcdctl("snapshot save "+containerSnapshotPath), containerSnapshotPath, remoteSnapshotPath))
+}
+
+func (bottlerocketEtcdCommands) snapshotFile() string {
+	return bottlerocketRootFS + remoteSnapshotPath
+}
+
+// inBottlerocketHost runs command as root in the host of a Bottlerocket machine from its admin container.
+// The command is encoded so it doesn't need to be quoted for the admin container shell.
+func inBottlerocketHost(command string) string {
+	return fmt.Sprintf("echo %s | base64 -d | sudo sheltie", base64.StdEncoding.EncodeToString([]byte(command)))
+}
+
+// machineAddress returns the external address of a machine, falling back to its internal one.
+func machineAddress(m *clusterv1.Machine) string {
+	for _, t := range []clusterv1.MachineAddressType{clusterv1.MachineExternalIP, clusterv1.MachineInternalIP} {
+		for _, a := range m.Status.Addresses {
+			if a.Type == t && a.Address != "" {
+				return a.Address
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,62 @@
+package etcdbackup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// Metadata describes the cluster an etcd snapshot was taken from.
+type Metadata struct {
+	Name              string                     `json:"name"`
+	ClusterName       string                     `json:"clusterName"`
+	KubernetesVersion v1alpha1.KubernetesVersion `json:"kubernetesVersion"`
+	BundlesName       string                     `json:"bundlesName,omitempty"`
+	BundlesVersion    int                        `json:"bundlesVersion,omitempty"`
+	// EksaVersion is the version of the CLI that took the snapshot.
+	EksaVersion string   `json:"eksaVersion,omitempty"`
+	Topology    Topology `json:"topology"`
+	// Machine is the name of the machine the snapshot was taken from.
+	Machine   string      `json:"machine"`
+	CreatedAt metav1.Time `json:"createdAt"`
+}
+
+func snapshotKey(name string) string {
+	return fmt.Sprintf("%s.db", name)
+}
+
+func metadataKey(name string) string {
+	return fmt.Sprintf("%s.yaml", name)
+}
+
+func putMetadata(ctx context.Context, store Store, m *Metadata) error {
+	content, err := yaml.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("marshalling snapshot metadata: %v", err)
+	}
+
+	if err = store.Put(ctx, metadataKey(m.Name), bytes.NewReader(content)); err != nil {
+		return fmt.Errorf("saving snapshot metadata: %v", err)
+	}
+
+	return nil
+}
+
+func getMetadata(ctx context.Context, store Store, name string) (*Metadata, error) {
+	content := &bytes.Buffer{}
+	if err := store.Get(ctx, metadataKey(name), content); err != nil {
+		return nil, fmt.Errorf("reading metadata for snapshot %s: %v", name, err)
+	}
+
+	m := &Metadata{}
+	if err := yaml.UnmarshalStrict(content.Bytes(), m); err != nil {
+		return nil, fmt.Errorf("parsing metadata for snapshot %s: %v", name, err)
+	}
+
+	return m, nil
+}
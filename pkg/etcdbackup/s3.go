@@ -0,0 +1,105 @@
+package etcdbackup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+// S3Config configures the bucket used by an S3Store.
+type S3Config struct {
+	Bucket string
+	// Prefix is prepended to all the object keys.
+	Prefix string
+	Region string
+	// Endpoint allows to use an S3-compatible object storage, like MinIO.
+	// Requests use path-style addressing when it's set. Optional.
+	Endpoint string
+}
+
+// S3Store is a Store backed by an S3-compatible bucket.
+// Credentials are read from the standard AWS environment variables and shared config files.
+type S3Store struct {
+	bucket   string
+	prefix   string
+	client   *s3.S3
+	uploader *s3manager.Uploader
+}
+
+// NewS3Store returns an S3Store for the bucket in config.
+func NewS3Store(config S3Config) (*S3Store, error) {
+	if config.Bucket == "" {
+		return nil, errors.New("s3 bucket is required")
+	}
+
+	awsConfig := aws.NewConfig()
+	if config.Region != "" {
+		awsConfig = awsConfig.WithRegion(config.Region)
+	}
+	if config.Endpoint != "" {
+		awsConfig = awsConfig.WithEndpoint(config.Endpoint).WithS3ForcePathStyle(true)
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            *awsConfig,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating aws session: %v", err)
+	}
+
+	return &S3Store{
+		bucket:   config.Bucket,
+		prefix:   config.Prefix,
+		client:   s3.New(sess),
+		uploader: s3manager.NewUploader(sess),
+	}, nil
+}
+
+// Put uploads the content of r to the bucket.
+func (s *S3Store) Put(ctx context.Context, key string, r io.Reader) error {
+	_, err := s.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key(key)),
+		Body:   r,
+	})
+	if err != nil {
+		return fmt.Errorf("uploading %s to bucket %s: %v", s.key(key), s.bucket, err)
+	}
+
+	return nil
+}
+
+// Get downloads an object from the bucket and writes it to w.
+func (s *S3Store) Get(ctx context.Context, key string, w io.Writer) error {
+	out, err := s.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key(key)),
+	})
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) && awsErr.Code() == s3.ErrCodeNoSuchKey {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("downloading %s from bucket %s: %v", s.key(key), s.bucket, err)
+	}
+	defer out.Body.Close()
+
+	if _, err = io.Copy(w, out.Body); err != nil {
+		return fmt.Errorf("downloading %s from bucket %s: %v", s.key(key), s.bucket, err)
+	}
+
+	return nil
+}
+
+func (s *S3Store) key(key string) string {
+	return path.Join(s.prefix, key)
+}
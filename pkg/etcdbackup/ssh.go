@@ -0,0 +1,119 @@
+package etcdbackup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+const (
+	sshPort        = "22"
+	sshDialTimeout = 30 * time.Second
+)
+
+// RemoteClient runs commands on the cluster machines.
+type RemoteClient interface {
+	// Run executes command in host as user. stdin and stdout are optional.
+	Run(ctx context.Context, user, host, command string, stdin io.Reader, stdout io.Writer) error
+}
+
+// SSHClient is a RemoteClient that authenticates with a private key.
+type SSHClient struct {
+	signer          ssh.Signer
+	hostKeyCallback ssh.HostKeyCallback
+}
+
+// NewSSHClient returns an SSHClient that uses the private key in privateKeyPath and verifies
+// the host keys of the machines against the known_hosts file in knownHostsPath.
+func NewSSHClient(privateKeyPath, knownHostsPath string) (*SSHClient, error) {
+	signer, err := readSigner(privateKeyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	hostKeyCallback, err := knownhosts.New(knownHostsPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading ssh known hosts: %v", err)
+	}
+
+	return &SSHClient{signer: signer, hostKeyCallback: hostKeyCallback}, nil
+}
+
+// NewInsecureSSHClient returns an SSHClient that uses the private key in privateKeyPath and accepts
+// any host key. Machines created by EKS-A generate new host keys that are not published anywhere,
+// so this is only meant for networks where the machine addresses can't be spoofed, since the snapshots
+// contain all the cluster secrets.
+func NewInsecureSSHClient(privateKeyPath string) (*SSHClient, error) {
+	signer, err := readSigner(privateKeyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SSHClient{signer: signer, hostKeyCallback: ssh.InsecureIgnoreHostKey()}, nil //nolint:gosec
+}
+
+func readSigner(privateKeyPath string) (ssh.Signer, error) {
+	key, err := os.ReadFile(privateKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading ssh private key: %v", err)
+	}
+
+	signer, err := ssh.ParsePrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("parsing ssh private key %s: %v", privateKeyPath, err)
+	}
+
+	return signer, nil
+}
+
+// Run opens a new SSH connection to host and executes command.
+func (c *SSHClient) Run(ctx context.Context, user, host, command string, stdin io.Reader, stdout io.Writer) error {
+	config := &ssh.ClientConfig{
+		User:            user,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(c.signer)},
+		HostKeyCallback: c.hostKeyCallback,
+		Timeout:         sshDialTimeout,
+	}
+
+	address := net.JoinHostPort(host, sshPort)
+	client, err := ssh.Dial("tcp", address, config)
+	if err != nil {
+		return fmt.Errorf("connecting to %s: %v", address, err)
+	}
+	defer client.Close()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return fmt.Errorf("opening ssh session in %s: %v", host, err)
+	}
+	defer session.Close()
+
+	stderr := &bytes.Buffer{}
+	session.Stdin = stdin
+	session.Stdout = stdout
+	session.Stderr = stderr
+
+	done := make(chan error, 1)
+	go func() { done <- session.Run(command) }()
+
+	select {
+	case <-ctx.Done():
+		client.Close()
+		return ctx.Err()
+	case err = <-done:
+	}
+
+	if err != nil {
+		return fmt.Errorf("running command in %s: %v: %s", host, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
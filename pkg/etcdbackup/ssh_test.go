@@ -0,0 +1,55 @@
+package etcdbackup_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+)
+
+func writePrivateKey(t *testing.T) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "id_ecdsa")
+	if err = os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestNewSSHClientKnownHosts(t *testing.T) {
+	g := NewWithT(t)
+	knownHosts := filepath.Join(t.TempDir(), "known_hosts")
+	g.Expect(os.WriteFile(knownHosts, nil, 0o600)).To(Succeed())
+
+	_, err := etcdbackup.NewSSHClient(writePrivateKey(t), knownHosts)
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestNewSSHClientMissingKnownHosts(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := etcdbackup.NewSSHClient(writePrivateKey(t), filepath.Join(t.TempDir(), "known_hosts"))
+	g.Expect(err).To(MatchError(ContainSubstring("reading ssh known hosts")))
+}
+
+func TestNewInsecureSSHClient(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := etcdbackup.NewInsecureSSHClient(writePrivateKey(t))
+	g.Expect(err).NotTo(HaveOccurred())
+}
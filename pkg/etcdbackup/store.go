@@ -0,0 +1,71 @@
+package etcdbackup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// ErrNotFound is returned by a Store when the requested object doesn't exist.
+var ErrNotFound = errors.New("object not found")
+
+// Store saves and retrieves etcd snapshots and their metadata.
+type Store interface {
+	// Put saves the content of r with the given key.
+	Put(ctx context.Context, key string, r io.Reader) error
+	// Get writes the object stored with the given key to w.
+	// It returns ErrNotFound if there is no object with that key.
+	Get(ctx context.Context, key string, w io.Writer) error
+}
+
+// LocalStore is a Store backed by a local directory.
+type LocalStore struct {
+	dir string
+}
+
+// NewLocalStore returns a LocalStore that saves objects in dir.
+func NewLocalStore(dir string) *LocalStore {
+	return &LocalStore{dir: dir}
+}
+
+// Put writes the content of r to the file key in the store directory, creating the directory if needed.
+func (s *LocalStore) Put(_ context.Context, key string, r io.Reader) error {
+	if err := os.MkdirAll(s.dir, os.ModePerm); err != nil {
+		return fmt.Errorf("creating backup directory %s: %v", s.dir, err)
+	}
+
+	path := filepath.Join(s.dir, key)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("creating file %s: %v", path, err)
+	}
+	defer f.Close()
+
+	if _, err = io.Copy(f, r); err != nil {
+		return fmt.Errorf("writing file %s: %v", path, err)
+	}
+
+	return nil
+}
+
+// Get copies the file key in the store directory to w.
+func (s *LocalStore) Get(_ context.Context, key string, w io.Writer) error {
+	path := filepath.Join(s.dir, key)
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("opening file %s: %v", path, err)
+	}
+	defer f.Close()
+
+	if _, err = io.Copy(w, f); err != nil {
+		return fmt.Errorf("reading file %s: %v", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package etcdbackup_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+)
+
+func TestLocalStorePutGet(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	store := etcdbackup.NewLocalStore(t.TempDir() + "/backups")
+
+	g.Expect(store.Put(ctx, "snapshot.db", strings.NewReader("data"))).To(Succeed())
+
+	b := &bytes.Buffer{}
+	g.Expect(store.Get(ctx, "snapshot.db", b)).To(Succeed())
+	g.Expect(b.String()).To(Equal("data"))
+}
+
+func TestLocalStoreGetNotFound(t *testing.T) {
+	g := NewWithT(t)
+	store := etcdbackup.NewLocalStore(t.TempDir())
+
+	g.Expect(store.Get(context.Background(), "snapshot.db", &bytes.Buffer{})).To(MatchError(etcdbackup.ErrNotFound))
+}
+
+// fakeS3 is a minimal S3-compatible server, standing in for MinIO, that supports
+// path-style PutObject and GetObject.
+type fakeS3 struct {
+	sync.Mutex
+	objects map[string][]byte
+}
+
+func (s *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		s.objects[r.URL.Path] = body
+		w.Header().Set("ETag", `"etag"`)
+	case http.MethodGet:
+		body, ok := s.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchKey</Code><Message>not found</Message></Error>`))
+			return
+		}
+		_, _ = w.Write(body)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func newS3Store(t *testing.T) (*etcdbackup.S3Store, *fakeS3) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "minio")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "minio123")
+	fake := &fakeS3{objects: map[string][]byte{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	store, err := etcdbackup.NewS3Store(etcdbackup.S3Config{
+		Bucket:   "backups",
+		Prefix:   "etcd",
+		Region:   "us-east-1",
+		Endpoint: server.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return store, fake
+}
+
+func TestS3StorePutGet(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	store, fake := newS3Store(t)
+
+	g.Expect(store.Put(ctx, "snapshot.db", strings.NewReader("data"))).To(Succeed())
+	g.Expect(fake.objects).To(HaveKeyWithValue("/backups/etcd/snapshot.db", []byte("data")))
+
+	b := &bytes.Buffer{}
+	g.Expect(store.Get(ctx, "snapshot.db", b)).To(Succeed())
+	g.Expect(b.String()).To(Equal("data"))
+}
+
+func TestS3StoreGetNotFound(t *testing.T) {
+	g := NewWithT(t)
+	store, _ := newS3Store(t)
+
+	g.Expect(store.Get(context.Background(), "snapshot.db", &bytes.Buffer{})).To(MatchError(etcdbackup.ErrNotFound))
+}
+
+func TestNewS3StoreNoBucket(t *testing.T) {
+	g := NewWithT(t)
+	_, err := etcdbackup.NewS3Store(etcdbackup.S3Config{})
+	g.Expect(err).To(MatchError("s3 bucket is required"))
+}
@@ -198,6 +198,29 @@ func (c *Clusterctl) MoveManagement(ctx context.Context, from, to *types.Cluster
 	return err
 }
 
+// BackupManagement saves the CAPI objects of a management cluster to a local directory.
+func (c *Clusterctl) BackupManagement(ctx context.Context, cluster *types.Cluster, managementStatePath string) error {
+	params := []string{"move", "--to-directory", managementStatePath, "--namespace", constants.EksaSystemNamespace}
+	if cluster.KubeconfigFile != "" {
+		params = append(params, "--kubeconfig", cluster.KubeconfigFile)
+	}
+
+	if _, err := c.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("failed taking backup of CAPI objects: %v", err)
+	}
+	return nil
+}
+
+// RestoreManagement creates in a management cluster the CAPI objects saved to a local directory by BackupManagement.
+func (c *Clusterctl) RestoreManagement(ctx context.Context, cluster *types.Cluster, managementStatePath string) error {
+	params := []string{"move", "--from-directory", managementStatePath, "--to-kubeconfig", cluster.KubeconfigFile}
+
+	if _, err := c.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("failed restoring CAPI objects from backup: %v", err)
+	}
+	return nil
+}
+
 func (c *Clusterctl) GetWorkloadKubeconfig(ctx context.Context, clusterName string, cluster *types.Cluster) ([]byte, error) {
 	stdOut, err := c.Execute(
 		ctx, "get", "kubeconfig", clusterName,
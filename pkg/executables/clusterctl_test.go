@@ -322,6 +322,42 @@ func TestClusterctlMoveManagementWithRetry(t *testing.T) {
 	}
 }
 
+func TestClusterctlBackupManagement(t *testing.T) {
+	tt := newClusterctlTest(t)
+	managementStatePath := "cluster-state-backup"
+
+	tt.e.EXPECT().Execute(tt.ctx, "move", "--to-directory", managementStatePath, "--namespace", constants.EksaSystemNamespace, "--kubeconfig", tt.cluster.KubeconfigFile)
+
+	tt.Expect(tt.clusterctl.BackupManagement(tt.ctx, tt.cluster, managementStatePath)).To(Succeed())
+}
+
+func TestClusterctlBackupManagementError(t *testing.T) {
+	tt := newClusterctlTest(t)
+	managementStatePath := "cluster-state-backup"
+
+	tt.e.EXPECT().Execute(tt.ctx, "move", "--to-directory", managementStatePath, "--namespace", constants.EksaSystemNamespace, "--kubeconfig", tt.cluster.KubeconfigFile).Return(bytes.Buffer{}, errors.New("error in backup"))
+
+	tt.Expect(tt.clusterctl.BackupManagement(tt.ctx, tt.cluster, managementStatePath)).To(MatchError(ContainSubstring("failed taking backup of CAPI objects")))
+}
+
+func TestClusterctlRestoreManagement(t *testing.T) {
+	tt := newClusterctlTest(t)
+	managementStatePath := "cluster-state-backup"
+
+	tt.e.EXPECT().Execute(tt.ctx, "move", "--from-directory", managementStatePath, "--to-kubeconfig", tt.cluster.KubeconfigFile)
+
+	tt.Expect(tt.clusterctl.RestoreManagement(tt.ctx, tt.cluster, managementStatePath)).To(Succeed())
+}
+
+func TestClusterctlRestoreManagementError(t *testing.T) {
+	tt := newClusterctlTest(t)
+	managementStatePath := "cluster-state-backup"
+
+	tt.e.EXPECT().Execute(tt.ctx, "move", "--from-directory", managementStatePath, "--to-kubeconfig", tt.cluster.KubeconfigFile).Return(bytes.Buffer{}, errors.New("error in restore"))
+
+	tt.Expect(tt.clusterctl.RestoreManagement(tt.ctx, tt.cluster, managementStatePath)).To(MatchError(ContainSubstring("failed restoring CAPI objects from backup")))
+}
+
 func TestClusterctlUpgradeAllProvidersSucess(t *testing.T) {
 	tt := newClusterctlTest(t)
 
@@ -27,6 +27,7 @@ var redactedEnvKeys = []string{
 	decoder.CloudStackCloudConfigB64SecretKey,
 	eksaGithubTokenEnv,
 	githubTokenEnv,
+	config.EksaGitPasswordEnv,
 	config.EksaAccessKeyIdEnv,
 	config.EksaSecretAccessKeyEnv,
 	config.AwsAccessKeyIdEnv,
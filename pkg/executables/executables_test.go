@@ -3,6 +3,7 @@ package executables_test
 import (
 	"testing"
 
+	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/executables"
 )
@@ -17,3 +18,14 @@ func TestRedactCreds(t *testing.T) {
 		t.Fatalf("executables.RedactCreds expected = %s, got = %s", expected, redactedStr)
 	}
 }
+
+func TestRedactCredsGitPassword(t *testing.T) {
+	str := "flux bootstrap git --username jane --token-auth with password git-pass"
+	envMap := map[string]string{config.EksaGitPasswordEnv: "git-pass"}
+	expected := "flux bootstrap git --username jane --token-auth with password *****"
+
+	redactedStr := executables.RedactCreds(str, envMap)
+	if redactedStr != expected {
+		t.Fatalf("executables.RedactCreds expected = %s, got = %s", expected, redactedStr)
+	}
+}
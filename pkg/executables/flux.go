@@ -6,7 +6,9 @@ import (
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/git/providers/bitbucketserver"
 	"github.com/aws/eks-anywhere/pkg/git/providers/github"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitlab"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
 
@@ -64,11 +66,84 @@ func (f *Flux) BootstrapGithub(ctx context.Context, cluster *types.Cluster, flux
 	return err
 }
 
+// BootstrapGitlab creates the GitLab project if it doesn’t exist, and commits the toolkit
+// components manifests to the main branch. Then it configures the target cluster to synchronize with the repository.
+// If the toolkit components are present on the cluster, the bootstrap command will perform an upgrade if needed.
+func (f *Flux) BootstrapGitlab(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig, cliConfig *config.CliConfig) error {
+	c := fluxConfig.Spec
+	params := []string{
+		"bootstrap",
+		gitlab.GitProviderName,
+		"--repository", c.Gitlab.Repository,
+		"--owner", c.Gitlab.Owner,
+		"--hostname", c.Gitlab.Hostname,
+		"--path", c.ClusterConfigPath,
+		"--ssh-key-algorithm", defaultPrivateKeyAlgorithm,
+	}
+	params = setUpCommonParamsBootstrap(cluster, fluxConfig, params)
+
+	if c.Gitlab.Personal {
+		params = append(params, "--personal")
+	}
+	params = appendCaFileParam(cliConfig, params)
+
+	token, err := gitlab.GetGitlabAccessTokenFromEnv()
+	if err != nil {
+		return fmt.Errorf("setting token env: %v", err)
+	}
+
+	env := map[string]string{gitlab.GitlabTokenEnv: token}
+	if _, err = f.ExecuteWithEnv(ctx, env, params...); err != nil {
+		return fmt.Errorf("executing flux bootstrap gitlab: %v", err)
+	}
+
+	return nil
+}
+
+// BootstrapBitbucketServer creates the Bitbucket Server repository if it doesn’t exist, and commits the toolkit
+// components manifests to the main branch. Then it configures the target cluster to synchronize with the repository.
+// If the toolkit components are present on the cluster, the bootstrap command will perform an upgrade if needed.
+func (f *Flux) BootstrapBitbucketServer(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig, cliConfig *config.CliConfig) error {
+	c := fluxConfig.Spec
+	auth, err := bitbucketserver.GetBitbucketAuthFromEnv()
+	if err != nil {
+		return fmt.Errorf("setting token env: %v", err)
+	}
+
+	params := []string{
+		"bootstrap",
+		bitbucketserver.GitProviderName,
+		"--repository", c.BitbucketServer.Repository,
+		"--owner", c.BitbucketServer.Owner,
+		"--hostname", c.BitbucketServer.Hostname,
+		"--username", auth.Username,
+		"--path", c.ClusterConfigPath,
+		"--ssh-key-algorithm", defaultPrivateKeyAlgorithm,
+	}
+	params = setUpCommonParamsBootstrap(cluster, fluxConfig, params)
+
+	if c.BitbucketServer.Personal {
+		params = append(params, "--personal")
+	}
+	params = appendCaFileParam(cliConfig, params)
+
+	env := map[string]string{bitbucketserver.BitbucketTokenEnv: auth.Token}
+	if _, err = f.ExecuteWithEnv(ctx, env, params...); err != nil {
+		return fmt.Errorf("executing flux bootstrap bitbucket-server: %v", err)
+	}
+
+	return nil
+}
+
 // BootstrapGit commits the toolkit components manifests to the branch of a Git repository.
 // It then configures the target cluster to synchronize with the repository. If the toolkit components are present on the cluster, the
 // bootstrap command will perform an upgrade if needed.
 func (f *Flux) BootstrapGit(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig, cliConfig *config.CliConfig) error {
 	c := fluxConfig.Spec
+	if c.Git.IsHTTPS() {
+		return f.bootstrapGitHTTPS(ctx, cluster, fluxConfig, cliConfig)
+	}
+
 	params := []string{
 		"bootstrap",
 		gitProvider,
@@ -98,6 +173,39 @@ func (f *Flux) BootstrapGit(ctx context.Context, cluster *types.Cluster, fluxCon
 	return err
 }
 
+// bootstrapGitHTTPS bootstraps flux with a repository accessed over HTTPS. Flux stores the username,
+// password and CA bundle in the flux system secret used by the source controller.
+func (f *Flux) bootstrapGitHTTPS(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig, cliConfig *config.CliConfig) error {
+	c := fluxConfig.Spec
+	params := []string{
+		"bootstrap",
+		gitProvider,
+		"--url", c.Git.RepositoryUrl,
+		"--path", c.ClusterConfigPath,
+		"--username", cliConfig.GitUsername,
+		"--password", cliConfig.GitPassword,
+		"--token-auth",
+		"--silent",
+	}
+
+	params = setUpCommonParamsBootstrap(cluster, fluxConfig, params)
+	params = appendCaFileParam(cliConfig, params)
+
+	if _, err := f.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("executing flux bootstrap git: %v", err)
+	}
+	return nil
+}
+
+// appendCaFileParam makes flux trust the CA bundle of the git server, if any, and store it in the
+// flux system secret used by the source controller.
+func appendCaFileParam(cliConfig *config.CliConfig, params []string) []string {
+	if cliConfig == nil || cliConfig.GitCaFile == "" {
+		return params
+	}
+	return append(params, "--ca-file", cliConfig.GitCaFile)
+}
+
 func setUpCommonParamsBootstrap(cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig, params []string) []string {
 	c := fluxConfig.Spec
 	if cluster.KubeconfigFile != "" {
@@ -1301,6 +1301,23 @@ func (k *Kubectl) GetSecret(ctx context.Context, secretObjectName string, opts .
 	return response, err
 }
 
+// GetEksaClusters returns the EKS-A clusters selected by opts.
+func (k *Kubectl) GetEksaClusters(ctx context.Context, opts ...KubectlOpt) ([]v1alpha1.Cluster, error) {
+	params := []string{"get", eksaClusterResourceType, "-o", "json"}
+	applyOpts(&params, opts...)
+	stdOut, err := k.Execute(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("getting eksa clusters: %v", err)
+	}
+
+	response := &v1alpha1.ClusterList{}
+	if err = json.Unmarshal(stdOut.Bytes(), response); err != nil {
+		return nil, fmt.Errorf("parsing get eksa clusters response: %v", err)
+	}
+
+	return response.Items, nil
+}
+
 func (k *Kubectl) GetKubeadmControlPlanes(ctx context.Context, opts ...KubectlOpt) ([]controlplanev1.KubeadmControlPlane, error) {
 	params := []string{"get", kubeadmControlPlaneResourceType, "-o", "json"}
 	applyOpts(&params, opts...)
@@ -1424,6 +1441,31 @@ func (k *Kubectl) RemoveAnnotationInNamespace(ctx context.Context, resourceType,
 	return k.RemoveAnnotation(ctx, resourceType, objectName, key, WithCluster(cluster), WithNamespace(namespace))
 }
 
+// MergePatchResource applies a JSON merge patch to a resource in a namespace.
+func (k *Kubectl) MergePatchResource(ctx context.Context, resourceType, name, patch, kubeconfig, namespace string) error {
+	params := []string{"patch", resourceType, name, "--type=merge", "-p", patch, "--kubeconfig", kubeconfig, "--namespace", namespace}
+	if _, err := k.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("patching %s %s: %v", resourceType, name, err)
+	}
+	return nil
+}
+
+// ReplaceResources replaces all the objects of the resource types in every namespace with their current content.
+func (k *Kubectl) ReplaceResources(ctx context.Context, resourceTypes []string, kubeconfig string) error {
+	resources := strings.Join(resourceTypes, ",")
+	params := []string{"get", resources, "--all-namespaces", "-o", "json", "--kubeconfig", kubeconfig}
+	stdOut, err := k.Execute(ctx, params...)
+	if err != nil {
+		return fmt.Errorf("getting %s: %v", resources, err)
+	}
+
+	params = []string{"replace", "-f", "-", "--kubeconfig", kubeconfig}
+	if _, err = k.ExecuteWithStdin(ctx, stdOut.Bytes(), params...); err != nil {
+		return fmt.Errorf("replacing %s: %v", resources, err)
+	}
+	return nil
+}
+
 func (k *Kubectl) GetEksaCluster(ctx context.Context, cluster *types.Cluster, clusterName string) (*v1alpha1.Cluster, error) {
 	params := []string{"get", eksaClusterResourceType, "-A", "-o", "jsonpath={.items[0]}", "--kubeconfig", cluster.KubeconfigFile, "--field-selector=metadata.name=" + clusterName}
 	stdOut, err := k.Execute(ctx, params...)
@@ -1951,6 +1951,52 @@ func TestKubectlRemoveAnnotationInNamespace(t *testing.T) {
 	}
 }
 
+func TestKubectlMergePatchResource(t *testing.T) {
+	tt := newKubectlTest(t)
+	patch := `{"spec":{"rolloutAfter":"2023-01-10T00:00:00Z"}}`
+	tt.e.EXPECT().Execute(tt.ctx,
+		"patch", "kubeadmcontrolplanes.controlplane.cluster.x-k8s.io", "w01", "--type=merge", "-p", patch,
+		"--kubeconfig", tt.kubeconfig, "--namespace", tt.namespace,
+	).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.MergePatchResource(tt.ctx, "kubeadmcontrolplanes.controlplane.cluster.x-k8s.io", "w01", patch, tt.kubeconfig, tt.namespace)).To(Succeed())
+}
+
+func TestKubectlMergePatchResourceError(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(tt.ctx,
+		"patch", "machinedeployments.cluster.x-k8s.io", "w01-md-0", "--type=merge", "-p", "{}",
+		"--kubeconfig", tt.kubeconfig, "--namespace", tt.namespace,
+	).Return(bytes.Buffer{}, errors.New("error in patch"))
+
+	tt.Expect(tt.k.MergePatchResource(tt.ctx, "machinedeployments.cluster.x-k8s.io", "w01-md-0", "{}", tt.kubeconfig, tt.namespace)).
+		To(MatchError(ContainSubstring("patching machinedeployments.cluster.x-k8s.io w01-md-0: error in patch")))
+}
+
+func TestKubectlReplaceResources(t *testing.T) {
+	tt := newKubectlTest(t)
+	objects := []byte(`{"apiVersion":"v1","kind":"List","items":[]}`)
+	tt.e.EXPECT().Execute(tt.ctx,
+		"get", "secrets,configmaps", "--all-namespaces", "-o", "json", "--kubeconfig", tt.kubeconfig,
+	).Return(*bytes.NewBuffer(objects), nil)
+	tt.e.EXPECT().ExecuteWithStdin(tt.ctx, objects, "replace", "-f", "-", "--kubeconfig", tt.kubeconfig).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.ReplaceResources(tt.ctx, []string{"secrets", "configmaps"}, tt.kubeconfig)).To(Succeed())
+}
+
+func TestKubectlReplaceResourcesError(t *testing.T) {
+	tt := newKubectlTest(t)
+	objects := []byte(`{"apiVersion":"v1","kind":"List","items":[]}`)
+	tt.e.EXPECT().Execute(tt.ctx,
+		"get", "secrets", "--all-namespaces", "-o", "json", "--kubeconfig", tt.kubeconfig,
+	).Return(*bytes.NewBuffer(objects), nil)
+	tt.e.EXPECT().ExecuteWithStdin(tt.ctx, objects, "replace", "-f", "-", "--kubeconfig", tt.kubeconfig).
+		Return(bytes.Buffer{}, errors.New("error in replace"))
+
+	tt.Expect(tt.k.ReplaceResources(tt.ctx, []string{"secrets"}, tt.kubeconfig)).
+		To(MatchError(ContainSubstring("replacing secrets: error in replace")))
+}
+
 func TestKubectlGetBundles(t *testing.T) {
 	tt := newKubectlTest(t)
 	wantBundles := test.Bundles(t)
@@ -2630,6 +2676,33 @@ func TestKubectlListObjectsMarshalError(t *testing.T) {
 	tt.Expect(tt.k.ListObjects(tt.ctx, "clusters", tt.namespace, tt.kubeconfig, &v1alpha1.ClusterList{})).To(MatchError(ContainSubstring("parsing get clusters response")))
 }
 
+func TestKubectlGetEksaClusters(t *testing.T) {
+	tt := newKubectlTest(t)
+	list := &v1alpha1.ClusterList{Items: []v1alpha1.Cluster{{ObjectMeta: metav1.ObjectMeta{Name: "workload"}}}}
+	b, err := json.Marshal(list)
+	tt.Expect(err).To(Succeed())
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"get", "clusters.anywhere.eks.amazonaws.com", "-o", "json", "--kubeconfig", tt.kubeconfig, "-A",
+	).Return(*bytes.NewBuffer(b), nil)
+
+	clusters, err := tt.k.GetEksaClusters(tt.ctx, executables.WithKubeconfig(tt.kubeconfig), executables.WithAllNamespaces())
+	tt.Expect(err).To(Succeed())
+	tt.Expect(clusters).To(HaveLen(1))
+	tt.Expect(clusters[0].Name).To(Equal("workload"))
+}
+
+func TestKubectlGetEksaClustersExecError(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"get", "clusters.anywhere.eks.amazonaws.com", "-o", "json", "--kubeconfig", tt.kubeconfig, "--namespace", tt.namespace,
+	).Return(bytes.Buffer{}, errors.New("error"))
+
+	_, err := tt.k.GetEksaClusters(tt.ctx, executables.WithKubeconfig(tt.kubeconfig), executables.WithNamespace(tt.namespace))
+	tt.Expect(err).To(MatchError(ContainSubstring("getting eksa clusters: error")))
+}
+
 func TestKubectlHasResource(t *testing.T) {
 	tt := newKubectlTest(t)
 	pbc := &packagesv1.PackageBundleController{
@@ -2,8 +2,12 @@ package gitfactory
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"io/ioutil"
+	nethttp "net/http"
 	"os"
 	"path"
 	"path/filepath"
@@ -20,7 +24,9 @@ import (
 	"github.com/aws/eks-anywhere/pkg/git"
 	"github.com/aws/eks-anywhere/pkg/git/gitclient"
 	"github.com/aws/eks-anywhere/pkg/git/gogithub"
+	"github.com/aws/eks-anywhere/pkg/git/providers/bitbucketserver"
 	"github.com/aws/eks-anywhere/pkg/git/providers/github"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitlab"
 )
 
 type GitTools struct {
@@ -28,6 +34,8 @@ type GitTools struct {
 	Client              git.Client
 	Writer              filewriter.FileWriter
 	RepositoryDirectory string
+	// CaFile is the path to the CA bundle of the git server, only set when one is provided in the FluxConfig.
+	CaFile string
 }
 
 type GitToolsOpt func(opts *GitTools)
@@ -36,6 +44,7 @@ func Build(ctx context.Context, cluster *v1alpha1.Cluster, fluxConfig *v1alpha1.
 	var repo string
 	var repoUrl string
 	var gitAuth transport.AuthMethod
+	var caBundle []byte
 	var err error
 	var tools GitTools
 
@@ -54,6 +63,49 @@ func Build(ctx context.Context, cluster *v1alpha1.Cluster, fluxConfig *v1alpha1.
 		gitAuth = &http.BasicAuth{Password: githubToken, Username: fluxConfig.Spec.Github.Owner}
 		repo = fluxConfig.Spec.Github.Repository
 		repoUrl = github.RepoUrl(fluxConfig.Spec.Github.Owner, repo)
+	case fluxConfig.Spec.Gitlab != nil:
+		config := fluxConfig.Spec.Gitlab
+		gitlabToken, err := gitlab.GetGitlabAccessTokenFromEnv()
+		if err != nil {
+			return nil, err
+		}
+
+		repo = config.Repository
+		caBundle = []byte(config.CaBundle)
+		httpClient, err := newHTTPClient(caBundle)
+		if err != nil {
+			return nil, err
+		}
+
+		auth := gitlab.Auth(gitlabToken)
+		tools.Provider = gitlab.New(httpClient, gitlab.APIUrl(config.Hostname), config, auth)
+		gitAuth = &http.BasicAuth{Password: auth.Token, Username: auth.Username}
+		repoUrl = gitlab.RepoUrl(config.Hostname, config.Owner, repo)
+	case fluxConfig.Spec.BitbucketServer != nil:
+		config := fluxConfig.Spec.BitbucketServer
+		auth, err := bitbucketserver.GetBitbucketAuthFromEnv()
+		if err != nil {
+			return nil, err
+		}
+
+		repo = config.Repository
+		caBundle = []byte(config.CaBundle)
+		httpClient, err := newHTTPClient(caBundle)
+		if err != nil {
+			return nil, err
+		}
+
+		tools.Provider = bitbucketserver.New(httpClient, bitbucketserver.ServerUrl(config.Hostname), config, auth)
+		gitAuth = &http.BasicAuth{Password: auth.Token, Username: auth.Username}
+		repoUrl = bitbucketserver.RepoUrl(config.Hostname, config.Owner, repo, config.Personal)
+	case fluxConfig.Spec.Git != nil && fluxConfig.Spec.Git.IsHTTPS():
+		gitAuth, err = getBasicAuthFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		repoUrl = fluxConfig.Spec.Git.RepositoryUrl
+		repo = path.Base(strings.TrimSuffix(repoUrl, filepath.Ext(repoUrl)))
+		caBundle = []byte(fluxConfig.Spec.Git.CaBundle)
 	case fluxConfig.Spec.Git != nil:
 		privateKeyFile := os.Getenv(config.EksaGitPrivateKeyTokenEnv)
 		privateKeyPassphrase := os.Getenv(config.EksaGitPassphraseTokenEnv)
@@ -71,13 +123,20 @@ func Build(ctx context.Context, cluster *v1alpha1.Cluster, fluxConfig *v1alpha1.
 		return nil, fmt.Errorf("no valid git provider in FluxConfigSpec. Spec: %v", fluxConfig)
 	}
 
+	if len(caBundle) > 0 {
+		tools.CaFile, err = writer.Write(fmt.Sprintf("%s-ca.pem", repo), caBundle)
+		if err != nil {
+			return nil, fmt.Errorf("writing git server CA bundle: %v", err)
+		}
+	}
+
 	tools.RepositoryDirectory = filepath.Join(cluster.Name, "git", repo)
 	for _, opt := range opts {
 		if opt != nil {
 			opt(&tools)
 		}
 	}
-	tools.Client = buildGitClient(ctx, gitAuth, repoUrl, tools.RepositoryDirectory)
+	tools.Client = buildGitClient(ctx, gitAuth, repoUrl, tools.RepositoryDirectory, caBundle)
 
 	tools.Writer, err = newRepositoryWriter(writer, repo)
 	if err != nil {
@@ -87,16 +146,39 @@ func Build(ctx context.Context, cluster *v1alpha1.Cluster, fluxConfig *v1alpha1.
 	return &tools, nil
 }
 
-func buildGitClient(ctx context.Context, auth transport.AuthMethod, repoUrl string, repo string) *gitclient.GitClient {
+func buildGitClient(ctx context.Context, auth transport.AuthMethod, repoUrl string, repo string, caBundle []byte) *gitclient.GitClient {
 	opts := []gitclient.Opt{
 		gitclient.WithRepositoryUrl(repoUrl),
 		gitclient.WithRepositoryDirectory(repo),
 		gitclient.WithAuth(auth),
 	}
+	if len(caBundle) > 0 {
+		opts = append(opts, gitclient.WithCaBundle(caBundle))
+	}
 
 	return gitclient.New(opts...)
 }
 
+// newHTTPClient returns the client used to call the REST API of a git provider. When caBundle is set, the
+// client trusts its certificates in addition to the system ones, for instances with a private CA.
+func newHTTPClient(caBundle []byte) (*nethttp.Client, error) {
+	if len(caBundle) == 0 {
+		return nethttp.DefaultClient, nil
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(caBundle) {
+		return nil, errors.New("git provider CA bundle doesn't contain any valid PEM encoded certificate")
+	}
+
+	transport := nethttp.DefaultTransport.(*nethttp.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	return &nethttp.Client{Transport: transport}, nil
+}
+
 func buildGithubProvider(ctx context.Context, githubToken string, config *v1alpha1.GithubProviderConfig) (git.ProviderClient, error) {
 	auth := git.TokenAuth{Token: githubToken, Username: config.Owner}
 	gogithubOpts := gogithub.Options{Auth: auth}
@@ -125,6 +207,15 @@ func WithRepositoryDirectory(repoDir string) GitToolsOpt {
 	}
 }
 
+func getBasicAuthFromEnv() (*http.BasicAuth, error) {
+	username := os.Getenv(config.EksaGitUsernameEnv)
+	password := os.Getenv(config.EksaGitPasswordEnv)
+	if username == "" || password == "" {
+		return nil, fmt.Errorf("%s and %s must be set to use the generic git Flux provider with an https repository url", config.EksaGitUsernameEnv, config.EksaGitPasswordEnv)
+	}
+	return &http.BasicAuth{Username: username, Password: password}, nil
+}
+
 func getSshAuthFromPrivateKey(privateKeyFile string, passphrase string) (gogitssh.AuthMethod, error) {
 	signer, err := getSignerFromPrivateKeyFile(privateKeyFile, passphrase)
 	if err != nil {
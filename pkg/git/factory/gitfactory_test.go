@@ -2,14 +2,19 @@ package gitfactory_test
 
 import (
 	"context"
+	"os"
 	"testing"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/crypto"
 	gitFactory "github.com/aws/eks-anywhere/pkg/git/factory"
+	"github.com/aws/eks-anywhere/pkg/git/providers/bitbucketserver"
 	"github.com/aws/eks-anywhere/pkg/git/providers/github"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitlab"
 )
 
 const (
@@ -69,3 +74,132 @@ func setupContext(t *testing.T) {
 	t.Setenv(github.EksaGithubTokenEnv, validPATValue)
 	t.Setenv(github.GithubTokenEnv, validPATValue)
 }
+
+func TestGitFactoryGitlab(t *testing.T) {
+	t.Setenv(gitlab.EksaGitlabTokenEnv, "glpat-token")
+	t.Setenv(gitlab.GitlabTokenEnv, "")
+	cluster := &v1alpha1.Cluster{ObjectMeta: v1.ObjectMeta{Name: "testCluster"}}
+	fluxConfig := &v1alpha1.FluxConfig{
+		Spec: v1alpha1.FluxConfigSpec{
+			Gitlab: &v1alpha1.GitlabProviderConfig{Owner: "fleet/infra", Repository: "testRepo", Hostname: "gitlab.internal"},
+		},
+	}
+	_, w := test.NewWriter(t)
+
+	tools, err := gitFactory.Build(context.Background(), cluster, fluxConfig, w)
+	if err != nil {
+		t.Fatalf("gitfactory.Build returned err, wanted nil. err: %v", err)
+	}
+	if tools.Provider == nil {
+		t.Fatal("gitfactory.Build returned no provider for gitlab")
+	}
+	if tools.RepositoryDirectory != "testCluster/git/testRepo" {
+		t.Errorf("gitfactory.Build repository directory = %s, want testCluster/git/testRepo", tools.RepositoryDirectory)
+	}
+}
+
+func TestGitFactoryGitlabCaBundle(t *testing.T) {
+	t.Setenv(gitlab.EksaGitlabTokenEnv, "glpat-token")
+	t.Setenv(gitlab.GitlabTokenEnv, "")
+	caBundle, _, err := crypto.NewCertificateGenerator().GenerateIamAuthSelfSignCertKeyPair()
+	if err != nil {
+		t.Fatalf("generating CA bundle: %v", err)
+	}
+	cluster := &v1alpha1.Cluster{ObjectMeta: v1.ObjectMeta{Name: "testCluster"}}
+	fluxConfig := &v1alpha1.FluxConfig{
+		Spec: v1alpha1.FluxConfigSpec{
+			Gitlab: &v1alpha1.GitlabProviderConfig{Owner: "fleet/infra", Repository: "testRepo", Hostname: "gitlab.internal", CaBundle: string(caBundle)},
+		},
+	}
+	_, w := test.NewWriter(t)
+
+	tools, err := gitFactory.Build(context.Background(), cluster, fluxConfig, w)
+	if err != nil {
+		t.Fatalf("gitfactory.Build returned err, wanted nil. err: %v", err)
+	}
+	content, err := os.ReadFile(tools.CaFile)
+	if err != nil {
+		t.Fatalf("reading CA file: %v", err)
+	}
+	if string(content) != string(caBundle) {
+		t.Errorf("gitfactory.Build CA file content = %s, want %s", content, caBundle)
+	}
+}
+
+func TestGitFactoryBitbucketServerInvalidCaBundle(t *testing.T) {
+	t.Setenv(bitbucketserver.EksaBitbucketUsernameEnv, "janedoe")
+	t.Setenv(bitbucketserver.EksaBitbucketTokenEnv, validPATValue)
+	cluster := &v1alpha1.Cluster{ObjectMeta: v1.ObjectMeta{Name: "testCluster"}}
+	fluxConfig := &v1alpha1.FluxConfig{
+		Spec: v1alpha1.FluxConfigSpec{
+			BitbucketServer: &v1alpha1.BitbucketServerProviderConfig{Owner: "FLEET", Repository: "testRepo", Hostname: "bitbucket.internal", CaBundle: "not a certificate"},
+		},
+	}
+	_, w := test.NewWriter(t)
+
+	if _, err := gitFactory.Build(context.Background(), cluster, fluxConfig, w); err == nil {
+		t.Fatal("gitfactory.Build returned nil, wanted invalid CA bundle error")
+	}
+}
+
+func TestGitFactoryBitbucketServerMissingCredentials(t *testing.T) {
+	t.Setenv(bitbucketserver.EksaBitbucketUsernameEnv, "")
+	cluster := &v1alpha1.Cluster{ObjectMeta: v1.ObjectMeta{Name: "testCluster"}}
+	fluxConfig := &v1alpha1.FluxConfig{
+		Spec: v1alpha1.FluxConfigSpec{
+			BitbucketServer: &v1alpha1.BitbucketServerProviderConfig{Owner: "FLEET", Repository: "testRepo", Hostname: "bitbucket.internal"},
+		},
+	}
+	_, w := test.NewWriter(t)
+
+	if _, err := gitFactory.Build(context.Background(), cluster, fluxConfig, w); err == nil {
+		t.Fatal("gitfactory.Build returned nil, wanted missing credentials error")
+	}
+}
+
+func TestGitFactoryGitHTTPS(t *testing.T) {
+	t.Setenv(config.EksaGitUsernameEnv, "jane")
+	t.Setenv(config.EksaGitPasswordEnv, "token")
+	caBundle := "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n"
+	cluster := &v1alpha1.Cluster{ObjectMeta: v1.ObjectMeta{Name: "testCluster"}}
+	fluxConfig := &v1alpha1.FluxConfig{
+		Spec: v1alpha1.FluxConfigSpec{
+			Git: &v1alpha1.GitProviderConfig{RepositoryUrl: "https://git.internal/fleet/testRepo.git", CaBundle: caBundle},
+		},
+	}
+	_, w := test.NewWriter(t)
+
+	tools, err := gitFactory.Build(context.Background(), cluster, fluxConfig, w)
+	if err != nil {
+		t.Fatalf("gitfactory.Build returned err, wanted nil. err: %v", err)
+	}
+	if tools.Provider != nil {
+		t.Error("gitfactory.Build returned a provider for generic git, want nil")
+	}
+	if tools.RepositoryDirectory != "testCluster/git/testRepo" {
+		t.Errorf("gitfactory.Build repository directory = %s, want testCluster/git/testRepo", tools.RepositoryDirectory)
+	}
+	content, err := os.ReadFile(tools.CaFile)
+	if err != nil {
+		t.Fatalf("reading CA file: %v", err)
+	}
+	if string(content) != caBundle {
+		t.Errorf("gitfactory.Build CA file content = %s, want %s", content, caBundle)
+	}
+}
+
+func TestGitFactoryGitHTTPSMissingCredentials(t *testing.T) {
+	t.Setenv(config.EksaGitUsernameEnv, "jane")
+	t.Setenv(config.EksaGitPasswordEnv, "")
+	cluster := &v1alpha1.Cluster{ObjectMeta: v1.ObjectMeta{Name: "testCluster"}}
+	fluxConfig := &v1alpha1.FluxConfig{
+		Spec: v1alpha1.FluxConfigSpec{
+			Git: &v1alpha1.GitProviderConfig{RepositoryUrl: "https://git.internal/fleet/testRepo.git"},
+		},
+	}
+	_, w := test.NewWriter(t)
+
+	if _, err := gitFactory.Build(context.Background(), cluster, fluxConfig, w); err == nil {
+		t.Fatal("gitfactory.Build returned nil, wanted missing credentials error")
+	}
+}
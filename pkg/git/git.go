@@ -24,6 +24,8 @@ type ProviderClient interface {
 	AddDeployKeyToRepo(ctx context.Context, opts AddDeployKeyOpts) error
 	Validate(ctx context.Context) error
 	PathExists(ctx context.Context, owner, repo, branch, path string) (bool, error)
+	CreatePullRequest(ctx context.Context, opts CreatePullRequestOpts) (*PullRequest, error)
+	GetPullRequest(ctx context.Context, owner, repo string, number int) (*PullRequest, error)
 }
 
 type CreateRepoOpts struct {
@@ -53,6 +55,28 @@ type AddDeployKeyOpts struct {
 	ReadOnly   bool
 }
 
+type CreatePullRequestOpts struct {
+	Owner      string
+	Repository string
+	Title      string
+	Body       string
+	// Head is the branch with the changes to merge.
+	Head string
+	// Base is the branch the changes are merged into.
+	Base string
+}
+
+// PullRequest is a pull request, or merge request for GitLab, of a repository.
+type PullRequest struct {
+	Number int
+	URL    string
+	Merged bool
+	// Closed is true when the pull request was closed without being merged.
+	Closed bool
+	// MergeCommit is the hash of the commit that merged the pull request, set once it's merged.
+	MergeCommit string
+}
+
 type Repository struct {
 	Name         string
 	Owner        string
@@ -54,6 +54,14 @@ func WithAuth(auth transport.AuthMethod) Opt {
 	}
 }
 
+// WithCaBundle makes the client trust the PEM encoded certificates in caBundle, in addition to the
+// system ones, when connecting to the remote over HTTPS.
+func WithCaBundle(caBundle []byte) Opt {
+	return func(c *GitClient) {
+		c.Client = &goGit{caBundle: caBundle}
+	}
+}
+
 func WithRepositoryUrl(repoUrl string) Opt {
 	return func(c *GitClient) {
 		c.RepoUrl = repoUrl
@@ -333,7 +341,9 @@ type GoGit interface {
 	SetRepositoryReference(r *gogit.Repository, p *plumbing.Reference) error
 }
 
-type goGit struct{}
+type goGit struct {
+	caBundle []byte
+}
 
 func (gg *goGit) Clone(ctx context.Context, dir string, repourl string, auth transport.AuthMethod) (*gogit.Repository, error) {
 	ctx, cancel := context.WithTimeout(ctx, gitTimeout)
@@ -343,6 +353,7 @@ func (gg *goGit) Clone(ctx context.Context, dir string, repourl string, auth tra
 		Auth:     auth,
 		URL:      repourl,
 		Progress: os.Stdout,
+		CABundle: gg.caBundle,
 	})
 }
 
@@ -373,7 +384,8 @@ func (gg *goGit) PushWithContext(ctx context.Context, r *gogit.Repository, auth
 	defer cancel()
 
 	return r.PushContext(ctx, &gogit.PushOptions{
-		Auth: auth,
+		Auth:     auth,
+		CABundle: gg.caBundle,
 	})
 }
 
@@ -381,7 +393,7 @@ func (gg *goGit) PullWithContext(ctx context.Context, w *gogit.Worktree, auth tr
 	ctx, cancel := context.WithTimeout(ctx, gitTimeout)
 	defer cancel()
 
-	return w.PullContext(ctx, &gogit.PullOptions{RemoteName: gogit.DefaultRemoteName, Auth: auth, ReferenceName: ref})
+	return w.PullContext(ctx, &gogit.PullOptions{RemoteName: gogit.DefaultRemoteName, Auth: auth, ReferenceName: ref, CABundle: gg.caBundle})
 }
 
 func (gg *goGit) Head(r *gogit.Repository) (*plumbing.Reference, error) {
@@ -422,7 +434,7 @@ func (gg *goGit) ListRemotes(r *gogit.Repository, auth transport.AuthMethod) ([]
 		}
 		return nil, err
 	}
-	refList, err := remote.List(&gogit.ListOptions{Auth: auth})
+	refList, err := remote.List(&gogit.ListOptions{Auth: auth, CABundle: gg.caBundle})
 	if err != nil {
 		return nil, err
 	}
@@ -434,7 +446,7 @@ func (gg *goGit) Remove(f string, w *gogit.Worktree) (plumbing.Hash, error) {
 }
 
 func (ggc *goGit) ListWithContext(ctx context.Context, r *gogit.Remote, auth transport.AuthMethod) ([]*plumbing.Reference, error) {
-	refList, err := r.ListContext(ctx, &gogit.ListOptions{Auth: auth})
+	refList, err := r.ListContext(ctx, &gogit.ListOptions{Auth: auth, CABundle: ggc.caBundle})
 	if err != nil {
 		return nil, err
 	}
@@ -2,8 +2,12 @@ package gitclient_test
 
 import (
 	"context"
+	"encoding/pem"
 	"fmt"
+	nethttp "net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 
 	goGit "github.com/go-git/go-git/v5"
@@ -346,3 +350,40 @@ func newGoGitMock(t *testing.T) (context.Context, *mockGitClient.MockGoGit) {
 
 	return ctx, client
 }
+
+func TestGoGitValidateRemoteExistsWithCaBundle(t *testing.T) {
+	server := httptest.NewTLSServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		w.WriteHeader(nethttp.StatusUnauthorized)
+	}))
+	defer server.Close()
+	repoUrl := server.URL + "/owner/repo.git"
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	tests := []struct {
+		name       string
+		opts       []gitclient.Opt
+		matchError string
+	}{
+		{
+			name:       "with server ca",
+			opts:       []gitclient.Opt{gitclient.WithCaBundle(caBundle)},
+			matchError: "authentication required",
+		},
+		{
+			name:       "without server ca",
+			matchError: "certificate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]gitclient.Opt{gitclient.WithRepositoryUrl(repoUrl)}, tt.opts...)
+			g := gitclient.New(opts...)
+
+			err := g.ValidateRemoteExists(context.Background())
+			if err == nil || !strings.Contains(err.Error(), tt.matchError) {
+				t.Errorf("ValidateRemoteExists() error = %v, want error containing %q", err, tt.matchError)
+			}
+		})
+	}
+}
@@ -445,6 +445,86 @@ func TestPathExistsItDoesNot(t *testing.T) {
 	tt.Expect(tt.g.PathExists(tt.ctx, owner, repo, branch, path)).To(BeTrue())
 }
 
+func TestCreatePullRequest(t *testing.T) {
+	tt := newTest(t)
+	opts := git.CreatePullRequestOpts{
+		Owner:      "owner1",
+		Repository: "repo1",
+		Title:      "Upgrade",
+		Body:       "details",
+		Head:       "eksa-upgrade",
+		Base:       "main",
+	}
+	tt.client.EXPECT().CreatePullRequest(tt.ctx, opts.Owner, opts.Repository, &github.NewPullRequest{
+		Title: &opts.Title,
+		Body:  &opts.Body,
+		Head:  &opts.Head,
+		Base:  &opts.Base,
+	}).Return(&github.PullRequest{
+		Number:  github.Int(5),
+		HTMLURL: github.String("https://github.com/owner1/repo1/pull/5"),
+		State:   github.String("open"),
+	}, nil, nil)
+
+	tt.Expect(tt.g.CreatePullRequest(tt.ctx, opts)).To(Equal(&git.PullRequest{
+		Number: 5,
+		URL:    "https://github.com/owner1/repo1/pull/5",
+	}))
+}
+
+func TestCreatePullRequestError(t *testing.T) {
+	tt := newTest(t)
+	tt.client.EXPECT().CreatePullRequest(tt.ctx, "owner1", "repo1", gomock.Any()).Return(nil, nil, errors.New("no commits"))
+
+	_, err := tt.g.CreatePullRequest(tt.ctx, git.CreatePullRequestOpts{Owner: "owner1", Repository: "repo1"})
+	tt.Expect(err).To(MatchError(ContainSubstring("creating pull request in repository repo1: no commits")))
+}
+
+func TestGetPullRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		pull *github.PullRequest
+		want *git.PullRequest
+	}{
+		{
+			name: "merged",
+			pull: &github.PullRequest{
+				Number:         github.Int(5),
+				State:          github.String("closed"),
+				Merged:         github.Bool(true),
+				MergeCommitSHA: github.String("abc123"),
+			},
+			want: &git.PullRequest{Number: 5, Merged: true, MergeCommit: "abc123"},
+		},
+		{
+			name: "closed without merging",
+			pull: &github.PullRequest{
+				Number:         github.Int(5),
+				State:          github.String("closed"),
+				MergeCommitSHA: github.String("def456"),
+			},
+			want: &git.PullRequest{Number: 5, Closed: true},
+		},
+		{
+			name: "open",
+			pull: &github.PullRequest{
+				Number:         github.Int(5),
+				State:          github.String("open"),
+				MergeCommitSHA: github.String("def456"),
+			},
+			want: &git.PullRequest{Number: 5},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tt := newTest(t)
+			tt.client.EXPECT().GetPullRequest(tt.ctx, "owner1", "repo1", 5).Return(tc.pull, nil, nil)
+
+			tt.Expect(tt.g.GetPullRequest(tt.ctx, "owner1", "repo1", 5)).To(Equal(tc.want))
+		})
+	}
+}
+
 type gogithubTest struct {
 	*WithT
 	g      *gogithub.GoGithub
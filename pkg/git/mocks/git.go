@@ -198,6 +198,21 @@ func (mr *MockProviderClientMockRecorder) AddDeployKeyToRepo(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDeployKeyToRepo", reflect.TypeOf((*MockProviderClient)(nil).AddDeployKeyToRepo), arg0, arg1)
 }
 
+// CreatePullRequest mocks base method.
+func (m *MockProviderClient) CreatePullRequest(arg0 context.Context, arg1 git.CreatePullRequestOpts) (*git.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePullRequest", arg0, arg1)
+	ret0, _ := ret[0].(*git.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePullRequest indicates an expected call of CreatePullRequest.
+func (mr *MockProviderClientMockRecorder) CreatePullRequest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePullRequest", reflect.TypeOf((*MockProviderClient)(nil).CreatePullRequest), arg0, arg1)
+}
+
 // CreateRepo mocks base method.
 func (m *MockProviderClient) CreateRepo(arg0 context.Context, arg1 git.CreateRepoOpts) (*git.Repository, error) {
 	m.ctrl.T.Helper()
@@ -227,6 +242,21 @@ func (mr *MockProviderClientMockRecorder) DeleteRepo(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRepo", reflect.TypeOf((*MockProviderClient)(nil).DeleteRepo), arg0, arg1)
 }
 
+// GetPullRequest mocks base method.
+func (m *MockProviderClient) GetPullRequest(arg0 context.Context, arg1, arg2 string, arg3 int) (*git.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPullRequest", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*git.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPullRequest indicates an expected call of GetPullRequest.
+func (mr *MockProviderClientMockRecorder) GetPullRequest(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPullRequest", reflect.TypeOf((*MockProviderClient)(nil).GetPullRequest), arg0, arg1, arg2, arg3)
+}
+
 // GetRepo mocks base method.
 func (m *MockProviderClient) GetRepo(arg0 context.Context) (*git.Repository, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,269 @@
+package bitbucketserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/git/providers/internal/rest"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	GitProviderName             = "bitbucket-server"
+	EksaBitbucketTokenEnv       = "EKSA_BITBUCKET_SERVER_TOKEN"
+	EksaBitbucketUsernameEnv    = "EKSA_BITBUCKET_SERVER_USERNAME"
+	BitbucketTokenEnv           = "BITBUCKET_TOKEN"
+	bitbucketUrlTemplate        = "https://%v/scm/%v/%v.git"
+	serverUrlTemplate           = "https://%v"
+	apiPath                     = "/rest/api/1.0"
+	keysPath                    = "/rest/keys/1.0"
+	authenticatedUsernameHeader = "X-AUSERNAME"
+)
+
+type bitbucketServerProvider struct {
+	client *rest.Client
+	config *v1alpha1.BitbucketServerProviderConfig
+}
+
+// New returns a git.ProviderClient for the Bitbucket Server instance at serverURL.
+func New(httpClient rest.HTTPClient, serverURL string, config *v1alpha1.BitbucketServerProviderConfig, auth git.TokenAuth) *bitbucketServerProvider {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+auth.Token)
+	return &bitbucketServerProvider{
+		client: rest.NewClient(httpClient, serverURL, header),
+		config: config,
+	}
+}
+
+type repo struct {
+	Slug    string `json:"slug"`
+	Name    string `json:"name"`
+	Project struct {
+		Key  string `json:"key"`
+		Type string `json:"type"`
+	} `json:"project"`
+	Links struct {
+		Clone []struct {
+			Href string `json:"href"`
+			Name string `json:"name"`
+		} `json:"clone"`
+	} `json:"links"`
+}
+
+type pullRequest struct {
+	ID    int    `json:"id"`
+	State string `json:"state"`
+	Links struct {
+		Self []struct {
+			Href string `json:"href"`
+		} `json:"self"`
+	} `json:"links"`
+	Properties struct {
+		MergeCommit struct {
+			ID string `json:"id"`
+		} `json:"mergeCommit"`
+	} `json:"properties"`
+}
+
+// CreateRepo creates a repository in the project Owner or, if Personal, in the personal project of the user Owner.
+func (b *bitbucketServerProvider) CreateRepo(ctx context.Context, opts git.CreateRepoOpts) (*git.Repository, error) {
+	logger.V(3).Info("Attempting to create new Bitbucket Server repo", "repo", opts.Name, "owner", opts.Owner)
+	body := map[string]interface{}{
+		"name":        opts.Name,
+		"scmId":       "git",
+		"description": opts.Description,
+		"public":      !opts.Privacy,
+	}
+
+	r := &repo{}
+	if _, err := b.client.Do(ctx, http.MethodPost, apiPath+projectPath(opts.Owner, opts.Personal)+"/repos", body, r); err != nil {
+		return nil, fmt.Errorf("failed to create new Bitbucket Server repo %s: %v", opts.Name, err)
+	}
+	logger.V(3).Info("Successfully created new Bitbucket Server repo", "repo", r.Slug, "owner", opts.Owner)
+
+	return repository(r), nil
+}
+
+// GetRepo describes the remote repository in the config. If the repository does not exist, a nil repo is returned.
+func (b *bitbucketServerProvider) GetRepo(ctx context.Context) (*git.Repository, error) {
+	name := b.config.Repository
+	logger.V(3).Info("Describing Bitbucket Server repo", "name", name, "owner", b.config.Owner)
+	r := &repo{}
+	if _, err := b.client.Do(ctx, http.MethodGet, b.repoPath(b.config.Owner, name), nil, r); err != nil {
+		if rest.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unexpected error when describing repository %s: %v", name, err)
+	}
+
+	return repository(r), nil
+}
+
+// AddDeployKeyToRepo registers an access key in a repository.
+func (b *bitbucketServerProvider) AddDeployKeyToRepo(ctx context.Context, opts git.AddDeployKeyOpts) error {
+	permission := "REPO_WRITE"
+	if opts.ReadOnly {
+		permission = "REPO_READ"
+	}
+	body := map[string]interface{}{
+		"key":        map[string]string{"text": opts.Key, "label": opts.Title},
+		"permission": permission,
+	}
+	path := keysPath + projectPath(opts.Owner, b.config.Personal) + "/repos/" + url.PathEscape(strings.ToLower(opts.Repository)) + "/ssh"
+	if _, err := b.client.Do(ctx, http.MethodPost, path, body, nil); err != nil {
+		return fmt.Errorf("adding deploy key to repo: %v", err)
+	}
+	return nil
+}
+
+// Validate checks the access token is valid and has access to the project of the repository.
+func (b *bitbucketServerProvider) Validate(ctx context.Context) error {
+	header, err := b.client.Do(ctx, http.MethodGet, apiPath+projectPath(b.config.Owner, b.config.Personal), nil, nil)
+	if err != nil {
+		return fmt.Errorf("the authenticated Bitbucket Server user doesn't have proper access to project %s: %v", b.config.Owner, err)
+	}
+
+	if b.config.Personal {
+		if user := header.Get(authenticatedUsernameHeader); !strings.EqualFold(user, b.config.Owner) {
+			return fmt.Errorf("the authenticated Bitbucket Server user %s and owner %s specified in the EKS-A gitops spec don't match; confirm access token owner is %s", user, b.config.Owner, b.config.Owner)
+		}
+	}
+	logger.MarkPass("Bitbucket Server access token has access to the repository project")
+
+	return nil
+}
+
+// PathExists checks if a file or directory exists in a branch of a repository.
+func (b *bitbucketServerProvider) PathExists(ctx context.Context, owner, repoName, branch, filePath string) (bool, error) {
+	query := url.Values{"at": {"refs/heads/" + branch}, "limit": {"1"}}.Encode()
+	path := b.repoPath(owner, repoName) + "/browse/" + escapePath(filePath) + "?" + query
+	if _, err := b.client.Do(ctx, http.MethodGet, path, nil, nil); err != nil {
+		if rest.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("checking if path %s exists in repository %s: %v", filePath, repoName, err)
+	}
+	return true, nil
+}
+
+// DeleteRepo deletes a repository.
+func (b *bitbucketServerProvider) DeleteRepo(ctx context.Context, opts git.DeleteRepoOpts) error {
+	if _, err := b.client.Do(ctx, http.MethodDelete, b.repoPath(opts.Owner, opts.Repository), nil, nil); err != nil {
+		return fmt.Errorf("deleting Bitbucket Server repo %s: %v", opts.Repository, err)
+	}
+	return nil
+}
+
+// CreatePullRequest opens a pull request to merge the Head branch into the Base branch of a repository.
+func (b *bitbucketServerProvider) CreatePullRequest(ctx context.Context, opts git.CreatePullRequestOpts) (*git.PullRequest, error) {
+	logger.V(3).Info("Creating Bitbucket Server pull request", "repo", opts.Repository, "owner", opts.Owner, "head", opts.Head, "base", opts.Base)
+	body := map[string]interface{}{
+		"title":       opts.Title,
+		"description": opts.Body,
+		"fromRef":     map[string]string{"id": "refs/heads/" + opts.Head},
+		"toRef":       map[string]string{"id": "refs/heads/" + opts.Base},
+	}
+	pr := &pullRequest{}
+	if _, err := b.client.Do(ctx, http.MethodPost, b.repoPath(opts.Owner, opts.Repository)+"/pull-requests", body, pr); err != nil {
+		return nil, fmt.Errorf("creating pull request in Bitbucket Server repo %s: %v", opts.Repository, err)
+	}
+	return toPullRequest(pr), nil
+}
+
+// GetPullRequest describes a pull request of a repository.
+func (b *bitbucketServerProvider) GetPullRequest(ctx context.Context, owner, repoName string, number int) (*git.PullRequest, error) {
+	pr := &pullRequest{}
+	if _, err := b.client.Do(ctx, http.MethodGet, fmt.Sprintf("%s/pull-requests/%d", b.repoPath(owner, repoName), number), nil, pr); err != nil {
+		return nil, fmt.Errorf("getting pull request %d in Bitbucket Server repo %s: %v", number, repoName, err)
+	}
+	return toPullRequest(pr), nil
+}
+
+// GetBitbucketAuthFromEnv reads the Bitbucket Server user and access token from EKSA_BITBUCKET_SERVER_USERNAME
+// and EKSA_BITBUCKET_SERVER_TOKEN and sets the token in BITBUCKET_TOKEN for flux.
+func GetBitbucketAuthFromEnv() (git.TokenAuth, error) {
+	username, ok := os.LookupEnv(EksaBitbucketUsernameEnv)
+	if !ok || len(username) == 0 {
+		return git.TokenAuth{}, fmt.Errorf("bitbucket server username environment variable %s is invalid; could not get var from environment", EksaBitbucketUsernameEnv)
+	}
+	token, ok := os.LookupEnv(EksaBitbucketTokenEnv)
+	if !ok || len(token) == 0 {
+		return git.TokenAuth{}, fmt.Errorf("bitbucket server access token environment variable %s is invalid; could not get var from environment", EksaBitbucketTokenEnv)
+	}
+	if err := os.Setenv(BitbucketTokenEnv, token); err != nil {
+		return git.TokenAuth{}, fmt.Errorf("unable to set %s: %v", BitbucketTokenEnv, err)
+	}
+	return git.TokenAuth{Username: username, Token: token}, nil
+}
+
+// ServerUrl returns the URL of a Bitbucket Server instance.
+func ServerUrl(hostname string) string {
+	return fmt.Sprintf(serverUrlTemplate, hostname)
+}
+
+// RepoUrl returns the HTTPS clone URL of a Bitbucket Server repository.
+func RepoUrl(hostname, owner, repo string, personal bool) string {
+	return fmt.Sprintf(bitbucketUrlTemplate, hostname, strings.ToLower(projectKey(owner, personal)), strings.ToLower(repo))
+}
+
+func (b *bitbucketServerProvider) repoPath(owner, repoName string) string {
+	return apiPath + projectPath(owner, b.config.Personal) + "/repos/" + url.PathEscape(strings.ToLower(repoName))
+}
+
+// projectKey returns the key of the project of owner. Personal repositories live in the
+// project of the user, whose key is the user name prefixed with '~'.
+func projectKey(owner string, personal bool) string {
+	if personal {
+		return "~" + owner
+	}
+	return owner
+}
+
+func projectPath(owner string, personal bool) string {
+	return "/projects/" + url.PathEscape(projectKey(owner, personal))
+}
+
+func escapePath(p string) string {
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+func repository(r *repo) *git.Repository {
+	repository := &git.Repository{
+		Name:  r.Slug,
+		Owner: r.Project.Key,
+	}
+	if r.Project.Type != "PERSONAL" {
+		repository.Organization = r.Project.Key
+	}
+	for _, l := range r.Links.Clone {
+		if l.Name == "http" {
+			repository.CloneUrl = l.Href
+		}
+	}
+	return repository
+}
+
+func toPullRequest(pr *pullRequest) *git.PullRequest {
+	p := &git.PullRequest{
+		Number: pr.ID,
+		Merged: pr.State == "MERGED",
+		Closed: pr.State == "DECLINED",
+	}
+	if len(pr.Links.Self) > 0 {
+		p.URL = pr.Links.Self[0].Href
+	}
+	if p.Merged {
+		p.MergeCommit = pr.Properties.MergeCommit.ID
+	}
+	return p
+}
@@ -0,0 +1,376 @@
+package bitbucketserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/git/providers/bitbucketserver"
+)
+
+const token = "bitbucket-token"
+
+// fakeBitbucketServer serves the subset of the Bitbucket Server REST API used by the provider.
+type fakeBitbucketServer struct {
+	*httptest.Server
+	username string
+	projects map[string]bool
+	repos    map[string]map[string]interface{}
+	keys     map[string][]map[string]interface{}
+	paths    map[string]bool
+	pulls    []map[string]interface{}
+}
+
+func newFakeBitbucketServer(t *testing.T) *fakeBitbucketServer {
+	f := &fakeBitbucketServer{
+		username: "janedoe",
+		projects: map[string]bool{"FLEET": true, "~janedoe": true},
+		repos:    map[string]map[string]interface{}{},
+		keys:     map[string][]map[string]interface{}{},
+		paths:    map[string]bool{},
+	}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeBitbucketServer) serve(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+token {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	w.Header().Set("X-AUSERNAME", f.username)
+
+	segments := strings.Split(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	// rest/{api,keys}/1.0/projects/{key}[/repos[/{slug}[/{ssh,browse/...}]]]
+	if len(segments) < 5 || segments[0] != "rest" || segments[3] != "projects" || !f.projects[segments[4]] {
+		http.NotFound(w, r)
+		return
+	}
+	project := segments[4]
+	segments = segments[5:]
+
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		writeJSON(w, map[string]string{"key": project})
+	case len(segments) == 1 && segments[0] == "repos" && r.Method == http.MethodPost:
+		body := map[string]interface{}{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		slug := strings.ToLower(body["name"].(string))
+		projectType := "NORMAL"
+		if strings.HasPrefix(project, "~") {
+			projectType = "PERSONAL"
+		}
+		repo := map[string]interface{}{
+			"slug":    slug,
+			"name":    body["name"],
+			"public":  body["public"],
+			"project": map[string]string{"key": project, "type": projectType},
+			"links": map[string]interface{}{"clone": []map[string]string{
+				{"name": "ssh", "href": "ssh://git@bitbucket.internal:7999/" + strings.ToLower(project) + "/" + slug + ".git"},
+				{"name": "http", "href": "https://bitbucket.internal/scm/" + strings.ToLower(project) + "/" + slug + ".git"},
+			}},
+		}
+		f.repos[project+"/"+slug] = repo
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(w, repo)
+	case len(segments) >= 2 && segments[0] == "repos":
+		key := project + "/" + segments[1]
+		repo, ok := f.repos[key]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		f.serveRepo(w, r, key, repo, segments[2:])
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (f *fakeBitbucketServer) serveRepo(w http.ResponseWriter, r *http.Request, key string, repo map[string]interface{}, segments []string) {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		writeJSON(w, repo)
+	case len(segments) == 0 && r.Method == http.MethodDelete:
+		delete(f.repos, key)
+		w.WriteHeader(http.StatusAccepted)
+	case len(segments) == 1 && segments[0] == "ssh" && r.Method == http.MethodPost:
+		body := map[string]interface{}{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.keys[key] = append(f.keys[key], body)
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(w, body)
+	case len(segments) == 1 && segments[0] == "pull-requests" && r.Method == http.MethodPost:
+		body := map[string]interface{}{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.pulls = append(f.pulls, body)
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(w, pullRequest(key, "OPEN"))
+	case len(segments) == 2 && segments[0] == "pull-requests" && segments[1] == "3" && r.Method == http.MethodGet:
+		pr := pullRequest(key, "MERGED")
+		pr["properties"] = map[string]interface{}{"mergeCommit": map[string]string{"id": "abc123"}}
+		writeJSON(w, pr)
+	case len(segments) > 1 && segments[0] == "browse" && r.Method == http.MethodGet:
+		if r.URL.Query().Get("at") != "refs/heads/main" || !f.paths[strings.Join(segments[1:], "/")] {
+			http.NotFound(w, r)
+			return
+		}
+		writeJSON(w, map[string]interface{}{})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func pullRequest(key, state string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":    3,
+		"state": state,
+		"links": map[string]interface{}{"self": []map[string]string{
+			{"href": "https://bitbucket.internal/projects/" + key + "/pull-requests/3"},
+		}},
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func newProvider(f *fakeBitbucketServer, config *v1alpha1.BitbucketServerProviderConfig) git.ProviderClient {
+	return bitbucketserver.New(f.Client(), f.URL, config, git.TokenAuth{Username: "janedoe", Token: token})
+}
+
+func TestCreateRepoProject(t *testing.T) {
+	g := NewWithT(t)
+	f := newFakeBitbucketServer(t)
+	provider := newProvider(f, &v1alpha1.BitbucketServerProviderConfig{Owner: "FLEET", Repository: "Clusters"})
+
+	repo, err := provider.CreateRepo(context.Background(), git.CreateRepoOpts{Name: "Clusters", Owner: "FLEET", Privacy: true})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo).To(Equal(&git.Repository{
+		Name:         "clusters",
+		Owner:        "FLEET",
+		Organization: "FLEET",
+		CloneUrl:     "https://bitbucket.internal/scm/fleet/clusters.git",
+	}))
+	g.Expect(f.repos["FLEET/clusters"]).To(HaveKeyWithValue("public", false))
+}
+
+func TestCreateRepoPersonal(t *testing.T) {
+	g := NewWithT(t)
+	f := newFakeBitbucketServer(t)
+	provider := newProvider(f, &v1alpha1.BitbucketServerProviderConfig{Owner: "janedoe", Repository: "clusters", Personal: true})
+
+	repo, err := provider.CreateRepo(context.Background(), git.CreateRepoOpts{Name: "clusters", Owner: "janedoe", Personal: true})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo.Owner).To(Equal("~janedoe"))
+	g.Expect(repo.Organization).To(BeEmpty())
+}
+
+func TestCreateRepoMissingProject(t *testing.T) {
+	g := NewWithT(t)
+	f := newFakeBitbucketServer(t)
+	provider := newProvider(f, &v1alpha1.BitbucketServerProviderConfig{Owner: "OTHER", Repository: "clusters"})
+
+	_, err := provider.CreateRepo(context.Background(), git.CreateRepoOpts{Name: "clusters", Owner: "OTHER"})
+	g.Expect(err).To(MatchError(ContainSubstring("failed to create new Bitbucket Server repo clusters")))
+}
+
+func TestGetRepo(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f := newFakeBitbucketServer(t)
+	provider := newProvider(f, &v1alpha1.BitbucketServerProviderConfig{Owner: "FLEET", Repository: "clusters"})
+
+	repo, err := provider.GetRepo(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo).To(BeNil())
+
+	_, err = provider.CreateRepo(ctx, git.CreateRepoOpts{Name: "clusters", Owner: "FLEET"})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	repo, err = provider.GetRepo(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo.Name).To(Equal("clusters"))
+}
+
+func TestAddDeployKeyToRepo(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f := newFakeBitbucketServer(t)
+	provider := newProvider(f, &v1alpha1.BitbucketServerProviderConfig{Owner: "FLEET", Repository: "clusters"})
+	_, err := provider.CreateRepo(ctx, git.CreateRepoOpts{Name: "clusters", Owner: "FLEET"})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	err = provider.AddDeployKeyToRepo(ctx, git.AddDeployKeyOpts{Owner: "FLEET", Repository: "clusters", Key: "ssh-ed25519 AAAA", Title: "flux", ReadOnly: true})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(f.keys["FLEET/clusters"]).To(ConsistOf(map[string]interface{}{
+		"key":        map[string]interface{}{"text": "ssh-ed25519 AAAA", "label": "flux"},
+		"permission": "REPO_READ",
+	}))
+
+	err = provider.AddDeployKeyToRepo(ctx, git.AddDeployKeyOpts{Owner: "FLEET", Repository: "missing"})
+	g.Expect(err).To(MatchError(ContainSubstring("adding deploy key to repo")))
+}
+
+func TestPathExists(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f := newFakeBitbucketServer(t)
+	f.paths["clusters/mgmt/eksa-system/eksa-cluster.yaml"] = true
+	provider := newProvider(f, &v1alpha1.BitbucketServerProviderConfig{Owner: "FLEET", Repository: "clusters"})
+	_, err := provider.CreateRepo(ctx, git.CreateRepoOpts{Name: "clusters", Owner: "FLEET"})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	exists, err := provider.PathExists(ctx, "FLEET", "clusters", "main", "clusters/mgmt/eksa-system/eksa-cluster.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(exists).To(BeTrue())
+
+	exists, err = provider.PathExists(ctx, "FLEET", "clusters", "main", "clusters/missing")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(exists).To(BeFalse())
+}
+
+func TestDeleteRepo(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f := newFakeBitbucketServer(t)
+	provider := newProvider(f, &v1alpha1.BitbucketServerProviderConfig{Owner: "FLEET", Repository: "clusters"})
+	_, err := provider.CreateRepo(ctx, git.CreateRepoOpts{Name: "clusters", Owner: "FLEET"})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(provider.DeleteRepo(ctx, git.DeleteRepoOpts{Owner: "FLEET", Repository: "clusters"})).To(Succeed())
+	g.Expect(f.repos).To(BeEmpty())
+}
+
+func TestCreatePullRequest(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f := newFakeBitbucketServer(t)
+	provider := newProvider(f, &v1alpha1.BitbucketServerProviderConfig{Owner: "FLEET", Repository: "clusters"})
+	_, err := provider.CreateRepo(ctx, git.CreateRepoOpts{Name: "clusters", Owner: "FLEET"})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	pr, err := provider.CreatePullRequest(ctx, git.CreatePullRequestOpts{
+		Owner: "FLEET", Repository: "clusters", Title: "Upgrade", Body: "details", Head: "eksa-upgrade", Base: "main",
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(pr).To(Equal(&git.PullRequest{Number: 3, URL: "https://bitbucket.internal/projects/FLEET/clusters/pull-requests/3"}))
+	g.Expect(f.pulls).To(ConsistOf(map[string]interface{}{
+		"title":       "Upgrade",
+		"description": "details",
+		"fromRef":     map[string]interface{}{"id": "refs/heads/eksa-upgrade"},
+		"toRef":       map[string]interface{}{"id": "refs/heads/main"},
+	}))
+
+	_, err = provider.CreatePullRequest(ctx, git.CreatePullRequestOpts{Owner: "FLEET", Repository: "missing"})
+	g.Expect(err).To(MatchError(ContainSubstring("creating pull request in Bitbucket Server repo missing")))
+}
+
+func TestGetPullRequest(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f := newFakeBitbucketServer(t)
+	provider := newProvider(f, &v1alpha1.BitbucketServerProviderConfig{Owner: "FLEET", Repository: "clusters"})
+	_, err := provider.CreateRepo(ctx, git.CreateRepoOpts{Name: "clusters", Owner: "FLEET"})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	pr, err := provider.GetPullRequest(ctx, "FLEET", "clusters", 3)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(pr).To(Equal(&git.PullRequest{
+		Number:      3,
+		URL:         "https://bitbucket.internal/projects/FLEET/clusters/pull-requests/3",
+		Merged:      true,
+		MergeCommit: "abc123",
+	}))
+
+	_, err = provider.GetPullRequest(ctx, "FLEET", "clusters", 4)
+	g.Expect(err).To(MatchError(ContainSubstring("getting pull request 4")))
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *v1alpha1.BitbucketServerProviderConfig
+		token   string
+		wantErr string
+	}{
+		{
+			name:   "project repo",
+			config: &v1alpha1.BitbucketServerProviderConfig{Owner: "FLEET", Repository: "clusters"},
+		},
+		{
+			name:   "personal repo",
+			config: &v1alpha1.BitbucketServerProviderConfig{Owner: "janedoe", Repository: "clusters", Personal: true},
+		},
+		{
+			name:    "project without access",
+			config:  &v1alpha1.BitbucketServerProviderConfig{Owner: "OTHER", Repository: "clusters"},
+			wantErr: "the authenticated Bitbucket Server user doesn't have proper access to project OTHER",
+		},
+		{
+			name:    "invalid token",
+			config:  &v1alpha1.BitbucketServerProviderConfig{Owner: "FLEET", Repository: "clusters"},
+			token:   "invalid",
+			wantErr: "returned 401",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			f := newFakeBitbucketServer(t)
+			auth := git.TokenAuth{Token: token}
+			if tt.token != "" {
+				auth.Token = tt.token
+			}
+			provider := bitbucketserver.New(f.Client(), f.URL, tt.config, auth)
+
+			err := provider.Validate(context.Background())
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidatePersonalRepoOfAnotherUser(t *testing.T) {
+	g := NewWithT(t)
+	f := newFakeBitbucketServer(t)
+	f.projects["~johndoe"] = true
+	provider := newProvider(f, &v1alpha1.BitbucketServerProviderConfig{Owner: "johndoe", Repository: "clusters", Personal: true})
+
+	g.Expect(provider.Validate(context.Background())).To(MatchError(ContainSubstring("the authenticated Bitbucket Server user janedoe and owner johndoe")))
+}
+
+func TestGetBitbucketAuthFromEnv(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(bitbucketserver.EksaBitbucketUsernameEnv, "janedoe")
+	t.Setenv(bitbucketserver.EksaBitbucketTokenEnv, token)
+	t.Setenv(bitbucketserver.BitbucketTokenEnv, "")
+
+	auth, err := bitbucketserver.GetBitbucketAuthFromEnv()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(auth).To(Equal(git.TokenAuth{Username: "janedoe", Token: token}))
+}
+
+func TestGetBitbucketAuthFromEnvMissingUsername(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(bitbucketserver.EksaBitbucketUsernameEnv, "")
+
+	_, err := bitbucketserver.GetBitbucketAuthFromEnv()
+	g.Expect(err).To(MatchError(ContainSubstring(bitbucketserver.EksaBitbucketUsernameEnv)))
+}
+
+func TestRepoUrl(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(bitbucketserver.RepoUrl("bitbucket.internal", "FLEET", "Clusters", false)).To(Equal("https://bitbucket.internal/scm/fleet/clusters.git"))
+	g.Expect(bitbucketserver.RepoUrl("bitbucket.internal", "janedoe", "clusters", true)).To(Equal("https://bitbucket.internal/scm/~janedoe/clusters.git"))
+}
@@ -47,6 +47,8 @@ type GithubClient interface {
 	CheckAccessTokenPermissions(checkPATPermission string, allPermissionScopes string) error
 	PathExists(ctx context.Context, owner, repo, branch, path string) (bool, error)
 	DeleteRepo(ctx context.Context, opts git.DeleteRepoOpts) error
+	CreatePullRequest(ctx context.Context, opts git.CreatePullRequestOpts) (*git.PullRequest, error)
+	GetPullRequest(ctx context.Context, owner, repo string, number int) (*git.PullRequest, error)
 }
 
 func New(githubProviderClient GithubClient, config *v1alpha1.GithubProviderConfig, auth git.TokenAuth) (*githubProvider, error) {
@@ -154,6 +156,16 @@ func (g *githubProvider) DeleteRepo(ctx context.Context, opts git.DeleteRepoOpts
 	return g.githubProviderClient.DeleteRepo(ctx, opts)
 }
 
+// CreatePullRequest opens a pull request in a Github repository.
+func (g *githubProvider) CreatePullRequest(ctx context.Context, opts git.CreatePullRequestOpts) (*git.PullRequest, error) {
+	return g.githubProviderClient.CreatePullRequest(ctx, opts)
+}
+
+// GetPullRequest describes a pull request of a Github repository.
+func (g *githubProvider) GetPullRequest(ctx context.Context, owner, repo string, number int) (*git.PullRequest, error) {
+	return g.githubProviderClient.GetPullRequest(ctx, owner, repo, number)
+}
+
 type GitProviderNotFoundError struct {
 	Provider string
 }
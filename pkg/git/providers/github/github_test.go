@@ -237,3 +237,28 @@ func TestGetNonExistantRepoSucceeds(t *testing.T) {
 		})
 	}
 }
+
+func TestPullRequests(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	githubproviderclient := mocks.NewMockGithubClient(mockCtrl)
+	config := &v1alpha1.GithubProviderConfig{Owner: "Jeff", Repository: "testRepo", Personal: true}
+	githubProvider, err := github.New(githubproviderclient, config, git.TokenAuth{Token: validPATValue, Username: "Jeff"})
+	if err != nil {
+		t.Fatalf("instantiating github provider: %v, wanted nil", err)
+	}
+
+	opts := git.CreatePullRequestOpts{Owner: "Jeff", Repository: "testRepo", Head: "eksa-upgrade", Base: "main"}
+	created := &git.PullRequest{Number: 1, URL: "https://github.com/Jeff/testRepo/pull/1"}
+	merged := &git.PullRequest{Number: 1, URL: "https://github.com/Jeff/testRepo/pull/1", Merged: true, MergeCommit: "abc123"}
+	githubproviderclient.EXPECT().CreatePullRequest(ctx, opts).Return(created, nil)
+	githubproviderclient.EXPECT().GetPullRequest(ctx, "Jeff", "testRepo", 1).Return(merged, nil)
+
+	pr, err := githubProvider.CreatePullRequest(ctx, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, created, pr)
+
+	pr, err = githubProvider.GetPullRequest(ctx, "Jeff", "testRepo", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, merged, pr)
+}
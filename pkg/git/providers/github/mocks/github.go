@@ -79,6 +79,21 @@ func (mr *MockGithubClientMockRecorder) CheckAccessTokenPermissions(arg0, arg1 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckAccessTokenPermissions", reflect.TypeOf((*MockGithubClient)(nil).CheckAccessTokenPermissions), arg0, arg1)
 }
 
+// CreatePullRequest mocks base method.
+func (m *MockGithubClient) CreatePullRequest(arg0 context.Context, arg1 git.CreatePullRequestOpts) (*git.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePullRequest", arg0, arg1)
+	ret0, _ := ret[0].(*git.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePullRequest indicates an expected call of CreatePullRequest.
+func (mr *MockGithubClientMockRecorder) CreatePullRequest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePullRequest", reflect.TypeOf((*MockGithubClient)(nil).CreatePullRequest), arg0, arg1)
+}
+
 // CreateRepo mocks base method.
 func (m *MockGithubClient) CreateRepo(arg0 context.Context, arg1 git.CreateRepoOpts) (*git.Repository, error) {
 	m.ctrl.T.Helper()
@@ -123,6 +138,21 @@ func (mr *MockGithubClientMockRecorder) GetAccessTokenPermissions(arg0 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccessTokenPermissions", reflect.TypeOf((*MockGithubClient)(nil).GetAccessTokenPermissions), arg0)
 }
 
+// GetPullRequest mocks base method.
+func (m *MockGithubClient) GetPullRequest(arg0 context.Context, arg1, arg2 string, arg3 int) (*git.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPullRequest", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*git.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPullRequest indicates an expected call of GetPullRequest.
+func (mr *MockGithubClientMockRecorder) GetPullRequest(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPullRequest", reflect.TypeOf((*MockGithubClient)(nil).GetPullRequest), arg0, arg1, arg2, arg3)
+}
+
 // GetRepo mocks base method.
 func (m *MockGithubClient) GetRepo(arg0 context.Context, arg1 git.GetRepoOpts) (*git.Repository, error) {
 	m.ctrl.T.Helper()
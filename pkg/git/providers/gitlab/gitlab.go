@@ -0,0 +1,290 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/git/providers/internal/rest"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	GitProviderName    = "gitlab"
+	EksaGitlabTokenEnv = "EKSA_GITLAB_TOKEN"
+	GitlabTokenEnv     = "GITLAB_TOKEN"
+	gitlabUrlTemplate  = "https://%v/%v/%v.git"
+	apiUrlTemplate     = "https://%v/api/v4"
+	apiScope           = "api"
+	// gitUsername is the user for git operations over HTTPS authenticated with an access token.
+	gitUsername = "oauth2"
+)
+
+type gitlabProvider struct {
+	client *rest.Client
+	config *v1alpha1.GitlabProviderConfig
+}
+
+// New returns a git.ProviderClient for the GitLab instance serving its REST API v4 at apiURL.
+func New(httpClient rest.HTTPClient, apiURL string, config *v1alpha1.GitlabProviderConfig, auth git.TokenAuth) *gitlabProvider {
+	header := http.Header{}
+	header.Set("PRIVATE-TOKEN", auth.Token)
+	return &gitlabProvider{
+		client: rest.NewClient(httpClient, apiURL, header),
+		config: config,
+	}
+}
+
+type project struct {
+	Name          string `json:"name"`
+	Path          string `json:"path"`
+	HTTPURLToRepo string `json:"http_url_to_repo"`
+	Namespace     struct {
+		Kind     string `json:"kind"`
+		FullPath string `json:"full_path"`
+	} `json:"namespace"`
+}
+
+type namespace struct {
+	ID int `json:"id"`
+}
+
+type user struct {
+	Username string `json:"username"`
+}
+
+type accessToken struct {
+	Scopes []string `json:"scopes"`
+}
+
+type treeEntry struct {
+	Name string `json:"name"`
+}
+
+type mergeRequest struct {
+	IID            int    `json:"iid"`
+	WebURL         string `json:"web_url"`
+	State          string `json:"state"`
+	MergeCommitSHA string `json:"merge_commit_sha"`
+}
+
+// CreateRepo creates a GitLab project. For non personal repositories, the project is created in the group Owner.
+func (g *gitlabProvider) CreateRepo(ctx context.Context, opts git.CreateRepoOpts) (*git.Repository, error) {
+	logger.V(3).Info("Attempting to create new GitLab project", "repo", opts.Name, "owner", opts.Owner)
+	visibility := "public"
+	if opts.Privacy {
+		visibility = "private"
+	}
+	body := map[string]interface{}{
+		"name":                   opts.Name,
+		"path":                   opts.Name,
+		"description":            opts.Description,
+		"visibility":             visibility,
+		"initialize_with_readme": opts.AutoInit,
+	}
+
+	if !opts.Personal {
+		ns := &namespace{}
+		if _, err := g.client.Do(ctx, http.MethodGet, "/namespaces/"+url.PathEscape(opts.Owner), nil, ns); err != nil {
+			return nil, fmt.Errorf("getting GitLab group %s: %v", opts.Owner, err)
+		}
+		body["namespace_id"] = ns.ID
+	}
+
+	p := &project{}
+	if _, err := g.client.Do(ctx, http.MethodPost, "/projects", body, p); err != nil {
+		return nil, fmt.Errorf("failed to create new GitLab project %s: %v", opts.Name, err)
+	}
+	logger.V(3).Info("Successfully created new GitLab project", "repo", p.Path, "owner", opts.Owner)
+
+	return repository(p), nil
+}
+
+// GetRepo describes the remote repository in the config. If the repository does not exist, a nil repo is returned.
+func (g *gitlabProvider) GetRepo(ctx context.Context) (*git.Repository, error) {
+	r := g.config.Repository
+	o := g.config.Owner
+	logger.V(3).Info("Describing GitLab project", "name", r, "owner", o)
+	p := &project{}
+	if _, err := g.client.Do(ctx, http.MethodGet, projectPath(o, r), nil, p); err != nil {
+		if rest.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unexpected error when describing repository %s: %v", r, err)
+	}
+
+	return repository(p), nil
+}
+
+// AddDeployKeyToRepo registers a deploy key in a GitLab project.
+func (g *gitlabProvider) AddDeployKeyToRepo(ctx context.Context, opts git.AddDeployKeyOpts) error {
+	body := map[string]interface{}{
+		"title":    opts.Title,
+		"key":      opts.Key,
+		"can_push": !opts.ReadOnly,
+	}
+	if _, err := g.client.Do(ctx, http.MethodPost, projectPath(opts.Owner, opts.Repository)+"/deploy_keys", body, nil); err != nil {
+		return fmt.Errorf("adding deploy key to repo: %v", err)
+	}
+	return nil
+}
+
+// Validate checks the access token is valid, has the api scope and has access to the owner of the repository.
+func (g *gitlabProvider) Validate(ctx context.Context) error {
+	u := &user{}
+	if _, err := g.client.Do(ctx, http.MethodGet, "/user", nil, u); err != nil {
+		return fmt.Errorf("authenticating with GitLab: %v", err)
+	}
+
+	token := &accessToken{}
+	if _, err := g.client.Do(ctx, http.MethodGet, "/personal_access_tokens/self", nil, token); err != nil {
+		return fmt.Errorf("getting GitLab access token scopes: %v", err)
+	}
+	if !contains(token.Scopes, apiScope) {
+		return fmt.Errorf("GitLab access token doesn't have the required %s scope, it has: %s", apiScope, strings.Join(token.Scopes, ", "))
+	}
+	logger.MarkPass("GitLab access token has the required api scope")
+
+	if g.config.Personal {
+		if !strings.EqualFold(g.config.Owner, u.Username) {
+			return fmt.Errorf("the authenticated GitLab user and owner %s specified in the EKS-A gitops spec don't match; confirm access token owner is %s", g.config.Owner, g.config.Owner)
+		}
+		return nil
+	}
+
+	if _, err := g.client.Do(ctx, http.MethodGet, "/groups/"+url.PathEscape(g.config.Owner), nil, nil); err != nil {
+		return fmt.Errorf("the authenticated GitLab user doesn't have proper access to group %s: %v", g.config.Owner, err)
+	}
+	return nil
+}
+
+// PathExists checks if a file or directory exists in a branch of a GitLab project.
+func (g *gitlabProvider) PathExists(ctx context.Context, owner, repo, branch, filePath string) (bool, error) {
+	project := projectPath(owner, repo)
+	query := url.Values{"ref": {branch}}.Encode()
+	_, err := g.client.Do(ctx, http.MethodHead, project+"/repository/files/"+url.PathEscape(filePath)+"?"+query, nil, nil)
+	if err == nil {
+		return true, nil
+	}
+	if !rest.IsNotFound(err) {
+		return false, fmt.Errorf("checking if path %s exists in repository %s: %v", filePath, repo, err)
+	}
+
+	// Not a file, check if it's a directory by listing it.
+	query = url.Values{"ref": {branch}, "path": {filePath}, "per_page": {"1"}}.Encode()
+	var entries []treeEntry
+	if _, err = g.client.Do(ctx, http.MethodGet, project+"/repository/tree?"+query, nil, &entries); err != nil {
+		if rest.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("checking if path %s exists in repository %s: %v", filePath, repo, err)
+	}
+
+	return len(entries) > 0, nil
+}
+
+// DeleteRepo deletes a GitLab project.
+func (g *gitlabProvider) DeleteRepo(ctx context.Context, opts git.DeleteRepoOpts) error {
+	if _, err := g.client.Do(ctx, http.MethodDelete, projectPath(opts.Owner, opts.Repository), nil, nil); err != nil {
+		return fmt.Errorf("deleting GitLab project %s: %v", opts.Repository, err)
+	}
+	return nil
+}
+
+// CreatePullRequest opens a merge request to merge the Head branch into the Base branch of a GitLab project.
+func (g *gitlabProvider) CreatePullRequest(ctx context.Context, opts git.CreatePullRequestOpts) (*git.PullRequest, error) {
+	logger.V(3).Info("Creating GitLab merge request", "repo", opts.Repository, "owner", opts.Owner, "head", opts.Head, "base", opts.Base)
+	body := map[string]interface{}{
+		"source_branch":        opts.Head,
+		"target_branch":        opts.Base,
+		"title":                opts.Title,
+		"description":          opts.Body,
+		"remove_source_branch": true,
+	}
+	mr := &mergeRequest{}
+	if _, err := g.client.Do(ctx, http.MethodPost, projectPath(opts.Owner, opts.Repository)+"/merge_requests", body, mr); err != nil {
+		return nil, fmt.Errorf("creating merge request in GitLab project %s: %v", opts.Repository, err)
+	}
+	return pullRequest(mr), nil
+}
+
+// GetPullRequest describes a merge request of a GitLab project.
+func (g *gitlabProvider) GetPullRequest(ctx context.Context, owner, repo string, number int) (*git.PullRequest, error) {
+	mr := &mergeRequest{}
+	if _, err := g.client.Do(ctx, http.MethodGet, fmt.Sprintf("%s/merge_requests/%d", projectPath(owner, repo), number), nil, mr); err != nil {
+		return nil, fmt.Errorf("getting merge request %d in GitLab project %s: %v", number, repo, err)
+	}
+	return pullRequest(mr), nil
+}
+
+// GetGitlabAccessTokenFromEnv reads the GitLab access token from EKSA_GITLAB_TOKEN and sets it
+// in GITLAB_TOKEN for flux.
+func GetGitlabAccessTokenFromEnv() (string, error) {
+	token, ok := os.LookupEnv(EksaGitlabTokenEnv)
+	if !ok || len(token) == 0 {
+		return "", fmt.Errorf("gitlab access token environment variable %s is invalid; could not get var from environment", EksaGitlabTokenEnv)
+	}
+	if err := os.Setenv(GitlabTokenEnv, token); err != nil {
+		return "", fmt.Errorf("unable to set %s: %v", GitlabTokenEnv, err)
+	}
+	return token, nil
+}
+
+// APIUrl returns the URL of the REST API of a GitLab instance.
+func APIUrl(hostname string) string {
+	return fmt.Sprintf(apiUrlTemplate, hostname)
+}
+
+// RepoUrl returns the HTTPS clone URL of a GitLab project.
+func RepoUrl(hostname, owner, repo string) string {
+	return fmt.Sprintf(gitlabUrlTemplate, hostname, owner, repo)
+}
+
+// Auth returns the credentials for git operations over HTTPS.
+func Auth(token string) git.TokenAuth {
+	return git.TokenAuth{Username: gitUsername, Token: token}
+}
+
+func projectPath(owner, repo string) string {
+	return "/projects/" + url.PathEscape(path.Join(owner, repo))
+}
+
+func repository(p *project) *git.Repository {
+	r := &git.Repository{
+		Name:     p.Path,
+		Owner:    p.Namespace.FullPath,
+		CloneUrl: p.HTTPURLToRepo,
+	}
+	if p.Namespace.Kind == "group" {
+		r.Organization = p.Namespace.FullPath
+	}
+	return r
+}
+
+func pullRequest(mr *mergeRequest) *git.PullRequest {
+	p := &git.PullRequest{
+		Number: mr.IID,
+		URL:    mr.WebURL,
+		Merged: mr.State == "merged",
+		Closed: mr.State == "closed",
+	}
+	if p.Merged {
+		p.MergeCommit = mr.MergeCommitSHA
+	}
+	return p
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
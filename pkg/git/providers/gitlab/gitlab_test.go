@@ -0,0 +1,380 @@
+package gitlab_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitlab"
+)
+
+const token = "glpat-token"
+
+// fakeGitlab serves the subset of the GitLab REST API v4 used by the provider.
+type fakeGitlab struct {
+	*httptest.Server
+	username string
+	scopes   []string
+	groups   map[string]int
+	projects map[string]map[string]interface{}
+	files    map[string]bool
+	dirs     map[string]bool
+	requests []*http.Request
+	bodies   []map[string]interface{}
+}
+
+func newFakeGitlab(t *testing.T) *fakeGitlab {
+	f := &fakeGitlab{
+		username: "janedoe",
+		scopes:   []string{"api", "read_user"},
+		groups:   map[string]int{"fleet%2Finfra": 42},
+		projects: map[string]map[string]interface{}{},
+		files:    map[string]bool{},
+		dirs:     map[string]bool{},
+	}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeGitlab) serve(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("PRIVATE-TOKEN") != token {
+		http.Error(w, `{"message":"401 Unauthorized"}`, http.StatusUnauthorized)
+		return
+	}
+	f.requests = append(f.requests, r)
+	body := map[string]interface{}{}
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+	}
+	f.bodies = append(f.bodies, body)
+
+	reqPath := r.URL.EscapedPath()
+	switch {
+	case r.Method == http.MethodGet && reqPath == "/api/v4/user":
+		writeJSON(w, map[string]string{"username": f.username})
+	case r.Method == http.MethodGet && reqPath == "/api/v4/personal_access_tokens/self":
+		writeJSON(w, map[string]interface{}{"scopes": f.scopes})
+	case r.Method == http.MethodGet && strings.HasPrefix(reqPath, "/api/v4/groups/"), r.Method == http.MethodGet && strings.HasPrefix(reqPath, "/api/v4/namespaces/"):
+		id, ok := f.groups[path.Base(reqPath)]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		writeJSON(w, map[string]int{"id": id})
+	case r.Method == http.MethodPost && reqPath == "/api/v4/projects":
+		name := body["name"].(string)
+		full := "janedoe"
+		kind := "user"
+		if body["namespace_id"] != nil {
+			full = "fleet/infra"
+			kind = "group"
+		}
+		p := map[string]interface{}{
+			"name":             name,
+			"path":             name,
+			"http_url_to_repo": f.URL + "/" + full + "/" + name + ".git",
+			"namespace":        map[string]string{"kind": kind, "full_path": full},
+		}
+		f.projects[full+"/"+name] = p
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(w, p)
+	default:
+		f.serveProject(w, r, reqPath)
+	}
+}
+
+func (f *fakeGitlab) serveProject(w http.ResponseWriter, r *http.Request, reqPath string) {
+	for id, p := range f.projects {
+		prefix := "/api/v4/projects/" + url.PathEscape(id)
+		if !strings.HasPrefix(reqPath, prefix) {
+			continue
+		}
+		switch rest := reqPath[len(prefix):]; {
+		case rest == "" && r.Method == http.MethodGet:
+			writeJSON(w, p)
+		case rest == "" && r.Method == http.MethodDelete:
+			delete(f.projects, id)
+			w.WriteHeader(http.StatusAccepted)
+		case rest == "/merge_requests" && r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			writeJSON(w, map[string]interface{}{"iid": 7, "web_url": f.URL + "/" + id + "/-/merge_requests/7", "state": "opened"})
+		case rest == "/merge_requests/7" && r.Method == http.MethodGet:
+			writeJSON(w, map[string]interface{}{"iid": 7, "web_url": f.URL + "/" + id + "/-/merge_requests/7", "state": "merged", "merge_commit_sha": "abc123"})
+		case rest == "/deploy_keys" && r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			writeJSON(w, map[string]int{"id": 1})
+		case strings.HasPrefix(rest, "/repository/files/") && r.Method == http.MethodHead:
+			if !f.files[path.Base(rest)] {
+				http.NotFound(w, r)
+			}
+		case rest == "/repository/tree" && r.Method == http.MethodGet:
+			if !f.dirs[r.URL.Query().Get("path")] {
+				http.NotFound(w, r)
+				return
+			}
+			writeJSON(w, []map[string]string{{"name": "kustomization.yaml"}})
+		default:
+			http.NotFound(w, r)
+		}
+		return
+	}
+	http.NotFound(w, r)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func newProvider(f *fakeGitlab, config *v1alpha1.GitlabProviderConfig) git.ProviderClient {
+	return gitlab.New(f.Client(), f.URL+"/api/v4", config, git.TokenAuth{Token: token})
+}
+
+func TestCreateRepoGroup(t *testing.T) {
+	g := NewWithT(t)
+	f := newFakeGitlab(t)
+	provider := newProvider(f, &v1alpha1.GitlabProviderConfig{Owner: "fleet/infra", Repository: "clusters"})
+
+	repo, err := provider.CreateRepo(context.Background(), git.CreateRepoOpts{Name: "clusters", Owner: "fleet/infra", Privacy: true})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo).To(Equal(&git.Repository{
+		Name:         "clusters",
+		Owner:        "fleet/infra",
+		Organization: "fleet/infra",
+		CloneUrl:     f.URL + "/fleet/infra/clusters.git",
+	}))
+	created := f.bodies[len(f.bodies)-1]
+	g.Expect(created).To(HaveKeyWithValue("namespace_id", BeEquivalentTo(42)))
+	g.Expect(created).To(HaveKeyWithValue("visibility", "private"))
+}
+
+func TestCreateRepoPersonal(t *testing.T) {
+	g := NewWithT(t)
+	f := newFakeGitlab(t)
+	provider := newProvider(f, &v1alpha1.GitlabProviderConfig{Owner: "janedoe", Repository: "clusters", Personal: true})
+
+	repo, err := provider.CreateRepo(context.Background(), git.CreateRepoOpts{Name: "clusters", Owner: "janedoe", Personal: true})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo.Owner).To(Equal("janedoe"))
+	g.Expect(repo.Organization).To(BeEmpty())
+	g.Expect(f.bodies[len(f.bodies)-1]).NotTo(HaveKey("namespace_id"))
+}
+
+func TestCreateRepoMissingGroup(t *testing.T) {
+	g := NewWithT(t)
+	f := newFakeGitlab(t)
+	provider := newProvider(f, &v1alpha1.GitlabProviderConfig{Owner: "other", Repository: "clusters"})
+
+	_, err := provider.CreateRepo(context.Background(), git.CreateRepoOpts{Name: "clusters", Owner: "other"})
+	g.Expect(err).To(MatchError(ContainSubstring("getting GitLab group other")))
+}
+
+func TestGetRepo(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f := newFakeGitlab(t)
+	provider := newProvider(f, &v1alpha1.GitlabProviderConfig{Owner: "fleet/infra", Repository: "clusters"})
+
+	repo, err := provider.GetRepo(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo).To(BeNil())
+
+	_, err = provider.CreateRepo(ctx, git.CreateRepoOpts{Name: "clusters", Owner: "fleet/infra"})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	repo, err = provider.GetRepo(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo.Name).To(Equal("clusters"))
+	g.Expect(f.requests[len(f.requests)-1].URL.EscapedPath()).To(Equal("/api/v4/projects/fleet%2Finfra%2Fclusters"))
+}
+
+func TestAddDeployKeyToRepo(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f := newFakeGitlab(t)
+	provider := newProvider(f, &v1alpha1.GitlabProviderConfig{Owner: "fleet/infra", Repository: "clusters"})
+	_, err := provider.CreateRepo(ctx, git.CreateRepoOpts{Name: "clusters", Owner: "fleet/infra"})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	err = provider.AddDeployKeyToRepo(ctx, git.AddDeployKeyOpts{Owner: "fleet/infra", Repository: "clusters", Key: "ssh-ed25519 AAAA", Title: "flux"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(f.bodies[len(f.bodies)-1]).To(Equal(map[string]interface{}{"title": "flux", "key": "ssh-ed25519 AAAA", "can_push": true}))
+
+	err = provider.AddDeployKeyToRepo(ctx, git.AddDeployKeyOpts{Owner: "fleet/infra", Repository: "missing"})
+	g.Expect(err).To(MatchError(ContainSubstring("adding deploy key to repo")))
+}
+
+func TestPathExists(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f := newFakeGitlab(t)
+	f.files["clusters%2Fmgmt%2Feksa-system%2Feksa-cluster.yaml"] = true
+	f.dirs["clusters/mgmt"] = true
+	provider := newProvider(f, &v1alpha1.GitlabProviderConfig{Owner: "fleet/infra", Repository: "clusters"})
+	_, err := provider.CreateRepo(ctx, git.CreateRepoOpts{Name: "clusters", Owner: "fleet/infra"})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	tests := map[string]bool{
+		"clusters/mgmt/eksa-system/eksa-cluster.yaml": true,
+		"clusters/mgmt":    true,
+		"clusters/missing": false,
+	}
+	for p, want := range tests {
+		exists, err := provider.PathExists(ctx, "fleet/infra", "clusters", "main", p)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(exists).To(Equal(want), p)
+	}
+}
+
+func TestDeleteRepo(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f := newFakeGitlab(t)
+	provider := newProvider(f, &v1alpha1.GitlabProviderConfig{Owner: "fleet/infra", Repository: "clusters"})
+	_, err := provider.CreateRepo(ctx, git.CreateRepoOpts{Name: "clusters", Owner: "fleet/infra"})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(provider.DeleteRepo(ctx, git.DeleteRepoOpts{Owner: "fleet/infra", Repository: "clusters"})).To(Succeed())
+	g.Expect(f.projects).To(BeEmpty())
+}
+
+func TestCreatePullRequest(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f := newFakeGitlab(t)
+	provider := newProvider(f, &v1alpha1.GitlabProviderConfig{Owner: "fleet/infra", Repository: "clusters"})
+	_, err := provider.CreateRepo(ctx, git.CreateRepoOpts{Name: "clusters", Owner: "fleet/infra"})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	pr, err := provider.CreatePullRequest(ctx, git.CreatePullRequestOpts{
+		Owner: "fleet/infra", Repository: "clusters", Title: "Upgrade", Body: "details", Head: "eksa-upgrade", Base: "main",
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(pr).To(Equal(&git.PullRequest{Number: 7, URL: f.URL + "/fleet/infra/clusters/-/merge_requests/7"}))
+	g.Expect(f.bodies[len(f.bodies)-1]).To(Equal(map[string]interface{}{
+		"source_branch":        "eksa-upgrade",
+		"target_branch":        "main",
+		"title":                "Upgrade",
+		"description":          "details",
+		"remove_source_branch": true,
+	}))
+
+	_, err = provider.CreatePullRequest(ctx, git.CreatePullRequestOpts{Owner: "fleet/infra", Repository: "missing"})
+	g.Expect(err).To(MatchError(ContainSubstring("creating merge request in GitLab project missing")))
+}
+
+func TestGetPullRequest(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f := newFakeGitlab(t)
+	provider := newProvider(f, &v1alpha1.GitlabProviderConfig{Owner: "fleet/infra", Repository: "clusters"})
+	_, err := provider.CreateRepo(ctx, git.CreateRepoOpts{Name: "clusters", Owner: "fleet/infra"})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	pr, err := provider.GetPullRequest(ctx, "fleet/infra", "clusters", 7)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(pr).To(Equal(&git.PullRequest{
+		Number:      7,
+		URL:         f.URL + "/fleet/infra/clusters/-/merge_requests/7",
+		Merged:      true,
+		MergeCommit: "abc123",
+	}))
+
+	_, err = provider.GetPullRequest(ctx, "fleet/infra", "clusters", 8)
+	g.Expect(err).To(MatchError(ContainSubstring("getting merge request 8")))
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *v1alpha1.GitlabProviderConfig
+		scopes  []string
+		token   string
+		wantErr string
+	}{
+		{
+			name:   "group repo",
+			config: &v1alpha1.GitlabProviderConfig{Owner: "fleet/infra", Repository: "clusters"},
+		},
+		{
+			name:   "personal repo",
+			config: &v1alpha1.GitlabProviderConfig{Owner: "JaneDoe", Repository: "clusters", Personal: true},
+		},
+		{
+			name:    "personal repo of another user",
+			config:  &v1alpha1.GitlabProviderConfig{Owner: "johndoe", Repository: "clusters", Personal: true},
+			wantErr: "the authenticated GitLab user and owner johndoe specified in the EKS-A gitops spec don't match",
+		},
+		{
+			name:    "group without access",
+			config:  &v1alpha1.GitlabProviderConfig{Owner: "other", Repository: "clusters"},
+			wantErr: "the authenticated GitLab user doesn't have proper access to group other",
+		},
+		{
+			name:    "token without api scope",
+			config:  &v1alpha1.GitlabProviderConfig{Owner: "fleet/infra", Repository: "clusters"},
+			scopes:  []string{"read_repository"},
+			wantErr: "GitLab access token doesn't have the required api scope",
+		},
+		{
+			name:    "invalid token",
+			config:  &v1alpha1.GitlabProviderConfig{Owner: "fleet/infra", Repository: "clusters"},
+			token:   "invalid",
+			wantErr: "authenticating with GitLab",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			f := newFakeGitlab(t)
+			if tt.scopes != nil {
+				f.scopes = tt.scopes
+			}
+			auth := git.TokenAuth{Token: token}
+			if tt.token != "" {
+				auth.Token = tt.token
+			}
+			provider := gitlab.New(f.Client(), f.URL+"/api/v4", tt.config, auth)
+
+			err := provider.Validate(context.Background())
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestGetGitlabAccessTokenFromEnv(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(gitlab.EksaGitlabTokenEnv, token)
+	t.Setenv(gitlab.GitlabTokenEnv, "")
+
+	got, err := gitlab.GetGitlabAccessTokenFromEnv()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got).To(Equal(token))
+}
+
+func TestGetGitlabAccessTokenFromEnvMissing(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(gitlab.EksaGitlabTokenEnv, "")
+
+	_, err := gitlab.GetGitlabAccessTokenFromEnv()
+	g.Expect(err).To(MatchError(ContainSubstring(gitlab.EksaGitlabTokenEnv)))
+}
+
+func TestRepoUrl(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(gitlab.RepoUrl("gitlab.internal", "fleet/infra", "clusters")).To(Equal("https://gitlab.internal/fleet/infra/clusters.git"))
+	g.Expect(gitlab.APIUrl("gitlab.internal")).To(Equal("https://gitlab.internal/api/v4"))
+}
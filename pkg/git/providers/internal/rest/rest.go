@@ -0,0 +1,100 @@
+// Package rest is a minimal JSON client for the REST APIs of the git providers.
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBodySize is the size limit of the response body included in errors.
+const maxErrorBodySize = 512
+
+// HTTPClient sends HTTP requests.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client sends JSON requests to a REST API and decodes its JSON responses.
+type Client struct {
+	httpClient HTTPClient
+	baseURL    string
+	header     http.Header
+}
+
+// NewClient returns a new Client for the API served at baseURL that sets header in all requests.
+func NewClient(httpClient HTTPClient, baseURL string, header http.Header) *Client {
+	return &Client{
+		httpClient: httpClient,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		header:     header,
+	}
+}
+
+// StatusError is returned when the API responds with a non 2xx status code.
+type StatusError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s %s returned %d: %s", e.Method, e.URL, e.StatusCode, e.Body)
+}
+
+// IsNotFound returns true if err is a StatusError with a 404 status code.
+func IsNotFound(err error) bool {
+	var e *StatusError
+	return errors.As(err, &e) && e.StatusCode == http.StatusNotFound
+}
+
+// Do sends a request with body encoded as JSON, if not nil, to path and decodes the response into out, if not nil.
+// path is appended to the base URL as is, so it must be already escaped. It returns the response headers.
+func (c *Client) Do(ctx context.Context, method, path string, body, out interface{}) (http.Header, error) {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("marshalling request body: %v", err)
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	url := c.baseURL + path
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("building request %s %s: %v", method, url, err)
+	}
+	for k, v := range c.header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %v", method, url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return resp.Header, &StatusError{Method: method, URL: url, StatusCode: resp.StatusCode, Body: string(b)}
+	}
+
+	if out != nil {
+		if err = json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return resp.Header, fmt.Errorf("decoding response from %s %s: %v", method, url, err)
+		}
+	}
+
+	return resp.Header, nil
+}
@@ -0,0 +1,193 @@
+package drift
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	apiyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+// Difference is a field of an EKS-A object whose value committed to the gitops repository
+// differs from the value in the cluster.
+type Difference struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	// Field is the path of the field in the object, empty when the object is Missing.
+	Field     string      `json:"field,omitempty"`
+	Committed interface{} `json:"committed,omitempty"`
+	Live      interface{} `json:"live,omitempty"`
+	// Missing is true when the committed object doesn't exist in the cluster.
+	Missing bool `json:"missing,omitempty"`
+}
+
+func (d Difference) String() string {
+	if d.Missing {
+		return fmt.Sprintf("%s %s doesn't exist in the cluster", d.Kind, d.Name)
+	}
+	return fmt.Sprintf("%s %s %s: committed %v, live %v", d.Kind, d.Name, d.Field, d.Committed, d.Live)
+}
+
+// Detect compares the spec of the EKS-A objects committed to the gitops repository with the
+// live objects and returns the fields that differ. Only the fields set in the committed objects
+// are compared: Flux applies the committed objects, so the fields they omit are defaulted or
+// left untouched in the cluster and are not drift.
+func Detect(committed []*unstructured.Unstructured, live *cluster.Config) ([]Difference, error) {
+	liveObjs := map[string]kubernetes.Object{}
+	for _, o := range objects(live) {
+		liveObjs[key(kindOf(o), o.GetName())] = o
+	}
+
+	diffs := []Difference{}
+	for _, c := range committed {
+		kind, name := c.GetKind(), c.GetName()
+		l, ok := liveObjs[key(kind, name)]
+		if !ok {
+			diffs = append(diffs, Difference{Kind: kind, Name: name, Missing: true})
+			continue
+		}
+
+		liveSpec, err := spec(l)
+		if err != nil {
+			return nil, fmt.Errorf("reading live %s %s: %v", kind, name, err)
+		}
+
+		for _, f := range compare("spec", c.Object["spec"], liveSpec) {
+			f.Kind, f.Name = kind, name
+			diffs = append(diffs, f)
+		}
+	}
+
+	sort.SliceStable(diffs, func(i, j int) bool {
+		if diffs[i].Kind != diffs[j].Kind {
+			return diffs[i].Kind < diffs[j].Kind
+		}
+		return diffs[i].Name < diffs[j].Name
+	})
+
+	return diffs, nil
+}
+
+// ParseCommittedConfig parses the EKS-A config of a cluster committed to the gitops repository and
+// returns its EKS-A objects as written in the file. They are kept unstructured so the fields omitted
+// in the file are not compared with their zero value.
+func ParseCommittedConfig(content []byte) ([]*unstructured.Unstructured, error) {
+	if _, err := cluster.ParseConfig(content); err != nil {
+		return nil, err
+	}
+
+	var objs []*unstructured.Unstructured
+	reader := apiyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(content)))
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading yaml document: %v", err)
+		}
+
+		j, err := yaml.YAMLToJSON(doc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid yaml document: %v", err)
+		}
+		if len(bytes.TrimSpace(j)) == 0 || string(bytes.TrimSpace(j)) == "null" {
+			continue
+		}
+
+		o := &unstructured.Unstructured{}
+		if err = o.UnmarshalJSON(j); err != nil {
+			return nil, fmt.Errorf("invalid kubernetes object: %v", err)
+		}
+		if o.GroupVersionKind().Group == v1alpha1.GroupVersion.Group {
+			objs = append(objs, o)
+		}
+	}
+
+	return objs, nil
+}
+
+func objects(c *cluster.Config) []kubernetes.Object {
+	if c == nil || c.Cluster == nil {
+		return nil
+	}
+	return append([]kubernetes.Object{c.Cluster}, c.ChildObjects()...)
+}
+
+// key identifies an object by its kind and name. The namespace is ignored since committed
+// objects can omit it.
+func key(kind, name string) string {
+	return kind + "/" + name
+}
+
+// kindOf returns the kind of an object from its go type, since objects read from the API
+// server don't always have their TypeMeta set.
+func kindOf(o kubernetes.Object) string {
+	return reflect.TypeOf(o).Elem().Name()
+}
+
+func spec(o kubernetes.Object) (interface{}, error) {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(o)
+	if err != nil {
+		return nil, err
+	}
+	return u["spec"], nil
+}
+
+// compare walks the committed value and returns the fields at path whose value differs in live.
+// Lists are compared element by element only when both have the same length, otherwise the whole
+// list is reported.
+func compare(path string, committed, live interface{}) []Difference {
+	switch c := committed.(type) {
+	case map[string]interface{}:
+		if len(c) == 0 {
+			return nil
+		}
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(c))
+		for k := range c {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		var diffs []Difference
+		for _, k := range keys {
+			diffs = append(diffs, compare(path+"."+k, c[k], l[k])...)
+		}
+		return diffs
+	case []interface{}:
+		if len(c) == 0 {
+			return nil
+		}
+		l, ok := live.([]interface{})
+		if !ok || len(l) != len(c) {
+			break
+		}
+		var diffs []Difference
+		for i := range c {
+			diffs = append(diffs, compare(fmt.Sprintf("%s[%d]", path, i), c[i], l[i])...)
+		}
+		return diffs
+	case nil:
+		return nil
+	}
+
+	if reflect.DeepEqual(committed, live) {
+		return nil
+	}
+	return []Difference{{Field: path, Committed: committed, Live: live}}
+}
@@ -0,0 +1,110 @@
+package drift_test
+
+import (
+	"os"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/gitops/drift"
+)
+
+func TestDetectNoDrift(t *testing.T) {
+	g := NewWithT(t)
+	committed := parseCommitted(t)
+	live := parseConfig(t)
+	live.Cluster.Namespace = "default"
+	live.Cluster.Spec.ControlPlaneConfiguration.Labels = map[string]string{"key": "value"}
+
+	diffs, err := drift.Detect(committed, live)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(diffs).To(BeEmpty())
+}
+
+func TestDetectOmittedFieldsNoDrift(t *testing.T) {
+	g := NewWithT(t)
+	committed := parseCommitted(t)
+	live := parseConfig(t)
+	live.Cluster.Spec.WorkerNodeGroupConfigurations[0].UpgradeRolloutStrategy.RollingUpdate.MaxSurge = 1
+	live.FluxConfig.Spec.Github.Personal = true
+
+	diffs, err := drift.Detect(committed, live)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(diffs).To(BeEmpty())
+}
+
+func TestDetectFieldDrift(t *testing.T) {
+	g := NewWithT(t)
+	committed := parseCommitted(t)
+	live := parseConfig(t)
+	live.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr(3)
+	live.Cluster.Spec.KubernetesVersion = "1.24"
+	live.OIDCConfigs["m-docker"].Spec.ClientId = "id13"
+
+	diffs, err := drift.Detect(committed, live)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(diffs).To(Equal([]drift.Difference{
+		{Kind: "Cluster", Name: "m-docker", Field: "spec.kubernetesVersion", Committed: "1.23", Live: "1.24"},
+		{Kind: "Cluster", Name: "m-docker", Field: "spec.workerNodeGroupConfigurations[0].count", Committed: int64(1), Live: int64(3)},
+		{Kind: "OIDCConfig", Name: "m-docker", Field: "spec.clientId", Committed: "id12", Live: "id13"},
+	}))
+}
+
+func TestDetectListLengthDrift(t *testing.T) {
+	g := NewWithT(t)
+	committed := parseCommitted(t)
+	live := parseConfig(t)
+	live.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks = append(live.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks, "192.169.0.0/16")
+
+	diffs, err := drift.Detect(committed, live)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(diffs).To(HaveLen(1))
+	g.Expect(diffs[0].Field).To(Equal("spec.clusterNetwork.pods.cidrBlocks"))
+	g.Expect(diffs[0].Live).To(Equal([]interface{}{"192.168.0.0/16", "192.169.0.0/16"}))
+}
+
+func TestDetectMissingObject(t *testing.T) {
+	g := NewWithT(t)
+	committed := parseCommitted(t)
+	live := parseConfig(t)
+	delete(live.OIDCConfigs, "m-docker")
+
+	diffs, err := drift.Detect(committed, live)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(diffs).To(Equal([]drift.Difference{{Kind: "OIDCConfig", Name: "m-docker", Missing: true}}))
+	g.Expect(diffs[0].String()).To(Equal("OIDCConfig m-docker doesn't exist in the cluster"))
+}
+
+func parseCommitted(t *testing.T) []*unstructured.Unstructured {
+	t.Helper()
+	content, err := os.ReadFile("testdata/cluster.yaml")
+	if err != nil {
+		t.Fatalf("reading cluster config: %v", err)
+	}
+	objs, err := drift.ParseCommittedConfig(content)
+	if err != nil {
+		t.Fatalf("parsing committed cluster config: %v", err)
+	}
+	return objs
+}
+
+func parseConfig(t *testing.T) *cluster.Config {
+	t.Helper()
+	config, err := cluster.ParseConfigFromFile("testdata/cluster.yaml")
+	if err != nil {
+		t.Fatalf("parsing cluster config: %v", err)
+	}
+	return config
+}
+
+func TestParseCommittedConfigInvalid(t *testing.T) {
+	g := NewWithT(t)
+	_, err := drift.ParseCommittedConfig([]byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\n"))
+	g.Expect(err).To(HaveOccurred())
+}
+
+func ptr(i int) *int {
+	return &i
+}
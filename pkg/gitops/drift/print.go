@@ -0,0 +1,105 @@
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Output formats supported by PrintStatus.
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputYAML  = "yaml"
+)
+
+// ValidateOutput returns an error if format is not a supported output format.
+func ValidateOutput(format string) error {
+	switch format {
+	case OutputTable, OutputJSON, OutputYAML:
+		return nil
+	default:
+		return fmt.Errorf("invalid output format [%s], valid options are: %s, %s, %s", format, OutputTable, OutputJSON, OutputYAML)
+	}
+}
+
+// PrintStatus writes the gitops status of a cluster to w in the given format.
+func PrintStatus(w io.Writer, format string, status *Status) error {
+	switch format {
+	case OutputJSON, OutputYAML:
+		return printSerialized(w, format, status)
+	case OutputTable:
+	default:
+		return ValidateOutput(format)
+	}
+
+	t := tabwriter.NewWriter(w, 10, 4, 3, ' ', 0)
+	fmt.Fprintf(t, "Cluster:\t%s\n", status.Cluster)
+	fmt.Fprintf(t, "Config file:\t%s\n", status.ConfigFile)
+	fmt.Fprintf(t, "Source revision:\t%s\n", valueOrUnknown(status.Source))
+	fmt.Fprintf(t, "Last applied revision:\t%s\n", valueOrUnknown(status.LastApplied))
+	if err := t.Flush(); err != nil {
+		return fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	if status.InSync() {
+		_, err := fmt.Fprintln(w, "\nNo drift detected between the committed config and the cluster")
+		return err
+	}
+
+	fmt.Fprintln(w)
+	t = tabwriter.NewWriter(w, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(t, "KIND\tNAME\tFIELD\tCOMMITTED\tLIVE")
+	for _, d := range status.Differences {
+		if d.Missing {
+			fmt.Fprintf(t, "%s\t%s\t-\t-\t<missing>\n", d.Kind, d.Name)
+			continue
+		}
+		fmt.Fprintf(t, "%s\t%s\t%s\t%s\t%s\n", d.Kind, d.Name, d.Field, printValue(d.Committed), printValue(d.Live))
+	}
+	if err := t.Flush(); err != nil {
+		return fmt.Errorf("failed flushing table writer: %v", err)
+	}
+	return nil
+}
+
+func valueOrUnknown(v string) string {
+	if v == "" {
+		return "<unknown>"
+	}
+	return v
+}
+
+func printValue(v interface{}) string {
+	if v == nil {
+		return "<unset>"
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	content, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(content)
+}
+
+func printSerialized(w io.Writer, format string, obj interface{}) error {
+	var content []byte
+	var err error
+	if format == OutputJSON {
+		content, err = json.MarshalIndent(obj, "", "  ")
+		content = append(content, '\n')
+	} else {
+		content, err = yaml.Marshal(obj)
+	}
+	if err != nil {
+		return fmt.Errorf("failed serializing output to %s: %v", format, err)
+	}
+
+	_, err = w.Write(content)
+	return err
+}
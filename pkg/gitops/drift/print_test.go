@@ -0,0 +1,56 @@
+package drift_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/gitops/drift"
+)
+
+func status() *drift.Status {
+	return &drift.Status{
+		Cluster:    "m-docker",
+		ConfigFile: configFile,
+		Revisions:  drift.Revisions{Source: "main/abc"},
+		Differences: []drift.Difference{
+			{Kind: "Cluster", Name: "m-docker", Field: "spec.workerNodeGroupConfigurations[0].count", Committed: int64(1), Live: int64(3)},
+			{Kind: "OIDCConfig", Name: "m-docker", Missing: true},
+		},
+	}
+}
+
+func TestPrintStatusTable(t *testing.T) {
+	g := NewWithT(t)
+	out := &strings.Builder{}
+
+	g.Expect(drift.PrintStatus(out, drift.OutputTable, status())).To(Succeed())
+	g.Expect(out.String()).To(MatchRegexp(`Source revision:\s+main/abc`))
+	g.Expect(out.String()).To(MatchRegexp(`Last applied revision:\s+<unknown>`))
+	g.Expect(out.String()).To(MatchRegexp(`Cluster\s+m-docker\s+spec.workerNodeGroupConfigurations\[0\].count\s+1\s+3`))
+	g.Expect(out.String()).To(MatchRegexp(`OIDCConfig\s+m-docker\s+-\s+-\s+<missing>`))
+}
+
+func TestPrintStatusTableInSync(t *testing.T) {
+	g := NewWithT(t)
+	out := &strings.Builder{}
+
+	g.Expect(drift.PrintStatus(out, drift.OutputTable, &drift.Status{Cluster: "m-docker"})).To(Succeed())
+	g.Expect(out.String()).To(ContainSubstring("No drift detected"))
+}
+
+func TestPrintStatusJSON(t *testing.T) {
+	g := NewWithT(t)
+	out := &strings.Builder{}
+
+	g.Expect(drift.PrintStatus(out, drift.OutputJSON, status())).To(Succeed())
+	g.Expect(out.String()).To(ContainSubstring(`"sourceRevision": "main/abc"`))
+	g.Expect(out.String()).To(ContainSubstring(`"field": "spec.workerNodeGroupConfigurations[0].count"`))
+	g.Expect(out.String()).To(ContainSubstring(`"missing": true`))
+}
+
+func TestPrintStatusInvalidOutput(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(drift.PrintStatus(&strings.Builder{}, "xml", status())).To(MatchError(ContainSubstring("invalid output format [xml]")))
+}
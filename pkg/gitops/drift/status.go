@@ -0,0 +1,117 @@
+package drift
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git/gitclient"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+)
+
+// Resource types of the objects created by flux bootstrap to sync the gitops repository. Both are
+// named after the flux system namespace.
+const (
+	GitRepositoryResourceType = "gitrepositories.source.toolkit.fluxcd.io"
+	KustomizationResourceType = "kustomizations.kustomize.toolkit.fluxcd.io"
+)
+
+// KubectlClient reads objects from the management cluster.
+type KubectlClient interface {
+	GetObject(ctx context.Context, resourceType, name, namespace, kubeconfig string, obj runtime.Object) error
+}
+
+// Revisions are the revisions of the gitops repository known to Flux.
+type Revisions struct {
+	// Source is the last revision of the repository fetched by Flux.
+	Source string `json:"sourceRevision,omitempty"`
+	// LastApplied is the last revision of the repository applied to the cluster by Flux.
+	LastApplied string `json:"lastAppliedRevision,omitempty"`
+}
+
+// Status is the drift between the EKS-A config of a cluster committed to the gitops repository
+// and the objects in the cluster.
+type Status struct {
+	Cluster string `json:"cluster"`
+	// ConfigFile is the path of the cluster config file in the gitops repository.
+	ConfigFile  string `json:"configFile"`
+	Revisions   `json:",inline"`
+	Differences []Difference `json:"differences"`
+}
+
+// InSync returns true if the cluster objects match the committed config.
+func (s *Status) InSync() bool {
+	return len(s.Differences) == 0
+}
+
+// GetRevisions reads the last fetched and applied revisions of the gitops repository from the
+// status of the Flux GitRepository and Kustomization objects.
+func GetRevisions(ctx context.Context, kubectl KubectlClient, kubeconfig string, fluxConfig *v1alpha1.FluxConfig) (Revisions, error) {
+	namespace := fluxConfig.Spec.SystemNamespace
+
+	repo := &unstructured.Unstructured{}
+	if err := kubectl.GetObject(ctx, GitRepositoryResourceType, namespace, namespace, kubeconfig, repo); err != nil {
+		return Revisions{}, fmt.Errorf("getting flux git repository: %v", err)
+	}
+
+	kustomization := &unstructured.Unstructured{}
+	if err := kubectl.GetObject(ctx, KustomizationResourceType, namespace, namespace, kubeconfig, kustomization); err != nil {
+		return Revisions{}, fmt.Errorf("getting flux kustomization: %v", err)
+	}
+
+	source, _, _ := unstructured.NestedString(repo.Object, "status", "artifact", "revision")
+	lastApplied, _, _ := unstructured.NestedString(kustomization.Object, "status", "lastAppliedRevision")
+
+	return Revisions{Source: source, LastApplied: lastApplied}, nil
+}
+
+// GitClient clones the gitops repository.
+type GitClient interface {
+	Clone(ctx context.Context) error
+	Branch(name string) error
+}
+
+// ReadCommittedConfig clones the gitops repository in repoDir and parses the EKS-A objects of a
+// cluster committed to the flux config branch.
+func ReadCommittedConfig(ctx context.Context, git GitClient, repoDir string, fluxConfig *v1alpha1.FluxConfig, clusterName string) ([]*unstructured.Unstructured, error) {
+	if err := git.Clone(ctx); err != nil {
+		return nil, fmt.Errorf("cloning gitops repository: %v", err)
+	}
+
+	if err := git.Branch(fluxConfig.Spec.Branch); err != nil {
+		return nil, fmt.Errorf("checking out gitops repository branch: %v", err)
+	}
+
+	filePath := flux.ClusterConfigFilePath(fluxConfig, clusterName)
+	content, err := os.ReadFile(filepath.Join(repoDir, filePath))
+	if err != nil {
+		return nil, fmt.Errorf("reading committed cluster config: %v", err)
+	}
+
+	objs, err := ParseCommittedConfig(content)
+	if err != nil {
+		return nil, fmt.Errorf("parsing committed cluster config %s: %v", filePath, err)
+	}
+
+	return objs, nil
+}
+
+// NewGitClient returns a GitClient cloning the repository at repoURL in repoDir. caBundle, when set,
+// is trusted in addition to the system certificates to connect to the repository over HTTPS.
+func NewGitClient(repoURL, repoDir string, auth transport.AuthMethod, caBundle []byte) GitClient {
+	opts := []gitclient.Opt{
+		gitclient.WithRepositoryUrl(repoURL),
+		gitclient.WithRepositoryDirectory(repoDir),
+		gitclient.WithAuth(auth),
+	}
+	if len(caBundle) > 0 {
+		opts = append(opts, gitclient.WithCaBundle(caBundle))
+	}
+	return gitclient.New(opts...)
+}
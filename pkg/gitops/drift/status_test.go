@@ -0,0 +1,115 @@
+package drift_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/gitops/drift"
+)
+
+const configFile = "clusters/m-docker/m-docker/eksa-system/eksa-cluster.yaml"
+
+type fakeKubectl struct {
+	objects map[string]map[string]interface{}
+}
+
+func (f *fakeKubectl) GetObject(_ context.Context, resourceType, name, namespace, _ string, obj runtime.Object) error {
+	o, ok := f.objects[resourceType+"/"+namespace+"/"+name]
+	if !ok {
+		return errors.New("not found")
+	}
+	obj.(*unstructured.Unstructured).Object = o
+	return nil
+}
+
+type fakeGit struct {
+	dir    string
+	files  map[string]string
+	branch string
+}
+
+func (f *fakeGit) Clone(_ context.Context) error {
+	for name, content := range f.files {
+		p := filepath.Join(f.dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fakeGit) Branch(name string) error {
+	f.branch = name
+	return nil
+}
+
+func TestGetRevisions(t *testing.T) {
+	g := NewWithT(t)
+	kubectl := &fakeKubectl{objects: map[string]map[string]interface{}{
+		drift.GitRepositoryResourceType + "/flux-system/flux-system": {
+			"status": map[string]interface{}{"artifact": map[string]interface{}{"revision": "main/abc"}},
+		},
+		drift.KustomizationResourceType + "/flux-system/flux-system": {
+			"status": map[string]interface{}{"lastAppliedRevision": "main/def"},
+		},
+	}}
+
+	revisions, err := drift.GetRevisions(context.Background(), kubectl, "kubeconfig", fluxConfig())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(revisions).To(Equal(drift.Revisions{Source: "main/abc", LastApplied: "main/def"}))
+}
+
+func TestGetRevisionsMissingKustomization(t *testing.T) {
+	g := NewWithT(t)
+	kubectl := &fakeKubectl{objects: map[string]map[string]interface{}{
+		drift.GitRepositoryResourceType + "/flux-system/flux-system": {},
+	}}
+
+	_, err := drift.GetRevisions(context.Background(), kubectl, "kubeconfig", fluxConfig())
+	g.Expect(err).To(MatchError(ContainSubstring("getting flux kustomization")))
+}
+
+func TestReadCommittedConfig(t *testing.T) {
+	g := NewWithT(t)
+	content, err := os.ReadFile("testdata/cluster.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+	dir := t.TempDir()
+	git := &fakeGit{dir: dir, files: map[string]string{configFile: string(content)}}
+
+	objs, err := drift.ReadCommittedConfig(context.Background(), git, dir, fluxConfig(), "m-docker")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(git.branch).To(Equal("main"))
+	g.Expect(objs).To(HaveLen(4))
+	g.Expect(objs[0].GetKind()).To(Equal(v1alpha1.ClusterKind))
+	g.Expect(objs[3].GetKind()).To(Equal(v1alpha1.FluxConfigKind))
+}
+
+func TestReadCommittedConfigMissingFile(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+	git := &fakeGit{dir: dir}
+
+	_, err := drift.ReadCommittedConfig(context.Background(), git, dir, fluxConfig(), "m-docker")
+	g.Expect(err).To(MatchError(ContainSubstring("reading committed cluster config")))
+}
+
+func fluxConfig() *v1alpha1.FluxConfig {
+	return &v1alpha1.FluxConfig{
+		Spec: v1alpha1.FluxConfigSpec{
+			Branch:            "main",
+			ClusterConfigPath: "clusters/m-docker",
+			SystemNamespace:   "flux-system",
+		},
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"strconv"
 	"time"
 
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/config"
@@ -17,11 +20,14 @@ const (
 	maxRetries          = 5
 	backOffPeriod       = 5 * time.Second
 	reconcileAnnotation = "kustomize.toolkit.fluxcd.io/reconcile"
+	kustomizationType   = "kustomizations.kustomize.toolkit.fluxcd.io"
 )
 
 // FluxClient is an interface that abstracts the basic commands of flux executable.
 type FluxClient interface {
 	BootstrapGithub(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
+	BootstrapGitlab(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig, cliConfig *config.CliConfig) error
+	BootstrapBitbucketServer(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig, cliConfig *config.CliConfig) error
 	BootstrapGit(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig, cliConfig *config.CliConfig) error
 	Uninstall(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
 	Reconcile(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
@@ -33,6 +39,7 @@ type KubeClient interface {
 	UpdateAnnotation(ctx context.Context, resourceType, objectName string, annotations map[string]string, opts ...executables.KubectlOpt) error
 	RemoveAnnotation(ctx context.Context, resourceType, objectName string, key string, opts ...executables.KubectlOpt) error
 	DeleteSecret(ctx context.Context, managementCluster *types.Cluster, secretName, namespace string) error
+	GetObject(ctx context.Context, resourceType, name, namespace, kubeconfig string, obj runtime.Object) error
 }
 
 type fluxClient struct {
@@ -57,6 +64,22 @@ func (c *fluxClient) BootstrapGithub(ctx context.Context, cluster *types.Cluster
 	)
 }
 
+func (c *fluxClient) BootstrapGitlab(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig, cliConfig *config.CliConfig) error {
+	return c.Retry(
+		func() error {
+			return c.flux.BootstrapGitlab(ctx, cluster, fluxConfig, cliConfig)
+		},
+	)
+}
+
+func (c *fluxClient) BootstrapBitbucketServer(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig, cliConfig *config.CliConfig) error {
+	return c.Retry(
+		func() error {
+			return c.flux.BootstrapBitbucketServer(ctx, cluster, fluxConfig, cliConfig)
+		},
+	)
+}
+
 func (c *fluxClient) BootstrapGit(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig, cliConfig *config.CliConfig) error {
 	return c.Retry(
 		func() error {
@@ -130,3 +153,20 @@ func (c *fluxClient) GetCluster(ctx context.Context, cluster *types.Cluster, clu
 	)
 	return eksaCluster, err
 }
+
+// GetKustomizationRevision returns the last revision of the gitops repository applied by the
+// Flux Kustomization created by bootstrap, which is named after the flux system namespace.
+func (c *fluxClient) GetKustomizationRevision(ctx context.Context, cluster *types.Cluster, namespace string) (string, error) {
+	kustomization := &unstructured.Unstructured{}
+	err := c.Retry(
+		func() error {
+			return c.kube.GetObject(ctx, kustomizationType, namespace, namespace, cluster.KubeconfigFile, kustomization)
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	revision, _, err := unstructured.NestedString(kustomization.Object, "status", "lastAppliedRevision")
+	return revision, err
+}
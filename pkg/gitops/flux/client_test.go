@@ -7,6 +7,8 @@ import (
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
@@ -58,6 +60,38 @@ func TestFluxClientBootstrapGithubError(t *testing.T) {
 	tt.Expect(tt.c.BootstrapGithub(tt.ctx, tt.cluster, tt.fluxConfig)).To(MatchError(ContainSubstring("error in bootstrap github")), "fluxClient.BootstrapGithub() should fail after 5 tries")
 }
 
+func TestFluxClientBootstrapGitlabSuccess(t *testing.T) {
+	tt := newFluxClientTest(t)
+	tt.f.EXPECT().BootstrapGitlab(tt.ctx, tt.cluster, tt.fluxConfig, nil).Return(errors.New("error in bootstrap gitlab")).Times(4)
+	tt.f.EXPECT().BootstrapGitlab(tt.ctx, tt.cluster, tt.fluxConfig, nil).Return(nil).Times(1)
+
+	tt.Expect(tt.c.BootstrapGitlab(tt.ctx, tt.cluster, tt.fluxConfig, nil)).To(Succeed(), "fluxClient.BootstrapGitlab() should succeed with 5 tries")
+}
+
+func TestFluxClientBootstrapGitlabError(t *testing.T) {
+	tt := newFluxClientTest(t)
+	tt.f.EXPECT().BootstrapGitlab(tt.ctx, tt.cluster, tt.fluxConfig, nil).Return(errors.New("error in bootstrap gitlab")).Times(5)
+	tt.f.EXPECT().BootstrapGitlab(tt.ctx, tt.cluster, tt.fluxConfig, nil).Return(nil).AnyTimes()
+
+	tt.Expect(tt.c.BootstrapGitlab(tt.ctx, tt.cluster, tt.fluxConfig, nil)).To(MatchError(ContainSubstring("error in bootstrap gitlab")), "fluxClient.BootstrapGitlab() should fail after 5 tries")
+}
+
+func TestFluxClientBootstrapBitbucketServerSuccess(t *testing.T) {
+	tt := newFluxClientTest(t)
+	tt.f.EXPECT().BootstrapBitbucketServer(tt.ctx, tt.cluster, tt.fluxConfig, nil).Return(errors.New("error in bootstrap bitbucket server")).Times(4)
+	tt.f.EXPECT().BootstrapBitbucketServer(tt.ctx, tt.cluster, tt.fluxConfig, nil).Return(nil).Times(1)
+
+	tt.Expect(tt.c.BootstrapBitbucketServer(tt.ctx, tt.cluster, tt.fluxConfig, nil)).To(Succeed(), "fluxClient.BootstrapBitbucketServer() should succeed with 5 tries")
+}
+
+func TestFluxClientBootstrapBitbucketServerError(t *testing.T) {
+	tt := newFluxClientTest(t)
+	tt.f.EXPECT().BootstrapBitbucketServer(tt.ctx, tt.cluster, tt.fluxConfig, nil).Return(errors.New("error in bootstrap bitbucket server")).Times(5)
+	tt.f.EXPECT().BootstrapBitbucketServer(tt.ctx, tt.cluster, tt.fluxConfig, nil).Return(nil).AnyTimes()
+
+	tt.Expect(tt.c.BootstrapBitbucketServer(tt.ctx, tt.cluster, tt.fluxConfig, nil)).To(MatchError(ContainSubstring("error in bootstrap bitbucket server")), "fluxClient.BootstrapBitbucketServer() should fail after 5 tries")
+}
+
 func TestFluxClientBootstrapGitSuccess(t *testing.T) {
 	tt := newFluxClientTest(t)
 	tt.f.EXPECT().BootstrapGit(tt.ctx, tt.cluster, tt.fluxConfig, nil).Return(errors.New("error in bootstrap git")).Times(4)
@@ -195,3 +229,28 @@ func TestFluxClientGetClusterError(t *testing.T) {
 
 	tt.Expect(err).To(MatchError(ContainSubstring("error in get eksa cluster")), "fluxClient.GetCluster() should fail after 5 tries")
 }
+
+func TestFluxClientGetKustomizationRevisionSuccess(t *testing.T) {
+	tt := newFluxClientTest(t)
+	tt.cluster.KubeconfigFile = "mgmt.kubeconfig"
+	tt.k.EXPECT().GetObject(tt.ctx, "kustomizations.kustomize.toolkit.fluxcd.io", "flux-system", "flux-system", "mgmt.kubeconfig", gomock.Any()).
+		Return(errors.New("error in get object")).Times(4)
+	tt.k.EXPECT().GetObject(tt.ctx, "kustomizations.kustomize.toolkit.fluxcd.io", "flux-system", "flux-system", "mgmt.kubeconfig", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _, _, _ string, obj runtime.Object) error {
+			obj.(*unstructured.Unstructured).Object = map[string]interface{}{
+				"status": map[string]interface{}{"lastAppliedRevision": "main@sha1:abc123"},
+			}
+			return nil
+		})
+
+	tt.Expect(tt.c.GetKustomizationRevision(tt.ctx, tt.cluster, "flux-system")).To(Equal("main@sha1:abc123"))
+}
+
+func TestFluxClientGetKustomizationRevisionError(t *testing.T) {
+	tt := newFluxClientTest(t)
+	tt.k.EXPECT().GetObject(tt.ctx, "kustomizations.kustomize.toolkit.fluxcd.io", "flux-system", "flux-system", "", gomock.Any()).
+		Return(errors.New("error in get object")).Times(5)
+
+	_, err := tt.c.GetKustomizationRevision(tt.ctx, tt.cluster, "flux-system")
+	tt.Expect(err).To(MatchError(ContainSubstring("error in get object")), "fluxClient.GetKustomizationRevision() should fail after 5 tries")
+}
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/git"
 	"github.com/aws/eks-anywhere/pkg/logger"
@@ -224,6 +225,12 @@ func (fc *fluxForCluster) repository() string {
 	if fc.clusterSpec.FluxConfig.Spec.Github != nil {
 		return fc.clusterSpec.FluxConfig.Spec.Github.Repository
 	}
+	if fc.clusterSpec.FluxConfig.Spec.Gitlab != nil {
+		return fc.clusterSpec.FluxConfig.Spec.Gitlab.Repository
+	}
+	if fc.clusterSpec.FluxConfig.Spec.BitbucketServer != nil {
+		return fc.clusterSpec.FluxConfig.Spec.BitbucketServer.Repository
+	}
 	if fc.clusterSpec.FluxConfig.Spec.Git != nil {
 		r := fc.clusterSpec.FluxConfig.Spec.Git.RepositoryUrl
 		return path.Base(strings.TrimSuffix(r, filepath.Ext(r)))
@@ -235,6 +242,12 @@ func (fc *fluxForCluster) owner() string {
 	if fc.clusterSpec.FluxConfig.Spec.Github != nil {
 		return fc.clusterSpec.FluxConfig.Spec.Github.Owner
 	}
+	if fc.clusterSpec.FluxConfig.Spec.Gitlab != nil {
+		return fc.clusterSpec.FluxConfig.Spec.Gitlab.Owner
+	}
+	if fc.clusterSpec.FluxConfig.Spec.BitbucketServer != nil {
+		return fc.clusterSpec.FluxConfig.Spec.BitbucketServer.Owner
+	}
 	return ""
 }
 
@@ -246,6 +259,12 @@ func (fc *fluxForCluster) personal() bool {
 	if fc.clusterSpec.FluxConfig.Spec.Github != nil {
 		return fc.clusterSpec.FluxConfig.Spec.Github.Personal
 	}
+	if fc.clusterSpec.FluxConfig.Spec.Gitlab != nil {
+		return fc.clusterSpec.FluxConfig.Spec.Gitlab.Personal
+	}
+	if fc.clusterSpec.FluxConfig.Spec.BitbucketServer != nil {
+		return fc.clusterSpec.FluxConfig.Spec.BitbucketServer.Personal
+	}
 	return false
 }
 
@@ -260,3 +279,8 @@ func (fc *fluxForCluster) eksaSystemDir() string {
 func (fc *fluxForCluster) fluxSystemDir() string {
 	return path.Join(fc.path(), fc.namespace())
 }
+
+// ClusterConfigFilePath returns the path in the gitops repository of the EKS-A config file of a cluster.
+func ClusterConfigFilePath(fluxConfig *v1alpha1.FluxConfig, clusterName string) string {
+	return path.Join(fluxConfig.Spec.ClusterConfigPath, clusterName, eksaSystemDirName, clusterConfigFileName)
+}
@@ -27,6 +27,8 @@ const (
 
 type GitOpsFluxClient interface {
 	BootstrapGithub(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
+	BootstrapGitlab(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig, cliConfig *config.CliConfig) error
+	BootstrapBitbucketServer(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig, cliConfig *config.CliConfig) error
 	BootstrapGit(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig, cliConfig *config.CliConfig) error
 	Uninstall(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
 	GetCluster(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) (eksaCluster *v1alpha1.Cluster, err error)
@@ -35,6 +37,7 @@ type GitOpsFluxClient interface {
 	Reconcile(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
 	ForceReconcile(ctx context.Context, cluster *types.Cluster, namespace string) error
 	DeleteSystemSecret(ctx context.Context, cluster *types.Cluster, namespace string) error
+	GetKustomizationRevision(ctx context.Context, cluster *types.Cluster, namespace string) (string, error)
 }
 
 type GitClient interface {
@@ -49,6 +52,8 @@ type GitClient interface {
 	Commit(message string) error
 	Branch(name string) error
 	Init() error
+	CreatePullRequest(ctx context.Context, opts git.CreatePullRequestOpts) (*git.PullRequest, error)
+	GetPullRequest(ctx context.Context, owner, repo string, number int) (*git.PullRequest, error)
 }
 
 type Flux struct {
@@ -56,12 +61,15 @@ type Flux struct {
 	gitClient  GitClient
 	writer     filewriter.FileWriter
 	cliConfig  *config.CliConfig
+	// mergedRevision is the merge commit of the last pull request opened with cluster config changes.
+	mergedRevision string
 }
 
 func NewFlux(fluxClient FluxClient, kubeClient KubeClient, gitTools *gitFactory.GitTools, cliConfig *config.CliConfig) *Flux {
 	var w filewriter.FileWriter
 	if gitTools != nil {
 		w = gitTools.Writer
+		cliConfig = withGitCaFile(cliConfig, gitTools.CaFile)
 	}
 
 	return &Flux{
@@ -72,6 +80,17 @@ func NewFlux(fluxClient FluxClient, kubeClient KubeClient, gitTools *gitFactory.
 	}
 }
 
+// withGitCaFile returns a copy of cliConfig pointing to the CA bundle of the git server, so flux
+// bootstrap stores it in the flux system secret along with the git credentials.
+func withGitCaFile(cliConfig *config.CliConfig, caFile string) *config.CliConfig {
+	if cliConfig == nil || caFile == "" {
+		return cliConfig
+	}
+	c := *cliConfig
+	c.GitCaFile = caFile
+	return &c
+}
+
 func NewFluxFromGitOpsFluxClient(fluxClient GitOpsFluxClient, gitClient GitClient, writer filewriter.FileWriter, cliConfig *config.CliConfig) *Flux {
 	return &Flux{
 		fluxClient: fluxClient,
@@ -117,6 +136,16 @@ func (f *Flux) Bootstrap(ctx context.Context, cluster *types.Cluster, clusterSpe
 		return fmt.Errorf("installing GitHub gitops: %v", err)
 	}
 
+	if err := f.BootstrapGitlab(ctx, cluster, clusterSpec); err != nil {
+		_ = f.Uninstall(ctx, cluster, clusterSpec)
+		return fmt.Errorf("installing GitLab gitops: %v", err)
+	}
+
+	if err := f.BootstrapBitbucketServer(ctx, cluster, clusterSpec); err != nil {
+		_ = f.Uninstall(ctx, cluster, clusterSpec)
+		return fmt.Errorf("installing Bitbucket Server gitops: %v", err)
+	}
+
 	if err := f.BootstrapGit(ctx, cluster, clusterSpec); err != nil {
 		_ = f.Uninstall(ctx, cluster, clusterSpec)
 		return fmt.Errorf("installing generic git gitops: %v", err)
@@ -133,6 +162,22 @@ func (f *Flux) BootstrapGithub(ctx context.Context, cluster *types.Cluster, clus
 	return f.fluxClient.BootstrapGithub(ctx, cluster, clusterSpec.FluxConfig)
 }
 
+func (f *Flux) BootstrapGitlab(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if cluster.ExistingManagement || clusterSpec.FluxConfig.Spec.Gitlab == nil {
+		return nil
+	}
+
+	return f.fluxClient.BootstrapGitlab(ctx, cluster, clusterSpec.FluxConfig, f.cliConfig)
+}
+
+func (f *Flux) BootstrapBitbucketServer(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if cluster.ExistingManagement || clusterSpec.FluxConfig.Spec.BitbucketServer == nil {
+		return nil
+	}
+
+	return f.fluxClient.BootstrapBitbucketServer(ctx, cluster, clusterSpec.FluxConfig, f.cliConfig)
+}
+
 func (f *Flux) BootstrapGit(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
 	if cluster.ExistingManagement || clusterSpec.FluxConfig.Spec.Git == nil {
 		return nil
@@ -209,7 +254,12 @@ func (f *Flux) ForceReconcileGitRepo(ctx context.Context, cluster *types.Cluster
 		return nil
 	}
 
-	return f.fluxClient.ForceReconcile(ctx, cluster, clusterSpec.FluxConfig.Spec.SystemNamespace)
+	namespace := clusterSpec.FluxConfig.Spec.SystemNamespace
+	if err := f.fluxClient.ForceReconcile(ctx, cluster, namespace); err != nil {
+		return err
+	}
+
+	return f.waitForMergedRevisionApplied(ctx, cluster, namespace)
 }
 
 func (f *Flux) UpdateGitEksaSpec(ctx context.Context, clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig) error {
@@ -224,6 +274,16 @@ func (f *Flux) UpdateGitEksaSpec(ctx context.Context, clusterSpec *cluster.Spec,
 		return err
 	}
 
+	// Changes are committed to a new branch that is merged with a pull request
+	// when pushing directly to the flux config branch is not allowed.
+	var head string
+	if f.pullRequestEnabled() {
+		head = pullRequestBranch(clusterSpec.Cluster.Name)
+		if err := f.gitClient.Branch(head); err != nil {
+			return fmt.Errorf("creating git branch %s: %v", head, err)
+		}
+	}
+
 	g := NewFileGenerator()
 	if err := g.Init(f.writer, fc.eksaSystemDir(), fc.fluxSystemDir()); err != nil {
 		return err
@@ -242,6 +302,10 @@ func (f *Flux) UpdateGitEksaSpec(ctx context.Context, clusterSpec *cluster.Spec,
 		return err
 	}
 	logger.V(3).Info("Finished pushing updated cluster config file to git", "repository", fc.repository())
+
+	if head != "" {
+		return fc.openPullRequest(ctx, head)
+	}
 	return nil
 }
 
@@ -253,12 +317,14 @@ func (f *Flux) Validations(ctx context.Context, clusterSpec *cluster.Spec) []val
 	fc := newFluxForCluster(f, clusterSpec, nil, nil)
 
 	return []validations.Validation{
-		func() *validations.ValidationResult {
-			return &validations.ValidationResult{
-				Name:        "Flux path",
-				Remediation: "Please provide a different path or different cluster name",
-				Err:         fc.validateRemoteConfigPathDoesNotExist(ctx),
-			}
+		validations.Validation{
+			Name: "Flux path",
+			Run: func(ctx context.Context) *validations.ValidationResult {
+				return &validations.ValidationResult{
+					Remediation: "Please provide a different path or different cluster name",
+					Err:         fc.validateRemoteConfigPathDoesNotExist(ctx),
+				}
+			},
 		},
 	}
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/git"
@@ -98,7 +99,7 @@ func (t *fluxTest) setupFlux() (owner, repo, path string) {
 
 func runValidations(validations []validations.Validation) error {
 	for _, v := range validations {
-		if err := v().Err; err != nil {
+		if err := v.Run(context.Background()).Err; err != nil {
 			return err
 		}
 	}
@@ -432,6 +433,50 @@ func TestInstallGitOpsGitProviderSuccess(t *testing.T) {
 	g.Expect(g.gitOpsFlux.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig, []providers.MachineConfig{machineConfig})).To(Succeed())
 }
 
+func TestInstallGitOpsGitlabProviderSuccess(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "management-cluster"
+	clusterConfig := v1alpha1.NewCluster(clusterName)
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+	clusterSpec.FluxConfig.Spec.Gitlab = &v1alpha1.GitlabProviderConfig{Owner: "fleet", Repository: "testRepo", Hostname: "gitlab.example.com"}
+	clusterSpec.FluxConfig.Spec.Github = nil
+
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: "testRepo"}, nil)
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add(path.Dir("clusters/management-cluster")).Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+	g.flux.EXPECT().BootstrapGitlab(g.ctx, cluster, clusterSpec.FluxConfig, nil)
+	g.git.EXPECT().Pull(g.ctx, clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+
+	datacenterConfig := datacenterConfig(clusterName)
+	machineConfig := machineConfig(clusterName)
+
+	g.Expect(g.gitOpsFlux.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig, []providers.MachineConfig{machineConfig})).To(Succeed())
+}
+
+func TestInstallGitOpsBitbucketServerBootstrapError(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterConfig := v1alpha1.NewCluster("test-cluster")
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+	clusterSpec.FluxConfig.Spec.BitbucketServer = &v1alpha1.BitbucketServerProviderConfig{Owner: "FLEET", Repository: "testRepo", Hostname: "bitbucket.example.com"}
+	clusterSpec.FluxConfig.Spec.Github = nil
+
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: "testRepo"}, nil)
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add(path.Dir("clusters/management-cluster")).Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+	g.flux.EXPECT().BootstrapBitbucketServer(g.ctx, cluster, clusterSpec.FluxConfig, nil).Return(errors.New("error in bootstrap"))
+	g.flux.EXPECT().Uninstall(g.ctx, cluster, clusterSpec.FluxConfig).Return(nil)
+
+	g.Expect(g.gitOpsFlux.InstallGitOps(g.ctx, cluster, clusterSpec, nil, nil)).To(MatchError(ContainSubstring("installing Bitbucket Server gitops: error in bootstrap")))
+}
+
 func TestInstallGitOpsCommitFilesError(t *testing.T) {
 	cluster := &types.Cluster{}
 	clusterName := "test-cluster"
@@ -1079,3 +1124,20 @@ func TestUninstallError(t *testing.T) {
 
 	g.Expect(g.gitOpsFlux.Uninstall(g.ctx, c, g.clusterSpec)).To(MatchError(ContainSubstring("error in uninstall")))
 }
+
+func TestBootstrapGitHTTPSWithCaFile(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	fluxClient := fluxMocks.NewMockFluxClient(gomock.NewController(t))
+	cluster := &types.Cluster{}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig.Spec.Git = &v1alpha1.GitProviderConfig{RepositoryUrl: "https://git.example.com/fleet/testRepo.git"}
+	clusterSpec.FluxConfig.Spec.Github = nil
+	cliConfig := &config.CliConfig{GitUsername: "jane", GitPassword: "token"}
+	f := flux.NewFlux(fluxClient, nil, &gitFactory.GitTools{CaFile: "testRepo-ca.pem"}, cliConfig)
+
+	fluxClient.EXPECT().BootstrapGit(ctx, cluster, clusterSpec.FluxConfig, &config.CliConfig{GitUsername: "jane", GitPassword: "token", GitCaFile: "testRepo-ca.pem"})
+
+	g.Expect(f.BootstrapGit(ctx, cluster, clusterSpec)).To(Succeed())
+	g.Expect(cliConfig.GitCaFile).To(BeEmpty())
+}
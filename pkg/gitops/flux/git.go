@@ -2,6 +2,7 @@ package flux
 
 import (
 	"context"
+	"errors"
 
 	"github.com/aws/eks-anywhere/pkg/git"
 	gitFactory "github.com/aws/eks-anywhere/pkg/git/factory"
@@ -110,3 +111,27 @@ func (c *gitClient) Branch(name string) error {
 func (c *gitClient) Init() error {
 	return c.git.Init()
 }
+
+// CreatePullRequest opens a pull request with the git provider. Unlike the other operations it's not retried, since
+// a request that fails after the pull request was created would open a duplicate one when retried.
+func (c *gitClient) CreatePullRequest(ctx context.Context, opts git.CreatePullRequestOpts) (*git.PullRequest, error) {
+	if c.gitProvider == nil {
+		return nil, errors.New("pull requests are not supported for generic git repositories")
+	}
+
+	return c.gitProvider.CreatePullRequest(ctx, opts)
+}
+
+func (c *gitClient) GetPullRequest(ctx context.Context, owner, repo string, number int) (pr *git.PullRequest, err error) {
+	if c.gitProvider == nil {
+		return nil, errors.New("pull requests are not supported for generic git repositories")
+	}
+
+	err = c.Retry(
+		func() error {
+			pr, err = c.gitProvider.GetPullRequest(ctx, owner, repo, number)
+			return err
+		},
+	)
+	return pr, err
+}
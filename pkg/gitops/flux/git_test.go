@@ -239,3 +239,44 @@ func TestGitClientInitError(t *testing.T) {
 
 	tt.Expect(tt.c.Init()).To(MatchError(ContainSubstring("error in init")), "gitClient.Init() should fail after 1 try")
 }
+
+func TestGitClientCreatePullRequestSuccess(t *testing.T) {
+	tt := newGitClientTest(t)
+	opts := git.CreatePullRequestOpts{Owner: "aws", Repository: "eksa-gitops", Head: "eksa-upgrade", Base: "main"}
+	tt.p.EXPECT().CreatePullRequest(tt.ctx, opts).Return(&git.PullRequest{Number: 1}, nil)
+
+	tt.Expect(tt.c.CreatePullRequest(tt.ctx, opts)).To(Equal(&git.PullRequest{Number: 1}))
+}
+
+func TestGitClientCreatePullRequestError(t *testing.T) {
+	tt := newGitClientTest(t)
+	opts := git.CreatePullRequestOpts{Owner: "aws", Repository: "eksa-gitops", Head: "eksa-upgrade", Base: "main"}
+	tt.p.EXPECT().CreatePullRequest(tt.ctx, opts).Return(nil, errors.New("error in create pull request")).Times(1)
+
+	_, err := tt.c.CreatePullRequest(tt.ctx, opts)
+	tt.Expect(err).To(MatchError(ContainSubstring("error in create pull request")), "gitClient.CreatePullRequest() should fail after 1 try")
+}
+
+func TestGitClientCreatePullRequestSkip(t *testing.T) {
+	tt := newGitClientTest(t)
+
+	c := newGitClient(&gitFactory.GitTools{Provider: nil, Client: tt.g})
+	_, err := c.CreatePullRequest(tt.ctx, git.CreatePullRequestOpts{})
+	tt.Expect(err).To(MatchError("pull requests are not supported for generic git repositories"))
+}
+
+func TestGitClientGetPullRequestSuccess(t *testing.T) {
+	tt := newGitClientTest(t)
+	tt.p.EXPECT().GetPullRequest(tt.ctx, "aws", "eksa-gitops", 1).Return(nil, errors.New("error in get pull request")).Times(4)
+	tt.p.EXPECT().GetPullRequest(tt.ctx, "aws", "eksa-gitops", 1).Return(&git.PullRequest{Number: 1, Merged: true}, nil).Times(1)
+
+	tt.Expect(tt.c.GetPullRequest(tt.ctx, "aws", "eksa-gitops", 1)).To(Equal(&git.PullRequest{Number: 1, Merged: true}), "gitClient.GetPullRequest() should succeed with 5 tries")
+}
+
+func TestGitClientGetPullRequestError(t *testing.T) {
+	tt := newGitClientTest(t)
+	tt.p.EXPECT().GetPullRequest(tt.ctx, "aws", "eksa-gitops", 1).Return(nil, errors.New("error in get pull request")).Times(5)
+
+	_, err := tt.c.GetPullRequest(tt.ctx, "aws", "eksa-gitops", 1)
+	tt.Expect(err).To(MatchError(ContainSubstring("error in get pull request")), "gitClient.GetPullRequest() should fail after 5 tries")
+}
@@ -16,6 +16,7 @@ import (
 	git "github.com/aws/eks-anywhere/pkg/git"
 	types "github.com/aws/eks-anywhere/pkg/types"
 	gomock "github.com/golang/mock/gomock"
+	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
 // MockFluxClient is a mock of FluxClient interface.
@@ -41,6 +42,20 @@ func (m *MockFluxClient) EXPECT() *MockFluxClientMockRecorder {
 	return m.recorder
 }
 
+// BootstrapBitbucketServer mocks base method.
+func (m *MockFluxClient) BootstrapBitbucketServer(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.FluxConfig, arg3 *config.CliConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BootstrapBitbucketServer", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BootstrapBitbucketServer indicates an expected call of BootstrapBitbucketServer.
+func (mr *MockFluxClientMockRecorder) BootstrapBitbucketServer(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapBitbucketServer", reflect.TypeOf((*MockFluxClient)(nil).BootstrapBitbucketServer), arg0, arg1, arg2, arg3)
+}
+
 // BootstrapGit mocks base method.
 func (m *MockFluxClient) BootstrapGit(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.FluxConfig, arg3 *config.CliConfig) error {
 	m.ctrl.T.Helper()
@@ -69,6 +84,20 @@ func (mr *MockFluxClientMockRecorder) BootstrapGithub(arg0, arg1, arg2 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapGithub", reflect.TypeOf((*MockFluxClient)(nil).BootstrapGithub), arg0, arg1, arg2)
 }
 
+// BootstrapGitlab mocks base method.
+func (m *MockFluxClient) BootstrapGitlab(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.FluxConfig, arg3 *config.CliConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BootstrapGitlab", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BootstrapGitlab indicates an expected call of BootstrapGitlab.
+func (mr *MockFluxClientMockRecorder) BootstrapGitlab(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapGitlab", reflect.TypeOf((*MockFluxClient)(nil).BootstrapGitlab), arg0, arg1, arg2, arg3)
+}
+
 // Reconcile mocks base method.
 func (m *MockFluxClient) Reconcile(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.FluxConfig) error {
 	m.ctrl.T.Helper()
@@ -149,6 +178,20 @@ func (mr *MockKubeClientMockRecorder) GetEksaCluster(arg0, arg1, arg2 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEksaCluster", reflect.TypeOf((*MockKubeClient)(nil).GetEksaCluster), arg0, arg1, arg2)
 }
 
+// GetObject mocks base method.
+func (m *MockKubeClient) GetObject(arg0 context.Context, arg1, arg2, arg3, arg4 string, arg5 runtime.Object) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObject", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetObject indicates an expected call of GetObject.
+func (mr *MockKubeClientMockRecorder) GetObject(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObject", reflect.TypeOf((*MockKubeClient)(nil).GetObject), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // RemoveAnnotation mocks base method.
 func (m *MockKubeClient) RemoveAnnotation(arg0 context.Context, arg1, arg2, arg3 string, arg4 ...executables.KubectlOpt) error {
 	m.ctrl.T.Helper()
@@ -210,6 +253,20 @@ func (m *MockGitOpsFluxClient) EXPECT() *MockGitOpsFluxClientMockRecorder {
 	return m.recorder
 }
 
+// BootstrapBitbucketServer mocks base method.
+func (m *MockGitOpsFluxClient) BootstrapBitbucketServer(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.FluxConfig, arg3 *config.CliConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BootstrapBitbucketServer", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BootstrapBitbucketServer indicates an expected call of BootstrapBitbucketServer.
+func (mr *MockGitOpsFluxClientMockRecorder) BootstrapBitbucketServer(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapBitbucketServer", reflect.TypeOf((*MockGitOpsFluxClient)(nil).BootstrapBitbucketServer), arg0, arg1, arg2, arg3)
+}
+
 // BootstrapGit mocks base method.
 func (m *MockGitOpsFluxClient) BootstrapGit(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.FluxConfig, arg3 *config.CliConfig) error {
 	m.ctrl.T.Helper()
@@ -238,6 +295,20 @@ func (mr *MockGitOpsFluxClientMockRecorder) BootstrapGithub(arg0, arg1, arg2 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapGithub", reflect.TypeOf((*MockGitOpsFluxClient)(nil).BootstrapGithub), arg0, arg1, arg2)
 }
 
+// BootstrapGitlab mocks base method.
+func (m *MockGitOpsFluxClient) BootstrapGitlab(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.FluxConfig, arg3 *config.CliConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BootstrapGitlab", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BootstrapGitlab indicates an expected call of BootstrapGitlab.
+func (mr *MockGitOpsFluxClientMockRecorder) BootstrapGitlab(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapGitlab", reflect.TypeOf((*MockGitOpsFluxClient)(nil).BootstrapGitlab), arg0, arg1, arg2, arg3)
+}
+
 // DeleteSystemSecret mocks base method.
 func (m *MockGitOpsFluxClient) DeleteSystemSecret(arg0 context.Context, arg1 *types.Cluster, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -309,6 +380,21 @@ func (mr *MockGitOpsFluxClientMockRecorder) GetCluster(arg0, arg1, arg2 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCluster", reflect.TypeOf((*MockGitOpsFluxClient)(nil).GetCluster), arg0, arg1, arg2)
 }
 
+// GetKustomizationRevision mocks base method.
+func (m *MockGitOpsFluxClient) GetKustomizationRevision(arg0 context.Context, arg1 *types.Cluster, arg2 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKustomizationRevision", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKustomizationRevision indicates an expected call of GetKustomizationRevision.
+func (mr *MockGitOpsFluxClientMockRecorder) GetKustomizationRevision(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKustomizationRevision", reflect.TypeOf((*MockGitOpsFluxClient)(nil).GetKustomizationRevision), arg0, arg1, arg2)
+}
+
 // Reconcile mocks base method.
 func (m *MockGitOpsFluxClient) Reconcile(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.FluxConfig) error {
 	m.ctrl.T.Helper()
@@ -416,6 +502,21 @@ func (mr *MockGitClientMockRecorder) Commit(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockGitClient)(nil).Commit), arg0)
 }
 
+// CreatePullRequest mocks base method.
+func (m *MockGitClient) CreatePullRequest(arg0 context.Context, arg1 git.CreatePullRequestOpts) (*git.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePullRequest", arg0, arg1)
+	ret0, _ := ret[0].(*git.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePullRequest indicates an expected call of CreatePullRequest.
+func (mr *MockGitClientMockRecorder) CreatePullRequest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePullRequest", reflect.TypeOf((*MockGitClient)(nil).CreatePullRequest), arg0, arg1)
+}
+
 // CreateRepo mocks base method.
 func (m *MockGitClient) CreateRepo(arg0 context.Context, arg1 git.CreateRepoOpts) error {
 	m.ctrl.T.Helper()
@@ -430,6 +531,21 @@ func (mr *MockGitClientMockRecorder) CreateRepo(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRepo", reflect.TypeOf((*MockGitClient)(nil).CreateRepo), arg0, arg1)
 }
 
+// GetPullRequest mocks base method.
+func (m *MockGitClient) GetPullRequest(arg0 context.Context, arg1, arg2 string, arg3 int) (*git.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPullRequest", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*git.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPullRequest indicates an expected call of GetPullRequest.
+func (mr *MockGitClientMockRecorder) GetPullRequest(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPullRequest", reflect.TypeOf((*MockGitClient)(nil).GetPullRequest), arg0, arg1, arg2, arg3)
+}
+
 // GetRepo mocks base method.
 func (m *MockGitClient) GetRepo(arg0 context.Context) (*git.Repository, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,131 @@
+package flux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	defaultPullRequestTimeout = time.Hour
+	pullRequestPollInterval   = 30 * time.Second
+	fluxReconcilePollInterval = 10 * time.Second
+	fluxReconcileTimeout      = 10 * time.Minute
+
+	pullRequestBranchPrefix = "eksa-upgrade-"
+	pullRequestTitle        = "Upgrade EKS-A cluster %s"
+	pullRequestBody         = "Update of the EKS-A cluster configuration generated by EKS-A CLI. " +
+		"The cluster upgrade waits for this pull request to be merged and reconciled by Flux before continuing."
+)
+
+// errPullRequestClosed aborts the wait for a pull request to be merged.
+var errPullRequestClosed = errors.New("pull request was closed without being merged")
+
+func (f *Flux) pullRequestEnabled() bool {
+	return f.cliConfig != nil && f.cliConfig.GitOpsPullRequest
+}
+
+func (f *Flux) pullRequestTimeout() time.Duration {
+	if f.cliConfig == nil || f.cliConfig.GitOpsPullRequestTimeout == 0 {
+		return defaultPullRequestTimeout
+	}
+	return f.cliConfig.GitOpsPullRequestTimeout
+}
+
+func pullRequestBranch(clusterName string) string {
+	return fmt.Sprintf("%s%s-%d", pullRequestBranchPrefix, clusterName, time.Now().Unix())
+}
+
+// openPullRequest opens a pull request to merge head into the flux config branch and waits until it's merged.
+// The local repository is switched back to the flux config branch once merged, so it contains the merge commit.
+func (fc *fluxForCluster) openPullRequest(ctx context.Context, head string) error {
+	opts := git.CreatePullRequestOpts{
+		Owner:      fc.owner(),
+		Repository: fc.repository(),
+		Title:      fmt.Sprintf(pullRequestTitle, fc.clusterSpec.Cluster.Name),
+		Body:       pullRequestBody,
+		Head:       head,
+		Base:       fc.branch(),
+	}
+	pr, err := fc.gitClient.CreatePullRequest(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("opening pull request with cluster config changes: %v", err)
+	}
+
+	logger.Info("Opened pull request with the cluster config changes, waiting for it to be merged", "url", pr.URL, "timeout", fc.pullRequestTimeout())
+	pr, err = fc.waitForPullRequestMerged(ctx, pr.Number)
+	if err != nil {
+		return err
+	}
+	logger.Info("Pull request merged", "url", pr.URL)
+
+	if err = fc.gitClient.Branch(fc.branch()); err != nil {
+		return fmt.Errorf("switching to git branch %s: %v", fc.branch(), err)
+	}
+
+	fc.mergedRevision = pr.MergeCommit
+	return nil
+}
+
+func (fc *fluxForCluster) waitForPullRequestMerged(ctx context.Context, number int) (pr *git.PullRequest, err error) {
+	r := retrier.New(fc.pullRequestTimeout(), retrier.WithRetryPolicy(func(_ int, err error) (bool, time.Duration) {
+		return !errors.Is(err, errPullRequestClosed), pullRequestPollInterval
+	}))
+
+	err = r.Retry(func() error {
+		pr, err = fc.gitClient.GetPullRequest(ctx, fc.owner(), fc.repository(), number)
+		if err != nil {
+			return err
+		}
+		if pr.Closed {
+			return errPullRequestClosed
+		}
+		if !pr.Merged {
+			return fmt.Errorf("pull request %s not merged yet", pr.URL)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("waiting for pull request %d to be merged: %v", number, err)
+	}
+	return pr, nil
+}
+
+// waitForMergedRevisionApplied waits until Flux has applied the revision merged from the last pull request.
+func (f *Flux) waitForMergedRevisionApplied(ctx context.Context, cluster *types.Cluster, namespace string) error {
+	if !f.pullRequestEnabled() {
+		return nil
+	}
+
+	if f.mergedRevision == "" {
+		logger.Info("Merge commit of the pull request is unknown, skipping wait for Flux to apply it")
+		return nil
+	}
+
+	logger.Info("Waiting for Flux to apply the merged revision", "revision", f.mergedRevision)
+	r := retrier.New(fluxReconcileTimeout, retrier.WithRetryPolicy(func(_ int, _ error) (bool, time.Duration) {
+		return true, fluxReconcilePollInterval
+	}))
+
+	err := r.Retry(func() error {
+		applied, err := f.fluxClient.GetKustomizationRevision(ctx, cluster, namespace)
+		if err != nil {
+			return err
+		}
+		if !strings.HasSuffix(applied, f.mergedRevision) {
+			return fmt.Errorf("last applied revision is %s", applied)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for Flux to apply revision %s: %v", f.mergedRevision, err)
+	}
+	return nil
+}
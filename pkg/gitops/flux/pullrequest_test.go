@@ -0,0 +1,146 @@
+package flux_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	fluxMocks "github.com/aws/eks-anywhere/pkg/gitops/flux/mocks"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type pullRequestTest struct {
+	fluxTest
+	gitOpsFlux  *flux.Flux
+	clusterSpec *cluster.Spec
+	eksaDir     string
+}
+
+func newPullRequestTest(t *testing.T, timeout time.Duration) *pullRequestTest {
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	cliConfig := &config.CliConfig{GitOpsPullRequest: true, GitOpsPullRequestTimeout: timeout}
+
+	return &pullRequestTest{
+		fluxTest:    g,
+		gitOpsFlux:  flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, cliConfig),
+		clusterSpec: clusterSpec,
+		eksaDir:     "clusters/management-cluster/management-cluster/eksa-system",
+	}
+}
+
+func (tt *pullRequestTest) expectPushToBranch() {
+	tt.git.EXPECT().Clone(tt.ctx).Return(nil)
+	base := tt.git.EXPECT().Branch(tt.clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	head := tt.git.EXPECT().Branch(gomock.Not(tt.clusterSpec.FluxConfig.Spec.Branch)).Return(nil).After(base)
+	tt.git.EXPECT().Add(tt.eksaDir).Return(nil).After(head)
+	tt.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	push := tt.git.EXPECT().Push(tt.ctx).Return(nil)
+	tt.git.EXPECT().CreatePullRequest(tt.ctx, gomock.Any()).DoAndReturn(
+		func(_ interface{}, opts git.CreatePullRequestOpts) (*git.PullRequest, error) {
+			tt.Expect(opts.Owner).To(Equal("mFolwer"))
+			tt.Expect(opts.Repository).To(Equal("testRepo"))
+			tt.Expect(opts.Base).To(Equal("testBranch"))
+			tt.Expect(opts.Head).To(HavePrefix("eksa-upgrade-management-cluster-"))
+			tt.Expect(opts.Title).To(Equal("Upgrade EKS-A cluster management-cluster"))
+			return &git.PullRequest{Number: 3, URL: "https://github.com/mFolwer/testRepo/pull/3"}, nil
+		},
+	).After(push)
+}
+
+func (tt *pullRequestTest) updateGitEksaSpec() error {
+	return tt.gitOpsFlux.UpdateGitEksaSpec(tt.ctx, tt.clusterSpec, datacenterConfig("management-cluster"),
+		[]providers.MachineConfig{machineConfig("management-cluster")})
+}
+
+func TestUpdateGitEksaSpecPullRequestMerged(t *testing.T) {
+	tt := newPullRequestTest(t, time.Minute)
+	cluster := &types.Cluster{KubeconfigFile: "mgmt.kubeconfig"}
+	tt.expectPushToBranch()
+	merged := &git.PullRequest{Number: 3, URL: "https://github.com/mFolwer/testRepo/pull/3", Merged: true, MergeCommit: "abc123"}
+	get := tt.git.EXPECT().GetPullRequest(tt.ctx, "mFolwer", "testRepo", 3).Return(merged, nil)
+	tt.git.EXPECT().Branch("testBranch").Return(nil).After(get)
+
+	tt.Expect(tt.updateGitEksaSpec()).To(Succeed())
+
+	force := tt.flux.EXPECT().ForceReconcile(tt.ctx, cluster, "flux-system")
+	tt.flux.EXPECT().GetKustomizationRevision(tt.ctx, cluster, "flux-system").Return("testBranch@sha1:abc123", nil).After(force)
+
+	tt.Expect(tt.gitOpsFlux.ForceReconcileGitRepo(tt.ctx, cluster, tt.clusterSpec)).To(Succeed())
+}
+
+func TestUpdateGitEksaSpecPullRequestClosed(t *testing.T) {
+	tt := newPullRequestTest(t, time.Minute)
+	tt.expectPushToBranch()
+	closed := &git.PullRequest{Number: 3, URL: "https://github.com/mFolwer/testRepo/pull/3", Closed: true}
+	tt.git.EXPECT().GetPullRequest(tt.ctx, "mFolwer", "testRepo", 3).Return(closed, nil).Times(1)
+
+	tt.Expect(tt.updateGitEksaSpec()).To(MatchError(ContainSubstring("pull request was closed without being merged")))
+}
+
+func TestUpdateGitEksaSpecPullRequestTimeout(t *testing.T) {
+	tt := newPullRequestTest(t, time.Millisecond)
+	tt.expectPushToBranch()
+	open := &git.PullRequest{Number: 3, URL: "https://github.com/mFolwer/testRepo/pull/3"}
+	tt.git.EXPECT().GetPullRequest(tt.ctx, "mFolwer", "testRepo", 3).Return(open, nil)
+
+	tt.Expect(tt.updateGitEksaSpec()).To(MatchError(ContainSubstring("waiting for pull request 3 to be merged: pull request https://github.com/mFolwer/testRepo/pull/3 not merged yet")))
+}
+
+func TestUpdateGitEksaSpecPullRequestCreateError(t *testing.T) {
+	tt := newPullRequestTest(t, time.Minute)
+	tt.git.EXPECT().Clone(tt.ctx).Return(nil)
+	tt.git.EXPECT().Branch(gomock.Any()).Return(nil).Times(2)
+	tt.git.EXPECT().Add(tt.eksaDir).Return(nil)
+	tt.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	tt.git.EXPECT().Push(tt.ctx).Return(nil)
+	tt.git.EXPECT().CreatePullRequest(tt.ctx, gomock.Any()).Return(nil, errors.New("no permissions"))
+
+	tt.Expect(tt.updateGitEksaSpec()).To(MatchError("opening pull request with cluster config changes: no permissions"))
+}
+
+func TestUpdateGitEksaSpecPullRequestBranchError(t *testing.T) {
+	tt := newPullRequestTest(t, time.Minute)
+	tt.git.EXPECT().Clone(tt.ctx).Return(nil)
+	tt.git.EXPECT().Branch("testBranch").Return(nil)
+	tt.git.EXPECT().Branch(gomock.Not("testBranch")).Return(errors.New("invalid branch"))
+
+	tt.Expect(tt.updateGitEksaSpec()).To(MatchError(ContainSubstring("invalid branch")))
+}
+
+func TestForceReconcileGitRepoPullRequestUnknownMergeCommit(t *testing.T) {
+	tt := newPullRequestTest(t, time.Minute)
+	cluster := &types.Cluster{}
+	tt.expectPushToBranch()
+	merged := &git.PullRequest{Number: 3, Merged: true}
+	tt.git.EXPECT().GetPullRequest(tt.ctx, "mFolwer", "testRepo", 3).Return(merged, nil)
+	tt.git.EXPECT().Branch("testBranch").Return(nil)
+
+	tt.Expect(tt.updateGitEksaSpec()).To(Succeed())
+
+	tt.flux.EXPECT().ForceReconcile(tt.ctx, cluster, "flux-system")
+	tt.flux.EXPECT().GetKustomizationRevision(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	tt.Expect(tt.gitOpsFlux.ForceReconcileGitRepo(tt.ctx, cluster, tt.clusterSpec)).To(Succeed())
+}
+
+func TestForceReconcileGitRepoWithoutPullRequest(t *testing.T) {
+	g := newFluxTest(t)
+	cluster := &types.Cluster{}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, fluxMocks.NewMockGitClient(gomock.NewController(t)), g.writer, &config.CliConfig{})
+
+	g.flux.EXPECT().ForceReconcile(g.ctx, cluster, "flux-system")
+
+	g.Expect(f.ForceReconcileGitRepo(g.ctx, cluster, clusterSpec)).To(Succeed())
+}
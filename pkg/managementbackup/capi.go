@@ -0,0 +1,61 @@
+package managementbackup
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// removeClusterObjects deletes from a clusterctl move directory the CAPI objects that belong to a cluster.
+// The new management cluster already runs its own CAPI objects, only the ones for the workload clusters
+// need to be moved to it.
+func removeClusterObjects(dir, clusterName string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return fmt.Errorf("reading CAPI backup directory %s: %v", dir, err)
+	}
+
+	for _, f := range files {
+		obj, err := readObject(f)
+		if err != nil {
+			return err
+		}
+
+		if !belongsToCluster(obj, clusterName) {
+			continue
+		}
+
+		if err = os.Remove(f); err != nil {
+			return fmt.Errorf("removing CAPI object %s %s from backup: %v", obj.GetKind(), obj.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+// belongsToCluster returns true if obj is the CAPI Cluster with the given name, it's labeled with it
+// or it's owned by it.
+func belongsToCluster(obj *unstructured.Unstructured, clusterName string) bool {
+	if isCAPICluster(obj.GetAPIVersion(), obj.GetKind()) && obj.GetName() == clusterName {
+		return true
+	}
+
+	if obj.GetLabels()[clusterv1.ClusterLabelName] == clusterName {
+		return true
+	}
+
+	for _, o := range obj.GetOwnerReferences() {
+		if isCAPICluster(o.APIVersion, o.Kind) && o.Name == clusterName {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isCAPICluster(apiVersion, kind string) bool {
+	return kind == "Cluster" && apiVersion == clusterv1.GroupVersion.String()
+}
@@ -0,0 +1,416 @@
+package managementbackup
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	eksdv1alpha1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/version"
+)
+
+// Layout of the backup archive.
+const (
+	// ManagementClusterConfigFile is the cluster config of the management cluster, ready to be used with create cluster.
+	ManagementClusterConfigFile = "management-cluster.yaml"
+	metadataFile                = "backup.yaml"
+	workloadDir                 = "eksa/workload"
+	managementDir               = "eksa/management"
+	releasesDir                 = "eksa/releases"
+	capiDir                     = "capi"
+)
+
+var (
+	eksaClusterResourceType = fmt.Sprintf("clusters.%s", v1alpha1.GroupVersion.Group)
+	bundlesResourceType     = fmt.Sprintf("bundles.%s", v1alpha1.GroupVersion.Group)
+	eksdReleaseResourceType = fmt.Sprintf("releases.%s", eksdv1alpha1.GroupVersion.Group)
+)
+
+// KubectlClient reads and creates the EKS-A objects in the management cluster.
+type KubectlClient interface {
+	GetObject(ctx context.Context, resourceType, name, namespace, kubeconfig string, obj runtime.Object) error
+	ListObjects(ctx context.Context, resourceType, namespace, kubeconfig string, list kubernetes.ObjectList) error
+	ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
+	RemoveAnnotationInNamespace(ctx context.Context, resourceType, objectName, key string, cluster *types.Cluster, namespace string) error
+}
+
+// ClusterctlClient saves and restores the CAPI objects of a management cluster.
+type ClusterctlClient interface {
+	BackupManagement(ctx context.Context, cluster *types.Cluster, managementStatePath string) error
+	RestoreManagement(ctx context.Context, cluster *types.Cluster, managementStatePath string) error
+}
+
+// Packager builds the backup archive from a folder and extracts it back.
+type Packager interface {
+	Package(sourceFolder, dstFile string) error
+	UnPackage(orgFile, dstFolder string) error
+}
+
+// Metadata describes the management cluster a backup was taken from.
+type Metadata struct {
+	ManagementCluster string   `json:"managementCluster"`
+	Namespace         string   `json:"namespace"`
+	WorkloadClusters  []string `json:"workloadClusters,omitempty"`
+	// EksaVersion is the version of the CLI that took the backup.
+	EksaVersion string      `json:"eksaVersion,omitempty"`
+	CreatedAt   metav1.Time `json:"createdAt"`
+}
+
+// Client backs up the state of a management cluster to an archive and restores it into a new management cluster.
+type Client struct {
+	kubectl    KubectlClient
+	clusterctl ClusterctlClient
+	packager   Packager
+	now        func() time.Time
+}
+
+// NewClient returns a new Client.
+func NewClient(kubectl KubectlClient, clusterctl ClusterctlClient, packager Packager) *Client {
+	return &Client{
+		kubectl:    kubectl,
+		clusterctl: clusterctl,
+		packager:   packager,
+		now:        time.Now,
+	}
+}
+
+// Backup saves to archivePath the EKS-A objects of a management cluster and all its workload clusters,
+// the bundles and EKS-D releases they use and the CAPI objects clusterctl move would move.
+func (c *Client) Backup(ctx context.Context, managementCluster *types.Cluster, clusterName, namespace, archivePath string) (*Metadata, error) {
+	dir, err := os.MkdirTemp("", "eksa-management-backup")
+	if err != nil {
+		return nil, fmt.Errorf("creating temporary backup folder: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	kubeconfig := managementCluster.KubeconfigFile
+	clusters := &unstructured.UnstructuredList{}
+	if err = c.kubectl.ListObjects(ctx, eksaClusterResourceType, namespace, kubeconfig, clusters); err != nil {
+		return nil, fmt.Errorf("listing EKS-A clusters: %v", err)
+	}
+
+	var management *unstructured.Unstructured
+	workloads := make([]*unstructured.Unstructured, 0, len(clusters.Items))
+	for i := range clusters.Items {
+		obj := &clusters.Items[i]
+		cluster, err := toCluster(obj)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case cluster.Name == clusterName:
+			if !cluster.IsSelfManaged() {
+				return nil, fmt.Errorf("cluster %s is not a management cluster, it's managed by %s", clusterName, cluster.ManagedBy())
+			}
+			management = obj
+		case cluster.ManagedBy() == clusterName:
+			workloads = append(workloads, obj)
+		}
+	}
+	if management == nil {
+		return nil, fmt.Errorf("EKS-A cluster %s not found in namespace %s", clusterName, namespace)
+	}
+
+	metadata := &Metadata{
+		ManagementCluster: clusterName,
+		Namespace:         namespace,
+		EksaVersion:       version.Get().GitVersion,
+		CreatedAt:         metav1.NewTime(c.now()),
+	}
+
+	logger.Info("Saving EKS-A objects", "cluster", clusterName)
+	// Objects shared with the management cluster, like its FluxConfig, are only saved once with the management cluster.
+	seen := map[string]struct{}{}
+	managementObjs, err := c.clusterObjects(ctx, kubeconfig, management, seen)
+	if err != nil {
+		return nil, err
+	}
+	if err = writeObjects(filepath.Join(dir, managementDir), managementObjs...); err != nil {
+		return nil, err
+	}
+	if err = writeManagementClusterConfig(filepath.Join(dir, ManagementClusterConfigFile), managementObjs); err != nil {
+		return nil, err
+	}
+
+	for _, w := range workloads {
+		logger.Info("Saving EKS-A objects", "cluster", w.GetName())
+		objs, err := c.clusterObjects(ctx, kubeconfig, w, seen)
+		if err != nil {
+			return nil, err
+		}
+		if err = writeObjects(filepath.Join(dir, workloadDir), objs...); err != nil {
+			return nil, err
+		}
+		metadata.WorkloadClusters = append(metadata.WorkloadClusters, w.GetName())
+	}
+
+	logger.V(3).Info("Saving bundles and EKS-D releases")
+	for _, resourceType := range []string{bundlesResourceType, eksdReleaseResourceType} {
+		objs, err := c.listObjects(ctx, kubeconfig, resourceType, constants.EksaSystemNamespace)
+		if err != nil {
+			return nil, err
+		}
+		if err = writeObjects(filepath.Join(dir, releasesDir), objs...); err != nil {
+			return nil, err
+		}
+	}
+
+	logger.Info("Saving CAPI objects", "cluster", clusterName)
+	if err = c.clusterctl.BackupManagement(ctx, managementCluster, filepath.Join(dir, capiDir)); err != nil {
+		return nil, err
+	}
+
+	if err = writeMetadata(filepath.Join(dir, metadataFile), metadata); err != nil {
+		return nil, err
+	}
+
+	if err = c.packager.Package(dir, archivePath); err != nil {
+		return nil, fmt.Errorf("creating backup archive %s: %v", archivePath, err)
+	}
+
+	return metadata, nil
+}
+
+// ReadManagementClusterConfig returns the metadata of the backup in the archive and the cluster config
+// of its management cluster, which can be used to create the new management cluster before Restore.
+func (c *Client) ReadManagementClusterConfig(archivePath string) (*Metadata, []byte, error) {
+	dir, err := c.extract(archivePath, "eksa-management-restore-config")
+	if err != nil {
+		return nil, nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	metadata, err := readMetadata(filepath.Join(dir, metadataFile))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, ManagementClusterConfigFile))
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading management cluster config: %v", err)
+	}
+
+	return metadata, content, nil
+}
+
+// Restore creates in a new management cluster the workload cluster objects saved in the archive and moves
+// the CAPI objects of the workload clusters to it, so it takes over their management.
+// The new management cluster must have been created beforehand with the same name as the backed up one,
+// using the cluster config saved in the archive as ManagementClusterConfigFile.
+func (c *Client) Restore(ctx context.Context, managementCluster *types.Cluster, archivePath string) (*Metadata, error) {
+	dir, err := c.extract(archivePath, "eksa-management-restore")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	metadata, err := readMetadata(filepath.Join(dir, metadataFile))
+	if err != nil {
+		return nil, err
+	}
+
+	kubeconfig := managementCluster.KubeconfigFile
+	management := &v1alpha1.Cluster{}
+	err = c.kubectl.GetObject(ctx, eksaClusterResourceType, metadata.ManagementCluster, metadata.Namespace, kubeconfig, management)
+	if apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("EKS-A cluster %s not found in the new management cluster, create it first using the %s file in the backup",
+			metadata.ManagementCluster, ManagementClusterConfigFile)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting EKS-A cluster %s: %v", metadata.ManagementCluster, err)
+	}
+
+	releases, err := readObjects(filepath.Join(dir, releasesDir))
+	if err != nil {
+		return nil, err
+	}
+	logger.V(3).Info("Restoring bundles and EKS-D releases")
+	if err = c.apply(ctx, managementCluster, releases); err != nil {
+		return nil, err
+	}
+
+	workloadObjs, err := readObjects(filepath.Join(dir, workloadDir))
+	if err != nil {
+		return nil, err
+	}
+
+	// Workload clusters are created paused so the EKS-A controller doesn't try to reconcile them
+	// before their CAPI objects have been moved.
+	pausedAnnotation := management.PausedAnnotation()
+	var workloads []*unstructured.Unstructured
+	for _, obj := range workloadObjs {
+		if isEksaCluster(obj) {
+			annotations := obj.GetAnnotations()
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			annotations[pausedAnnotation] = "true"
+			obj.SetAnnotations(annotations)
+			workloads = append(workloads, obj)
+		}
+	}
+
+	logger.Info("Restoring EKS-A objects", "clusters", len(workloads))
+	if err = c.apply(ctx, managementCluster, workloadObjs); err != nil {
+		return nil, err
+	}
+
+	capiPath := filepath.Join(dir, capiDir)
+	if err = removeClusterObjects(capiPath, metadata.ManagementCluster); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Restoring CAPI objects")
+	if err = c.clusterctl.RestoreManagement(ctx, managementCluster, capiPath); err != nil {
+		return nil, err
+	}
+
+	for _, w := range workloads {
+		logger.V(3).Info("Resuming EKS-A cluster reconciliation", "cluster", w.GetName())
+		if err = c.kubectl.RemoveAnnotationInNamespace(ctx, eksaClusterResourceType, w.GetName(), pausedAnnotation, managementCluster, w.GetNamespace()); err != nil {
+			return nil, fmt.Errorf("resuming EKS-A cluster %s reconciliation: %v", w.GetName(), err)
+		}
+	}
+
+	return metadata, nil
+}
+
+// extract unpackages the archive to a new temporary folder. The caller is responsible for removing it.
+func (c *Client) extract(archivePath, pattern string) (string, error) {
+	dir, err := os.MkdirTemp("", pattern)
+	if err != nil {
+		return "", fmt.Errorf("creating temporary restore folder: %v", err)
+	}
+
+	if err = c.packager.UnPackage(archivePath, dir); err != nil {
+		os.RemoveAll(dir)
+		return "", fmt.Errorf("extracting backup archive %s: %v", archivePath, err)
+	}
+
+	return dir, nil
+}
+
+// clusterObjects returns the EKS-A cluster object together with all the objects it references, skipping the ones in seen.
+// Returned objects are added to seen.
+func (c *Client) clusterObjects(ctx context.Context, kubeconfig string, obj *unstructured.Unstructured, seen map[string]struct{}) ([]*unstructured.Unstructured, error) {
+	cluster, err := toCluster(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	cleanObject(obj)
+	objs := []*unstructured.Unstructured{obj}
+	for _, ref := range clusterRefs(cluster) {
+		key := refKey(ref.Kind, ref.Name)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+
+		refObj := &unstructured.Unstructured{}
+		if err := c.kubectl.GetObject(ctx, resourceType(ref.Kind), ref.Name, cluster.Namespace, kubeconfig, refObj); err != nil {
+			return nil, fmt.Errorf("getting %s %s for cluster %s: %v", ref.Kind, ref.Name, cluster.Name, err)
+		}
+		cleanObject(refObj)
+		objs = append(objs, refObj)
+		seen[key] = struct{}{}
+	}
+
+	return objs, nil
+}
+
+func (c *Client) listObjects(ctx context.Context, kubeconfig, resourceType, namespace string) ([]*unstructured.Unstructured, error) {
+	list := &unstructured.UnstructuredList{}
+	err := c.kubectl.ListObjects(ctx, resourceType, namespace, kubeconfig, list)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("listing %s: %v", resourceType, err)
+	}
+
+	objs := make([]*unstructured.Unstructured, 0, len(list.Items))
+	for i := range list.Items {
+		cleanObject(&list.Items[i])
+		objs = append(objs, &list.Items[i])
+	}
+
+	return objs, nil
+}
+
+func (c *Client) apply(ctx context.Context, cluster *types.Cluster, objs []*unstructured.Unstructured) error {
+	if len(objs) == 0 {
+		return nil
+	}
+
+	content, err := marshalObjects(objs)
+	if err != nil {
+		return err
+	}
+
+	if err = c.kubectl.ApplyKubeSpecFromBytes(ctx, cluster, content); err != nil {
+		return fmt.Errorf("applying EKS-A objects: %v", err)
+	}
+
+	return nil
+}
+
+func isEksaCluster(obj *unstructured.Unstructured) bool {
+	return obj.GetKind() == v1alpha1.ClusterKind && obj.GroupVersionKind().Group == v1alpha1.GroupVersion.Group
+}
+
+func refKey(kind, name string) string {
+	return fmt.Sprintf("%s/%s", kind, name)
+}
+
+func writeManagementClusterConfig(path string, objs []*unstructured.Unstructured) error {
+	content, err := marshalObjects(objs)
+	if err != nil {
+		return err
+	}
+
+	if err = os.WriteFile(path, content, 0o600); err != nil {
+		return fmt.Errorf("writing management cluster config: %v", err)
+	}
+
+	return nil
+}
+
+func writeMetadata(path string, m *Metadata) error {
+	content, err := yaml.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("marshalling backup metadata: %v", err)
+	}
+
+	if err = os.WriteFile(path, content, 0o600); err != nil {
+		return fmt.Errorf("writing backup metadata: %v", err)
+	}
+
+	return nil
+}
+
+func readMetadata(path string) (*Metadata, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading backup metadata: %v", err)
+	}
+
+	m := &Metadata{}
+	if err = yaml.UnmarshalStrict(content, m); err != nil {
+		return nil, fmt.Errorf("parsing backup metadata: %v", err)
+	}
+
+	return m, nil
+}
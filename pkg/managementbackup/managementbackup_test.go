@@ -0,0 +1,296 @@
+package managementbackup_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/managementbackup"
+	"github.com/aws/eks-anywhere/pkg/managementbackup/mocks"
+	"github.com/aws/eks-anywhere/pkg/tar"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const kubeconfig = "mgmt.kubeconfig"
+
+type managementBackupTest struct {
+	*WithT
+	ctx        context.Context
+	kubectl    *mocks.MockKubectlClient
+	clusterctl *mocks.MockClusterctlClient
+	client     *managementbackup.Client
+	mgmt       *types.Cluster
+	archive    string
+	clusters   []*v1alpha1.Cluster
+}
+
+func newManagementBackupTest(t *testing.T) *managementBackupTest {
+	ctrl := gomock.NewController(t)
+	kubectl := mocks.NewMockKubectlClient(ctrl)
+	clusterctl := mocks.NewMockClusterctlClient(ctrl)
+
+	return &managementBackupTest{
+		WithT:      NewWithT(t),
+		ctx:        context.Background(),
+		kubectl:    kubectl,
+		clusterctl: clusterctl,
+		client:     managementbackup.NewClient(kubectl, clusterctl, tar.NewGzipPackager()),
+		mgmt:       &types.Cluster{Name: "mgmt", KubeconfigFile: kubeconfig},
+		archive:    filepath.Join(t.TempDir(), "backup.tar.gz"),
+		clusters: []*v1alpha1.Cluster{
+			cluster("mgmt", "mgmt"),
+			cluster("w01", "mgmt"),
+			cluster("other-w01", "other"),
+		},
+	}
+}
+
+func cluster(name, managedBy string) *v1alpha1.Cluster {
+	return &v1alpha1.Cluster{
+		TypeMeta: metav1.TypeMeta{APIVersion: v1alpha1.GroupVersion.String(), Kind: v1alpha1.ClusterKind},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       "default",
+			ResourceVersion: "1234",
+		},
+		Spec: v1alpha1.ClusterSpec{
+			ManagementCluster: v1alpha1.ManagementCluster{Name: managedBy},
+			DatacenterRef:     v1alpha1.Ref{Kind: v1alpha1.VSphereDatacenterKind, Name: "datacenter"},
+			ControlPlaneConfiguration: v1alpha1.ControlPlaneConfiguration{
+				MachineGroupRef: &v1alpha1.Ref{Kind: v1alpha1.VSphereMachineConfigKind, Name: name + "-cp"},
+			},
+			GitOpsRef: &v1alpha1.Ref{Kind: v1alpha1.FluxConfigKind, Name: "flux"},
+		},
+		Status: v1alpha1.ClusterStatus{FailureMessage: ptr("failed")},
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
+
+func object(apiVersion, kind, name string, labels map[string]string, owners ...metav1.OwnerReference) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion(apiVersion)
+	u.SetKind(kind)
+	u.SetName(name)
+	u.SetNamespace(constants.EksaSystemNamespace)
+	u.SetLabels(labels)
+	u.SetOwnerReferences(owners)
+	return u
+}
+
+func (tt *managementBackupTest) expectListClusters() {
+	tt.kubectl.EXPECT().ListObjects(tt.ctx, "clusters.anywhere.eks.amazonaws.com", "default", kubeconfig, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _, _ string, list kubernetes.ObjectList) error {
+			l := list.(*unstructured.UnstructuredList)
+			for _, c := range tt.clusters {
+				u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(c)
+				tt.Expect(err).NotTo(HaveOccurred())
+				l.Items = append(l.Items, unstructured.Unstructured{Object: u})
+			}
+			return nil
+		})
+}
+
+func (tt *managementBackupTest) expectGetRef(kind, name string) {
+	tt.kubectl.EXPECT().GetObject(tt.ctx, gomock.Any(), name, "default", kubeconfig, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _, _, _ string, obj runtime.Object) error {
+			u := obj.(*unstructured.Unstructured)
+			u.SetAPIVersion(v1alpha1.GroupVersion.String())
+			u.SetKind(kind)
+			u.SetName(name)
+			u.SetNamespace("default")
+			u.SetUID("uid")
+			return nil
+		})
+}
+
+func (tt *managementBackupTest) expectBackup() {
+	tt.expectListClusters()
+	tt.expectGetRef(v1alpha1.VSphereDatacenterKind, "datacenter")
+	tt.expectGetRef(v1alpha1.VSphereMachineConfigKind, "mgmt-cp")
+	tt.expectGetRef(v1alpha1.FluxConfigKind, "flux")
+	tt.expectGetRef(v1alpha1.VSphereMachineConfigKind, "w01-cp")
+	tt.kubectl.EXPECT().ListObjects(tt.ctx, "bundles.anywhere.eks.amazonaws.com", constants.EksaSystemNamespace, kubeconfig, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _, _ string, list kubernetes.ObjectList) error {
+			l := list.(*unstructured.UnstructuredList)
+			l.Items = append(l.Items, *object(v1alpha1.GroupVersion.String(), "Bundles", "bundles-10", nil))
+			return nil
+		})
+	tt.kubectl.EXPECT().ListObjects(tt.ctx, "releases.distro.eks.amazonaws.com", constants.EksaSystemNamespace, kubeconfig, gomock.Any()).
+		Return(apierrors.NewNotFound(schema.GroupResource{}, ""))
+
+	mgmtOwner := metav1.OwnerReference{APIVersion: clusterv1.GroupVersion.String(), Kind: "Cluster", Name: "mgmt"}
+	tt.clusterctl.EXPECT().BackupManagement(tt.ctx, tt.mgmt, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *types.Cluster, dir string) error {
+			tt.Expect(os.MkdirAll(dir, os.ModePerm)).To(Succeed())
+			for _, obj := range []*unstructured.Unstructured{
+				object(clusterv1.GroupVersion.String(), "Cluster", "mgmt", nil),
+				object(clusterv1.GroupVersion.String(), "Cluster", "w01", nil),
+				object("v1", "Secret", "mgmt-kubeconfig", map[string]string{clusterv1.ClusterLabelName: "mgmt"}),
+				object("v1", "Secret", "w01-kubeconfig", map[string]string{clusterv1.ClusterLabelName: "w01"}),
+				object("infrastructure.cluster.x-k8s.io/v1beta1", "VSphereMachineTemplate", "mgmt-cp", nil, mgmtOwner),
+			} {
+				content, err := yaml.Marshal(obj.Object)
+				tt.Expect(err).NotTo(HaveOccurred())
+				name := obj.GetKind() + "_" + obj.GetNamespace() + "_" + obj.GetName() + ".yaml"
+				tt.Expect(os.WriteFile(filepath.Join(dir, name), content, 0o600)).To(Succeed())
+			}
+			return nil
+		})
+}
+
+func TestClientBackupAndRestore(t *testing.T) {
+	tt := newManagementBackupTest(t)
+	tt.expectBackup()
+
+	metadata, err := tt.client.Backup(tt.ctx, tt.mgmt, "mgmt", "default", tt.archive)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(metadata.ManagementCluster).To(Equal("mgmt"))
+	tt.Expect(metadata.WorkloadClusters).To(ConsistOf("w01"))
+	tt.Expect(tt.archive).To(BeAnExistingFile())
+
+	newMgmt := &types.Cluster{Name: "mgmt", KubeconfigFile: "new-mgmt.kubeconfig"}
+	tt.kubectl.EXPECT().GetObject(tt.ctx, "clusters.anywhere.eks.amazonaws.com", "mgmt", "default", "new-mgmt.kubeconfig", gomock.Any())
+	tt.kubectl.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, newMgmt, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *types.Cluster, data []byte) error {
+			tt.Expect(string(data)).To(ContainSubstring("name: bundles-10"))
+			return nil
+		})
+	tt.kubectl.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, newMgmt, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *types.Cluster, data []byte) error {
+			content := string(data)
+			tt.Expect(content).To(ContainSubstring("name: w01"))
+			tt.Expect(content).To(ContainSubstring("name: w01-cp"))
+			tt.Expect(content).To(ContainSubstring("anywhere.eks.amazonaws.com/paused: \"true\""))
+			tt.Expect(content).NotTo(ContainSubstring("name: mgmt-cp"))
+			tt.Expect(content).NotTo(ContainSubstring("kind: FluxConfig\nmetadata"))
+			tt.Expect(content).NotTo(ContainSubstring("resourceVersion"))
+			tt.Expect(content).NotTo(ContainSubstring("failureMessage"))
+			return nil
+		})
+	tt.clusterctl.EXPECT().RestoreManagement(tt.ctx, newMgmt, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *types.Cluster, dir string) error {
+			entries, err := os.ReadDir(dir)
+			tt.Expect(err).NotTo(HaveOccurred())
+			names := make([]string, 0, len(entries))
+			for _, e := range entries {
+				names = append(names, e.Name())
+			}
+			sort.Strings(names)
+			tt.Expect(names).To(Equal([]string{"Cluster_eksa-system_w01.yaml", "Secret_eksa-system_w01-kubeconfig.yaml"}))
+			return nil
+		})
+	tt.kubectl.EXPECT().RemoveAnnotationInNamespace(tt.ctx, "clusters.anywhere.eks.amazonaws.com", "w01", "anywhere.eks.amazonaws.com/paused", newMgmt, "default")
+
+	metadata, err = tt.client.Restore(tt.ctx, newMgmt, tt.archive)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(metadata.WorkloadClusters).To(ConsistOf("w01"))
+}
+
+func TestClientBackupManagementConfig(t *testing.T) {
+	tt := newManagementBackupTest(t)
+	tt.expectBackup()
+
+	_, err := tt.client.Backup(tt.ctx, tt.mgmt, "mgmt", "default", tt.archive)
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	dir := t.TempDir()
+	tt.Expect(tar.NewGzipPackager().UnPackage(tt.archive, dir)).To(Succeed())
+	content, err := os.ReadFile(filepath.Join(dir, managementbackup.ManagementClusterConfigFile))
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(string(content)).To(ContainSubstring("name: mgmt-cp"))
+	tt.Expect(string(content)).To(ContainSubstring("name: flux"))
+	tt.Expect(string(content)).NotTo(ContainSubstring("name: w01"))
+}
+
+func TestClientBackupClusterNotFound(t *testing.T) {
+	tt := newManagementBackupTest(t)
+	tt.expectListClusters()
+
+	_, err := tt.client.Backup(tt.ctx, tt.mgmt, "mgmt-2", "default", tt.archive)
+	tt.Expect(err).To(MatchError(ContainSubstring("EKS-A cluster mgmt-2 not found in namespace default")))
+}
+
+func TestClientBackupNotManagementCluster(t *testing.T) {
+	tt := newManagementBackupTest(t)
+	tt.expectListClusters()
+
+	_, err := tt.client.Backup(tt.ctx, tt.mgmt, "w01", "default", tt.archive)
+	tt.Expect(err).To(MatchError(ContainSubstring("cluster w01 is not a management cluster, it's managed by mgmt")))
+}
+
+func TestClientBackupClusterctlError(t *testing.T) {
+	tt := newManagementBackupTest(t)
+	tt.clusters = tt.clusters[:1]
+	tt.expectListClusters()
+	tt.expectGetRef(v1alpha1.VSphereDatacenterKind, "datacenter")
+	tt.expectGetRef(v1alpha1.VSphereMachineConfigKind, "mgmt-cp")
+	tt.expectGetRef(v1alpha1.FluxConfigKind, "flux")
+	tt.kubectl.EXPECT().ListObjects(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, kubeconfig, gomock.Any()).Times(2)
+	tt.clusterctl.EXPECT().BackupManagement(tt.ctx, tt.mgmt, gomock.Any()).Return(errors.New("error in move"))
+
+	_, err := tt.client.Backup(tt.ctx, tt.mgmt, "mgmt", "default", tt.archive)
+	tt.Expect(err).To(MatchError(ContainSubstring("error in move")))
+	tt.Expect(tt.archive).NotTo(BeAnExistingFile())
+}
+
+func TestClientRestoreManagementClusterNotFound(t *testing.T) {
+	tt := newManagementBackupTest(t)
+	tt.expectBackup()
+
+	_, err := tt.client.Backup(tt.ctx, tt.mgmt, "mgmt", "default", tt.archive)
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	tt.kubectl.EXPECT().GetObject(tt.ctx, "clusters.anywhere.eks.amazonaws.com", "mgmt", "default", kubeconfig, gomock.Any()).
+		Return(apierrors.NewNotFound(schema.GroupResource{}, "mgmt"))
+
+	_, err = tt.client.Restore(tt.ctx, tt.mgmt, tt.archive)
+	tt.Expect(err).To(MatchError(ContainSubstring("EKS-A cluster mgmt not found in the new management cluster, create it first using the management-cluster.yaml file")))
+}
+
+func TestClientRestoreInvalidArchive(t *testing.T) {
+	tt := newManagementBackupTest(t)
+	tt.Expect(os.WriteFile(tt.archive, []byte("not an archive"), 0o600)).To(Succeed())
+
+	_, err := tt.client.Restore(tt.ctx, tt.mgmt, tt.archive)
+	tt.Expect(err).To(MatchError(ContainSubstring("extracting backup archive")))
+}
+
+func TestClientReadManagementClusterConfig(t *testing.T) {
+	tt := newManagementBackupTest(t)
+	tt.expectBackup()
+
+	_, err := tt.client.Backup(tt.ctx, tt.mgmt, "mgmt", "default", tt.archive)
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	metadata, config, err := tt.client.ReadManagementClusterConfig(tt.archive)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(metadata.ManagementCluster).To(Equal("mgmt"))
+	tt.Expect(string(config)).To(ContainSubstring("name: mgmt-cp"))
+}
+
+func TestClientReadManagementClusterConfigInvalidArchive(t *testing.T) {
+	tt := newManagementBackupTest(t)
+	tt.Expect(os.WriteFile(tt.archive, []byte("not an archive"), 0o600)).To(Succeed())
+
+	_, _, err := tt.client.ReadManagementClusterConfig(tt.archive)
+	tt.Expect(err).To(MatchError(ContainSubstring("extracting backup archive")))
+}
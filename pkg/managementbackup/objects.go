@@ -0,0 +1,130 @@
+package managementbackup
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+const lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// resourceType returns the kubectl resource type for an EKS-A kind, like vspheremachineconfigs.anywhere.eks.amazonaws.com.
+func resourceType(kind string) string {
+	return fmt.Sprintf("%ss.%s", strings.ToLower(kind), v1alpha1.GroupVersion.Group)
+}
+
+// objectFileName follows the same format clusterctl uses when saving objects to a directory.
+func objectFileName(obj *unstructured.Unstructured) string {
+	return fmt.Sprintf("%s_%s_%s.yaml", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+}
+
+// cleanObject removes the fields set by the API server so the object can be created in a different cluster.
+func cleanObject(obj *unstructured.Unstructured) {
+	unstructured.RemoveNestedField(obj.Object, "status")
+	obj.SetResourceVersion("")
+	obj.SetUID("")
+	obj.SetGeneration(0)
+	obj.SetManagedFields(nil)
+	obj.SetSelfLink("")
+	unstructured.RemoveNestedField(obj.Object, "metadata", "creationTimestamp")
+
+	annotations := obj.GetAnnotations()
+	delete(annotations, lastAppliedConfigAnnotation)
+	obj.SetAnnotations(annotations)
+}
+
+func writeObjects(dir string, objs ...*unstructured.Unstructured) error {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return fmt.Errorf("creating directory %s: %v", dir, err)
+	}
+
+	for _, obj := range objs {
+		content, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return fmt.Errorf("marshalling %s %s: %v", obj.GetKind(), obj.GetName(), err)
+		}
+
+		if err = os.WriteFile(filepath.Join(dir, objectFileName(obj)), content, 0o600); err != nil {
+			return fmt.Errorf("writing %s %s: %v", obj.GetKind(), obj.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+// readObjects reads all the objects saved in dir, sorted by file name.
+// It returns no objects if dir doesn't exist.
+func readObjects(dir string) ([]*unstructured.Unstructured, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("reading directory %s: %v", dir, err)
+	}
+	sort.Strings(files)
+
+	objs := make([]*unstructured.Unstructured, 0, len(files))
+	for _, f := range files {
+		obj, err := readObject(f)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, obj)
+	}
+
+	return objs, nil
+}
+
+func readObject(path string) (*unstructured.Unstructured, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %v", path, err)
+	}
+
+	obj := &unstructured.Unstructured{}
+	if err = yaml.Unmarshal(content, &obj.Object); err != nil {
+		return nil, fmt.Errorf("parsing %s: %v", path, err)
+	}
+
+	return obj, nil
+}
+
+// marshalObjects returns a multi document yaml with all objs.
+func marshalObjects(objs []*unstructured.Unstructured) ([]byte, error) {
+	b := &bytes.Buffer{}
+	for _, obj := range objs {
+		content, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return nil, fmt.Errorf("marshalling %s %s: %v", obj.GetKind(), obj.GetName(), err)
+		}
+		b.WriteString("---\n")
+		b.Write(content)
+	}
+	return b.Bytes(), nil
+}
+
+func toCluster(obj *unstructured.Unstructured) (*v1alpha1.Cluster, error) {
+	cluster := &v1alpha1.Cluster{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, cluster); err != nil {
+		return nil, fmt.Errorf("converting cluster %s: %v", obj.GetName(), err)
+	}
+	return cluster, nil
+}
+
+// clusterRefs returns the references to all the EKS-A objects a cluster depends on.
+func clusterRefs(cluster *v1alpha1.Cluster) []v1alpha1.Ref {
+	refs := []v1alpha1.Ref{cluster.Spec.DatacenterRef}
+	refs = append(refs, cluster.MachineConfigRefs()...)
+	refs = append(refs, cluster.Spec.IdentityProviderRefs...)
+	if cluster.Spec.GitOpsRef != nil {
+		refs = append(refs, *cluster.Spec.GitOpsRef)
+	}
+	return refs
+}
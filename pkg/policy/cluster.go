@@ -0,0 +1,76 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ClusterValidator validates objects against the policies in the policies ConfigMap of a cluster.
+// The ConfigMap is read for every validation, so policy changes apply right away, but its rules
+// are only compiled again when it changes. It's safe for concurrent use.
+type ClusterValidator struct {
+	client client.Reader
+
+	mu              sync.Mutex
+	resourceVersion string
+	evaluator       *Evaluator
+}
+
+// NewClusterValidator returns a ClusterValidator reading the policies with client.
+func NewClusterValidator(client client.Reader) *ClusterValidator {
+	return &ClusterValidator{client: client}
+}
+
+// ValidatePolicies returns a ViolationsError if obj doesn't follow the cluster policies.
+// If the cluster doesn't have a policies ConfigMap, all objects are valid.
+func (v *ClusterValidator) ValidatePolicies(ctx context.Context, obj runtime.Object) error {
+	evaluator, err := v.load(ctx)
+	if err != nil {
+		return err
+	}
+	if evaluator == nil {
+		return nil
+	}
+
+	return evaluator.Validate(obj)
+}
+
+func (v *ClusterValidator) load(ctx context.Context) (*Evaluator, error) {
+	cm := &corev1.ConfigMap{}
+	err := v.client.Get(ctx, client.ObjectKey{Name: ConfigMapName, Namespace: ConfigMapNamespace}, cm)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading policies: %v", err)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.evaluator != nil && cm.ResourceVersion != "" && cm.ResourceVersion == v.resourceVersion {
+		return v.evaluator, nil
+	}
+
+	evaluator, err := NewConfigMapEvaluator(cm)
+	if err != nil {
+		return nil, err
+	}
+	v.evaluator, v.resourceVersion = evaluator, cm.ResourceVersion
+
+	return evaluator, nil
+}
+
+// NewConfigMapEvaluator returns an Evaluator for the policies in the policies ConfigMap.
+func NewConfigMapEvaluator(cm *corev1.ConfigMap) (*Evaluator, error) {
+	policies, err := ParseConfigMap(cm)
+	if err != nil {
+		return nil, err
+	}
+	return NewEvaluator(policies)
+}
@@ -0,0 +1,71 @@
+package policy_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/pkg/policy"
+)
+
+func policiesConfigMap(content string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: policy.ConfigMapName, Namespace: policy.ConfigMapNamespace},
+		Data:       map[string]string{policy.ConfigMapKey: content},
+	}
+}
+
+func TestClusterValidatorNoPolicies(t *testing.T) {
+	g := NewWithT(t)
+	v := policy.NewClusterValidator(fake.NewClientBuilder().Build())
+	c := compliantCluster()
+	c.Spec.ControlPlaneConfiguration.Count = 1
+
+	g.Expect(v.ValidatePolicies(context.Background(), c)).To(Succeed())
+}
+
+func TestClusterValidatorViolation(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	client := fake.NewClientBuilder().WithObjects(policiesConfigMap(teamPolicies)).Build()
+	v := policy.NewClusterValidator(client)
+	c := compliantCluster()
+
+	g.Expect(v.ValidatePolicies(ctx, c)).To(Succeed())
+
+	c.Spec.ControlPlaneConfiguration.Count = 1
+	err := v.ValidatePolicies(ctx, c)
+	g.Expect(err).To(MatchError("Cluster test violates policy ha-control-plane: object.spec.controlPlaneConfiguration.count >= 3"))
+	g.Expect(err).To(BeAssignableToTypeOf(&policy.ViolationsError{}))
+}
+
+func TestClusterValidatorPolicyUpdated(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cm := policiesConfigMap(teamPolicies)
+	client := fake.NewClientBuilder().WithObjects(cm).Build()
+	v := policy.NewClusterValidator(client)
+	c := compliantCluster()
+	c.Spec.ControlPlaneConfiguration.Count = 1
+
+	g.Expect(v.ValidatePolicies(ctx, c)).NotTo(Succeed())
+
+	g.Expect(client.Get(ctx, types.NamespacedName{Name: cm.Name, Namespace: cm.Namespace}, cm)).To(Succeed())
+	cm.Data[policy.ConfigMapKey] = "rules: []"
+	g.Expect(client.Update(ctx, cm)).To(Succeed())
+
+	g.Expect(v.ValidatePolicies(ctx, c)).To(Succeed())
+}
+
+func TestClusterValidatorInvalidPolicies(t *testing.T) {
+	g := NewWithT(t)
+	client := fake.NewClientBuilder().WithObjects(policiesConfigMap("rules:\n- name: a\n  expression: object.\n")).Build()
+	v := policy.NewClusterValidator(client)
+
+	g.Expect(v.ValidatePolicies(context.Background(), compliantCluster())).To(MatchError(ContainSubstring("compiling policy a")))
+}
@@ -0,0 +1,146 @@
+package policy
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// objectVariable is the name of the variable with the evaluated object in the rule expressions.
+const objectVariable = "object"
+
+// Violation is the failure of an object to follow a policy rule.
+type Violation struct {
+	Rule    string
+	Kind    string
+	Name    string
+	Message string
+}
+
+func (v Violation) Error() string {
+	return fmt.Sprintf("%s %s violates policy %s: %s", v.Kind, v.Name, v.Rule, v.Message)
+}
+
+// ViolationsError is returned when one or more objects don't follow the policies.
+type ViolationsError struct {
+	Violations []Violation
+}
+
+func (e *ViolationsError) Error() string {
+	msgs := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		msgs = append(msgs, v.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+type compiledRule struct {
+	Rule
+	program cel.Program
+}
+
+// Evaluator checks objects against compiled policies.
+type Evaluator struct {
+	rules []compiledRule
+}
+
+// NewEvaluator compiles the rule expressions of policies. It fails if any of them
+// is not a valid CEL expression returning a bool.
+func NewEvaluator(policies *Policies) (*Evaluator, error) {
+	env, err := cel.NewEnv(cel.Variable(objectVariable, cel.DynType))
+	if err != nil {
+		return nil, fmt.Errorf("creating policy environment: %v", err)
+	}
+
+	e := &Evaluator{rules: make([]compiledRule, 0, len(policies.Rules))}
+	for _, r := range policies.Rules {
+		ast, issues := env.Compile(r.Expression)
+		if issues != nil && issues.Err() != nil {
+			return nil, fmt.Errorf("compiling policy %s: %v", r.Name, issues.Err())
+		}
+		if t := ast.OutputType(); t != cel.BoolType && t != cel.DynType {
+			return nil, fmt.Errorf("compiling policy %s: expression must return a bool, got %s", r.Name, t)
+		}
+
+		program, err := env.Program(ast)
+		if err != nil {
+			return nil, fmt.Errorf("compiling policy %s: %v", r.Name, err)
+		}
+		e.rules = append(e.rules, compiledRule{Rule: r, program: program})
+	}
+
+	return e, nil
+}
+
+// Evaluate returns the violations of the policy rules applying to obj.
+// Rules that fail to evaluate for obj, for example because they access a field
+// that isn't set without checking it with has(), are reported as violations.
+func (e *Evaluator) Evaluate(obj runtime.Object) ([]Violation, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("converting object to evaluate policies: %v", err)
+	}
+
+	kind := kindOf(obj)
+	name := ""
+	if accessor, err := meta.Accessor(obj); err == nil {
+		name = accessor.GetName()
+	}
+
+	var violations []Violation
+	for _, r := range e.rules {
+		if !r.appliesTo(kind) {
+			continue
+		}
+
+		violation := Violation{Rule: r.Name, Kind: kind, Name: name, Message: r.message()}
+		out, _, err := r.program.Eval(map[string]interface{}{objectVariable: content})
+		if err != nil {
+			violation.Message = fmt.Sprintf("evaluating expression: %v", err)
+			violations = append(violations, violation)
+			continue
+		}
+
+		passed, ok := out.Value().(bool)
+		if !ok {
+			violation.Message = fmt.Sprintf("expression returned %v instead of a bool", out.Value())
+			violations = append(violations, violation)
+			continue
+		}
+		if !passed {
+			violations = append(violations, violation)
+		}
+	}
+
+	return violations, nil
+}
+
+// Validate returns a ViolationsError with the violations of the policies by objs, if any.
+func (e *Evaluator) Validate(objs ...runtime.Object) error {
+	var violations []Violation
+	for _, obj := range objs {
+		v, err := e.Evaluate(obj)
+		if err != nil {
+			return err
+		}
+		violations = append(violations, v...)
+	}
+
+	if len(violations) > 0 {
+		return &ViolationsError{Violations: violations}
+	}
+	return nil
+}
+
+// kindOf returns the kind of obj. The type meta is not always set for typed objects,
+// in which case the name of the go type, which is the same for API types, is used.
+func kindOf(obj runtime.Object) string {
+	if kind := obj.GetObjectKind().GroupVersionKind().Kind; kind != "" {
+		return kind
+	}
+	return reflect.Indirect(reflect.ValueOf(obj)).Type().Name()
+}
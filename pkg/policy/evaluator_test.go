@@ -0,0 +1,145 @@
+package policy_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/policy"
+)
+
+const teamPolicies = `
+rules:
+- name: allowed-kubernetes-versions
+  kinds: [Cluster]
+  expression: object.spec.kubernetesVersion in ["1.23", "1.24"]
+  message: kubernetes version must be 1.23 or 1.24
+- name: ha-control-plane
+  kinds: [Cluster]
+  expression: object.spec.controlPlaneConfiguration.count >= 3
+- name: registry-mirror
+  kinds: [Cluster]
+  expression: has(object.spec.registryMirrorConfiguration)
+  message: a registry mirror is required
+- name: allowed-os-families
+  kinds: [VSphereMachineConfig]
+  expression: object.spec.osFamily == "bottlerocket"
+- name: no-insecure-registry
+  kinds: [Cluster]
+  expression: '!has(object.spec.registryMirrorConfiguration) || !has(object.spec.registryMirrorConfiguration.insecureSkipVerify) || !object.spec.registryMirrorConfiguration.insecureSkipVerify'
+  message: insecureSkipVerify is forbidden
+`
+
+func newEvaluator(t *testing.T, content string) *policy.Evaluator {
+	t.Helper()
+	p, err := policy.Parse([]byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := policy.NewEvaluator(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func compliantCluster() *anywherev1.Cluster {
+	c := anywherev1.NewCluster("test")
+	c.Spec.KubernetesVersion = anywherev1.Kube124
+	c.Spec.ControlPlaneConfiguration.Count = 3
+	c.Spec.RegistryMirrorConfiguration = &anywherev1.RegistryMirrorConfiguration{Endpoint: "1.2.3.4"}
+	return c
+}
+
+func TestEvaluatorValidateSuccess(t *testing.T) {
+	g := NewWithT(t)
+	e := newEvaluator(t, teamPolicies)
+	machineConfig := &anywherev1.VSphereMachineConfig{}
+	machineConfig.Name = "cp"
+	machineConfig.Spec.OSFamily = anywherev1.Bottlerocket
+
+	g.Expect(e.Validate(compliantCluster(), machineConfig)).To(Succeed())
+}
+
+func TestEvaluatorEvaluateViolations(t *testing.T) {
+	g := NewWithT(t)
+	e := newEvaluator(t, teamPolicies)
+	c := compliantCluster()
+	c.Spec.KubernetesVersion = anywherev1.Kube121
+	c.Spec.ControlPlaneConfiguration.Count = 1
+	c.Spec.RegistryMirrorConfiguration.InsecureSkipVerify = true
+
+	violations, err := e.Evaluate(c)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(violations).To(Equal([]policy.Violation{
+		{Rule: "allowed-kubernetes-versions", Kind: "Cluster", Name: "test", Message: "kubernetes version must be 1.23 or 1.24"},
+		{Rule: "ha-control-plane", Kind: "Cluster", Name: "test", Message: "object.spec.controlPlaneConfiguration.count >= 3"},
+		{Rule: "no-insecure-registry", Kind: "Cluster", Name: "test", Message: "insecureSkipVerify is forbidden"},
+	}))
+}
+
+func TestEvaluatorValidateKinds(t *testing.T) {
+	g := NewWithT(t)
+	e := newEvaluator(t, teamPolicies)
+	machineConfig := &anywherev1.VSphereMachineConfig{}
+	machineConfig.Name = "cp"
+	machineConfig.Spec.OSFamily = anywherev1.Ubuntu
+	// The type meta isn't set, so the kind comes from the go type.
+	snowMachineConfig := &anywherev1.SnowMachineConfig{}
+	snowMachineConfig.Name = "snow"
+	snowMachineConfig.Spec.OSFamily = anywherev1.Ubuntu
+
+	err := e.Validate(compliantCluster(), machineConfig, snowMachineConfig)
+	g.Expect(err).To(MatchError("VSphereMachineConfig cp violates policy allowed-os-families: object.spec.osFamily == \"bottlerocket\""))
+}
+
+func TestEvaluatorEvaluateMissingField(t *testing.T) {
+	g := NewWithT(t)
+	e := newEvaluator(t, `
+rules:
+- name: proxy
+  expression: object.spec.proxyConfiguration.httpProxy != ""
+`)
+
+	violations, err := e.Evaluate(compliantCluster())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(violations).To(HaveLen(1))
+	g.Expect(violations[0].Message).To(ContainSubstring("evaluating expression: no such key: proxyConfiguration"))
+}
+
+func TestNewEvaluatorErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   string
+		wantErr string
+	}{
+		{
+			name: "syntax error",
+			rules: `
+rules:
+- name: broken
+  expression: object.spec.
+`,
+			wantErr: "compiling policy broken:",
+		},
+		{
+			name: "not a bool",
+			rules: `
+rules:
+- name: count
+  expression: "3"
+`,
+			wantErr: "compiling policy count: expression must return a bool, got int",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			p, err := policy.Parse([]byte(tt.rules))
+			g.Expect(err).NotTo(HaveOccurred())
+			_, err = policy.NewEvaluator(p)
+			g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package policy
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+const (
+	// ConfigMapName is the name of the ConfigMap in the eksa-system namespace holding the policies.
+	ConfigMapName = "eksa-policies"
+	// ConfigMapNamespace is the namespace of the policies ConfigMap.
+	ConfigMapNamespace = constants.EksaSystemNamespace
+	// ConfigMapKey is the key in the ConfigMap data with the policies in YAML.
+	ConfigMapKey = "policies.yaml"
+)
+
+// Policies are the rules every EKS Anywhere object created or updated in a management cluster must follow.
+type Policies struct {
+	Rules []Rule `json:"rules"`
+}
+
+// Rule is a CEL expression evaluated against the objects of the kinds it applies to.
+// The object is available in the expression as the variable object, e.g.
+// object.spec.controlPlaneConfiguration.count >= 3. Objects for which the expression
+// evaluates to false violate the rule.
+type Rule struct {
+	Name string `json:"name"`
+	// Kinds are the kinds of objects the rule applies to. An empty list applies the rule to all of them.
+	Kinds      []string `json:"kinds,omitempty"`
+	Expression string   `json:"expression"`
+	// Message describes the violation. It defaults to the expression.
+	Message string `json:"message,omitempty"`
+}
+
+func (r Rule) appliesTo(kind string) bool {
+	if len(r.Kinds) == 0 {
+		return true
+	}
+	for _, k := range r.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Rule) message() string {
+	if r.Message != "" {
+		return r.Message
+	}
+	return r.Expression
+}
+
+// Parse reads the policies from their YAML content.
+func Parse(content []byte) (*Policies, error) {
+	p := &Policies{}
+	if err := yaml.UnmarshalStrict(content, p); err != nil {
+		return nil, fmt.Errorf("parsing policies: %v", err)
+	}
+
+	names := make(map[string]struct{}, len(p.Rules))
+	for i, r := range p.Rules {
+		if r.Name == "" {
+			return nil, fmt.Errorf("policy rule #%d must have a name", i+1)
+		}
+		if r.Expression == "" {
+			return nil, fmt.Errorf("policy rule %s must have an expression", r.Name)
+		}
+		if _, ok := names[r.Name]; ok {
+			return nil, fmt.Errorf("duplicated policy rule %s", r.Name)
+		}
+		names[r.Name] = struct{}{}
+	}
+
+	return p, nil
+}
+
+// ParseConfigMap reads the policies from the policies ConfigMap.
+func ParseConfigMap(cm *corev1.ConfigMap) (*Policies, error) {
+	content, ok := cm.Data[ConfigMapKey]
+	if !ok {
+		return nil, fmt.Errorf("configmap %s/%s doesn't have key %s", cm.Namespace, cm.Name, ConfigMapKey)
+	}
+	return Parse([]byte(content))
+}
@@ -0,0 +1,70 @@
+package policy_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/policy"
+)
+
+func TestParse(t *testing.T) {
+	g := NewWithT(t)
+	p, err := policy.Parse([]byte(teamPolicies))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(p.Rules).To(HaveLen(5))
+	g.Expect(p.Rules[0]).To(Equal(policy.Rule{
+		Name:       "allowed-kubernetes-versions",
+		Kinds:      []string{"Cluster"},
+		Expression: `object.spec.kubernetesVersion in ["1.23", "1.24"]`,
+		Message:    "kubernetes version must be 1.23 or 1.24",
+	}))
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "unknown field",
+			content: "rules:\n- name: a\n  expresion: 'true'\n",
+			wantErr: "parsing policies:",
+		},
+		{
+			name:    "no name",
+			content: "rules:\n- expression: 'true'\n",
+			wantErr: "policy rule #1 must have a name",
+		},
+		{
+			name:    "no expression",
+			content: "rules:\n- name: a\n",
+			wantErr: "policy rule a must have an expression",
+		},
+		{
+			name:    "duplicated",
+			content: "rules:\n- name: a\n  expression: 'true'\n- name: a\n  expression: 'false'\n",
+			wantErr: "duplicated policy rule a",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			_, err := policy.Parse([]byte(tt.content))
+			g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
+
+func TestParseConfigMapMissingKey(t *testing.T) {
+	g := NewWithT(t)
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: policy.ConfigMapName, Namespace: policy.ConfigMapNamespace},
+		Data:       map[string]string{"rules": ""},
+	}
+	_, err := policy.ParseConfigMap(cm)
+	g.Expect(err).To(MatchError("configmap eksa-system/eksa-policies doesn't have key policies.yaml"))
+}
@@ -546,14 +546,16 @@ func NeedsNewWorkloadTemplate(oldSpec, newSpec *cluster.Spec, oldCsdc, newCsdc *
 		return true
 	}
 	if !v1alpha1.WorkerNodeGroupConfigurationSliceTaintsEqual(oldSpec.Cluster.Spec.WorkerNodeGroupConfigurations, newSpec.Cluster.Spec.WorkerNodeGroupConfigurations) ||
-		!v1alpha1.WorkerNodeGroupConfigurationsLabelsMapEqual(oldSpec.Cluster.Spec.WorkerNodeGroupConfigurations, newSpec.Cluster.Spec.WorkerNodeGroupConfigurations) {
+		!v1alpha1.WorkerNodeGroupConfigurationsLabelsMapEqual(oldSpec.Cluster.Spec.WorkerNodeGroupConfigurations, newSpec.Cluster.Spec.WorkerNodeGroupConfigurations) ||
+		!v1alpha1.WorkerNodeGroupConfigurationsKubeletConfigurationEqual(oldSpec.Cluster.Spec.WorkerNodeGroupConfigurations, newSpec.Cluster.Spec.WorkerNodeGroupConfigurations) {
 		return true
 	}
 	return AnyImmutableFieldChanged(oldCsdc, newCsdc, oldCsmc, newCsmc, log)
 }
 
 func NeedsNewKubeadmConfigTemplate(newWorkerNodeGroup *v1alpha1.WorkerNodeGroupConfiguration, oldWorkerNodeGroup *v1alpha1.WorkerNodeGroupConfiguration) bool {
-	return !v1alpha1.TaintsSliceEqual(newWorkerNodeGroup.Taints, oldWorkerNodeGroup.Taints) || !v1alpha1.MapEqual(newWorkerNodeGroup.Labels, oldWorkerNodeGroup.Labels)
+	return !v1alpha1.TaintsSliceEqual(newWorkerNodeGroup.Taints, oldWorkerNodeGroup.Taints) || !v1alpha1.MapEqual(newWorkerNodeGroup.Labels, oldWorkerNodeGroup.Labels) ||
+		!newWorkerNodeGroup.KubeletConfiguration.Equal(oldWorkerNodeGroup.KubeletConfiguration)
 }
 
 func needsNewEtcdTemplate(oldSpec, newSpec *cluster.Spec, oldCsmc, newCsmc *v1alpha1.CloudStackMachineConfig, log logr.Logger) bool {
@@ -759,13 +761,20 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, controlPlaneMachineSpec, etcd
 	sharedExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs()
 	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
 		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf)).
-		Append(clusterapi.ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration))
+		Append(clusterapi.ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration)).
+		Append(clusterapi.KubeletConfigurationExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.KubeletConfiguration))
 	apiServerExtraArgs := clusterapi.OIDCToExtraArgs(clusterSpec.OIDCConfig).
 		Append(clusterapi.AwsIamAuthExtraArgs(clusterSpec.AWSIamConfig)).
 		Append(clusterapi.PodIAMAuthExtraArgs(clusterSpec.Cluster.Spec.PodIAMConfig)).
-		Append(sharedExtraArgs)
+		Append(clusterapi.EncryptionConfigurationExtraArgs(clusterSpec.Cluster.Spec.EncryptionConfiguration)).
+		Append(clusterapi.PodSecurityExtraArgs(clusterSpec.Cluster.Spec.PodSecurity)).
+		Append(sharedExtraArgs).
+		Append(clusterapi.APIServerExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration))
 	controllerManagerExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
-		Append(clusterapi.NodeCIDRMaskExtraArgs(&clusterSpec.Cluster.Spec.ClusterNetwork))
+		Append(clusterapi.NodeCIDRMaskExtraArgs(&clusterSpec.Cluster.Spec.ClusterNetwork)).
+		Append(clusterapi.ControllerManagerExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration))
+	schedulerExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
+		Append(clusterapi.SchedulerExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration))
 
 	values := map[string]interface{}{
 		"clusterName":                                clusterSpec.Cluster.Name,
@@ -812,18 +821,21 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, controlPlaneMachineSpec, etcd
 		"etcdExtraArgs":                              etcdExtraArgs.ToPartialYaml(),
 		"etcdCipherSuites":                           crypto.SecureCipherSuitesString(),
 		"controllermanagerExtraArgs":                 controllerManagerExtraArgs.ToPartialYaml(),
-		"schedulerExtraArgs":                         sharedExtraArgs.ToPartialYaml(),
+		"schedulerExtraArgs":                         schedulerExtraArgs.ToPartialYaml(),
 		"format":                                     format,
 		"externalEtcdVersion":                        bundle.KubeDistro.EtcdVersion,
 		"etcdImage":                                  bundle.KubeDistro.EtcdImage.VersionedImage(),
 		"eksaSystemNamespace":                        constants.EksaSystemNamespace,
 	}
 
-	auditPolicy, err := common.GetAuditPolicy(clusterSpec.Cluster.Spec.KubernetesVersion)
+	auditPolicy, err := common.AuditPolicy(clusterSpec)
 	if err != nil {
 		return nil, err
 	}
 	values["auditPolicy"] = auditPolicy
+	values["auditLogMaxAge"] = clusterSpec.Cluster.Spec.AuditPolicy.LogMaxAgeOrDefault()
+	values["auditLogMaxBackup"] = clusterSpec.Cluster.Spec.AuditPolicy.LogMaxBackupOrDefault()
+	values["auditLogMaxSize"] = clusterSpec.Cluster.Spec.AuditPolicy.LogMaxSizeOrDefault()
 
 	fillDiskOffering(values, controlPlaneMachineSpec.DiskOffering, "ControlPlane")
 	fillDiskOffering(values, etcdMachineSpec.DiskOffering, "Etcd")
@@ -862,6 +874,18 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, controlPlaneMachineSpec, etcd
 		values["upgradeRolloutStrategy"] = true
 		values["maxSurge"] = clusterSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy.RollingUpdate.MaxSurge
 	}
+	if clusterSpec.Cluster.Spec.EncryptionConfiguration != nil {
+		values["encryptionConfig"] = true
+		values["kmsPluginSocketDirs"] = clusterapi.KMSPluginSocketDirs(clusterSpec.Cluster.Spec.EncryptionConfiguration)
+	}
+
+	if clusterSpec.Cluster.Spec.PodSecurity != nil {
+		admissionConfig, err := clusterapi.PodSecurityAdmissionConfiguration(clusterSpec.Cluster.Spec.PodSecurity)
+		if err != nil {
+			return nil, err
+		}
+		values["admissionConfig"] = admissionConfig
+	}
 
 	return values, nil
 }
@@ -912,7 +936,8 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupMachineSpec v1
 	format := "cloud-config"
 	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
 		Append(clusterapi.WorkerNodeLabelsExtraArgs(workerNodeGroupConfiguration)).
-		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf))
+		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf)).
+		Append(clusterapi.KubeletConfigurationExtraArgs(workerNodeGroupConfiguration.KubeletConfiguration))
 
 	values := map[string]interface{}{
 		"clusterName":                      clusterSpec.Cluster.Name,
@@ -0,0 +1,107 @@
+package cloudstack
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	cloudstackv1 "sigs.k8s.io/cluster-api-provider-cloudstack/api/v1beta2"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	yamlcapi "github.com/aws/eks-anywhere/pkg/clusterapi/yaml"
+	"github.com/aws/eks-anywhere/pkg/yamlutil"
+)
+
+// ControlPlane represents a CAPI CloudStack control plane.
+type ControlPlane = clusterapi.ControlPlane[*cloudstackv1.CloudStackCluster, *cloudstackv1.CloudStackMachineTemplate]
+
+// ControlPlaneSpec builds a CloudStack ControlPlane definition based on an eks-a cluster spec.
+func ControlPlaneSpec(ctx context.Context, logger logr.Logger, client kubernetes.Client, spec *cluster.Spec) (*ControlPlane, error) {
+	templateBuilder, err := newControllerTemplateBuilder(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	controlPlaneYaml, err := templateBuilder.GenerateCAPISpecControlPlane(
+		spec,
+		func(values map[string]interface{}) {
+			values[cpTemplateNameKey] = clusterapi.ControlPlaneMachineTemplateName(spec.Cluster)
+			values["cloudstackControlPlaneSshAuthorizedKey"] = sshAuthorizedKey(templateBuilder.controlPlaneMachineSpec.Users)
+			values["cloudstackEtcdSshAuthorizedKey"] = sshAuthorizedKey(templateBuilder.etcdMachineSpec.Users)
+			values[etcdTemplateNameKey] = clusterapi.EtcdMachineTemplateName(spec.Cluster)
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "generating cloudstack control plane yaml spec")
+	}
+
+	parser, builder, err := yamlcapi.NewControlPlaneParserAndBuilder(
+		logger,
+		yamlutil.NewMapping(
+			"CloudStackCluster",
+			func() *cloudstackv1.CloudStackCluster {
+				return &cloudstackv1.CloudStackCluster{}
+			},
+		),
+		machineTemplateMapping(),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "building cloudstack control plane parser")
+	}
+
+	if err = parser.Parse(controlPlaneYaml, builder); err != nil {
+		return nil, errors.Wrap(err, "parsing cloudstack control plane yaml")
+	}
+
+	cp := builder.ControlPlane
+	if err = cp.UpdateImmutableObjectNames(ctx, client, getMachineTemplate, machineTemplateEqual); err != nil {
+		return nil, errors.Wrap(err, "updating cloudstack immutable object names")
+	}
+
+	return cp, nil
+}
+
+// newControllerTemplateBuilder builds a CloudStackTemplateBuilder from the machine configs in an eks-a cluster spec.
+func newControllerTemplateBuilder(spec *cluster.Spec) (*CloudStackTemplateBuilder, error) {
+	controlPlaneMachineConfig := spec.CloudStackMachineConfig(spec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name)
+	if controlPlaneMachineConfig == nil {
+		return nil, errors.New("control plane CloudStackMachineConfig is missing from cluster spec")
+	}
+
+	etcdMachineSpec := &v1alpha1.CloudStackMachineConfigSpec{}
+	if spec.Cluster.Spec.ExternalEtcdConfiguration != nil {
+		etcdMachineConfig := spec.CloudStackMachineConfig(spec.Cluster.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name)
+		if etcdMachineConfig == nil {
+			return nil, errors.New("etcd CloudStackMachineConfig is missing from cluster spec")
+		}
+		etcdMachineSpec = &etcdMachineConfig.Spec
+	}
+
+	workerNodeGroupMachineSpecs := make(map[string]v1alpha1.CloudStackMachineConfigSpec, len(spec.Cluster.Spec.WorkerNodeGroupConfigurations))
+	for _, wnConfig := range spec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		machineConfig := spec.CloudStackMachineConfig(wnConfig.MachineGroupRef.Name)
+		if machineConfig == nil {
+			return nil, fmt.Errorf("CloudStackMachineConfig %s for worker node group %s is missing from cluster spec", wnConfig.MachineGroupRef.Name, wnConfig.Name)
+		}
+		workerNodeGroupMachineSpecs[wnConfig.MachineGroupRef.Name] = machineConfig.Spec
+	}
+
+	return &CloudStackTemplateBuilder{
+		controlPlaneMachineSpec:     &controlPlaneMachineConfig.Spec,
+		WorkerNodeGroupMachineSpecs: workerNodeGroupMachineSpecs,
+		etcdMachineSpec:             etcdMachineSpec,
+		now:                         time.Now,
+	}, nil
+}
+
+func sshAuthorizedKey(users []v1alpha1.UserConfiguration) string {
+	if len(users) == 0 || len(users[0].SshAuthorizedKeys) == 0 {
+		return ""
+	}
+	return users[0].SshAuthorizedKeys[0]
+}
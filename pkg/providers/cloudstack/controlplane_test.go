@@ -0,0 +1,103 @@
+package cloudstack_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack"
+)
+
+const testClusterConfigMainFilename = "testdata/cluster_main.yaml"
+
+func TestControlPlaneSpecNewCluster(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	client := test.NewFakeKubeClient()
+	spec := test.NewFullClusterSpec(t, testClusterConfigMainFilename)
+	spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host = "1.2.3.4:6443"
+
+	cp, err := cloudstack.ControlPlaneSpec(ctx, logger, client, spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cp).NotTo(BeNil())
+	g.Expect(cp.Cluster.Name).To(Equal("test"))
+	g.Expect(cp.KubeadmControlPlane.Name).To(Equal("test"))
+	g.Expect(cp.ProviderCluster.Name).To(Equal("test"))
+	g.Expect(cp.ProviderCluster.Spec.ControlPlaneEndpoint.Host).To(Equal("1.2.3.4"))
+	g.Expect(cp.ControlPlaneMachineTemplate.Name).To(Equal("test-control-plane-1"))
+	g.Expect(cp.KubeadmControlPlane.Spec.MachineTemplate.InfrastructureRef.Name).To(Equal("test-control-plane-1"))
+	g.Expect(cp.EtcdCluster).NotTo(BeNil())
+	g.Expect(cp.EtcdMachineTemplate.Name).To(Equal("test-etcd-1"))
+	g.Expect(cp.EtcdCluster.Spec.InfrastructureTemplate.Name).To(Equal("test-etcd-1"))
+}
+
+func TestControlPlaneSpecUpdateMachineTemplates(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	spec := test.NewFullClusterSpec(t, testClusterConfigMainFilename)
+	spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host = "1.2.3.4:6443"
+
+	cp, err := cloudstack.ControlPlaneSpec(ctx, logger, test.NewFakeKubeClient(), spec)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	oldCPTemplate := cp.ControlPlaneMachineTemplate.DeepCopy()
+	oldCPTemplate.Spec.Spec.Spec.Offering.Name = "old-offering"
+	oldEtcdTemplate := cp.EtcdMachineTemplate.DeepCopy()
+	oldEtcdTemplate.Annotations["symlinks.cloudstack.anywhere.eks.amazonaws.com/v1alpha1"] = "/var/old:/data/old"
+	client := test.NewFakeKubeClient(cp.KubeadmControlPlane.DeepCopy(), cp.EtcdCluster.DeepCopy(), oldCPTemplate, oldEtcdTemplate)
+
+	cp, err = cloudstack.ControlPlaneSpec(ctx, logger, client, spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cp.ControlPlaneMachineTemplate.Name).To(Equal("test-control-plane-2"))
+	g.Expect(cp.KubeadmControlPlane.Spec.MachineTemplate.InfrastructureRef.Name).To(Equal("test-control-plane-2"))
+	g.Expect(cp.EtcdMachineTemplate.Name).To(Equal("test-etcd-2"))
+	g.Expect(cp.EtcdCluster.Spec.InfrastructureTemplate.Name).To(Equal("test-etcd-2"))
+}
+
+func TestControlPlaneSpecNoChangesMachineTemplates(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	spec := test.NewFullClusterSpec(t, testClusterConfigMainFilename)
+	spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host = "1.2.3.4:6443"
+
+	cp, err := cloudstack.ControlPlaneSpec(ctx, logger, test.NewFakeKubeClient(), spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	client := test.NewFakeKubeClient(
+		cp.KubeadmControlPlane.DeepCopy(),
+		cp.EtcdCluster.DeepCopy(),
+		cp.ControlPlaneMachineTemplate.DeepCopy(),
+		cp.EtcdMachineTemplate.DeepCopy(),
+	)
+
+	cp, err = cloudstack.ControlPlaneSpec(ctx, logger, client, spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cp.ControlPlaneMachineTemplate.Name).To(Equal("test-control-plane-1"))
+	g.Expect(cp.EtcdMachineTemplate.Name).To(Equal("test-etcd-1"))
+}
+
+func TestControlPlaneSpecMissingMachineConfig(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	spec := test.NewFullClusterSpec(t, testClusterConfigMainFilename)
+	delete(spec.CloudStackMachineConfigs, "test-cp")
+
+	_, err := cloudstack.ControlPlaneSpec(ctx, logger, test.NewFakeKubeClient(), spec)
+	g.Expect(err).To(MatchError(ContainSubstring("control plane CloudStackMachineConfig is missing from cluster spec")))
+}
+
+func TestControlPlaneSpecMissingEtcdMachineConfig(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	spec := test.NewFullClusterSpec(t, testClusterConfigMainFilename)
+	delete(spec.CloudStackMachineConfigs, "test-etcd")
+
+	_, err := cloudstack.ControlPlaneSpec(ctx, logger, test.NewFakeKubeClient(), spec)
+	g.Expect(err).To(MatchError(ContainSubstring("etcd CloudStackMachineConfig is missing from cluster spec")))
+}
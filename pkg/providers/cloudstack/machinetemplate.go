@@ -0,0 +1,37 @@
+package cloudstack
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	cloudstackv1 "sigs.k8s.io/cluster-api-provider-cloudstack/api/v1beta2"
+
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/yamlutil"
+)
+
+func getMachineTemplate(ctx context.Context, client kubernetes.Client, name, namespace string) (*cloudstackv1.CloudStackMachineTemplate, error) {
+	m := &cloudstackv1.CloudStackMachineTemplate{}
+	if err := client.Get(ctx, name, namespace, m); err != nil {
+		return nil, errors.Wrap(err, "reading cloudstackMachineTemplate")
+	}
+
+	return m, nil
+}
+
+// machineTemplateEqual also compares annotations since CAPC reads the disk offering
+// and symlinks configuration from them.
+func machineTemplateEqual(new, old *cloudstackv1.CloudStackMachineTemplate) bool {
+	return equality.Semantic.DeepDerivative(new.Spec, old.Spec) &&
+		equality.Semantic.DeepDerivative(new.Annotations, old.Annotations)
+}
+
+func machineTemplateMapping() yamlutil.Mapping[*cloudstackv1.CloudStackMachineTemplate] {
+	return yamlutil.NewMapping(
+		CloudStackMachineTemplateKind,
+		func() *cloudstackv1.CloudStackMachineTemplate {
+			return &cloudstackv1.CloudStackMachineTemplate{}
+		},
+	)
+}
@@ -0,0 +1,221 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	apiv1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	c "github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+	"github.com/aws/eks-anywhere/pkg/controller/serverside"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+)
+
+// CNIReconciler is an interface for reconciling CNI in the CloudStack cluster reconciler.
+type CNIReconciler interface {
+	Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *c.Spec) (controller.Result, error)
+}
+
+// RemoteClientRegistry is an interface that defines methods for remote clients.
+type RemoteClientRegistry interface {
+	GetClient(ctx context.Context, cluster client.ObjectKey) (client.Client, error)
+}
+
+// IPValidator is an interface that defines methods to validate the control plane IP.
+type IPValidator interface {
+	ValidateControlPlaneIP(ctx context.Context, log logr.Logger, spec *c.Spec) (controller.Result, error)
+}
+
+// Reconciler contains dependencies for a CloudStack reconciler.
+type Reconciler struct {
+	client               client.Client
+	validatorRegistry    cloudstack.ValidatorRegistry
+	cniReconciler        CNIReconciler
+	remoteClientRegistry RemoteClientRegistry
+	ipValidator          IPValidator
+	*serverside.ObjectApplier
+}
+
+// New defines a new CloudStack reconciler.
+func New(client client.Client, validatorRegistry cloudstack.ValidatorRegistry, cniReconciler CNIReconciler, remoteClientRegistry RemoteClientRegistry, ipValidator IPValidator) *Reconciler {
+	return &Reconciler{
+		client:               client,
+		validatorRegistry:    validatorRegistry,
+		cniReconciler:        cniReconciler,
+		remoteClientRegistry: remoteClientRegistry,
+		ipValidator:          ipValidator,
+		ObjectApplier:        serverside.NewObjectApplier(client),
+	}
+}
+
+// CloudStackExecConfig builds the cmk exec config from the credentials secrets referenced
+// by the availability zones in a CloudStackDatacenterConfig.
+func CloudStackExecConfig(ctx context.Context, cli client.Client, datacenterConfig *anywherev1.CloudStackDatacenterConfig) (*decoder.CloudStackExecConfig, error) {
+	secrets := make([]apiv1.Secret, 0, len(datacenterConfig.Spec.AvailabilityZones))
+	seen := make(map[string]struct{}, len(datacenterConfig.Spec.AvailabilityZones))
+	for _, az := range datacenterConfig.Spec.AvailabilityZones {
+		if _, ok := seen[az.CredentialsRef]; ok {
+			continue
+		}
+		seen[az.CredentialsRef] = struct{}{}
+
+		secret := &apiv1.Secret{}
+		secretKey := client.ObjectKey{
+			Namespace: constants.EksaSystemNamespace,
+			Name:      az.CredentialsRef,
+		}
+		if err := cli.Get(ctx, secretKey, secret); err != nil {
+			return nil, fmt.Errorf("getting cloudstack credentials secret %s: %v", az.CredentialsRef, err)
+		}
+		secrets = append(secrets, *secret)
+	}
+
+	return decoder.ParseCloudStackCredsFromSecrets(secrets)
+}
+
+// Reconcile reconciles the cluster to the desired state.
+func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
+	log = log.WithValues("provider", "cloudstack")
+	clusterSpec, err := c.BuildSpec(ctx, clientutil.NewKubeClient(r.client), cluster)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return controller.NewPhaseRunner().Register(
+		r.ipValidator.ValidateControlPlaneIP,
+		r.ValidateDatacenterConfig,
+		r.ValidateMachineConfigs,
+		clusters.CleanupStatusAfterValidate,
+		r.ReconcileControlPlane,
+		r.CheckControlPlaneReady,
+		r.ReconcileCNI,
+		r.ReconcileWorkers,
+	).Run(ctx, log, clusterSpec)
+}
+
+// ReconcileWorkerNodes validates the cluster definition and reconciles the worker nodes
+// to the desired state.
+func (r *Reconciler) ReconcileWorkerNodes(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
+	log = log.WithValues("provider", "cloudstack", "reconcile type", "workers")
+	clusterSpec, err := c.BuildSpec(ctx, clientutil.NewKubeClient(r.client), cluster)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return controller.NewPhaseRunner().Register(
+		r.ValidateDatacenterConfig,
+		r.ValidateMachineConfigs,
+		r.ReconcileWorkers,
+	).Run(ctx, log, clusterSpec)
+}
+
+// ValidateDatacenterConfig validates the CloudStackDatacenterConfig against the CloudStack API and
+// updates the cluster status if it's invalid.
+func (r *Reconciler) ValidateDatacenterConfig(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "validateDatacenterConfig")
+	validator, err := r.validator(ctx, clusterSpec)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	if err := validator.ValidateCloudStackDatacenterConfig(ctx, clusterSpec.CloudStackDatacenter); err != nil {
+		log.Error(err, "Invalid CloudStackDatacenterConfig")
+		failureMessage := fmt.Sprintf("Invalid %s CloudStackDatacenterConfig: %s", clusterSpec.CloudStackDatacenter.Name, err.Error())
+		clusterSpec.Cluster.Status.FailureMessage = &failureMessage
+		return controller.ResultWithReturn(), nil
+	}
+	return controller.Result{}, nil
+}
+
+// ValidateMachineConfigs performs additional, context-aware validations on the machine configs.
+func (r *Reconciler) ValidateMachineConfigs(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "validateMachineConfigs")
+	validator, err := r.validator(ctx, clusterSpec)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	cloudstackClusterSpec := cloudstack.NewSpec(clusterSpec, clusterSpec.CloudStackMachineConfigs, clusterSpec.CloudStackDatacenter)
+	if err := validator.ValidateClusterMachineConfigs(ctx, cloudstackClusterSpec); err != nil {
+		log.Error(err, "Invalid CloudStackMachineConfig")
+		failureMessage := err.Error()
+		clusterSpec.Cluster.Status.FailureMessage = &failureMessage
+		return controller.ResultWithReturn(), nil
+	}
+	return controller.Result{}, nil
+}
+
+// ReconcileControlPlane applies the control plane CAPI objects to the cluster.
+func (r *Reconciler) ReconcileControlPlane(ctx context.Context, log logr.Logger, spec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "reconcileControlPlane")
+	log.Info("Applying control plane CAPI objects")
+	cp, err := cloudstack.ControlPlaneSpec(ctx, log, clientutil.NewKubeClient(r.client), spec)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return clusters.ReconcileControlPlane(ctx, r.client, toClientControlPlane(cp))
+}
+
+// CheckControlPlaneReady checks whether the control plane for an eks-a cluster is ready or not.
+// Requeues with the appropriate wait times whenever the cluster is not ready yet.
+func (r *Reconciler) CheckControlPlaneReady(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "checkControlPlaneReady")
+	return clusters.CheckControlPlaneReady(ctx, r.client, log, clusterSpec.Cluster)
+}
+
+// ReconcileCNI takes the Cilium CNI in a cluster to the desired state defined in a cluster spec.
+func (r *Reconciler) ReconcileCNI(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "reconcileCNI")
+	client, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(clusterSpec.Cluster))
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return r.cniReconciler.Reconcile(ctx, log, client, clusterSpec)
+}
+
+// ReconcileWorkers applies the worker CAPI objects to the cluster.
+func (r *Reconciler) ReconcileWorkers(ctx context.Context, log logr.Logger, spec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "reconcileWorkers")
+	log.Info("Applying worker CAPI objects")
+	w, err := cloudstack.WorkersSpec(ctx, log, clientutil.NewKubeClient(r.client), spec)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return clusters.ReconcileWorkersForEKSA(ctx, log, r.client, spec.Cluster, clusters.ToWorkers(w))
+}
+
+func (r *Reconciler) validator(ctx context.Context, clusterSpec *c.Spec) (cloudstack.ProviderValidator, error) {
+	execConfig, err := CloudStackExecConfig(ctx, r.client, clusterSpec.CloudStackDatacenter)
+	if err != nil {
+		return nil, err
+	}
+
+	validator, err := r.validatorRegistry.Get(execConfig)
+	if err != nil {
+		return nil, fmt.Errorf("building cloudstack validator: %v", err)
+	}
+
+	return validator, nil
+}
+
+func toClientControlPlane(cp *cloudstack.ControlPlane) *clusters.ControlPlane {
+	return &clusters.ControlPlane{
+		Cluster:                     cp.Cluster,
+		ProviderCluster:             cp.ProviderCluster,
+		KubeadmControlPlane:         cp.KubeadmControlPlane,
+		ControlPlaneMachineTemplate: cp.ControlPlaneMachineTemplate,
+		EtcdCluster:                 cp.EtcdCluster,
+		EtcdMachineTemplate:         cp.EtcdMachineTemplate,
+	}
+}
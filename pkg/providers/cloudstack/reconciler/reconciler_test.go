@@ -0,0 +1,636 @@
+package reconciler_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	cloudstackv1 "sigs.k8s.io/cluster-api-provider-cloudstack/api/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/internal/test/envtest"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	clusterspec "github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+	"github.com/aws/eks-anywhere/pkg/networkutils"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/mocks"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/reconciler"
+	cloudstackreconcilermocks "github.com/aws/eks-anywhere/pkg/providers/cloudstack/reconciler/mocks"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const (
+	clusterNamespace      = "test-namespace"
+	credentialsRef        = "global"
+	managementAPIEndpoint = "http://127.16.0.1:8080/client/api"
+)
+
+func TestReconcilerReconcileSuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	// We want to check that the cluster status is cleaned up if validations are passed
+	tt.cluster.Status.FailureMessage = ptr.String("invalid cluster")
+	capiCluster := test.CAPICluster(func(c *clusterv1.Cluster) {
+		c.Name = tt.cluster.Name
+	})
+	tt.eksaSupportObjs = append(tt.eksaSupportObjs, capiCluster)
+	tt.createAllObjs()
+
+	logger := test.NewNullLogger()
+	remoteClient := fake.NewClientBuilder().Build()
+
+	tt.ipValidator.EXPECT().ValidateControlPlaneIP(tt.ctx, logger, gomock.Any()).Return(controller.Result{}, nil)
+	tt.expectValidationsPass()
+
+	tt.remoteClientRegistry.EXPECT().GetClient(
+		tt.ctx, client.ObjectKey{Name: "workload-cluster", Namespace: "eksa-system"},
+	).Return(remoteClient, nil)
+	tt.cniReconciler.EXPECT().Reconcile(tt.ctx, logger, remoteClient, gomock.Any())
+
+	result, err := tt.reconciler().Reconcile(tt.ctx, logger, tt.cluster)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(tt.cluster.Status.FailureMessage).To(BeZero())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerReconcileWorkerNodesSuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.cluster.Name = "my-management-cluster"
+	tt.cluster.SetSelfManaged()
+	capiCluster := test.CAPICluster(func(c *clusterv1.Cluster) {
+		c.Name = tt.cluster.Name
+	})
+	tt.eksaSupportObjs = append(tt.eksaSupportObjs, capiCluster)
+	tt.createAllObjs()
+
+	logger := test.NewNullLogger()
+	tt.expectValidationsPass()
+
+	result, err := tt.reconciler().ReconcileWorkerNodes(tt.ctx, logger, tt.cluster)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(tt.cluster.Status.FailureMessage).To(BeZero())
+	tt.Expect(result).To(Equal(controller.Result{}))
+
+	tt.ShouldEventuallyExist(tt.ctx,
+		&bootstrapv1.KubeadmConfigTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-management-cluster-md-0-1",
+				Namespace: constants.EksaSystemNamespace,
+			},
+		},
+	)
+
+	tt.ShouldEventuallyExist(tt.ctx,
+		&cloudstackv1.CloudStackMachineTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-management-cluster-md-0-1",
+				Namespace: constants.EksaSystemNamespace,
+			},
+		},
+	)
+
+	tt.ShouldEventuallyExist(tt.ctx,
+		&clusterv1.MachineDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-management-cluster-md-0",
+				Namespace: constants.EksaSystemNamespace,
+			},
+		},
+	)
+}
+
+func TestReconcilerControlPlaneIsNotReady(t *testing.T) {
+	tt := newReconcilerTest(t)
+	capiCluster := test.CAPICluster(func(c *clusterv1.Cluster) {
+		c.Name = tt.cluster.Name
+	})
+	capiCluster.Status.Conditions = clusterv1.Conditions{
+		{
+			Type:               clusterapi.ControlPlaneReadyCondition,
+			Status:             corev1.ConditionFalse,
+			LastTransitionTime: metav1.NewTime(time.Now()),
+		},
+	}
+	tt.eksaSupportObjs = append(tt.eksaSupportObjs, capiCluster)
+	tt.createAllObjs()
+
+	logger := test.NewNullLogger()
+
+	tt.ipValidator.EXPECT().ValidateControlPlaneIP(tt.ctx, logger, gomock.Any()).Return(controller.Result{}, nil)
+	tt.expectValidationsPass()
+
+	result, err := tt.reconciler().Reconcile(tt.ctx, logger, tt.cluster)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(tt.cluster.Status.FailureMessage).To(BeZero())
+	tt.Expect(result).To(Equal(controller.ResultWithRequeue(30 * time.Second)))
+}
+
+func TestReconcilerValidateDatacenterConfigSuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+	tt.expectValidationsPass()
+
+	result, err := tt.reconciler().ValidateDatacenterConfig(tt.ctx, test.NewNullLogger(), tt.buildSpec())
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	tt.Expect(tt.cluster.Status.FailureMessage).To(BeNil())
+}
+
+func TestReconcilerValidateDatacenterConfigInvalid(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+	spec := tt.buildSpec()
+	tt.validatorRegistry.EXPECT().Get(gomock.Any()).Return(tt.validator, nil)
+	tt.cmk.EXPECT().GetManagementApiEndpoint(credentialsRef).Return("http://other:8080/client/api", nil)
+
+	result, err := tt.reconciler().ValidateDatacenterConfig(tt.ctx, test.NewNullLogger(), spec)
+
+	tt.Expect(err).To(BeNil(), "error should be nil to prevent requeue")
+	tt.Expect(result).To(Equal(controller.Result{Result: &reconcile.Result{}}), "result should stop reconciliation")
+	tt.Expect(spec.Cluster.Status.FailureMessage).To(HaveValue(ContainSubstring("Invalid datacenter CloudStackDatacenterConfig")))
+	tt.Expect(spec.Cluster.Status.FailureMessage).To(HaveValue(ContainSubstring("differs from cluster spec management url")))
+}
+
+func TestReconcilerValidateDatacenterConfigMissingCredentials(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.eksaSupportObjs = tt.eksaSupportObjs[:len(tt.eksaSupportObjs)-1]
+	tt.withFakeClient()
+
+	result, err := tt.reconciler().ValidateDatacenterConfig(tt.ctx, test.NewNullLogger(), tt.buildSpec())
+
+	tt.Expect(err).To(MatchError(ContainSubstring("getting cloudstack credentials secret global")))
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerValidateDatacenterConfigRegistryError(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+	tt.validatorRegistry.EXPECT().Get(gomock.Any()).Return(nil, errors.New("building cmk"))
+
+	result, err := tt.reconciler().ValidateDatacenterConfig(tt.ctx, test.NewNullLogger(), tt.buildSpec())
+
+	tt.Expect(err).To(MatchError(ContainSubstring("building cloudstack validator: building cmk")))
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerValidateMachineConfigsInvalid(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+	spec := tt.buildSpec()
+	tt.validatorRegistry.EXPECT().Get(gomock.Any()).Return(tt.validator, nil)
+	tt.cmk.EXPECT().ValidateZoneAndGetId(tt.ctx, credentialsRef, gomock.Any()).Return("zone-id", nil).AnyTimes()
+	tt.cmk.EXPECT().ValidateTemplatePresent(tt.ctx, credentialsRef, gomock.Any(), "zone-id", "admin", gomock.Any()).Return(errors.New("template not found")).MinTimes(1)
+
+	result, err := tt.reconciler().ValidateMachineConfigs(tt.ctx, test.NewNullLogger(), spec)
+
+	tt.Expect(err).To(BeNil(), "error should be nil to prevent requeue")
+	tt.Expect(result).To(Equal(controller.Result{Result: &reconcile.Result{}}), "result should stop reconciliation")
+	tt.Expect(spec.Cluster.Status.FailureMessage).To(HaveValue(ContainSubstring("validating template: template not found")))
+}
+
+func TestReconcilerValidateMachineConfigsSetsDefaultPort(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+	spec := tt.buildSpec()
+	tt.expectValidationsPass()
+
+	result, err := tt.reconciler().ValidateMachineConfigs(tt.ctx, test.NewNullLogger(), spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	tt.Expect(spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host).To(Equal("1.1.1.1:6443"))
+}
+
+func TestReconcileCNISuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+
+	logger := test.NewNullLogger()
+	remoteClient := fake.NewClientBuilder().Build()
+	spec := tt.buildSpec()
+
+	tt.remoteClientRegistry.EXPECT().GetClient(
+		tt.ctx, client.ObjectKey{Name: "workload-cluster", Namespace: "eksa-system"},
+	).Return(remoteClient, nil)
+	tt.cniReconciler.EXPECT().Reconcile(tt.ctx, logger, remoteClient, spec)
+
+	result, err := tt.reconciler().ReconcileCNI(tt.ctx, logger, spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(tt.cluster.Status.FailureMessage).To(BeZero())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcileCNIErrorClientRegistry(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+
+	logger := test.NewNullLogger()
+	spec := tt.buildSpec()
+
+	tt.remoteClientRegistry.EXPECT().GetClient(
+		tt.ctx, client.ObjectKey{Name: "workload-cluster", Namespace: "eksa-system"},
+	).Return(nil, errors.New("building client"))
+
+	result, err := tt.reconciler().ReconcileCNI(tt.ctx, logger, spec)
+
+	tt.Expect(err).To(MatchError(ContainSubstring("building client")))
+	tt.Expect(tt.cluster.Status.FailureMessage).To(BeZero())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerReconcileControlPlaneSuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.createAllObjs()
+	spec := tt.buildSpec()
+	spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host = "1.1.1.1:6443"
+
+	result, err := tt.reconciler().ReconcileControlPlane(tt.ctx, test.NewNullLogger(), spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(tt.cluster.Status.FailureMessage).To(BeZero())
+	tt.Expect(result).To(Equal(controller.Result{}))
+
+	tt.ShouldEventuallyExist(tt.ctx,
+		&controlplanev1.KubeadmControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "workload-cluster",
+				Namespace: "eksa-system",
+			},
+		},
+	)
+
+	tt.ShouldEventuallyExist(tt.ctx,
+		&cloudstackv1.CloudStackCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "workload-cluster",
+				Namespace: "eksa-system",
+			},
+		},
+	)
+
+	tt.ShouldEventuallyExist(tt.ctx,
+		&cloudstackv1.CloudStackMachineTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "workload-cluster-control-plane-1",
+				Namespace: "eksa-system",
+			},
+		},
+	)
+
+	capiCluster := test.CAPICluster(func(c *clusterv1.Cluster) {
+		c.Name = "workload-cluster"
+	})
+	tt.ShouldEventuallyExist(tt.ctx, capiCluster)
+}
+
+func TestReconcilerReconcileControlPlaneFailure(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.createAllObjs()
+	spec := tt.buildSpec()
+	spec.Cluster.Spec.KubernetesVersion = ""
+
+	_, err := tt.reconciler().ReconcileControlPlane(tt.ctx, test.NewNullLogger(), spec)
+
+	tt.Expect(err).To(HaveOccurred())
+}
+
+func TestCloudStackExecConfig(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	datacenter := dataCenter(func(d *anywherev1.CloudStackDatacenterConfig) {
+		d.Spec.AvailabilityZones = append(d.Spec.AvailabilityZones, d.Spec.AvailabilityZones[0])
+		d.Spec.AvailabilityZones[1].Name = "az-2"
+	})
+	cli := fake.NewClientBuilder().WithObjects(credentialsSecret()).Build()
+
+	execConfig, err := reconciler.CloudStackExecConfig(ctx, cli, datacenter)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(execConfig).To(Equal(&decoder.CloudStackExecConfig{
+		Profiles: []decoder.CloudStackProfileConfig{
+			{
+				Name:          credentialsRef,
+				ApiKey:        "test-key",
+				SecretKey:     "test-secret",
+				ManagementUrl: managementAPIEndpoint,
+				VerifySsl:     "false",
+			},
+		},
+	}))
+}
+
+type reconcilerTest struct {
+	t testing.TB
+	*WithT
+	*envtest.APIExpecter
+	ctx                  context.Context
+	cniReconciler        *cloudstackreconcilermocks.MockCNIReconciler
+	remoteClientRegistry *cloudstackreconcilermocks.MockRemoteClientRegistry
+	validatorRegistry    *cloudstackreconcilermocks.MockValidatorRegistry
+	ipValidator          *cloudstackreconcilermocks.MockIPValidator
+	cmk                  *mocks.MockProviderCmkClient
+	validator            *cloudstack.Validator
+	cluster              *anywherev1.Cluster
+	client               client.Client
+	env                  *envtest.Environment
+	bundle               *releasev1.Bundles
+	eksaSupportObjs      []client.Object
+	datacenterConfig     *anywherev1.CloudStackDatacenterConfig
+	machineConfigCP      *anywherev1.CloudStackMachineConfig
+	machineConfigWorker  *anywherev1.CloudStackMachineConfig
+}
+
+func newReconcilerTest(t testing.TB) *reconcilerTest {
+	ctrl := gomock.NewController(t)
+	cniReconciler := cloudstackreconcilermocks.NewMockCNIReconciler(ctrl)
+	remoteClientRegistry := cloudstackreconcilermocks.NewMockRemoteClientRegistry(ctrl)
+	validatorRegistry := cloudstackreconcilermocks.NewMockValidatorRegistry(ctrl)
+	ipValidator := cloudstackreconcilermocks.NewMockIPValidator(ctrl)
+	cmk := mocks.NewMockProviderCmkClient(ctrl)
+	validator := cloudstack.NewValidator(cmk, &networkutils.DefaultNetClient{}, true)
+	c := env.Client()
+
+	bundle := test.Bundle()
+
+	managementCluster := cloudstackCluster(func(c *anywherev1.Cluster) {
+		c.Name = "management-cluster"
+		c.Spec.ManagementCluster = anywherev1.ManagementCluster{
+			Name: c.Name,
+		}
+		c.Spec.BundlesRef = &anywherev1.BundlesRef{
+			Name:       bundle.Name,
+			Namespace:  bundle.Namespace,
+			APIVersion: bundle.APIVersion,
+		}
+	})
+
+	machineConfigCP := machineConfig(func(m *anywherev1.CloudStackMachineConfig) {
+		m.Name = "cp-machine-config"
+	})
+	machineConfigWN := machineConfig(func(m *anywherev1.CloudStackMachineConfig) {
+		m.Name = "worker-machine-config"
+	})
+
+	workloadClusterDatacenter := dataCenter()
+
+	cluster := cloudstackCluster(func(c *anywherev1.Cluster) {
+		c.Name = "workload-cluster"
+		c.Spec.ManagementCluster = anywherev1.ManagementCluster{
+			Name: managementCluster.Name,
+		}
+		c.Spec.BundlesRef = &anywherev1.BundlesRef{
+			Name:       bundle.Name,
+			Namespace:  bundle.Namespace,
+			APIVersion: bundle.APIVersion,
+		}
+		c.Spec.ControlPlaneConfiguration = anywherev1.ControlPlaneConfiguration{
+			Count: 1,
+			Endpoint: &anywherev1.Endpoint{
+				Host: "1.1.1.1",
+			},
+			MachineGroupRef: &anywherev1.Ref{
+				Kind: anywherev1.CloudStackMachineConfigKind,
+				Name: machineConfigCP.Name,
+			},
+		}
+		c.Spec.DatacenterRef = anywherev1.Ref{
+			Kind: anywherev1.CloudStackDatacenterKind,
+			Name: workloadClusterDatacenter.Name,
+		}
+
+		c.Spec.WorkerNodeGroupConfigurations = append(c.Spec.WorkerNodeGroupConfigurations,
+			anywherev1.WorkerNodeGroupConfiguration{
+				Count: ptr.Int(1),
+				MachineGroupRef: &anywherev1.Ref{
+					Kind: anywherev1.CloudStackMachineConfigKind,
+					Name: machineConfigWN.Name,
+				},
+				Name:   "md-0",
+				Labels: nil,
+			},
+		)
+	})
+
+	tt := &reconcilerTest{
+		t:                    t,
+		WithT:                NewWithT(t),
+		APIExpecter:          envtest.NewAPIExpecter(t, c),
+		ctx:                  context.Background(),
+		cniReconciler:        cniReconciler,
+		remoteClientRegistry: remoteClientRegistry,
+		validatorRegistry:    validatorRegistry,
+		ipValidator:          ipValidator,
+		cmk:                  cmk,
+		validator:            validator,
+		client:               c,
+		env:                  env,
+		eksaSupportObjs: []client.Object{
+			test.Namespace(clusterNamespace),
+			test.Namespace(constants.EksaSystemNamespace),
+			managementCluster,
+			workloadClusterDatacenter,
+			bundle,
+			test.EksdRelease(),
+			credentialsSecret(),
+		},
+		bundle:              bundle,
+		cluster:             cluster,
+		datacenterConfig:    workloadClusterDatacenter,
+		machineConfigCP:     machineConfigCP,
+		machineConfigWorker: machineConfigWN,
+	}
+
+	t.Cleanup(tt.cleanup)
+	return tt
+}
+
+func (tt *reconcilerTest) cleanup() {
+	tt.DeleteAndWait(tt.ctx, tt.allObjs()...)
+
+	tt.DeleteAllOfAndWait(tt.ctx, &bootstrapv1.KubeadmConfigTemplate{})
+	tt.DeleteAllOfAndWait(tt.ctx, &cloudstackv1.CloudStackMachineTemplate{})
+	tt.DeleteAllOfAndWait(tt.ctx, &clusterv1.MachineDeployment{})
+}
+
+func (tt *reconcilerTest) buildSpec() *clusterspec.Spec {
+	tt.t.Helper()
+	spec, err := clusterspec.BuildSpec(tt.ctx, clientutil.NewKubeClient(tt.client), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	return spec
+}
+
+func (tt *reconcilerTest) withFakeClient() {
+	tt.client = fake.NewClientBuilder().WithObjects(clientutil.ObjectsToClientObjects(tt.allObjs())...).Build()
+}
+
+func (tt *reconcilerTest) reconciler() *reconciler.Reconciler {
+	return reconciler.New(tt.client, tt.validatorRegistry, tt.cniReconciler, tt.remoteClientRegistry, tt.ipValidator)
+}
+
+func (tt *reconcilerTest) createAllObjs() {
+	tt.t.Helper()
+	envtest.CreateObjs(tt.ctx, tt.t, tt.client, tt.allObjs()...)
+}
+
+func (tt *reconcilerTest) allObjs() []client.Object {
+	objs := make([]client.Object, 0, len(tt.eksaSupportObjs)+3)
+	objs = append(objs, tt.eksaSupportObjs...)
+	objs = append(objs, tt.cluster, tt.machineConfigCP, tt.machineConfigWorker)
+
+	return objs
+}
+
+// expectValidationsPass sets up the CloudStack API calls made by the datacenter and machine config validations.
+func (tt *reconcilerTest) expectValidationsPass() {
+	tt.validatorRegistry.EXPECT().Get(gomock.Any()).Return(tt.validator, nil).AnyTimes()
+	tt.cmk.EXPECT().GetManagementApiEndpoint(credentialsRef).Return(managementAPIEndpoint, nil).AnyTimes()
+	tt.cmk.EXPECT().ValidateDomainAndGetId(tt.ctx, credentialsRef, "domain1").Return("domain-id", nil).AnyTimes()
+	tt.cmk.EXPECT().ValidateAccountPresent(tt.ctx, credentialsRef, "admin", "domain-id").Return(nil).AnyTimes()
+	tt.cmk.EXPECT().ValidateZoneAndGetId(tt.ctx, credentialsRef, gomock.Any()).Return("zone-id", nil).AnyTimes()
+	tt.cmk.EXPECT().ValidateNetworkPresent(tt.ctx, credentialsRef, "domain-id", gomock.Any(), "zone-id", "admin").Return(nil).AnyTimes()
+	tt.cmk.EXPECT().ValidateTemplatePresent(tt.ctx, credentialsRef, gomock.Any(), "zone-id", "admin", gomock.Any()).Return(nil).AnyTimes()
+	tt.cmk.EXPECT().ValidateServiceOfferingPresent(tt.ctx, credentialsRef, "zone-id", gomock.Any()).Return(nil).AnyTimes()
+}
+
+type clusterOpt func(*anywherev1.Cluster)
+
+func cloudstackCluster(opts ...clusterOpt) *anywherev1.Cluster {
+	c := &anywherev1.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.ClusterKind,
+			APIVersion: anywherev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: clusterNamespace,
+		},
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion: "1.20",
+			ClusterNetwork: anywherev1.ClusterNetwork{
+				Pods: anywherev1.Pods{
+					CidrBlocks: []string{"0.0.0.0"},
+				},
+				Services: anywherev1.Services{
+					CidrBlocks: []string{"0.0.0.0"},
+				},
+			},
+		},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+type datacenterOpt func(config *anywherev1.CloudStackDatacenterConfig)
+
+func dataCenter(opts ...datacenterOpt) *anywherev1.CloudStackDatacenterConfig {
+	d := &anywherev1.CloudStackDatacenterConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.CloudStackDatacenterKind,
+			APIVersion: anywherev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "datacenter",
+			Namespace: clusterNamespace,
+		},
+		Spec: anywherev1.CloudStackDatacenterConfigSpec{
+			AvailabilityZones: []anywherev1.CloudStackAvailabilityZone{
+				{
+					Name:           "default-az-0",
+					CredentialsRef: credentialsRef,
+					Domain:         "domain1",
+					Account:        "admin",
+					Zone: anywherev1.CloudStackZone{
+						Name: "zone1",
+						Network: anywherev1.CloudStackResourceIdentifier{
+							Name: "net1",
+						},
+					},
+					ManagementApiEndpoint: managementAPIEndpoint,
+				},
+			},
+		},
+	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
+type cloudstackMachineOpt func(config *anywherev1.CloudStackMachineConfig)
+
+func machineConfig(opts ...cloudstackMachineOpt) *anywherev1.CloudStackMachineConfig {
+	m := &anywherev1.CloudStackMachineConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.CloudStackMachineConfigKind,
+			APIVersion: anywherev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: clusterNamespace,
+		},
+		Spec: anywherev1.CloudStackMachineConfigSpec{
+			ComputeOffering: anywherev1.CloudStackResourceIdentifier{
+				Name: "m4-large",
+			},
+			Template: anywherev1.CloudStackResourceIdentifier{
+				Name: "kubernetes_1_20",
+			},
+			Users: []anywherev1.UserConfiguration{
+				{
+					Name:              "user",
+					SshAuthorizedKeys: []string{"ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABgQC8ZEibIrz1AUBKDvmDiWLs9f5DnOerC4qPITiDtSOuPAsxgZbRMavBfVTxodMdAkYRYlXxK6PqNo0ve0qcOV2yvpxH1OogasMMetck6BlM/dIoo3vEY4ZoG9DuVRIf9Iry5gJKbpMDYWpx1IGZrDMOFcIM20ii2qLQQk5hfq9OqdqhToEJFixdgJt/y/zt6Koy3kix+XsnrVdAHgWAq4CZuwt1G6JUAqrpob3H8vPmL7aS+35ktf0pHBm6nYoxRhslnWMUb/7vpzWiq+fUBIm2LYqvrnm7t3fRqFx7p2sZqAm2jDNivyYXwRXkoQPR96zvGeMtuQ5BVGPpsDfVudSW21+pEXHI0GINtTbua7Ogz7wtpVywSvHraRgdFOeY9mkXPzvm2IhoqNrteck2GErwqSqb19mPz6LnHueK0u7i6WuQWJn0CUoCtyMGIrowXSviK8qgHXKrmfTWATmCkbtosnLskNdYuOw8bKxq5S4WgdQVhPps2TiMSZndjX5NTr8= ubuntu@ip-10-2-0-6"},
+				},
+			},
+		},
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+func credentialsSecret() *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: constants.EksaSystemNamespace,
+			Name:      credentialsRef,
+		},
+		Data: map[string][]byte{
+			decoder.APIKeyKey:    []byte("test-key"),
+			decoder.SecretKeyKey: []byte("test-secret"),
+			decoder.APIUrlKey:    []byte(managementAPIEndpoint),
+			decoder.VerifySslKey: []byte("false"),
+		},
+	}
+}
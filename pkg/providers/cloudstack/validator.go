@@ -10,6 +10,7 @@ import (
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/networkutils"
+	"github.com/aws/eks-anywhere/pkg/validations"
 )
 
 type Validator struct {
@@ -63,44 +64,61 @@ func (v *Validator) ValidateCloudStackDatacenterConfig(ctx context.Context, data
 		return err
 	}
 
+	runner := validations.NewRunner()
 	for _, az := range localAvailabilityZones {
-		_, err := getHostnameFromUrl(az.ManagementApiEndpoint)
-		if err != nil {
-			return fmt.Errorf("checking management api endpoint: %v", err)
-		}
+		az := az
+		runner.Register(validations.Validation{
+			Name: fmt.Sprintf("availability zone %s", az.Name),
+			Run: func(ctx context.Context) *validations.ValidationResult {
+				return &validations.ValidationResult{Err: v.validateAvailabilityZone(ctx, az)}
+			},
+		})
+	}
+	if err := runner.FirstError(ctx); err != nil {
+		return err
+	}
 
-		endpoint, err := v.cmk.GetManagementApiEndpoint(az.CredentialsRef)
-		if err != nil {
-			return err
-		}
-		if endpoint != az.ManagementApiEndpoint {
-			return fmt.Errorf("cloudstack secret management url (%s) differs from cluster spec management url (%s)",
-				endpoint, az.ManagementApiEndpoint)
-		}
+	logger.MarkPass("Datacenter validated")
+	return nil
+}
 
-		domainId, err := v.cmk.ValidateDomainAndGetId(ctx, az.CredentialsRef, az.Domain)
-		if err != nil {
-			return err
-		}
-		az.DomainId = domainId
+// validateAvailabilityZone checks the CloudStack resources of az exist and are accessible with its credentials.
+func (v *Validator) validateAvailabilityZone(ctx context.Context, az localAvailabilityZone) error {
+	_, err := getHostnameFromUrl(az.ManagementApiEndpoint)
+	if err != nil {
+		return fmt.Errorf("checking management api endpoint: %v", err)
+	}
 
-		if err := v.cmk.ValidateAccountPresent(ctx, az.CredentialsRef, az.Account, az.DomainId); err != nil {
-			return err
-		}
+	endpoint, err := v.cmk.GetManagementApiEndpoint(az.CredentialsRef)
+	if err != nil {
+		return err
+	}
+	if endpoint != az.ManagementApiEndpoint {
+		return fmt.Errorf("cloudstack secret management url (%s) differs from cluster spec management url (%s)",
+			endpoint, az.ManagementApiEndpoint)
+	}
 
-		zoneId, err := v.cmk.ValidateZoneAndGetId(ctx, az.CredentialsRef, az.CloudStackAvailabilityZone.Zone)
-		if err != nil {
-			return err
-		}
-		if len(az.CloudStackAvailabilityZone.Zone.Network.Id) == 0 && len(az.CloudStackAvailabilityZone.Zone.Network.Name) == 0 {
-			return fmt.Errorf("zone network is not set or is empty")
-		}
-		if err := v.cmk.ValidateNetworkPresent(ctx, az.CredentialsRef, az.DomainId, az.CloudStackAvailabilityZone.Zone.Network, zoneId, az.Account); err != nil {
-			return err
-		}
+	domainId, err := v.cmk.ValidateDomainAndGetId(ctx, az.CredentialsRef, az.Domain)
+	if err != nil {
+		return err
+	}
+	az.DomainId = domainId
+
+	if err := v.cmk.ValidateAccountPresent(ctx, az.CredentialsRef, az.Account, az.DomainId); err != nil {
+		return err
+	}
+
+	zoneId, err := v.cmk.ValidateZoneAndGetId(ctx, az.CredentialsRef, az.CloudStackAvailabilityZone.Zone)
+	if err != nil {
+		return err
+	}
+	if len(az.CloudStackAvailabilityZone.Zone.Network.Id) == 0 && len(az.CloudStackAvailabilityZone.Zone.Network.Name) == 0 {
+		return fmt.Errorf("zone network is not set or is empty")
+	}
+	if err := v.cmk.ValidateNetworkPresent(ctx, az.CredentialsRef, az.DomainId, az.CloudStackAvailabilityZone.Zone.Network, zoneId, az.Account); err != nil {
+		return err
 	}
 
-	logger.MarkPass("Datacenter validated")
 	return nil
 }
 
@@ -251,27 +269,41 @@ func (v *Validator) validateMachineConfig(ctx context.Context, datacenterConfig
 		return err
 	}
 
+	runner := validations.NewRunner()
 	for _, az := range localAvailabilityZones {
-		zoneId, err := v.cmk.ValidateZoneAndGetId(ctx, az.CredentialsRef, az.CloudStackAvailabilityZone.Zone)
-		if err != nil {
-			return err
-		}
+		az := az
+		runner.Register(validations.Validation{
+			Name: fmt.Sprintf("machine config %s in availability zone %s", machineConfig.Name, az.Name),
+			Run: func(ctx context.Context) *validations.ValidationResult {
+				return &validations.ValidationResult{Err: v.validateMachineConfigInAvailabilityZone(ctx, az, machineConfig)}
+			},
+		})
+	}
 
-		if err := v.cmk.ValidateTemplatePresent(ctx, az.CredentialsRef, az.DomainId, zoneId, az.Account, machineConfig.Spec.Template); err != nil {
-			return fmt.Errorf("validating template: %v", err)
-		}
-		if err := v.cmk.ValidateServiceOfferingPresent(ctx, az.CredentialsRef, zoneId, machineConfig.Spec.ComputeOffering); err != nil {
-			return fmt.Errorf("validating service offering: %v", err)
-		}
-		if machineConfig.Spec.DiskOffering != nil && (len(machineConfig.Spec.DiskOffering.Id) > 0 || len(machineConfig.Spec.DiskOffering.Name) > 0) {
-			if err := v.cmk.ValidateDiskOfferingPresent(ctx, az.CredentialsRef, zoneId, *machineConfig.Spec.DiskOffering); err != nil {
-				return fmt.Errorf("validating disk offering: %v", err)
-			}
+	return runner.FirstError(ctx)
+}
+
+// validateMachineConfigInAvailabilityZone checks the CloudStack resources referenced by machineConfig exist in az.
+func (v *Validator) validateMachineConfigInAvailabilityZone(ctx context.Context, az localAvailabilityZone, machineConfig *anywherev1.CloudStackMachineConfig) error {
+	zoneId, err := v.cmk.ValidateZoneAndGetId(ctx, az.CredentialsRef, az.CloudStackAvailabilityZone.Zone)
+	if err != nil {
+		return err
+	}
+
+	if err := v.cmk.ValidateTemplatePresent(ctx, az.CredentialsRef, az.DomainId, zoneId, az.Account, machineConfig.Spec.Template); err != nil {
+		return fmt.Errorf("validating template: %v", err)
+	}
+	if err := v.cmk.ValidateServiceOfferingPresent(ctx, az.CredentialsRef, zoneId, machineConfig.Spec.ComputeOffering); err != nil {
+		return fmt.Errorf("validating service offering: %v", err)
+	}
+	if machineConfig.Spec.DiskOffering != nil && (len(machineConfig.Spec.DiskOffering.Id) > 0 || len(machineConfig.Spec.DiskOffering.Name) > 0) {
+		if err := v.cmk.ValidateDiskOfferingPresent(ctx, az.CredentialsRef, zoneId, *machineConfig.Spec.DiskOffering); err != nil {
+			return fmt.Errorf("validating disk offering: %v", err)
 		}
-		if len(machineConfig.Spec.AffinityGroupIds) > 0 {
-			if err := v.cmk.ValidateAffinityGroupsPresent(ctx, az.CredentialsRef, az.DomainId, az.Account, machineConfig.Spec.AffinityGroupIds); err != nil {
-				return fmt.Errorf("validating affinity group ids: %v", err)
-			}
+	}
+	if len(machineConfig.Spec.AffinityGroupIds) > 0 {
+		if err := v.cmk.ValidateAffinityGroupsPresent(ctx, az.CredentialsRef, az.DomainId, az.Account, machineConfig.Spec.AffinityGroupIds); err != nil {
+			return fmt.Errorf("validating affinity group ids: %v", err)
 		}
 	}
 
@@ -0,0 +1,57 @@
+package cloudstack_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack"
+)
+
+func TestWorkersSpecNewCluster(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	spec := test.NewFullClusterSpec(t, testClusterConfigMainFilename)
+
+	workers, err := cloudstack.WorkersSpec(ctx, logger, test.NewFakeKubeClient(), spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(workers.Groups).To(HaveLen(1))
+	g.Expect(workers.Groups[0].MachineDeployment.Name).To(Equal("test-md-0"))
+	g.Expect(workers.Groups[0].ProviderMachineTemplate.Name).To(Equal("test-md-0-1"))
+	g.Expect(workers.Groups[0].KubeadmConfigTemplate.Name).To(Equal("test-md-0-1"))
+}
+
+func TestWorkersSpecUpdateMachineTemplate(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	spec := test.NewFullClusterSpec(t, testClusterConfigMainFilename)
+
+	workers, err := cloudstack.WorkersSpec(ctx, logger, test.NewFakeKubeClient(), spec)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	group := workers.Groups[0]
+	oldTemplate := group.ProviderMachineTemplate.DeepCopy()
+	oldTemplate.Spec.Spec.Spec.Template.Name = "old-template"
+	client := test.NewFakeKubeClient(group.MachineDeployment.DeepCopy(), group.KubeadmConfigTemplate.DeepCopy(), oldTemplate)
+
+	workers, err = cloudstack.WorkersSpec(ctx, logger, client, spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(workers.Groups[0].ProviderMachineTemplate.Name).To(Equal("test-md-0-2"))
+	g.Expect(workers.Groups[0].MachineDeployment.Spec.Template.Spec.InfrastructureRef.Name).To(Equal("test-md-0-2"))
+	g.Expect(workers.Groups[0].KubeadmConfigTemplate.Name).To(Equal("test-md-0-1"))
+}
+
+func TestWorkersSpecMissingMachineConfig(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	spec := test.NewFullClusterSpec(t, testClusterConfigMainFilename)
+	delete(spec.CloudStackMachineConfigs, "test")
+
+	_, err := cloudstack.WorkersSpec(ctx, logger, test.NewFakeKubeClient(), spec)
+	g.Expect(err).To(MatchError(ContainSubstring("CloudStackMachineConfig test for worker node group md-0 is missing")))
+}
@@ -9,9 +9,19 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/semver"
 )
 
+// AuditPolicy returns the audit policy set in the cluster spec, either inline or in a ConfigMap,
+// or the default audit policy for the cluster kubernetes version when the spec doesn't set one.
+func AuditPolicy(clusterSpec *cluster.Spec) (string, error) {
+	if policy := clusterSpec.Config.AuditPolicy(); policy != "" {
+		return strings.TrimSpace(policy), nil
+	}
+	return GetAuditPolicy(clusterSpec.Cluster.Spec.KubernetesVersion)
+}
+
 // GetAuditPolicy returns the audit policy either v1 or v1beta1 depending on kube version.
 func GetAuditPolicy(kubeVersion v1alpha1.KubernetesVersion) (string, error) {
 	// appending the ".0" as the patch version to have a valid semver string and use those semvers for comparison
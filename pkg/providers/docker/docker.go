@@ -228,13 +228,23 @@ func kubeletCgroupDriverExtraArgs(kubeVersion v1alpha1.KubernetesVersion) (clust
 	return clusterapi.CgroupDriverCgroupfsExtraArgs(), nil
 }
 
+// evictionHardExtraArgs returns the default kubelet eviction thresholds, which disable the disk evictions
+// since the kind nodes share the host disk. The evictionHard set in the node group kubeletConfiguration replaces them.
+func evictionHardExtraArgs() clusterapi.ExtraArgs {
+	return clusterapi.ExtraArgs{
+		"eviction-hard": "nodefs.available<0%,nodefs.inodesFree<0%,imagefs.available<0%",
+	}
+}
+
 func buildTemplateMapCP(clusterSpec *cluster.Spec) (map[string]interface{}, error) {
 	bundle := clusterSpec.VersionsBundle
 	etcdExtraArgs := clusterapi.SecureEtcdTlsCipherSuitesExtraArgs()
 	sharedExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs()
 	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
 		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf)).
-		Append(clusterapi.ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration))
+		Append(clusterapi.ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration)).
+		Append(evictionHardExtraArgs()).
+		Append(clusterapi.KubeletConfigurationExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.KubeletConfiguration))
 
 	cgroupDriverArgs, err := kubeletCgroupDriverExtraArgs(clusterSpec.Cluster.Spec.KubernetesVersion)
 	if err != nil {
@@ -247,9 +257,15 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec) (map[string]interface{}, erro
 	apiServerExtraArgs := clusterapi.OIDCToExtraArgs(clusterSpec.OIDCConfig).
 		Append(clusterapi.AwsIamAuthExtraArgs(clusterSpec.AWSIamConfig)).
 		Append(clusterapi.PodIAMAuthExtraArgs(clusterSpec.Cluster.Spec.PodIAMConfig)).
-		Append(sharedExtraArgs)
+		Append(clusterapi.EncryptionConfigurationExtraArgs(clusterSpec.Cluster.Spec.EncryptionConfiguration)).
+		Append(clusterapi.PodSecurityExtraArgs(clusterSpec.Cluster.Spec.PodSecurity)).
+		Append(sharedExtraArgs).
+		Append(clusterapi.APIServerExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration))
 	controllerManagerExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
-		Append(clusterapi.NodeCIDRMaskExtraArgs(&clusterSpec.Cluster.Spec.ClusterNetwork))
+		Append(clusterapi.NodeCIDRMaskExtraArgs(&clusterSpec.Cluster.Spec.ClusterNetwork)).
+		Append(clusterapi.ControllerManagerExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration))
+	schedulerExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
+		Append(clusterapi.SchedulerExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration))
 
 	values := map[string]interface{}{
 		"clusterName":                   clusterSpec.Cluster.Name,
@@ -265,7 +281,7 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec) (map[string]interface{}, erro
 		"etcdCipherSuites":              crypto.SecureCipherSuitesString(),
 		"apiserverExtraArgs":            apiServerExtraArgs.ToPartialYaml(),
 		"controllermanagerExtraArgs":    controllerManagerExtraArgs.ToPartialYaml(),
-		"schedulerExtraArgs":            sharedExtraArgs.ToPartialYaml(),
+		"schedulerExtraArgs":            schedulerExtraArgs.ToPartialYaml(),
 		"kubeletExtraArgs":              kubeletExtraArgs.ToPartialYaml(),
 		"externalEtcdVersion":           bundle.KubeDistro.EtcdVersion,
 		"eksaSystemNamespace":           constants.EksaSystemNamespace,
@@ -286,11 +302,27 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec) (map[string]interface{}, erro
 
 	values["controlPlaneTaints"] = clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Taints
 
-	auditPolicy, err := common.GetAuditPolicy(clusterSpec.Cluster.Spec.KubernetesVersion)
+	auditPolicy, err := common.AuditPolicy(clusterSpec)
 	if err != nil {
 		return nil, err
 	}
 	values["auditPolicy"] = auditPolicy
+	values["auditLogMaxAge"] = clusterSpec.Cluster.Spec.AuditPolicy.LogMaxAgeOrDefault()
+	values["auditLogMaxBackup"] = clusterSpec.Cluster.Spec.AuditPolicy.LogMaxBackupOrDefault()
+	values["auditLogMaxSize"] = clusterSpec.Cluster.Spec.AuditPolicy.LogMaxSizeOrDefault()
+
+	if clusterSpec.Cluster.Spec.EncryptionConfiguration != nil {
+		values["encryptionConfig"] = true
+		values["kmsPluginSocketDirs"] = clusterapi.KMSPluginSocketDirs(clusterSpec.Cluster.Spec.EncryptionConfiguration)
+	}
+
+	if clusterSpec.Cluster.Spec.PodSecurity != nil {
+		admissionConfig, err := clusterapi.PodSecurityAdmissionConfiguration(clusterSpec.Cluster.Spec.PodSecurity)
+		if err != nil {
+			return nil, err
+		}
+		values["admissionConfig"] = admissionConfig
+	}
 
 	return values, nil
 }
@@ -299,7 +331,9 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupConfiguration
 	bundle := clusterSpec.VersionsBundle
 	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
 		Append(clusterapi.WorkerNodeLabelsExtraArgs(workerNodeGroupConfiguration)).
-		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf))
+		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf)).
+		Append(evictionHardExtraArgs()).
+		Append(clusterapi.KubeletConfigurationExtraArgs(workerNodeGroupConfiguration.KubeletConfiguration))
 
 	cgroupDriverArgs, err := kubeletCgroupDriverExtraArgs(clusterSpec.Cluster.Spec.KubernetesVersion)
 	if err != nil {
@@ -329,14 +363,16 @@ func NeedsNewControlPlaneTemplate(oldSpec, newSpec *cluster.Spec) bool {
 
 func NeedsNewWorkloadTemplate(oldSpec, newSpec *cluster.Spec) bool {
 	if !v1alpha1.WorkerNodeGroupConfigurationSliceTaintsEqual(oldSpec.Cluster.Spec.WorkerNodeGroupConfigurations, newSpec.Cluster.Spec.WorkerNodeGroupConfigurations) ||
-		!v1alpha1.WorkerNodeGroupConfigurationsLabelsMapEqual(oldSpec.Cluster.Spec.WorkerNodeGroupConfigurations, newSpec.Cluster.Spec.WorkerNodeGroupConfigurations) {
+		!v1alpha1.WorkerNodeGroupConfigurationsLabelsMapEqual(oldSpec.Cluster.Spec.WorkerNodeGroupConfigurations, newSpec.Cluster.Spec.WorkerNodeGroupConfigurations) ||
+		!v1alpha1.WorkerNodeGroupConfigurationsKubeletConfigurationEqual(oldSpec.Cluster.Spec.WorkerNodeGroupConfigurations, newSpec.Cluster.Spec.WorkerNodeGroupConfigurations) {
 		return true
 	}
 	return (oldSpec.Cluster.Spec.KubernetesVersion != newSpec.Cluster.Spec.KubernetesVersion) || (oldSpec.Bundles.Spec.Number != newSpec.Bundles.Spec.Number)
 }
 
 func NeedsNewKubeadmConfigTemplate(newWorkerNodeGroup *v1alpha1.WorkerNodeGroupConfiguration, oldWorkerNodeGroup *v1alpha1.WorkerNodeGroupConfiguration) bool {
-	return !v1alpha1.TaintsSliceEqual(newWorkerNodeGroup.Taints, oldWorkerNodeGroup.Taints) || !v1alpha1.MapEqual(newWorkerNodeGroup.Labels, oldWorkerNodeGroup.Labels)
+	return !v1alpha1.TaintsSliceEqual(newWorkerNodeGroup.Taints, oldWorkerNodeGroup.Taints) || !v1alpha1.MapEqual(newWorkerNodeGroup.Labels, oldWorkerNodeGroup.Labels) ||
+		!newWorkerNodeGroup.KubeletConfiguration.Equal(oldWorkerNodeGroup.KubeletConfiguration)
 }
 
 func NeedsNewEtcdTemplate(oldSpec, newSpec *cluster.Spec) bool {
@@ -13,6 +13,7 @@ import (
 	dockerv1 "sigs.k8s.io/cluster-api/test/infrastructure/docker/api/v1beta1"
 
 	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
@@ -194,6 +195,63 @@ func TestWorkersSpecUpgradeClusterRemoveLabels(t *testing.T) {
 	g.Expect(workers.Groups).To(ConsistOf(*expectedGroup1, *expectedGroup2))
 }
 
+func TestWorkersSpecUpgradeClusterKubeletConfiguration(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	spec := testClusterSpec()
+
+	currentGroup1 := clusterapi.WorkerGroup[*dockerv1.DockerMachineTemplate]{
+		KubeadmConfigTemplate:   kubeadmConfigTemplate(),
+		MachineDeployment:       machineDeployment(),
+		ProviderMachineTemplate: dockerMachineTemplate("test-md-0-1"),
+	}
+
+	currentGroup2 := clusterapi.WorkerGroup[*dockerv1.DockerMachineTemplate]{
+		KubeadmConfigTemplate: kubeadmConfigTemplate(
+			func(kct *bootstrapv1.KubeadmConfigTemplate) {
+				kct.Name = "test-md-1-1"
+			},
+		),
+		MachineDeployment: machineDeployment(
+			func(md *clusterv1.MachineDeployment) {
+				md.Name = "test-md-1"
+				md.Spec.Template.Spec.InfrastructureRef.Name = "test-md-1-1"
+				md.Spec.Template.Spec.Bootstrap.ConfigRef.Name = "test-md-1-1"
+			},
+		),
+		ProviderMachineTemplate: dockerMachineTemplate("test-md-1-1"),
+	}
+
+	expectedGroup1 := currentGroup1.DeepCopy()
+	expectedGroup2 := currentGroup2.DeepCopy()
+
+	objs := make([]kubernetes.Object, 0, 6)
+	objs = append(objs, currentGroup1.Objects()...)
+	objs = append(objs, currentGroup2.Objects()...)
+	client := test.NewFakeKubeClient(clientutil.ObjectsToClientObjects(objs)...)
+
+	// The kubelet settings are kubelet args in the kubeadmconfigtemplate, so this triggers a new template
+	spec.Cluster.Spec.WorkerNodeGroupConfigurations[0].KubeletConfiguration = &anywherev1.KubeletConfiguration{
+		MaxPods:      ptr.Int(50),
+		EvictionHard: map[string]string{"memory.available": "100Mi"},
+	}
+	expectedGroup1.KubeadmConfigTemplate.Spec.Template.Spec.JoinConfiguration.NodeRegistration.KubeletExtraArgs = map[string]string{
+		"tls-cipher-suites": "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+		"cgroup-driver":     "cgroupfs",
+		"eviction-hard":     "memory.available<100Mi",
+		"max-pods":          "50",
+	}
+	expectedGroup1.KubeadmConfigTemplate.Name = "test-md-0-2"
+	expectedGroup1.MachineDeployment.Spec.Template.Spec.Bootstrap.ConfigRef.Name = "test-md-0-2"
+
+	workers, err := docker.WorkersSpec(ctx, logger, client, spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(workers).NotTo(BeNil())
+	g.Expect(workers.Groups).To(HaveLen(2))
+	g.Expect(workers.Groups).To(ConsistOf(*expectedGroup1, *expectedGroup2))
+}
+
 func TestWorkersSpecNoMachineTemplateChanges(t *testing.T) {
 	g := NewWithT(t)
 	logger := test.NewNullLogger()
@@ -2,8 +2,15 @@ package nutanix
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
 
+	prismgoclient "github.com/nutanix-cloud-native/prism-go-client"
+	"github.com/nutanix-cloud-native/prism-go-client/environment/credentials"
 	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 )
 
 type Client interface {
@@ -18,3 +25,40 @@ type Client interface {
 
 	GetCurrentLoggedInUser(ctx context.Context) (*v3.UserIntentResponse, error)
 }
+
+// NewPrismClient builds a Prism Central v3 client for the endpoint in a NutanixDatacenterConfig.
+func NewPrismClient(datacenterConfig *anywherev1.NutanixDatacenterConfig, creds credentials.BasicAuthCredential) (*v3.Client, error) {
+	clientOpts := make([]v3.ClientOption, 0)
+	if datacenterConfig.Spec.AdditionalTrustBundle != "" {
+		block, _ := pem.Decode([]byte(datacenterConfig.Spec.AdditionalTrustBundle))
+		if block == nil {
+			return nil, fmt.Errorf("unable to decode additional trust bundle %s", datacenterConfig.Spec.AdditionalTrustBundle)
+		}
+		certs, err := x509.ParseCertificates(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse additional trust bundle %s: %v", datacenterConfig.Spec.AdditionalTrustBundle, err)
+		}
+		if len(certs) == 0 {
+			return nil, fmt.Errorf("unable to extract certs from the addtional trust bundle %s", datacenterConfig.Spec.AdditionalTrustBundle)
+		}
+		clientOpts = append(clientOpts, v3.WithCertificate(certs[0]))
+	}
+
+	endpoint := datacenterConfig.Spec.Endpoint
+	port := datacenterConfig.Spec.Port
+	nutanixCreds := prismgoclient.Credentials{
+		URL:      fmt.Sprintf("%s:%d", endpoint, port),
+		Username: creds.PrismCentral.Username,
+		Password: creds.PrismCentral.Password,
+		Endpoint: endpoint,
+		Port:     fmt.Sprintf("%d", port),
+		Insecure: datacenterConfig.Spec.Insecure,
+	}
+
+	client, err := v3.NewV3Client(nutanixCreds, clientOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating nutanix client: %v", err)
+	}
+
+	return client, nil
+}
@@ -0,0 +1,165 @@
+package nutanix
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/nutanix-cloud-native/prism-go-client/environment/credentials"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	yamlcapi "github.com/aws/eks-anywhere/pkg/clusterapi/yaml"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/templater"
+	"github.com/aws/eks-anywhere/pkg/yamlutil"
+)
+
+// BaseControlPlane represents a CAPI Nutanix control plane.
+// CAPX objects are handled as unstructured since their go types are not vendored in this module.
+type BaseControlPlane = clusterapi.ControlPlane[*unstructured.Unstructured, *unstructured.Unstructured]
+
+// ControlPlane holds the Nutanix specific objects for a CAPI Nutanix control plane.
+type ControlPlane struct {
+	BaseControlPlane
+	Secrets []*corev1.Secret
+}
+
+// Objects returns the control plane objects associated with the Nutanix cluster.
+func (p ControlPlane) Objects() []kubernetes.Object {
+	o := p.BaseControlPlane.Objects()
+	for _, s := range p.Secrets {
+		o = append(o, s)
+	}
+
+	return o
+}
+
+// ControlPlaneBuilder defines the builder for all objects in the CAPI Nutanix control plane.
+type ControlPlaneBuilder struct {
+	BaseBuilder  *yamlcapi.ControlPlaneBuilder[*unstructured.Unstructured, *unstructured.Unstructured]
+	ControlPlane *ControlPlane
+}
+
+// BuildFromParsed implements the base yamlcapi.BuildFromParsed and processes any additional objects for the Nutanix control plane.
+func (b *ControlPlaneBuilder) BuildFromParsed(lookup yamlutil.ObjectLookup) error {
+	if err := b.BaseBuilder.BuildFromParsed(lookup); err != nil {
+		return err
+	}
+
+	b.ControlPlane.BaseControlPlane = *b.BaseBuilder.ControlPlane
+	for _, obj := range lookup {
+		if obj.GetObjectKind().GroupVersionKind().Kind == constants.SecretKind {
+			b.ControlPlane.Secrets = append(b.ControlPlane.Secrets, obj.(*corev1.Secret))
+		}
+	}
+
+	return nil
+}
+
+// ControlPlaneSpec builds a Nutanix ControlPlane definition based on an eks-a cluster spec.
+// creds are the Prism Central credentials written to the secret referenced by the NutanixCluster.
+func ControlPlaneSpec(ctx context.Context, logger logr.Logger, client kubernetes.Client, spec *cluster.Spec, creds credentials.BasicAuthCredential) (*ControlPlane, error) {
+	templateBuilder, err := newControllerTemplateBuilder(spec, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	controlPlaneYaml, err := templateBuilder.GenerateCAPISpecControlPlane(
+		spec,
+		func(values map[string]interface{}) {
+			values["controlPlaneTemplateName"] = clusterapi.ControlPlaneMachineTemplateName(spec.Cluster)
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "generating nutanix control plane yaml spec")
+	}
+
+	secretYaml, err := templateBuilder.GenerateCAPISpecSecret(spec)
+	if err != nil {
+		return nil, errors.Wrap(err, "generating nutanix credentials secret yaml spec")
+	}
+
+	parser, builder, err := newControlPlaneParser(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = parser.Parse(templater.AppendYamlResources(controlPlaneYaml, secretYaml), builder); err != nil {
+		return nil, errors.Wrap(err, "parsing nutanix control plane yaml")
+	}
+
+	cp := builder.ControlPlane
+	if err = cp.UpdateImmutableObjectNames(ctx, client, getMachineTemplate, machineTemplateEqual); err != nil {
+		return nil, errors.Wrap(err, "updating nutanix immutable object names")
+	}
+
+	return cp, nil
+}
+
+func newControlPlaneParser(logger logr.Logger) (*yamlutil.Parser, *ControlPlaneBuilder, error) {
+	parser, baseBuilder, err := yamlcapi.NewControlPlaneParserAndBuilder(
+		logger,
+		yamlutil.NewMapping(nutanixClusterKind, newUnstructured),
+		machineTemplateMapping(),
+	)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "building nutanix control plane parser")
+	}
+
+	err = parser.RegisterMappings(
+		yamlutil.NewMapping(constants.SecretKind, func() yamlutil.APIObject {
+			return &corev1.Secret{}
+		}),
+	)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "registering nutanix control plane mappings in parser")
+	}
+
+	builder := &ControlPlaneBuilder{
+		BaseBuilder:  baseBuilder,
+		ControlPlane: &ControlPlane{},
+	}
+
+	return parser, builder, nil
+}
+
+// newControllerTemplateBuilder builds a TemplateBuilder from the objects in an eks-a cluster spec.
+func newControllerTemplateBuilder(spec *cluster.Spec, creds credentials.BasicAuthCredential) (*TemplateBuilder, error) {
+	controlPlaneMachineConfig := spec.NutanixMachineConfig(spec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name)
+	if controlPlaneMachineConfig == nil {
+		return nil, errors.New("control plane NutanixMachineConfig is missing from cluster spec")
+	}
+
+	var etcdMachineSpec *v1alpha1.NutanixMachineConfigSpec
+	if spec.Cluster.Spec.ExternalEtcdConfiguration != nil {
+		etcdMachineConfig := spec.NutanixMachineConfig(spec.Cluster.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name)
+		if etcdMachineConfig == nil {
+			return nil, errors.New("etcd NutanixMachineConfig is missing from cluster spec")
+		}
+		etcdMachineSpec = &etcdMachineConfig.Spec
+	}
+
+	workerMachineSpecs := make(map[string]v1alpha1.NutanixMachineConfigSpec, len(spec.Cluster.Spec.WorkerNodeGroupConfigurations))
+	for _, wnConfig := range spec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		machineConfig := spec.NutanixMachineConfig(wnConfig.MachineGroupRef.Name)
+		if machineConfig == nil {
+			return nil, errors.Errorf("NutanixMachineConfig %s for worker node group %s is missing from cluster spec", wnConfig.MachineGroupRef.Name, wnConfig.Name)
+		}
+		workerMachineSpecs[wnConfig.MachineGroupRef.Name] = machineConfig.Spec
+	}
+
+	return NewNutanixTemplateBuilder(
+		&spec.NutanixDatacenter.Spec,
+		&controlPlaneMachineConfig.Spec,
+		etcdMachineSpec,
+		workerMachineSpecs,
+		creds,
+		time.Now,
+	), nil
+}
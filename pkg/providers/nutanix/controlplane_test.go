@@ -0,0 +1,94 @@
+package nutanix_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nutanix-cloud-native/prism-go-client/environment/credentials"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/providers/nutanix"
+)
+
+const testClusterConfigMainFilename = "testdata/eksa-cluster.yaml"
+
+func testCreds() credentials.BasicAuthCredential {
+	return credentials.BasicAuthCredential{
+		PrismCentral: credentials.PrismCentralBasicAuth{
+			BasicAuth: credentials.BasicAuth{
+				Username: "admin",
+				Password: "password",
+			},
+		},
+	}
+}
+
+func TestControlPlaneSpecNewCluster(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	spec := test.NewFullClusterSpec(t, testClusterConfigMainFilename)
+
+	cp, err := nutanix.ControlPlaneSpec(ctx, logger, test.NewFakeKubeClient(), spec, testCreds())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cp).NotTo(BeNil())
+	g.Expect(cp.Cluster.Name).To(Equal("eksa-unit-test"))
+	g.Expect(cp.KubeadmControlPlane.Name).To(Equal("eksa-unit-test"))
+	g.Expect(cp.ProviderCluster.GetName()).To(Equal("eksa-unit-test"))
+	g.Expect(cp.ControlPlaneMachineTemplate.GetName()).To(Equal("eksa-unit-test-control-plane-1"))
+	g.Expect(cp.KubeadmControlPlane.Spec.MachineTemplate.InfrastructureRef.Name).To(Equal("eksa-unit-test-control-plane-1"))
+	g.Expect(cp.Secrets).To(HaveLen(1))
+	g.Expect(cp.Secrets[0].Name).To(Equal("eksa-unit-test"))
+	g.Expect(cp.Secrets[0].Data).To(HaveKey(credentials.KeyName))
+	g.Expect(cp.Objects()).To(ContainElement(cp.Secrets[0]))
+}
+
+func TestControlPlaneSpecUpdateMachineTemplates(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	spec := test.NewFullClusterSpec(t, testClusterConfigMainFilename)
+
+	cp, err := nutanix.ControlPlaneSpec(ctx, logger, test.NewFakeKubeClient(), spec, testCreds())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	oldCPTemplate := cp.ControlPlaneMachineTemplate.DeepCopy()
+	g.Expect(unstructured.SetNestedField(oldCPTemplate.Object, "old-image", "spec", "template", "spec", "image", "name")).To(Succeed())
+	client := test.NewFakeKubeClient(cp.KubeadmControlPlane.DeepCopy(), oldCPTemplate)
+
+	cp, err = nutanix.ControlPlaneSpec(ctx, logger, client, spec, testCreds())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cp.ControlPlaneMachineTemplate.GetName()).To(Equal("eksa-unit-test-control-plane-2"))
+	g.Expect(cp.KubeadmControlPlane.Spec.MachineTemplate.InfrastructureRef.Name).To(Equal("eksa-unit-test-control-plane-2"))
+}
+
+func TestControlPlaneSpecNoChangesMachineTemplates(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	spec := test.NewFullClusterSpec(t, testClusterConfigMainFilename)
+
+	cp, err := nutanix.ControlPlaneSpec(ctx, logger, test.NewFakeKubeClient(), spec, testCreds())
+	g.Expect(err).NotTo(HaveOccurred())
+	client := test.NewFakeKubeClient(
+		cp.KubeadmControlPlane.DeepCopy(),
+		cp.ControlPlaneMachineTemplate.DeepCopy(),
+	)
+
+	cp, err = nutanix.ControlPlaneSpec(ctx, logger, client, spec, testCreds())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cp.ControlPlaneMachineTemplate.GetName()).To(Equal("eksa-unit-test-control-plane-1"))
+}
+
+func TestControlPlaneSpecMissingMachineConfig(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	spec := test.NewFullClusterSpec(t, testClusterConfigMainFilename)
+	delete(spec.NutanixMachineConfigs, "eksa-unit-test")
+
+	_, err := nutanix.ControlPlaneSpec(ctx, logger, test.NewFakeKubeClient(), spec, testCreds())
+	g.Expect(err).To(MatchError(ContainSubstring("control plane NutanixMachineConfig is missing from cluster spec")))
+}
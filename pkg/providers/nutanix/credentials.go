@@ -0,0 +1,38 @@
+package nutanix
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/nutanix-cloud-native/prism-go-client/environment/credentials"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GetCredsFromSecret returns the Prism Central basic auth credentials stored in a secret.
+// The secret is expected to follow the same format CAPX uses for its credentialRef.
+func GetCredsFromSecret(secret *corev1.Secret) (credentials.BasicAuthCredential, error) {
+	data, ok := secret.Data[credentials.KeyName]
+	if !ok {
+		return credentials.BasicAuthCredential{}, fmt.Errorf("secret %s is missing key %s", secret.Name, credentials.KeyName)
+	}
+
+	var creds []credentials.Credential
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return credentials.BasicAuthCredential{}, fmt.Errorf("parsing nutanix credentials from secret %s: %v", secret.Name, err)
+	}
+
+	for _, c := range creds {
+		if c.Type != credentials.BasicAuthCredentialType {
+			continue
+		}
+
+		basicAuth := credentials.BasicAuthCredential{}
+		if err := json.Unmarshal(c.Data, &basicAuth); err != nil {
+			return credentials.BasicAuthCredential{}, fmt.Errorf("parsing nutanix basic auth credentials from secret %s: %v", secret.Name, err)
+		}
+
+		return basicAuth, nil
+	}
+
+	return credentials.BasicAuthCredential{}, fmt.Errorf("secret %s does not contain nutanix basic auth credentials", secret.Name)
+}
@@ -0,0 +1,56 @@
+package nutanix_test
+
+import (
+	"testing"
+
+	"github.com/nutanix-cloud-native/prism-go-client/environment/credentials"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/providers/nutanix"
+)
+
+func credsSecret(data string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nutanix-credentials",
+			Namespace: "eksa-system",
+		},
+		Data: map[string][]byte{
+			credentials.KeyName: []byte(data),
+		},
+	}
+}
+
+func TestGetCredsFromSecretSuccess(t *testing.T) {
+	g := NewWithT(t)
+	secret := credsSecret(`[{"type":"basic_auth","data":{"prismCentral":{"username":"admin","password":"password"},"prismElements":null}}]`)
+
+	creds, err := nutanix.GetCredsFromSecret(secret)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(creds).To(Equal(testCreds()))
+}
+
+func TestGetCredsFromSecretMissingKey(t *testing.T) {
+	g := NewWithT(t)
+	secret := credsSecret("")
+	secret.Data = nil
+
+	_, err := nutanix.GetCredsFromSecret(secret)
+	g.Expect(err).To(MatchError("secret nutanix-credentials is missing key credentials"))
+}
+
+func TestGetCredsFromSecretInvalidJSON(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := nutanix.GetCredsFromSecret(credsSecret("not-json"))
+	g.Expect(err).To(MatchError(ContainSubstring("parsing nutanix credentials from secret nutanix-credentials")))
+}
+
+func TestGetCredsFromSecretNoBasicAuth(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := nutanix.GetCredsFromSecret(credsSecret(`[{"type":"token","data":{}}]`))
+	g.Expect(err).To(MatchError("secret nutanix-credentials does not contain nutanix basic auth credentials"))
+}
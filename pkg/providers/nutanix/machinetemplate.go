@@ -0,0 +1,41 @@
+package nutanix
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/yamlutil"
+)
+
+const (
+	nutanixClusterKind         = "NutanixCluster"
+	nutanixMachineTemplateKind = "NutanixMachineTemplate"
+	capxAPIVersion             = "infrastructure.cluster.x-k8s.io/v1beta1"
+)
+
+func getMachineTemplate(ctx context.Context, client kubernetes.Client, name, namespace string) (*unstructured.Unstructured, error) {
+	m := newUnstructured()
+	m.SetAPIVersion(capxAPIVersion)
+	m.SetKind(nutanixMachineTemplateKind)
+	if err := client.Get(ctx, name, namespace, m); err != nil {
+		return nil, errors.Wrap(err, "reading nutanixMachineTemplate")
+	}
+
+	return m, nil
+}
+
+func machineTemplateEqual(new, old *unstructured.Unstructured) bool {
+	return equality.Semantic.DeepDerivative(new.Object["spec"], old.Object["spec"])
+}
+
+func machineTemplateMapping() yamlutil.Mapping[*unstructured.Unstructured] {
+	return yamlutil.NewMapping(nutanixMachineTemplateKind, newUnstructured)
+}
+
+func newUnstructured() *unstructured.Unstructured {
+	return &unstructured.Unstructured{}
+}
@@ -0,0 +1,223 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/nutanix-cloud-native/prism-go-client/environment/credentials"
+	apiv1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	c "github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+	"github.com/aws/eks-anywhere/pkg/controller/serverside"
+	"github.com/aws/eks-anywhere/pkg/providers/nutanix"
+)
+
+// CNIReconciler is an interface for reconciling CNI in the Nutanix cluster reconciler.
+type CNIReconciler interface {
+	Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *c.Spec) (controller.Result, error)
+}
+
+// RemoteClientRegistry is an interface that defines methods for remote clients.
+type RemoteClientRegistry interface {
+	GetClient(ctx context.Context, cluster client.ObjectKey) (client.Client, error)
+}
+
+// IPValidator is an interface that defines methods to validate the control plane IP.
+type IPValidator interface {
+	ValidateControlPlaneIP(ctx context.Context, log logr.Logger, spec *c.Spec) (controller.Result, error)
+}
+
+// Reconciler contains dependencies for a Nutanix reconciler.
+type Reconciler struct {
+	client               client.Client
+	validatorRegistry    nutanix.ValidatorRegistry
+	cniReconciler        CNIReconciler
+	remoteClientRegistry RemoteClientRegistry
+	ipValidator          IPValidator
+	*serverside.ObjectApplier
+}
+
+// New defines a new Nutanix reconciler.
+func New(client client.Client, validatorRegistry nutanix.ValidatorRegistry, cniReconciler CNIReconciler, remoteClientRegistry RemoteClientRegistry, ipValidator IPValidator) *Reconciler {
+	return &Reconciler{
+		client:               client,
+		validatorRegistry:    validatorRegistry,
+		cniReconciler:        cniReconciler,
+		remoteClientRegistry: remoteClientRegistry,
+		ipValidator:          ipValidator,
+		ObjectApplier:        serverside.NewObjectApplier(client),
+	}
+}
+
+// PrismCredentials reads the Prism Central credentials from the secret in the eksa-system namespace.
+func PrismCredentials(ctx context.Context, cli client.Client) (credentials.BasicAuthCredential, error) {
+	secret := &apiv1.Secret{}
+	secretKey := client.ObjectKey{
+		Namespace: constants.EksaSystemNamespace,
+		Name:      constants.NutanixCredentialsName,
+	}
+	if err := cli.Get(ctx, secretKey, secret); err != nil {
+		return credentials.BasicAuthCredential{}, fmt.Errorf("getting nutanix credentials secret %s: %v", constants.NutanixCredentialsName, err)
+	}
+
+	return nutanix.GetCredsFromSecret(secret)
+}
+
+// Reconcile reconciles the cluster to the desired state.
+func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
+	log = log.WithValues("provider", "nutanix")
+	clusterSpec, err := c.BuildSpec(ctx, clientutil.NewKubeClient(r.client), cluster)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return controller.NewPhaseRunner().Register(
+		r.ipValidator.ValidateControlPlaneIP,
+		r.ValidateDatacenterConfig,
+		r.ValidateMachineConfigs,
+		clusters.CleanupStatusAfterValidate,
+		r.ReconcileControlPlane,
+		r.CheckControlPlaneReady,
+		r.ReconcileCNI,
+		r.ReconcileWorkers,
+	).Run(ctx, log, clusterSpec)
+}
+
+// ReconcileWorkerNodes validates the cluster definition and reconciles the worker nodes
+// to the desired state.
+func (r *Reconciler) ReconcileWorkerNodes(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
+	log = log.WithValues("provider", "nutanix", "reconcile type", "workers")
+	clusterSpec, err := c.BuildSpec(ctx, clientutil.NewKubeClient(r.client), cluster)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return controller.NewPhaseRunner().Register(
+		r.ValidateDatacenterConfig,
+		r.ValidateMachineConfigs,
+		r.ReconcileWorkers,
+	).Run(ctx, log, clusterSpec)
+}
+
+// ValidateDatacenterConfig validates the NutanixDatacenterConfig against Prism Central and
+// updates the cluster status if it's invalid.
+func (r *Reconciler) ValidateDatacenterConfig(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "validateDatacenterConfig")
+	validator, err := r.validator(ctx, clusterSpec)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	if err := validator.ValidateDatacenterConfig(ctx, clusterSpec.NutanixDatacenter); err != nil {
+		log.Error(err, "Invalid NutanixDatacenterConfig")
+		failureMessage := fmt.Sprintf("Invalid %s NutanixDatacenterConfig: %s", clusterSpec.NutanixDatacenter.Name, err.Error())
+		clusterSpec.Cluster.Status.FailureMessage = &failureMessage
+		return controller.ResultWithReturn(), nil
+	}
+	return controller.Result{}, nil
+}
+
+// ValidateMachineConfigs validates the image, subnet and Prism Element cluster referenced by each
+// NutanixMachineConfig and updates the cluster status if any of them is invalid.
+func (r *Reconciler) ValidateMachineConfigs(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "validateMachineConfigs")
+	validator, err := r.validator(ctx, clusterSpec)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	for _, machineConfig := range clusterSpec.NutanixMachineConfigs {
+		if err := validator.ValidateMachineConfig(ctx, machineConfig); err != nil {
+			log.Error(err, "Invalid NutanixMachineConfig")
+			failureMessage := fmt.Sprintf("Invalid %s NutanixMachineConfig: %s", machineConfig.Name, err.Error())
+			clusterSpec.Cluster.Status.FailureMessage = &failureMessage
+			return controller.ResultWithReturn(), nil
+		}
+	}
+	return controller.Result{}, nil
+}
+
+// ReconcileControlPlane applies the control plane CAPI objects to the cluster.
+func (r *Reconciler) ReconcileControlPlane(ctx context.Context, log logr.Logger, spec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "reconcileControlPlane")
+	log.Info("Applying control plane CAPI objects")
+	creds, err := PrismCredentials(ctx, r.client)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	cp, err := nutanix.ControlPlaneSpec(ctx, log, clientutil.NewKubeClient(r.client), spec, creds)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return clusters.ReconcileControlPlane(ctx, r.client, toClientControlPlane(cp))
+}
+
+// CheckControlPlaneReady checks whether the control plane for an eks-a cluster is ready or not.
+// Requeues with the appropriate wait times whenever the cluster is not ready yet.
+func (r *Reconciler) CheckControlPlaneReady(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "checkControlPlaneReady")
+	return clusters.CheckControlPlaneReady(ctx, r.client, log, clusterSpec.Cluster)
+}
+
+// ReconcileCNI takes the Cilium CNI in a cluster to the desired state defined in a cluster spec.
+func (r *Reconciler) ReconcileCNI(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "reconcileCNI")
+	client, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(clusterSpec.Cluster))
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return r.cniReconciler.Reconcile(ctx, log, client, clusterSpec)
+}
+
+// ReconcileWorkers applies the worker CAPI objects to the cluster.
+func (r *Reconciler) ReconcileWorkers(ctx context.Context, log logr.Logger, spec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "reconcileWorkers")
+	log.Info("Applying worker CAPI objects")
+	w, err := nutanix.WorkersSpec(ctx, log, clientutil.NewKubeClient(r.client), spec)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return clusters.ReconcileWorkersForEKSA(ctx, log, r.client, spec.Cluster, clusters.ToWorkers(w))
+}
+
+func (r *Reconciler) validator(ctx context.Context, clusterSpec *c.Spec) (nutanix.ProviderValidator, error) {
+	creds, err := PrismCredentials(ctx, r.client)
+	if err != nil {
+		return nil, err
+	}
+
+	validator, err := r.validatorRegistry.Get(clusterSpec.NutanixDatacenter, creds)
+	if err != nil {
+		return nil, fmt.Errorf("building nutanix validator: %v", err)
+	}
+
+	return validator, nil
+}
+
+func toClientControlPlane(cp *nutanix.ControlPlane) *clusters.ControlPlane {
+	other := make([]client.Object, 0, len(cp.Secrets))
+	for _, s := range cp.Secrets {
+		other = append(other, s)
+	}
+
+	return &clusters.ControlPlane{
+		Cluster:                     cp.Cluster,
+		ProviderCluster:             cp.ProviderCluster,
+		KubeadmControlPlane:         cp.KubeadmControlPlane,
+		ControlPlaneMachineTemplate: cp.ControlPlaneMachineTemplate,
+		EtcdCluster:                 cp.EtcdCluster,
+		EtcdMachineTemplate:         cp.EtcdMachineTemplate,
+		Other:                       other,
+	}
+}
@@ -0,0 +1,620 @@
+package reconciler_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/nutanix-cloud-native/prism-go-client/environment/credentials"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/internal/test/envtest"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	clusterspec "github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+	"github.com/aws/eks-anywhere/pkg/providers/nutanix/reconciler"
+	nutanixreconcilermocks "github.com/aws/eks-anywhere/pkg/providers/nutanix/reconciler/mocks"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const (
+	clusterNamespace   = "test-namespace"
+	capxAPIVersion     = "infrastructure.cluster.x-k8s.io/v1beta1"
+	prismCredsUsername = "admin"
+	prismCredsPassword = "password"
+)
+
+func TestReconcilerReconcileSuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	// We want to check that the cluster status is cleaned up if validations are passed
+	tt.cluster.Status.FailureMessage = ptr.String("invalid cluster")
+	capiCluster := test.CAPICluster(func(c *clusterv1.Cluster) {
+		c.Name = tt.cluster.Name
+	})
+	tt.eksaSupportObjs = append(tt.eksaSupportObjs, capiCluster)
+	tt.createAllObjs()
+
+	logger := test.NewNullLogger()
+	remoteClient := fake.NewClientBuilder().Build()
+
+	tt.ipValidator.EXPECT().ValidateControlPlaneIP(tt.ctx, logger, gomock.Any()).Return(controller.Result{}, nil)
+	tt.expectValidationsPass()
+
+	tt.remoteClientRegistry.EXPECT().GetClient(
+		tt.ctx, client.ObjectKey{Name: "workload-cluster", Namespace: "eksa-system"},
+	).Return(remoteClient, nil)
+	tt.cniReconciler.EXPECT().Reconcile(tt.ctx, logger, remoteClient, gomock.Any())
+
+	result, err := tt.reconciler().Reconcile(tt.ctx, logger, tt.cluster)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(tt.cluster.Status.FailureMessage).To(BeZero())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerReconcileWorkerNodesSuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.cluster.Name = "my-management-cluster"
+	tt.cluster.SetSelfManaged()
+	capiCluster := test.CAPICluster(func(c *clusterv1.Cluster) {
+		c.Name = tt.cluster.Name
+	})
+	tt.eksaSupportObjs = append(tt.eksaSupportObjs, capiCluster)
+	tt.createAllObjs()
+
+	logger := test.NewNullLogger()
+	tt.expectValidationsPass()
+
+	result, err := tt.reconciler().ReconcileWorkerNodes(tt.ctx, logger, tt.cluster)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(tt.cluster.Status.FailureMessage).To(BeZero())
+	tt.Expect(result).To(Equal(controller.Result{}))
+
+	tt.ShouldEventuallyExist(tt.ctx,
+		&bootstrapv1.KubeadmConfigTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-management-cluster-md-0-1",
+				Namespace: constants.EksaSystemNamespace,
+			},
+		},
+	)
+
+	tt.ShouldEventuallyExist(tt.ctx, nutanixObject("NutanixMachineTemplate", "my-management-cluster-md-0-1"))
+
+	tt.ShouldEventuallyExist(tt.ctx,
+		&clusterv1.MachineDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-management-cluster-md-0",
+				Namespace: constants.EksaSystemNamespace,
+			},
+		},
+	)
+}
+
+func TestReconcilerControlPlaneIsNotReady(t *testing.T) {
+	tt := newReconcilerTest(t)
+	capiCluster := test.CAPICluster(func(c *clusterv1.Cluster) {
+		c.Name = tt.cluster.Name
+	})
+	capiCluster.Status.Conditions = clusterv1.Conditions{
+		{
+			Type:               clusterapi.ControlPlaneReadyCondition,
+			Status:             corev1.ConditionFalse,
+			LastTransitionTime: metav1.NewTime(time.Now()),
+		},
+	}
+	tt.eksaSupportObjs = append(tt.eksaSupportObjs, capiCluster)
+	tt.createAllObjs()
+
+	logger := test.NewNullLogger()
+
+	tt.ipValidator.EXPECT().ValidateControlPlaneIP(tt.ctx, logger, gomock.Any()).Return(controller.Result{}, nil)
+	tt.expectValidationsPass()
+
+	result, err := tt.reconciler().Reconcile(tt.ctx, logger, tt.cluster)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(tt.cluster.Status.FailureMessage).To(BeZero())
+	tt.Expect(result).To(Equal(controller.ResultWithRequeue(30 * time.Second)))
+}
+
+func TestReconcilerValidateDatacenterConfigSuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+	spec := tt.buildSpec()
+	tt.validatorRegistry.EXPECT().Get(spec.NutanixDatacenter, prismCreds()).Return(tt.validator, nil)
+	tt.validator.EXPECT().ValidateDatacenterConfig(tt.ctx, spec.NutanixDatacenter).Return(nil)
+
+	result, err := tt.reconciler().ValidateDatacenterConfig(tt.ctx, test.NewNullLogger(), spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	tt.Expect(spec.Cluster.Status.FailureMessage).To(BeNil())
+}
+
+func TestReconcilerValidateDatacenterConfigInvalid(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+	spec := tt.buildSpec()
+	tt.validatorRegistry.EXPECT().Get(gomock.Any(), gomock.Any()).Return(tt.validator, nil)
+	tt.validator.EXPECT().ValidateDatacenterConfig(tt.ctx, gomock.Any()).Return(errors.New("invalid credentials"))
+
+	result, err := tt.reconciler().ValidateDatacenterConfig(tt.ctx, test.NewNullLogger(), spec)
+
+	tt.Expect(err).To(BeNil(), "error should be nil to prevent requeue")
+	tt.Expect(result).To(Equal(controller.Result{Result: &reconcile.Result{}}), "result should stop reconciliation")
+	tt.Expect(spec.Cluster.Status.FailureMessage).To(HaveValue(Equal("Invalid datacenter NutanixDatacenterConfig: invalid credentials")))
+}
+
+func TestReconcilerValidateDatacenterConfigMissingCredentials(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.eksaSupportObjs = tt.eksaSupportObjs[:len(tt.eksaSupportObjs)-1]
+	tt.withFakeClient()
+
+	result, err := tt.reconciler().ValidateDatacenterConfig(tt.ctx, test.NewNullLogger(), tt.buildSpec())
+
+	tt.Expect(err).To(MatchError(ContainSubstring("getting nutanix credentials secret nutanix-credentials")))
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerValidateDatacenterConfigRegistryError(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+	tt.validatorRegistry.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, errors.New("building prism client"))
+
+	result, err := tt.reconciler().ValidateDatacenterConfig(tt.ctx, test.NewNullLogger(), tt.buildSpec())
+
+	tt.Expect(err).To(MatchError(ContainSubstring("building nutanix validator: building prism client")))
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerValidateMachineConfigsSuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+	spec := tt.buildSpec()
+	tt.validatorRegistry.EXPECT().Get(gomock.Any(), gomock.Any()).Return(tt.validator, nil)
+	tt.validator.EXPECT().ValidateMachineConfig(tt.ctx, spec.NutanixMachineConfigs["cp-machine-config"]).Return(nil)
+	tt.validator.EXPECT().ValidateMachineConfig(tt.ctx, spec.NutanixMachineConfigs["worker-machine-config"]).Return(nil)
+
+	result, err := tt.reconciler().ValidateMachineConfigs(tt.ctx, test.NewNullLogger(), spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	tt.Expect(spec.Cluster.Status.FailureMessage).To(BeNil())
+}
+
+func TestReconcilerValidateMachineConfigsInvalid(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+	spec := tt.buildSpec()
+	tt.validatorRegistry.EXPECT().Get(gomock.Any(), gomock.Any()).Return(tt.validator, nil)
+	tt.validator.EXPECT().ValidateMachineConfig(tt.ctx, gomock.Any()).Return(errors.New("failed to find subnet by name \"prism-subnet\""))
+
+	result, err := tt.reconciler().ValidateMachineConfigs(tt.ctx, test.NewNullLogger(), spec)
+
+	tt.Expect(err).To(BeNil(), "error should be nil to prevent requeue")
+	tt.Expect(result).To(Equal(controller.Result{Result: &reconcile.Result{}}), "result should stop reconciliation")
+	tt.Expect(spec.Cluster.Status.FailureMessage).To(HaveValue(ContainSubstring("NutanixMachineConfig: failed to find subnet by name \"prism-subnet\"")))
+}
+
+func TestReconcileCNISuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+
+	logger := test.NewNullLogger()
+	remoteClient := fake.NewClientBuilder().Build()
+	spec := tt.buildSpec()
+
+	tt.remoteClientRegistry.EXPECT().GetClient(
+		tt.ctx, client.ObjectKey{Name: "workload-cluster", Namespace: "eksa-system"},
+	).Return(remoteClient, nil)
+	tt.cniReconciler.EXPECT().Reconcile(tt.ctx, logger, remoteClient, spec)
+
+	result, err := tt.reconciler().ReconcileCNI(tt.ctx, logger, spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(tt.cluster.Status.FailureMessage).To(BeZero())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcileCNIErrorClientRegistry(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+
+	logger := test.NewNullLogger()
+	spec := tt.buildSpec()
+
+	tt.remoteClientRegistry.EXPECT().GetClient(
+		tt.ctx, client.ObjectKey{Name: "workload-cluster", Namespace: "eksa-system"},
+	).Return(nil, errors.New("building client"))
+
+	result, err := tt.reconciler().ReconcileCNI(tt.ctx, logger, spec)
+
+	tt.Expect(err).To(MatchError(ContainSubstring("building client")))
+	tt.Expect(tt.cluster.Status.FailureMessage).To(BeZero())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerReconcileControlPlaneSuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.createAllObjs()
+
+	result, err := tt.reconciler().ReconcileControlPlane(tt.ctx, test.NewNullLogger(), tt.buildSpec())
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(tt.cluster.Status.FailureMessage).To(BeZero())
+	tt.Expect(result).To(Equal(controller.Result{}))
+
+	tt.ShouldEventuallyExist(tt.ctx,
+		&controlplanev1.KubeadmControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "workload-cluster",
+				Namespace: "eksa-system",
+			},
+		},
+	)
+
+	tt.ShouldEventuallyExist(tt.ctx, nutanixObject("NutanixCluster", "workload-cluster"))
+	tt.ShouldEventuallyExist(tt.ctx, nutanixObject("NutanixMachineTemplate", "workload-cluster-control-plane-1"))
+
+	tt.ShouldEventuallyExist(tt.ctx,
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "workload-cluster",
+				Namespace: "eksa-system",
+			},
+		},
+	)
+
+	capiCluster := test.CAPICluster(func(c *clusterv1.Cluster) {
+		c.Name = "workload-cluster"
+	})
+	tt.ShouldEventuallyExist(tt.ctx, capiCluster)
+}
+
+func TestReconcilerReconcileControlPlaneFailure(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.createAllObjs()
+	spec := tt.buildSpec()
+	spec.Cluster.Spec.KubernetesVersion = ""
+
+	_, err := tt.reconciler().ReconcileControlPlane(tt.ctx, test.NewNullLogger(), spec)
+
+	tt.Expect(err).To(HaveOccurred())
+}
+
+func TestPrismCredentials(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cli := fake.NewClientBuilder().WithObjects(credentialsSecret()).Build()
+
+	creds, err := reconciler.PrismCredentials(ctx, cli)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(creds).To(Equal(prismCreds()))
+}
+
+type reconcilerTest struct {
+	t testing.TB
+	*WithT
+	*envtest.APIExpecter
+	ctx                  context.Context
+	cniReconciler        *nutanixreconcilermocks.MockCNIReconciler
+	remoteClientRegistry *nutanixreconcilermocks.MockRemoteClientRegistry
+	validatorRegistry    *nutanixreconcilermocks.MockValidatorRegistry
+	ipValidator          *nutanixreconcilermocks.MockIPValidator
+	validator            *nutanixreconcilermocks.MockProviderValidator
+	cluster              *anywherev1.Cluster
+	client               client.Client
+	env                  *envtest.Environment
+	bundle               *releasev1.Bundles
+	eksaSupportObjs      []client.Object
+	datacenterConfig     *anywherev1.NutanixDatacenterConfig
+	machineConfigCP      *anywherev1.NutanixMachineConfig
+	machineConfigWorker  *anywherev1.NutanixMachineConfig
+}
+
+func newReconcilerTest(t testing.TB) *reconcilerTest {
+	ctrl := gomock.NewController(t)
+	cniReconciler := nutanixreconcilermocks.NewMockCNIReconciler(ctrl)
+	remoteClientRegistry := nutanixreconcilermocks.NewMockRemoteClientRegistry(ctrl)
+	validatorRegistry := nutanixreconcilermocks.NewMockValidatorRegistry(ctrl)
+	ipValidator := nutanixreconcilermocks.NewMockIPValidator(ctrl)
+	validator := nutanixreconcilermocks.NewMockProviderValidator(ctrl)
+	c := env.Client()
+
+	bundle := test.Bundle()
+
+	managementCluster := nutanixCluster(func(c *anywherev1.Cluster) {
+		c.Name = "management-cluster"
+		c.Spec.ManagementCluster = anywherev1.ManagementCluster{
+			Name: c.Name,
+		}
+		c.Spec.BundlesRef = &anywherev1.BundlesRef{
+			Name:       bundle.Name,
+			Namespace:  bundle.Namespace,
+			APIVersion: bundle.APIVersion,
+		}
+	})
+
+	machineConfigCP := machineConfig(func(m *anywherev1.NutanixMachineConfig) {
+		m.Name = "cp-machine-config"
+	})
+	machineConfigWN := machineConfig(func(m *anywherev1.NutanixMachineConfig) {
+		m.Name = "worker-machine-config"
+	})
+
+	workloadClusterDatacenter := dataCenter()
+
+	cluster := nutanixCluster(func(c *anywherev1.Cluster) {
+		c.Name = "workload-cluster"
+		c.Spec.ManagementCluster = anywherev1.ManagementCluster{
+			Name: managementCluster.Name,
+		}
+		c.Spec.BundlesRef = &anywherev1.BundlesRef{
+			Name:       bundle.Name,
+			Namespace:  bundle.Namespace,
+			APIVersion: bundle.APIVersion,
+		}
+		c.Spec.ControlPlaneConfiguration = anywherev1.ControlPlaneConfiguration{
+			Count: 1,
+			Endpoint: &anywherev1.Endpoint{
+				Host: "1.1.1.1",
+			},
+			MachineGroupRef: &anywherev1.Ref{
+				Kind: anywherev1.NutanixMachineConfigKind,
+				Name: machineConfigCP.Name,
+			},
+		}
+		c.Spec.DatacenterRef = anywherev1.Ref{
+			Kind: anywherev1.NutanixDatacenterKind,
+			Name: workloadClusterDatacenter.Name,
+		}
+
+		c.Spec.WorkerNodeGroupConfigurations = append(c.Spec.WorkerNodeGroupConfigurations,
+			anywherev1.WorkerNodeGroupConfiguration{
+				Count: ptr.Int(1),
+				MachineGroupRef: &anywherev1.Ref{
+					Kind: anywherev1.NutanixMachineConfigKind,
+					Name: machineConfigWN.Name,
+				},
+				Name:   "md-0",
+				Labels: nil,
+			},
+		)
+	})
+
+	tt := &reconcilerTest{
+		t:                    t,
+		WithT:                NewWithT(t),
+		APIExpecter:          envtest.NewAPIExpecter(t, c),
+		ctx:                  context.Background(),
+		cniReconciler:        cniReconciler,
+		remoteClientRegistry: remoteClientRegistry,
+		validatorRegistry:    validatorRegistry,
+		ipValidator:          ipValidator,
+		validator:            validator,
+		client:               c,
+		env:                  env,
+		eksaSupportObjs: []client.Object{
+			test.Namespace(clusterNamespace),
+			test.Namespace(constants.EksaSystemNamespace),
+			managementCluster,
+			workloadClusterDatacenter,
+			bundle,
+			test.EksdRelease(),
+			credentialsSecret(),
+		},
+		bundle:              bundle,
+		cluster:             cluster,
+		datacenterConfig:    workloadClusterDatacenter,
+		machineConfigCP:     machineConfigCP,
+		machineConfigWorker: machineConfigWN,
+	}
+
+	t.Cleanup(tt.cleanup)
+	return tt
+}
+
+func (tt *reconcilerTest) cleanup() {
+	tt.DeleteAndWait(tt.ctx, tt.allObjs()...)
+
+	tt.DeleteAllOfAndWait(tt.ctx, &bootstrapv1.KubeadmConfigTemplate{})
+	tt.DeleteAllOfAndWait(tt.ctx, &clusterv1.MachineDeployment{})
+	tt.DeleteAndWait(tt.ctx,
+		nutanixObject("NutanixMachineTemplate", "workload-cluster-control-plane-1"),
+		nutanixObject("NutanixMachineTemplate", "workload-cluster-md-0-1"),
+		nutanixObject("NutanixMachineTemplate", "my-management-cluster-md-0-1"),
+	)
+}
+
+func (tt *reconcilerTest) buildSpec() *clusterspec.Spec {
+	tt.t.Helper()
+	spec, err := clusterspec.BuildSpec(tt.ctx, clientutil.NewKubeClient(tt.client), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	return spec
+}
+
+func (tt *reconcilerTest) withFakeClient() {
+	tt.client = fake.NewClientBuilder().WithObjects(clientutil.ObjectsToClientObjects(tt.allObjs())...).Build()
+}
+
+func (tt *reconcilerTest) reconciler() *reconciler.Reconciler {
+	return reconciler.New(tt.client, tt.validatorRegistry, tt.cniReconciler, tt.remoteClientRegistry, tt.ipValidator)
+}
+
+func (tt *reconcilerTest) createAllObjs() {
+	tt.t.Helper()
+	envtest.CreateObjs(tt.ctx, tt.t, tt.client, tt.allObjs()...)
+}
+
+func (tt *reconcilerTest) allObjs() []client.Object {
+	objs := make([]client.Object, 0, len(tt.eksaSupportObjs)+3)
+	objs = append(objs, tt.eksaSupportObjs...)
+	objs = append(objs, tt.cluster, tt.machineConfigCP, tt.machineConfigWorker)
+
+	return objs
+}
+
+// expectValidationsPass sets up the Prism Central validations made for the datacenter and machine configs.
+func (tt *reconcilerTest) expectValidationsPass() {
+	tt.validatorRegistry.EXPECT().Get(gomock.Any(), prismCreds()).Return(tt.validator, nil).AnyTimes()
+	tt.validator.EXPECT().ValidateDatacenterConfig(tt.ctx, gomock.Any()).Return(nil).AnyTimes()
+	tt.validator.EXPECT().ValidateMachineConfig(tt.ctx, gomock.Any()).Return(nil).AnyTimes()
+}
+
+type clusterOpt func(*anywherev1.Cluster)
+
+func nutanixCluster(opts ...clusterOpt) *anywherev1.Cluster {
+	c := &anywherev1.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.ClusterKind,
+			APIVersion: anywherev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: clusterNamespace,
+		},
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion: "1.20",
+			ClusterNetwork: anywherev1.ClusterNetwork{
+				Pods: anywherev1.Pods{
+					CidrBlocks: []string{"0.0.0.0"},
+				},
+				Services: anywherev1.Services{
+					CidrBlocks: []string{"0.0.0.0"},
+				},
+			},
+		},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+type datacenterOpt func(config *anywherev1.NutanixDatacenterConfig)
+
+func dataCenter(opts ...datacenterOpt) *anywherev1.NutanixDatacenterConfig {
+	d := &anywherev1.NutanixDatacenterConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.NutanixDatacenterKind,
+			APIVersion: anywherev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "datacenter",
+			Namespace: clusterNamespace,
+		},
+		Spec: anywherev1.NutanixDatacenterConfigSpec{
+			Endpoint: "prism.nutanix.com",
+			Port:     9440,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
+type nutanixMachineOpt func(config *anywherev1.NutanixMachineConfig)
+
+func machineConfig(opts ...nutanixMachineOpt) *anywherev1.NutanixMachineConfig {
+	m := &anywherev1.NutanixMachineConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.NutanixMachineConfigKind,
+			APIVersion: anywherev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: clusterNamespace,
+		},
+		Spec: anywherev1.NutanixMachineConfigSpec{
+			OSFamily:       anywherev1.Ubuntu,
+			VCPUsPerSocket: 1,
+			VCPUSockets:    4,
+			MemorySize:     resource.MustParse("8Gi"),
+			SystemDiskSize: resource.MustParse("40Gi"),
+			Image: anywherev1.NutanixResourceIdentifier{
+				Type: anywherev1.NutanixIdentifierName,
+				Name: ptr.String("prism-image"),
+			},
+			Cluster: anywherev1.NutanixResourceIdentifier{
+				Type: anywherev1.NutanixIdentifierName,
+				Name: ptr.String("prism-cluster"),
+			},
+			Subnet: anywherev1.NutanixResourceIdentifier{
+				Type: anywherev1.NutanixIdentifierName,
+				Name: ptr.String("prism-subnet"),
+			},
+			Users: []anywherev1.UserConfiguration{
+				{
+					Name:              "user",
+					SshAuthorizedKeys: []string{"ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABgQC8ZEibIrz1AUBKDvmDiWLs9f5DnOerC4qPITiDtSOuPAsxgZbRMavBfVTxodMdAkYRYlXxK6PqNo0ve0qcOV2yvpxH1OogasMMetck6BlM/dIoo3vEY4ZoG9DuVRIf9Iry5gJKbpMDYWpx1IGZrDMOFcIM20ii2qLQQk5hfq9OqdqhToEJFixdgJt/y/zt6Koy3kix+XsnrVdAHgWAq4CZuwt1G6JUAqrpob3H8vPmL7aS+35ktf0pHBm6nYoxRhslnWMUb/7vpzWiq+fUBIm2LYqvrnm7t3fRqFx7p2sZqAm2jDNivyYXwRXkoQPR96zvGeMtuQ5BVGPpsDfVudSW21+pEXHI0GINtTbua7Ogz7wtpVywSvHraRgdFOeY9mkXPzvm2IhoqNrteck2GErwqSqb19mPz6LnHueK0u7i6WuQWJn0CUoCtyMGIrowXSviK8qgHXKrmfTWATmCkbtosnLskNdYuOw8bKxq5S4WgdQVhPps2TiMSZndjX5NTr8= ubuntu@ip-10-2-0-6"},
+				},
+			},
+		},
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+func nutanixObject(kind, name string) *unstructured.Unstructured {
+	o := &unstructured.Unstructured{}
+	o.SetAPIVersion(capxAPIVersion)
+	o.SetKind(kind)
+	o.SetName(name)
+	o.SetNamespace(constants.EksaSystemNamespace)
+
+	return o
+}
+
+func prismCreds() credentials.BasicAuthCredential {
+	return credentials.BasicAuthCredential{
+		PrismCentral: credentials.PrismCentralBasicAuth{
+			BasicAuth: credentials.BasicAuth{
+				Username: prismCredsUsername,
+				Password: prismCredsPassword,
+			},
+		},
+	}
+}
+
+func credentialsSecret() *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: constants.EksaSystemNamespace,
+			Name:      constants.NutanixCredentialsName,
+		},
+		Data: map[string][]byte{
+			credentials.KeyName: []byte(`[{"type":"basic_auth","data":{"prismCentral":{"username":"` + prismCredsUsername + `","password":"` + prismCredsPassword + `"}}}]`),
+		},
+	}
+}
@@ -9,9 +9,11 @@ import (
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/crypto"
 	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/providers/common"
 	"github.com/aws/eks-anywhere/pkg/templater"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
@@ -54,7 +56,10 @@ func (ntb *TemplateBuilder) GenerateCAPISpecControlPlane(clusterSpec *cluster.Sp
 		etcdMachineSpec = *ntb.etcdMachineSpec
 	}
 
-	values := buildTemplateMapCP(ntb.datacenterSpec, clusterSpec, *ntb.controlPlaneMachineSpec, etcdMachineSpec)
+	values, err := buildTemplateMapCP(ntb.datacenterSpec, clusterSpec, *ntb.controlPlaneMachineSpec, etcdMachineSpec)
+	if err != nil {
+		return nil, err
+	}
 	for _, buildOption := range buildOptions {
 		buildOption(values)
 	}
@@ -86,6 +91,16 @@ func (ntb *TemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluster.Spec, w
 }
 
 func (ntb *TemplateBuilder) GenerateCAPISpecSecret(clusterSpec *cluster.Spec, buildOptions ...providers.BuildMapOption) (content []byte, err error) {
+	return ntb.generateSecret(clusterSpec.Cluster.Name, buildOptions...)
+}
+
+// GenerateEKSASpecSecret generates the secret in the eksa-system namespace the EKS-A controller
+// reads the Prism Central credentials from.
+func (ntb *TemplateBuilder) GenerateEKSASpecSecret(buildOptions ...providers.BuildMapOption) (content []byte, err error) {
+	return ntb.generateSecret(constants.NutanixCredentialsName, buildOptions...)
+}
+
+func (ntb *TemplateBuilder) generateSecret(secretName string, buildOptions ...providers.BuildMapOption) (content []byte, err error) {
 	encodedCreds, err := jsonMarshal(ntb.creds)
 	if err != nil {
 		return nil, err
@@ -100,7 +115,7 @@ func (ntb *TemplateBuilder) GenerateCAPISpecSecret(clusterSpec *cluster.Spec, bu
 		return nil, err
 	}
 
-	values := buildTemplateMapSecret(clusterSpec, credsJSON)
+	values := buildTemplateMapSecret(secretName, credsJSON)
 	for _, buildOption := range buildOptions {
 		buildOption(values)
 	}
@@ -122,9 +137,14 @@ func buildTemplateMapCP(
 	clusterSpec *cluster.Spec,
 	controlPlaneMachineSpec v1alpha1.NutanixMachineConfigSpec,
 	etcdMachineSpec v1alpha1.NutanixMachineConfigSpec,
-) map[string]interface{} {
+) (map[string]interface{}, error) {
 	bundle := clusterSpec.VersionsBundle
 	format := "cloud-config"
+	kubeletExtraArgs := evictionHardExtraArgs().
+		Append(clusterapi.KubeletConfigurationExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.KubeletConfiguration))
+	apiServerExtraArgs := clusterapi.EncryptionConfigurationExtraArgs(clusterSpec.Cluster.Spec.EncryptionConfiguration).
+		Append(clusterapi.PodSecurityExtraArgs(clusterSpec.Cluster.Spec.PodSecurity)).
+		Append(clusterapi.APIServerExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration))
 
 	values := map[string]interface{}{
 		"clusterName":                  clusterSpec.Cluster.Name,
@@ -164,6 +184,10 @@ func buildTemplateMapCP(
 		"subnetIDType":                 controlPlaneMachineSpec.Subnet.Type,
 		"subnetName":                   controlPlaneMachineSpec.Subnet.Name,
 		"subnetUUID":                   controlPlaneMachineSpec.Subnet.UUID,
+		"kubeletExtraArgs":             kubeletExtraArgs.ToPartialYaml(),
+		"apiserverExtraArgs":           apiServerExtraArgs.ToPartialYaml(),
+		"controllerManagerExtraArgs":   clusterapi.ControllerManagerExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration).ToPartialYaml(),
+		"schedulerExtraArgs":           clusterapi.SchedulerExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration).ToPartialYaml(),
 	}
 
 	if clusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil {
@@ -172,12 +196,38 @@ func buildTemplateMapCP(
 		values["etcdSshUsername"] = etcdMachineSpec.Users[0].Name
 	}
 
-	return values
+	// Audit logging is only enabled when the cluster spec sets an audit policy
+	if clusterSpec.Cluster.Spec.AuditPolicy != nil {
+		auditPolicy, err := common.AuditPolicy(clusterSpec)
+		if err != nil {
+			return nil, err
+		}
+		values["auditPolicy"] = auditPolicy
+		values["apiserverExtraArgs"] = clusterapi.AuditLogExtraArgs(clusterSpec.Cluster.Spec.AuditPolicy).
+			Append(apiServerExtraArgs).ToPartialYaml()
+	}
+
+	if clusterSpec.Cluster.Spec.EncryptionConfiguration != nil {
+		values["encryptionConfig"] = true
+		values["kmsPluginSocketDirs"] = clusterapi.KMSPluginSocketDirs(clusterSpec.Cluster.Spec.EncryptionConfiguration)
+	}
+
+	if clusterSpec.Cluster.Spec.PodSecurity != nil {
+		admissionConfig, err := clusterapi.PodSecurityAdmissionConfiguration(clusterSpec.Cluster.Spec.PodSecurity)
+		if err != nil {
+			return nil, err
+		}
+		values["admissionConfig"] = admissionConfig
+	}
+
+	return values, nil
 }
 
 func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupMachineSpec v1alpha1.NutanixMachineConfigSpec, workerNodeGroupConfiguration v1alpha1.WorkerNodeGroupConfiguration) map[string]interface{} {
 	bundle := clusterSpec.VersionsBundle
 	format := "cloud-config"
+	kubeletExtraArgs := evictionHardExtraArgs().
+		Append(clusterapi.KubeletConfigurationExtraArgs(workerNodeGroupConfiguration.KubeletConfiguration))
 
 	values := map[string]interface{}{
 		"clusterName":            clusterSpec.Cluster.Name,
@@ -202,13 +252,22 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupMachineSpec v1
 		"subnetName":             workerNodeGroupMachineSpec.Subnet.Name,
 		"subnetUUID":             workerNodeGroupMachineSpec.Subnet.UUID,
 		"workerNodeGroupName":    fmt.Sprintf("%s-%s", clusterSpec.Cluster.Name, workerNodeGroupConfiguration.Name),
+		"kubeletExtraArgs":       kubeletExtraArgs.ToPartialYaml(),
 	}
 	return values
 }
 
-func buildTemplateMapSecret(clusterSpec *cluster.Spec, creds []byte) map[string]interface{} {
+// evictionHardExtraArgs returns the default kubelet eviction thresholds, which the evictionHard
+// set in the node group kubeletConfiguration replaces.
+func evictionHardExtraArgs() clusterapi.ExtraArgs {
+	return clusterapi.ExtraArgs{
+		"eviction-hard": "nodefs.available<0%,nodefs.inodesFree<0%,imagefs.available<0%",
+	}
+}
+
+func buildTemplateMapSecret(secretName string, creds []byte) map[string]interface{} {
 	values := map[string]interface{}{
-		"clusterName":              clusterSpec.Cluster.Name,
+		"secretName":               secretName,
 		"eksaSystemNamespace":      constants.EksaSystemNamespace,
 		"base64EncodedCredentials": base64.StdEncoding.EncodeToString(creds),
 	}
@@ -0,0 +1,46 @@
+package nutanix
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/nutanix-cloud-native/prism-go-client/environment/credentials"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/crypto"
+)
+
+// ValidatorRegistry exposes a single method for retrieving the Nutanix validator, and abstracts away how they are injected.
+type ValidatorRegistry interface {
+	Get(datacenterConfig *anywherev1.NutanixDatacenterConfig, creds credentials.BasicAuthCredential) (ProviderValidator, error)
+}
+
+// ProviderValidator exposes a common interface to avoid coupling on implementation details and to support mocking.
+type ProviderValidator interface {
+	ValidateDatacenterConfig(ctx context.Context, config *anywherev1.NutanixDatacenterConfig) error
+	ValidateMachineConfig(ctx context.Context, config *anywherev1.NutanixMachineConfig) error
+}
+
+// ValidatorFactory implements the ValidatorRegistry interface and holds the necessary structs for building fresh Validator objects.
+type ValidatorFactory struct {
+	certValidator crypto.TlsValidator
+	httpClient    *http.Client
+}
+
+// NewValidatorFactory initializes a factory for the Nutanix provider validator.
+func NewValidatorFactory(certValidator crypto.TlsValidator, httpClient *http.Client) ValidatorFactory {
+	return ValidatorFactory{
+		certValidator: certValidator,
+		httpClient:    httpClient,
+	}
+}
+
+// Get returns a validator for the Prism Central in a NutanixDatacenterConfig.
+func (f ValidatorFactory) Get(datacenterConfig *anywherev1.NutanixDatacenterConfig, creds credentials.BasicAuthCredential) (ProviderValidator, error) {
+	client, err := NewPrismClient(datacenterConfig, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewValidator(client.V3, f.certValidator, f.httpClient), nil
+}
@@ -0,0 +1,58 @@
+package nutanix_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/providers/nutanix"
+)
+
+func TestWorkersSpecNewCluster(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	spec := test.NewFullClusterSpec(t, testClusterConfigMainFilename)
+
+	workers, err := nutanix.WorkersSpec(ctx, logger, test.NewFakeKubeClient(), spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(workers.Groups).To(HaveLen(1))
+	g.Expect(workers.Groups[0].MachineDeployment.Name).To(Equal("eksa-unit-test-eksa-unit-test"))
+	g.Expect(workers.Groups[0].ProviderMachineTemplate.GetName()).To(Equal("eksa-unit-test-eksa-unit-test-1"))
+	g.Expect(workers.Groups[0].KubeadmConfigTemplate.Name).To(Equal("eksa-unit-test-eksa-unit-test-1"))
+}
+
+func TestWorkersSpecUpdateMachineTemplate(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	spec := test.NewFullClusterSpec(t, testClusterConfigMainFilename)
+
+	workers, err := nutanix.WorkersSpec(ctx, logger, test.NewFakeKubeClient(), spec)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	group := workers.Groups[0]
+	oldTemplate := group.ProviderMachineTemplate.DeepCopy()
+	g.Expect(unstructured.SetNestedField(oldTemplate.Object, "old-image", "spec", "template", "spec", "image", "name")).To(Succeed())
+	client := test.NewFakeKubeClient(group.MachineDeployment.DeepCopy(), group.KubeadmConfigTemplate.DeepCopy(), oldTemplate)
+
+	workers, err = nutanix.WorkersSpec(ctx, logger, client, spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(workers.Groups[0].ProviderMachineTemplate.GetName()).To(Equal("eksa-unit-test-eksa-unit-test-2"))
+	g.Expect(workers.Groups[0].MachineDeployment.Spec.Template.Spec.InfrastructureRef.Name).To(Equal("eksa-unit-test-eksa-unit-test-2"))
+	g.Expect(workers.Groups[0].KubeadmConfigTemplate.Name).To(Equal("eksa-unit-test-eksa-unit-test-1"))
+}
+
+func TestWorkersSpecMissingMachineConfig(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	spec := test.NewFullClusterSpec(t, testClusterConfigMainFilename)
+	spec.Cluster.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name = "missing"
+
+	_, err := nutanix.WorkersSpec(ctx, logger, test.NewFakeKubeClient(), spec)
+	g.Expect(err).To(MatchError(ContainSubstring("NutanixMachineConfig missing for worker node group eksa-unit-test is missing")))
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers/common"
 	snowv1 "github.com/aws/eks-anywhere/pkg/providers/snow/api/v1beta1"
 )
 
@@ -64,6 +65,22 @@ func KubeadmControlPlane(log logr.Logger, clusterSpec *cluster.Spec, snowMachine
 	)
 
 	osFamily := clusterSpec.SnowMachineConfig(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name).OSFamily()
+
+	// Audit logging is only enabled when the cluster spec sets an audit policy
+	if clusterSpec.Cluster.Spec.AuditPolicy != nil {
+		auditPolicy, err := common.AuditPolicy(clusterSpec)
+		if err != nil {
+			return nil, fmt.Errorf("generating audit policy: %v", err)
+		}
+		clusterapi.SetAuditPolicyInKubeadmControlPlane(kcp, auditPolicy, clusterSpec.Cluster.Spec.AuditPolicy, osFamily)
+	}
+
+	clusterapi.SetEncryptionConfigurationInKubeadmControlPlane(kcp, clusterSpec.Cluster.Name, clusterSpec.Cluster.Spec.EncryptionConfiguration, osFamily)
+
+	if err := clusterapi.SetPodSecurityInKubeadmControlPlane(kcp, clusterSpec.Cluster.Spec.PodSecurity, osFamily); err != nil {
+		return nil, fmt.Errorf("generating pod security admission configuration: %v", err)
+	}
+
 	switch osFamily {
 	case v1alpha1.Bottlerocket:
 		clusterapi.SetProxyConfigInKubeadmControlPlaneForBottlerocket(kcp, clusterSpec.Cluster)
@@ -2,8 +2,11 @@ package snow
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/validations"
 )
 
 type ConfigManager struct {
@@ -47,37 +50,38 @@ func (cm *ConfigManager) snowEntry(ctx context.Context) *cluster.ConfigManagerEn
 		},
 		Validations: []cluster.Validation{
 			func(c *cluster.Config) error {
-				for _, m := range c.SnowMachineConfigs {
-					if err := cm.validator.ValidateEC2ImageExistsOnDevice(ctx, m); err != nil {
-						return err
-					}
-				}
-				return nil
-			},
-			func(c *cluster.Config) error {
-				for _, m := range c.SnowMachineConfigs {
-					if err := cm.validator.ValidateEC2SshKeyNameExists(ctx, m); err != nil {
-						return err
-					}
-				}
-				return nil
-			},
-			func(c *cluster.Config) error {
-				for _, m := range c.SnowMachineConfigs {
-					if err := cm.validator.ValidateDeviceIsUnlocked(ctx, m); err != nil {
-						return err
-					}
-				}
-				return nil
-			},
-			func(c *cluster.Config) error {
-				for _, m := range c.SnowMachineConfigs {
-					if err := cm.validator.ValidateDeviceSoftware(ctx, m); err != nil {
-						return err
-					}
-				}
-				return nil
+				return cm.validateMachineConfigs(ctx, c)
 			},
 		},
 	}
 }
+
+// validateMachineConfigs checks the images, ssh keys and devices of the machine configs in c.
+// Each check calls every device of a machine config, so they all run in parallel with a validations.Runner,
+// but the error of the first failed check, in the order of machineConfigValidations, is returned.
+func (cm *ConfigManager) validateMachineConfigs(ctx context.Context, c *cluster.Config) error {
+	machineConfigValidations := []struct {
+		name     string
+		validate func(context.Context, *v1alpha1.SnowMachineConfig) error
+	}{
+		{name: "image exists", validate: cm.validator.ValidateEC2ImageExistsOnDevice},
+		{name: "ssh key exists", validate: cm.validator.ValidateEC2SshKeyNameExists},
+		{name: "devices unlocked", validate: cm.validator.ValidateDeviceIsUnlocked},
+		{name: "devices software version", validate: cm.validator.ValidateDeviceSoftware},
+	}
+
+	runner := validations.NewRunner()
+	for _, mv := range machineConfigValidations {
+		for _, m := range c.SnowMachineConfigs {
+			mv, m := mv, m
+			runner.Register(validations.Validation{
+				Name: fmt.Sprintf("%s for SnowMachineConfig %s", mv.name, m.Name),
+				Run: func(ctx context.Context) *validations.ValidationResult {
+					return &validations.ValidationResult{Err: mv.validate(ctx, m)}
+				},
+			})
+		}
+	}
+
+	return runner.FirstError(ctx)
+}
@@ -0,0 +1,149 @@
+package tinkerbell
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	yamlcapi "github.com/aws/eks-anywhere/pkg/clusterapi/yaml"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	"github.com/aws/eks-anywhere/pkg/yamlutil"
+)
+
+// ControlPlane represents a CAPI Tinkerbell control plane.
+// CAPT objects are handled as unstructured since their go types are not vendored in this module.
+type ControlPlane = clusterapi.ControlPlane[*unstructured.Unstructured, *unstructured.Unstructured]
+
+// ControlPlaneSpec builds a Tinkerbell ControlPlane definition based on an eks-a cluster spec.
+// The disk types for the machine templates are extracted from hw, the hardware available in the cluster.
+func ControlPlaneSpec(ctx context.Context, logger logr.Logger, client kubernetes.Client, spec *cluster.Spec, hw []tinkv1alpha1.Hardware) (*ControlPlane, error) {
+	templateBuilder, err := newControllerTemplateBuilder(spec, hw)
+	if err != nil {
+		return nil, err
+	}
+
+	controlPlaneYaml, err := templateBuilder.GenerateCAPISpecControlPlane(
+		spec,
+		func(values map[string]interface{}) {
+			values["controlPlaneTemplateName"] = clusterapi.ControlPlaneMachineTemplateName(spec.Cluster)
+			values["controlPlaneSshAuthorizedKey"] = sshAuthorizedKey(spec.TinkerbellMachineConfig(spec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name))
+			if spec.Cluster.Spec.ExternalEtcdConfiguration != nil {
+				values["etcdSshAuthorizedKey"] = sshAuthorizedKey(spec.TinkerbellMachineConfig(spec.Cluster.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name))
+			}
+			values["etcdTemplateName"] = clusterapi.EtcdMachineTemplateName(spec.Cluster)
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "generating tinkerbell control plane yaml spec")
+	}
+
+	parser, builder, err := yamlcapi.NewControlPlaneParserAndBuilder(
+		logger,
+		yamlutil.NewMapping(tinkerbellClusterKind, newUnstructured),
+		machineTemplateMapping(),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "building tinkerbell control plane parser")
+	}
+
+	if err = parser.Parse(controlPlaneYaml, builder); err != nil {
+		return nil, errors.Wrap(err, "parsing tinkerbell control plane yaml")
+	}
+
+	cp := builder.ControlPlane
+	if err = cp.UpdateImmutableObjectNames(ctx, client, getMachineTemplate, machineTemplateEqual); err != nil {
+		return nil, errors.Wrap(err, "updating tinkerbell immutable object names")
+	}
+
+	return cp, nil
+}
+
+// newControllerTemplateBuilder builds a TemplateBuilder from the objects in an eks-a cluster spec.
+// Since the Tinkerbell stack runs in the management cluster, the datacenter TinkerbellIP is used
+// for both the local and load balanced Tinkerbell IPs.
+func newControllerTemplateBuilder(spec *cluster.Spec, hw []tinkv1alpha1.Hardware) (*TemplateBuilder, error) {
+	controlPlaneMachineConfig := spec.TinkerbellMachineConfig(spec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name)
+	if controlPlaneMachineConfig == nil {
+		return nil, errors.New("control plane TinkerbellMachineConfig is missing from cluster spec")
+	}
+
+	var etcdMachineSpec *v1alpha1.TinkerbellMachineConfigSpec
+	if spec.Cluster.Spec.ExternalEtcdConfiguration != nil {
+		etcdMachineConfig := spec.TinkerbellMachineConfig(spec.Cluster.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name)
+		if etcdMachineConfig == nil {
+			return nil, errors.New("etcd TinkerbellMachineConfig is missing from cluster spec")
+		}
+		etcdMachineSpec = &etcdMachineConfig.Spec
+	}
+
+	workerMachineSpecs := make(map[string]v1alpha1.TinkerbellMachineConfigSpec, len(spec.Cluster.Spec.WorkerNodeGroupConfigurations))
+	for _, wnConfig := range spec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		machineConfig := spec.TinkerbellMachineConfig(wnConfig.MachineGroupRef.Name)
+		if machineConfig == nil {
+			return nil, errors.Errorf("TinkerbellMachineConfig %s for worker node group %s is missing from cluster spec", wnConfig.MachineGroupRef.Name, wnConfig.Name)
+		}
+		workerMachineSpecs[wnConfig.MachineGroupRef.Name] = machineConfig.Spec
+	}
+
+	diskExtractor, err := newDiskExtractor(spec, hw)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TemplateBuilder{
+		controlPlaneMachineSpec:     &controlPlaneMachineConfig.Spec,
+		datacenterSpec:              &spec.TinkerbellDatacenter.Spec,
+		WorkerNodeGroupMachineSpecs: workerMachineSpecs,
+		etcdMachineSpec:             etcdMachineSpec,
+		diskExtractor:               diskExtractor,
+		tinkerbellIp:                spec.TinkerbellDatacenter.Spec.TinkerbellIP,
+		now:                         time.Now,
+	}, nil
+}
+
+// newDiskExtractor registers the hardware selectors of all the machine configs in spec and caches
+// the disks of the matching hardware. Hardware already owned by a machine is cached separately so
+// it's only used when no unprovisioned hardware matches a selector.
+func newDiskExtractor(spec *cluster.Spec, hw []tinkv1alpha1.Hardware) (*hardware.DiskExtractor, error) {
+	diskExtractor := hardware.NewDiskExtractor()
+	for _, m := range spec.TinkerbellMachineConfigs {
+		if err := diskExtractor.Register(m.Spec.HardwareSelector); err != nil {
+			return nil, errors.Wrapf(err, "registering hardware selector for TinkerbellMachineConfig %s", m.Name)
+		}
+	}
+
+	for i := range hw {
+		if len(hw[i].Spec.Disks) == 0 {
+			continue
+		}
+
+		if isProvisioned(&hw[i]) {
+			if err := diskExtractor.InsertProvisionedHardwareDisks(&hw[i]); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if err := diskExtractor.InsertDisks(&hw[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return diskExtractor, nil
+}
+
+func sshAuthorizedKey(m *v1alpha1.TinkerbellMachineConfig) string {
+	if m == nil || len(m.Spec.Users) == 0 || len(m.Spec.Users[0].SshAuthorizedKeys) == 0 {
+		return ""
+	}
+
+	return m.Spec.Users[0].SshAuthorizedKeys[0]
+}
@@ -0,0 +1,141 @@
+package tinkerbell_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell"
+)
+
+const testClusterConfigStackedEtcdFilename = "testdata/cluster_tinkerbell_stacked_etcd.yaml"
+
+func TestControlPlaneSpecNewCluster(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	client := test.NewFakeKubeClient()
+	spec := test.NewFullClusterSpec(t, testClusterConfigStackedEtcdFilename)
+
+	cp, err := tinkerbell.ControlPlaneSpec(ctx, logger, client, spec, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cp).NotTo(BeNil())
+	g.Expect(cp.Cluster.Name).To(Equal("test"))
+	g.Expect(cp.KubeadmControlPlane.Name).To(Equal("test"))
+	g.Expect(cp.EtcdCluster).To(BeNil())
+	g.Expect(cp.ProviderCluster.GetKind()).To(Equal("TinkerbellCluster"))
+	g.Expect(cp.ControlPlaneMachineTemplate.GetName()).To(Equal("test-control-plane-1"))
+	g.Expect(cp.KubeadmControlPlane.Spec.MachineTemplate.InfrastructureRef.Name).To(Equal("test-control-plane-1"))
+}
+
+func TestControlPlaneSpecUpdateMachineTemplate(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	spec := test.NewFullClusterSpec(t, testClusterConfigStackedEtcdFilename)
+
+	cp, err := tinkerbell.ControlPlaneSpec(ctx, logger, test.NewFakeKubeClient(), spec, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	oldTemplate := cp.ControlPlaneMachineTemplate.DeepCopy()
+	g.Expect(unstructured.SetNestedField(oldTemplate.Object, "old-template", "spec", "template", "spec", "templateOverride")).To(Succeed())
+	client := test.NewFakeKubeClient(cp.KubeadmControlPlane.DeepCopy(), oldTemplate)
+
+	cp, err = tinkerbell.ControlPlaneSpec(ctx, logger, client, spec, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cp.ControlPlaneMachineTemplate.GetName()).To(Equal("test-control-plane-2"))
+	g.Expect(cp.KubeadmControlPlane.Spec.MachineTemplate.InfrastructureRef.Name).To(Equal("test-control-plane-2"))
+}
+
+func TestControlPlaneSpecNoChangesMachineTemplate(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	spec := test.NewFullClusterSpec(t, testClusterConfigStackedEtcdFilename)
+
+	cp, err := tinkerbell.ControlPlaneSpec(ctx, logger, test.NewFakeKubeClient(), spec, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	client := test.NewFakeKubeClient(cp.KubeadmControlPlane.DeepCopy(), cp.ControlPlaneMachineTemplate.DeepCopy())
+
+	cp, err = tinkerbell.ControlPlaneSpec(ctx, logger, client, spec, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cp.ControlPlaneMachineTemplate.GetName()).To(Equal("test-control-plane-1"))
+}
+
+func TestControlPlaneSpecDiskFromHardware(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	spec := test.NewFullClusterSpec(t, testClusterConfigStackedEtcdFilename)
+	spec.TinkerbellTemplateConfigs = nil
+	hw := []tinkv1alpha1.Hardware{
+		hardwareWithDisk("hw-no-disk", map[string]string{"type": "cp"}, ""),
+		hardwareWithDisk("hw-cp", map[string]string{"type": "cp"}, "/dev/nvme0n1"),
+	}
+
+	cp, err := tinkerbell.ControlPlaneSpec(ctx, logger, test.NewFakeKubeClient(), spec, hw)
+	g.Expect(err).NotTo(HaveOccurred())
+	override, _, err := unstructured.NestedString(cp.ControlPlaneMachineTemplate.Object, "spec", "template", "spec", "templateOverride")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(override).To(ContainSubstring("/dev/nvme0n1"))
+}
+
+func TestControlPlaneSpecDiskFromProvisionedHardware(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	spec := test.NewFullClusterSpec(t, testClusterConfigStackedEtcdFilename)
+	spec.TinkerbellTemplateConfigs = nil
+	hw := []tinkv1alpha1.Hardware{
+		hardwareWithDisk("hw-cp", map[string]string{"type": "cp", tinkerbell.HardwareOwnerNameLabel: "test-control-plane-1-abcde"}, "/dev/sdb"),
+	}
+
+	cp, err := tinkerbell.ControlPlaneSpec(ctx, logger, test.NewFakeKubeClient(), spec, hw)
+	g.Expect(err).NotTo(HaveOccurred())
+	override, _, err := unstructured.NestedString(cp.ControlPlaneMachineTemplate.Object, "spec", "template", "spec", "templateOverride")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(override).To(ContainSubstring("/dev/sdb"))
+}
+
+func TestControlPlaneSpecNoHardwareForDisk(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	spec := test.NewFullClusterSpec(t, testClusterConfigStackedEtcdFilename)
+	spec.TinkerbellTemplateConfigs = nil
+
+	_, err := tinkerbell.ControlPlaneSpec(ctx, logger, test.NewFakeKubeClient(), spec, nil)
+	g.Expect(err).To(MatchError(ContainSubstring("generating tinkerbell control plane yaml spec")))
+}
+
+func TestControlPlaneSpecMissingMachineConfig(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	spec := test.NewFullClusterSpec(t, testClusterConfigStackedEtcdFilename)
+	delete(spec.TinkerbellMachineConfigs, "test-cp")
+
+	_, err := tinkerbell.ControlPlaneSpec(ctx, logger, test.NewFakeKubeClient(), spec, nil)
+	g.Expect(err).To(MatchError(ContainSubstring("control plane TinkerbellMachineConfig is missing")))
+}
+
+func hardwareWithDisk(name string, labels map[string]string, disk string) tinkv1alpha1.Hardware {
+	hw := tinkv1alpha1.Hardware{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    labels,
+		},
+	}
+	if disk != "" {
+		hw.Spec.Disks = []tinkv1alpha1.Disk{{Device: disk}}
+	}
+
+	return hw
+}
@@ -0,0 +1,49 @@
+package tinkerbell
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/yamlutil"
+)
+
+const (
+	tinkerbellClusterKind = "TinkerbellCluster"
+	captAPIVersion        = "infrastructure.cluster.x-k8s.io/v1beta1"
+
+	// HardwareOwnerNameLabel is set by CAPT in the Hardware assigned to a machine.
+	HardwareOwnerNameLabel = "v1alpha1.tinkerbell.org/ownerName"
+)
+
+func getMachineTemplate(ctx context.Context, client kubernetes.Client, name, namespace string) (*unstructured.Unstructured, error) {
+	m := newUnstructured()
+	m.SetAPIVersion(captAPIVersion)
+	m.SetKind(TinkerbellMachineTemplateKind)
+	if err := client.Get(ctx, name, namespace, m); err != nil {
+		return nil, errors.Wrap(err, "reading tinkerbellMachineTemplate")
+	}
+
+	return m, nil
+}
+
+func machineTemplateEqual(new, old *unstructured.Unstructured) bool {
+	return equality.Semantic.DeepDerivative(new.Object["spec"], old.Object["spec"])
+}
+
+func machineTemplateMapping() yamlutil.Mapping[*unstructured.Unstructured] {
+	return yamlutil.NewMapping(TinkerbellMachineTemplateKind, newUnstructured)
+}
+
+func newUnstructured() *unstructured.Unstructured {
+	return &unstructured.Unstructured{}
+}
+
+func isProvisioned(hw *tinkv1alpha1.Hardware) bool {
+	_, ok := hw.Labels[HardwareOwnerNameLabel]
+	return ok
+}
@@ -0,0 +1,309 @@
+package reconciler
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	c "github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+	"github.com/aws/eks-anywhere/pkg/controller/serverside"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+// CNIReconciler is an interface for reconciling CNI in the Tinkerbell cluster reconciler.
+type CNIReconciler interface {
+	Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *c.Spec) (controller.Result, error)
+}
+
+// RemoteClientRegistry is an interface that defines methods for remote clients.
+type RemoteClientRegistry interface {
+	GetClient(ctx context.Context, cluster client.ObjectKey) (client.Client, error)
+}
+
+// IPValidator is an interface that defines methods to validate the control plane IP.
+type IPValidator interface {
+	ValidateControlPlaneIP(ctx context.Context, log logr.Logger, spec *c.Spec) (controller.Result, error)
+}
+
+// Reconciler reconciles the Tinkerbell specific objects of an eks-a cluster.
+type Reconciler struct {
+	client               client.Client
+	cniReconciler        CNIReconciler
+	remoteClientRegistry RemoteClientRegistry
+	ipValidator          IPValidator
+	*serverside.ObjectApplier
+}
+
+// New defines a new Tinkerbell reconciler.
+func New(client client.Client, cniReconciler CNIReconciler, remoteClientRegistry RemoteClientRegistry, ipValidator IPValidator) *Reconciler {
+	return &Reconciler{
+		client:               client,
+		cniReconciler:        cniReconciler,
+		remoteClientRegistry: remoteClientRegistry,
+		ipValidator:          ipValidator,
+		ObjectApplier:        serverside.NewObjectApplier(client),
+	}
+}
+
+// Reconcile reconciles the cluster to the desired state.
+func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
+	log = log.WithValues("provider", "tinkerbell")
+	clusterSpec, err := c.BuildSpec(ctx, clientutil.NewKubeClient(r.client), cluster)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return controller.NewPhaseRunner().Register(
+		r.ipValidator.ValidateControlPlaneIP,
+		r.ValidateClusterSpec,
+		r.ValidateHardware,
+		clusters.CleanupStatusAfterValidate,
+		r.ReconcileControlPlane,
+		r.CheckControlPlaneReady,
+		r.ReconcileCNI,
+		r.ReconcileWorkers,
+	).Run(ctx, log, clusterSpec)
+}
+
+// ReconcileWorkerNodes validates the cluster definition and reconciles the worker nodes
+// to the desired state.
+func (r *Reconciler) ReconcileWorkerNodes(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
+	log = log.WithValues("provider", "tinkerbell", "reconcile type", "workers")
+	clusterSpec, err := c.BuildSpec(ctx, clientutil.NewKubeClient(r.client), cluster)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return controller.NewPhaseRunner().Register(
+		r.ValidateClusterSpec,
+		r.ValidateHardware,
+		r.ReconcileWorkers,
+	).Run(ctx, log, clusterSpec)
+}
+
+// ValidateClusterSpec performs the static validations on the Tinkerbell datacenter and machine configs.
+func (r *Reconciler) ValidateClusterSpec(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "validateClusterSpec")
+
+	tinkerbellClusterSpec := tinkerbell.NewClusterSpec(clusterSpec, clusterSpec.TinkerbellMachineConfigs, clusterSpec.TinkerbellDatacenter)
+	if err := tinkerbell.NewClusterSpecValidator().Validate(tinkerbellClusterSpec); err != nil {
+		log.Error(err, "Invalid Tinkerbell cluster spec")
+		failureMessage := err.Error()
+		clusterSpec.Cluster.Status.FailureMessage = &failureMessage
+		return controller.ResultWithReturn(), nil
+	}
+
+	return controller.Result{}, nil
+}
+
+// ValidateHardware checks that there is enough available hardware in the cluster to satisfy the
+// cluster spec. For existing clusters, only the extra hardware needed by a scale up or a rolling
+// upgrade is checked.
+func (r *Reconciler) ValidateHardware(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "validateHardware")
+
+	hw, err := r.hardware(ctx)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	catalogue := hardware.NewCatalogue()
+	for i := range hw {
+		if _, provisioned := hw[i].Labels[tinkerbell.HardwareOwnerNameLabel]; provisioned {
+			continue
+		}
+		if err := catalogue.InsertHardware(&hw[i]); err != nil {
+			return controller.Result{}, err
+		}
+	}
+
+	validator := tinkerbell.NewClusterSpecValidator(
+		tinkerbell.HardwareSatisfiesOnlyOneSelectorAssertion(catalogue),
+	)
+
+	kcp, err := r.kubeadmControlPlane(ctx, clusterSpec)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	if kcp == nil {
+		validator.Register(tinkerbell.MinimumHardwareAvailableAssertionForCreate(catalogue))
+	} else {
+		currentSpec, err := r.currentSpec(ctx, clusterSpec, kcp)
+		if err != nil {
+			return controller.Result{}, err
+		}
+
+		rollingUpgrade := kcp.Spec.Version != clusterSpec.VersionsBundle.KubeDistro.Kubernetes.Tag
+		if rollingUpgrade {
+			validator.Register(tinkerbell.ExtraHardwareAvailableAssertionForRollingUpgrade(catalogue))
+		}
+
+		if rollingUpgrade || isScaling(currentSpec, clusterSpec) {
+			validator.Register(tinkerbell.AssertionsForScaleUpDown(catalogue, currentSpec, rollingUpgrade))
+		}
+	}
+
+	tinkerbellClusterSpec := tinkerbell.NewClusterSpec(clusterSpec, clusterSpec.TinkerbellMachineConfigs, clusterSpec.TinkerbellDatacenter)
+	if err := validator.Validate(tinkerbellClusterSpec); err != nil {
+		log.Error(err, "Invalid Tinkerbell hardware")
+		failureMessage := err.Error()
+		clusterSpec.Cluster.Status.FailureMessage = &failureMessage
+		return controller.ResultWithReturn(), nil
+	}
+
+	return controller.Result{}, nil
+}
+
+// ReconcileControlPlane applies the control plane CAPI objects to the cluster.
+func (r *Reconciler) ReconcileControlPlane(ctx context.Context, log logr.Logger, spec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "reconcileControlPlane")
+	hw, err := r.hardware(ctx)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	log.Info("Applying control plane CAPI objects")
+	cp, err := tinkerbell.ControlPlaneSpec(ctx, log, clientutil.NewKubeClient(r.client), spec, hw)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return clusters.ReconcileControlPlane(ctx, r.client, toClientControlPlane(cp))
+}
+
+// CheckControlPlaneReady checks whether the control plane for an eks-a cluster is ready or not.
+// Requeues with the appropriate wait times whenever the cluster is not ready yet.
+func (r *Reconciler) CheckControlPlaneReady(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "checkControlPlaneReady")
+	return clusters.CheckControlPlaneReady(ctx, r.client, log, clusterSpec.Cluster)
+}
+
+// ReconcileCNI takes the Cilium CNI in a cluster to the desired state defined in a cluster spec.
+func (r *Reconciler) ReconcileCNI(ctx context.Context, log logr.Logger, clusterSpec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "reconcileCNI")
+	client, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(clusterSpec.Cluster))
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return r.cniReconciler.Reconcile(ctx, log, client, clusterSpec)
+}
+
+// ReconcileWorkers applies the worker CAPI objects to the cluster.
+func (r *Reconciler) ReconcileWorkers(ctx context.Context, log logr.Logger, spec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "reconcileWorkers")
+	hw, err := r.hardware(ctx)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	log.Info("Applying worker CAPI objects")
+	w, err := tinkerbell.WorkersSpec(ctx, log, clientutil.NewKubeClient(r.client), spec, hw)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return clusters.ReconcileWorkersForEKSA(ctx, log, r.client, spec.Cluster, clusters.ToWorkers(w))
+}
+
+// hardware returns all the Tinkerbell Hardware registered in the management cluster.
+func (r *Reconciler) hardware(ctx context.Context) ([]tinkv1alpha1.Hardware, error) {
+	hardwareList := &tinkv1alpha1.HardwareList{}
+	if err := r.client.List(ctx, hardwareList, client.InNamespace(constants.EksaSystemNamespace)); err != nil {
+		return nil, errors.Wrap(err, "listing tinkerbell hardware")
+	}
+
+	return hardwareList.Items, nil
+}
+
+// kubeadmControlPlane returns the KubeadmControlPlane for the cluster or nil if it doesn't exist yet.
+func (r *Reconciler) kubeadmControlPlane(ctx context.Context, spec *c.Spec) (*controlplanev1.KubeadmControlPlane, error) {
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	key := client.ObjectKey{Name: clusterapi.KubeadmControlPlaneName(spec), Namespace: constants.EksaSystemNamespace}
+	err := r.client.Get(ctx, key, kcp)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "reading kubeadm control plane")
+	}
+
+	return kcp, nil
+}
+
+// currentSpec builds a copy of spec with the node counts currently applied to the CAPI objects.
+// Worker node groups without a MachineDeployment are removed so they are treated as new.
+func (r *Reconciler) currentSpec(ctx context.Context, spec *c.Spec, kcp *controlplanev1.KubeadmControlPlane) (*c.Spec, error) {
+	currentSpec := spec.DeepCopy()
+	if kcp.Spec.Replicas != nil {
+		currentSpec.Cluster.Spec.ControlPlaneConfiguration.Count = int(*kcp.Spec.Replicas)
+	}
+
+	workerNodeGroups := make([]anywherev1.WorkerNodeGroupConfiguration, 0, len(spec.Cluster.Spec.WorkerNodeGroupConfigurations))
+	for _, wnConfig := range spec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		md := &clusterv1.MachineDeployment{}
+		key := client.ObjectKey{Name: clusterapi.MachineDeploymentName(spec, wnConfig), Namespace: constants.EksaSystemNamespace}
+		err := r.client.Get(ctx, key, md)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "reading machine deployment")
+		}
+
+		current := *wnConfig.DeepCopy()
+		if md.Spec.Replicas != nil {
+			count := int(*md.Spec.Replicas)
+			current.Count = &count
+		}
+		workerNodeGroups = append(workerNodeGroups, current)
+	}
+	currentSpec.Cluster.Spec.WorkerNodeGroupConfigurations = workerNodeGroups
+
+	return currentSpec, nil
+}
+
+func isScaling(currentSpec, spec *c.Spec) bool {
+	if currentSpec.Cluster.Spec.ControlPlaneConfiguration.Count != spec.Cluster.Spec.ControlPlaneConfiguration.Count {
+		return true
+	}
+
+	if len(currentSpec.Cluster.Spec.WorkerNodeGroupConfigurations) != len(spec.Cluster.Spec.WorkerNodeGroupConfigurations) {
+		return true
+	}
+
+	for i, wnConfig := range spec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		current := currentSpec.Cluster.Spec.WorkerNodeGroupConfigurations[i]
+		if current.Name != wnConfig.Name || !reflect.DeepEqual(current.Count, wnConfig.Count) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func toClientControlPlane(cp *tinkerbell.ControlPlane) *clusters.ControlPlane {
+	return &clusters.ControlPlane{
+		Cluster:                     cp.Cluster,
+		ProviderCluster:             cp.ProviderCluster,
+		KubeadmControlPlane:         cp.KubeadmControlPlane,
+		ControlPlaneMachineTemplate: cp.ControlPlaneMachineTemplate,
+		EtcdCluster:                 cp.EtcdCluster,
+		EtcdMachineTemplate:         cp.EtcdMachineTemplate,
+	}
+}
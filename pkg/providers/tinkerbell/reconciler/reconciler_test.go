@@ -0,0 +1,641 @@
+package reconciler_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/internal/test/envtest"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	clusterspec "github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/reconciler"
+	tinkerbellreconcilermocks "github.com/aws/eks-anywhere/pkg/providers/tinkerbell/reconciler/mocks"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const (
+	clusterNamespace = "test-namespace"
+	// kubernetesVersion matches the kube-apiserver image tag in test.EksdRelease().
+	kubernetesVersion = "v1.19.8"
+)
+
+func TestReconcilerReconcileSuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	// We want to check that the cluster status is cleaned up if validations are passed
+	tt.cluster.Status.FailureMessage = ptr.String("invalid cluster")
+	capiCluster := test.CAPICluster(func(c *clusterv1.Cluster) {
+		c.Name = tt.cluster.Name
+	})
+	tt.eksaSupportObjs = append(tt.eksaSupportObjs, capiCluster)
+	tt.createAllObjs()
+
+	logger := test.NewNullLogger()
+	remoteClient := fake.NewClientBuilder().Build()
+
+	tt.ipValidator.EXPECT().ValidateControlPlaneIP(tt.ctx, logger, tt.buildSpec()).Return(controller.Result{}, nil)
+	tt.remoteClientRegistry.EXPECT().GetClient(
+		tt.ctx, client.ObjectKey{Name: "workload-cluster", Namespace: "eksa-system"},
+	).Return(remoteClient, nil)
+	tt.cniReconciler.EXPECT().Reconcile(tt.ctx, logger, remoteClient, tt.buildSpec())
+
+	result, err := tt.reconciler().Reconcile(tt.ctx, logger, tt.cluster)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(tt.cluster.Status.FailureMessage).To(BeZero())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerReconcileWorkerNodesSuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.cluster.Name = "my-management-cluster"
+	tt.cluster.SetSelfManaged()
+	capiCluster := test.CAPICluster(func(c *clusterv1.Cluster) {
+		c.Name = tt.cluster.Name
+	})
+	tt.eksaSupportObjs = append(tt.eksaSupportObjs, capiCluster)
+	tt.createAllObjs()
+
+	result, err := tt.reconciler().ReconcileWorkerNodes(tt.ctx, test.NewNullLogger(), tt.cluster)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(tt.cluster.Status.FailureMessage).To(BeZero())
+	tt.Expect(result).To(Equal(controller.Result{}))
+
+	tt.ShouldEventuallyExist(tt.ctx,
+		&bootstrapv1.KubeadmConfigTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-management-cluster-md-0-1",
+				Namespace: constants.EksaSystemNamespace,
+			},
+		},
+	)
+
+	tt.ShouldEventuallyExist(tt.ctx, tinkerbellMachineTemplate("my-management-cluster-md-0-1"))
+
+	tt.ShouldEventuallyExist(tt.ctx,
+		&clusterv1.MachineDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-management-cluster-md-0",
+				Namespace: constants.EksaSystemNamespace,
+			},
+		},
+	)
+}
+
+func TestReconcilerControlPlaneIsNotReady(t *testing.T) {
+	tt := newReconcilerTest(t)
+	capiCluster := test.CAPICluster(func(c *clusterv1.Cluster) {
+		c.Name = tt.cluster.Name
+	})
+	capiCluster.Status.Conditions = clusterv1.Conditions{
+		{
+			Type:               clusterapi.ControlPlaneReadyCondition,
+			Status:             corev1.ConditionFalse,
+			LastTransitionTime: metav1.NewTime(time.Now()),
+		},
+	}
+	tt.eksaSupportObjs = append(tt.eksaSupportObjs, capiCluster)
+	tt.createAllObjs()
+
+	logger := test.NewNullLogger()
+
+	tt.ipValidator.EXPECT().ValidateControlPlaneIP(tt.ctx, logger, tt.buildSpec()).Return(controller.Result{}, nil)
+
+	result, err := tt.reconciler().Reconcile(tt.ctx, logger, tt.cluster)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(tt.cluster.Status.FailureMessage).To(BeZero())
+	tt.Expect(result).To(Equal(controller.ResultWithRequeue(30 * time.Second)))
+}
+
+func TestReconcilerReconcileControlPlaneSuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.createAllObjs()
+
+	result, err := tt.reconciler().ReconcileControlPlane(tt.ctx, test.NewNullLogger(), tt.buildSpec())
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(tt.cluster.Status.FailureMessage).To(BeZero())
+	tt.Expect(result).To(Equal(controller.Result{}))
+
+	tt.ShouldEventuallyExist(tt.ctx,
+		&controlplanev1.KubeadmControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "workload-cluster",
+				Namespace: "eksa-system",
+			},
+		},
+	)
+
+	tt.ShouldEventuallyExist(tt.ctx, tinkerbellMachineTemplate("workload-cluster-control-plane-1"))
+
+	capiCluster := test.CAPICluster(func(c *clusterv1.Cluster) {
+		c.Name = "workload-cluster"
+	})
+	tt.ShouldEventuallyExist(tt.ctx, capiCluster)
+}
+
+func TestReconcilerReconcileControlPlaneFailure(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.createAllObjs()
+	spec := tt.buildSpec()
+	spec.Cluster.Spec.KubernetesVersion = ""
+
+	_, err := tt.reconciler().ReconcileControlPlane(tt.ctx, test.NewNullLogger(), spec)
+
+	tt.Expect(err).To(HaveOccurred())
+}
+
+func TestReconcilerValidateClusterSpecSuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+
+	result, err := tt.reconciler().ValidateClusterSpec(tt.ctx, test.NewNullLogger(), tt.buildSpec())
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	tt.Expect(tt.cluster.Status.FailureMessage).To(BeNil())
+}
+
+func TestReconcilerValidateClusterSpecInvalidDatacenterConfig(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.datacenterConfig.Spec.TinkerbellIP = ""
+	tt.withFakeClient()
+
+	result, err := tt.reconciler().ValidateClusterSpec(tt.ctx, test.NewNullLogger(), tt.buildSpec())
+
+	tt.Expect(err).To(BeNil(), "error should be nil to prevent requeue")
+	tt.Expect(result).To(Equal(controller.Result{Result: &reconcile.Result{}}), "result should stop reconciliation")
+	tt.Expect(tt.cluster.Status.FailureMessage).To(HaveValue(ContainSubstring("missing spec.tinkerbellIP field")))
+}
+
+func TestReconcilerValidateClusterSpecInvalidMachineConfig(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.machineConfigWorker.Spec.HardwareSelector = nil
+	tt.withFakeClient()
+
+	result, err := tt.reconciler().ValidateClusterSpec(tt.ctx, test.NewNullLogger(), tt.buildSpec())
+
+	tt.Expect(err).To(BeNil(), "error should be nil to prevent requeue")
+	tt.Expect(result).To(Equal(controller.Result{Result: &reconcile.Result{}}), "result should stop reconciliation")
+	tt.Expect(tt.cluster.Status.FailureMessage).To(HaveValue(ContainSubstring("missing spec.hardwareSelector")))
+}
+
+func TestReconcilerValidateHardwareNewClusterSuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+
+	result, err := tt.reconciler().ValidateHardware(tt.ctx, test.NewNullLogger(), tt.buildSpec())
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	tt.Expect(tt.cluster.Status.FailureMessage).To(BeNil())
+}
+
+func TestReconcilerValidateHardwareNewClusterNotEnoughHardware(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.hardware = tt.hardware[:1]
+	tt.withFakeClient()
+
+	result, err := tt.reconciler().ValidateHardware(tt.ctx, test.NewNullLogger(), tt.buildSpec())
+
+	tt.Expect(err).To(BeNil(), "error should be nil to prevent requeue")
+	tt.Expect(result).To(Equal(controller.Result{Result: &reconcile.Result{}}), "result should stop reconciliation")
+	tt.Expect(tt.cluster.Status.FailureMessage).To(HaveValue(ContainSubstring("minimum hardware count not met")))
+}
+
+func TestReconcilerValidateHardwareIgnoresProvisionedHardware(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.hardware[1].Labels[tinkerbell.HardwareOwnerNameLabel] = "other-cluster-md-0-abcde"
+	tt.withFakeClient()
+
+	result, err := tt.reconciler().ValidateHardware(tt.ctx, test.NewNullLogger(), tt.buildSpec())
+
+	tt.Expect(err).To(BeNil(), "error should be nil to prevent requeue")
+	tt.Expect(result).To(Equal(controller.Result{Result: &reconcile.Result{}}), "result should stop reconciliation")
+	tt.Expect(tt.cluster.Status.FailureMessage).To(HaveValue(ContainSubstring("minimum hardware count not met")))
+}
+
+func TestReconcilerValidateHardwareExistingClusterNoChanges(t *testing.T) {
+	tt := newReconcilerTest(t)
+	// All hardware is already in use by the cluster
+	for _, hw := range tt.hardware {
+		hw.Labels[tinkerbell.HardwareOwnerNameLabel] = "workload-cluster"
+	}
+	tt.eksaSupportObjs = append(tt.eksaSupportObjs, tt.kubeadmControlPlane(1), tt.machineDeployment(1))
+	tt.withFakeClient()
+
+	result, err := tt.reconciler().ValidateHardware(tt.ctx, test.NewNullLogger(), tt.buildSpec())
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	tt.Expect(tt.cluster.Status.FailureMessage).To(BeNil())
+}
+
+func TestReconcilerValidateHardwareExistingClusterScaleUp(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.Int(2)
+	tt.hardware[0].Labels[tinkerbell.HardwareOwnerNameLabel] = "workload-cluster"
+	tt.eksaSupportObjs = append(tt.eksaSupportObjs, tt.kubeadmControlPlane(1), tt.machineDeployment(1))
+	tt.withFakeClient()
+
+	result, err := tt.reconciler().ValidateHardware(tt.ctx, test.NewNullLogger(), tt.buildSpec())
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	tt.Expect(tt.cluster.Status.FailureMessage).To(BeNil())
+}
+
+func TestReconcilerValidateHardwareExistingClusterScaleUpNotEnoughHardware(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.Int(2)
+	for _, hw := range tt.hardware {
+		hw.Labels[tinkerbell.HardwareOwnerNameLabel] = "workload-cluster"
+	}
+	tt.eksaSupportObjs = append(tt.eksaSupportObjs, tt.kubeadmControlPlane(1), tt.machineDeployment(1))
+	tt.withFakeClient()
+
+	result, err := tt.reconciler().ValidateHardware(tt.ctx, test.NewNullLogger(), tt.buildSpec())
+
+	tt.Expect(err).To(BeNil(), "error should be nil to prevent requeue")
+	tt.Expect(result).To(Equal(controller.Result{Result: &reconcile.Result{}}), "result should stop reconciliation")
+	tt.Expect(tt.cluster.Status.FailureMessage).To(HaveValue(ContainSubstring("for scale up")))
+}
+
+func TestReconcilerValidateHardwareExistingClusterRollingUpgradeNotEnoughHardware(t *testing.T) {
+	tt := newReconcilerTest(t)
+	for _, hw := range tt.hardware {
+		hw.Labels[tinkerbell.HardwareOwnerNameLabel] = "workload-cluster"
+	}
+	kcp := tt.kubeadmControlPlane(1)
+	kcp.Spec.Version = "v1.18.0"
+	tt.eksaSupportObjs = append(tt.eksaSupportObjs, kcp, tt.machineDeployment(1))
+	tt.withFakeClient()
+
+	result, err := tt.reconciler().ValidateHardware(tt.ctx, test.NewNullLogger(), tt.buildSpec())
+
+	tt.Expect(err).To(BeNil(), "error should be nil to prevent requeue")
+	tt.Expect(result).To(Equal(controller.Result{Result: &reconcile.Result{}}), "result should stop reconciliation")
+	tt.Expect(tt.cluster.Status.FailureMessage).To(HaveValue(ContainSubstring("for rolling upgrade")))
+}
+
+func TestReconcileCNISuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+
+	logger := test.NewNullLogger()
+	remoteClient := fake.NewClientBuilder().Build()
+	spec := tt.buildSpec()
+
+	tt.remoteClientRegistry.EXPECT().GetClient(
+		tt.ctx, client.ObjectKey{Name: "workload-cluster", Namespace: "eksa-system"},
+	).Return(remoteClient, nil)
+	tt.cniReconciler.EXPECT().Reconcile(tt.ctx, logger, remoteClient, spec)
+
+	result, err := tt.reconciler().ReconcileCNI(tt.ctx, logger, spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(tt.cluster.Status.FailureMessage).To(BeZero())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcileCNIErrorClientRegistry(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+
+	logger := test.NewNullLogger()
+	spec := tt.buildSpec()
+
+	tt.remoteClientRegistry.EXPECT().GetClient(
+		tt.ctx, client.ObjectKey{Name: "workload-cluster", Namespace: "eksa-system"},
+	).Return(nil, errors.New("building client"))
+
+	result, err := tt.reconciler().ReconcileCNI(tt.ctx, logger, spec)
+
+	tt.Expect(err).To(MatchError(ContainSubstring("building client")))
+	tt.Expect(tt.cluster.Status.FailureMessage).To(BeZero())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+type reconcilerTest struct {
+	t testing.TB
+	*WithT
+	*envtest.APIExpecter
+	ctx                       context.Context
+	cniReconciler             *tinkerbellreconcilermocks.MockCNIReconciler
+	remoteClientRegistry      *tinkerbellreconcilermocks.MockRemoteClientRegistry
+	ipValidator               *tinkerbellreconcilermocks.MockIPValidator
+	cluster                   *anywherev1.Cluster
+	client                    client.Client
+	env                       *envtest.Environment
+	bundle                    *releasev1.Bundles
+	eksaSupportObjs           []client.Object
+	datacenterConfig          *anywherev1.TinkerbellDatacenterConfig
+	machineConfigControlPlane *anywherev1.TinkerbellMachineConfig
+	machineConfigWorker       *anywherev1.TinkerbellMachineConfig
+	hardware                  []*tinkv1alpha1.Hardware
+}
+
+func newReconcilerTest(t testing.TB) *reconcilerTest {
+	ctrl := gomock.NewController(t)
+	cniReconciler := tinkerbellreconcilermocks.NewMockCNIReconciler(ctrl)
+	remoteClientRegistry := tinkerbellreconcilermocks.NewMockRemoteClientRegistry(ctrl)
+	ipValidator := tinkerbellreconcilermocks.NewMockIPValidator(ctrl)
+	c := env.Client()
+
+	bundle := test.Bundle()
+	// Tinkerbell doesn't support kubernetes 1.20
+	bundle.Spec.VersionsBundles[0].KubeVersion = "1.21"
+	bundle.Spec.VersionsBundles[0].EksD.KubeVersion = "1.21"
+
+	managementCluster := tinkerbellCluster(func(c *anywherev1.Cluster) {
+		c.Name = "management-cluster"
+		c.Spec.ManagementCluster = anywherev1.ManagementCluster{
+			Name: c.Name,
+		}
+		c.Spec.BundlesRef = &anywherev1.BundlesRef{
+			Name:       bundle.Name,
+			Namespace:  bundle.Namespace,
+			APIVersion: bundle.APIVersion,
+		}
+	})
+
+	machineConfigCP := machineConfig(func(m *anywherev1.TinkerbellMachineConfig) {
+		m.Name = "cp-machine-config"
+		m.Spec.HardwareSelector = anywherev1.HardwareSelector{"type": "cp"}
+	})
+	machineConfigWN := machineConfig(func(m *anywherev1.TinkerbellMachineConfig) {
+		m.Name = "worker-machine-config"
+		m.Spec.HardwareSelector = anywherev1.HardwareSelector{"type": "worker"}
+	})
+
+	workloadClusterDatacenter := dataCenter()
+
+	cluster := tinkerbellCluster(func(c *anywherev1.Cluster) {
+		c.Name = "workload-cluster"
+		c.Spec.ManagementCluster = anywherev1.ManagementCluster{
+			Name: managementCluster.Name,
+		}
+		c.Spec.BundlesRef = &anywherev1.BundlesRef{
+			Name:       bundle.Name,
+			Namespace:  bundle.Namespace,
+			APIVersion: bundle.APIVersion,
+		}
+		c.Spec.ControlPlaneConfiguration = anywherev1.ControlPlaneConfiguration{
+			Count: 1,
+			Endpoint: &anywherev1.Endpoint{
+				Host: "1.1.1.1",
+			},
+			MachineGroupRef: &anywherev1.Ref{
+				Kind: anywherev1.TinkerbellMachineConfigKind,
+				Name: machineConfigCP.Name,
+			},
+		}
+		c.Spec.DatacenterRef = anywherev1.Ref{
+			Kind: anywherev1.TinkerbellDatacenterKind,
+			Name: workloadClusterDatacenter.Name,
+		}
+
+		c.Spec.WorkerNodeGroupConfigurations = append(c.Spec.WorkerNodeGroupConfigurations,
+			anywherev1.WorkerNodeGroupConfiguration{
+				Count: ptr.Int(1),
+				MachineGroupRef: &anywherev1.Ref{
+					Kind: anywherev1.TinkerbellMachineConfigKind,
+					Name: machineConfigWN.Name,
+				},
+				Name:   "md-0",
+				Labels: nil,
+			},
+		)
+	})
+
+	tt := &reconcilerTest{
+		t:                    t,
+		WithT:                NewWithT(t),
+		APIExpecter:          envtest.NewAPIExpecter(t, c),
+		ctx:                  context.Background(),
+		cniReconciler:        cniReconciler,
+		remoteClientRegistry: remoteClientRegistry,
+		ipValidator:          ipValidator,
+		client:               c,
+		env:                  env,
+		eksaSupportObjs: []client.Object{
+			test.Namespace(clusterNamespace),
+			test.Namespace(constants.EksaSystemNamespace),
+			managementCluster,
+			workloadClusterDatacenter,
+			bundle,
+			test.EksdRelease(),
+		},
+		bundle:                    bundle,
+		cluster:                   cluster,
+		datacenterConfig:          workloadClusterDatacenter,
+		machineConfigControlPlane: machineConfigCP,
+		machineConfigWorker:       machineConfigWN,
+		hardware: []*tinkv1alpha1.Hardware{
+			hardware("hw-cp", map[string]string{"type": "cp"}),
+			hardware("hw-worker", map[string]string{"type": "worker"}),
+		},
+	}
+
+	t.Cleanup(tt.cleanup)
+	return tt
+}
+
+func (tt *reconcilerTest) cleanup() {
+	tt.DeleteAndWait(tt.ctx, tt.allObjs()...)
+
+	tt.DeleteAllOfAndWait(tt.ctx, &bootstrapv1.KubeadmConfigTemplate{})
+	tt.DeleteAllOfAndWait(tt.ctx, tinkerbellMachineTemplate(""))
+	tt.DeleteAllOfAndWait(tt.ctx, &clusterv1.MachineDeployment{})
+}
+
+func (tt *reconcilerTest) buildSpec() *clusterspec.Spec {
+	tt.t.Helper()
+	spec, err := clusterspec.BuildSpec(tt.ctx, clientutil.NewKubeClient(tt.client), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	return spec
+}
+
+func (tt *reconcilerTest) withFakeClient() {
+	tt.client = fake.NewClientBuilder().WithObjects(clientutil.ObjectsToClientObjects(tt.allObjs())...).Build()
+}
+
+func (tt *reconcilerTest) reconciler() *reconciler.Reconciler {
+	return reconciler.New(tt.client, tt.cniReconciler, tt.remoteClientRegistry, tt.ipValidator)
+}
+
+func (tt *reconcilerTest) createAllObjs() {
+	tt.t.Helper()
+	envtest.CreateObjs(tt.ctx, tt.t, tt.client, tt.allObjs()...)
+}
+
+func (tt *reconcilerTest) allObjs() []client.Object {
+	objs := make([]client.Object, 0, len(tt.eksaSupportObjs)+len(tt.hardware)+3)
+	objs = append(objs, tt.eksaSupportObjs...)
+	objs = append(objs, tt.cluster, tt.machineConfigControlPlane, tt.machineConfigWorker)
+	for _, hw := range tt.hardware {
+		objs = append(objs, hw)
+	}
+
+	return objs
+}
+
+func (tt *reconcilerTest) kubeadmControlPlane(replicas int32) *controlplanev1.KubeadmControlPlane {
+	return &controlplanev1.KubeadmControlPlane{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "KubeadmControlPlane",
+			APIVersion: controlplanev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      tt.cluster.Name,
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			Replicas: ptr.Int32(replicas),
+			Version:  kubernetesVersion,
+		},
+	}
+}
+
+func (tt *reconcilerTest) machineDeployment(replicas int32) *clusterv1.MachineDeployment {
+	return &clusterv1.MachineDeployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "MachineDeployment",
+			APIVersion: clusterv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      tt.cluster.Name + "-md-0",
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Spec: clusterv1.MachineDeploymentSpec{
+			Replicas: ptr.Int32(replicas),
+		},
+	}
+}
+
+type clusterOpt func(*anywherev1.Cluster)
+
+func tinkerbellCluster(opts ...clusterOpt) *anywherev1.Cluster {
+	c := &anywherev1.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.ClusterKind,
+			APIVersion: anywherev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: clusterNamespace,
+		},
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion: "1.21",
+			ClusterNetwork: anywherev1.ClusterNetwork{
+				Pods: anywherev1.Pods{
+					CidrBlocks: []string{"0.0.0.0"},
+				},
+				Services: anywherev1.Services{
+					CidrBlocks: []string{"0.0.0.0"},
+				},
+			},
+		},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+func dataCenter() *anywherev1.TinkerbellDatacenterConfig {
+	return &anywherev1.TinkerbellDatacenterConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.TinkerbellDatacenterKind,
+			APIVersion: anywherev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "datacenter",
+			Namespace: clusterNamespace,
+		},
+		Spec: anywherev1.TinkerbellDatacenterConfigSpec{
+			TinkerbellIP: "2.2.2.2",
+			OSImageURL:   "https://ubuntu.gz",
+		},
+	}
+}
+
+type tinkerbellMachineOpt func(config *anywherev1.TinkerbellMachineConfig)
+
+func machineConfig(opts ...tinkerbellMachineOpt) *anywherev1.TinkerbellMachineConfig {
+	m := &anywherev1.TinkerbellMachineConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.TinkerbellMachineConfigKind,
+			APIVersion: anywherev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: clusterNamespace,
+		},
+		Spec: anywherev1.TinkerbellMachineConfigSpec{
+			OSFamily: anywherev1.Ubuntu,
+			Users: []anywherev1.UserConfiguration{
+				{
+					Name:              "user",
+					SshAuthorizedKeys: []string{"ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABgQC8ZEibIrz1AUBKDvmDiWLs9f5DnOerC4qPITiDtSOuPAsxgZbRMavBfVTxodMdAkYRYlXxK6PqNo0ve0qcOV2yvpxH1OogasMMetck6BlM/dIoo3vEY4ZoG9DuVRIf9Iry5gJKbpMDYWpx1IGZrDMOFcIM20ii2qLQQk5hfq9OqdqhToEJFixdgJt/y/zt6Koy3kix+XsnrVdAHgWAq4CZuwt1G6JUAqrpob3H8vPmL7aS+35ktf0pHBm6nYoxRhslnWMUb/7vpzWiq+fUBIm2LYqvrnm7t3fRqFx7p2sZqAm2jDNivyYXwRXkoQPR96zvGeMtuQ5BVGPpsDfVudSW21+pEXHI0GINtTbua7Ogz7wtpVywSvHraRgdFOeY9mkXPzvm2IhoqNrteck2GErwqSqb19mPz6LnHueK0u7i6WuQWJn0CUoCtyMGIrowXSviK8qgHXKrmfTWATmCkbtosnLskNdYuOw8bKxq5S4WgdQVhPps2TiMSZndjX5NTr8= ubuntu@ip-10-2-0-6"},
+				},
+			},
+		},
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+func hardware(name string, labels map[string]string) *tinkv1alpha1.Hardware {
+	return &tinkv1alpha1.Hardware{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Hardware",
+			APIVersion: tinkv1alpha1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    labels,
+		},
+		Spec: tinkv1alpha1.HardwareSpec{
+			Disks: []tinkv1alpha1.Disk{{Device: "/dev/sda"}},
+		},
+	}
+}
+
+func tinkerbellMachineTemplate(name string) *unstructured.Unstructured {
+	m := &unstructured.Unstructured{}
+	m.SetAPIVersion("infrastructure.cluster.x-k8s.io/v1beta1")
+	m.SetKind(tinkerbell.TinkerbellMachineTemplateKind)
+	m.SetName(name)
+	m.SetNamespace(constants.EksaSystemNamespace)
+
+	return m
+}
@@ -100,7 +100,10 @@ func (tb *TemplateBuilder) GenerateCAPISpecControlPlane(clusterSpec *cluster.Spe
 			return nil, fmt.Errorf("failed to get ETCD TinkerbellTemplateConfig: %v", err)
 		}
 	}
-	values := buildTemplateMapCP(clusterSpec, *tb.controlPlaneMachineSpec, etcdMachineSpec, cpTemplateString, etcdTemplateString, *tb.datacenterSpec)
+	values, err := buildTemplateMapCP(clusterSpec, *tb.controlPlaneMachineSpec, etcdMachineSpec, cpTemplateString, etcdTemplateString, *tb.datacenterSpec)
+	if err != nil {
+		return nil, err
+	}
 
 	for _, buildOption := range buildOptions {
 		buildOption(values)
@@ -370,21 +373,30 @@ func machineDeploymentName(clusterName, nodeGroupName string) string {
 	return fmt.Sprintf("%s-%s", clusterName, nodeGroupName)
 }
 
-func buildTemplateMapCP(clusterSpec *cluster.Spec, controlPlaneMachineSpec, etcdMachineSpec v1alpha1.TinkerbellMachineConfigSpec, cpTemplateOverride, etcdTemplateOverride string, datacenterSpec v1alpha1.TinkerbellDatacenterConfigSpec) map[string]interface{} {
+func buildTemplateMapCP(clusterSpec *cluster.Spec, controlPlaneMachineSpec, etcdMachineSpec v1alpha1.TinkerbellMachineConfigSpec, cpTemplateOverride, etcdTemplateOverride string, datacenterSpec v1alpha1.TinkerbellDatacenterConfigSpec) (map[string]interface{}, error) {
 	bundle := clusterSpec.VersionsBundle
 	format := "cloud-config"
 
 	apiServerExtraArgs := clusterapi.OIDCToExtraArgs(clusterSpec.OIDCConfig).
-		Append(clusterapi.AwsIamAuthExtraArgs(clusterSpec.AWSIamConfig))
+		Append(clusterapi.AwsIamAuthExtraArgs(clusterSpec.AWSIamConfig)).
+		Append(clusterapi.EncryptionConfigurationExtraArgs(clusterSpec.Cluster.Spec.EncryptionConfiguration)).
+		Append(clusterapi.PodSecurityExtraArgs(clusterSpec.Cluster.Spec.PodSecurity)).
+		Append(clusterapi.APIServerExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration))
 
 	// LoadBalancerClass is feature gated in K8S v1.21 and needs to be enabled manually
 	if clusterSpec.Cluster.Spec.KubernetesVersion == v1alpha1.Kube121 {
-		apiServerExtraArgs.Append(clusterapi.FeatureGatesExtraArgs("ServiceLoadBalancerClass=true"))
+		featureGates := []string{"ServiceLoadBalancerClass=true"}
+		// keep the feature gates set in apiServerExtraArgs
+		if userFeatureGates, ok := apiServerExtraArgs["feature-gates"]; ok {
+			featureGates = append(featureGates, userFeatureGates)
+		}
+		apiServerExtraArgs.Append(clusterapi.FeatureGatesExtraArgs(featureGates...))
 	}
 
 	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
 		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf)).
-		Append(clusterapi.ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration))
+		Append(clusterapi.ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration)).
+		Append(clusterapi.KubeletConfigurationExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.KubeletConfiguration))
 
 	values := map[string]interface{}{
 		"clusterName":                   clusterSpec.Cluster.Name,
@@ -399,6 +411,8 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, controlPlaneMachineSpec, etcd
 		"podCidrs":                      clusterSpec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks,
 		"serviceCidrs":                  clusterSpec.Cluster.Spec.ClusterNetwork.Services.CidrBlocks,
 		"apiserverExtraArgs":            apiServerExtraArgs.ToPartialYaml(),
+		"controllerManagerExtraArgs":    clusterapi.ControllerManagerExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration).ToPartialYaml(),
+		"schedulerExtraArgs":            clusterapi.SchedulerExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration).ToPartialYaml(),
 		"baseRegistry":                  "", // TODO: need to get this values for creating template IMAGE_URL
 		"osDistro":                      "", // TODO: need to get this values for creating template IMAGE_URL
 		"osVersion":                     "", // TODO: need to get this values for creating template IMAGE_URL
@@ -451,7 +465,30 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, controlPlaneMachineSpec, etcd
 		values["awsIamAuth"] = true
 	}
 
-	return values
+	// Audit logging is only enabled when the cluster spec sets an audit policy
+	if clusterSpec.Cluster.Spec.AuditPolicy != nil {
+		auditPolicy, err := common.AuditPolicy(clusterSpec)
+		if err != nil {
+			return nil, err
+		}
+		values["auditPolicy"] = auditPolicy
+		values["apiserverExtraArgs"] = apiServerExtraArgs.Append(clusterapi.AuditLogExtraArgs(clusterSpec.Cluster.Spec.AuditPolicy)).ToPartialYaml()
+	}
+
+	if clusterSpec.Cluster.Spec.EncryptionConfiguration != nil {
+		values["encryptionConfig"] = true
+		values["kmsPluginSocketDirs"] = clusterapi.KMSPluginSocketDirs(clusterSpec.Cluster.Spec.EncryptionConfiguration)
+	}
+
+	if clusterSpec.Cluster.Spec.PodSecurity != nil {
+		admissionConfig, err := clusterapi.PodSecurityAdmissionConfiguration(clusterSpec.Cluster.Spec.PodSecurity)
+		if err != nil {
+			return nil, err
+		}
+		values["admissionConfig"] = admissionConfig
+	}
+
+	return values, nil
 }
 
 func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupMachineSpec v1alpha1.TinkerbellMachineConfigSpec, workerNodeGroupConfiguration v1alpha1.WorkerNodeGroupConfiguration, workerTemplateOverride string) map[string]interface{} {
@@ -460,7 +497,8 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupMachineSpec v1
 
 	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
 		Append(clusterapi.WorkerNodeLabelsExtraArgs(workerNodeGroupConfiguration)).
-		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf))
+		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf)).
+		Append(clusterapi.KubeletConfigurationExtraArgs(workerNodeGroupConfiguration.KubeletConfiguration))
 
 	values := map[string]interface{}{
 		"clusterName":            clusterSpec.Cluster.Name,
@@ -0,0 +1,56 @@
+package tinkerbell
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	capiyaml "github.com/aws/eks-anywhere/pkg/clusterapi/yaml"
+)
+
+// Workers represents the Tinkerbell specific CAPI spec for worker nodes.
+type Workers = clusterapi.Workers[*unstructured.Unstructured]
+
+// WorkersSpec generates a Tinkerbell specific CAPI spec for an eks-a cluster worker nodes.
+// It talks to the cluster with a client to detect changes in immutable objects and generates new
+// names for them.
+func WorkersSpec(ctx context.Context, logger logr.Logger, client kubernetes.Client, spec *cluster.Spec, hw []tinkv1alpha1.Hardware) (*Workers, error) {
+	templateBuilder, err := newControllerTemplateBuilder(spec, hw)
+	if err != nil {
+		return nil, err
+	}
+
+	machineTemplateNames := make(map[string]string, len(spec.Cluster.Spec.WorkerNodeGroupConfigurations))
+	kubeadmConfigTemplateNames := make(map[string]string, len(spec.Cluster.Spec.WorkerNodeGroupConfigurations))
+	for _, wnConfig := range spec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		machineTemplateNames[wnConfig.Name] = clusterapi.WorkerMachineTemplateName(spec, wnConfig)
+		kubeadmConfigTemplateNames[wnConfig.Name] = clusterapi.DefaultKubeadmConfigTemplateName(spec, wnConfig)
+	}
+
+	workersYaml, err := templateBuilder.GenerateCAPISpecWorkers(spec, machineTemplateNames, kubeadmConfigTemplateNames)
+	if err != nil {
+		return nil, err
+	}
+
+	parser, builder, err := capiyaml.NewWorkersParserAndBuilder(logger, machineTemplateMapping())
+	if err != nil {
+		return nil, errors.Wrap(err, "building tinkerbell workers parser and builder")
+	}
+
+	if err = parser.Parse(workersYaml, builder); err != nil {
+		return nil, errors.Wrap(err, "parsing tinkerbell CAPI workers yaml")
+	}
+
+	workers := builder.Workers
+	if err = workers.UpdateImmutableObjectNames(ctx, client, getMachineTemplate, machineTemplateEqual); err != nil {
+		return nil, errors.Wrap(err, "updating tinkerbell worker immutable object names")
+	}
+
+	return workers, nil
+}
@@ -0,0 +1,58 @@
+package tinkerbell_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell"
+)
+
+func TestWorkersSpecNewCluster(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	spec := test.NewFullClusterSpec(t, testClusterConfigStackedEtcdFilename)
+
+	workers, err := tinkerbell.WorkersSpec(ctx, logger, test.NewFakeKubeClient(), spec, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(workers.Groups).To(HaveLen(1))
+	g.Expect(workers.Groups[0].MachineDeployment.Name).To(Equal("test-md-0"))
+	g.Expect(workers.Groups[0].ProviderMachineTemplate.GetName()).To(Equal("test-md-0-1"))
+	g.Expect(workers.Groups[0].KubeadmConfigTemplate.Name).To(Equal("test-md-0-1"))
+}
+
+func TestWorkersSpecUpdateMachineTemplate(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	spec := test.NewFullClusterSpec(t, testClusterConfigStackedEtcdFilename)
+
+	workers, err := tinkerbell.WorkersSpec(ctx, logger, test.NewFakeKubeClient(), spec, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	group := workers.Groups[0]
+	oldTemplate := group.ProviderMachineTemplate.DeepCopy()
+	g.Expect(unstructured.SetNestedField(oldTemplate.Object, "old-template", "spec", "template", "spec", "templateOverride")).To(Succeed())
+	client := test.NewFakeKubeClient(group.MachineDeployment.DeepCopy(), group.KubeadmConfigTemplate.DeepCopy(), oldTemplate)
+
+	workers, err = tinkerbell.WorkersSpec(ctx, logger, client, spec, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(workers.Groups[0].ProviderMachineTemplate.GetName()).To(Equal("test-md-0-2"))
+	g.Expect(workers.Groups[0].MachineDeployment.Spec.Template.Spec.InfrastructureRef.Name).To(Equal("test-md-0-2"))
+	g.Expect(workers.Groups[0].KubeadmConfigTemplate.Name).To(Equal("test-md-0-1"))
+}
+
+func TestWorkersSpecMissingMachineConfig(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	spec := test.NewFullClusterSpec(t, testClusterConfigStackedEtcdFilename)
+	delete(spec.TinkerbellMachineConfigs, "test-md")
+
+	_, err := tinkerbell.WorkersSpec(ctx, logger, test.NewFakeKubeClient(), spec, nil)
+	g.Expect(err).To(MatchError(ContainSubstring("TinkerbellMachineConfig test-md for worker node group md-0 is missing")))
+}
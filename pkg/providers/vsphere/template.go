@@ -131,13 +131,20 @@ func buildTemplateMapCP(
 	sharedExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs()
 	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
 		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf)).
-		Append(clusterapi.ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration))
+		Append(clusterapi.ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration)).
+		Append(clusterapi.KubeletConfigurationExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.KubeletConfiguration))
 	apiServerExtraArgs := clusterapi.OIDCToExtraArgs(clusterSpec.OIDCConfig).
 		Append(clusterapi.AwsIamAuthExtraArgs(clusterSpec.AWSIamConfig)).
 		Append(clusterapi.PodIAMAuthExtraArgs(clusterSpec.Cluster.Spec.PodIAMConfig)).
-		Append(sharedExtraArgs)
+		Append(clusterapi.EncryptionConfigurationExtraArgs(clusterSpec.Cluster.Spec.EncryptionConfiguration)).
+		Append(clusterapi.PodSecurityExtraArgs(clusterSpec.Cluster.Spec.PodSecurity)).
+		Append(sharedExtraArgs).
+		Append(clusterapi.APIServerExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration))
 	controllerManagerExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
-		Append(clusterapi.NodeCIDRMaskExtraArgs(&clusterSpec.Cluster.Spec.ClusterNetwork))
+		Append(clusterapi.NodeCIDRMaskExtraArgs(&clusterSpec.Cluster.Spec.ClusterNetwork)).
+		Append(clusterapi.ControllerManagerExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration))
+	schedulerExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
+		Append(clusterapi.SchedulerExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration))
 
 	vuc := config.NewVsphereUserConfig()
 
@@ -186,7 +193,7 @@ func buildTemplateMapCP(
 		"etcdCipherSuites":                     crypto.SecureCipherSuitesString(),
 		"apiserverExtraArgs":                   apiServerExtraArgs.ToPartialYaml(),
 		"controllerManagerExtraArgs":           controllerManagerExtraArgs.ToPartialYaml(),
-		"schedulerExtraArgs":                   sharedExtraArgs.ToPartialYaml(),
+		"schedulerExtraArgs":                   schedulerExtraArgs.ToPartialYaml(),
 		"kubeletExtraArgs":                     kubeletExtraArgs.ToPartialYaml(),
 		"format":                               format,
 		"externalEtcdVersion":                  bundle.KubeDistro.EtcdVersion,
@@ -203,11 +210,14 @@ func buildTemplateMapCP(
 		"disableCSI":                           datacenterSpec.DisableCSI,
 	}
 
-	auditPolicy, err := common.GetAuditPolicy(clusterSpec.Cluster.Spec.KubernetesVersion)
+	auditPolicy, err := common.AuditPolicy(clusterSpec)
 	if err != nil {
 		return nil, err
 	}
 	values["auditPolicy"] = auditPolicy
+	values["auditLogMaxAge"] = clusterSpec.Cluster.Spec.AuditPolicy.LogMaxAgeOrDefault()
+	values["auditLogMaxBackup"] = clusterSpec.Cluster.Spec.AuditPolicy.LogMaxBackupOrDefault()
+	values["auditLogMaxSize"] = clusterSpec.Cluster.Spec.AuditPolicy.LogMaxSizeOrDefault()
 
 	if clusterSpec.Cluster.Spec.RegistryMirrorConfiguration != nil {
 		registryMirror := registrymirror.FromCluster(clusterSpec.Cluster)
@@ -284,6 +294,19 @@ func buildTemplateMapCP(
 		values["awsIamAuth"] = true
 	}
 
+	if clusterSpec.Cluster.Spec.EncryptionConfiguration != nil {
+		values["encryptionConfig"] = true
+		values["kmsPluginSocketDirs"] = clusterapi.KMSPluginSocketDirs(clusterSpec.Cluster.Spec.EncryptionConfiguration)
+	}
+
+	if clusterSpec.Cluster.Spec.PodSecurity != nil {
+		admissionConfig, err := clusterapi.PodSecurityAdmissionConfiguration(clusterSpec.Cluster.Spec.PodSecurity)
+		if err != nil {
+			return nil, err
+		}
+		values["admissionConfig"] = admissionConfig
+	}
+
 	return values, nil
 }
 
@@ -297,7 +320,8 @@ func buildTemplateMapMD(
 	format := "cloud-config"
 	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
 		Append(clusterapi.WorkerNodeLabelsExtraArgs(workerNodeGroupConfiguration)).
-		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf))
+		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf)).
+		Append(clusterapi.KubeletConfigurationExtraArgs(workerNodeGroupConfiguration.KubeletConfiguration))
 
 	firstUser := workerNodeGroupMachineSpec.Users[0]
 	sshKey, err := common.StripSshAuthorizedKeyComment(firstUser.SshAuthorizedKeys[0])
@@ -17,6 +17,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/govmomi"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
 )
 
 const (
@@ -321,6 +322,42 @@ func (v *Validator) collectSpecMachineConfigs(ctx context.Context, spec *Spec) (
 	return machineConfigs, nil
 }
 
+// userPrivsValidations returns the validations of the vSphere privileges of the users in vuc.
+// Each of them makes several calls to vCenter, so they are meant to run in parallel with a validations.Runner.
+func (v *Validator) userPrivsValidations(spec *Spec, vuc *config.VSphereUserConfig) []validations.Validation {
+	vs := []validations.Validation{
+		privsValidation(vuc.EksaVsphereUsername, func(ctx context.Context) (bool, error) {
+			return v.validateUserPrivs(ctx, spec, vuc)
+		}),
+	}
+
+	if len(vuc.EksaVsphereCPUsername) > 0 && vuc.EksaVsphereCPUsername != vuc.EksaVsphereUsername {
+		vs = append(vs, privsValidation(vuc.EksaVsphereCPUsername, func(ctx context.Context) (bool, error) {
+			return v.validateCPUserPrivs(ctx, spec, vuc)
+		}))
+	}
+
+	if len(vuc.EksaVsphereCSIUsername) > 0 && vuc.EksaVsphereCSIUsername != vuc.EksaVsphereUsername {
+		vs = append(vs, privsValidation(vuc.EksaVsphereCSIUsername, func(ctx context.Context) (bool, error) {
+			return v.validateCSIUserPrivs(ctx, spec, vuc)
+		}))
+	}
+
+	return vs
+}
+
+func privsValidation(username string, validate func(ctx context.Context) (bool, error)) validations.Validation {
+	return validations.Validation{
+		Name: fmt.Sprintf("%s user vSphere privileges validated", username),
+		Run: func(ctx context.Context) *validations.ValidationResult {
+			passed, err := validate(ctx)
+			// Missing privileges are logged as warnings by validatePrivs and don't fail the validation,
+			// but the user privileges are not reported as validated either.
+			return &validations.ValidationResult{Err: err, Silent: !passed}
+		},
+	}
+}
+
 func (v *Validator) validateUserPrivs(ctx context.Context, spec *Spec, vuc *config.VSphereUserConfig) (bool, error) {
 	machineConfigs, err := v.collectSpecMachineConfigs(ctx, spec)
 	if err != nil {
@@ -14,6 +14,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/workflows/interfaces"
 )
 
@@ -45,6 +46,8 @@ type CommandContext struct {
 	WorkloadCluster    *types.Cluster
 	Profiler           *Profiler
 	OriginalError      error
+	// ValidationReport records the results of the validations run by the command if set.
+	ValidationReport *validations.Report
 }
 
 func (c *CommandContext) SetError(err error) {
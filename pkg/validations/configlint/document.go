@@ -0,0 +1,217 @@
+package configlint
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// separatorRegex splits documents the same way the cluster config parser does.
+var separatorRegex = regexp.MustCompile(`(?m)^---$`)
+
+var yamlErrorLineRegex = regexp.MustCompile(`^yaml: line (\d+): `)
+
+// document is a YAML document in a cluster config file with the line of each of its fields.
+type document struct {
+	index   int
+	content []byte
+	// offset is the number of lines in the file before the document.
+	offset int
+	kind   string
+	name   string
+	// paths are the JSON paths of the fields in the document, in the order they appear.
+	paths []string
+	lines map[string]int
+}
+
+func splitDocuments(content []byte) []*document {
+	var docs []*document
+	start, offset := 0, 0
+	split := func(end int) {
+		docs = append(docs, &document{
+			index:   len(docs),
+			content: content[start:end],
+			offset:  offset,
+			lines:   map[string]int{},
+		})
+	}
+
+	for _, loc := range separatorRegex.FindAllIndex(content, -1) {
+		split(loc[0])
+		offset += strings.Count(string(content[start:loc[1]]), "\n")
+		start = loc[1]
+	}
+	split(len(content))
+
+	return docs
+}
+
+// parse reads the kind, name and field lines of the document.
+// It returns the line and cause of the error if the document is not valid YAML.
+func (d *document) parse() (line int, err error) {
+	node := &yamlv3.Node{}
+	if err := yamlv3.Unmarshal(d.content, node); err != nil {
+		msg := err.Error()
+		if m := yamlErrorLineRegex.FindStringSubmatch(msg); m != nil {
+			line, _ = strconv.Atoi(m[1])
+			return d.offset + line, fmt.Errorf("invalid yaml: %s", strings.TrimPrefix(msg, m[0]))
+		}
+		return d.offset + 1, fmt.Errorf("invalid yaml: %s", strings.TrimPrefix(msg, "yaml: "))
+	}
+
+	if len(node.Content) == 0 {
+		return 0, nil
+	}
+	d.indexNode(node.Content[0], "")
+	d.kind = d.scalar(node.Content[0], "kind")
+	if metadata := mappingValue(node.Content[0], "metadata"); metadata != nil {
+		d.name = d.scalar(metadata, "name")
+	}
+	return 0, nil
+}
+
+func (d *document) empty() bool {
+	return len(d.paths) == 0
+}
+
+func (d *document) indexNode(node *yamlv3.Node, path string) {
+	switch node.Kind {
+	case yamlv3.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			p := key.Value
+			if path != "" {
+				p = path + "." + key.Value
+			}
+			d.add(p, key.Line)
+			d.indexNode(node.Content[i+1], p)
+		}
+	case yamlv3.SequenceNode:
+		for i, item := range node.Content {
+			p := fmt.Sprintf("%s[%d]", path, i)
+			d.add(p, item.Line)
+			d.indexNode(item, p)
+		}
+	}
+}
+
+func (d *document) add(path string, line int) {
+	d.paths = append(d.paths, path)
+	d.lines[path] = d.offset + line
+}
+
+func (d *document) scalar(node *yamlv3.Node, key string) string {
+	if v := mappingValue(node, key); v != nil && v.Kind == yamlv3.ScalarNode {
+		return v.Value
+	}
+	return ""
+}
+
+func mappingValue(node *yamlv3.Node, key string) *yamlv3.Node {
+	if node.Kind != yamlv3.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+var indexRegex = regexp.MustCompile(`\[\d+\]`)
+
+// locate returns the path in the document closest to path and its line. Paths can omit
+// the list indexes, in which case the first matching element is used. If neither path nor
+// any of its parents is in the document, it returns the line of the document's first field.
+func (d *document) locate(path string) (string, int) {
+	for p := path; p != ""; p = parentPath(p) {
+		if line, ok := d.lines[p]; ok {
+			return p, line
+		}
+		for _, candidate := range d.paths {
+			if indexRegex.ReplaceAllString(candidate, "") == p {
+				return candidate, d.lines[candidate]
+			}
+		}
+	}
+
+	if d.empty() {
+		return "", d.offset + 1
+	}
+	return "", d.lines[d.paths[0]]
+}
+
+// fieldPath returns the path of the first field with the given name.
+func (d *document) fieldPath(name string) string {
+	for _, p := range d.paths {
+		if p == name || strings.HasSuffix(p, "."+name) {
+			return p
+		}
+	}
+	return ""
+}
+
+func parentPath(path string) string {
+	if i := strings.LastIndexAny(path, ".["); i > 0 {
+		return path[:i]
+	}
+	return ""
+}
+
+// findPath returns the path in the document whose trailing field names appear in msg, ignoring case,
+// preferring the one matching the most field names. Single field names only match if they're
+// camel cased, since short common words like name are ambiguous.
+func (d *document) findPath(msg string) (path string, found bool) {
+	msg = strings.ToLower(msg)
+	best := 0
+	for _, p := range d.paths {
+		segments := strings.Split(indexRegex.ReplaceAllString(p, ""), ".")
+		for n := len(segments); n > best; n-- {
+			suffix := segments[len(segments)-n:]
+			if n == 1 && !isCamelCase(suffix[0]) {
+				continue
+			}
+			if containsWord(msg, strings.ToLower(strings.Join(suffix, "."))) {
+				path, best = p, n
+				break
+			}
+		}
+	}
+	return path, best > 0
+}
+
+func isCamelCase(s string) bool {
+	return strings.ToLower(s) != s && strings.ToLower(s[:1]) == s[:1]
+}
+
+func containsWord(s, word string) bool {
+	for i := strings.Index(s, word); i >= 0; {
+		end := i + len(word)
+		if (i == 0 || !isWordChar(s[i-1])) && (end == len(s) || !continuesWord(s[end:])) {
+			return true
+		}
+		next := strings.Index(s[i+1:], word)
+		if next < 0 {
+			break
+		}
+		i += next + 1
+	}
+	return false
+}
+
+func isWordChar(c byte) bool {
+	return c == '.' || c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// continuesWord returns true if s continues the field name or path before it.
+// A dot only does if it's followed by another field name, not at the end of a sentence.
+func continuesWord(s string) bool {
+	if s[0] == '.' {
+		return len(s) > 1 && isWordChar(s[1]) && s[1] != '.'
+	}
+	return isWordChar(s[0])
+}
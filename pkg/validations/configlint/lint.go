@@ -0,0 +1,361 @@
+// Package configlint validates cluster config files offline, reporting each issue found
+// with its location in the file.
+package configlint
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell"
+)
+
+// Severity is how serious an issue is.
+type Severity string
+
+// Issue severities. Only errors make a cluster config invalid.
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Issue is a problem found in a cluster config file.
+type Issue struct {
+	Severity Severity `json:"severity"`
+	// Document is the index of the YAML document in the file, starting at 0.
+	Document int `json:"document"`
+	// Line is the line in the file, starting at 1.
+	Line int    `json:"line"`
+	Kind string `json:"kind,omitempty"`
+	Name string `json:"name,omitempty"`
+	// Path is the JSON path of the field with the issue, empty if the issue is with the whole object.
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+}
+
+func (i Issue) String() string {
+	object := fmt.Sprintf("document %d", i.Document)
+	if i.Kind != "" {
+		object = fmt.Sprintf("%s %s/%s", object, i.Kind, i.Name)
+	}
+	if i.Path != "" {
+		object = fmt.Sprintf("%s %s", object, i.Path)
+	}
+	return fmt.Sprintf("%s: %s: %s", i.Severity, object, i.Message)
+}
+
+// HasErrors returns true if any of the issues is an error.
+func HasErrors(issues []Issue) bool {
+	for _, i := range issues {
+		if i.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Lint runs the provider independent and provider static validations on the cluster config
+// in content, without accessing any infrastructure, and returns the issues found sorted by line.
+func Lint(content []byte) []Issue {
+	l := &linter{
+		docs:     splitDocuments(content),
+		reported: map[string]bool{},
+	}
+
+	if l.parseDocuments() && l.decodeObjects() {
+		l.validateConfig(content)
+	}
+
+	sort.SliceStable(l.issues, func(i, j int) bool {
+		return l.issues[i].Line < l.issues[j].Line
+	})
+	return l.issues
+}
+
+type linter struct {
+	docs     []*document
+	issues   []Issue
+	reported map[string]bool
+}
+
+func (l *linter) add(severity Severity, doc *document, path, msg string) {
+	path, line := doc.locate(path)
+	key := fmt.Sprintf("%d/%s/%s", doc.index, path, msg)
+	if l.reported[key] {
+		return
+	}
+	l.reported[key] = true
+
+	l.issues = append(l.issues, Issue{
+		Severity: severity,
+		Document: doc.index,
+		Line:     line,
+		Kind:     doc.kind,
+		Name:     doc.name,
+		Path:     path,
+		Message:  msg,
+	})
+}
+
+// parseDocuments indexes the fields of each YAML document and returns false if any of them is invalid.
+func (l *linter) parseDocuments() bool {
+	valid := true
+	for _, doc := range l.docs {
+		if line, err := doc.parse(); err != nil {
+			l.issues = append(l.issues, Issue{Severity: SeverityError, Document: doc.index, Line: line, Message: err.Error()})
+			valid = false
+		}
+	}
+	return valid
+}
+
+var (
+	jsonErrorPrefixRegex  = regexp.MustCompile(`^error unmarshaling JSON: while decoding JSON: (json: )?`)
+	jsonTypeErrorRegex    = regexp.MustCompile(`Go struct field \w*\.(\S+) of type`)
+	jsonUnknownFieldRegex = regexp.MustCompile(`unknown field "([^"]+)"`)
+	jsonIndexRegex        = regexp.MustCompile(`\.(\d+)(\.|$)`)
+)
+
+// decodeObjects decodes the documents of EKS-A kinds into their API types and returns false if
+// any of them has a field of the wrong type. Unknown fields, which are ignored when parsing the
+// cluster config, are reported as warnings.
+func (l *linter) decodeObjects() bool {
+	scheme := runtime.NewScheme()
+	if err := anywherev1.AddToScheme(scheme); err != nil {
+		l.add(SeverityError, l.docs[0], "", fmt.Sprintf("building scheme: %v", err))
+		return false
+	}
+
+	valid := true
+	for _, doc := range l.docs {
+		obj, err := scheme.New(anywherev1.GroupVersion.WithKind(doc.kind))
+		if err != nil {
+			continue
+		}
+
+		if err := yaml.Unmarshal(doc.content, obj); err != nil {
+			valid = false
+			path := ""
+			if m := jsonTypeErrorRegex.FindStringSubmatch(err.Error()); m != nil {
+				path = jsonIndexRegex.ReplaceAllString(m[1], "[$1]$2")
+			}
+			msg := jsonErrorPrefixRegex.ReplaceAllString(err.Error(), "")
+			l.add(SeverityError, doc, path, jsonTypeErrorRegex.ReplaceAllString(msg, "field of type"))
+			continue
+		}
+
+		if err := yaml.UnmarshalStrict(doc.content, obj); err != nil {
+			path := ""
+			if m := jsonUnknownFieldRegex.FindStringSubmatch(err.Error()); m != nil {
+				path = doc.fieldPath(m[1])
+			}
+			l.add(SeverityWarning, doc, path, jsonErrorPrefixRegex.ReplaceAllString(err.Error(), ""))
+		}
+	}
+	return valid
+}
+
+func (l *linter) find(kind, name string) *document {
+	for _, doc := range l.docs {
+		if doc.kind == kind && doc.name == name {
+			return doc
+		}
+	}
+	return nil
+}
+
+func (l *linter) firstDocument() *document {
+	for _, doc := range l.docs {
+		if !doc.empty() {
+			return doc
+		}
+	}
+	return l.docs[0]
+}
+
+func (l *linter) validateConfig(content []byte) {
+	config, err := cluster.ParseConfig(content)
+	if err != nil {
+		l.add(SeverityError, l.firstDocument(), "", err.Error())
+		return
+	}
+
+	clusterDoc := l.find(anywherev1.ClusterKind, config.Cluster.Name)
+	if clusterDoc == nil {
+		clusterDoc = l.firstDocument()
+	}
+
+	if err = cluster.SetConfigDefaults(config); err != nil {
+		l.report(clusterDoc, err)
+		return
+	}
+
+	for _, err := range anywherev1.ClusterConfigContentErrors(config.Cluster) {
+		l.report(clusterDoc, err)
+	}
+	l.validateReferences(clusterDoc, config.Cluster)
+	l.validateChildObjects(config)
+
+	if config.TinkerbellDatacenter != nil {
+		l.validateTinkerbell(clusterDoc, config)
+	}
+}
+
+// report adds the issues in err, which are located using the field paths in structured errors
+// or the field names mentioned in the error message.
+func (l *linter) report(doc *document, err error) {
+	var statusErr *apierrors.StatusError
+	if errors.As(err, &statusErr) && statusErr.ErrStatus.Details != nil && len(statusErr.ErrStatus.Details.Causes) > 0 {
+		for _, cause := range statusErr.ErrStatus.Details.Causes {
+			l.add(SeverityError, doc, cause.Field, cause.Message)
+		}
+		return
+	}
+
+	msg := err.Error()
+	path, found := doc.findPath(msg)
+	if !found {
+		path = "spec"
+	}
+	l.add(SeverityError, doc, path, msg)
+}
+
+func (l *linter) reportFieldErrors(doc *document, errs field.ErrorList) {
+	for _, err := range errs {
+		l.add(SeverityError, doc, err.Field, err.ErrorBody())
+	}
+}
+
+// validateReferences checks the objects referenced by the cluster are in the file.
+func (l *linter) validateReferences(doc *document, c *anywherev1.Cluster) {
+	refs := map[string]*anywherev1.Ref{
+		"spec.datacenterRef":                             &c.Spec.DatacenterRef,
+		"spec.controlPlaneConfiguration.machineGroupRef": c.Spec.ControlPlaneConfiguration.MachineGroupRef,
+		"spec.gitOpsRef":                                 c.Spec.GitOpsRef,
+	}
+	if c.Spec.ExternalEtcdConfiguration != nil {
+		refs["spec.externalEtcdConfiguration.machineGroupRef"] = c.Spec.ExternalEtcdConfiguration.MachineGroupRef
+	}
+	for i, group := range c.Spec.WorkerNodeGroupConfigurations {
+		refs[fmt.Sprintf("spec.workerNodeGroupConfigurations[%d].machineGroupRef", i)] = group.MachineGroupRef
+	}
+	for i := range c.Spec.IdentityProviderRefs {
+		refs[fmt.Sprintf("spec.identityProviderRefs[%d]", i)] = &c.Spec.IdentityProviderRefs[i]
+	}
+
+	paths := make([]string, 0, len(refs))
+	for path := range refs {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		ref := refs[path]
+		if ref == nil || ref.Kind == "" || ref.Name == "" {
+			continue
+		}
+		if l.find(ref.Kind, ref.Name) == nil {
+			l.add(SeverityError, doc, path+".name", fmt.Sprintf("%s %s not found in the cluster config", ref.Kind, ref.Name))
+		}
+	}
+}
+
+type validator interface {
+	Validate() error
+}
+
+type fieldValidator interface {
+	Validate() field.ErrorList
+}
+
+// validateChildObjects runs the validations of the objects referenced by the cluster,
+// the same ones their webhooks run on create.
+func (l *linter) validateChildObjects(config *cluster.Config) {
+	for _, obj := range config.ChildObjects() {
+		kind := obj.GetObjectKind().GroupVersionKind().Kind
+		doc := l.find(kind, obj.GetName())
+		if doc == nil {
+			continue
+		}
+
+		switch o := obj.(type) {
+		case validator:
+			if err := o.Validate(); err != nil {
+				l.report(doc, err)
+			}
+		case fieldValidator:
+			l.reportFieldErrors(doc, o.Validate())
+		}
+
+		if obj.GetNamespace() != config.Cluster.Namespace {
+			l.add(SeverityError, doc, "metadata.namespace", fmt.Sprintf("%s and Cluster objects must have the same namespace specified", kind))
+		}
+	}
+}
+
+// validateTinkerbell runs the Tinkerbell assertions that don't need access to the network or the hardware catalogue.
+func (l *linter) validateTinkerbell(clusterDoc *document, config *cluster.Config) {
+	spec := tinkerbell.NewClusterSpec(&cluster.Spec{Config: config}, config.TinkerbellMachineConfigs, config.TinkerbellDatacenter)
+	docs := []*document{clusterDoc}
+	if doc := l.find(anywherev1.TinkerbellDatacenterKind, config.TinkerbellDatacenter.Name); doc != nil {
+		docs = append(docs, doc)
+	}
+	for _, m := range config.TinkerbellMachineConfigs {
+		if doc := l.find(anywherev1.TinkerbellMachineConfigKind, m.Name); doc != nil {
+			docs = append(docs, doc)
+		}
+	}
+
+	// The other assertions expect the machine refs to exist. Missing ones are reported by validateReferences.
+	for _, assert := range []tinkerbell.ClusterSpecAssertion{
+		tinkerbell.AssertControlPlaneMachineRefExists,
+		tinkerbell.AssertEtcdMachineRefExists,
+		tinkerbell.AssertWorkerNodeGroupMachineRefsExists,
+	} {
+		if err := assert(spec); err != nil {
+			return
+		}
+	}
+
+	assertions := []tinkerbell.ClusterSpecAssertion{
+		tinkerbell.AssertK8SVersionNot120,
+		tinkerbell.AssertDatacenterConfigValid,
+		tinkerbell.AssertMachineConfigsValid,
+		tinkerbell.AssertMachineConfigNamespaceMatchesDatacenterConfig,
+		tinkerbell.AssertOsFamilyValid,
+		tinkerbell.AssertTinkerbellIPAndControlPlaneIPNotSame,
+	}
+	for _, assert := range assertions {
+		if err := assert(spec); err != nil {
+			l.report(mentionedDocument(docs, err.Error()), err)
+		}
+	}
+}
+
+// mentionedDocument returns the first of docs with a field mentioned in msg, then the one whose
+// object name is mentioned, preferring the longest name. Otherwise, it returns the first one.
+func mentionedDocument(docs []*document, msg string) *document {
+	for _, doc := range docs {
+		if _, found := doc.findPath(msg); found {
+			return doc
+		}
+	}
+
+	mentioned := docs[0]
+	longest := 0
+	for _, doc := range docs {
+		if len(doc.name) > longest && strings.Contains(msg, doc.name) {
+			mentioned, longest = doc, len(doc.name)
+		}
+	}
+	return mentioned
+}
@@ -0,0 +1,215 @@
+package configlint_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/validations/configlint"
+)
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name       string
+		file       string
+		wantIssues []configlint.Issue
+		wantErrors bool
+	}{
+		{
+			name: "valid",
+			file: "testdata/cluster_docker_valid.yaml",
+		},
+		{
+			name: "invalid yaml",
+			file: "testdata/cluster_invalid_yaml.yaml",
+			wantIssues: []configlint.Issue{
+				{Severity: configlint.SeverityError, Document: 1, Line: 11, Message: "invalid yaml: did not find expected node content"},
+			},
+			wantErrors: true,
+		},
+		{
+			name: "wrong type",
+			file: "testdata/cluster_wrong_type.yaml",
+			wantIssues: []configlint.Issue{
+				{
+					Severity: configlint.SeverityError,
+					Document: 0,
+					Line:     23,
+					Kind:     "Cluster",
+					Name:     "mgmt",
+					Path:     "spec.workerNodeGroupConfigurations[0].count",
+					Message:  "cannot unmarshal string into field of type int",
+				},
+			},
+			wantErrors: true,
+		},
+		{
+			name: "unknown field",
+			file: "testdata/cluster_unknown_field.yaml",
+			wantIssues: []configlint.Issue{
+				{
+					Severity: configlint.SeverityWarning,
+					Document: 0,
+					Line:     17,
+					Kind:     "Cluster",
+					Name:     "mgmt",
+					Path:     "spec.controlPlaneConfiguration.replicas",
+					Message:  `unknown field "replicas"`,
+				},
+			},
+		},
+		{
+			name: "invalid config",
+			file: "testdata/cluster_invalid.yaml",
+			wantIssues: []configlint.Issue{
+				{
+					Severity: configlint.SeverityError,
+					Document: 0,
+					Line:     5,
+					Kind:     "Cluster",
+					Name:     "mgmt",
+					Path:     "spec",
+					Message:  "control plane node count cannot be an even number",
+				},
+				{
+					Severity: configlint.SeverityError,
+					Document: 0,
+					Line:     23,
+					Kind:     "Cluster",
+					Name:     "mgmt",
+					Path:     "spec.registryMirrorConfiguration.insecureSkipVerify",
+					Message:  "insecureSkipVerify is only supported for snow provider",
+				},
+				{
+					Severity: configlint.SeverityError,
+					Document: 0,
+					Line:     31,
+					Kind:     "Cluster",
+					Name:     "mgmt",
+					Path:     "spec.workerNodeGroupConfigurations[1].machineGroupRef.name",
+					Message:  "VSphereMachineConfig md-1 not found in the cluster config",
+				},
+				{
+					Severity: configlint.SeverityError,
+					Document: 1,
+					Line:     37,
+					Kind:     "DockerDatacenterConfig",
+					Name:     "mgmt",
+					Path:     "metadata.namespace",
+					Message:  "DockerDatacenterConfig and Cluster objects must have the same namespace specified",
+				},
+			},
+			wantErrors: true,
+		},
+		{
+			name: "no cluster",
+			file: "testdata/no_cluster.yaml",
+			wantIssues: []configlint.Issue{
+				{
+					Severity: configlint.SeverityError,
+					Document: 0,
+					Line:     1,
+					Kind:     "DockerDatacenterConfig",
+					Name:     "mgmt",
+					Message:  "no Cluster found in manifest",
+				},
+			},
+			wantErrors: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			content, err := os.ReadFile(tt.file)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			issues := configlint.Lint(content)
+			g.Expect(issues).To(Equal(tt.wantIssues))
+			g.Expect(configlint.HasErrors(issues)).To(Equal(tt.wantErrors))
+		})
+	}
+}
+
+func TestLintTinkerbell(t *testing.T) {
+	g := NewWithT(t)
+	content, err := os.ReadFile("testdata/cluster_tinkerbell_same_ip.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(configlint.Lint(content)).To(ConsistOf(configlint.Issue{
+		Severity: configlint.SeverityError,
+		Document: 0,
+		Line:     18,
+		Kind:     "Cluster",
+		Name:     "tink",
+		Path:     "spec.controlPlaneConfiguration.endpoint.host",
+		Message:  "controlPlaneConfiguration.endpoint.host and tinkerbellIP are the same (1.2.3.4), please provide two unique IPs",
+	}))
+}
+
+func TestIssueString(t *testing.T) {
+	g := NewWithT(t)
+	issue := configlint.Issue{
+		Severity: configlint.SeverityError,
+		Document: 1,
+		Line:     12,
+		Kind:     "VSphereMachineConfig",
+		Name:     "cp",
+		Path:     "spec.osFamily",
+		Message:  "unsupported osFamily",
+	}
+	g.Expect(issue.String()).To(Equal("error: document 1 VSphereMachineConfig/cp spec.osFamily: unsupported osFamily"))
+}
+
+func TestPrintIssuesText(t *testing.T) {
+	g := NewWithT(t)
+	buf := &bytes.Buffer{}
+	issues := []configlint.Issue{
+		{Severity: configlint.SeverityWarning, Document: 0, Line: 17, Kind: "Cluster", Name: "mgmt", Path: "spec.replicas", Message: `unknown field "replicas"`},
+		{Severity: configlint.SeverityError, Document: 1, Line: 25, Message: "invalid yaml: did not find expected node content"},
+	}
+
+	g.Expect(configlint.PrintIssues(buf, configlint.OutputText, "cluster.yaml", issues)).To(Succeed())
+	g.Expect(buf.String()).To(Equal(`cluster.yaml:17: warning: document 0 Cluster/mgmt spec.replicas: unknown field "replicas"
+cluster.yaml:25: error: document 1: invalid yaml: did not find expected node content
+`))
+}
+
+func TestPrintIssuesJSON(t *testing.T) {
+	g := NewWithT(t)
+	buf := &bytes.Buffer{}
+	issues := []configlint.Issue{
+		{Severity: configlint.SeverityError, Document: 0, Line: 16, Kind: "Cluster", Name: "mgmt", Path: "spec.controlPlaneConfiguration.count", Message: "control plane node count cannot be an even number"},
+	}
+
+	g.Expect(configlint.PrintIssues(buf, configlint.OutputJSON, "cluster.yaml", issues)).To(Succeed())
+	g.Expect(buf.String()).To(MatchJSON(`{
+		"file": "cluster.yaml",
+		"issues": [{
+			"severity": "error",
+			"document": 0,
+			"line": 16,
+			"kind": "Cluster",
+			"name": "mgmt",
+			"path": "spec.controlPlaneConfiguration.count",
+			"message": "control plane node count cannot be an even number"
+		}]
+	}`))
+}
+
+func TestPrintIssuesJSONEmpty(t *testing.T) {
+	g := NewWithT(t)
+	buf := &bytes.Buffer{}
+
+	g.Expect(configlint.PrintIssues(buf, configlint.OutputJSON, "cluster.yaml", nil)).To(Succeed())
+	g.Expect(buf.String()).To(MatchJSON(`{"file": "cluster.yaml", "issues": []}`))
+}
+
+func TestValidateOutput(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(configlint.ValidateOutput(configlint.OutputText)).To(Succeed())
+	g.Expect(configlint.ValidateOutput(configlint.OutputJSON)).To(Succeed())
+	g.Expect(configlint.ValidateOutput("yaml")).To(MatchError("invalid output format [yaml], valid options are: text, json"))
+}
@@ -0,0 +1,58 @@
+package configlint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Output formats supported by PrintIssues.
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+)
+
+// ValidateOutput returns an error if format is not a supported output format.
+func ValidateOutput(format string) error {
+	switch format {
+	case OutputText, OutputJSON:
+		return nil
+	default:
+		return fmt.Errorf("invalid output format [%s], valid options are: %s, %s", format, OutputText, OutputJSON)
+	}
+}
+
+// PrintIssues writes the issues found in a cluster config file to w in the given format.
+// The text format has one issue per line, prefixed by the file and line, like compiler errors.
+func PrintIssues(w io.Writer, format, file string, issues []Issue) error {
+	switch format {
+	case OutputJSON:
+		return printJSON(w, file, issues)
+	case OutputText:
+	default:
+		return ValidateOutput(format)
+	}
+
+	for _, i := range issues {
+		if _, err := fmt.Fprintf(w, "%s:%d: %s\n", file, i.Line, i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printJSON(w io.Writer, file string, issues []Issue) error {
+	if issues == nil {
+		issues = []Issue{}
+	}
+	content, err := json.MarshalIndent(struct {
+		File   string  `json:"file"`
+		Issues []Issue `json:"issues"`
+	}{File: file, Issues: issues}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed serializing output to %s: %v", OutputJSON, err)
+	}
+
+	_, err = w.Write(append(content, '\n'))
+	return err
+}
@@ -19,6 +19,7 @@ type ValidationManager struct {
 	gitOpsFlux        *flux.Flux
 	createValidations Validator
 	dockerExec        validations.DockerExecutable
+	report            *validations.Report
 }
 
 type Validator interface {
@@ -35,50 +36,67 @@ func NewValidations(clusterSpec *cluster.Spec, provider providers.Provider, gitO
 	}
 }
 
+// WithReport records the result of each validation in report.
+func (v *ValidationManager) WithReport(report *validations.Report) *ValidationManager {
+	v.report = report
+	return v
+}
+
 func (v *ValidationManager) Validate(ctx context.Context) error {
-	runner := validations.NewRunner()
+	// The provider validation sets up the provider and the spec for the validations after it, so they run sequentially.
+	runner := validations.NewRunner(validations.WithReport(v.report), validations.WithConcurrency(1))
 	runner.Register(v.generateCreateValidations(ctx)...)
 	runner.Register(v.gitOpsFlux.Validations(ctx, v.clusterSpec)...)
-	err := runner.Run()
+	err := runner.Run(ctx)
 
 	return err
 }
 
 func (v *ValidationManager) generateCreateValidations(ctx context.Context) []validations.Validation {
 	vs := []validations.Validation{
-		func() *validations.ValidationResult {
-			return &validations.ValidationResult{
-				Name:   "validate docker executable",
-				Err:    validations.ValidateDockerExecutable(ctx, v.dockerExec, runtime.GOOS),
-				Silent: true,
-			}
+		validations.Validation{
+			Name: "validate docker executable",
+			Run: func(ctx context.Context) *validations.ValidationResult {
+				return &validations.ValidationResult{
+					Err:    validations.ValidateDockerExecutable(ctx, v.dockerExec, runtime.GOOS),
+					Silent: true,
+				}
+			},
 		},
-		func() *validations.ValidationResult {
-			return &validations.ValidationResult{
-				Name:   "validate kubeconfig path",
-				Err:    kubeconfig.ValidateKubeconfigPath(v.clusterSpec.Cluster.Name),
-				Silent: true,
-			}
+		validations.Validation{
+			Name: "validate kubeconfig path",
+			Run: func(ctx context.Context) *validations.ValidationResult {
+				return &validations.ValidationResult{
+					Err:    kubeconfig.ValidateKubeconfigPath(v.clusterSpec.Cluster.Name),
+					Silent: true,
+				}
+			},
 		},
-		func() *validations.ValidationResult {
-			return &validations.ValidationResult{
-				Name:   "validate cluster",
-				Err:    cluster.ValidateConfig(v.clusterSpec.Config),
-				Silent: true,
-			}
+		validations.Validation{
+			Name: "validate cluster",
+			Run: func(ctx context.Context) *validations.ValidationResult {
+				return &validations.ValidationResult{
+					Err:    cluster.ValidateConfig(v.clusterSpec.Config),
+					Silent: true,
+				}
+			},
 		},
-		func() *validations.ValidationResult {
-			return &validations.ValidationResult{
-				Name:   "validate supported provider",
-				Err:    validator.ValidateSupportedProviderCreate(v.provider),
-				Silent: true,
-			}
+		validations.Validation{
+			Name: "validate supported provider",
+			Run: func(ctx context.Context) *validations.ValidationResult {
+				return &validations.ValidationResult{
+					Err:    validator.ValidateSupportedProviderCreate(v.provider),
+					Silent: true,
+				}
+			},
 		},
-		func() *validations.ValidationResult {
-			return &validations.ValidationResult{
-				Name: fmt.Sprintf("validate %s Provider", v.provider.Name()),
-				Err:  v.provider.SetupAndValidateCreateCluster(ctx, v.clusterSpec),
-			}
+		validations.Validation{
+			Name: fmt.Sprintf("validate %s Provider", v.provider.Name()),
+			Run: func(ctx context.Context) *validations.ValidationResult {
+				return &validations.ValidationResult{
+					Err: v.provider.SetupAndValidateCreateCluster(ctx, v.clusterSpec),
+				}
+			},
 		},
 	}
 
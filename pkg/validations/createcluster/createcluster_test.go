@@ -115,11 +115,14 @@ func (c *createClusterValidationTest) expectBuildValidations() *validation {
 	v := &validation{}
 	c.createValidations.EXPECT().BuildValidations(c.ctx).Return(
 		[]validations.Validation{
-			func() *validations.ValidationResult {
-				v.run = true
-				return &validations.ValidationResult{
-					Err: nil,
-				}
+			{
+				Name: "test validation",
+				Run: func(context.Context) *validations.ValidationResult {
+					v.run = true
+					return &validations.ValidationResult{
+						Err: nil,
+					}
+				},
 			},
 		},
 	)
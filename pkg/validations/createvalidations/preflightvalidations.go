@@ -10,17 +10,18 @@ import (
 	"github.com/aws/eks-anywhere/pkg/validations"
 )
 
+const registryMirrorCertificateName = "validate certificate for registry mirror"
+
 func (v *CreateValidations) PreflightValidations(ctx context.Context) (err error) {
-	vs := v.BuildValidations(ctx)
-	results := make([]validations.ValidationResult, 0, len(vs))
-	for _, validation := range vs {
-		results = append(results, *validation())
-	}
+	runner := v.Opts.NewRunner()
+	runner.Register(v.BuildValidations(ctx)...)
 
-	return validations.ProcessValidationResults(results)
+	return validations.ProcessValidationResults(runner.Collect(ctx))
 }
 
-func (v *CreateValidations) BuildValidations(ctx context.Context) []validations.Validation {
+// BuildValidations returns the preflight validations for creating the cluster. They check the cluster with
+// the context the Runner runs them with, derived from the one the Runner is run with.
+func (v *CreateValidations) BuildValidations(_ context.Context) []validations.Validation {
 	k := v.Opts.Kubectl
 
 	targetCluster := &types.Cluster{
@@ -29,52 +30,75 @@ func (v *CreateValidations) BuildValidations(ctx context.Context) []validations.
 	}
 
 	createValidations := []validations.Validation{
-		func() *validations.ValidationResult {
-			return &validations.ValidationResult{
-				Name:        "validate certificate for registry mirror",
-				Remediation: fmt.Sprintf("provide a valid certificate for you registry endpoint using %s env var", anywherev1.RegistryMirrorCAKey),
-				Err:         validations.ValidateCertForRegistryMirror(v.Opts.Spec, v.Opts.TlsValidator),
-			}
-		},
-		func() *validations.ValidationResult {
-			return &validations.ValidationResult{
-				Name:        "validate authentication for git provider",
-				Remediation: fmt.Sprintf("ensure %s, %s env variable are set and valid", config.EksaGitPrivateKeyTokenEnv, config.EksaGitKnownHostsFileEnv),
-				Err:         validations.ValidateAuthenticationForGitProvider(v.Opts.Spec, v.Opts.CliConfig),
-			}
+		validations.Skippable(validations.RegistryMirrorCertificate, v.Opts.SkippedValidations,
+			validations.Validation{
+				Name: registryMirrorCertificateName,
+				Run: func(ctx context.Context) *validations.ValidationResult {
+					return &validations.ValidationResult{
+						Remediation: fmt.Sprintf("provide a valid certificate for you registry endpoint using %s env var", anywherev1.RegistryMirrorCAKey),
+						Err:         validations.ValidateCertForRegistryMirror(v.Opts.Spec, v.Opts.TlsValidator),
+					}
+				},
+			},
+		),
+		validations.Validation{
+			Name: "validate authentication for git provider",
+			Run: func(ctx context.Context) *validations.ValidationResult {
+				return &validations.ValidationResult{
+					Remediation: fmt.Sprintf("ensure %s, %s env variable are set and valid", config.EksaGitPrivateKeyTokenEnv, config.EksaGitKnownHostsFileEnv),
+					Err:         validations.ValidateAuthenticationForGitProvider(v.Opts.Spec, v.Opts.CliConfig),
+				}
+			},
 		},
 	}
 
 	if v.Opts.Spec.Cluster.IsManaged() {
 		createValidations = append(
 			createValidations,
-			func() *validations.ValidationResult {
-				return &validations.ValidationResult{
-					Name:        "validate cluster name",
-					Remediation: "",
-					Err:         ValidateClusterNameIsUnique(ctx, k, targetCluster, v.Opts.Spec.Cluster.Name),
-				}
+			validations.Validation{
+				Name: "validate cluster name",
+				Run: func(ctx context.Context) *validations.ValidationResult {
+					return &validations.ValidationResult{
+						Remediation: "",
+						Err:         ValidateClusterNameIsUnique(ctx, k, targetCluster, v.Opts.Spec.Cluster.Name),
+					}
+				},
 			},
-			func() *validations.ValidationResult {
-				return &validations.ValidationResult{
-					Name:        "validate gitops",
-					Remediation: "",
-					Err:         ValidateGitOps(ctx, k, v.Opts.ManagementCluster, v.Opts.Spec),
-				}
+			validations.Validation{
+				Name: "validate gitops",
+				Run: func(ctx context.Context) *validations.ValidationResult {
+					return &validations.ValidationResult{
+						Remediation: "",
+						Err:         ValidateGitOps(ctx, k, v.Opts.ManagementCluster, v.Opts.Spec),
+					}
+				},
 			},
-			func() *validations.ValidationResult {
-				return &validations.ValidationResult{
-					Name:        "validate identity providers' name",
-					Remediation: "",
-					Err:         ValidateIdentityProviderNameIsUnique(ctx, k, targetCluster, v.Opts.Spec),
-				}
+			validations.Validation{
+				Name: "validate identity providers' name",
+				Run: func(ctx context.Context) *validations.ValidationResult {
+					return &validations.ValidationResult{
+						Remediation: "",
+						Err:         ValidateIdentityProviderNameIsUnique(ctx, k, targetCluster, v.Opts.Spec),
+					}
+				},
 			},
-			func() *validations.ValidationResult {
-				return &validations.ValidationResult{
-					Name:        "validate management cluster has eksa crds",
-					Remediation: "",
-					Err:         ValidateManagementCluster(ctx, k, targetCluster),
-				}
+			validations.Validation{
+				Name: "validate management cluster has eksa crds",
+				Run: func(ctx context.Context) *validations.ValidationResult {
+					return &validations.ValidationResult{
+						Remediation: "",
+						Err:         ValidateManagementCluster(ctx, k, targetCluster),
+					}
+				},
+			},
+			validations.Validation{
+				Name: "validate cluster policies",
+				Run: func(ctx context.Context) *validations.ValidationResult {
+					return &validations.ValidationResult{
+						Remediation: validations.PoliciesRemediation,
+						Err:         validations.ValidatePolicies(ctx, k, v.Opts.ManagementCluster, v.Opts.Spec),
+					}
+				},
 			},
 		)
 	}
@@ -6,10 +6,14 @@ import (
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/policy"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/validations/createvalidations"
@@ -60,6 +64,37 @@ func TestPreFlightValidationsWorkloadCluster(t *testing.T) {
 	tt.k.EXPECT().GetClusters(tt.ctx, tt.c.Opts.WorkloadCluster).Return(nil, nil)
 	tt.k.EXPECT().ValidateClustersCRD(tt.ctx, tt.c.Opts.WorkloadCluster).Return(nil)
 	tt.k.EXPECT().ValidateEKSAClustersCRD(tt.ctx, tt.c.Opts.WorkloadCluster).Return(nil)
+	tt.expectPolicies(nil)
 
 	tt.Expect(tt.c.PreflightValidations(tt.ctx)).To(Succeed())
 }
+
+func TestPreFlightValidationsWorkloadClusterPolicyViolation(t *testing.T) {
+	tt := newPreflightValidationsTest(t)
+	tt.c.Opts.Spec.Cluster.SetManagedBy("mgmt-cluster")
+	tt.c.Opts.Spec.Cluster.Spec.ControlPlaneConfiguration.Count = 1
+
+	tt.k.EXPECT().GetClusters(tt.ctx, tt.c.Opts.WorkloadCluster).Return(nil, nil)
+	tt.k.EXPECT().ValidateClustersCRD(tt.ctx, tt.c.Opts.WorkloadCluster).Return(nil)
+	tt.k.EXPECT().ValidateEKSAClustersCRD(tt.ctx, tt.c.Opts.WorkloadCluster).Return(nil)
+	tt.expectPolicies(&corev1.ConfigMap{
+		Data: map[string]string{
+			policy.ConfigMapKey: `rules:
+- name: ha-control-plane
+  kinds: [Cluster]
+  expression: object.spec.controlPlaneConfiguration.count >= 3`,
+		},
+	})
+
+	tt.Expect(tt.c.PreflightValidations(tt.ctx)).To(MatchError(ContainSubstring("violates policy ha-control-plane")))
+}
+
+// expectPolicies sets up the policies ConfigMap of the management cluster. A nil cm means there are no policies.
+func (tt *preflightValidationsTest) expectPolicies(cm *corev1.ConfigMap) {
+	call := tt.k.EXPECT().GetObject(gomock.Any(), "configmap", policy.ConfigMapName, policy.ConfigMapNamespace, "kubeconfig", &corev1.ConfigMap{})
+	if cm == nil {
+		call.Return(apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, policy.ConfigMapName))
+		return
+	}
+	call.SetArg(5, *cm).Return(nil)
+}
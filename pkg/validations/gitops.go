@@ -17,6 +17,10 @@ func ValidateAuthenticationForGitProvider(clusterSpec *cluster.Spec, cliConfig *
 		return nil
 	}
 
+	if clusterSpec.FluxConfig.Spec.Git.IsHTTPS() {
+		return validateHTTPSAuthenticationForGitProvider(cliConfig)
+	}
+
 	if cliConfig.GitPrivateKeyFile == "" {
 		return errors.New("provide a path to a private key file via the EKSA_GIT_PRIVATE_KEY in order to use the generic git Flux provider")
 	}
@@ -35,3 +39,15 @@ func ValidateAuthenticationForGitProvider(clusterSpec *cluster.Spec, cliConfig *
 
 	return nil
 }
+
+func validateHTTPSAuthenticationForGitProvider(cliConfig *config.CliConfig) error {
+	if cliConfig.GitUsername == "" {
+		return fmt.Errorf("provide a username via the %s environment variable in order to use the generic git Flux provider with an https repository url", config.EksaGitUsernameEnv)
+	}
+
+	if cliConfig.GitPassword == "" {
+		return fmt.Errorf("provide a password or access token via the %s environment variable in order to use the generic git Flux provider with an https repository url", config.EksaGitPasswordEnv)
+	}
+
+	return nil
+}
@@ -122,6 +122,39 @@ func TestValidateGitOpsGitProviderNoAuthForWorkloadCluster(t *testing.T) {
 				GitKnownHostsFile:   "testdata/git_empty_file",
 			},
 		},
+		{
+			name:    "HTTPS with username and password",
+			wantErr: nil,
+			git: &v1alpha1.GitProviderConfig{
+				RepositoryUrl: "https://git.example.com/fleet/testRepo.git",
+			},
+			cliConfig: &config.CliConfig{
+				GitUsername: testEnvVar,
+				GitPassword: testEnvVar,
+			},
+		},
+		{
+			name:    "HTTPS empty username",
+			wantErr: fmt.Errorf("provide a username via the EKSA_GIT_USERNAME environment variable in order to use the generic git Flux provider with an https repository url"),
+			git: &v1alpha1.GitProviderConfig{
+				RepositoryUrl: "https://git.example.com/fleet/testRepo.git",
+			},
+			cliConfig: &config.CliConfig{
+				GitUsername: emptyVar,
+				GitPassword: testEnvVar,
+			},
+		},
+		{
+			name:    "HTTPS empty password",
+			wantErr: fmt.Errorf("provide a password or access token via the EKSA_GIT_PASSWORD environment variable in order to use the generic git Flux provider with an https repository url"),
+			git: &v1alpha1.GitProviderConfig{
+				RepositoryUrl: "https://git.example.com/fleet/testRepo.git",
+			},
+			cliConfig: &config.CliConfig{
+				GitUsername: testEnvVar,
+				GitPassword: emptyVar,
+			},
+		},
 		{
 			name:    "No known hosts",
 			wantErr: fmt.Errorf("SSH known hosts file does not exist at testdata/git_empty_file or is empty"),
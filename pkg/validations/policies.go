@@ -0,0 +1,81 @@
+package validations
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/policy"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// PoliciesRemediation is the remediation for clusters that don't follow the management cluster policies.
+var PoliciesRemediation = fmt.Sprintf("update the cluster config to follow the policies in configmap %s/%s of the management cluster", policy.ConfigMapNamespace, policy.ConfigMapName)
+
+// ValidatePolicies checks the cluster and machine configs in spec against the policies configured
+// in the management cluster, the same way its webhooks do. If the management cluster doesn't have
+// a policies ConfigMap, there's nothing to check.
+func ValidatePolicies(ctx context.Context, k KubectlClient, managementCluster *types.Cluster, spec *cluster.Spec) error {
+	cm := &corev1.ConfigMap{}
+	err := k.GetObject(ctx, "configmap", policy.ConfigMapName, policy.ConfigMapNamespace, managementCluster.KubeconfigFile, cm)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading policies: %v", err)
+	}
+
+	evaluator, err := policy.NewConfigMapEvaluator(cm)
+	if err != nil {
+		return err
+	}
+
+	return evaluator.Validate(policyObjects(spec)...)
+}
+
+// policyObjects returns the objects in spec the policies apply to: the Cluster, its datacenter config
+// and its machine configs. The machine configs of each kind are sorted by name so violations are always
+// reported in the same order.
+func policyObjects(spec *cluster.Spec) []runtime.Object {
+	objs := []runtime.Object{spec.Cluster}
+
+	datacenterConfigs := []client.Object{
+		spec.VSphereDatacenter,
+		spec.CloudStackDatacenter,
+		spec.SnowDatacenter,
+		spec.NutanixDatacenter,
+		spec.TinkerbellDatacenter,
+		spec.DockerDatacenter,
+	}
+	for _, d := range datacenterConfigs {
+		// The datacenter configs of the other providers are typed nil pointers.
+		if !reflect.ValueOf(d).IsNil() {
+			objs = append(objs, d)
+		}
+	}
+
+	objs = appendSortedByName(objs, spec.VSphereMachineConfigs)
+	objs = appendSortedByName(objs, spec.CloudStackMachineConfigs)
+	objs = appendSortedByName(objs, spec.SnowMachineConfigs)
+	objs = appendSortedByName(objs, spec.NutanixMachineConfigs)
+	return appendSortedByName(objs, spec.TinkerbellMachineConfigs)
+}
+
+func appendSortedByName[O runtime.Object](objs []runtime.Object, add map[string]O) []runtime.Object {
+	names := make([]string, 0, len(add))
+	for name := range add {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		objs = append(objs, add[name])
+	}
+	return objs
+}
@@ -0,0 +1,127 @@
+package validations_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/policy"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/validations/mocks"
+)
+
+type policiesTest struct {
+	*WithT
+	ctx               context.Context
+	k                 *mocks.MockKubectlClient
+	managementCluster *types.Cluster
+	spec              *cluster.Spec
+}
+
+func newPoliciesTest(t *testing.T) *policiesTest {
+	return &policiesTest{
+		WithT:             NewWithT(t),
+		ctx:               context.Background(),
+		k:                 mocks.NewMockKubectlClient(gomock.NewController(t)),
+		managementCluster: &types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"},
+		spec: test.NewClusterSpec(func(s *cluster.Spec) {
+			s.Cluster.Name = "workload"
+			s.Cluster.Spec.ControlPlaneConfiguration.Count = 3
+		}),
+	}
+}
+
+func (tt *policiesTest) expectGetPolicies(rules string, err error) {
+	call := tt.k.EXPECT().GetObject(tt.ctx, "configmap", policy.ConfigMapName, policy.ConfigMapNamespace, "mgmt.kubeconfig", &corev1.ConfigMap{})
+	if err != nil {
+		call.Return(err)
+		return
+	}
+	call.SetArg(5, corev1.ConfigMap{Data: map[string]string{policy.ConfigMapKey: rules}}).Return(nil)
+}
+
+func (tt *policiesTest) validate() error {
+	return validations.ValidatePolicies(tt.ctx, tt.k, tt.managementCluster, tt.spec)
+}
+
+func TestValidatePoliciesNoConfigMap(t *testing.T) {
+	tt := newPoliciesTest(t)
+	tt.expectGetPolicies("", apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, policy.ConfigMapName))
+
+	tt.Expect(tt.validate()).To(Succeed())
+}
+
+func TestValidatePoliciesErrorReadingConfigMap(t *testing.T) {
+	tt := newPoliciesTest(t)
+	tt.expectGetPolicies("", errors.New("connection refused"))
+
+	tt.Expect(tt.validate()).To(MatchError("reading policies: connection refused"))
+}
+
+func TestValidatePoliciesInvalidRule(t *testing.T) {
+	tt := newPoliciesTest(t)
+	tt.expectGetPolicies(`rules:
+- name: broken
+  expression: object.spec.(`, nil)
+
+	tt.Expect(tt.validate()).To(MatchError(ContainSubstring("compiling policy broken")))
+}
+
+func TestValidatePoliciesClusterPasses(t *testing.T) {
+	tt := newPoliciesTest(t)
+	tt.expectGetPolicies(`rules:
+- name: ha-control-plane
+  kinds: [Cluster]
+  expression: object.spec.controlPlaneConfiguration.count >= 3`, nil)
+
+	tt.Expect(tt.validate()).To(Succeed())
+}
+
+func TestValidatePoliciesDatacenterConfigViolation(t *testing.T) {
+	tt := newPoliciesTest(t)
+	tt.spec.VSphereDatacenter = &v1alpha1.VSphereDatacenterConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "vsphere"},
+		Spec:       v1alpha1.VSphereDatacenterConfigSpec{Insecure: true},
+	}
+	tt.expectGetPolicies(`rules:
+- name: no-insecure
+  kinds: [VSphereDatacenterConfig]
+  expression: "!has(object.spec.insecure) || !object.spec.insecure"
+  message: TLS verification can't be disabled`, nil)
+
+	tt.Expect(tt.validate()).To(MatchError("VSphereDatacenterConfig vsphere violates policy no-insecure: TLS verification can't be disabled"))
+}
+
+func TestValidatePoliciesMachineConfigsViolationsSortedByName(t *testing.T) {
+	tt := newPoliciesTest(t)
+	tt.spec.NutanixMachineConfigs = map[string]*v1alpha1.NutanixMachineConfig{
+		"worker": {ObjectMeta: metav1.ObjectMeta{Name: "worker"}, Spec: v1alpha1.NutanixMachineConfigSpec{OSFamily: v1alpha1.Bottlerocket}},
+		"cp":     {ObjectMeta: metav1.ObjectMeta{Name: "cp"}, Spec: v1alpha1.NutanixMachineConfigSpec{OSFamily: v1alpha1.Bottlerocket}},
+	}
+	tt.spec.TinkerbellMachineConfigs = map[string]*v1alpha1.TinkerbellMachineConfig{
+		"tink": {ObjectMeta: metav1.ObjectMeta{Name: "tink"}, Spec: v1alpha1.TinkerbellMachineConfigSpec{OSFamily: v1alpha1.Ubuntu}},
+	}
+	tt.expectGetPolicies(`rules:
+- name: ubuntu-only
+  kinds: [NutanixMachineConfig, TinkerbellMachineConfig]
+  expression: object.spec.osFamily == "ubuntu"`, nil)
+
+	err := tt.validate()
+	tt.Expect(err).To(HaveOccurred())
+	violations := &policy.ViolationsError{}
+	tt.Expect(errors.As(err, &violations)).To(BeTrue())
+	tt.Expect(violations.Violations).To(HaveLen(2))
+	tt.Expect(violations.Violations[0].Name).To(Equal("cp"))
+	tt.Expect(violations.Violations[1].Name).To(Equal("worker"))
+}
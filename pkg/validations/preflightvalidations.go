@@ -5,8 +5,8 @@ func ProcessValidationResults(validations []ValidationResult) error {
 	for _, validation := range validations {
 		if validation.Err != nil {
 			errs = append(errs, validation.Err.Error())
-		} else if !validation.Silent {
-			validation.LogPass()
+		} else {
+			validation.Report()
 		}
 	}
 
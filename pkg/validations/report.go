@@ -0,0 +1,220 @@
+package validations
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Status is the outcome of a validation.
+type Status string
+
+// Validation statuses.
+const (
+	StatusPassed  Status = "passed"
+	StatusFailed  Status = "failed"
+	StatusSkipped Status = "skipped"
+)
+
+// Formats supported by Report.Write.
+const (
+	ReportFormatJSON  = "json"
+	ReportFormatJUnit = "junit"
+)
+
+// Result is the record of a validation run.
+type Result struct {
+	Name        string
+	Status      Status
+	Error       string
+	Remediation string
+	Duration    time.Duration
+	// Provider is the infrastructure provider of the cluster the validation ran for.
+	Provider string
+}
+
+type jsonResult struct {
+	Name            string  `json:"name"`
+	Status          Status  `json:"status"`
+	Error           string  `json:"error,omitempty"`
+	Remediation     string  `json:"remediation,omitempty"`
+	DurationSeconds float64 `json:"durationSeconds"`
+	Provider        string  `json:"provider,omitempty"`
+}
+
+// MarshalJSON serializes the result with its duration in seconds.
+func (r Result) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonResult{
+		Name:            r.Name,
+		Status:          r.Status,
+		Error:           r.Error,
+		Remediation:     r.Remediation,
+		DurationSeconds: r.Duration.Seconds(),
+		Provider:        r.Provider,
+	})
+}
+
+// Report collects the results of the validations run for a command, so they can be written
+// in a machine-readable format. It's safe for concurrent use.
+type Report struct {
+	provider string
+
+	mu      sync.Mutex
+	results []Result
+}
+
+// NewReport returns an empty report for the validations of a cluster with the given provider.
+func NewReport(provider string) *Report {
+	return &Report{provider: provider}
+}
+
+// Add records the result of a validation.
+func (r *Report) Add(result Result) {
+	if result.Provider == "" {
+		result.Provider = r.provider
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.results = append(r.results, result)
+}
+
+// Results returns the recorded results in the order they were added.
+func (r *Report) Results() []Result {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Result(nil), r.results...)
+}
+
+// Failed returns true if any of the recorded validations failed.
+func (r *Report) Failed() bool {
+	for _, result := range r.Results() {
+		if result.Status == StatusFailed {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateReportFormat returns an error if format is not supported by Report.Write.
+func ValidateReportFormat(format string) error {
+	switch format {
+	case ReportFormatJSON, ReportFormatJUnit:
+		return nil
+	default:
+		return fmt.Errorf("invalid validation report format [%s], valid options are: %s, %s", format, ReportFormatJSON, ReportFormatJUnit)
+	}
+}
+
+// Write writes the report to w in the given format.
+func (r *Report) Write(w io.Writer, format string) error {
+	switch format {
+	case ReportFormatJSON:
+		return r.writeJSON(w)
+	case ReportFormatJUnit:
+		return r.writeJUnit(w)
+	default:
+		return ValidateReportFormat(format)
+	}
+}
+
+// WriteFile writes the report to the file at path in the given format.
+func (r *Report) WriteFile(path, format string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating validation report file: %v", err)
+	}
+
+	if err = r.Write(f, format); err != nil {
+		f.Close()
+		return fmt.Errorf("writing validation report: %v", err)
+	}
+	if err = f.Close(); err != nil {
+		return fmt.Errorf("closing validation report file: %v", err)
+	}
+	return nil
+}
+
+func (r *Report) writeJSON(w io.Writer) error {
+	results := r.Results()
+	if results == nil {
+		results = []Result{}
+	}
+	content, err := json.MarshalIndent(struct {
+		Results []Result `json:"results"`
+	}{Results: results}, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(content, '\n'))
+	return err
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *struct{}     `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Content string `xml:",chardata"`
+}
+
+func (r *Report) writeJUnit(w io.Writer) error {
+	suite := junitTestSuite{Name: "eksa-validations"}
+	var total time.Duration
+	for _, result := range r.Results() {
+		c := junitTestCase{
+			Name:      result.Name,
+			Classname: result.Provider,
+			Time:      junitSeconds(result.Duration),
+		}
+		switch result.Status {
+		case StatusFailed:
+			suite.Failures++
+			c.Failure = &junitFailure{Message: result.Error, Content: result.Remediation}
+		case StatusSkipped:
+			suite.Skipped++
+			c.Skipped = &struct{}{}
+		}
+		total += result.Duration
+		suite.Cases = append(suite.Cases, c)
+	}
+	suite.Tests = len(suite.Cases)
+	suite.Time = junitSeconds(total)
+
+	content, err := xml.MarshalIndent(junitTestSuites{Suites: []junitTestSuite{suite}}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err = io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	_, err = w.Write(append(content, '\n'))
+	return err
+}
+
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -0,0 +1,102 @@
+package validations_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+func newTestReport() *validations.Report {
+	report := validations.NewReport("docker")
+	report.Add(validations.Result{Name: "validate certificate", Status: validations.StatusPassed, Duration: 1500 * time.Millisecond})
+	report.Add(validations.Result{
+		Name:        "validate kubernetes version",
+		Status:      validations.StatusFailed,
+		Error:       "unsupported version",
+		Remediation: "use a supported version",
+		Duration:    250 * time.Millisecond,
+	})
+	report.Add(validations.Result{Name: "nodes ready", Status: validations.StatusSkipped, Provider: "vsphere"})
+	return report
+}
+
+func TestReportWriteJSON(t *testing.T) {
+	g := NewWithT(t)
+	buf := &bytes.Buffer{}
+
+	g.Expect(newTestReport().Write(buf, validations.ReportFormatJSON)).To(Succeed())
+	g.Expect(buf.String()).To(MatchJSON(`{
+		"results": [
+			{"name": "validate certificate", "status": "passed", "durationSeconds": 1.5, "provider": "docker"},
+			{"name": "validate kubernetes version", "status": "failed", "error": "unsupported version", "remediation": "use a supported version", "durationSeconds": 0.25, "provider": "docker"},
+			{"name": "nodes ready", "status": "skipped", "durationSeconds": 0, "provider": "vsphere"}
+		]
+	}`))
+}
+
+func TestReportWriteJSONEmpty(t *testing.T) {
+	g := NewWithT(t)
+	buf := &bytes.Buffer{}
+
+	g.Expect(validations.NewReport("docker").Write(buf, validations.ReportFormatJSON)).To(Succeed())
+	g.Expect(buf.String()).To(MatchJSON(`{"results": []}`))
+}
+
+func TestReportWriteJUnit(t *testing.T) {
+	g := NewWithT(t)
+	buf := &bytes.Buffer{}
+
+	g.Expect(newTestReport().Write(buf, validations.ReportFormatJUnit)).To(Succeed())
+	g.Expect(buf.String()).To(Equal(`<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="eksa-validations" tests="3" failures="1" skipped="1" time="1.750">
+    <testcase name="validate certificate" classname="docker" time="1.500"></testcase>
+    <testcase name="validate kubernetes version" classname="docker" time="0.250">
+      <failure message="unsupported version">use a supported version</failure>
+    </testcase>
+    <testcase name="nodes ready" classname="vsphere" time="0.000">
+      <skipped></skipped>
+    </testcase>
+  </testsuite>
+</testsuites>
+`))
+}
+
+func TestReportWriteInvalidFormat(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(newTestReport().Write(&bytes.Buffer{}, "yaml")).To(MatchError(ContainSubstring("invalid validation report format [yaml]")))
+}
+
+func TestReportWriteFile(t *testing.T) {
+	g := NewWithT(t)
+	path := filepath.Join(t.TempDir(), "report.json")
+
+	g.Expect(newTestReport().WriteFile(path, validations.ReportFormatJSON)).To(Succeed())
+	content, err := os.ReadFile(path)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(content)).To(ContainSubstring(`"name": "validate kubernetes version"`))
+}
+
+func TestReportFailed(t *testing.T) {
+	g := NewWithT(t)
+	report := validations.NewReport("docker")
+	report.Add(validations.Result{Name: "passing", Status: validations.StatusPassed})
+	report.Add(validations.Result{Name: "skipped", Status: validations.StatusSkipped})
+	g.Expect(report.Failed()).To(BeFalse())
+
+	report.Add(validations.Result{Name: "failing", Status: validations.StatusFailed})
+	g.Expect(report.Failed()).To(BeTrue())
+}
+
+func TestValidateReportFormat(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(validations.ValidateReportFormat(validations.ReportFormatJSON)).To(Succeed())
+	g.Expect(validations.ValidateReportFormat(validations.ReportFormatJUnit)).To(Succeed())
+	g.Expect(validations.ValidateReportFormat("xml")).NotTo(Succeed())
+}
@@ -1,32 +1,90 @@
 package validations
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
 
 var errRunnerValidation = errors.New("validations failed")
 
-type Validation func() *ValidationResult
+// DefaultConcurrency is the number of validations a Runner runs in parallel unless configured otherwise.
+// Validations run sequentially by default, since many of them share clients or set up the spec for the
+// ones after them, and a Runner only runs them in parallel when configured WithConcurrency.
+const DefaultConcurrency = 1
+
+// Validation is a check run by a Runner.
+type Validation struct {
+	// Name identifies the validation. It's used as the name of its result when the result doesn't
+	// set one, or when the validation times out or panics before returning it.
+	Name string
+	// Run performs the check. ctx is derived from the one the Runner is run with, and it's also
+	// cancelled when the validation times out.
+	Run func(ctx context.Context) *ValidationResult
+}
 
 type Runner struct {
 	validations []Validation
+	report      *Report
+	concurrency int
+	timeout     time.Duration
+}
+
+// RunnerOpt configures a Runner.
+type RunnerOpt func(*Runner)
+
+// WithReport makes the Runner record the result of each validation in report.
+// A nil report is ignored.
+func WithReport(report *Report) RunnerOpt {
+	return func(r *Runner) {
+		r.report = report
+	}
 }
 
-func NewRunner() *Runner {
-	return &Runner{validations: make([]Validation, 0)}
+// WithConcurrency makes the Runner run up to n validations in parallel. Values lower than 1
+// keep DefaultConcurrency, and 1 runs the validations sequentially.
+func WithConcurrency(n int) RunnerOpt {
+	return func(r *Runner) {
+		if n >= 1 {
+			r.concurrency = n
+		}
+	}
+}
+
+// WithValidationTimeout makes the Runner fail any validation that doesn't complete within timeout.
+// A zero timeout, the default, waits for validations indefinitely.
+func WithValidationTimeout(timeout time.Duration) RunnerOpt {
+	return func(r *Runner) {
+		r.timeout = timeout
+	}
+}
+
+func NewRunner(opts ...RunnerOpt) *Runner {
+	r := &Runner{
+		validations: make([]Validation, 0),
+		concurrency: DefaultConcurrency,
+	}
+	for _, o := range opts {
+		o(r)
+	}
+	return r
 }
 
 func (r *Runner) Register(validations ...Validation) {
 	r.validations = append(r.validations, validations...)
 }
 
-func (r *Runner) Run() error {
+// Run runs the registered validations and logs their results in the order they were registered,
+// regardless of the order they complete in.
+func (r *Runner) Run(ctx context.Context) error {
 	failed := false
-	for _, v := range r.validations {
-		result := v()
+	r.runAll(ctx, func(result *ValidationResult) {
 		result.Report()
 		if result.Err != nil {
 			failed = true
 		}
-	}
+	})
 
 	if failed {
 		return errRunnerValidation
@@ -34,3 +92,117 @@ func (r *Runner) Run() error {
 
 	return nil
 }
+
+// Collect runs the registered validations and returns their results, in the order the validations
+// were registered, without logging them.
+func (r *Runner) Collect(ctx context.Context) []ValidationResult {
+	results := make([]ValidationResult, 0, len(r.validations))
+	r.runAll(ctx, func(result *ValidationResult) {
+		results = append(results, *result)
+	})
+	return results
+}
+
+// FirstError runs the registered validations and returns the error of the first one that failed,
+// in the order they were registered, without logging the results. It's meant for checks that run
+// in parallel but fail with a single error, like the ones of each availability zone of a provider.
+func (r *Runner) FirstError(ctx context.Context) error {
+	var err error
+	r.runAll(ctx, func(result *ValidationResult) {
+		if err == nil {
+			err = result.Err
+		}
+	})
+	return err
+}
+
+type timedResult struct {
+	result   *ValidationResult
+	duration time.Duration
+}
+
+// runAll runs the validations with up to r.concurrency of them in parallel, starting them in the
+// order they were registered. handle is called with each result in that same order, as soon as the
+// result and all previous ones are available.
+func (r *Runner) runAll(ctx context.Context, handle func(*ValidationResult)) {
+	done := make([]chan timedResult, len(r.validations))
+	for i := range done {
+		done[i] = make(chan timedResult, 1)
+	}
+
+	go func() {
+		sem := make(chan struct{}, r.concurrency)
+		for i, v := range r.validations {
+			sem <- struct{}{}
+			go func(i int, v Validation) {
+				defer func() { <-sem }()
+				done[i] <- r.run(ctx, v)
+			}(i, v)
+		}
+	}()
+
+	for _, d := range done {
+		t := <-d
+		if r.report != nil {
+			r.report.Add(t.result.record(t.duration))
+		}
+		handle(t.result)
+	}
+}
+
+func (r *Runner) run(ctx context.Context, v Validation) timedResult {
+	start := time.Now()
+	result := r.runWithTimeout(ctx, v)
+	if result.Name == "" {
+		result.Name = v.Name
+	}
+	return timedResult{result: result, duration: time.Since(start)}
+}
+
+// runWithTimeout runs v with a context derived from parent, cancelled after r.timeout if set. It returns
+// a failed result without waiting for v if parent is done or v times out.
+func (r *Runner) runWithTimeout(parent context.Context, v Validation) *ValidationResult {
+	ctx := parent
+	var timeout <-chan struct{}
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(parent, r.timeout)
+		defer cancel()
+		timeout = ctx.Done()
+	}
+
+	c := make(chan *ValidationResult, 1)
+	go runSafely(ctx, v, c)
+
+	select {
+	case result := <-c:
+		return result
+	case <-parent.Done():
+		return &ValidationResult{Name: v.Name, Err: fmt.Errorf("validation cancelled: %v", parent.Err())}
+	case <-timeout:
+		if parent.Err() != nil {
+			return &ValidationResult{Name: v.Name, Err: fmt.Errorf("validation cancelled: %v", parent.Err())}
+		}
+		return &ValidationResult{
+			Name:        v.Name,
+			Err:         fmt.Errorf("validation timed out after %s", r.timeout),
+			Remediation: "check the connectivity to the cluster and infrastructure endpoints, or increase --validation-timeout",
+		}
+	}
+}
+
+// runSafely runs v and sends its result to c. If v panics or ends its goroutine without returning,
+// for example through runtime.Goexit, a failed result is sent instead, so c always gets a result.
+func runSafely(ctx context.Context, v Validation, c chan<- *ValidationResult) {
+	var result *ValidationResult
+	defer func() {
+		if r := recover(); r != nil {
+			result = &ValidationResult{Name: v.Name, Err: fmt.Errorf("validation panicked: %v", r)}
+		} else if result == nil {
+			result = &ValidationResult{Name: v.Name, Err: errors.New("validation didn't return a result")}
+		}
+		c <- result
+	}()
+
+	result = v.Run(ctx)
+}
@@ -1,29 +1,44 @@
 package validations_test
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"runtime"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 
 	"github.com/aws/eks-anywhere/pkg/validations"
 )
 
+func validation(name string, run func() *validations.ValidationResult) validations.Validation {
+	return validations.Validation{
+		Name: name,
+		Run: func(context.Context) *validations.ValidationResult {
+			return run()
+		},
+	}
+}
+
 func TestRunnerRunError(t *testing.T) {
 	g := NewWithT(t)
 	r := validations.NewRunner()
-	r.Register(func() *validations.ValidationResult {
+	r.Register(validation("passing", func() *validations.ValidationResult {
 		return &validations.ValidationResult{
 			Err: nil,
 		}
-	})
-	r.Register(func() *validations.ValidationResult {
+	}))
+	r.Register(validation("failing", func() *validations.ValidationResult {
 		return &validations.ValidationResult{
 			Err: errors.New("failed"),
 		}
-	})
+	}))
 
-	err := r.Run()
+	err := r.Run(context.Background())
 	g.Expect(err).NotTo(BeNil())
 	g.Expect(err.Error()).To(Equal("validations failed"))
 }
@@ -31,16 +46,251 @@ func TestRunnerRunError(t *testing.T) {
 func TestRunnerRunSuccess(t *testing.T) {
 	g := NewWithT(t)
 	r := validations.NewRunner()
-	r.Register(func() *validations.ValidationResult {
+	r.Register(validation("first", func() *validations.ValidationResult {
 		return &validations.ValidationResult{
 			Err: nil,
 		}
-	})
-	r.Register(func() *validations.ValidationResult {
+	}))
+	r.Register(validation("second", func() *validations.ValidationResult {
 		return &validations.ValidationResult{
 			Err: nil,
 		}
+	}))
+
+	g.Expect(r.Run(context.Background())).To(Succeed())
+}
+
+func TestRunnerRunWithReport(t *testing.T) {
+	g := NewWithT(t)
+	report := validations.NewReport("vsphere")
+	r := validations.NewRunner(validations.WithReport(report))
+	r.Register(
+		validation("passing", func() *validations.ValidationResult {
+			return &validations.ValidationResult{}
+		}),
+		validation("failing", func() *validations.ValidationResult {
+			return &validations.ValidationResult{Err: errors.New("failed"), Remediation: "fix it"}
+		}),
+		validation("skipped", func() *validations.ValidationResult {
+			return &validations.ValidationResult{Skipped: true}
+		}),
+	)
+
+	g.Expect(r.Run(context.Background())).To(MatchError("validations failed"))
+
+	results := report.Results()
+	g.Expect(results).To(HaveLen(3))
+	g.Expect(results[0]).To(MatchFields(IgnoreExtras, Fields{
+		"Name": Equal("passing"), "Status": Equal(validations.StatusPassed), "Provider": Equal("vsphere"),
+	}))
+	g.Expect(results[1]).To(MatchFields(IgnoreExtras, Fields{
+		"Name": Equal("failing"), "Status": Equal(validations.StatusFailed), "Error": Equal("failed"), "Remediation": Equal("fix it"),
+	}))
+	g.Expect(results[2]).To(MatchFields(IgnoreExtras, Fields{
+		"Name": Equal("skipped"), "Status": Equal(validations.StatusSkipped),
+	}))
+	g.Expect(report.Failed()).To(BeTrue())
+}
+
+func TestRunnerCollect(t *testing.T) {
+	g := NewWithT(t)
+	r := validations.NewRunner(validations.WithReport(nil))
+	r.Register(
+		validation("first", func() *validations.ValidationResult {
+			return &validations.ValidationResult{}
+		}),
+		validation("second", func() *validations.ValidationResult {
+			return &validations.ValidationResult{Err: errors.New("failed")}
+		}),
+	)
+
+	results := r.Collect(context.Background())
+	g.Expect(results).To(HaveLen(2))
+	g.Expect(results[0].Name).To(Equal("first"))
+	g.Expect(results[1].Err).To(MatchError("failed"))
+}
+
+func TestRunnerCollectKeepsResultName(t *testing.T) {
+	g := NewWithT(t)
+	r := validations.NewRunner()
+	r.Register(validation("validation", func() *validations.ValidationResult {
+		return &validations.ValidationResult{Name: "result"}
+	}))
+
+	results := r.Collect(context.Background())
+	g.Expect(results).To(HaveLen(1))
+	g.Expect(results[0].Name).To(Equal("result"))
+}
+
+func TestRunnerCollectConcurrentKeepsOrder(t *testing.T) {
+	g := NewWithT(t)
+	r := validations.NewRunner(validations.WithConcurrency(3))
+	for i, delay := range []time.Duration{30 * time.Millisecond, 0, 10 * time.Millisecond} {
+		delay := delay
+		r.Register(validation(fmt.Sprintf("validation %d", i), func() *validations.ValidationResult {
+			time.Sleep(delay)
+			return &validations.ValidationResult{}
+		}))
+	}
+
+	results := r.Collect(context.Background())
+	g.Expect(results).To(HaveLen(3))
+	for i, result := range results {
+		g.Expect(result.Name).To(Equal(fmt.Sprintf("validation %d", i)))
+	}
+}
+
+func runConcurrent(r *validations.Runner, n int) (maxRunning int32, err error) {
+	var running int32
+	for i := 0; i < n; i++ {
+		r.Register(validation(fmt.Sprintf("validation %d", i), func() *validations.ValidationResult {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				m := atomic.LoadInt32(&maxRunning)
+				if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return &validations.ValidationResult{Silent: true}
+		}))
+	}
+
+	err = r.Run(context.Background())
+	return atomic.LoadInt32(&maxRunning), err
+}
+
+func TestRunnerRunConcurrencyLimit(t *testing.T) {
+	g := NewWithT(t)
+	maxRunning, err := runConcurrent(validations.NewRunner(validations.WithConcurrency(2)), 6)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(maxRunning).To(BeEquivalentTo(2))
+}
+
+func TestRunnerRunDefaultConcurrency(t *testing.T) {
+	g := NewWithT(t)
+	maxRunning, err := runConcurrent(validations.NewRunner(), 4)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(maxRunning).To(BeEquivalentTo(1))
+}
+
+func TestRunnerRunSequential(t *testing.T) {
+	g := NewWithT(t)
+	var order []int
+	r := validations.NewRunner(validations.WithConcurrency(1))
+	for i := 0; i < 5; i++ {
+		i := i
+		r.Register(validation(fmt.Sprintf("validation %d", i), func() *validations.ValidationResult {
+			order = append(order, i)
+			return &validations.ValidationResult{Silent: true}
+		}))
+	}
+
+	g.Expect(r.Run(context.Background())).To(Succeed())
+	g.Expect(order).To(Equal([]int{0, 1, 2, 3, 4}))
+}
+
+func TestRunnerRunValidationTimeout(t *testing.T) {
+	g := NewWithT(t)
+	report := validations.NewReport("vsphere")
+	r := validations.NewRunner(
+		validations.WithValidationTimeout(10*time.Millisecond),
+		validations.WithReport(report),
+	)
+	cancelled := make(chan struct{})
+	r.Register(
+		validation("fast", func() *validations.ValidationResult {
+			return &validations.ValidationResult{}
+		}),
+		validations.Validation{
+			Name: "slow",
+			Run: func(ctx context.Context) *validations.ValidationResult {
+				<-ctx.Done()
+				close(cancelled)
+				return &validations.ValidationResult{Err: ctx.Err()}
+			},
+		},
+	)
+
+	g.Expect(r.Run(context.Background())).To(MatchError("validations failed"))
+	g.Eventually(cancelled).Should(BeClosed())
+
+	results := report.Results()
+	g.Expect(results).To(HaveLen(2))
+	g.Expect(results[0].Status).To(Equal(validations.StatusPassed))
+	g.Expect(results[1]).To(MatchFields(IgnoreExtras, Fields{
+		"Name":   Equal("slow"),
+		"Status": Equal(validations.StatusFailed),
+		"Error":  Equal("validation timed out after 10ms"),
+	}))
+}
+
+func TestRunnerCollectValidationPanics(t *testing.T) {
+	g := NewWithT(t)
+	r := validations.NewRunner()
+	r.Register(
+		validation("panics", func() *validations.ValidationResult {
+			panic("boom")
+		}),
+		validation("passing", func() *validations.ValidationResult {
+			return &validations.ValidationResult{}
+		}),
+	)
+
+	results := r.Collect(context.Background())
+	g.Expect(results).To(HaveLen(2))
+	g.Expect(results[0].Name).To(Equal("panics"))
+	g.Expect(results[0].Err).To(MatchError("validation panicked: boom"))
+	g.Expect(results[1].Err).NotTo(HaveOccurred())
+}
+
+func TestRunnerCollectValidationExitsGoroutine(t *testing.T) {
+	g := NewWithT(t)
+	r := validations.NewRunner(validations.WithValidationTimeout(time.Minute))
+	r.Register(validation("exits", func() *validations.ValidationResult {
+		runtime.Goexit()
+		return nil
+	}))
+
+	results := r.Collect(context.Background())
+	g.Expect(results).To(HaveLen(1))
+	g.Expect(results[0].Name).To(Equal("exits"))
+	g.Expect(results[0].Err).To(MatchError("validation didn't return a result"))
+}
+
+type contextKey struct{}
+
+func TestRunnerCollectPassesContext(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.WithValue(context.Background(), contextKey{}, "value")
+	r := validations.NewRunner(validations.WithValidationTimeout(time.Minute))
+	r.Register(validations.Validation{
+		Name: "reads context",
+		Run: func(ctx context.Context) *validations.ValidationResult {
+			if ctx.Value(contextKey{}) != "value" {
+				return &validations.ValidationResult{Err: errors.New("context value missing")}
+			}
+			return &validations.ValidationResult{}
+		},
 	})
 
-	g.Expect(r.Run()).To(Succeed())
+	results := r.Collect(ctx)
+	g.Expect(results).To(HaveLen(1))
+	g.Expect(results[0].Err).NotTo(HaveOccurred())
+}
+
+func TestRunnerFirstErrorCancelledContext(t *testing.T) {
+	g := NewWithT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	release := make(chan struct{})
+	defer close(release)
+	r := validations.NewRunner()
+	r.Register(validation("blocks", func() *validations.ValidationResult {
+		<-release
+		return &validations.ValidationResult{}
+	}))
+
+	cancel()
+	g.Expect(r.FirstError(ctx)).To(MatchError("validation cancelled: context canceled"))
 }
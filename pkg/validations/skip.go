@@ -0,0 +1,57 @@
+package validations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// IDs of the non-critical validations that can be skipped with --skip-validations.
+const (
+	RegistryMirrorCertificate = "registry-mirror-certificate"
+	NodesReady                = "nodes-ready"
+	WorkerNodesReady          = "worker-nodes-ready"
+)
+
+var skippableValidations = []string{
+	RegistryMirrorCertificate,
+	NodesReady,
+	WorkerNodesReady,
+}
+
+// SkippableValidations returns the IDs of the validations that can be skipped.
+func SkippableValidations() []string {
+	return append([]string(nil), skippableValidations...)
+}
+
+// ValidateSkippedValidations returns the set of validation IDs to skip, or an error if any of
+// them is not a skippable validation.
+func ValidateSkippedValidations(ids []string) (map[string]bool, error) {
+	skippable := make(map[string]bool, len(skippableValidations))
+	for _, id := range skippableValidations {
+		skippable[id] = true
+	}
+
+	skipped := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if !skippable[id] {
+			return nil, fmt.Errorf("invalid validation %s to skip, validations that can be skipped are: %s", id, strings.Join(skippableValidations, ", "))
+		}
+		skipped[id] = true
+	}
+	return skipped, nil
+}
+
+// Skippable returns v unless the validation id is in skipped, in which case it returns a
+// validation that reports v as skipped without running it.
+func Skippable(id string, skipped map[string]bool, v Validation) Validation {
+	if !skipped[id] {
+		return v
+	}
+	return Validation{
+		Name: v.Name,
+		Run: func(context.Context) *ValidationResult {
+			return &ValidationResult{Name: v.Name, Skipped: true}
+		},
+	}
+}
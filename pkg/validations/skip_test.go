@@ -0,0 +1,45 @@
+package validations_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+func TestValidateSkippedValidations(t *testing.T) {
+	g := NewWithT(t)
+	skipped, err := validations.ValidateSkippedValidations([]string{validations.NodesReady, validations.RegistryMirrorCertificate})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(skipped).To(Equal(map[string]bool{validations.NodesReady: true, validations.RegistryMirrorCertificate: true}))
+}
+
+func TestValidateSkippedValidationsInvalid(t *testing.T) {
+	g := NewWithT(t)
+	_, err := validations.ValidateSkippedValidations([]string{validations.NodesReady, "control-plane-ready"})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid validation control-plane-ready to skip")))
+}
+
+func TestSkippable(t *testing.T) {
+	g := NewWithT(t)
+	ran := false
+	v := validations.Validation{
+		Name: "nodes ready",
+		Run: func(context.Context) *validations.ValidationResult {
+			ran = true
+			return &validations.ValidationResult{}
+		},
+	}
+
+	skippable := validations.Skippable(validations.NodesReady, map[string]bool{validations.NodesReady: true}, v)
+	g.Expect(skippable.Name).To(Equal("nodes ready"))
+	result := skippable.Run(context.Background())
+	g.Expect(ran).To(BeFalse())
+	g.Expect(result).To(Equal(&validations.ValidationResult{Name: "nodes ready", Skipped: true}))
+
+	result = validations.Skippable(validations.NodesReady, nil, v).Run(context.Background())
+	g.Expect(ran).To(BeTrue())
+	g.Expect(result.Skipped).To(BeFalse())
+}
@@ -43,6 +43,10 @@ func ValidateImmutableFields(ctx context.Context, k validations.KubectlClient, c
 		return fmt.Errorf("spec.controlPlaneConfiguration.endpoint is immutable")
 	}
 
+	if !nSpec.ControlPlaneConfiguration.ComponentExtraArgsEqual(&oSpec.ControlPlaneConfiguration) {
+		return fmt.Errorf("spec.controlPlaneConfiguration apiServerExtraArgs, controllerManagerExtraArgs and schedulerExtraArgs are immutable")
+	}
+
 	/* compare all clusterNetwork fields individually, since we do allow updating updating fields for configuring plugins such as CiliumConfig through the cli*/
 	if !nSpec.ClusterNetwork.Pods.Equal(&oSpec.ClusterNetwork.Pods) {
 		return fmt.Errorf("spec.clusterNetwork.Pods is immutable")
@@ -61,6 +65,10 @@ func ValidateImmutableFields(ctx context.Context, k validations.KubectlClient, c
 		return fmt.Errorf("spec.proxyConfiguration is immutable")
 	}
 
+	if !nSpec.EncryptionConfiguration.Equal(oSpec.EncryptionConfiguration) {
+		return errors.New("spec.encryptionConfiguration is immutable")
+	}
+
 	oldETCD := oSpec.ExternalEtcdConfiguration
 	newETCD := nSpec.ExternalEtcdConfiguration
 	if oldETCD != nil && newETCD != nil {
@@ -165,6 +173,14 @@ func ValidateGitOpsImmutableFields(ctx context.Context, k validations.KubectlCli
 			}
 		}
 
+		if prevGitOps.Spec.Gitlab != nil && !prevGitOps.Spec.Gitlab.Equal(clusterSpec.FluxConfig.Spec.Gitlab) {
+			return errors.New("fluxConfig spec.gitlab is immutable")
+		}
+
+		if prevGitOps.Spec.BitbucketServer != nil && !prevGitOps.Spec.BitbucketServer.Equal(clusterSpec.FluxConfig.Spec.BitbucketServer) {
+			return errors.New("fluxConfig spec.bitbucketServer is immutable")
+		}
+
 		if prevGitOps.Spec.Branch != clusterSpec.FluxConfig.Spec.Branch {
 			return errors.New("fluxConfig spec.branch is immutable")
 		}
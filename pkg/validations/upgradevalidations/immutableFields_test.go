@@ -180,6 +180,38 @@ func TestValidateGitOpsImmutableFieldsFluxConfig(t *testing.T) {
 			},
 			wantErr: "fluxConfig spec.github.personal is immutable",
 		},
+		{
+			name: "gitlab hostname diff",
+			new: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					Gitlab: &v1alpha1.GitlabProviderConfig{
+						Hostname: "a",
+					},
+				},
+			},
+			old: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					Gitlab: &v1alpha1.GitlabProviderConfig{
+						Hostname: "b",
+					},
+				},
+			},
+			wantErr: "fluxConfig spec.gitlab is immutable",
+		},
+		{
+			name: "bitbucket server removed",
+			new: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{},
+			},
+			old: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					BitbucketServer: &v1alpha1.BitbucketServerProviderConfig{
+						Repository: "a",
+					},
+				},
+			},
+			wantErr: "fluxConfig spec.bitbucketServer is immutable",
+		},
 		{
 			name: "branch diff",
 			new: &v1alpha1.FluxConfig{
@@ -12,60 +12,125 @@ import (
 	"github.com/aws/eks-anywhere/pkg/validations"
 )
 
+const (
+	registryMirrorCertificateName = "validate certificate for registry mirror"
+	workerNodesReadyName          = "worker nodes ready"
+	nodesReadyName                = "nodes ready"
+)
+
 func (u *UpgradeValidations) PreflightValidations(ctx context.Context) (err error) {
+	runner := u.Opts.NewRunner()
+	runner.Register(u.BuildValidations(ctx)...)
+
+	return validations.ProcessValidationResults(runner.Collect(ctx))
+}
+
+// BuildValidations returns the preflight validations for upgrading the cluster. They check the cluster with
+// the context the Runner runs them with, derived from the one the Runner is run with.
+func (u *UpgradeValidations) BuildValidations(_ context.Context) []validations.Validation {
 	k := u.Opts.Kubectl
+	skipped := u.Opts.SkippedValidations
 
 	targetCluster := &types.Cluster{
 		Name:           u.Opts.WorkloadCluster.Name,
 		KubeconfigFile: u.Opts.ManagementCluster.KubeconfigFile,
 	}
-	upgradeValidations := []validations.ValidationResult{
-		{
-			Name:        "validate certificate for registry mirror",
-			Remediation: fmt.Sprintf("provide a valid certificate for you registry endpoint using %s env var", anywherev1.RegistryMirrorCAKey),
-			Err:         validations.ValidateCertForRegistryMirror(u.Opts.Spec, u.Opts.TlsValidator),
-		},
-		{
-			Name:        "control plane ready",
-			Remediation: fmt.Sprintf("ensure control plane nodes and pods for cluster %s are Ready", u.Opts.WorkloadCluster.Name),
-			Err:         k.ValidateControlPlaneNodes(ctx, targetCluster, targetCluster.Name),
+	return []validations.Validation{
+		validations.Skippable(validations.RegistryMirrorCertificate, skipped,
+			validations.Validation{
+				Name: registryMirrorCertificateName,
+				Run: func(ctx context.Context) *validations.ValidationResult {
+					return &validations.ValidationResult{
+						Remediation: fmt.Sprintf("provide a valid certificate for you registry endpoint using %s env var", anywherev1.RegistryMirrorCAKey),
+						Err:         validations.ValidateCertForRegistryMirror(u.Opts.Spec, u.Opts.TlsValidator),
+					}
+				},
+			},
+		),
+		validations.Validation{
+			Name: "control plane ready",
+			Run: func(ctx context.Context) *validations.ValidationResult {
+				return &validations.ValidationResult{
+					Remediation: fmt.Sprintf("ensure control plane nodes and pods for cluster %s are Ready", u.Opts.WorkloadCluster.Name),
+					Err:         k.ValidateControlPlaneNodes(ctx, targetCluster, targetCluster.Name),
+				}
+			},
 		},
-		{
-			Name:        "worker nodes ready",
-			Remediation: fmt.Sprintf("ensure machine deployments for cluster %s are Ready", u.Opts.WorkloadCluster.Name),
-			Err:         k.ValidateWorkerNodes(ctx, u.Opts.Spec.Cluster.Name, targetCluster.KubeconfigFile),
+		validations.Skippable(validations.WorkerNodesReady, skipped,
+			validations.Validation{
+				Name: workerNodesReadyName,
+				Run: func(ctx context.Context) *validations.ValidationResult {
+					return &validations.ValidationResult{
+						Remediation: fmt.Sprintf("ensure machine deployments for cluster %s are Ready", u.Opts.WorkloadCluster.Name),
+						Err:         k.ValidateWorkerNodes(ctx, u.Opts.Spec.Cluster.Name, targetCluster.KubeconfigFile),
+					}
+				},
+			},
+		),
+		validations.Skippable(validations.NodesReady, skipped,
+			validations.Validation{
+				Name: nodesReadyName,
+				Run: func(ctx context.Context) *validations.ValidationResult {
+					return &validations.ValidationResult{
+						Remediation: fmt.Sprintf("check the Status of the control plane and worker nodes in cluster %s and verify they are Ready", u.Opts.WorkloadCluster.Name),
+						Err:         k.ValidateNodes(ctx, u.Opts.WorkloadCluster.KubeconfigFile),
+					}
+				},
+			},
+		),
+		validations.Validation{
+			Name: "cluster CRDs ready",
+			Run: func(ctx context.Context) *validations.ValidationResult {
+				return &validations.ValidationResult{
+					Remediation: "",
+					Err:         k.ValidateClustersCRD(ctx, u.Opts.ManagementCluster),
+				}
+			},
 		},
-		{
-			Name:        "nodes ready",
-			Remediation: fmt.Sprintf("check the Status of the control plane and worker nodes in cluster %s and verify they are Ready", u.Opts.WorkloadCluster.Name),
-			Err:         k.ValidateNodes(ctx, u.Opts.WorkloadCluster.KubeconfigFile),
+		validations.Validation{
+			Name: "cluster object present on workload cluster",
+			Run: func(ctx context.Context) *validations.ValidationResult {
+				return &validations.ValidationResult{
+					Remediation: fmt.Sprintf("ensure that the CAPI cluster object %s representing cluster %s is present", clusterv1.GroupVersion, u.Opts.WorkloadCluster.Name),
+					Err:         ValidateClusterObjectExists(ctx, k, u.Opts.ManagementCluster),
+				}
+			},
 		},
-		{
-			Name:        "cluster CRDs ready",
-			Remediation: "",
-			Err:         k.ValidateClustersCRD(ctx, u.Opts.ManagementCluster),
+		validations.Validation{
+			Name: "upgrade cluster kubernetes version increment",
+			Run: func(ctx context.Context) *validations.ValidationResult {
+				return &validations.ValidationResult{
+					Remediation: "ensure that the cluster kubernetes version is incremented by one minor version exactly (e.g. 1.18 -> 1.19)",
+					Err:         ValidateServerVersionSkew(ctx, u.Opts.Spec.Cluster.Spec.KubernetesVersion, u.Opts.WorkloadCluster, k),
+				}
+			},
 		},
-		{
-			Name:        "cluster object present on workload cluster",
-			Remediation: fmt.Sprintf("ensure that the CAPI cluster object %s representing cluster %s is present", clusterv1.GroupVersion, u.Opts.WorkloadCluster.Name),
-			Err:         ValidateClusterObjectExists(ctx, k, u.Opts.ManagementCluster),
+		validations.Validation{
+			Name: "validate authentication for git provider",
+			Run: func(ctx context.Context) *validations.ValidationResult {
+				return &validations.ValidationResult{
+					Remediation: fmt.Sprintf("ensure %s, %s env variable are set and valid", config.EksaGitPrivateKeyTokenEnv, config.EksaGitKnownHostsFileEnv),
+					Err:         validations.ValidateAuthenticationForGitProvider(u.Opts.Spec, u.Opts.CliConfig),
+				}
+			},
 		},
-		{
-			Name:        "upgrade cluster kubernetes version increment",
-			Remediation: "ensure that the cluster kubernetes version is incremented by one minor version exactly (e.g. 1.18 -> 1.19)",
-			Err:         ValidateServerVersionSkew(ctx, u.Opts.Spec.Cluster.Spec.KubernetesVersion, u.Opts.WorkloadCluster, k),
+		validations.Validation{
+			Name: "validate immutable fields",
+			Run: func(ctx context.Context) *validations.ValidationResult {
+				return &validations.ValidationResult{
+					Remediation: "",
+					Err:         ValidateImmutableFields(ctx, k, targetCluster, u.Opts.Spec, u.Opts.Provider),
+				}
+			},
 		},
-		{
-			Name:        "validate authentication for git provider",
-			Remediation: fmt.Sprintf("ensure %s, %s env variable are set and valid", config.EksaGitPrivateKeyTokenEnv, config.EksaGitKnownHostsFileEnv),
-			Err:         validations.ValidateAuthenticationForGitProvider(u.Opts.Spec, u.Opts.CliConfig),
-		},
-		{
-			Name:        "validate immutable fields",
-			Remediation: "",
-			Err:         ValidateImmutableFields(ctx, k, targetCluster, u.Opts.Spec, u.Opts.Provider),
+		validations.Validation{
+			Name: "validate cluster policies",
+			Run: func(ctx context.Context) *validations.ValidationResult {
+				return &validations.ValidationResult{
+					Remediation: validations.PoliciesRemediation,
+					Err:         validations.ValidatePolicies(ctx, k, u.Opts.ManagementCluster, u.Opts.Spec),
+				}
+			},
 		},
 	}
-
-	return validations.ProcessValidationResults(upgradeValidations)
 }
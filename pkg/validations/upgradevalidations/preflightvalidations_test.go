@@ -7,6 +7,9 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/version"
 
 	"github.com/aws/eks-anywhere/internal/test"
@@ -16,6 +19,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	filewritermocks "github.com/aws/eks-anywhere/pkg/filewriter/mocks"
+	"github.com/aws/eks-anywhere/pkg/policy"
 	mockproviders "github.com/aws/eks-anywhere/pkg/providers/mocks"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell"
 	tinkerbellmocks "github.com/aws/eks-anywhere/pkg/providers/tinkerbell/mocks"
@@ -414,6 +418,7 @@ func TestPreflightValidationsTinkerbell(t *testing.T) {
 			k.EXPECT().GetClusters(ctx, workloadCluster).Return(tc.getClusterResponse, nil)
 			k.EXPECT().GetEksaCluster(ctx, workloadCluster, clusterSpec.Cluster.Name).Return(existingClusterSpec.Cluster, nil)
 			k.EXPECT().Version(ctx, workloadCluster).Return(versionResponse, nil)
+			expectNoPolicies(k)
 			upgradeValidations := upgradevalidations.New(opts)
 			err := upgradeValidations.PreflightValidations(ctx)
 			if !reflect.DeepEqual(err, tc.wantErr) {
@@ -466,6 +471,7 @@ func TestPreflightValidationsVsphere(t *testing.T) {
 		crdResponse        error
 		wantErr            error
 		modifyFunc         func(s *cluster.Spec)
+		skippedValidations map[string]bool
 	}{
 		{
 			name:               "ValidationSucceeds",
@@ -478,6 +484,18 @@ func TestPreflightValidationsVsphere(t *testing.T) {
 			crdResponse:        nil,
 			wantErr:            nil,
 		},
+		{
+			name:               "ValidationSucceedsNodesNotReadySkipped",
+			clusterVersion:     "v1.19.16-eks-1-19-4",
+			upgradeVersion:     "1.19",
+			getClusterResponse: goodClusterResponse,
+			cpResponse:         nil,
+			workerResponse:     errors.New("2 worker nodes are not ready"),
+			nodeResponse:       errors.New("node test-node is not ready, currently in Unknown state"),
+			crdResponse:        nil,
+			wantErr:            nil,
+			skippedValidations: map[string]bool{validations.WorkerNodesReady: true, validations.NodesReady: true},
+		},
 		{
 			name:               "ValidationFailsMajorVersionPlus2",
 			clusterVersion:     "v1.18.16-eks-1-18-4",
@@ -1134,12 +1152,14 @@ func TestPreflightValidationsVsphere(t *testing.T) {
 
 			provider := mockproviders.NewMockProvider(mockCtrl)
 			opts := &validations.Opts{
-				Kubectl:           k,
-				Spec:              clusterSpec,
-				WorkloadCluster:   workloadCluster,
-				ManagementCluster: workloadCluster,
-				Provider:          provider,
-				TlsValidator:      tlsValidator,
+				Kubectl:            k,
+				Spec:               clusterSpec,
+				WorkloadCluster:    workloadCluster,
+				ManagementCluster:  workloadCluster,
+				Provider:           provider,
+				TlsValidator:       tlsValidator,
+				SkippedValidations: tc.skippedValidations,
+				Concurrency:        4,
 			}
 
 			clusterSpec.Cluster.Spec.KubernetesVersion = v1alpha1.KubernetesVersion(tc.upgradeVersion)
@@ -1158,8 +1178,12 @@ func TestPreflightValidationsVsphere(t *testing.T) {
 			provider.EXPECT().ValidateNewSpec(ctx, workloadCluster, clusterSpec).Return(nil).MaxTimes(1)
 			k.EXPECT().GetEksaVSphereDatacenterConfig(ctx, clusterSpec.Cluster.Spec.DatacenterRef.Name, gomock.Any(), gomock.Any()).Return(existingProviderSpec, nil).MaxTimes(1)
 			k.EXPECT().ValidateControlPlaneNodes(ctx, workloadCluster, clusterSpec.Cluster.Name).Return(tc.cpResponse)
-			k.EXPECT().ValidateWorkerNodes(ctx, workloadCluster.Name, workloadCluster.KubeconfigFile).Return(tc.workerResponse)
-			k.EXPECT().ValidateNodes(ctx, kubeconfigFilePath).Return(tc.nodeResponse)
+			if !tc.skippedValidations[validations.WorkerNodesReady] {
+				k.EXPECT().ValidateWorkerNodes(ctx, workloadCluster.Name, workloadCluster.KubeconfigFile).Return(tc.workerResponse)
+			}
+			if !tc.skippedValidations[validations.NodesReady] {
+				k.EXPECT().ValidateNodes(ctx, kubeconfigFilePath).Return(tc.nodeResponse)
+			}
 			k.EXPECT().ValidateClustersCRD(ctx, workloadCluster).Return(tc.crdResponse)
 			k.EXPECT().GetClusters(ctx, workloadCluster).Return(tc.getClusterResponse, nil)
 			k.EXPECT().GetEksaCluster(ctx, workloadCluster, clusterSpec.Cluster.Name).Return(existingClusterSpec.Cluster, nil)
@@ -1167,6 +1191,7 @@ func TestPreflightValidationsVsphere(t *testing.T) {
 			k.EXPECT().GetEksaOIDCConfig(ctx, clusterSpec.Cluster.Spec.IdentityProviderRefs[1].Name, gomock.Any(), gomock.Any()).Return(existingClusterSpec.OIDCConfig, nil).MaxTimes(1)
 			k.EXPECT().GetEksaAWSIamConfig(ctx, clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, gomock.Any(), gomock.Any()).Return(existingClusterSpec.AWSIamConfig, nil).MaxTimes(1)
 			k.EXPECT().Version(ctx, workloadCluster).Return(versionResponse, nil)
+			expectNoPolicies(k)
 			upgradeValidations := upgradevalidations.New(opts)
 			err := upgradeValidations.PreflightValidations(ctx)
 			if !reflect.DeepEqual(err, tc.wantErr) {
@@ -1176,6 +1201,12 @@ func TestPreflightValidationsVsphere(t *testing.T) {
 	}
 }
 
+func expectNoPolicies(k *mocks.MockKubectlClient) {
+	k.EXPECT().
+		GetObject(gomock.Any(), "configmap", policy.ConfigMapName, policy.ConfigMapNamespace, kubeconfigFilePath, &corev1.ConfigMap{}).
+		Return(apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, policy.ConfigMapName))
+}
+
 func composeError(msgs ...string) *validations.ValidationError {
 	var errs []string
 	errs = append(errs, msgs...)
@@ -1401,6 +1432,7 @@ func TestPreFlightValidationsGit(t *testing.T) {
 			k.EXPECT().GetEksaOIDCConfig(ctx, clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, gomock.Any(), gomock.Any()).Return(existingClusterSpec.OIDCConfig, nil).MaxTimes(1)
 			k.EXPECT().GetEksaAWSIamConfig(ctx, clusterSpec.Cluster.Spec.IdentityProviderRefs[1].Name, gomock.Any(), gomock.Any()).Return(existingClusterSpec.AWSIamConfig, nil).MaxTimes(1)
 			k.EXPECT().Version(ctx, workloadCluster).Return(versionResponse, nil)
+			expectNoPolicies(k)
 			upgradeValidations := upgradevalidations.New(opts)
 			err := upgradeValidations.PreflightValidations(ctx)
 			if !reflect.DeepEqual(err, tc.wantErr) {
@@ -1,6 +1,7 @@
 package validations
 
 import (
+	"time"
 	"unicode"
 
 	"github.com/aws/eks-anywhere/pkg/logger"
@@ -11,6 +12,8 @@ type ValidationResult struct {
 	Err         error
 	Remediation string
 	Silent      bool
+	// Skipped is true when the validation was not run because it was skipped with --skip-validations.
+	Skipped bool
 }
 
 func (v *ValidationResult) Report() {
@@ -18,6 +21,10 @@ func (v *ValidationResult) Report() {
 		logger.MarkFail("Validation failed", "validation", v.Name, "error", v.Err, "remediation", v.Remediation)
 		return
 	}
+	if v.Skipped {
+		logger.MarkWarning("Validation skipped", "validation", v.Name)
+		return
+	}
 	if !v.Silent {
 		v.LogPass()
 	}
@@ -27,6 +34,23 @@ func (v *ValidationResult) LogPass() {
 	logger.MarkPass(capitalize(v.Name))
 }
 
+func (v *ValidationResult) record(duration time.Duration) Result {
+	r := Result{
+		Name:     v.Name,
+		Status:   StatusPassed,
+		Duration: duration,
+	}
+	switch {
+	case v.Err != nil:
+		r.Status = StatusFailed
+		r.Error = v.Err.Error()
+		r.Remediation = v.Remediation
+	case v.Skipped:
+		r.Status = StatusSkipped
+	}
+	return r
+}
+
 func capitalize(s string) string {
 	if len(s) == 0 {
 		return s
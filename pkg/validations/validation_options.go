@@ -1,6 +1,8 @@
 package validations
 
 import (
+	"time"
+
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/crypto"
@@ -16,6 +18,14 @@ type Opts struct {
 	Provider          providers.Provider
 	TlsValidator      TlsValidator
 	CliConfig         *config.CliConfig
+	// SkippedValidations are the IDs of the validations that are not run.
+	SkippedValidations map[string]bool
+	// Report records the result of each validation if set.
+	Report *Report
+	// Concurrency is the maximum number of preflight validations run in parallel, DefaultConcurrency if lower than 1.
+	Concurrency int
+	// ValidationTimeout is the maximum duration of each preflight validation, no limit if zero.
+	ValidationTimeout time.Duration
 }
 
 func (o *Opts) SetDefaults() {
@@ -23,3 +33,8 @@ func (o *Opts) SetDefaults() {
 		o.TlsValidator = crypto.NewTlsValidator()
 	}
 }
+
+// NewRunner returns a Runner for the preflight validations with the report, concurrency and timeout in o.
+func (o *Opts) NewRunner() *Runner {
+	return NewRunner(WithReport(o.Report), WithConcurrency(o.Concurrency), WithValidationTimeout(o.ValidationTimeout))
+}
@@ -0,0 +1,250 @@
+package workflow
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// taskRun records the context a task was run with and the one it returned so the values the task
+// set can be merged into the context of dependent tasks.
+type taskRun struct {
+	in  context.Context
+	out context.Context
+
+	mu sync.Mutex
+	// set caches, for each key looked up in the contexts of the dependent tasks, whether the task
+	// set it and the value it set.
+	set map[interface{}]taskValue
+}
+
+type taskValue struct {
+	value interface{}
+	ok    bool
+}
+
+// setValue returns the value the task set for key and true, or false if the task didn't set it. A
+// task sets a key when the value in the context it returned differs from the one in the context it
+// was run with, so keys are resolved from the values the task added on top of its input. Values are
+// compared with reflect.DeepEqual, so setting a key to a value equal to the one it had doesn't set it.
+func (r *taskRun) setValue(key interface{}) (interface{}, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if v, ok := r.set[key]; ok {
+		return v.value, v.ok
+	}
+
+	v := taskValue{value: r.out.Value(key)}
+	v.ok = v.value != nil && !reflect.DeepEqual(v.value, r.in.Value(key))
+	if r.set == nil {
+		r.set = make(map[interface{}]taskValue)
+	}
+	r.set[key] = v
+
+	return v.value, v.ok
+}
+
+// taskCompletion is sent by a task goroutine when the task, including its hooks, finishes.
+type taskCompletion struct {
+	index int
+	ctx   context.Context
+	err   error
+
+	// restored indicates the task completed in a previous execution and wasn't run.
+	restored bool
+}
+
+// runTasks executes the workflow tasks respecting their dependencies and MaxConcurrency. Tasks
+// completed in a previous execution, as recorded in the StateStore, are restored instead of run.
+// It returns the merged context of all tasks or the first error encountered.
+func (w *Workflow) runTasks(ctx context.Context) (context.Context, error) {
+	if len(w.tasks) == 0 {
+		return ctx, nil
+	}
+
+	progress, err := w.loadProgress(ctx)
+	if err != nil {
+		return ctx, w.handleError(ctx, err)
+	}
+
+	index := make(map[TaskName]int, len(w.tasks))
+	for i, t := range w.tasks {
+		index[t.Name] = i
+	}
+
+	// Dependencies always point to tasks appended earlier so the graph is acyclic and ancestors
+	// can be computed in a single pass.
+	ancestors := make([][]int, len(w.tasks))
+	dependents := make([][]int, len(w.tasks))
+	pending := make([]int, len(w.tasks))
+	isSink := make([]bool, len(w.tasks))
+	for i, t := range w.tasks {
+		isSink[i] = true
+		seen := make(map[int]struct{})
+		for _, name := range t.Dependencies {
+			dep := index[name]
+			if _, ok := seen[dep]; ok {
+				continue
+			}
+			seen[dep] = struct{}{}
+			pending[i]++
+			dependents[dep] = append(dependents[dep], i)
+			isSink[dep] = false
+			for _, a := range ancestors[dep] {
+				seen[a] = struct{}{}
+			}
+		}
+		ancestors[i] = sortedKeys(seen)
+	}
+
+	var ready []int
+	for i := range w.tasks {
+		if pending[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	runs := make([]*taskRun, len(w.tasks))
+	for i := range runs {
+		runs[i] = &taskRun{}
+	}
+	completions := make(chan taskCompletion)
+	running := 0
+	var firstErr error
+
+	for {
+		for firstErr == nil && len(ready) > 0 && running < w.MaxConcurrency {
+			i := ready[0]
+			ready = ready[1:]
+
+			runs[i].in = mergeContexts(ctx, w.directDependencies(i, index), ancestors[i], runs)
+			running++
+			// progress is only updated by this goroutine, so it's checked before starting the task.
+			go func(i int, in context.Context, completed bool) {
+				if completed {
+					out, err := progress.restore(in, w.tasks[i].Name)
+					completions <- taskCompletion{index: i, ctx: out, err: err, restored: true}
+					return
+				}
+
+				out, err := w.runTask(in, w.tasks[i])
+				completions <- taskCompletion{index: i, ctx: out, err: err}
+			}(i, runs[i].in, progress.isCompleted(w.tasks[i].Name))
+		}
+
+		if running == 0 {
+			break
+		}
+
+		c := <-completions
+		running--
+		runs[c.index].out = c.ctx
+
+		if c.err == nil && !c.restored {
+			c.err = progress.taskCompleted(ctx, w.tasks[c.index].Name, c.ctx)
+		}
+
+		if c.err != nil {
+			w.ErrorHandler(c.ctx, c.err)
+			if firstErr == nil {
+				firstErr = c.err
+			}
+			continue
+		}
+
+		for _, d := range dependents[c.index] {
+			pending[d]--
+			if pending[d] == 0 {
+				ready = insertSorted(ready, d)
+			}
+		}
+	}
+
+	if firstErr != nil {
+		return ctx, firstErr
+	}
+
+	var sinks []int
+	all := make([]int, 0, len(w.tasks))
+	for i := range w.tasks {
+		all = append(all, i)
+		if isSink[i] {
+			sinks = append(sinks, i)
+		}
+	}
+
+	if err := progress.finish(ctx); err != nil {
+		return ctx, w.handleError(ctx, err)
+	}
+
+	return mergeContexts(ctx, sinks, all, runs), nil
+}
+
+// directDependencies returns the indexes of the tasks task i directly depends on.
+func (w *Workflow) directDependencies(i int, index map[TaskName]int) []int {
+	seen := make(map[int]struct{}, len(w.tasks[i].Dependencies))
+	for _, name := range w.tasks[i].Dependencies {
+		seen[index[name]] = struct{}{}
+	}
+	return sortedKeys(seen)
+}
+
+// mergeContexts builds the context for a task with the given direct dependencies and ancestors.
+// With no dependencies the parent context is used. Otherwise the values set by the ancestors are
+// merged with a mergedContext.
+func mergeContexts(parent context.Context, deps, ancestors []int, runs []*taskRun) context.Context {
+	if len(deps) == 0 {
+		return parent
+	}
+
+	merged := mergedContext{Context: parent}
+	for _, a := range ancestors {
+		merged.runs = append(merged.runs, runs[a])
+	}
+	return merged
+}
+
+// mergedContext merges the contexts returned by a set of tasks. Values are resolved from the most
+// recently appended task that set them, falling back to the parent context. A task sets the keys
+// whose value in the context it returns differs from the one in the context it was run with. Because
+// tasks are ordered by the order they were appended the result is deterministic regardless of the
+// order in which tasks completed. Deadlines and cancellation are inherited from the parent context only.
+type mergedContext struct {
+	context.Context
+	runs []*taskRun
+}
+
+// Value satisfies the context.Context interface.
+func (c mergedContext) Value(key interface{}) interface{} {
+	for i := len(c.runs) - 1; i >= 0; i-- {
+		r := c.runs[i]
+		if r.out == nil {
+			continue
+		}
+
+		if v, ok := r.setValue(key); ok {
+			return v
+		}
+	}
+
+	return c.Context.Value(key)
+}
+
+func sortedKeys(m map[int]struct{}) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+func insertSorted(s []int, v int) []int {
+	i := sort.SearchInts(s, v)
+	s = append(s, 0)
+	copy(s[i+1:], s[i:])
+	s[i] = v
+	return s
+}
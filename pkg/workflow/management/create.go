@@ -8,6 +8,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/workflow"
 	"github.com/aws/eks-anywhere/pkg/workflow/task/bootstrap"
 	"github.com/aws/eks-anywhere/pkg/workflow/task/workload"
+	"github.com/aws/eks-anywhere/pkg/workflow/workflowcontext"
 )
 
 // Define tasks names for each task run as part of the create cluster workflow. To aid readability
@@ -47,6 +48,10 @@ type CreateCluster struct {
 	// FS is a file system abstraction used to write files.
 	FS filewriter.FileWriter
 
+	// StateStore persists the workflow progress so an interrupted run can be resumed, skipping
+	// completed tasks. Optional.
+	StateStore workflow.StateStore
+
 	// hookRegistrars are data structures that wish to bind runtime hooks to the workflow.
 	// They should be added via the WithHookRegistrar method.
 	hookRegistrars []CreateClusterHookRegistrar
@@ -69,7 +74,10 @@ func (c CreateCluster) Run(ctx context.Context) error {
 }
 
 func (c CreateCluster) build() (*workflow.Workflow, error) {
-	wflw := workflow.New(workflow.Config{})
+	wflw := workflow.New(workflow.Config{
+		StateStore:   c.StateStore,
+		ContextCodec: workflowcontext.Codec{},
+	})
 
 	for _, r := range c.hookRegistrars {
 		r.RegisterCreateManagementClusterHooks(wflw)
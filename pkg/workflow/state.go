@@ -0,0 +1,159 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// State records the progress of a workflow so an interrupted workflow can be resumed.
+type State struct {
+	// CompletedTasks are the tasks that completed successfully in order of completion.
+	CompletedTasks []TaskName `json:"completedTasks"`
+
+	// Outputs are the serialized contexts returned by completed tasks.
+	Outputs map[TaskName]json.RawMessage `json:"outputs,omitempty"`
+}
+
+// StateStore persists workflow State between executions.
+type StateStore interface {
+	// Load retrieves the persisted state. It returns a nil State if no state has been persisted.
+	Load(context.Context) (*State, error)
+
+	// Save persists the state overwriting any previously persisted state.
+	Save(context.Context, *State) error
+
+	// Delete removes any persisted state. It's called when a workflow completes successfully.
+	Delete(context.Context) error
+}
+
+// ContextCodec serializes the values tasks add to a context so they can be restored when a
+// workflow is resumed.
+type ContextCodec interface {
+	// Encode returns the JSON representation of the values in ctx.
+	Encode(ctx context.Context) ([]byte, error)
+
+	// Decode returns a context based on ctx containing the values in data.
+	Decode(ctx context.Context, data []byte) (context.Context, error)
+}
+
+// progress tracks the tasks completed during a workflow execution, persisting them to the
+// workflow's StateStore if configured.
+type progress struct {
+	store     StateStore
+	codec     ContextCodec
+	state     *State
+	completed map[TaskName]struct{}
+}
+
+// loadProgress loads the workflow state from the StateStore and validates it against the
+// workflow tasks.
+func (w *Workflow) loadProgress(ctx context.Context) (*progress, error) {
+	p := &progress{
+		store:     w.StateStore,
+		codec:     w.ContextCodec,
+		state:     &State{},
+		completed: make(map[TaskName]struct{}),
+	}
+
+	if p.store == nil {
+		return p, nil
+	}
+
+	state, err := p.store.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading workflow state: %v", err)
+	}
+
+	if state == nil {
+		return p, nil
+	}
+
+	for _, name := range state.CompletedTasks {
+		if _, found := w.taskNames[name]; !found {
+			return nil, ErrInvalidState{fmt.Sprintf("completed task %v is not part of the workflow", name)}
+		}
+		p.completed[name] = struct{}{}
+	}
+
+	// A task can only have completed if all its dependencies completed before it.
+	for _, t := range w.tasks {
+		if !p.isCompleted(t.Name) {
+			continue
+		}
+		for _, dep := range t.Dependencies {
+			if !p.isCompleted(dep) {
+				return nil, ErrInvalidState{fmt.Sprintf("task %v completed before its dependency %v", t.Name, dep)}
+			}
+		}
+	}
+
+	p.state = state
+	return p, nil
+}
+
+func (p *progress) isCompleted(name TaskName) bool {
+	_, ok := p.completed[name]
+	return ok
+}
+
+// restore rebuilds the context returned by a previously completed task. If the output can't be
+// restored, ctx is returned with the error.
+func (p *progress) restore(ctx context.Context, name TaskName) (context.Context, error) {
+	logger.V(4).Info("Restoring completed task", "task_name", name)
+
+	output, ok := p.state.Outputs[name]
+	if p.codec == nil || !ok {
+		return ctx, nil
+	}
+
+	restored, err := p.codec.Decode(ctx, output)
+	if err != nil {
+		return ctx, fmt.Errorf("restoring output of task %v: %v", name, err)
+	}
+
+	return restored, nil
+}
+
+// taskCompleted records a task as completed and persists the state.
+func (p *progress) taskCompleted(ctx context.Context, name TaskName, output context.Context) error {
+	p.completed[name] = struct{}{}
+	p.state.CompletedTasks = append(p.state.CompletedTasks, name)
+
+	if p.store == nil {
+		return nil
+	}
+
+	if p.codec != nil && output != nil {
+		data, err := p.codec.Encode(output)
+		if err != nil {
+			return fmt.Errorf("serializing output of task %v: %v", name, err)
+		}
+
+		if p.state.Outputs == nil {
+			p.state.Outputs = make(map[TaskName]json.RawMessage)
+		}
+		p.state.Outputs[name] = data
+	}
+
+	if err := p.store.Save(ctx, p.state); err != nil {
+		return fmt.Errorf("saving workflow state: %v", err)
+	}
+
+	return nil
+}
+
+// finish removes the persisted state once all tasks have completed.
+func (p *progress) finish(ctx context.Context) error {
+	if p.store == nil {
+		return nil
+	}
+
+	if err := p.store.Delete(ctx); err != nil {
+		return fmt.Errorf("deleting workflow state: %v", err)
+	}
+
+	return nil
+}
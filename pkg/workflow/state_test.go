@@ -0,0 +1,162 @@
+package workflow_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/workflow"
+)
+
+// memoryStateStore is an in memory workflow.StateStore.
+type memoryStateStore struct {
+	state   *workflow.State
+	saves   int
+	deleted bool
+	saveErr error
+}
+
+func (m *memoryStateStore) Load(context.Context) (*workflow.State, error) {
+	return m.state, nil
+}
+
+func (m *memoryStateStore) Save(_ context.Context, s *workflow.State) error {
+	if m.saveErr != nil {
+		return m.saveErr
+	}
+	m.saves++
+	m.state = &workflow.State{CompletedTasks: append([]workflow.TaskName{}, s.CompletedTasks...), Outputs: s.Outputs}
+	return nil
+}
+
+func (m *memoryStateStore) Delete(context.Context) error {
+	m.deleted = true
+	m.state = nil
+	return nil
+}
+
+// stringCodec serializes the stateContextKey value of a context.
+type stringCodec struct{}
+
+type stateContextKey struct{}
+
+func (stringCodec) Encode(ctx context.Context) ([]byte, error) {
+	v, _ := ctx.Value(stateContextKey{}).(string)
+	return json.Marshal(v)
+}
+
+func (stringCodec) Decode(ctx context.Context, data []byte) (context.Context, error) {
+	var v string
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return context.WithValue(ctx, stateContextKey{}, v), nil
+}
+
+func TestWorkflowExecuteResumesFromState(t *testing.T) {
+	g := gomega.NewWithT(t)
+	store := &memoryStateStore{}
+
+	var ran []workflow.TaskName
+	var task2Value interface{}
+	task1 := workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		ran = append(ran, "task1")
+		return context.WithValue(ctx, stateContextKey{}, "from-task1"), nil
+	})
+	attempts := 0
+	task2 := workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		ran = append(ran, "task2")
+		task2Value = ctx.Value(stateContextKey{})
+		attempts++
+		if attempts == 1 {
+			return ctx, errors.New("interrupted")
+		}
+		return ctx, nil
+	})
+
+	build := func() *workflow.Workflow {
+		wflw := workflow.New(workflow.Config{StateStore: store, ContextCodec: stringCodec{}})
+		g.Expect(wflw.AppendTask("task1", task1)).To(gomega.Succeed())
+		g.Expect(wflw.AppendTask("task2", task2)).To(gomega.Succeed())
+		return wflw
+	}
+
+	g.Expect(build().Execute(context.Background())).To(gomega.MatchError("interrupted"))
+	g.Expect(store.state.CompletedTasks).To(gomega.Equal([]workflow.TaskName{"task1"}))
+
+	ran = nil
+	task2Value = nil
+	g.Expect(build().Execute(context.Background())).To(gomega.Succeed())
+	g.Expect(ran).To(gomega.Equal([]workflow.TaskName{"task2"}))
+	g.Expect(task2Value).To(gomega.Equal("from-task1"))
+	g.Expect(store.deleted).To(gomega.BeTrue())
+	g.Expect(store.state).To(gomega.BeNil())
+}
+
+func TestWorkflowExecuteInvalidStateUnknownTask(t *testing.T) {
+	g := gomega.NewWithT(t)
+	store := &memoryStateStore{state: &workflow.State{CompletedTasks: []workflow.TaskName{"unknown"}}}
+
+	wflw := workflow.New(workflow.Config{StateStore: store})
+	g.Expect(wflw.AppendTask("task1", nopTask())).To(gomega.Succeed())
+
+	err := wflw.Execute(context.Background())
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("completed task unknown is not part of the workflow")))
+}
+
+func TestWorkflowExecuteInvalidStateMissingDependency(t *testing.T) {
+	g := gomega.NewWithT(t)
+	store := &memoryStateStore{state: &workflow.State{CompletedTasks: []workflow.TaskName{"task2"}}}
+
+	wflw := workflow.New(workflow.Config{StateStore: store})
+	g.Expect(wflw.AppendTask("task1", nopTask())).To(gomega.Succeed())
+	g.Expect(wflw.AppendTask("task2", nopTask())).To(gomega.Succeed())
+
+	err := wflw.Execute(context.Background())
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("task task2 completed before its dependency task1")))
+}
+
+func TestWorkflowExecuteSaveStateError(t *testing.T) {
+	g := gomega.NewWithT(t)
+	store := &memoryStateStore{saveErr: errors.New("disk full")}
+
+	var handled error
+	wflw := workflow.New(workflow.Config{
+		StateStore: store,
+		ErrorHandler: func(_ context.Context, err error) {
+			handled = err
+		},
+	})
+	g.Expect(wflw.AppendTask("task1", nopTask())).To(gomega.Succeed())
+
+	err := wflw.Execute(context.Background())
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("saving workflow state: disk full")))
+	g.Expect(handled).To(gomega.Equal(err))
+}
+
+func TestWorkflowExecuteRestoreOutputError(t *testing.T) {
+	g := gomega.NewWithT(t)
+	store := &memoryStateStore{state: &workflow.State{
+		CompletedTasks: []workflow.TaskName{"task1"},
+		Outputs:        map[workflow.TaskName]json.RawMessage{"task1": json.RawMessage(`{"not":"a string"}`)},
+	}}
+
+	var handledCtx context.Context
+	wflw := workflow.New(workflow.Config{
+		StateStore:   store,
+		ContextCodec: stringCodec{},
+		ErrorHandler: func(ctx context.Context, _ error) {
+			handledCtx = ctx
+		},
+	})
+	g.Expect(wflw.AppendTask("task1", nopTask())).To(gomega.Succeed())
+
+	ctx := context.WithValue(context.Background(), stateContextKey{}, "original")
+	err := wflw.Execute(ctx)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("restoring output of task task1")))
+	g.Expect(handledCtx).NotTo(gomega.BeNil())
+	g.Expect(handledCtx.Value(stateContextKey{})).To(gomega.Equal("original"))
+}
@@ -0,0 +1,78 @@
+package statestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/workflow"
+)
+
+// File persists workflow state as a YAML file in the temporary directory of a
+// filewriter.FileWriter.
+type File struct {
+	writer   filewriter.FileWriter
+	fileName string
+}
+
+// NewFile returns a File store that persists state in fileName.
+func NewFile(writer filewriter.FileWriter, fileName string) *File {
+	return &File{
+		writer:   writer,
+		fileName: fileName,
+	}
+}
+
+// Load satisfies workflow.StateStore.
+func (f *File) Load(_ context.Context) (*workflow.State, error) {
+	content, err := os.ReadFile(f.path())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading workflow state file: %v", err)
+	}
+
+	return unmarshalState(content)
+}
+
+// Save satisfies workflow.StateStore.
+func (f *File) Save(_ context.Context, state *workflow.State) error {
+	content, err := yaml.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("marshalling workflow state: %v", err)
+	}
+
+	if _, err := f.writer.Write(f.fileName, content, filewriter.Permission0600); err != nil {
+		return fmt.Errorf("writing workflow state file: %v", err)
+	}
+
+	return nil
+}
+
+// Delete satisfies workflow.StateStore.
+func (f *File) Delete(_ context.Context) error {
+	if err := os.Remove(f.path()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("deleting workflow state file: %v", err)
+	}
+
+	return nil
+}
+
+func (f *File) path() string {
+	return filepath.Join(f.writer.TempDir(), f.fileName)
+}
+
+func unmarshalState(content []byte) (*workflow.State, error) {
+	state := &workflow.State{}
+	if err := yaml.Unmarshal(content, state); err != nil {
+		return nil, fmt.Errorf("unmarshalling workflow state: %v", err)
+	}
+
+	return state, nil
+}
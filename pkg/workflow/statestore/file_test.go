@@ -0,0 +1,68 @@
+package statestore_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/workflow"
+	"github.com/aws/eks-anywhere/pkg/workflow/statestore"
+)
+
+func testState() *workflow.State {
+	return &workflow.State{
+		CompletedTasks: []workflow.TaskName{"task1", "task2"},
+		Outputs: map[workflow.TaskName]json.RawMessage{
+			"task1": json.RawMessage(`{"bootstrapCluster":{"Name":"bootstrap"}}`),
+		},
+	}
+}
+
+func TestFileSaveAndLoad(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	writer, err := filewriter.NewWriter(t.TempDir())
+	g.Expect(err).NotTo(HaveOccurred())
+	store := statestore.NewFile(writer, "cluster-workflow-state.yaml")
+
+	state, err := store.Load(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(state).To(BeNil())
+
+	g.Expect(store.Save(ctx, testState())).To(Succeed())
+	g.Expect(filepath.Join(writer.TempDir(), "cluster-workflow-state.yaml")).To(BeAnExistingFile())
+
+	state, err = store.Load(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(state).To(Equal(testState()))
+}
+
+func TestFileDelete(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	writer, err := filewriter.NewWriter(t.TempDir())
+	g.Expect(err).NotTo(HaveOccurred())
+	store := statestore.NewFile(writer, "cluster-workflow-state.yaml")
+
+	g.Expect(store.Delete(ctx)).To(Succeed())
+	g.Expect(store.Save(ctx, testState())).To(Succeed())
+	g.Expect(store.Delete(ctx)).To(Succeed())
+	g.Expect(filepath.Join(writer.TempDir(), "cluster-workflow-state.yaml")).NotTo(BeAnExistingFile())
+}
+
+func TestFileLoadInvalidState(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	writer, err := filewriter.NewWriter(t.TempDir())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(os.WriteFile(filepath.Join(writer.TempDir(), "state.yaml"), []byte("completedTasks: {"), 0o600)).To(Succeed())
+	store := statestore.NewFile(writer, "state.yaml")
+
+	_, err = store.Load(ctx)
+	g.Expect(err).To(MatchError(ContainSubstring("unmarshalling workflow state")))
+}
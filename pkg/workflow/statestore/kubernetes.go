@@ -0,0 +1,150 @@
+package statestore
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/workflow"
+)
+
+// StateKey is the data key the workflow state is stored under in ConfigMaps and Secrets.
+const StateKey = "state"
+
+// Kubernetes persists workflow state in a Kubernetes ConfigMap or Secret.
+type Kubernetes struct {
+	client    client.Client
+	namespace string
+	name      string
+	object    kubernetesObject
+}
+
+// kubernetesObject abstracts how the state is read and written for a specific object kind.
+type kubernetesObject struct {
+	kind    string
+	new     func() client.Object
+	getData func(client.Object) ([]byte, bool)
+	setData func(client.Object, []byte)
+}
+
+// NewConfigMap returns a Kubernetes store that persists state in a ConfigMap.
+func NewConfigMap(c client.Client, namespace, name string) *Kubernetes {
+	return &Kubernetes{
+		client:    c,
+		namespace: namespace,
+		name:      name,
+		object: kubernetesObject{
+			kind: "ConfigMap",
+			new: func() client.Object {
+				return &corev1.ConfigMap{}
+			},
+			getData: func(o client.Object) ([]byte, bool) {
+				data, ok := o.(*corev1.ConfigMap).Data[StateKey]
+				return []byte(data), ok
+			},
+			setData: func(o client.Object, data []byte) {
+				cm := o.(*corev1.ConfigMap)
+				if cm.Data == nil {
+					cm.Data = map[string]string{}
+				}
+				cm.Data[StateKey] = string(data)
+			},
+		},
+	}
+}
+
+// NewSecret returns a Kubernetes store that persists state in a Secret. It should be preferred
+// over NewConfigMap when task outputs contain sensitive data.
+func NewSecret(c client.Client, namespace, name string) *Kubernetes {
+	return &Kubernetes{
+		client:    c,
+		namespace: namespace,
+		name:      name,
+		object: kubernetesObject{
+			kind: "Secret",
+			new: func() client.Object {
+				return &corev1.Secret{}
+			},
+			getData: func(o client.Object) ([]byte, bool) {
+				data, ok := o.(*corev1.Secret).Data[StateKey]
+				return data, ok
+			},
+			setData: func(o client.Object, data []byte) {
+				s := o.(*corev1.Secret)
+				if s.Data == nil {
+					s.Data = map[string][]byte{}
+				}
+				s.Data[StateKey] = data
+			},
+		},
+	}
+}
+
+// Load satisfies workflow.StateStore.
+func (k *Kubernetes) Load(ctx context.Context) (*workflow.State, error) {
+	obj := k.object.new()
+	err := k.client.Get(ctx, k.key(), obj)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading workflow state %s %s: %v", k.object.kind, k.name, err)
+	}
+
+	data, ok := k.object.getData(obj)
+	if !ok {
+		return nil, nil
+	}
+
+	return unmarshalState(data)
+}
+
+// Save satisfies workflow.StateStore.
+func (k *Kubernetes) Save(ctx context.Context, state *workflow.State) error {
+	data, err := yaml.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("marshalling workflow state: %v", err)
+	}
+
+	obj := k.object.new()
+	err = k.client.Get(ctx, k.key(), obj)
+	if apierrors.IsNotFound(err) {
+		obj.SetNamespace(k.namespace)
+		obj.SetName(k.name)
+		k.object.setData(obj, data)
+		if err := k.client.Create(ctx, obj); err != nil {
+			return fmt.Errorf("creating workflow state %s %s: %v", k.object.kind, k.name, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading workflow state %s %s: %v", k.object.kind, k.name, err)
+	}
+
+	k.object.setData(obj, data)
+	if err := k.client.Update(ctx, obj); err != nil {
+		return fmt.Errorf("updating workflow state %s %s: %v", k.object.kind, k.name, err)
+	}
+
+	return nil
+}
+
+// Delete satisfies workflow.StateStore.
+func (k *Kubernetes) Delete(ctx context.Context) error {
+	obj := k.object.new()
+	obj.SetNamespace(k.namespace)
+	obj.SetName(k.name)
+	if err := k.client.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("deleting workflow state %s %s: %v", k.object.kind, k.name, err)
+	}
+
+	return nil
+}
+
+func (k *Kubernetes) key() client.ObjectKey {
+	return client.ObjectKey{Namespace: k.namespace, Name: k.name}
+}
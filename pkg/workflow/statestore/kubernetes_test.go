@@ -0,0 +1,75 @@
+package statestore_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/pkg/workflow/statestore"
+)
+
+func TestConfigMapSaveLoadAndDelete(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := fake.NewClientBuilder().Build()
+	store := statestore.NewConfigMap(c, "eksa-system", "workflow-state")
+
+	state, err := store.Load(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(state).To(BeNil())
+
+	g.Expect(store.Save(ctx, testState())).To(Succeed())
+	cm := &corev1.ConfigMap{}
+	g.Expect(c.Get(ctx, client.ObjectKey{Namespace: "eksa-system", Name: "workflow-state"}, cm)).To(Succeed())
+	g.Expect(cm.Data).To(HaveKey(statestore.StateKey))
+
+	// Saving again updates the existing object.
+	updated := testState()
+	updated.CompletedTasks = append(updated.CompletedTasks, "task3")
+	g.Expect(store.Save(ctx, updated)).To(Succeed())
+
+	state, err = store.Load(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(state).To(Equal(updated))
+
+	g.Expect(store.Delete(ctx)).To(Succeed())
+	err = c.Get(ctx, client.ObjectKey{Namespace: "eksa-system", Name: "workflow-state"}, cm)
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	g.Expect(store.Delete(ctx)).To(Succeed())
+}
+
+func TestSecretSaveAndLoad(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := fake.NewClientBuilder().Build()
+	store := statestore.NewSecret(c, "eksa-system", "workflow-state")
+
+	g.Expect(store.Save(ctx, testState())).To(Succeed())
+	secret := &corev1.Secret{}
+	g.Expect(c.Get(ctx, client.ObjectKey{Namespace: "eksa-system", Name: "workflow-state"}, secret)).To(Succeed())
+	g.Expect(secret.Data).To(HaveKey(statestore.StateKey))
+
+	state, err := store.Load(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(state).To(Equal(testState()))
+}
+
+func TestSecretLoadMissingKey(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := fake.NewClientBuilder().Build()
+	secret := &corev1.Secret{}
+	secret.Namespace = "eksa-system"
+	secret.Name = "workflow-state"
+	g.Expect(c.Create(ctx, secret)).To(Succeed())
+	store := statestore.NewSecret(c, "eksa-system", "workflow-state")
+
+	state, err := store.Load(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(state).To(BeNil())
+}
@@ -8,7 +8,8 @@ type TaskName string
 // Task represents an individual step within a workflow that can be run.
 type Task interface {
 	// RunTask executes the task. Tasks may return a context that should be used in subsequent task
-	// execution.
+	// execution. When run by a Workflow, the values of the context are only available until the
+	// task returns, so it shouldn't be kept to be used afterwards.
 	RunTask(context.Context) (context.Context, error)
 }
 
@@ -31,4 +32,21 @@ func (fn TaskFunc) RunTask(ctx context.Context) (context.Context, error) {
 type namedTask struct {
 	Task
 	Name TaskName
+
+	// Dependencies are the tasks that must complete successfully before this task can run.
+	Dependencies []TaskName
+}
+
+// TaskOption configures how a task is scheduled when appended to a Workflow.
+type TaskOption func(*namedTask)
+
+// WithDependencies declares the tasks that must complete before the appended task runs. A task
+// may run concurrently with any task it doesn't transitively depend on. Calling WithDependencies
+// without arguments lets the task run as soon as the workflow starts.
+//
+// Tasks appended without WithDependencies depend on the task appended immediately before them.
+func WithDependencies(names ...TaskName) TaskOption {
+	return func(t *namedTask) {
+		t.Dependencies = append([]TaskName{}, names...)
+	}
 }
@@ -10,9 +10,27 @@ type Config struct {
 	// from hook or from a task. The original error is alwasy returned from the workflow's Execute.
 	// Optional. Defaults to a no-op handler.
 	ErrorHandler ErrorHandler
+
+	// MaxConcurrency is the maximum number of tasks that can run at the same time.
+	// Optional. Defaults to DefaultMaxConcurrency.
+	MaxConcurrency int
+
+	// StateStore persists the workflow progress. When a workflow is executed with a store that
+	// contains the state of a previous, interrupted execution, completed tasks are skipped.
+	// Optional. Defaults to not persisting progress.
+	StateStore StateStore
+
+	// ContextCodec serializes the contexts returned by tasks so they can be restored for
+	// completed tasks when resuming a workflow. Optional. When nil, completed tasks are restored
+	// with the context they would have received.
+	ContextCodec ContextCodec
 }
 
-// Workflow defines an abstract workflow that can execute a serialized set of tasks.
+// DefaultMaxConcurrency is the default maximum number of tasks a workflow runs concurrently.
+const DefaultMaxConcurrency = 4
+
+// Workflow defines an abstract workflow that executes a set of tasks ordered by their
+// dependencies. Tasks that don't depend on each other may run concurrently.
 type Workflow struct {
 	Config
 
@@ -35,6 +53,10 @@ func New(cfg Config) *Workflow {
 		cfg.ErrorHandler = nopErrorHandler
 	}
 
+	if cfg.MaxConcurrency < 1 {
+		cfg.MaxConcurrency = DefaultMaxConcurrency
+	}
+
 	wflw := &Workflow{
 		Config:        cfg,
 		taskNames:     make(map[TaskName]struct{}),
@@ -47,16 +69,41 @@ func New(cfg Config) *Workflow {
 
 // AppendTask appends t to the list of workflow tasks. Task names must be unique within a workflow.
 // Duplicate names will receive an ErrDuplicateTaskName.
-func (w *Workflow) AppendTask(name TaskName, t Task) error {
+//
+// By default t depends on the previously appended task so tasks run serially. Use WithDependencies
+// to declare the tasks t depends on instead. Dependencies must have been appended before t,
+// otherwise an ErrUnknownDependency is returned.
+func (w *Workflow) AppendTask(name TaskName, t Task, opts ...TaskOption) error {
 	if _, found := w.taskNames[name]; found {
 		return ErrDuplicateTaskName{name}
 	}
-	w.tasks = append(w.tasks, namedTask{Task: t, Name: name})
+
+	task := namedTask{Task: t, Name: name}
+	if len(w.tasks) > 0 {
+		task.Dependencies = []TaskName{w.tasks[len(w.tasks)-1].Name}
+	}
+
+	for _, opt := range opts {
+		opt(&task)
+	}
+
+	for _, dep := range task.Dependencies {
+		if _, found := w.taskNames[dep]; !found {
+			return ErrUnknownDependency{Name: name, Dependency: dep}
+		}
+	}
+
+	w.tasks = append(w.tasks, task)
 	w.taskNames[name] = struct{}{}
 	return nil
 }
 
-// Execute executes the workflow running any pre and post hooks registered for each task.
+// Execute executes the workflow running any pre and post hooks registered for each task. Tasks
+// run as soon as all their dependencies complete, with at most MaxConcurrency tasks running at
+// the same time. Each task receives the contexts returned by its dependencies merged together.
+//
+// When a task fails no further tasks are started, tasks already running are awaited and the
+// first error is returned. The ErrorHandler is called for every failed task.
 func (w *Workflow) Execute(ctx context.Context) error {
 	var err error
 
@@ -64,18 +111,8 @@ func (w *Workflow) Execute(ctx context.Context) error {
 		return w.handleError(ctx, err)
 	}
 
-	for _, task := range w.tasks {
-		if ctx, err = w.runPreTaskHooks(ctx, task.Name); err != nil {
-			return w.handleError(ctx, err)
-		}
-
-		if ctx, err = task.RunTask(ctx); err != nil {
-			return w.handleError(ctx, err)
-		}
-
-		if ctx, err = w.runPostTaskHooks(ctx, task.Name); err != nil {
-			return w.handleError(ctx, err)
-		}
+	if ctx, err = w.runTasks(ctx); err != nil {
+		return err
 	}
 
 	if ctx, err = runHooks(ctx, w.postWorkflowHooks); err != nil {
@@ -125,6 +162,21 @@ func (w *Workflow) runPostTaskHooks(ctx context.Context, id TaskName) (context.C
 	return ctx, nil
 }
 
+// runTask executes t surrounded by its pre and post task hooks.
+func (w *Workflow) runTask(ctx context.Context, t namedTask) (context.Context, error) {
+	var err error
+
+	if ctx, err = w.runPreTaskHooks(ctx, t.Name); err != nil {
+		return ctx, err
+	}
+
+	if ctx, err = t.RunTask(ctx); err != nil {
+		return ctx, err
+	}
+
+	return w.runPostTaskHooks(ctx, t.Name)
+}
+
 func runHooks(ctx context.Context, hooks []Task) (context.Context, error) {
 	var err error
 	for _, hook := range hooks {
@@ -3,7 +3,10 @@ package workflow_test
 import (
 	"context"
 	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	gomock "github.com/golang/mock/gomock"
 	"github.com/onsi/gomega"
@@ -348,3 +351,244 @@ func TestDuplicateTaskNames(t *testing.T) {
 	err = wflw.AppendTask(taskName, task2)
 	g.Expect(err).To(gomega.HaveOccurred())
 }
+
+func TestAppendTaskUnknownDependency(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	wflw := workflow.New(workflow.Config{})
+
+	err := wflw.AppendTask("task1", nopTask(), workflow.WithDependencies("task2"))
+	g.Expect(err).To(gomega.MatchError(workflow.ErrUnknownDependency{Name: "task1", Dependency: "task2"}))
+}
+
+func TestWorkflowExecuteIndependentTasksConcurrently(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	task1Started := make(chan struct{})
+	task2Started := make(chan struct{})
+
+	// Each task waits for the other to start so the workflow only succeeds if they run concurrently.
+	waitFor := func(started, other chan struct{}) workflow.Task {
+		return workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+			close(started)
+			select {
+			case <-other:
+				return ctx, nil
+			case <-time.After(5 * time.Second):
+				return ctx, errors.New("tasks didn't run concurrently")
+			}
+		})
+	}
+
+	wflw := workflow.New(workflow.Config{})
+	g.Expect(wflw.AppendTask("task1", waitFor(task1Started, task2Started))).To(gomega.Succeed())
+	g.Expect(wflw.AppendTask("task2", waitFor(task2Started, task1Started), workflow.WithDependencies())).To(gomega.Succeed())
+
+	g.Expect(wflw.Execute(context.Background())).To(gomega.Succeed())
+}
+
+func TestWorkflowExecuteMaxConcurrency(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	var running, maxRunning int32
+	task := workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return ctx, nil
+	})
+
+	wflw := workflow.New(workflow.Config{MaxConcurrency: 2})
+	for _, name := range []workflow.TaskName{"task1", "task2", "task3", "task4"} {
+		g.Expect(wflw.AppendTask(name, task, workflow.WithDependencies())).To(gomega.Succeed())
+	}
+
+	g.Expect(wflw.Execute(context.Background())).To(gomega.Succeed())
+	g.Expect(maxRunning).To(gomega.BeEquivalentTo(2))
+}
+
+func TestWorkflowExecuteDependencies(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	var mu sync.Mutex
+	var order []workflow.TaskName
+	record := func(name workflow.TaskName, delay time.Duration) workflow.Task {
+		return workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+			time.Sleep(delay)
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, name)
+			return ctx, nil
+		})
+	}
+
+	wflw := workflow.New(workflow.Config{})
+	g.Expect(wflw.AppendTask("task1", record("task1", 20*time.Millisecond))).To(gomega.Succeed())
+	g.Expect(wflw.AppendTask("task2", record("task2", 0), workflow.WithDependencies())).To(gomega.Succeed())
+	g.Expect(wflw.AppendTask("task3", record("task3", 0), workflow.WithDependencies("task1", "task2"))).To(gomega.Succeed())
+	g.Expect(wflw.AppendTask("task4", record("task4", 0))).To(gomega.Succeed())
+
+	g.Expect(wflw.Execute(context.Background())).To(gomega.Succeed())
+	g.Expect(order).To(gomega.Equal([]workflow.TaskName{"task2", "task1", "task3", "task4"}))
+}
+
+type testContextKey string
+
+func TestWorkflowExecuteMergesContexts(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	task2Done := make(chan struct{})
+	setValues := func(values map[testContextKey]string, wait, done chan struct{}) workflow.Task {
+		return workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+			if wait != nil {
+				<-wait
+			}
+			for k, v := range values {
+				ctx = context.WithValue(ctx, k, v)
+			}
+			if done != nil {
+				close(done)
+			}
+			return ctx, nil
+		})
+	}
+
+	keys := []testContextKey{"shared", "task1", "base", "task4"}
+	var task3Values, postWorkflowValues map[testContextKey]interface{}
+	capture := func(values *map[testContextKey]interface{}) workflow.Task {
+		return workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+			*values = make(map[testContextKey]interface{}, len(keys))
+			for _, k := range keys {
+				(*values)[k] = ctx.Value(k)
+			}
+			return ctx, nil
+		})
+	}
+
+	wflw := workflow.New(workflow.Config{})
+	wflw.BindPreWorkflowHook(setValues(map[testContextKey]string{"shared": "pre", "base": "pre"}, nil, nil))
+	// task1 completes after task2 but task2 was appended later so its values take precedence.
+	g.Expect(wflw.AppendTask("task1", setValues(map[testContextKey]string{"shared": "task1", "task1": "task1"}, task2Done, nil))).To(gomega.Succeed())
+	g.Expect(wflw.AppendTask("task2", setValues(map[testContextKey]string{"shared": "task2"}, nil, task2Done), workflow.WithDependencies())).To(gomega.Succeed())
+	g.Expect(wflw.AppendTask("task3", capture(&task3Values), workflow.WithDependencies("task1", "task2"))).To(gomega.Succeed())
+	g.Expect(wflw.AppendTask("task4", setValues(map[testContextKey]string{"task4": "task4"}, nil, nil), workflow.WithDependencies())).To(gomega.Succeed())
+	wflw.BindPostWorkflowHook(capture(&postWorkflowValues))
+
+	g.Expect(wflw.Execute(context.Background())).To(gomega.Succeed())
+
+	g.Expect(task3Values["shared"]).To(gomega.Equal("task2"))
+	g.Expect(task3Values["task1"]).To(gomega.Equal("task1"))
+	g.Expect(task3Values["base"]).To(gomega.Equal("pre"))
+	g.Expect(task3Values["task4"]).To(gomega.BeNil())
+
+	g.Expect(postWorkflowValues["shared"]).To(gomega.Equal("task2"))
+	g.Expect(postWorkflowValues["task1"]).To(gomega.Equal("task1"))
+	g.Expect(postWorkflowValues["task4"]).To(gomega.Equal("task4"))
+}
+
+func TestWorkflowExecuteMergesValuesSetByTasks(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	set := func(k testContextKey, v interface{}) workflow.Task {
+		return workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+			return context.WithValue(ctx, k, v), nil
+		})
+	}
+	var got []interface{}
+	read := workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		got = append(got, ctx.Value(testContextKey("shared")), ctx.Value(testContextKey("list")))
+		return ctx, nil
+	})
+
+	wflw := workflow.New(workflow.Config{})
+	wflw.BindPreWorkflowHook(set("shared", "pre"))
+	g.Expect(wflw.AppendTask("task1", set("shared", "task1"), workflow.WithDependencies())).To(gomega.Succeed())
+	// task2 sets the value it received, which doesn't change it, so task1's value is used.
+	g.Expect(wflw.AppendTask("task2", set("shared", "pre"), workflow.WithDependencies())).To(gomega.Succeed())
+	// Values that can't be compared are merged too.
+	g.Expect(wflw.AppendTask("task3", set("list", []string{"a"}), workflow.WithDependencies())).To(gomega.Succeed())
+	g.Expect(wflw.AppendTask("task4", read, workflow.WithDependencies("task1", "task2", "task3"))).To(gomega.Succeed())
+
+	g.Expect(wflw.Execute(context.Background())).To(gomega.Succeed())
+	g.Expect(got).To(gomega.Equal([]interface{}{"task1", []string{"a"}}))
+}
+
+func TestWorkflowExecuteTaskContextUsableAfterTaskReturns(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	type savedContext struct{ ctx context.Context }
+	save := workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		return context.WithValue(ctx, testContextKey("saved"), &savedContext{ctx: ctx}), nil
+	})
+	var gotPre, gotMissing interface{}
+	read := workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		saved := ctx.Value(testContextKey("saved")).(*savedContext)
+		gotPre = saved.ctx.Value(testContextKey("pre"))
+		gotMissing = saved.ctx.Value(testContextKey("missing"))
+		return ctx, nil
+	})
+
+	wflw := workflow.New(workflow.Config{})
+	wflw.BindPreWorkflowHook(workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		return context.WithValue(ctx, testContextKey("pre"), "pre"), nil
+	}))
+	g.Expect(wflw.AppendTask("task1", workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		return ctx, nil
+	}))).To(gomega.Succeed())
+	g.Expect(wflw.AppendTask("task2", save)).To(gomega.Succeed())
+	g.Expect(wflw.AppendTask("task3", read)).To(gomega.Succeed())
+
+	g.Expect(wflw.Execute(context.Background())).To(gomega.Succeed())
+	g.Expect(gotPre).To(gomega.Equal("pre"))
+	g.Expect(gotMissing).To(gomega.BeNil())
+}
+
+func TestWorkflowExecuteFailedTaskSkipsDependents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := gomega.NewWithT(t)
+
+	expect := errors.New("expected error")
+
+	var handled []error
+	wflw := workflow.New(workflow.Config{
+		ErrorHandler: func(_ context.Context, err error) {
+			handled = append(handled, err)
+		},
+	})
+
+	failing := workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		return ctx, expect
+	})
+
+	var independentRan int32
+	independent := workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		atomic.StoreInt32(&independentRan, 1)
+		return ctx, nil
+	})
+
+	// These shouldn't run.
+	dependent := NewMockTask(ctrl)
+	postWorkflowHook := NewMockTask(ctrl)
+
+	g.Expect(wflw.AppendTask("failing", failing)).To(gomega.Succeed())
+	g.Expect(wflw.AppendTask("independent", independent, workflow.WithDependencies())).To(gomega.Succeed())
+	g.Expect(wflw.AppendTask("dependent", dependent, workflow.WithDependencies("failing"))).To(gomega.Succeed())
+	wflw.BindPostWorkflowHook(postWorkflowHook)
+
+	err := wflw.Execute(context.Background())
+	g.Expect(err).To(gomega.MatchError(expect))
+	g.Expect(handled).To(gomega.ConsistOf(expect))
+	g.Expect(atomic.LoadInt32(&independentRan)).To(gomega.BeEquivalentTo(1))
+}
+
+func nopTask() workflow.Task {
+	return workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		return ctx, nil
+	})
+}
@@ -0,0 +1,58 @@
+package workflowcontext
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// values are the serializable values this package stores in a context.
+type values struct {
+	BootstrapCluster  *types.Cluster `json:"bootstrapCluster,omitempty"`
+	ManagementCluster *types.Cluster `json:"managementCluster,omitempty"`
+	WorkloadCluster   *types.Cluster `json:"workloadCluster,omitempty"`
+}
+
+// Codec serializes the workflow context data managed by this package. It satisfies the
+// workflow.ContextCodec interface so task outputs can be persisted and restored when resuming
+// a workflow.
+type Codec struct{}
+
+// Encode returns the JSON representation of the workflow context data in ctx.
+func (Codec) Encode(ctx context.Context) ([]byte, error) {
+	v := values{
+		BootstrapCluster:  clusterValue(ctx, bootstrapCluster),
+		ManagementCluster: clusterValue(ctx, managementCluster),
+		WorkloadCluster:   clusterValue(ctx, workloadCluster),
+	}
+
+	return json.Marshal(v)
+}
+
+// Decode returns a context based on ctx populated with the workflow context data in data.
+func (Codec) Decode(ctx context.Context, data []byte) (context.Context, error) {
+	v := values{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+
+	if v.BootstrapCluster != nil {
+		ctx = WithBootstrapCluster(ctx, v.BootstrapCluster)
+	}
+
+	if v.ManagementCluster != nil {
+		ctx = WithManagementCluster(ctx, v.ManagementCluster)
+	}
+
+	if v.WorkloadCluster != nil {
+		ctx = WithWorkloadCluster(ctx, v.WorkloadCluster)
+	}
+
+	return ctx, nil
+}
+
+func clusterValue(ctx context.Context, key contextKey) *types.Cluster {
+	cluster, _ := ctx.Value(key).(*types.Cluster)
+	return cluster
+}
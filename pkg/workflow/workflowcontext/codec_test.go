@@ -0,0 +1,51 @@
+package workflowcontext_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/workflow"
+	"github.com/aws/eks-anywhere/pkg/workflow/workflowcontext"
+)
+
+var _ workflow.ContextCodec = workflowcontext.Codec{}
+
+func TestCodecRoundTrip(t *testing.T) {
+	g := NewWithT(t)
+	bootstrap := &types.Cluster{Name: "bootstrap", KubeconfigFile: "bootstrap.kubeconfig"}
+	workload := &types.Cluster{Name: "workload", KubeconfigFile: "workload.kubeconfig"}
+
+	ctx := workflowcontext.WithBootstrapAsManagementCluster(context.Background(), bootstrap)
+	ctx = workflowcontext.WithWorkloadCluster(ctx, workload)
+
+	data, err := workflowcontext.Codec{}.Encode(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	restored, err := workflowcontext.Codec{}.Decode(context.Background(), data)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(workflowcontext.BootstrapCluster(restored)).To(Equal(bootstrap))
+	g.Expect(workflowcontext.ManagementCluster(restored)).To(Equal(bootstrap))
+	g.Expect(workflowcontext.WorkloadCluster(restored)).To(Equal(workload))
+}
+
+func TestCodecEncodeEmptyContext(t *testing.T) {
+	g := NewWithT(t)
+
+	data, err := workflowcontext.Codec{}.Encode(context.Background())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(Equal("{}"))
+
+	restored, err := workflowcontext.Codec{}.Decode(context.Background(), data)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(restored.Value("bootstrap-cluster")).To(BeNil())
+}
+
+func TestCodecDecodeInvalidData(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := workflowcontext.Codec{}.Decode(context.Background(), []byte("not-json"))
+	g.Expect(err).To(HaveOccurred())
+}
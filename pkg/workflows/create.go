@@ -106,6 +106,13 @@ func (s *CreateBootStrapClusterTask) Run(ctx context.Context, commandContext *ta
 				return &CollectMgmtClusterDiagnosticsTask{}
 			}
 		}
+		if commandContext.ClusterSpec.Cluster.Spec.EncryptionConfiguration != nil {
+			logger.Info("Creating encryption configuration secret on bootstrap cluster")
+			if err := commandContext.ClusterManager.CreateEncryptionConfigSecret(ctx, commandContext.BootstrapCluster, commandContext.ClusterSpec); err != nil {
+				commandContext.SetError(err)
+				return &CollectMgmtClusterDiagnosticsTask{}
+			}
+		}
 
 		return &CreateWorkloadClusterTask{}
 	}
@@ -144,6 +151,14 @@ func (s *CreateBootStrapClusterTask) Run(ctx context.Context, commandContext *ta
 		}
 	}
 
+	if commandContext.ClusterSpec.Cluster.Spec.EncryptionConfiguration != nil {
+		logger.Info("Creating encryption configuration secret on bootstrap cluster")
+		if err = commandContext.ClusterManager.CreateEncryptionConfigSecret(ctx, bootstrapCluster, commandContext.ClusterSpec); err != nil {
+			commandContext.SetError(err)
+			return &CollectMgmtClusterDiagnosticsTask{}
+		}
+	}
+
 	logger.Info("Provider specific post-setup")
 	if err = commandContext.Provider.PostBootstrapSetup(ctx, commandContext.ClusterSpec.Cluster, bootstrapCluster); err != nil {
 		commandContext.SetError(err)
@@ -169,12 +184,13 @@ func (s *CreateBootStrapClusterTask) Checkpoint() *task.CompletedTask {
 
 func (s *SetAndValidateTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	logger.Info("Performing setup and validations")
-	runner := validations.NewRunner()
+	// The provider validation sets up the provider and the spec for the other validations, so they run sequentially.
+	runner := validations.NewRunner(validations.WithConcurrency(1))
 	runner.Register(s.providerValidation(ctx, commandContext)...)
 	runner.Register(commandContext.GitOpsManager.Validations(ctx, commandContext.ClusterSpec)...)
 	runner.Register(s.validations(ctx, commandContext)...)
 
-	err := runner.Run()
+	err := runner.Run(ctx)
 	if err != nil {
 		commandContext.SetError(err)
 		return nil
@@ -184,22 +200,26 @@ func (s *SetAndValidateTask) Run(ctx context.Context, commandContext *task.Comma
 
 func (s *SetAndValidateTask) validations(ctx context.Context, commandContext *task.CommandContext) []validations.Validation {
 	return []validations.Validation{
-		func() *validations.ValidationResult {
-			return &validations.ValidationResult{
-				Name: "create preflight validations pass",
-				Err:  commandContext.Validations.PreflightValidations(ctx),
-			}
+		validations.Validation{
+			Name: "create preflight validations pass",
+			Run: func(ctx context.Context) *validations.ValidationResult {
+				return &validations.ValidationResult{
+					Err: commandContext.Validations.PreflightValidations(ctx),
+				}
+			},
 		},
 	}
 }
 
 func (s *SetAndValidateTask) providerValidation(ctx context.Context, commandContext *task.CommandContext) []validations.Validation {
 	return []validations.Validation{
-		func() *validations.ValidationResult {
-			return &validations.ValidationResult{
-				Name: fmt.Sprintf("%s Provider setup is valid", commandContext.Provider.Name()),
-				Err:  commandContext.Provider.SetupAndValidateCreateCluster(ctx, commandContext.ClusterSpec),
-			}
+		validations.Validation{
+			Name: fmt.Sprintf("%s Provider setup is valid", commandContext.Provider.Name()),
+			Run: func(ctx context.Context) *validations.ValidationResult {
+				return &validations.ValidationResult{
+					Err: commandContext.Provider.SetupAndValidateCreateCluster(ctx, commandContext.ClusterSpec),
+				}
+			},
 		},
 	}
 }
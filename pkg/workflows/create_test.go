@@ -326,6 +326,53 @@ func TestCreateRunAWSIamConfigSuccess(t *testing.T) {
 	}
 }
 
+func TestCreateRunEncryptionConfigurationFail(t *testing.T) {
+	wantError := errors.New("test error")
+	test := newCreateTest(t)
+
+	test.clusterSpec.Cluster.Spec.EncryptionConfiguration = &v1alpha1.EncryptionConfiguration{
+		Providers: []v1alpha1.EncryptionProvider{{Type: v1alpha1.AESCBCEncryptionProvider}},
+	}
+	test.expectSetup()
+	test.expectPreflightValidationsToPass()
+	test.provider.EXPECT().BootstrapClusterOpts(test.clusterSpec).Return([]bootstrapper.BootstrapClusterOption{bootstrapper.WithExtraDockerMounts()}, nil)
+	test.bootstrapper.EXPECT().CreateBootstrapCluster(test.ctx, test.clusterSpec, gomock.Not(gomock.Nil())).Return(test.bootstrapCluster, nil)
+	test.provider.EXPECT().PreCAPIInstallOnBootstrap(test.ctx, test.bootstrapCluster, test.clusterSpec)
+	test.clusterManager.EXPECT().InstallCAPI(test.ctx, test.clusterSpec, test.bootstrapCluster, test.provider)
+	test.clusterManager.EXPECT().CreateEncryptionConfigSecret(test.ctx, test.bootstrapCluster, test.clusterSpec).Return(wantError)
+	test.clusterManager.EXPECT().SaveLogsManagementCluster(test.ctx, test.clusterSpec, test.bootstrapCluster)
+	test.writer.EXPECT().Write(fmt.Sprintf("%s-checkpoint.yaml", test.clusterSpec.Cluster.Name), gomock.Any())
+
+	if err := test.run(); err == nil {
+		t.Fatalf("Create.Run() err = %v, want err = %v", err, wantError)
+	}
+}
+
+func TestCreateRunEncryptionConfigurationSuccess(t *testing.T) {
+	test := newCreateTest(t)
+
+	test.clusterSpec.Cluster.Spec.EncryptionConfiguration = &v1alpha1.EncryptionConfiguration{
+		Providers: []v1alpha1.EncryptionProvider{{Type: v1alpha1.AESCBCEncryptionProvider}},
+	}
+	test.clusterManager.EXPECT().CreateEncryptionConfigSecret(test.ctx, test.bootstrapCluster, test.clusterSpec)
+	test.expectSetup()
+	test.expectCreateBootstrap()
+	test.expectCreateWorkload()
+	test.expectInstallResourcesOnManagementTask()
+	test.expectMoveManagement()
+	test.expectInstallEksaComponents()
+	test.expectInstallGitOpsManager()
+	test.expectWriteClusterConfig()
+	test.expectDeleteBootstrap()
+	test.expectPreflightValidationsToPass()
+	test.expectCuratedPackagesInstallation()
+
+	err := test.run()
+	if err != nil {
+		t.Fatalf("Create.Run() err = %v, want err = nil", err)
+	}
+}
+
 func TestCreateRunSuccessForceCleanup(t *testing.T) {
 	test := newCreateTest(t)
 	test.forceCleanup = true
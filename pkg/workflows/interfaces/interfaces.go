@@ -17,6 +17,8 @@ type Bootstrapper interface {
 }
 
 type ClusterManager interface {
+	BackupCAPI(ctx context.Context, cluster *types.Cluster, managementStatePath string) error
+	RestoreCAPI(ctx context.Context, cluster *types.Cluster, managementStatePath string) error
 	MoveCAPI(ctx context.Context, from, to *types.Cluster, clusterName string, clusterSpec *cluster.Spec, checkers ...types.NodeReadyChecker) error
 	CreateWorkloadCluster(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) (*types.Cluster, error)
 	RunPostCreateWorkloadCluster(ctx context.Context, managementCluster, workloadCluster *types.Cluster, clusterSpec *cluster.Spec) error
@@ -40,6 +42,7 @@ type ClusterManager interface {
 	Upgrade(ctx context.Context, cluster *types.Cluster, currentSpec, newSpec *cluster.Spec) (*types.ChangeDiff, error)
 	InstallAwsIamAuth(ctx context.Context, managementCluster, workloadCluster *types.Cluster, clusterSpec *cluster.Spec) error
 	CreateAwsIamAuthCaSecret(ctx context.Context, bootstrapCluster *types.Cluster, workloadClusterName string) error
+	CreateEncryptionConfigSecret(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error
 	DeletePackageResources(ctx context.Context, managementCluster *types.Cluster, clusterName string) error
 }
 
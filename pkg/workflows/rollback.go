@@ -0,0 +1,217 @@
+package workflows
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/task"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// upgradeSnapshot holds the state of a cluster taken before an upgrade starts.
+type upgradeSnapshot struct {
+	// clusterSpec is the spec the cluster was running before the upgrade, including the
+	// Bundles and EKS-D release it referenced.
+	clusterSpec *cluster.Spec
+
+	// capiBackupPath is the directory containing a copy of the CAPI objects of the management cluster.
+	capiBackupPath string
+}
+
+// restoreInstructions tells how to manually restore the CAPI objects backup into cluster.
+func (s *upgradeSnapshot) restoreInstructions(cluster *types.Cluster) string {
+	return fmt.Sprintf("CAPI objects backup is in %s, restore it with 'clusterctl move --from-directory %s --to-kubeconfig %s'",
+		s.capiBackupPath, s.capiBackupPath, cluster.KubeconfigFile)
+}
+
+func (c *Upgrade) takeSnapshot(ctx context.Context, commandContext *task.CommandContext) (*upgradeSnapshot, error) {
+	logger.Info("Taking snapshot of cluster before upgrade")
+	clusterName := commandContext.ClusterSpec.Cluster.Name
+	currentSpec, err := c.clusterManager.GetCurrentClusterSpec(ctx, commandContext.ManagementCluster, clusterName)
+	if err != nil {
+		return nil, fmt.Errorf("taking pre-upgrade snapshot: %v", err)
+	}
+
+	backupPath := filepath.Join(c.writer.Dir(), fmt.Sprintf("%s-pre-upgrade-capi-backup", clusterName))
+	if err = c.clusterManager.BackupCAPI(ctx, commandContext.ManagementCluster, backupPath); err != nil {
+		return nil, fmt.Errorf("taking pre-upgrade snapshot: %v", err)
+	}
+	logger.V(3).Info("CAPI objects saved", "path", backupPath)
+
+	return &upgradeSnapshot{
+		clusterSpec:    currentSpec,
+		capiBackupPath: backupPath,
+	}, nil
+}
+
+// taskTracker records the upgrade tasks that were started so a failed upgrade only
+// reverts the steps that actually ran.
+type taskTracker struct {
+	started map[string]bool
+}
+
+func newTaskTracker() *taskTracker {
+	return &taskTracker{started: map[string]bool{}}
+}
+
+func (r *taskTracker) track(t task.Task) task.Task {
+	if t == nil {
+		return nil
+	}
+	return &trackedTask{Task: t, tracker: r}
+}
+
+func (r *taskTracker) ran(t task.Task) bool {
+	return r.started[t.Name()]
+}
+
+// trackedTask wraps a task so it and all the tasks that follow it are recorded by a taskTracker.
+type trackedTask struct {
+	task.Task
+	tracker *taskTracker
+}
+
+func (t *trackedTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	t.tracker.started[t.Name()] = true
+	return t.tracker.track(t.Task.Run(ctx, commandContext))
+}
+
+func (t *trackedTask) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
+	t.tracker.started[t.Name()] = true
+	next, err := t.Task.Restore(ctx, commandContext, completedTask)
+	return t.tracker.track(next), err
+}
+
+// rollbackSummary lists the changes reverted by a rollback and the ones that need manual intervention.
+type rollbackSummary struct {
+	reverted    []string
+	notReverted []string
+}
+
+func (s *rollbackSummary) log() {
+	logger.Info("Upgrade rollback summary")
+	for _, r := range s.reverted {
+		logger.Info(fmt.Sprintf("  Reverted: %s", r))
+	}
+	for _, r := range s.notReverted {
+		logger.MarkWarning(fmt.Sprintf("  Not reverted: %s", r))
+	}
+}
+
+// rollback reverts the changes made by a failed upgrade: it moves the CAPI management back to the
+// workload cluster and re-applies the specs from the pre-upgrade snapshot, with the provider set up
+// again with the pre-upgrade spec. Only the steps that ran before the failure are reverted.
+//
+// The CAPI backup is only restored when the move to the bootstrap cluster failed, since it can leave
+// the workload cluster without some of its CAPI objects. Once the objects are in the bootstrap cluster
+// they are moved back instead, and if that fails the backup is kept for a manual restore.
+func (c *Upgrade) rollback(ctx context.Context, commandContext *task.CommandContext, snapshot *upgradeSnapshot, tracker *taskTracker) error {
+	if !tracker.ran(&updateSecrets{}) {
+		logger.Info("Upgrade failed before modifying the cluster, nothing to roll back")
+		return nil
+	}
+
+	logger.Info("Rolling back cluster upgrade")
+	summary := &rollbackSummary{}
+	defer summary.log()
+
+	if tracker.ran(&upgradeCoreComponents{}) {
+		summary.notReverted = append(summary.notReverted, "core components (CAPI providers, CNI, GitOps, EKS-A controller and EKS-D) upgrades")
+	}
+
+	previousSpec := snapshot.clusterSpec
+	bootstrapCluster := commandContext.BootstrapCluster
+	workloadCluster := commandContext.WorkloadCluster
+
+	if bootstrapCluster != nil && commandContext.ManagementCluster == bootstrapCluster {
+		logger.Info("Moving cluster management from bootstrap back to workload cluster")
+		err := c.clusterManager.MoveCAPI(ctx, bootstrapCluster, workloadCluster, workloadCluster.Name, previousSpec, types.WithNodeRef())
+		if err != nil {
+			summary.notReverted = append(summary.notReverted,
+				fmt.Sprintf("cluster management is still in bootstrap cluster %s, %s", bootstrapCluster.Name, snapshot.restoreInstructions(workloadCluster)),
+			)
+			return fmt.Errorf("moving cluster management back to workload cluster: %v", err)
+		}
+		commandContext.ManagementCluster = workloadCluster
+		summary.reverted = append(summary.reverted, "moved cluster management back to workload cluster")
+	} else if tracker.ran(&moveManagementToBootstrapTask{}) {
+		logger.Info("Restoring CAPI objects in workload cluster from backup")
+		if err := c.clusterManager.RestoreCAPI(ctx, workloadCluster, snapshot.capiBackupPath); err != nil {
+			summary.notReverted = append(summary.notReverted,
+				fmt.Sprintf("workload cluster CAPI objects, %s", snapshot.restoreInstructions(workloadCluster)),
+			)
+			return fmt.Errorf("restoring CAPI objects in workload cluster: %v", err)
+		}
+		summary.reverted = append(summary.reverted, "restored workload cluster CAPI objects from backup")
+	}
+
+	eksaManagementCluster := commandContext.ManagementCluster
+
+	// The provider was set up with the spec of the failed upgrade, so it's set up again with the previous spec
+	// before generating the CAPI objects and the machine configs that are re-applied.
+	if tracker.ran(&upgradeWorkloadClusterTask{}) || tracker.ran(&updateClusterAndGitResources{}) {
+		logger.Info("Setting up provider with previous cluster spec")
+		if err := c.provider.SetupAndValidateUpgradeCluster(ctx, eksaManagementCluster, previousSpec, commandContext.ClusterSpec); err != nil {
+			summary.notReverted = append(summary.notReverted,
+				fmt.Sprintf("workload cluster CAPI objects and EKS-A cluster resources, %s", snapshot.restoreInstructions(eksaManagementCluster)),
+			)
+			return fmt.Errorf("setting up provider with previous cluster spec: %v", err)
+		}
+	}
+
+	if tracker.ran(&upgradeWorkloadClusterTask{}) {
+		logger.Info("Re-applying previous workload cluster spec")
+		if err := c.clusterManager.UpgradeCluster(ctx, eksaManagementCluster, workloadCluster, previousSpec, c.provider); err != nil {
+			summary.notReverted = append(summary.notReverted,
+				fmt.Sprintf("workload cluster CAPI objects, %s", snapshot.restoreInstructions(eksaManagementCluster)),
+			)
+			return fmt.Errorf("re-applying previous workload cluster spec: %v", err)
+		}
+		summary.reverted = append(summary.reverted, "workload cluster CAPI objects")
+
+		if commandContext.UpgradeChangeDiff.Changed() {
+			if err := c.clusterManager.ApplyBundles(ctx, previousSpec, eksaManagementCluster); err != nil {
+				return fmt.Errorf("re-applying previous bundles: %v", err)
+			}
+			summary.reverted = append(summary.reverted, "bundles")
+		}
+	}
+
+	if tracker.ran(&updateClusterAndGitResources{}) {
+		logger.Info("Re-applying previous EKS-A cluster resources")
+		datacenterConfig := c.provider.DatacenterConfig(previousSpec)
+		machineConfigs := c.provider.MachineConfigs(previousSpec)
+		if err := c.clusterManager.CreateEKSAResources(ctx, eksaManagementCluster, previousSpec, datacenterConfig, machineConfigs); err != nil {
+			return fmt.Errorf("re-applying previous EKS-A cluster resources: %v", err)
+		}
+		if err := c.eksdInstaller.InstallEksdManifest(ctx, previousSpec, eksaManagementCluster); err != nil {
+			return fmt.Errorf("re-applying previous EKS-D manifest: %v", err)
+		}
+		summary.reverted = append(summary.reverted, "EKS-A cluster resources")
+	}
+
+	if tracker.ran(&pauseEksaReconcile{}) {
+		logger.Info("Resuming EKS-A controller reconcile")
+		if err := c.clusterManager.ResumeEKSAControllerReconcile(ctx, eksaManagementCluster, previousSpec, c.provider); err != nil {
+			return fmt.Errorf("resuming EKS-A controller reconcile: %v", err)
+		}
+		summary.reverted = append(summary.reverted, "EKS-A controller reconcile resumed")
+		summary.notReverted = append(summary.notReverted, "GitOps cluster resources reconcile is still paused, resume it after checking the Git repository")
+	}
+
+	if bootstrapCluster != nil && !bootstrapCluster.ExistingManagement {
+		logger.Info("Deleting bootstrap cluster")
+		if err := c.bootstrapper.DeleteBootstrapCluster(ctx, bootstrapCluster, constants.Upgrade, false); err != nil {
+			summary.notReverted = append(summary.notReverted, fmt.Sprintf("bootstrap cluster %s was not deleted", bootstrapCluster.Name))
+			return fmt.Errorf("deleting bootstrap cluster: %v", err)
+		}
+		summary.reverted = append(summary.reverted, "bootstrap cluster deleted")
+	}
+
+	logger.MarkSuccess("Cluster upgrade rolled back")
+	return nil
+}
@@ -27,6 +27,8 @@ type Upgrade struct {
 	eksdInstaller     interfaces.EksdInstaller
 	eksdUpgrader      interfaces.EksdUpgrader
 	upgradeChangeDiff *types.ChangeDiff
+	rollbackOnFailure bool
+	validationReport  *validations.Report
 }
 
 func NewUpgrade(bootstrapper interfaces.Bootstrapper, provider providers.Provider,
@@ -47,6 +49,20 @@ func NewUpgrade(bootstrapper interfaces.Bootstrapper, provider providers.Provide
 	}
 }
 
+// WithRollback enables rolling back a failed upgrade. Before upgrading, a snapshot of the cluster spec and
+// CAPI objects is taken. If the upgrade fails, the cluster management is moved back to the workload cluster
+// and the specs from the snapshot are re-applied.
+func (c *Upgrade) WithRollback() *Upgrade {
+	c.rollbackOnFailure = true
+	return c
+}
+
+// WithValidationReport records the results of the setup and preflight validations in report.
+func (c *Upgrade) WithValidationReport(report *validations.Report) *Upgrade {
+	c.validationReport = report
+	return c
+}
+
 func (c *Upgrade) Run(ctx context.Context, clusterSpec *cluster.Spec, managementCluster *types.Cluster, workloadCluster *types.Cluster, validator interfaces.Validator, forceCleanup bool) error {
 	if forceCleanup {
 		if err := c.bootstrapper.DeleteBootstrapCluster(ctx, &types.Cluster{
@@ -70,12 +86,34 @@ func (c *Upgrade) Run(ctx context.Context, clusterSpec *cluster.Spec, management
 		EksdInstaller:     c.eksdInstaller,
 		EksdUpgrader:      c.eksdUpgrader,
 		UpgradeChangeDiff: c.upgradeChangeDiff,
+		ValidationReport:  c.validationReport,
 	}
+
+	var opts []task.TaskRunnerOpt
 	if features.IsActive(features.CheckpointEnabled()) {
-		return task.NewTaskRunner(&setupAndValidateTasks{}, c.writer, task.WithCheckpointFile()).RunTask(ctx, commandContext)
+		opts = append(opts, task.WithCheckpointFile())
+	}
+
+	if !c.rollbackOnFailure {
+		return task.NewTaskRunner(&setupAndValidateTasks{}, c.writer, opts...).RunTask(ctx, commandContext)
+	}
+
+	snapshot, err := c.takeSnapshot(ctx, commandContext)
+	if err != nil {
+		return err
+	}
+
+	tracker := newTaskTracker()
+	err = task.NewTaskRunner(tracker.track(&setupAndValidateTasks{}), c.writer, opts...).RunTask(ctx, commandContext)
+	if err == nil {
+		return nil
+	}
+
+	if rollbackErr := c.rollback(ctx, commandContext, snapshot, tracker); rollbackErr != nil {
+		return fmt.Errorf("%v, rolling back upgrade: %v", err, rollbackErr)
 	}
 
-	return task.NewTaskRunner(&setupAndValidateTasks{}, c.writer).RunTask(ctx, commandContext)
+	return err
 }
 
 type setupAndValidateTasks struct{}
@@ -124,10 +162,11 @@ func (s *setupAndValidateTasks) Run(ctx context.Context, commandContext *task.Co
 		return nil
 	}
 	commandContext.CurrentClusterSpec = currentSpec
-	runner := validations.NewRunner()
+	// The provider validation sets up the provider and the spec for the preflight validations, so they run sequentially.
+	runner := validations.NewRunner(validations.WithReport(commandContext.ValidationReport), validations.WithConcurrency(1))
 	runner.Register(s.validations(ctx, commandContext)...)
 
-	err = runner.Run()
+	err = runner.Run(ctx)
 	if err != nil {
 		commandContext.SetError(err)
 		return nil
@@ -138,17 +177,21 @@ func (s *setupAndValidateTasks) Run(ctx context.Context, commandContext *task.Co
 
 func (s *setupAndValidateTasks) validations(ctx context.Context, commandContext *task.CommandContext) []validations.Validation {
 	return []validations.Validation{
-		func() *validations.ValidationResult {
-			return &validations.ValidationResult{
-				Name: fmt.Sprintf("%s provider validation", commandContext.Provider.Name()),
-				Err:  commandContext.Provider.SetupAndValidateUpgradeCluster(ctx, commandContext.ManagementCluster, commandContext.ClusterSpec, commandContext.CurrentClusterSpec),
-			}
+		validations.Validation{
+			Name: fmt.Sprintf("%s provider validation", commandContext.Provider.Name()),
+			Run: func(ctx context.Context) *validations.ValidationResult {
+				return &validations.ValidationResult{
+					Err: commandContext.Provider.SetupAndValidateUpgradeCluster(ctx, commandContext.ManagementCluster, commandContext.ClusterSpec, commandContext.CurrentClusterSpec),
+				}
+			},
 		},
-		func() *validations.ValidationResult {
-			return &validations.ValidationResult{
-				Name: "upgrade preflight validations pass",
-				Err:  commandContext.Validations.PreflightValidations(ctx),
-			}
+		validations.Validation{
+			Name: "upgrade preflight validations pass",
+			Run: func(ctx context.Context) *validations.ValidationResult {
+				return &validations.ValidationResult{
+					Err: commandContext.Validations.PreflightValidations(ctx),
+				}
+			},
 		},
 	}
 }
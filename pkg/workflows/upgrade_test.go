@@ -596,3 +596,181 @@ func TestUpgradeWithCheckpointSecondRunSuccess(t *testing.T) {
 		t.Fatalf("Upgrade.Run() err = %v, want nil", err)
 	}
 }
+
+// newUpgradeRollbackTest returns an upgrade test whose new spec changes the template of the vSphere machine config,
+// so the provider is set up with a different spec for the upgrade and for the rollback.
+func newUpgradeRollbackTest(t *testing.T) *upgradeTestSetup {
+	features.ClearCache()
+	tt := newUpgradeSelfManagedClusterTest(t)
+	tt.currentClusterSpec = test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "cluster-name"
+		s.VSphereMachineConfigs = map[string]*v1alpha1.VSphereMachineConfig{
+			"machine-config": {Spec: v1alpha1.VSphereMachineConfigSpec{Template: "/templates/ubuntu-1-23"}},
+		}
+	})
+	tt.newClusterSpec.VSphereMachineConfigs = map[string]*v1alpha1.VSphereMachineConfig{
+		"machine-config": {Spec: v1alpha1.VSphereMachineConfigSpec{Template: "/templates/ubuntu-1-24"}},
+	}
+	tt.workflow.WithRollback()
+	return tt
+}
+
+func (c *upgradeTestSetup) expectSnapshot() {
+	backupPath := "cluster-name/cluster-name-pre-upgrade-capi-backup"
+	gomock.InOrder(
+		c.clusterManager.EXPECT().GetCurrentClusterSpec(c.ctx, c.managementCluster, c.newClusterSpec.Cluster.Name).Return(c.currentClusterSpec, nil),
+		c.writer.EXPECT().Dir().Return("cluster-name"),
+		c.clusterManager.EXPECT().BackupCAPI(c.ctx, c.managementCluster, backupPath),
+	)
+}
+
+func (c *upgradeTestSetup) expectFailedWorkloadUpgrade() {
+	c.expectSetup()
+	c.expectPreflightValidationsToPass()
+	c.expectUpdateSecrets(c.workloadCluster)
+	c.expectEnsureEtcdCAPIComponentsExistTask(c.workloadCluster)
+	c.expectUpgradeCoreComponents(c.workloadCluster, c.workloadCluster)
+	c.expectProviderNoUpgradeNeeded(c.workloadCluster)
+	c.expectVerifyClusterSpecChanged(c.workloadCluster)
+	c.expectPauseEKSAControllerReconcile(c.workloadCluster)
+	c.expectPauseGitOpsReconcile(c.workloadCluster)
+	c.expectCreateBootstrap()
+	c.expectMoveManagementToBootstrap()
+	c.expectUpgradeWorkloadToReturn(c.bootstrapCluster, c.workloadCluster, errors.New("failed upgrading"))
+	c.expectSaveLogs(c.workloadCluster)
+	c.expectWriteCheckpointFile()
+}
+
+func (c *upgradeTestSetup) expectMoveManagementBackToWorkload(err error) *gomock.Call {
+	return c.clusterManager.EXPECT().MoveCAPI(
+		c.ctx, c.bootstrapCluster, c.workloadCluster, c.workloadCluster.Name, c.currentClusterSpec, gomock.Any(),
+	).Return(err)
+}
+
+func TestUpgradeWithRollbackFailedUpgrade(t *testing.T) {
+	test := newUpgradeRollbackTest(t)
+	test.expectSnapshot()
+	test.expectFailedWorkloadUpgrade()
+	gomock.InOrder(
+		test.clusterManager.EXPECT().MoveCAPI(
+			test.ctx, test.bootstrapCluster, test.workloadCluster, test.workloadCluster.Name, test.currentClusterSpec, gomock.Any(),
+		),
+		test.provider.EXPECT().SetupAndValidateUpgradeCluster(test.ctx, test.workloadCluster, test.currentClusterSpec, test.newClusterSpec),
+		test.clusterManager.EXPECT().UpgradeCluster(test.ctx, test.workloadCluster, test.workloadCluster, test.currentClusterSpec, test.provider),
+		test.clusterManager.EXPECT().ApplyBundles(test.ctx, test.currentClusterSpec, test.workloadCluster),
+		test.clusterManager.EXPECT().ResumeEKSAControllerReconcile(test.ctx, test.workloadCluster, test.currentClusterSpec, test.provider),
+		test.bootstrapper.EXPECT().DeleteBootstrapCluster(test.ctx, test.bootstrapCluster, gomock.Any(), false),
+	)
+
+	err := test.run()
+	if err == nil || err.Error() != "failed upgrading" {
+		t.Fatalf("Upgrade.Run() err = %v, want err = failed upgrading", err)
+	}
+}
+
+func TestUpgradeWithRollbackProviderSetupFailed(t *testing.T) {
+	test := newUpgradeRollbackTest(t)
+	test.expectSnapshot()
+	test.expectFailedWorkloadUpgrade()
+	gomock.InOrder(
+		test.expectMoveManagementBackToWorkload(nil),
+		test.provider.EXPECT().SetupAndValidateUpgradeCluster(
+			test.ctx, test.workloadCluster, test.currentClusterSpec, test.newClusterSpec,
+		).Return(errors.New("failed setup")),
+	)
+	test.expectNotToDeleteBootstrap()
+
+	err := test.run()
+	want := "failed upgrading, rolling back upgrade: setting up provider with previous cluster spec: failed setup"
+	if err == nil || err.Error() != want {
+		t.Fatalf("Upgrade.Run() err = %v, want err = %s", err, want)
+	}
+}
+
+func TestUpgradeWithRollbackMoveBackFailed(t *testing.T) {
+	test := newUpgradeRollbackTest(t)
+	test.expectSnapshot()
+	test.expectFailedWorkloadUpgrade()
+	test.expectMoveManagementBackToWorkload(errors.New("failed moving"))
+	test.expectNotToDeleteBootstrap()
+
+	err := test.run()
+	want := "failed upgrading, rolling back upgrade: moving cluster management back to workload cluster: failed moving"
+	if err == nil || err.Error() != want {
+		t.Fatalf("Upgrade.Run() err = %v, want err = %s", err, want)
+	}
+}
+
+func (c *upgradeTestSetup) expectFailedMoveManagementToBootstrap() {
+	c.expectSetup()
+	c.expectPreflightValidationsToPass()
+	c.expectUpdateSecrets(c.workloadCluster)
+	c.expectEnsureEtcdCAPIComponentsExistTask(c.workloadCluster)
+	c.expectUpgradeCoreComponents(c.workloadCluster, c.workloadCluster)
+	c.expectProviderNoUpgradeNeeded(c.workloadCluster)
+	c.expectVerifyClusterSpecChanged(c.workloadCluster)
+	c.expectPauseEKSAControllerReconcile(c.workloadCluster)
+	c.expectPauseGitOpsReconcile(c.workloadCluster)
+	c.expectCreateBootstrap()
+	c.clusterManager.EXPECT().MoveCAPI(
+		c.ctx, c.managementCluster, c.bootstrapCluster, gomock.Any(), c.newClusterSpec, gomock.Any(),
+	).Return(errors.New("failed moving"))
+	c.expectSaveLogs(c.workloadCluster)
+	c.expectWriteCheckpointFile()
+}
+
+func TestUpgradeWithRollbackMoveToBootstrapFailed(t *testing.T) {
+	test := newUpgradeRollbackTest(t)
+	test.expectSnapshot()
+	test.expectFailedMoveManagementToBootstrap()
+	gomock.InOrder(
+		test.clusterManager.EXPECT().RestoreCAPI(test.ctx, test.workloadCluster, "cluster-name/cluster-name-pre-upgrade-capi-backup"),
+		test.clusterManager.EXPECT().ResumeEKSAControllerReconcile(test.ctx, test.workloadCluster, test.currentClusterSpec, test.provider),
+		test.bootstrapper.EXPECT().DeleteBootstrapCluster(test.ctx, test.bootstrapCluster, gomock.Any(), false),
+	)
+
+	err := test.run()
+	if err == nil || err.Error() != "failed moving" {
+		t.Fatalf("Upgrade.Run() err = %v, want err = failed moving", err)
+	}
+}
+
+func TestUpgradeWithRollbackRestoreCAPIFailed(t *testing.T) {
+	test := newUpgradeRollbackTest(t)
+	test.expectSnapshot()
+	test.expectFailedMoveManagementToBootstrap()
+	test.clusterManager.EXPECT().RestoreCAPI(test.ctx, test.workloadCluster, gomock.Any()).Return(errors.New("failed restoring"))
+	test.expectNotToDeleteBootstrap()
+
+	err := test.run()
+	want := "failed moving, rolling back upgrade: restoring CAPI objects in workload cluster: failed restoring"
+	if err == nil || err.Error() != want {
+		t.Fatalf("Upgrade.Run() err = %v, want err = %s", err, want)
+	}
+}
+
+func TestUpgradeWithRollbackFailedValidations(t *testing.T) {
+	test := newUpgradeRollbackTest(t)
+	test.expectSnapshot()
+	test.expectSetup()
+	test.validator.EXPECT().PreflightValidations(test.ctx).Return(errors.New("failed validations"))
+	test.expectWriteCheckpointFile()
+
+	err := test.run()
+	if err == nil {
+		t.Fatal("Upgrade.Run() err = nil, want err not nil")
+	}
+}
+
+func TestUpgradeWithRollbackSnapshotFailed(t *testing.T) {
+	test := newUpgradeRollbackTest(t)
+	test.clusterManager.EXPECT().GetCurrentClusterSpec(test.ctx, test.managementCluster, test.newClusterSpec.Cluster.Name).Return(test.currentClusterSpec, nil)
+	test.writer.EXPECT().Dir().Return("cluster-name")
+	test.clusterManager.EXPECT().BackupCAPI(test.ctx, test.managementCluster, gomock.Any()).Return(errors.New("failed backup"))
+
+	err := test.run()
+	want := "taking pre-upgrade snapshot: failed backup"
+	if err == nil || err.Error() != want {
+		t.Fatalf("Upgrade.Run() err = %v, want err = %s", err, want)
+	}
+}